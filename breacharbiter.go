@@ -9,12 +9,10 @@ import (
 	"sync/atomic"
 
 	"github.com/boltdb/bolt"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
@@ -49,31 +47,43 @@ type BreachConfig struct {
 	// it should respond to channel closure.
 	DB *channeldb.DB
 
-	// Estimator is used by the breach arbiter to determine an appropriate
-	// fee level when generating, signing, and broadcasting sweep
-	// transactions.
+	// Estimator is handed to each of the channels the breach arbiter
+	// restores from disk, allowing them to determine an appropriate fee
+	// level.
 	Estimator lnwallet.FeeEstimator
 
-	// GenSweepScript generates the receiving scripts for swept outputs.
-	GenSweepScript func() ([]byte, error)
+	// IncubateOutputs hands off the HTLC resolutions of a channel that has
+	// been unilaterally closed by the remote party to the utxoNursery, so
+	// any outgoing HTLC's can be timed out, and any incoming HTLC's we
+	// know the preimage to can be claimed.
+	IncubateOutputs func(*lnwallet.ForceCloseSummary)
 
 	// Notifier provides a publish/subscribe interface for event driven
 	// notifications regarding the confirmation of txids.
 	Notifier chainntnfs.ChainNotifier
 
-	// PublishTransaction facilitates the process of broadcasting a
-	// transaction to the network.
-	PublishTransaction func(*wire.MsgTx) error
-
-	// Signer is used by the breach arbiter to generate sweep transactions,
-	// which move coins from previously open channels back to the user's
-	// wallet.
-	Signer lnwallet.Signer
+	// PreimageCache is the global preimage cache of the daemon. It's
+	// handed to each of the channels we restore from disk, so we're able
+	// to claim any incoming HTLC's on the remote party's commitment.
+	PreimageCache lnwallet.PreimageCache
 
 	// Store is a persistent resource that maintains information regarding
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStore
+
+	// SweepOutput hands off an output to the sweeper, which will sweep it
+	// into the wallet. The returned channel is sent upon once the output
+	// has been spent within a confirmed transaction.
+	SweepOutput func(*SweepRequest) (chan *SweepResult, error)
+
+	// NotifyClosingChannel is called once the breach arbiter detects that
+	// a channel has been closed on-chain by the remote party.
+	NotifyClosingChannel func(wire.OutPoint)
+
+	// NotifyClosedChannel is called once a channel watched by the breach
+	// arbiter has been marked as fully closed.
+	NotifyClosedChannel func(wire.OutPoint)
 }
 
 // breachArbiter is a special subsystem which is responsible for watching and
@@ -213,7 +223,7 @@ func (b *breachArbiter) Start() error {
 	for _, chanState := range activeChannels {
 		// Initialize active channel from persisted channel state.
 		channel, err := lnwallet.NewLightningChannel(nil,
-			b.cfg.Notifier, b.cfg.Estimator, chanState)
+			b.cfg.PreimageCache, b.cfg.Notifier, b.cfg.Estimator, chanState)
 		if err != nil {
 			brarLog.Errorf("unable to load channel from "+
 				"disk: %v", err)
@@ -345,11 +355,21 @@ func (b *breachArbiter) watchForPendingCloseConfs(currentHeight int32) error {
 				// UnilateralCloseSummary on disk so can
 				// possibly sweep output here
 
-				err := b.cfg.DB.MarkChanFullyClosed(&chanPoint)
+				err := b.cfg.DB.SetCloseHeight(
+					&chanPoint, confInfo.BlockHeight,
+				)
+				if err != nil {
+					brarLog.Errorf("unable to set close "+
+						"height: %v", err)
+				}
+
+				err = b.cfg.DB.MarkChanFullyClosed(&chanPoint)
 				if err != nil {
 					brarLog.Errorf("unable to mark channel"+
 						" as closed: %v", err)
+					return
 				}
+				b.cfg.NotifyClosedChannel(chanPoint)
 
 			case <-b.quit:
 				return
@@ -516,8 +536,9 @@ func (b *breachArbiter) exactRetribution(
 
 	// TODO(roasbeef): state needs to be checkpointed here
 
+	var closeHeight uint32
 	select {
-	case _, ok := <-confChan.Confirmed:
+	case conf, ok := <-confChan.Confirmed:
 		// If the second value is !ok, then the channel has been closed
 		// signifying a daemon shutdown, so we exit.
 		if !ok {
@@ -526,6 +547,7 @@ func (b *breachArbiter) exactRetribution(
 
 		// Otherwise, if this is a real confirmation notification, then
 		// we fall through to complete our duty.
+		closeHeight = conf.BlockHeight
 	case <-b.quit:
 		return
 	}
@@ -533,84 +555,106 @@ func (b *breachArbiter) exactRetribution(
 	brarLog.Debugf("Breach transaction %v has been confirmed, sweeping "+
 		"revoked funds", breachInfo.commitHash)
 
-	// With the breach transaction confirmed, we now create the justice tx
-	// which will claim ALL the funds within the channel.
-	justiceTx, err := b.createJusticeTx(breachInfo)
+	err := b.cfg.DB.SetCloseHeight(&breachInfo.chanPoint, closeHeight)
 	if err != nil {
-		brarLog.Errorf("unable to create justice tx: %v", err)
-		return
+		brarLog.Errorf("unable to set close height: %v", err)
 	}
 
-	brarLog.Debugf("Broadcasting justice tx: %v",
-		newLogClosure(func() string {
-			return spew.Sdump(justiceTx)
-		}))
-
 	_, currentHeight, err := b.cfg.ChainIO.GetBestBlock()
 	if err != nil {
 		brarLog.Errorf("unable to get current height: %v", err)
 		return
 	}
 
-	// Finally, broadcast the transaction, finalizing the channels'
-	// retribution against the cheating counterparty.
-	if err := b.cfg.PublishTransaction(justiceTx); err != nil {
-		brarLog.Errorf("unable to broadcast "+
-			"justice tx: %v", err)
-		return
-	}
-
-	// As a conclusionary step, we register for a notification to be
-	// dispatched once the justice tx is confirmed. After confirmation we
-	// notify the caller that initiated the retribution workflow that the
-	// deed has been done.
-	justiceTXID := justiceTx.TxHash()
-	confChan, err = b.cfg.Notifier.RegisterConfirmationsNtfn(
-		&justiceTXID, 1, uint32(currentHeight))
-	if err != nil {
-		brarLog.Errorf("unable to register for conf for txid: %v",
-			justiceTXID)
-		return
-	}
-
-	select {
-	case _, ok := <-confChan.Confirmed:
-		if !ok {
+	// With the breach transaction confirmed, we now hand off ALL the funds
+	// within the channel to the sweeper. As the remote party is able to
+	// contest the revoked outputs once their time-locks expire, we'll ask
+	// for the outputs to be swept within the next block.
+	breachedOutputs := make([]*breachedOutput, 0, 2+len(breachInfo.htlcOutputs))
+	breachedOutputs = append(breachedOutputs, breachInfo.selfOutput,
+		breachInfo.revokedOutput)
+	breachedOutputs = append(breachedOutputs, breachInfo.htlcOutputs...)
+
+	resultChans := make([]chan *SweepResult, 0, len(breachedOutputs))
+	for _, output := range breachedOutputs {
+		resultChan, err := b.cfg.SweepOutput(&SweepRequest{
+			Output:     output,
+			Deadline:   uint32(currentHeight) + urgentSweepConfTarget,
+			HeightHint: uint32(currentHeight),
+			ChanPoint:  &breachInfo.chanPoint,
+		})
+		if err != nil {
+			brarLog.Errorf("unable to sweep breached output %v: %v",
+				output.outpoint, err)
 			return
 		}
 
-		// TODO(roasbeef): factor in HTLCs
-		revokedFunds := breachInfo.revokedOutput.amt
-		totalFunds := revokedFunds + breachInfo.selfOutput.amt
-
-		brarLog.Infof("Justice for ChannelPoint(%v) has "+
-			"been served, %v revoked funds (%v total) "+
-			"have been claimed", breachInfo.chanPoint,
-			revokedFunds, totalFunds)
+		resultChans = append(resultChans, resultChan)
+	}
 
-		// With the channel closed, mark it in the database as such.
-		err := b.cfg.DB.MarkChanFullyClosed(&breachInfo.chanPoint)
+	// Our anchor output, if any, isn't contested by the remote party, and
+	// may well be uneconomical to sweep, so we'll hand it off without
+	// waiting for it to be swept.
+	if breachInfo.anchorOutput != nil {
+		_, err := b.cfg.SweepOutput(&SweepRequest{
+			Output:     breachInfo.anchorOutput,
+			Deadline:   uint32(currentHeight) + defaultSweepConfTarget,
+			HeightHint: uint32(currentHeight),
+			ChanPoint:  &breachInfo.chanPoint,
+		})
 		if err != nil {
-			brarLog.Errorf("unable to mark chan as closed: %v", err)
+			brarLog.Errorf("unable to sweep anchor output %v: %v",
+				breachInfo.anchorOutput.outpoint, err)
 		}
+	}
 
-		// Justice has been carried out; we can safely delete the
-		// retribution info from the database.
-		err = b.cfg.Store.Remove(&breachInfo.chanPoint)
-		if err != nil {
-			brarLog.Errorf("unable to remove retribution "+
-				"from the db: %v", err)
+	// As a conclusionary step, we wait until each of the breached outputs
+	// has been spent within a confirmed transaction. After confirmation
+	// we notify the caller that initiated the retribution workflow that
+	// the deed has been done.
+	for i, resultChan := range resultChans {
+		select {
+		case result := <-resultChan:
+			if result.Err != nil {
+				brarLog.Errorf("unable to sweep breached "+
+					"output %v: %v",
+					breachedOutputs[i].outpoint, result.Err)
+			}
+
+		case <-b.quit:
+			return
 		}
+	}
 
-		// TODO(roasbeef): add peer to blacklist?
+	// TODO(roasbeef): factor in HTLCs
+	revokedFunds := breachInfo.revokedOutput.amt
+	totalFunds := revokedFunds + breachInfo.selfOutput.amt
 
-		// TODO(roasbeef): close other active channels with offending
-		// peer
+	brarLog.Infof("Justice for ChannelPoint(%v) has "+
+		"been served, %v revoked funds (%v total) "+
+		"have been claimed", breachInfo.chanPoint,
+		revokedFunds, totalFunds)
 
-		return
-	case <-b.quit:
-		return
+	// With the channel closed, mark it in the database as such.
+	err = b.cfg.DB.MarkChanFullyClosed(&breachInfo.chanPoint)
+	if err != nil {
+		brarLog.Errorf("unable to mark chan as closed: %v", err)
+	} else {
+		b.cfg.NotifyClosedChannel(breachInfo.chanPoint)
+	}
+
+	// Justice has been carried out; we can safely delete the
+	// retribution info from the database.
+	err = b.cfg.Store.Remove(&breachInfo.chanPoint)
+	if err != nil {
+		brarLog.Errorf("unable to remove retribution "+
+			"from the db: %v", err)
 	}
+
+	// TODO(roasbeef): add peer to blacklist?
+
+	// TODO(roasbeef): close other active channels with offending
+	// peer
 }
 
 // breachObserver notifies the breachArbiter contract observer goroutine that a
@@ -640,6 +684,8 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 	// The channel has been closed by a normal means: force closing with
 	// the latest commitment transaction.
 	case closeInfo := <-contract.UnilateralClose:
+		b.cfg.NotifyClosingChannel(*chanPoint)
+
 		// Launch a goroutine to cancel out this contract within the
 		// breachArbiter's main goroutine.
 		b.wg.Add(1)
@@ -652,6 +698,22 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 			}
 		}()
 
+		// If we had any HTLC's in flight on the remote party's
+		// commitment transaction, then we'll hand them off to the
+		// utxoNursery. Outgoing HTLC's will be swept once they time
+		// out, and incoming HTLC's we know the preimage to will be
+		// claimed immediately.
+		htlcs := closeInfo.HtlcResolutions
+		if htlcs != nil && (len(htlcs.IncomingHTLCs) != 0 ||
+			len(htlcs.OutgoingHTLCs) != 0) {
+
+			b.cfg.IncubateOutputs(&lnwallet.ForceCloseSummary{
+				ChanPoint:       *chanPoint,
+				CloseTx:         closeInfo.SpendingTx,
+				HtlcResolutions: htlcs,
+			})
+		}
+
 		// Next, we'll launch a goroutine to wait until the closing
 		// transaction has been confirmed so we can mark the contract
 		// as resolved in the database. This go routine is _not_ tracked
@@ -659,52 +721,79 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 		// be executed before shutdown, potentially leading to a
 		// deadlocks as the arbiter may not be able to finish shutting
 		// down.
-		//
-		// TODO(roasbeef): also notify utxoNursery, might've had
-		// outbound HTLC's in flight
 		go waitForChanToClose(uint32(closeInfo.SpendingHeight),
 			b.cfg.Notifier, nil, chanPoint, closeInfo.SpenderTxHash,
-			func() {
+			func(closeHeight uint32) {
+				err := b.cfg.DB.SetCloseHeight(
+					chanPoint, closeHeight,
+				)
+				if err != nil {
+					brarLog.Errorf("unable to set close "+
+						"height: %v", err)
+				}
+
 				// As we just detected a channel was closed via
 				// a unilateral commitment broadcast by the
 				// remote party, we'll need to sweep our main
-				// commitment output, and any outstanding
-				// outgoing HTLC we had as well.
-				//
-				// TODO(roasbeef): actually sweep HTLC's *
-				// ensure reliable confirmation
+				// commitment output. Any outstanding HTLC's
+				// are handled by the utxoNursery.
 				if closeInfo.SelfOutPoint != nil {
-					sweepTx, err := b.craftCommitSweepTx(
-						closeInfo,
+					selfOutput := newBreachedOutput(
+						closeInfo.SelfOutPoint,
+						lnwallet.CommitmentNoDelay,
+						closeInfo.SelfOutputSignDesc,
 					)
+
+					spendHeight := uint32(closeInfo.SpendingHeight)
+					_, err := b.cfg.SweepOutput(&SweepRequest{
+						Output: selfOutput,
+						Deadline: spendHeight +
+							defaultSweepConfTarget,
+						HeightHint: spendHeight,
+						ChanPoint:  chanPoint,
+					})
 					if err != nil {
 						brarLog.Errorf("unable to "+
-							"generate sweep tx: %v",
-							err)
-						goto close
+							"sweep commitment "+
+							"output: %v", err)
 					}
+				}
 
-					brarLog.Infof("Sweeping breached "+
-						"outputs with: %v",
-						spew.Sdump(sweepTx))
+				// Our anchor output is swept on a best effort
+				// basis, so we won't wait on its result.
+				if anchor := closeInfo.AnchorResolution; anchor != nil {
+					anchorOutput := newBreachedOutput(
+						&anchor.CommitAnchor,
+						lnwallet.CommitmentAnchor,
+						&anchor.AnchorSignDesc,
+					)
 
-					err = b.cfg.PublishTransaction(sweepTx)
+					spendHeight := uint32(closeInfo.SpendingHeight)
+					_, err := b.cfg.SweepOutput(&SweepRequest{
+						Output: anchorOutput,
+						Deadline: spendHeight +
+							defaultSweepConfTarget,
+						HeightHint: spendHeight,
+						ChanPoint:  chanPoint,
+					})
 					if err != nil {
 						brarLog.Errorf("unable to "+
-							"broadcast tx: %v", err)
+							"sweep anchor "+
+							"output: %v", err)
 					}
 				}
 
-			close:
 				brarLog.Infof("Force closed ChannelPoint(%v) "+
 					"is fully closed, updating DB",
 					chanPoint)
 
-				err := b.cfg.DB.MarkChanFullyClosed(chanPoint)
+				err = b.cfg.DB.MarkChanFullyClosed(chanPoint)
 				if err != nil {
 					brarLog.Errorf("unable to mark chan "+
 						"as closed: %v", err)
+					return
 				}
+				b.cfg.NotifyClosedChannel(*chanPoint)
 			})
 
 	// A read from this channel indicates that a channel breach has been
@@ -777,6 +866,7 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 			brarLog.Errorf("unable to delete channel state: %v",
 				err)
 		}
+		b.cfg.NotifyClosingChannel(*chanPoint)
 
 		// Finally, we send the retribution information into the
 		// breachArbiter event loop to deal swift justice.
@@ -790,24 +880,6 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 	}
 }
 
-// SpendableOutput an interface which can be used by the breach arbiter to
-// construct a transaction spending from outputs we control.
-type SpendableOutput interface {
-	// Amount returns the number of satoshis contained within the output.
-	Amount() btcutil.Amount
-
-	// Outpoint returns the reference to the output being spent, used to
-	// construct the corresponding transaction input.
-	OutPoint() *wire.OutPoint
-
-	// BuildWitness returns a valid witness allowing this output to be
-	// spent, the witness should be attached to the transaction at the
-	// location determined by the given `txinIdx`.
-	BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
-		hashCache *txscript.TxSigHashes,
-		txinIdx int) ([][]byte, error)
-}
-
 // breachedOutput contains all the information needed to sweep a breached
 // output. A breached output is an output that we are now entitled to due to a
 // revoked commitment transaction being broadcast.
@@ -847,6 +919,12 @@ func (bo *breachedOutput) OutPoint() *wire.OutPoint {
 	return &bo.outpoint
 }
 
+// WitnessType returns the type of witness that must be generated to spend the
+// breached output.
+func (bo *breachedOutput) WitnessType() lnwallet.WitnessType {
+	return bo.witnessType
+}
+
 // BuildWitness computes a valid witness that allows us to spend from the
 // breached output. It does so by first generating and memoizing the witness
 // generation function, which parameterized primarily by the witness type and
@@ -900,6 +978,10 @@ type retributionInfo struct {
 
 	htlcOutputs []*breachedOutput
 
+	// anchorOutput is our anchor output within the breach transaction. If
+	// the channel doesn't use anchor outputs, then this will be nil.
+	anchorOutput *breachedOutput
+
 	doneChan chan struct{}
 }
 
@@ -952,6 +1034,14 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 			&breachInfo.HtlcRetributions[i].SignDesc)
 	}
 
+	// If the channel uses anchor outputs, then we'll also record our
+	// anchor so it can be swept alongside the rest of the funds.
+	var anchorOutput *breachedOutput
+	if anchor := breachInfo.AnchorResolution; anchor != nil {
+		anchorOutput = newBreachedOutput(&anchor.CommitAnchor,
+			lnwallet.CommitmentAnchor, &anchor.AnchorSignDesc)
+	}
+
 	// TODO(conner): remove dependency on channel snapshot after decoupling
 	// channel closure from the breach arbiter.
 
@@ -964,170 +1054,10 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 		selfOutput:     selfOutput,
 		revokedOutput:  revokedOutput,
 		htlcOutputs:    htlcOutputs,
+		anchorOutput:   anchorOutput,
 	}
 }
 
-// createJusticeTx creates a transaction which exacts "justice" by sweeping ALL
-// the funds within the channel which we are now entitled to due to a breach of
-// the channel's contract by the counterparty. This function returns a *fully*
-// signed transaction with the witness for each input fully in place.
-func (b *breachArbiter) createJusticeTx(
-	r *retributionInfo) (*wire.MsgTx, error) {
-
-	// Determine the number of HTLCs to be swept by the justice txn.
-	nHtlcs := len(r.htlcOutputs)
-
-	// Assemble the breached outputs into a slice of spendable outputs,
-	// starting with the self and revoked outputs, then adding any htlc
-	// outputs.
-	breachedOutputs := make([]SpendableOutput, 2+nHtlcs)
-	breachedOutputs[0] = r.selfOutput
-	breachedOutputs[1] = r.revokedOutput
-	for i, htlcOutput := range r.htlcOutputs {
-		breachedOutputs[2+i] = htlcOutput
-	}
-
-	// Compute the transaction weight of the justice transaction, which
-	// includes 2 + nHtlcs inputs and one output.
-	var txWeight uint64
-	// Begin with a base txn weight, e.g. version, nLockTime, etc.
-	txWeight += 4*lnwallet.BaseSweepTxSize + lnwallet.WitnessHeaderSize
-	// Add to_local revoke script and tx input.
-	txWeight += 4*lnwallet.InputSize + lnwallet.ToLocalPenaltyWitnessSize
-	// Add to_remote p2wpkh witness and tx input.
-	txWeight += 4*lnwallet.InputSize + lnwallet.P2WKHWitnessSize
-
-	// Compute the appropriate weight contributed by each revoked accepted
-	// or offered HTLC witnesses and tx inputs.
-	for _, htlcOutput := range r.htlcOutputs {
-		switch htlcOutput.witnessType {
-		case lnwallet.HtlcOfferedRevoke:
-			txWeight += 4*lnwallet.InputSize +
-				lnwallet.OfferedHtlcPenaltyWitnessSize
-		case lnwallet.HtlcAcceptedRevoke:
-			txWeight += 4*lnwallet.InputSize +
-				lnwallet.AcceptedHtlcPenaltyWitnessSize
-		}
-	}
-
-	return b.sweepSpendableOutputsTxn(txWeight, breachedOutputs...)
-}
-
-// craftCommitmentSweepTx creates a transaction to sweep the non-delayed output
-// within the commitment transaction that pays to us. We must manually sweep
-// this output as it uses a tweaked public key in its pkScript, so the wallet
-// won't immediacy be aware of it.
-//
-// TODO(roasbeef): alternative options
-//  * leave the output in the chain, use as input to future funding tx
-//  * leave output in the chain, extend wallet to add knowledge of how to claim
-func (b *breachArbiter) craftCommitSweepTx(
-	closeInfo *lnwallet.UnilateralCloseSummary) (*wire.MsgTx, error) {
-
-	selfOutput := newBreachedOutput(
-		closeInfo.SelfOutPoint,
-		lnwallet.CommitmentNoDelay,
-		closeInfo.SelfOutputSignDesc,
-	)
-
-	// Compute the transaction weight of the commit sweep transaction, which
-	// includes a single input and output.
-	var txWeight uint64
-	// Begin with a base txn weight, e.g. version, nLockTime, etc.
-	txWeight += 4*lnwallet.BaseSweepTxSize + lnwallet.WitnessHeaderSize
-	// Add to_local p2wpkh witness and tx input.
-	txWeight += 4*lnwallet.InputSize + lnwallet.P2WKHWitnessSize
-
-	return b.sweepSpendableOutputsTxn(txWeight, selfOutput)
-}
-
-// sweepSpendableOutputsTxn creates a signed transaction from a sequence of
-// spendable outputs by sweeping the funds into a single p2wkh output.
-func (b *breachArbiter) sweepSpendableOutputsTxn(txWeight uint64,
-	inputs ...SpendableOutput) (*wire.MsgTx, error) {
-
-	// First, we obtain a new public key script from the wallet which we'll
-	// sweep the funds to.
-	// TODO(roasbeef): possibly create many outputs to minimize change in
-	// the future?
-	pkScript, err := b.cfg.GenSweepScript()
-	if err != nil {
-		return nil, err
-	}
-
-	// Compute the total amount contained in the inputs.
-	var totalAmt btcutil.Amount
-	for _, input := range inputs {
-		totalAmt += input.Amount()
-	}
-
-	feePerWeight := b.cfg.Estimator.EstimateFeePerWeight(1)
-	txFee := btcutil.Amount(txWeight * feePerWeight)
-
-	sweepAmt := int64(totalAmt - txFee)
-
-	// With the fee calculated, we can now create the transaction using the
-	// information gathered above and the provided retribution information.
-	txn := wire.NewMsgTx(2)
-
-	// We begin by adding the output to which our funds will be deposited.
-	txn.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// Next, we add all of the spendable outputs as inputs to the
-	// transaction.
-	for _, input := range inputs {
-		txn.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	btx := btcutil.NewTx(txn)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
-
-	// Create a sighash cache to improve the performance of hashing and
-	// signing SigHashAll inputs.
-	hashCache := txscript.NewTxSigHashes(txn)
-
-	// Create a closure that encapsulates the process of initializing a
-	// particular output's witness generation function, computing the
-	// witness, and attaching it to the transaction. This function accepts
-	// an integer index representing the intended txin index, and the
-	// breached output from which it will spend.
-	addWitness := func(idx int, so SpendableOutput) error {
-		// First, we construct a valid witness for this outpoint and
-		// transaction using the SpendableOutput's witness generation
-		// function.
-		witness, err := so.BuildWitness(b.cfg.Signer, txn, hashCache,
-			idx)
-		if err != nil {
-			return err
-		}
-
-		// Then, we add the witness to the transaction at the
-		// appropriate txin index.
-		txn.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	// Finally, generate a witness for each output and attach it to the
-	// transaction.
-	for i, input := range inputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	return txn, nil
-}
-
 // RetributionStore provides an interface for managing a persistent map from
 // wire.OutPoint -> retributionInfo. Upon learning of a breach, a BreachArbiter
 // should record the retributionInfo for the breached channel, which serves a
@@ -1291,6 +1221,19 @@ func (ret *retributionInfo) Encode(w io.Writer) error {
 		}
 	}
 
+	var numAnchorOutputs uint64
+	if ret.anchorOutput != nil {
+		numAnchorOutputs = 1
+	}
+	if err := wire.WriteVarInt(w, 0, numAnchorOutputs); err != nil {
+		return err
+	}
+	if ret.anchorOutput != nil {
+		if err := ret.anchorOutput.Encode(w); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1355,6 +1298,21 @@ func (ret *retributionInfo) Decode(r io.Reader) error {
 		}
 	}
 
+	// Retributions persisted before the introduction of anchor outputs
+	// end here, so we'll treat the lack of the anchor count as none.
+	numAnchorOutputs, err := wire.ReadVarInt(r, 0)
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if numAnchorOutputs != 0 {
+		ret.anchorOutput = &breachedOutput{}
+		if err := ret.anchorOutput.Decode(r); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
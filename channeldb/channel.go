@@ -96,6 +96,19 @@ var (
 	// revocationStateKey stores their current revocation hash, our
 	// preimage producer and their preimage store.
 	revocationStateKey = []byte("esk")
+
+	// fundingTxKey stores the full funding transaction of channels which
+	// we initiated.
+	fundingTxKey = []byte("ftk")
+
+	// shutdownScriptsKey stores the upfront shutdown scripts, if any,
+	// committed to by both parties during the funding workflow.
+	shutdownScriptsKey = []byte("ssk")
+
+	// pendingSpliceKey stores the details of a splice transaction which
+	// has been negotiated for an active channel, but has yet to be
+	// sufficiently confirmed.
+	pendingSpliceKey = []byte("spk")
 )
 
 // ChannelType is an enum-like type that describes one of several possible
@@ -117,8 +130,47 @@ const (
 	// funds towards the total capacity of the channel. The channel may be
 	// funded symmetrically or asymmetrically.
 	DualFunder = 1
+
+	// AnchorOutputsBit is a bit which, if set, indicates that the
+	// commitment transactions of the channel carry an anchor output for
+	// each party. Either party is able to spend its anchor in order to
+	// bump the fee of a commitment transaction via CPFP. This bit may be
+	// combined with either of the types above.
+	AnchorOutputsBit ChannelType = 1 << 1
+
+	// StaticRemoteKeyBit is a bit which, if set, indicates that the
+	// to_remote output on each commitment transaction pays directly to the
+	// payment base point of the remote party, rather than to a key tweaked
+	// by the per-commitment point. This allows the output to be swept with
+	// only the seed of the wallet after data loss. This bit may be
+	// combined with any of the types above.
+	StaticRemoteKeyBit ChannelType = 1 << 2
 )
 
+// IsSingleFunder returns true if the channel was funded solely by one party.
+func (c ChannelType) IsSingleFunder() bool {
+	return c&DualFunder == 0
+}
+
+// IsDualFunder returns true if both parties contributed funds towards the
+// capacity of the channel.
+func (c ChannelType) IsDualFunder() bool {
+	return c&DualFunder == DualFunder
+}
+
+// HasAnchors returns true if the commitment transactions of the channel carry
+// anchor outputs.
+func (c ChannelType) HasAnchors() bool {
+	return c&AnchorOutputsBit == AnchorOutputsBit
+}
+
+// IsTweakless returns true if the to_remote output on the commitment
+// transactions of the channel pays to the static payment base point of the
+// remote party.
+func (c ChannelType) IsTweakless() bool {
+	return c&StaticRemoteKeyBit == StaticRemoteKeyBit
+}
+
 // ChannelConstraints represents a set of constraints meant to allow a node to
 // limit their exposure, enact flow control and ensure that all HTLC's are
 // economically relevant This struct will be mirrored for both sides of the
@@ -195,7 +247,9 @@ type ChannelConfig struct {
 	// the key used within the non-delayed pay-to-self output on the
 	// commitment transaction for a node. This will be combined with a
 	// tweak derived from the per-commitment point to ensure unique keys
-	// for each commitment transaction.
+	// for each commitment transaction. If the channel uses a static
+	// remote key, then the non-delayed output instead pays to this key
+	// directly, though it's still tweaked within HTLC scripts.
 	PaymentBasePoint *btcec.PublicKey
 
 	// DelayBasePoint is the based public key to be used when deriving the
@@ -249,6 +303,12 @@ type OpenChannel struct {
 	// been confirmed before a certain height.
 	FundingBroadcastHeight uint32
 
+	// FundingTxn is the funding transaction of the channel. This is only
+	// populated for channels we initiated, as it allows the inputs we
+	// spent to fund the channel to be recovered if the channel is later
+	// abandoned.
+	FundingTxn *wire.MsgTx
+
 	// IdentityPub is the identity public key of the remote node this
 	// channel has been established with.
 	IdentityPub *btcec.PublicKey
@@ -298,6 +358,17 @@ type OpenChannel struct {
 	// for normal transactional use.
 	NumConfsRequired uint16
 
+	// LocalShutdownScript is the script we committed to paying our funds
+	// to upon a cooperative close during the funding workflow. If empty,
+	// then we're free to choose any delivery address at closing time.
+	LocalShutdownScript []byte
+
+	// RemoteShutdownScript is the script the remote party committed to
+	// paying their funds to upon a cooperative close during the funding
+	// workflow. If set, then we MUST reject any cooperative close which
+	// pays the remote party to a different script.
+	RemoteShutdownScript []byte
+
 	// RemoteCurrentRevocation is the current revocation for their
 	// commitment transaction. However, since this the derived public key,
 	// we don't yet have the private key so we aren't yet able to verify
@@ -410,40 +481,79 @@ func (c *OpenChannel) SyncPending(addr *net.TCPAddr, pendingHeight uint32) error
 	c.FundingBroadcastHeight = pendingHeight
 
 	return c.Db.Update(func(tx *bolt.Tx) error {
-		// First, sync all the persistent channel state to disk.
-		if err := c.fullSync(tx); err != nil {
-			return err
-		}
+		return c.syncPending(tx, addr)
+	})
+}
 
-		nodeInfoBucket, err := tx.CreateBucketIfNotExists(nodeInfoBucket)
-		if err != nil {
-			return err
-		}
+// syncPending is an internal version of the SyncPending method which allows
+// callers to write a pending channel while re-using an existing database
+// transaction.
+func (c *OpenChannel) syncPending(tx *bolt.Tx, addr *net.TCPAddr) error {
+	// First, sync all the persistent channel state to disk.
+	if err := c.fullSync(tx); err != nil {
+		return err
+	}
 
-		// If a LinkNode for this identity public key already exists,
-		// then we can exit early.
-		nodePub := c.IdentityPub.SerializeCompressed()
-		if nodeInfoBucket.Get(nodePub) != nil {
-			return nil
-		}
+	nodeInfoBucket, err := tx.CreateBucketIfNotExists(nodeInfoBucket)
+	if err != nil {
+		return err
+	}
+
+	// If a LinkNode for this identity public key already exists, then we
+	// can exit early.
+	nodePub := c.IdentityPub.SerializeCompressed()
+	if nodeInfoBucket.Get(nodePub) != nil {
+		return nil
+	}
+
+	// Next, we need to establish a (possibly) new LinkNode relationship
+	// for this channel. The LinkNode metadata contains reachability,
+	// up-time, and service bits related information.
+	// TODO(roasbeef): net info should be in lnwire.NetAddress
+	linkNode := c.Db.NewLinkNode(wire.MainNet, c.IdentityPub, addr)
+
+	return putLinkNode(nodeInfoBucket, linkNode)
+}
 
-		// Next, we need to establish a (possibly) new LinkNode
-		// relationship for this channel. The LinkNode metadata
-		// contains reachability, up-time, and service bits related
-		// information.
-		// TODO(roasbeef): net info should be in lnwire.NetAddress
-		linkNode := c.Db.NewLinkNode(wire.MainNet, c.IdentityPub, addr)
+// SyncPendingBatch writes a set of channels which share a single funding
+// transaction to the database in the pending state. All channels are written
+// within a single database transaction, so either every channel within the
+// batch is persisted, or none of them are. The i-th address is used to create
+// the LinkNode of the i-th channel.
+func (d *DB) SyncPendingBatch(channels []*OpenChannel, addrs []*net.TCPAddr,
+	pendingHeight uint32) error {
 
-		return putLinkNode(nodeInfoBucket, linkNode)
+	if len(channels) != len(addrs) {
+		return fmt.Errorf("number of channels (%v) doesn't match number "+
+			"of addresses (%v)", len(channels), len(addrs))
+	}
+
+	for _, c := range channels {
+		c.Lock()
+		defer c.Unlock()
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		for i, c := range channels {
+			c.FundingBroadcastHeight = pendingHeight
+			if err := c.syncPending(tx, addrs[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
 // UpdateCommitment updates the on-disk state of our currently broadcastable
 // commitment state. This method is to be called once we have revoked our prior
 // commitment state, accepting the new state as defined by the passed
-// parameters.
+// parameters. If the channel has a pending splice, then spliceCommit is our
+// version of the new state which spends the funding output of the splice,
+// and is written atomically along with the new state.
 func (c *OpenChannel) UpdateCommitment(newCommitment *wire.MsgTx,
-	newSig []byte, delta *ChannelDelta) error {
+	newSig []byte, delta *ChannelDelta,
+	spliceCommit *SpliceCommitment) error {
 
 	c.Lock()
 	defer c.Unlock()
@@ -499,7 +609,25 @@ func (c *OpenChannel) UpdateCommitment(newCommitment *wire.MsgTx,
 			return err
 		}
 
-		return nil
+		if spliceCommit == nil {
+			return nil
+		}
+
+		// As the channel has a pending splice, we'll also replace our
+		// version of the commitment spending the new funding output.
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &c.FundingOutpoint); err != nil {
+			return err
+		}
+		splice, err := fetchPendingSplice(nodeChanBucket, b.Bytes())
+		if err != nil {
+			return err
+		}
+		splice.CommitTx = spliceCommit.CommitTx
+		splice.CommitSig = spliceCommit.CommitSig
+		splice.Htlcs = spliceCommit.Htlcs
+
+		return putPendingSplice(nodeChanBucket, b.Bytes(), splice)
 	})
 }
 
@@ -766,8 +894,7 @@ func (c *OpenChannel) FindPreviousState(updateNum uint64) (*ChannelDelta, error)
 }
 
 // ClosureType is an enum like structure that details exactly _how_ a channel
-// was closed. Three closure types are currently possible: cooperative, force,
-// and breach.
+// was closed.
 type ClosureType uint8
 
 const (
@@ -777,8 +904,14 @@ const (
 	// contract.
 	CooperativeClose ClosureType = iota
 
-	// ForceClose indicates that one peer unilaterally broadcast their
-	// current commitment state on-chain.
+	// ForceClose indicates that we unilaterally broadcast our current
+	// commitment state on-chain.
+	//
+	// NOTE: Force closes recorded by prior versions didn't distinguish
+	// which party initiated them. Those we could attribute to the remote
+	// party were migrated to RemoteForceClose, but a force close in which
+	// we had neither a settled nor a time-locked balance may still have
+	// been initiated by the remote party.
 	ForceClose
 
 	// BreachClose indicates that one peer attempted to broadcast a prior
@@ -790,6 +923,16 @@ const (
 	// fail at some point during the opening workflow, or we timeout waiting for
 	// the funding transaction to be confirmed.
 	FundingCanceled
+
+	// Abandoned indicates that the channel was manually abandoned by the
+	// user. This is used to clean up channels which are stuck in a
+	// pending state, or otherwise can't be resolved, such as a channel
+	// whose funding transaction will never confirm.
+	Abandoned
+
+	// RemoteForceClose indicates that the remote peer unilaterally
+	// broadcast their current commitment state on-chain.
+	RemoteForceClose
 )
 
 // ChannelCloseSummary contains the final state of a channel at the point it
@@ -825,10 +968,14 @@ type ChannelCloseSummary struct {
 	// outstanding outgoing HTLC's at the time of channel closure.
 	TimeLockedBalance btcutil.Amount
 
-	// CloseType details exactly _how_ the channel was closed. Three
-	// closure types are possible: cooperative, force, and breach.
+	// CloseType details exactly _how_ the channel was closed.
 	CloseType ClosureType
 
+	// CloseHeight is the height at which the closing transaction was
+	// confirmed. This will be zero until the closing transaction has been
+	// confirmed.
+	CloseHeight uint32
+
 	// IsPending indicates whether this channel is in the 'pending close'
 	// state, which means the channel closing transaction has been
 	// broadcast, but not confirmed yet or has not yet been fully resolved.
@@ -913,6 +1060,254 @@ func (c *OpenChannel) CloseChannel(summary *ChannelCloseSummary) error {
 	})
 }
 
+// PendingSplice houses the details of a splice which has been negotiated for
+// an active channel, but whose splice transaction hasn't yet been
+// sufficiently confirmed. A splice transaction spends the current funding
+// output of a channel, creating a new funding output with a larger (splice
+// in) or smaller (splice out) capacity. Until the splice transaction
+// confirms, the channel remains anchored to its current funding output.
+type PendingSplice struct {
+	// SpliceTx is the transaction which spends the current funding output
+	// of the channel into the new funding output.
+	SpliceTx *wire.MsgTx
+
+	// FundingOutpoint is the outpoint of the new funding output within
+	// the splice transaction.
+	FundingOutpoint wire.OutPoint
+
+	// Capacity is the capacity of the channel once the splice
+	// transaction has been confirmed.
+	Capacity btcutil.Amount
+
+	// LocalDelta is the amount (which may be negative) by which our
+	// balance changes once the splice transaction has been confirmed.
+	LocalDelta btcutil.Amount
+
+	// RemoteDelta is the amount (which may be negative) by which the
+	// balance of the remote party changes once the splice transaction has
+	// been confirmed.
+	RemoteDelta btcutil.Amount
+
+	// CommitTx is our version of the latest commitment transaction which
+	// spends the new funding output. As the channel remains active while
+	// the splice transaction confirms, this is updated along with the
+	// commitment spending the current funding output.
+	CommitTx *wire.MsgTx
+
+	// CommitSig is the signature of the remote party for the commitment
+	// transaction above.
+	CommitSig []byte
+
+	// Htlcs is the set of HTLCs present on the commitment transaction
+	// above, along with their output indexes and second-level signatures
+	// within it.
+	Htlcs []*HTLC
+
+	// IsInitiator denotes whether we initiated the splice, and are
+	// therefore responsible for broadcasting the splice transaction.
+	IsInitiator bool
+
+	// BroadcastHeight is the height at which the splice transaction was
+	// negotiated.
+	BroadcastHeight uint32
+}
+
+// SpliceCommitment is our version of a commitment transaction which spends
+// the funding output of a pending splice, rather than the current funding
+// output of the channel. One exists for each state of the channel which is
+// accepted while a splice transaction is awaiting confirmation.
+type SpliceCommitment struct {
+	// CommitTx is the commitment transaction spending the funding output
+	// of the splice.
+	CommitTx *wire.MsgTx
+
+	// CommitSig is the signature of the remote party for the commitment
+	// transaction above.
+	CommitSig []byte
+
+	// Htlcs is the set of HTLCs present on the commitment transaction
+	// above.
+	Htlcs []*HTLC
+}
+
+// SetPendingSplice records a newly negotiated splice for the channel. This
+// MUST be called before the splice transaction is either signed or
+// broadcast, in order to allow the splice to be recognized after a restart.
+func (c *OpenChannel) SetPendingSplice(splice *PendingSplice) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
+		if err != nil {
+			return err
+		}
+
+		nodePub := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(nodePub)
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &c.FundingOutpoint); err != nil {
+			return err
+		}
+
+		return putPendingSplice(nodeChanBucket, b.Bytes(), splice)
+	})
+}
+
+// FetchPendingSplice returns the splice currently pending for the channel.
+// If the channel isn't being spliced, then ErrNoPendingSplice is returned.
+func (c *OpenChannel) FetchPendingSplice() (*PendingSplice, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	var splice *PendingSplice
+	err := c.Db.View(func(tx *bolt.Tx) error {
+		chanBucket := tx.Bucket(openChannelBucket)
+		if chanBucket == nil {
+			return ErrNoPendingSplice
+		}
+
+		nodePub := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket := chanBucket.Bucket(nodePub)
+		if nodeChanBucket == nil {
+			return ErrNoPendingSplice
+		}
+
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &c.FundingOutpoint); err != nil {
+			return err
+		}
+
+		var err error
+		splice, err = fetchPendingSplice(nodeChanBucket, b.Bytes())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return splice, nil
+}
+
+// CancelPendingSplice removes the pending splice of the channel, if any. This
+// should only be called if the splice transaction can no longer confirm.
+func (c *OpenChannel) CancelPendingSplice() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket := tx.Bucket(openChannelBucket)
+		if chanBucket == nil {
+			return ErrNoChanDBExists
+		}
+
+		nodePub := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket := chanBucket.Bucket(nodePub)
+		if nodeChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &c.FundingOutpoint); err != nil {
+			return err
+		}
+
+		return deletePendingSplice(nodeChanBucket, b.Bytes())
+	})
+}
+
+// CompleteSplice migrates the channel to the funding output created by its
+// pending splice once the splice transaction has been confirmed at the
+// location described by the passed short channel ID. All channel state is
+// re-indexed under the new funding outpoint, and the balances, capacity and
+// commitment transaction of the channel are replaced by those negotiated
+// within the splice.
+func (c *OpenChannel) CompleteSplice(shortChanID lnwire.ShortChannelID) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket := tx.Bucket(openChannelBucket)
+		if chanBucket == nil {
+			return ErrNoChanDBExists
+		}
+
+		nodePub := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket := chanBucket.Bucket(nodePub)
+		if nodeChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+		chanIndexBucket := nodeChanBucket.Bucket(chanIDBucket)
+		if chanIndexBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, &c.FundingOutpoint); err != nil {
+			return err
+		}
+		oldChanID := b.Bytes()
+
+		splice, err := fetchPendingSplice(nodeChanBucket, oldChanID)
+		if err != nil {
+			return err
+		}
+
+		// With the splice located, we'll purge all state stored under
+		// the prior funding outpoint.
+		if err := chanIndexBucket.Delete(oldChanID); err != nil {
+			return err
+		}
+		if err := deleteOpenChannel(chanBucket, nodeChanBucket,
+			oldChanID, &c.FundingOutpoint); err != nil {
+			return err
+		}
+
+		// The revocation log is carried over to the new funding
+		// outpoint, as it's used to determine the current height of
+		// the remote party's commitment chain.
+		logBucket := nodeChanBucket.Bucket(channelLogBucket)
+		if logBucket != nil {
+			err := moveChannelLogEntries(logBucket,
+				&c.FundingOutpoint, &splice.FundingOutpoint)
+			if err != nil {
+				return err
+			}
+		}
+
+		c.FundingOutpoint = splice.FundingOutpoint
+		c.ShortChanID = shortChanID
+		c.FundingBroadcastHeight = splice.BroadcastHeight
+		c.IsPending = false
+		c.Capacity = splice.Capacity
+		c.LocalBalance += lnwire.NewMSatFromSatoshis(splice.LocalDelta)
+		c.RemoteBalance += lnwire.NewMSatFromSatoshis(splice.RemoteDelta)
+		c.CommitTx = *splice.CommitTx
+		c.CommitSig = splice.CommitSig
+		c.Htlcs = splice.Htlcs
+
+		// The original funding transaction has long since confirmed,
+		// so there's no need to carry it over.
+		c.FundingTxn = nil
+
+		// Finally, we'll write out the channel in its entirety under
+		// the new funding outpoint.
+		var nb bytes.Buffer
+		if err := writeOutpoint(&nb, &c.FundingOutpoint); err != nil {
+			return err
+		}
+		if err := chanIndexBucket.Put(nb.Bytes(), nil); err != nil {
+			return err
+		}
+
+		return putOpenChannel(chanBucket, nodeChanBucket, c)
+	})
+}
+
 // ChannelSnapshot is a frozen snapshot of the current channel state. A
 // snapshot is detached from the original channel that generated it, providing
 // read-only access to the current or prior state of an active channel.
@@ -1008,7 +1403,7 @@ func serializeChannelCloseSummary(w io.Writer, cs *ChannelCloseSummary) error {
 		return err
 	}
 
-	return nil
+	return binary.Write(w, byteOrder, cs.CloseHeight)
 }
 
 func fetchChannelCloseSummary(tx *bolt.Tx,
@@ -1069,6 +1464,13 @@ func deserializeCloseChannelSummary(r io.Reader) (*ChannelCloseSummary, error) {
 		return nil, err
 	}
 
+	// Summaries written before the close height was tracked won't have
+	// this field, so we'll treat its absence as an unknown height.
+	err = binary.Read(r, byteOrder, &c.CloseHeight)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -1119,6 +1521,12 @@ func putOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err := putChanRevocationState(nodeChanBucket, channel); err != nil {
 		return err
 	}
+	if err := putChanShutdownScripts(nodeChanBucket, channel); err != nil {
+		return err
+	}
+	if err := putChanFundingTxn(nodeChanBucket, channel); err != nil {
+		return err
+	}
 	if err := putCurrentHtlcs(nodeChanBucket, channel.Htlcs,
 		&channel.FundingOutpoint); err != nil {
 		return err
@@ -1153,6 +1561,12 @@ func fetchOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err = fetchChanRevocationState(nodeChanBucket, channel); err != nil {
 		return nil, err
 	}
+	if err = fetchChanShutdownScripts(nodeChanBucket, channel); err != nil {
+		return nil, fmt.Errorf("unable to read shutdown scripts: %v", err)
+	}
+	if err = fetchChanFundingTxn(nodeChanBucket, channel); err != nil {
+		return nil, fmt.Errorf("unable to read funding txn: %v", err)
+	}
 	channel.Htlcs, err = fetchCurrentHtlcs(nodeChanBucket, chanID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read current htlc's: %v", err)
@@ -1231,9 +1645,18 @@ func deleteOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err := deleteChanRevocationState(nodeChanBucket, channelID); err != nil {
 		return err
 	}
+	if err := deleteChanShutdownScripts(nodeChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanFundingTxn(nodeChanBucket, channelID); err != nil {
+		return err
+	}
 	if err := deleteCurrentHtlcs(nodeChanBucket, o); err != nil {
 		return err
 	}
+	if err := deletePendingSplice(nodeChanBucket, channelID); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2023,6 +2446,261 @@ func fetchChanRevocationState(nodeChanBucket *bolt.Bucket, channel *OpenChannel)
 	return nil
 }
 
+func putChanShutdownScripts(nodeChanBucket *bolt.Bucket,
+	channel *OpenChannel) error {
+
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, &channel.FundingOutpoint); err != nil {
+		return err
+	}
+	scriptsKey := make([]byte, len(shutdownScriptsKey)+bc.Len())
+	copy(scriptsKey[:3], shutdownScriptsKey)
+	copy(scriptsKey[3:], bc.Bytes())
+
+	var b bytes.Buffer
+	if err := wire.WriteVarBytes(&b, 0, channel.LocalShutdownScript); err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(&b, 0, channel.RemoteShutdownScript); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(scriptsKey, b.Bytes())
+}
+
+func deleteChanShutdownScripts(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	scriptsKey := make([]byte, len(shutdownScriptsKey)+len(chanID))
+	copy(scriptsKey[:3], shutdownScriptsKey)
+	copy(scriptsKey[3:], chanID)
+	return nodeChanBucket.Delete(scriptsKey)
+}
+
+func fetchChanShutdownScripts(nodeChanBucket *bolt.Bucket,
+	channel *OpenChannel) error {
+
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, &channel.FundingOutpoint); err != nil {
+		return err
+	}
+	scriptsKey := make([]byte, len(shutdownScriptsKey)+bc.Len())
+	copy(scriptsKey[:3], shutdownScriptsKey)
+	copy(scriptsKey[3:], bc.Bytes())
+
+	// Channels created before upfront shutdown scripts were introduced
+	// won't have this key, in which case neither party has committed to a
+	// script.
+	scriptBytes := nodeChanBucket.Get(scriptsKey)
+	if scriptBytes == nil {
+		return nil
+	}
+	reader := bytes.NewReader(scriptBytes)
+
+	localScript, err := wire.ReadVarBytes(reader, 0, 34, "")
+	if err != nil {
+		return err
+	}
+	remoteScript, err := wire.ReadVarBytes(reader, 0, 34, "")
+	if err != nil {
+		return err
+	}
+
+	if len(localScript) != 0 {
+		channel.LocalShutdownScript = localScript
+	}
+	if len(remoteScript) != 0 {
+		channel.RemoteShutdownScript = remoteScript
+	}
+
+	return nil
+}
+
+func putChanFundingTxn(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	// Only channels we initiated carry their funding transaction.
+	if channel.FundingTxn == nil {
+		return nil
+	}
+
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, &channel.FundingOutpoint); err != nil {
+		return err
+	}
+	txnKey := make([]byte, len(fundingTxKey)+bc.Len())
+	copy(txnKey[:3], fundingTxKey)
+	copy(txnKey[3:], bc.Bytes())
+
+	var b bytes.Buffer
+	if err := channel.FundingTxn.Serialize(&b); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(txnKey, b.Bytes())
+}
+
+func deleteChanFundingTxn(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	txnKey := make([]byte, len(fundingTxKey)+len(chanID))
+	copy(txnKey[:3], fundingTxKey)
+	copy(txnKey[3:], chanID)
+	return nodeChanBucket.Delete(txnKey)
+}
+
+func fetchChanFundingTxn(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, &channel.FundingOutpoint); err != nil {
+		return err
+	}
+	txnKey := make([]byte, len(fundingTxKey)+bc.Len())
+	copy(txnKey[:3], fundingTxKey)
+	copy(txnKey[3:], bc.Bytes())
+
+	// Channels initiated by the remote party, or created before the
+	// funding transaction was stored, won't have this key.
+	txnBytes := nodeChanBucket.Get(txnKey)
+	if txnBytes == nil {
+		return nil
+	}
+
+	channel.FundingTxn = wire.NewMsgTx(1)
+	return channel.FundingTxn.Deserialize(bytes.NewReader(txnBytes))
+}
+
+func putPendingSplice(nodeChanBucket *bolt.Bucket, chanID []byte,
+	splice *PendingSplice) error {
+
+	spliceKey := make([]byte, len(pendingSpliceKey)+len(chanID))
+	copy(spliceKey[:3], pendingSpliceKey)
+	copy(spliceKey[3:], chanID)
+
+	var b bytes.Buffer
+	if err := splice.SpliceTx.Serialize(&b); err != nil {
+		return err
+	}
+	if err := writeOutpoint(&b, &splice.FundingOutpoint); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(splice.Capacity))
+	if _, err := b.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], uint64(splice.LocalDelta))
+	if _, err := b.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], uint64(splice.RemoteDelta))
+	if _, err := b.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := splice.CommitTx.Serialize(&b); err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(&b, 0, splice.CommitSig); err != nil {
+		return err
+	}
+
+	numHtlcs := uint64(len(splice.Htlcs))
+	if err := wire.WriteVarInt(&b, 0, numHtlcs); err != nil {
+		return err
+	}
+	for _, htlc := range splice.Htlcs {
+		if err := serializeHTLC(&b, htlc); err != nil {
+			return err
+		}
+	}
+	if err := writeBool(&b, splice.IsInitiator); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], splice.BroadcastHeight)
+	if _, err := b.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(spliceKey, b.Bytes())
+}
+
+func fetchPendingSplice(nodeChanBucket *bolt.Bucket,
+	chanID []byte) (*PendingSplice, error) {
+
+	spliceKey := make([]byte, len(pendingSpliceKey)+len(chanID))
+	copy(spliceKey[:3], pendingSpliceKey)
+	copy(spliceKey[3:], chanID)
+
+	spliceBytes := nodeChanBucket.Get(spliceKey)
+	if spliceBytes == nil {
+		return nil, ErrNoPendingSplice
+	}
+	r := bytes.NewReader(spliceBytes)
+
+	splice := &PendingSplice{
+		SpliceTx: wire.NewMsgTx(2),
+		CommitTx: wire.NewMsgTx(2),
+	}
+	if err := splice.SpliceTx.Deserialize(r); err != nil {
+		return nil, err
+	}
+	if err := readOutpoint(r, &splice.FundingOutpoint); err != nil {
+		return nil, err
+	}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	splice.Capacity = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	splice.LocalDelta = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	splice.RemoteDelta = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	if err := splice.CommitTx.Deserialize(r); err != nil {
+		return nil, err
+	}
+
+	var err error
+	splice.CommitSig, err = wire.ReadVarBytes(r, 0, 80, "")
+	if err != nil {
+		return nil, err
+	}
+
+	numHtlcs, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numHtlcs > 0 {
+		splice.Htlcs = make([]*HTLC, numHtlcs)
+		for i := uint64(0); i < numHtlcs; i++ {
+			splice.Htlcs[i], err = deserializeHTLC(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	splice.IsInitiator, err = readBool(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	splice.BroadcastHeight = byteOrder.Uint32(scratch[:4])
+
+	return splice, nil
+}
+
+func deletePendingSplice(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	spliceKey := make([]byte, len(pendingSpliceKey)+len(chanID))
+	copy(spliceKey[:3], pendingSpliceKey)
+	copy(spliceKey[3:], chanID)
+	return nodeChanBucket.Delete(spliceKey)
+}
+
 func serializeHTLC(w io.Writer, h *HTLC) error {
 	if err := wire.WriteVarBytes(w, 0, h.Signature); err != nil {
 		return err
@@ -2316,6 +2994,52 @@ func wipeChannelLogEntries(log *bolt.Bucket, o *wire.OutPoint) error {
 	return nil
 }
 
+// moveChannelLogEntries re-keys all the revocation log entries of the channel
+// with the funding outpoint oldPoint such that they're instead indexed under
+// the funding outpoint newPoint.
+func moveChannelLogEntries(log *bolt.Bucket, oldPoint,
+	newPoint *wire.OutPoint) error {
+
+	var (
+		n         int
+		logPrefix [32 + 4]byte
+		scratch   [4]byte
+	)
+
+	n += copy(logPrefix[:], oldPoint.Hash[:])
+	byteOrder.PutUint32(scratch[:], oldPoint.Index)
+	copy(logPrefix[n:], scratch[:])
+
+	// We'll first gather all the existing entries, as modifying the
+	// bucket while iterating over it with a cursor isn't safe.
+	var (
+		updateNums []uint64
+		entries    [][]byte
+	)
+	logCursor := log.Cursor()
+	for logKey, entry := logCursor.Seek(logPrefix[:]); bytes.HasPrefix(logKey, logPrefix[:]); logKey, entry = logCursor.Next() {
+		entryCopy := make([]byte, len(entry))
+		copy(entryCopy, entry)
+
+		updateNums = append(updateNums, byteOrder.Uint64(logKey[36:]))
+		entries = append(entries, entryCopy)
+	}
+
+	for i, updateNum := range updateNums {
+		oldKey := makeLogKey(oldPoint, updateNum)
+		if err := log.Delete(oldKey[:]); err != nil {
+			return err
+		}
+
+		newKey := makeLogKey(newPoint, updateNum)
+		if err := log.Put(newKey[:], entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func writeOutpoint(w io.Writer, o *wire.OutPoint) error {
 	// TODO(roasbeef): make all scratch buffers on the stack
 	scratch := make([]byte, 4)
@@ -176,7 +176,9 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		RemoteBalance:           lnwire.MilliSatoshi(9000),
 		CommitTx:                *testTx,
 		CommitSig:               bytes.Repeat([]byte{1}, 71),
+		FundingTxn:              testTx,
 		NumConfsRequired:        4,
+		LocalShutdownScript:     bytes.Repeat([]byte{2}, 22),
 		RemoteCurrentRevocation: privKey.PubKey(),
 		RemoteNextRevocation:    privKey.PubKey(),
 		RevocationProducer:      producer,
@@ -375,7 +377,8 @@ func TestChannelStateTransition(t *testing.T) {
 	}
 
 	// First update the local node's broadcastable state.
-	if err := channel.UpdateCommitment(newTx, newSig, delta); err != nil {
+	err = channel.UpdateCommitment(newTx, newSig, delta, nil)
+	if err != nil {
 		t.Fatalf("unable to update commitment: %v", err)
 	}
 
@@ -721,6 +724,14 @@ func TestFetchClosedChannels(t *testing.T) {
 			spew.Sdump(summary), spew.Sdump(closed[0]))
 	}
 
+	// Once the closing transaction confirms, we'll record its height
+	// within the summary.
+	const closeHeight = 1337
+	err = cdb.SetCloseHeight(&state.FundingOutpoint, closeHeight)
+	if err != nil {
+		t.Fatalf("unable to set close height: %v", err)
+	}
+
 	// Mark the channel as fully closed
 	err = cdb.MarkChanFullyClosed(&state.FundingOutpoint)
 	if err != nil {
@@ -737,6 +748,10 @@ func TestFetchClosedChannels(t *testing.T) {
 		t.Fatalf("incorrect number of closed channels: expecting %v, "+
 			"got %v", 1, len(closed))
 	}
+	if closed[0].CloseHeight != closeHeight {
+		t.Fatalf("expected close height %v, got %v", closeHeight,
+			closed[0].CloseHeight)
+	}
 	pendingClose, err := cdb.FetchClosedChannels(true)
 	if err != nil {
 		t.Fatalf("failed fetching channels pending close: %v", err)
@@ -746,3 +761,325 @@ func TestFetchClosedChannels(t *testing.T) {
 			"got %v", 0, len(closed))
 	}
 }
+
+// TestSyncPendingBatch tests that a set of channels sharing a funding
+// transaction are written to the database atomically in the pending state.
+func TestSyncPendingBatch(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+
+	// Create two channels which are funded by distinct outputs of the
+	// same funding transaction.
+	channels := make([]*OpenChannel, 2)
+	for i := range channels {
+		state, err := createTestChannelState(cdb)
+		if err != nil {
+			t.Fatalf("unable to create channel state: %v", err)
+		}
+		state.FundingOutpoint.Index = uint32(i)
+		channels[i] = state
+	}
+
+	// Attempting to write the batch with a mismatched number of addresses
+	// should fail, and leave nothing behind.
+	err = cdb.SyncPendingBatch(channels, []*net.TCPAddr{addr}, 99)
+	if err == nil {
+		t.Fatalf("expected batch with missing address to fail")
+	}
+	pendingChannels, err := cdb.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to list pending channels: %v", err)
+	}
+	if len(pendingChannels) != 0 {
+		t.Fatalf("incorrect number of pending channels: expecting %v, "+
+			"got %v", 0, len(pendingChannels))
+	}
+
+	const broadcastHeight = 100
+	addrs := []*net.TCPAddr{addr, addr}
+	if err := cdb.SyncPendingBatch(channels, addrs, broadcastHeight); err != nil {
+		t.Fatalf("unable to sync pending batch: %v", err)
+	}
+
+	pendingChannels, err = cdb.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to list pending channels: %v", err)
+	}
+	if len(pendingChannels) != len(channels) {
+		t.Fatalf("incorrect number of pending channels: expecting %v, "+
+			"got %v", len(channels), len(pendingChannels))
+	}
+	for _, channel := range pendingChannels {
+		if channel.FundingBroadcastHeight != broadcastHeight {
+			t.Fatalf("broadcast height mismatch: expected %v, "+
+				"got %v", broadcastHeight,
+				channel.FundingBroadcastHeight)
+		}
+	}
+}
+
+// TestMarkChanAbandoned tests that a channel pending closure can be marked as
+// abandoned, removing it from the set of pending closed channels and updating
+// its close type.
+func TestMarkChanAbandoned(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := state.SyncPending(addr, 99); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Attempting to abandon a channel which hasn't yet been closed should
+	// fail, as there's no close summary to update.
+	if err := cdb.MarkChanAbandoned(&state.FundingOutpoint); err == nil {
+		t.Fatalf("expected abandoning an open channel to fail")
+	}
+
+	// Next, force close the channel, leaving it in a pending closed state.
+	summary := &ChannelCloseSummary{
+		ChanPoint:   state.FundingOutpoint,
+		ClosingTXID: rev,
+		RemotePub:   state.IdentityPub,
+		Capacity:    state.Capacity,
+		CloseType:   ForceClose,
+		IsPending:   true,
+	}
+	if err := state.CloseChannel(summary); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+
+	if err := cdb.MarkChanAbandoned(&state.FundingOutpoint); err != nil {
+		t.Fatalf("unable to abandon channel: %v", err)
+	}
+
+	// The channel should no longer be pending closure, but should still
+	// be found amongst all closed channels with the abandoned close type.
+	pendingClose, err := cdb.FetchClosedChannels(true)
+	if err != nil {
+		t.Fatalf("failed fetching channels pending close: %v", err)
+	}
+	if len(pendingClose) != 0 {
+		t.Fatalf("incorrect number of pending closed channels: "+
+			"expecting %v, got %v", 0, len(pendingClose))
+	}
+	closed, err := cdb.FetchClosedChannels(false)
+	if err != nil {
+		t.Fatalf("failed fetching closed channels: %v", err)
+	}
+	if len(closed) != 1 {
+		t.Fatalf("incorrect number of closed channels: expecting %v, "+
+			"got %v", 1, len(closed))
+	}
+	if closed[0].CloseType != Abandoned {
+		t.Fatalf("expected close type %v, got %v", Abandoned,
+			closed[0].CloseType)
+	}
+}
+
+// TestChannelSplice tests that once a pending splice is completed, the channel
+// is re-indexed under the new funding outpoint with the state negotiated
+// within the splice, and that the revocation log is carried over.
+func TestChannelSplice(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Add an entry to the revocation log so we can ensure it survives
+	// the splice.
+	delta := &ChannelDelta{
+		LocalBalance:  state.LocalBalance,
+		RemoteBalance: state.RemoteBalance,
+		UpdateNum:     7,
+	}
+	if err := state.AppendToRevocationLog(delta); err != nil {
+		t.Fatalf("unable to append to revocation log: %v", err)
+	}
+
+	// Initially, the channel shouldn't have a pending splice.
+	if _, err := state.FetchPendingSplice(); err != ErrNoPendingSplice {
+		t.Fatalf("expected ErrNoPendingSplice, got %v", err)
+	}
+
+	spliceTx := testTx.Copy()
+	spliceTx.TxIn[0].PreviousOutPoint = state.FundingOutpoint
+	newCommitTx := testTx.Copy()
+	newCommitTx.LockTime = 99
+	splice := &PendingSplice{
+		SpliceTx: spliceTx,
+		FundingOutpoint: wire.OutPoint{
+			Hash:  spliceTx.TxHash(),
+			Index: 0,
+		},
+		Capacity:        state.Capacity + 5000,
+		LocalDelta:      5000,
+		RemoteDelta:     0,
+		CommitTx:        newCommitTx,
+		CommitSig:       bytes.Repeat([]byte{4}, 71),
+		IsInitiator:     true,
+		BroadcastHeight: 200,
+	}
+	if err := state.SetPendingSplice(splice); err != nil {
+		t.Fatalf("unable to set pending splice: %v", err)
+	}
+
+	diskSplice, err := state.FetchPendingSplice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending splice: %v", err)
+	}
+	if !reflect.DeepEqual(splice, diskSplice) {
+		t.Fatalf("splices don't match: expected %v, got %v",
+			spew.Sdump(splice), spew.Sdump(diskSplice))
+	}
+
+	// The channel remains active while the splice transaction confirms,
+	// so we'll now accept a new state which carries an HTLC. Our version
+	// of the new state spending the funding output of the splice should
+	// be updated along with it.
+	htlc := &HTLC{
+		Signature:     bytes.Repeat([]byte{5}, 71),
+		Amt:           lnwire.NewMSatFromSatoshis(1000),
+		RefundTimeout: 500,
+		OutputIndex:   1,
+	}
+	spliceHtlc := *htlc
+	spliceHtlc.Signature = bytes.Repeat([]byte{6}, 71)
+	spliceHtlc.OutputIndex = 2
+
+	newTx := state.CommitTx.Copy()
+	newTx.LockTime = 100
+	spliceCommitTx := newCommitTx.Copy()
+	spliceCommitTx.LockTime = 101
+	newDelta := &ChannelDelta{
+		LocalBalance:  state.LocalBalance - htlc.Amt,
+		RemoteBalance: state.RemoteBalance,
+		Htlcs:         []*HTLC{htlc},
+		UpdateNum:     8,
+	}
+	spliceCommit := &SpliceCommitment{
+		CommitTx:  spliceCommitTx,
+		CommitSig: bytes.Repeat([]byte{7}, 71),
+		Htlcs:     []*HTLC{&spliceHtlc},
+	}
+	err = state.UpdateCommitment(newTx, bytes.Repeat([]byte{3}, 71),
+		newDelta, spliceCommit)
+	if err != nil {
+		t.Fatalf("unable to update commitment: %v", err)
+	}
+
+	diskSplice, err = state.FetchPendingSplice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending splice: %v", err)
+	}
+	if diskSplice.CommitTx.TxHash() != spliceCommitTx.TxHash() {
+		t.Fatalf("splice commitment transaction wasn't updated")
+	}
+	if !bytes.Equal(diskSplice.CommitSig, spliceCommit.CommitSig) {
+		t.Fatalf("splice commitment signature wasn't updated")
+	}
+	if !reflect.DeepEqual(diskSplice.Htlcs, spliceCommit.Htlcs) {
+		t.Fatalf("splice htlcs don't match: expected %v, got %v",
+			spew.Sdump(spliceCommit.Htlcs),
+			spew.Sdump(diskSplice.Htlcs))
+	}
+
+	// Complete the splice, the channel should now be found under the new
+	// funding outpoint with the updated state.
+	shortChanID := lnwire.NewShortChanIDFromInt(1337)
+	if err := state.CompleteSplice(shortChanID); err != nil {
+		t.Fatalf("unable to complete splice: %v", err)
+	}
+
+	openChans, err := cdb.FetchOpenChannels(state.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch open channels: %v", err)
+	}
+	if len(openChans) != 1 {
+		t.Fatalf("expected 1 open channel, got %v", len(openChans))
+	}
+	newState := openChans[0]
+	if newState.FundingOutpoint != splice.FundingOutpoint {
+		t.Fatalf("funding outpoint mismatch: expected %v, got %v",
+			splice.FundingOutpoint, newState.FundingOutpoint)
+	}
+	if newState.ShortChanID != shortChanID {
+		t.Fatalf("short chan id mismatch: expected %v, got %v",
+			shortChanID, newState.ShortChanID)
+	}
+	if newState.Capacity != splice.Capacity {
+		t.Fatalf("capacity mismatch: expected %v, got %v",
+			splice.Capacity, newState.Capacity)
+	}
+	expectedBalance := newDelta.LocalBalance +
+		lnwire.NewMSatFromSatoshis(splice.LocalDelta)
+	if newState.LocalBalance != expectedBalance {
+		t.Fatalf("local balance mismatch: expected %v, got %v",
+			expectedBalance, newState.LocalBalance)
+	}
+	if newState.RemoteBalance != newDelta.RemoteBalance {
+		t.Fatalf("remote balance mismatch: expected %v, got %v",
+			newDelta.RemoteBalance, newState.RemoteBalance)
+	}
+	if newState.CommitTx.TxHash() != spliceCommitTx.TxHash() {
+		t.Fatalf("commitment transaction wasn't updated")
+	}
+	if !reflect.DeepEqual(newState.Htlcs, spliceCommit.Htlcs) {
+		t.Fatalf("htlcs don't match: expected %v, got %v",
+			spew.Sdump(spliceCommit.Htlcs),
+			spew.Sdump(newState.Htlcs))
+	}
+	if !bytes.Equal(newState.LocalShutdownScript,
+		state.LocalShutdownScript) {
+
+		t.Fatalf("shutdown script wasn't carried over")
+	}
+
+	// The pending splice should have been consumed, and the revocation
+	// log should now be indexed under the new funding outpoint.
+	if _, err := newState.FetchPendingSplice(); err != ErrNoPendingSplice {
+		t.Fatalf("expected ErrNoPendingSplice, got %v", err)
+	}
+	prevState, err := newState.FindPreviousState(delta.UpdateNum)
+	if err != nil {
+		t.Fatalf("unable to fetch revocation log entry: %v", err)
+	}
+	if prevState.UpdateNum != delta.UpdateNum {
+		t.Fatalf("log entry mismatch: expected %v, got %v",
+			delta.UpdateNum, prevState.UpdateNum)
+	}
+}
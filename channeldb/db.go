@@ -40,6 +40,13 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of the database where force closes
+			// initiated by the remote party are recorded with
+			// their own close type.
+			number:    1,
+			migration: migrateRemoteForceCloses,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -479,6 +486,53 @@ func (d *DB) MarkChanFullyClosed(chanPoint *wire.OutPoint) error {
 	})
 }
 
+// MarkChanAbandoned marks a channel which is pending closure as fully closed,
+// updating its close type to reflect that it was manually abandoned rather
+// than resolved on-chain. This should only be used for channels whose
+// closing transaction can no longer be resolved.
+func (d *DB) MarkChanAbandoned(chanPoint *wire.OutPoint) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, chanPoint); err != nil {
+			return err
+		}
+
+		chanID := b.Bytes()
+
+		chanSummary, err := fetchChannelCloseSummary(tx, chanID)
+		if err != nil {
+			return err
+		}
+
+		chanSummary.IsPending = false
+		chanSummary.CloseType = Abandoned
+
+		return putChannelCloseSummary(tx, chanID, chanSummary)
+	})
+}
+
+// SetCloseHeight records the height at which the closing transaction of the
+// target channel was confirmed within its close summary.
+func (d *DB) SetCloseHeight(chanPoint *wire.OutPoint, height uint32) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, chanPoint); err != nil {
+			return err
+		}
+
+		chanID := b.Bytes()
+
+		chanSummary, err := fetchChannelCloseSummary(tx, chanID)
+		if err != nil {
+			return err
+		}
+
+		chanSummary.CloseHeight = height
+
+		return putChannelCloseSummary(tx, chanID, chanSummary)
+	})
+}
+
 // syncVersions function is used for safe db version synchronization. It applies
 // migration functions to the current database and recovers the previous
 // state of db if at least one error/panic appeared during migration.
@@ -85,4 +85,33 @@ var (
 	// ErrNoClosedChannels is returned when a node is queries for all the
 	// channels it has closed, but it hasn't yet closed any channels.
 	ErrNoClosedChannels = fmt.Errorf("no channel have been closed yet")
+
+	// ErrNoPendingSplice is returned when a channel is queried for its
+	// pending splice, but the channel isn't currently being spliced.
+	ErrNoPendingSplice = fmt.Errorf("channel has no pending splice")
+
+	// ErrNoWitnesses is returned when we're unable to find a witness
+	// within the witness cache.
+	ErrNoWitnesses = fmt.Errorf("no witnesses")
+
+	// ErrUnknownWitnessType is returned if a caller attempts to add or
+	// look up a witness of a type the witness cache doesn't know of.
+	ErrUnknownWitnessType = fmt.Errorf("unknown witness type")
+
+	// ErrAlreadyPaid is returned when attempting to initiate a payment
+	// to a payment hash which has already been paid successfully.
+	ErrAlreadyPaid = fmt.Errorf("invoice is already paid")
+
+	// ErrPaymentInFlight is returned when attempting to initiate a
+	// payment to a payment hash which is already being paid.
+	ErrPaymentInFlight = fmt.Errorf("payment is in transition")
+
+	// ErrPaymentNotInitiated is returned when attempting to register or
+	// fail an attempt of a payment which hasn't been initiated, or has
+	// already been finalized.
+	ErrPaymentNotInitiated = fmt.Errorf("payment isn't initiated")
+
+	// ErrPaymentNotFound is returned when no payment is known for the
+	// target payment hash.
+	ErrPaymentNotFound = fmt.Errorf("unable to locate payment")
 )
@@ -80,6 +80,17 @@ var (
 	// maps: outPoint -> chanID
 	channelPointBucket = []byte("chan-index")
 
+	// zombieIndexBucket is an index of the channels which have been pruned
+	// from the graph as zombies, as neither of their nodes has sent out a
+	// channel update for a long while. This bucket resides within the
+	// edgeBucket above. We keep the full edge information of each zombie
+	// channel, so that re-gossiped announcements of the channel can be
+	// ignored, while a fresh update for the channel can still be validated
+	// and used to resurrect it.
+	//
+	// maps: chanID -> pubKey1 || pubKey2 || restofEdgeInfo
+	zombieIndexBucket = []byte("zombie-index")
+
 	// graphMetaBucket is a top-level bucket which stores various meta-deta
 	// related to the on-disk channel graph. Data stored in this bucket
 	// includes the block to which the graph has been synced to, the total
@@ -717,6 +728,224 @@ func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
 	})
 }
 
+// MarkEdgeZombie removes the channels identified by the passed channel IDs
+// from the graph, and adds them to the zombie index. Unlike channels which
+// have been closed, zombie channels may be resurrected by MarkEdgeLive if
+// either of their nodes sends out a fresh update. Channels which aren't known
+// are skipped. The information of each removed channel is returned.
+func (c *ChannelGraph) MarkEdgeZombie(
+	chanIDs ...uint64) ([]*ChannelEdgeInfo, error) {
+
+	var zombies []*ChannelEdgeInfo
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		if err != nil {
+			return err
+		}
+		edgeIndex, err := edges.CreateBucketIfNotExists(edgeIndexBucket)
+		if err != nil {
+			return err
+		}
+		chanIndex, err := edges.CreateBucketIfNotExists(channelPointBucket)
+		if err != nil {
+			return err
+		}
+		zombieIndex, err := edges.CreateBucketIfNotExists(
+			zombieIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, chanID := range chanIDs {
+			var chanKey [8]byte
+			byteOrder.PutUint64(chanKey[:], chanID)
+
+			edgeInfo, err := fetchChanEdgeInfo(edgeIndex, chanKey[:])
+			if err == ErrEdgeNotFound {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			// We'll first record the channel within the zombie
+			// index, before removing it from the graph along with
+			// both of its policies.
+			err = putChanEdgeInfo(zombieIndex, edgeInfo, chanKey)
+			if err != nil {
+				return err
+			}
+			err = delChannelByEdge(
+				edges, edgeIndex, chanIndex,
+				&edgeInfo.ChannelPoint,
+			)
+			if err != nil {
+				return err
+			}
+
+			zombies = append(zombies, edgeInfo)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return zombies, nil
+}
+
+// FetchZombieEdge returns the information of the channel identified by the
+// passed channel ID, if it has been pruned from the graph as a zombie. If the
+// channel isn't a zombie, then ErrEdgeNotFound is returned.
+func (c *ChannelGraph) FetchZombieEdge(chanID uint64) (*ChannelEdgeInfo, error) {
+	var edgeInfo *ChannelEdgeInfo
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrEdgeNotFound
+		}
+		zombieIndex := edges.Bucket(zombieIndexBucket)
+		if zombieIndex == nil {
+			return ErrEdgeNotFound
+		}
+
+		var chanKey [8]byte
+		byteOrder.PutUint64(chanKey[:], chanID)
+
+		info, err := fetchChanEdgeInfo(zombieIndex, chanKey[:])
+		if err != nil {
+			return err
+		}
+		edgeInfo = info
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edgeInfo, nil
+}
+
+// MarkEdgeLive removes the channel identified by the passed channel ID from
+// the zombie index, so that it may be added back to the graph. If the channel
+// isn't a zombie, then this method is a noop.
+func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		if err != nil {
+			return err
+		}
+		zombieIndex, err := edges.CreateBucketIfNotExists(
+			zombieIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var chanKey [8]byte
+		byteOrder.PutUint64(chanKey[:], chanID)
+
+		return zombieIndex.Delete(chanKey[:])
+	})
+}
+
+// PruneGraphNodes removes all nodes from the graph which are no longer a party
+// to any channel, with the exception of the source node. The public keys of
+// the removed nodes are returned.
+func (c *ChannelGraph) PruneGraphNodes() ([]*btcec.PublicKey, error) {
+	var prunedNodes []*btcec.PublicKey
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return nil
+		}
+		aliases := nodes.Bucket(aliasIndexBucket)
+
+		// First, we'll gather the set of nodes which are still a party
+		// to at least one channel. Each entry of the edge index leads
+		// with the public keys of both nodes of the channel.
+		linkedNodes := make(map[[33]byte]struct{})
+		linkNodes := func(_, edgeInfo []byte) error {
+			var node1, node2 [33]byte
+			copy(node1[:], edgeInfo[:33])
+			copy(node2[:], edgeInfo[33:66])
+
+			linkedNodes[node1] = struct{}{}
+			linkedNodes[node2] = struct{}{}
+			return nil
+		}
+		if edges := tx.Bucket(edgeBucket); edges != nil {
+			edgeIndex := edges.Bucket(edgeIndexBucket)
+			if edgeIndex != nil {
+				if err := edgeIndex.ForEach(linkNodes); err != nil {
+					return err
+				}
+			}
+		}
+
+		// The source node is never pruned, even if it doesn't have any
+		// channels.
+		if sourcePub := nodes.Get(sourceKey); sourcePub != nil {
+			var source [33]byte
+			copy(source[:], sourcePub)
+			linkedNodes[source] = struct{}{}
+		}
+
+		// Next, we'll gather all the nodes which aren't linked. Keys
+		// within the node bucket other than node public keys, such as
+		// the source key and the alias index, are skipped.
+		var orphans [][33]byte
+		err := nodes.ForEach(func(pubKey, _ []byte) error {
+			if len(pubKey) != 33 {
+				return nil
+			}
+
+			var node [33]byte
+			copy(node[:], pubKey)
+			if _, ok := linkedNodes[node]; !ok {
+				orphans = append(orphans, node)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Finally, with the orphaned nodes gathered, we'll remove them,
+		// along with their aliases. The removal is done separately, as
+		// a bucket mustn't be modified while it's being iterated.
+		for _, node := range orphans {
+			pub, err := btcec.ParsePubKey(node[:], btcec.S256())
+			if err != nil {
+				return err
+			}
+
+			if aliases != nil {
+				if err := aliases.Delete(node[:]); err != nil {
+					return err
+				}
+			}
+			if err := nodes.Delete(node[:]); err != nil {
+				return err
+			}
+
+			prunedNodes = append(prunedNodes, pub)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prunedNodes, nil
+}
+
 // ChannelID attempt to lookup the 8-byte compact channel ID which maps to the
 // passed channel point (outpoint). If the passed channel doesn't exist within
 // the database, then ErrEdgeNotFound is returned.
@@ -835,7 +1064,7 @@ func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
 		// Depending on the flags value passed above, either the first
 		// or second edge policy is being updated.
 		var fromNode, toNode []byte
-		if edge.Flags == 0 {
+		if edge.Flags&lnwire.ChanUpdateDirection == 0 {
 			fromNode = nodeInfo[:33]
 			toNode = nodeInfo[33:67]
 		} else {
@@ -1407,6 +1636,268 @@ func (c *ChannelGraph) ChannelView() ([]wire.OutPoint, error) {
 	return chanPoints, nil
 }
 
+// ChannelEdge represents the complete set of information for a channel edge in
+// the known channel graph. This struct couples the core information of the
+// edge as well as each of the known advertised edge policies.
+type ChannelEdge struct {
+	// Info contains all the static information describing the channel.
+	Info *ChannelEdgeInfo
+
+	// Policy1 points to the "first" edge policy of the channel containing
+	// the dynamic information required to properly route through the
+	// edge. It may be nil if the policy hasn't been advertised.
+	Policy1 *ChannelEdgePolicy
+
+	// Policy2 points to the "second" edge policy of the channel, and may
+	// likewise be nil.
+	Policy2 *ChannelEdgePolicy
+}
+
+// HighestChanID returns the "highest" known channel ID in the channel graph.
+// This represents the "newest" channel from the PoV of the chain. This method
+// can be used by peers to quickly determine if their graphs are in sync. If
+// the graph doesn't have any channels, then zero is returned.
+func (c *ChannelGraph) HighestChanID() (uint64, error) {
+	var cid uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// As the edge index is keyed by the big-endian channel ID, the
+		// last key within the index is the highest channel ID.
+		lastChanID, _ := edgeIndex.Cursor().Last()
+		if lastChanID == nil {
+			return nil
+		}
+
+		cid = byteOrder.Uint64(lastChanID)
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return 0, err
+	}
+
+	return cid, nil
+}
+
+// ChanUpdatesInHorizon returns all the known channels which have at least one
+// edge policy updated within the passed inclusive time range.
+//
+// TODO(roasbeef): add an index by update time to avoid a full scan
+func (c *ChannelGraph) ChanUpdatesInHorizon(startTime,
+	endTime time.Time) ([]ChannelEdge, error) {
+
+	inHorizon := func(policy *ChannelEdgePolicy) bool {
+		return policy != nil && !policy.LastUpdate.Before(startTime) &&
+			!policy.LastUpdate.After(endTime)
+	}
+
+	var edgesInHorizon []ChannelEdge
+	err := c.ForEachChannel(func(info *ChannelEdgeInfo,
+		policy1, policy2 *ChannelEdgePolicy) error {
+
+		if !inHorizon(policy1) && !inHorizon(policy2) {
+			return nil
+		}
+
+		edgesInHorizon = append(edgesInHorizon, ChannelEdge{
+			Info:    info,
+			Policy1: policy1,
+			Policy2: policy2,
+		})
+		return nil
+	})
+	switch {
+	case err == ErrGraphNotFound:
+	case err == ErrGraphNoEdgesFound:
+	case err != nil:
+		return nil, err
+	}
+
+	return edgesInHorizon, nil
+}
+
+// NodeUpdatesInHorizon returns all the known nodes whose node announcement
+// was last updated within the passed inclusive time range. Nodes which never
+// announced themselves are skipped.
+//
+// TODO(roasbeef): add an index by update time to avoid a full scan
+func (c *ChannelGraph) NodeUpdatesInHorizon(startTime,
+	endTime time.Time) ([]LightningNode, error) {
+
+	var nodesInHorizon []LightningNode
+	err := c.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+		if node.LastUpdate.Before(startTime) ||
+			node.LastUpdate.After(endTime) {
+
+			return nil
+		}
+
+		nodesInHorizon = append(nodesInHorizon, *node)
+		return nil
+	})
+	if err != nil && err != ErrGraphNotFound {
+		return nil, err
+	}
+
+	return nodesInHorizon, nil
+}
+
+// FilterKnownChanIDs takes a set of channel IDs and returns the subset of
+// channel IDs that we don't know of, preserving their order. This can be used
+// to determine which channels we should request from a peer after learning of
+// the channels it knows of. Channels pruned as zombies are considered known,
+// as their announcements would be ignored anyway.
+func (c *ChannelGraph) FilterKnownChanIDs(chanIDs []uint64) ([]uint64, error) {
+	var newChanIDs []uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		// If we don't have any edges yet, then all the channels are
+		// new to us.
+		var edgeIndex, zombieIndex *bolt.Bucket
+		if edges := tx.Bucket(edgeBucket); edges != nil {
+			edgeIndex = edges.Bucket(edgeIndexBucket)
+			zombieIndex = edges.Bucket(zombieIndexBucket)
+		}
+
+		var cid [8]byte
+		for _, chanID := range chanIDs {
+			byteOrder.PutUint64(cid[:], chanID)
+			if edgeIndex != nil && edgeIndex.Get(cid[:]) != nil {
+				continue
+			}
+			if zombieIndex != nil && zombieIndex.Get(cid[:]) != nil {
+				continue
+			}
+
+			newChanIDs = append(newChanIDs, chanID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newChanIDs, nil
+}
+
+// FilterChannelRange returns the IDs of all the known channels which were
+// confirmed within the passed inclusive range of block heights, in ascending
+// order.
+func (c *ChannelGraph) FilterChannelRange(startHeight,
+	endHeight uint32) ([]uint64, error) {
+
+	// As the block height makes up the most significant bytes of a
+	// channel ID, the channels within the range form a contiguous range
+	// of keys within the edge index.
+	startChanID := &lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+	}
+	endChanID := &lnwire.ShortChannelID{
+		BlockHeight: endHeight,
+		TxIndex:     (1 << 24) - 1,
+		TxPosition:  (1 << 16) - 1,
+	}
+
+	var startKey, endKey [8]byte
+	byteOrder.PutUint64(startKey[:], startChanID.ToUint64())
+	byteOrder.PutUint64(endKey[:], endChanID.ToUint64())
+
+	var chanIDs []uint64
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cursor := edgeIndex.Cursor()
+		for k, _ := cursor.Seek(startKey[:]); k != nil &&
+			bytes.Compare(k, endKey[:]) <= 0; k, _ = cursor.Next() {
+
+			chanIDs = append(chanIDs, byteOrder.Uint64(k))
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// FetchChanInfos returns the information and edge policies of each of the
+// channels identified by the passed channel IDs, in the same order. Channels
+// which aren't known are skipped.
+func (c *ChannelGraph) FetchChanInfos(chanIDs []uint64) ([]ChannelEdge, error) {
+	var chanEdges []ChannelEdge
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		var cid [8]byte
+		for _, chanID := range chanIDs {
+			byteOrder.PutUint64(cid[:], chanID)
+
+			edgeInfo, err := fetchChanEdgeInfo(edgeIndex, cid[:])
+			if err == ErrEdgeNotFound {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			policy1, policy2, err := fetchChanEdgePolicies(
+				edgeIndex, edges, nodes, cid[:], c.db,
+			)
+			if err != nil {
+				return err
+			}
+
+			chanEdges = append(chanEdges, ChannelEdge{
+				Info:    edgeInfo,
+				Policy1: policy1,
+				Policy2: policy2,
+			})
+		}
+
+		return nil
+	})
+	switch {
+	case err == ErrGraphNotFound:
+	case err == ErrGraphNoEdgesFound:
+	case err != nil:
+		return nil, err
+	}
+
+	return chanEdges, nil
+}
+
 // NewChannelEdgePolicy returns a new blank ChannelEdgePolicy.
 func (c *ChannelGraph) NewChannelEdgePolicy() *ChannelEdgePolicy {
 	return &ChannelEdgePolicy{db: c.db}
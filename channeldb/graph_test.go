@@ -744,6 +744,328 @@ func TestGraphTraversal(t *testing.T) {
 	}
 }
 
+// TestGraphRangeQueries tests the queries used to synchronize the graph with
+// peers: querying channels by block range and update time, filtering out known
+// channels, and fetching the channels themselves.
+func TestGraphRangeQueries(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// With an empty graph, the highest channel ID should be zero.
+	highestChanID, err := graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != 0 {
+		t.Fatalf("expected highest chan ID of zero, got %v",
+			highestChanID)
+	}
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	node1.LastUpdate = time.Unix(5000, 0)
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	node2.LastUpdate = time.Unix(9000, 0)
+	for _, node := range []*LightningNode{node1, node2} {
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	// We'll create a channel at each of the following heights. Only the
+	// first two will have a policy, which is updated at a time
+	// proportional to the height of the channel.
+	heights := []uint32{100, 200, 300}
+	chanIDs := make([]uint64, len(heights))
+	for i, height := range heights {
+		shortChanID := lnwire.ShortChannelID{
+			BlockHeight: height,
+		}
+		chanIDs[i] = shortChanID.ToUint64()
+
+		op := wire.OutPoint{
+			Hash: sha256.Sum256([]byte{byte(i)}),
+		}
+		edgeInfo := ChannelEdgeInfo{
+			ChannelID:    chanIDs[i],
+			ChainHash:    key,
+			NodeKey1:     node1.PubKey,
+			NodeKey2:     node2.PubKey,
+			BitcoinKey1:  node1.PubKey,
+			BitcoinKey2:  node2.PubKey,
+			ChannelPoint: op,
+			Capacity:     1000,
+		}
+		if err := graph.AddChannelEdge(&edgeInfo); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		if height == 300 {
+			continue
+		}
+
+		edge := randEdgePolicy(chanIDs[i], op, db)
+		edge.Flags = 0
+		edge.Node = node2
+		edge.Signature = testSig
+		edge.LastUpdate = time.Unix(int64(height)*1000, 0)
+		if err := graph.UpdateEdgePolicy(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	highestChanID, err = graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != chanIDs[2] {
+		t.Fatalf("expected highest chan ID of %v, got %v", chanIDs[2],
+			highestChanID)
+	}
+
+	// Only the channels confirmed within the range should be returned.
+	rangeChanIDs, err := graph.FilterChannelRange(150, 300)
+	if err != nil {
+		t.Fatalf("unable to filter channel range: %v", err)
+	}
+	if !reflect.DeepEqual(rangeChanIDs, chanIDs[1:]) {
+		t.Fatalf("expected chan IDs %v, got %v", chanIDs[1:],
+			rangeChanIDs)
+	}
+	rangeChanIDs, err = graph.FilterChannelRange(0, 99)
+	if err != nil {
+		t.Fatalf("unable to filter channel range: %v", err)
+	}
+	if len(rangeChanIDs) != 0 {
+		t.Fatalf("expected no chan IDs, got %v", rangeChanIDs)
+	}
+
+	// Of a set of both known and unknown channels, only the unknown ones
+	// should remain after filtering.
+	const unknownChanID = 999
+	newChanIDs, err := graph.FilterKnownChanIDs(
+		[]uint64{chanIDs[0], unknownChanID},
+	)
+	if err != nil {
+		t.Fatalf("unable to filter known chan IDs: %v", err)
+	}
+	if !reflect.DeepEqual(newChanIDs, []uint64{unknownChanID}) {
+		t.Fatalf("expected only chan ID %v, got %v", unknownChanID,
+			newChanIDs)
+	}
+
+	// Fetching the channels should skip the unknown one, while keeping
+	// the order of the rest.
+	chanEdges, err := graph.FetchChanInfos(
+		[]uint64{chanIDs[1], unknownChanID, chanIDs[0]},
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch chan infos: %v", err)
+	}
+	if len(chanEdges) != 2 {
+		t.Fatalf("expected 2 channels, got %v", len(chanEdges))
+	}
+	if chanEdges[0].Info.ChannelID != chanIDs[1] ||
+		chanEdges[1].Info.ChannelID != chanIDs[0] {
+
+		t.Fatalf("channels returned in wrong order")
+	}
+	if chanEdges[0].Policy1 == nil || chanEdges[0].Policy2 != nil {
+		t.Fatalf("expected only the first policy to be found")
+	}
+
+	// Only the second channel has a policy updated within this horizon.
+	edgesInHorizon, err := graph.ChanUpdatesInHorizon(
+		time.Unix(150000, 0), time.Unix(250000, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch chan updates: %v", err)
+	}
+	if len(edgesInHorizon) != 1 ||
+		edgesInHorizon[0].Info.ChannelID != chanIDs[1] {
+
+		t.Fatalf("expected only channel %v within horizon, got %v",
+			chanIDs[1], len(edgesInHorizon))
+	}
+
+	// Similarly, only the first node was updated within this horizon.
+	nodesInHorizon, err := graph.NodeUpdatesInHorizon(
+		time.Unix(4000, 0), time.Unix(6000, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch node updates: %v", err)
+	}
+	if len(nodesInHorizon) != 1 ||
+		!nodesInHorizon[0].PubKey.IsEqual(node1.PubKey) {
+
+		t.Fatalf("expected only the first node within horizon, got %v "+
+			"nodes", len(nodesInHorizon))
+	}
+}
+
+// TestGraphZombieIndex tests that channels marked as zombies are removed from
+// the graph while being kept within the zombie index until marked live, and
+// that nodes left without channels are pruned.
+func TestGraphZombieIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	sourceNode, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create source node: %v", err)
+	}
+	if err := graph.SetSourceNode(sourceNode); err != nil {
+		t.Fatalf("unable to set source node: %v", err)
+	}
+
+	// We'll create three nodes, with a channel between the first two, and
+	// a channel between the second and the third.
+	var nodes []*LightningNode
+	for i := 0; i < 3; i++ {
+		node, err := createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	var edges []*ChannelEdgeInfo
+	for i := 0; i < 2; i++ {
+		edgeInfo := &ChannelEdgeInfo{
+			ChannelID:   uint64(i + 1),
+			ChainHash:   key,
+			NodeKey1:    nodes[i].PubKey,
+			NodeKey2:    nodes[i+1].PubKey,
+			BitcoinKey1: nodes[i].PubKey,
+			BitcoinKey2: nodes[i+1].PubKey,
+			ChannelPoint: wire.OutPoint{
+				Hash: sha256.Sum256([]byte{byte(i)}),
+			},
+			Capacity: 1000,
+		}
+		if err := graph.AddChannelEdge(edgeInfo); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+		edges = append(edges, edgeInfo)
+	}
+
+	// While all the nodes have channels, none should be pruned.
+	prunedNodes, err := graph.PruneGraphNodes()
+	if err != nil {
+		t.Fatalf("unable to prune nodes: %v", err)
+	}
+	if len(prunedNodes) != 0 {
+		t.Fatalf("expected no nodes to be pruned, got %v",
+			len(prunedNodes))
+	}
+
+	// A channel which isn't a zombie shouldn't be found within the zombie
+	// index.
+	_, err = graph.FetchZombieEdge(edges[0].ChannelID)
+	if err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", err)
+	}
+
+	// Next, we'll mark the first channel as a zombie, along with an
+	// unknown channel, which should be skipped.
+	zombies, err := graph.MarkEdgeZombie(edges[0].ChannelID, 100)
+	if err != nil {
+		t.Fatalf("unable to mark edge zombie: %v", err)
+	}
+	if len(zombies) != 1 || zombies[0].ChannelID != edges[0].ChannelID {
+		t.Fatalf("expected only the first channel to be marked as "+
+			"zombie, got %v zombies", len(zombies))
+	}
+
+	// The channel should no longer be within the graph, but its
+	// information should be kept within the zombie index.
+	_, _, exists, err := graph.HasChannelEdge(edges[0].ChannelID)
+	if err != nil {
+		t.Fatalf("unable to query graph: %v", err)
+	}
+	if exists {
+		t.Fatalf("zombie channel still within graph")
+	}
+	zombie, err := graph.FetchZombieEdge(edges[0].ChannelID)
+	if err != nil {
+		t.Fatalf("unable to fetch zombie: %v", err)
+	}
+	if zombie.ChannelPoint != edges[0].ChannelPoint ||
+		!zombie.NodeKey1.IsEqual(edges[0].NodeKey1) ||
+		!zombie.NodeKey2.IsEqual(edges[0].NodeKey2) {
+
+		t.Fatalf("zombie doesn't match channel: %v vs %v",
+			spew.Sdump(zombie), spew.Sdump(edges[0]))
+	}
+
+	// As we'd ignore its announcement, the zombie channel should be
+	// considered known when filtering channel IDs.
+	newChanIDs, err := graph.FilterKnownChanIDs(
+		[]uint64{edges[0].ChannelID},
+	)
+	if err != nil {
+		t.Fatalf("unable to filter known chan IDs: %v", err)
+	}
+	if len(newChanIDs) != 0 {
+		t.Fatalf("expected zombie channel to be known, got new "+
+			"chan IDs %v", newChanIDs)
+	}
+
+	// Only the first node is now left without a channel, so it should be
+	// the only node pruned. The source node should be kept, even though
+	// it doesn't have any channels either.
+	prunedNodes, err = graph.PruneGraphNodes()
+	if err != nil {
+		t.Fatalf("unable to prune nodes: %v", err)
+	}
+	if len(prunedNodes) != 1 || !prunedNodes[0].IsEqual(nodes[0].PubKey) {
+		t.Fatalf("expected only the first node to be pruned, got %v "+
+			"nodes", len(prunedNodes))
+	}
+	_, exists, err = graph.HasLightningNode(nodes[0].PubKey)
+	if err != nil {
+		t.Fatalf("unable to query graph: %v", err)
+	}
+	if exists {
+		t.Fatalf("pruned node still within graph")
+	}
+	if _, err := graph.SourceNode(); err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	// Finally, once marked live, the channel should no longer be a
+	// zombie.
+	if err := graph.MarkEdgeLive(edges[0].ChannelID); err != nil {
+		t.Fatalf("unable to mark edge live: %v", err)
+	}
+	_, err = graph.FetchZombieEdge(edges[0].ChannelID)
+	if err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", err)
+	}
+}
+
 func assertPruneTip(t *testing.T, graph *ChannelGraph, blockHash *chainhash.Hash,
 	blockHeight uint32) {
 
@@ -0,0 +1,176 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// heldForwardBucket is the name of the bucket that stores the HTLCs
+	// currently held by the switch on behalf of the forward interceptor.
+	// Each held HTLC is keyed by the short channel ID of the channel it
+	// was received on, followed by its index within that channel.
+	heldForwardBucket = []byte("held-forwards")
+)
+
+// HeldForward is an HTLC received on one of our channels which the switch is
+// holding until the forward interceptor decides what to do with it. It's
+// persisted so the HTLC can be handed to the interceptor again if we restart
+// while it's held.
+type HeldForward struct {
+	// IncomingChanID is the channel the HTLC was received on.
+	IncomingChanID lnwire.ShortChannelID
+
+	// IncomingHtlcID is the index of the HTLC within the incoming
+	// channel.
+	IncomingHtlcID uint64
+
+	// IncomingAmt is the value of the HTLC within the incoming channel.
+	IncomingAmt lnwire.MilliSatoshi
+
+	// IncomingOnion is the onion blob of the HTLC within the incoming
+	// channel, from which the state needed to fail the HTLC back can be
+	// recovered.
+	IncomingOnion [lnwire.OnionPacketSize]byte
+
+	// OutgoingChanID is the channel the HTLC is requested to be forwarded
+	// over.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// Htlc is the HTLC to be forwarded over the outgoing channel.
+	Htlc *lnwire.UpdateAddHTLC
+}
+
+// heldForwardKey returns the key the held HTLC identified by the passed
+// channel and HTLC index is stored under.
+func heldForwardKey(chanID lnwire.ShortChannelID, htlcID uint64) []byte {
+	var key [16]byte
+	byteOrder.PutUint64(key[:8], chanID.ToUint64())
+	byteOrder.PutUint64(key[8:], htlcID)
+	return key[:]
+}
+
+// PutHeldForward persists the passed held HTLC, replacing any prior entry for
+// the same incoming HTLC.
+func (d *DB) PutHeldForward(fwd *HeldForward) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		fwdBucket, err := tx.CreateBucketIfNotExists(heldForwardBucket)
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializeHeldForward(&b, fwd); err != nil {
+			return err
+		}
+
+		key := heldForwardKey(fwd.IncomingChanID, fwd.IncomingHtlcID)
+		return fwdBucket.Put(key, b.Bytes())
+	})
+}
+
+// DeleteHeldForward removes the held HTLC identified by the passed channel and
+// HTLC index, as it's no longer held by the switch.
+func (d *DB) DeleteHeldForward(chanID lnwire.ShortChannelID,
+	htlcID uint64) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		fwdBucket := tx.Bucket(heldForwardBucket)
+		if fwdBucket == nil {
+			return nil
+		}
+
+		return fwdBucket.Delete(heldForwardKey(chanID, htlcID))
+	})
+}
+
+// FetchHeldForwards returns all HTLCs currently persisted as being held by the
+// switch.
+func (d *DB) FetchHeldForwards() ([]*HeldForward, error) {
+	var fwds []*HeldForward
+	err := d.View(func(tx *bolt.Tx) error {
+		fwdBucket := tx.Bucket(heldForwardBucket)
+		if fwdBucket == nil {
+			return nil
+		}
+
+		return fwdBucket.ForEach(func(_, v []byte) error {
+			fwd, err := deserializeHeldForward(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			fwds = append(fwds, fwd)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fwds, nil
+}
+
+func serializeHeldForward(w io.Writer, fwd *HeldForward) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], fwd.IncomingChanID.ToUint64())
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], fwd.IncomingHtlcID)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, fwd.IncomingAmt); err != nil {
+		return err
+	}
+	if _, err := w.Write(fwd.IncomingOnion[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], fwd.OutgoingChanID.ToUint64())
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	return fwd.Htlc.Encode(w, 0)
+}
+
+func deserializeHeldForward(r io.Reader) (*HeldForward, error) {
+	fwd := &HeldForward{}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	fwd.IncomingChanID = lnwire.NewShortChanIDFromInt(
+		byteOrder.Uint64(scratch[:]),
+	)
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	fwd.IncomingHtlcID = byteOrder.Uint64(scratch[:])
+	if err := binary.Read(r, byteOrder, &fwd.IncomingAmt); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, fwd.IncomingOnion[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	fwd.OutgoingChanID = lnwire.NewShortChanIDFromInt(
+		byteOrder.Uint64(scratch[:]),
+	)
+
+	fwd.Htlc = &lnwire.UpdateAddHTLC{}
+	if err := fwd.Htlc.Decode(r, 0); err != nil {
+		return nil, err
+	}
+
+	return fwd, nil
+}
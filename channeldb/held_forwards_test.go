@@ -0,0 +1,78 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestHeldForwards tests that we're able to persist the HTLCs held by the
+// switch, retrieve them again, and remove them once they're resolved.
+func TestHeldForwards(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// Before any HTLCs are held, we should get back an empty set.
+	fwds, err := cdb.FetchHeldForwards()
+	if err != nil {
+		t.Fatalf("unable to fetch held forwards: %v", err)
+	}
+	if len(fwds) != 0 {
+		t.Fatalf("expected no held forwards, got %v", len(fwds))
+	}
+
+	fwd := &HeldForward{
+		IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+		IncomingHtlcID: 2,
+		IncomingAmt:    100000,
+		IncomingOnion:  [lnwire.OnionPacketSize]byte{1, 2, 3},
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(3),
+		Htlc: &lnwire.UpdateAddHTLC{
+			Amount:      99000,
+			PaymentHash: key,
+			Expiry:      500,
+			OnionBlob:   [lnwire.OnionPacketSize]byte{4, 5, 6},
+		},
+	}
+
+	// Persisting the same HTLC twice should replace the prior entry
+	// rather than add a new one.
+	for i := 0; i < 2; i++ {
+		if err := cdb.PutHeldForward(fwd); err != nil {
+			t.Fatalf("unable to put held forward: %v", err)
+		}
+	}
+
+	fwds, err = cdb.FetchHeldForwards()
+	if err != nil {
+		t.Fatalf("unable to fetch held forwards: %v", err)
+	}
+	if len(fwds) != 1 {
+		t.Fatalf("expected 1 held forward, got %v", len(fwds))
+	}
+	if !reflect.DeepEqual(fwd, fwds[0]) {
+		t.Fatalf("held forwards don't match: expected %v got %v",
+			spew.Sdump(fwd), spew.Sdump(fwds[0]))
+	}
+
+	// Once the HTLC is resolved, it should no longer be returned.
+	err = cdb.DeleteHeldForward(fwd.IncomingChanID, fwd.IncomingHtlcID)
+	if err != nil {
+		t.Fatalf("unable to delete held forward: %v", err)
+	}
+
+	fwds, err = cdb.FetchHeldForwards()
+	if err != nil {
+		t.Fatalf("unable to fetch held forwards: %v", err)
+	}
+	if len(fwds) != 0 {
+		t.Fatalf("expected no held forwards, got %v", len(fwds))
+	}
+}
@@ -1 +1,65 @@
 package channeldb
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+)
+
+// migrateRemoteForceCloses is a migration function that will update the
+// database from version 0 to version 1. Before this version, force closes
+// initiated by the remote party were recorded with the same ForceClose type
+// as our own, so we'll re-classify each of those we're able to as
+// RemoteForceClose.
+//
+// When we force closed a channel, we recorded our balance as both settled and
+// time-locked, or neither if our output was dust and no HTLCs were active.
+// When the remote party did, we recorded our balance as settled, but never
+// any time-locked balance. As a result, a force close with a settled balance
+// but no time-locked balance can only have been initiated by the remote
+// party. A force close with neither balance is ambiguous, and remains
+// recorded as ForceClose.
+func migrateRemoteForceCloses(tx *bolt.Tx) error {
+	closedChanBucket := tx.Bucket(closedChannelBucket)
+	if closedChanBucket == nil {
+		return nil
+	}
+
+	// We'll first gather the summaries to be re-classified, as the bucket
+	// can't be modified while we're iterating over it.
+	remoteCloses := make(map[string]*ChannelCloseSummary)
+	err := closedChanBucket.ForEach(func(chanID, summaryBytes []byte) error {
+		summary, err := deserializeCloseChannelSummary(
+			bytes.NewReader(summaryBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		if summary.CloseType != ForceClose ||
+			summary.SettledBalance == 0 ||
+			summary.TimeLockedBalance != 0 {
+
+			return nil
+		}
+
+		summary.CloseType = RemoteForceClose
+		remoteCloses[string(chanID)] = summary
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for chanID, summary := range remoteCloses {
+		log.Infof("Marking force close of ChannelPoint(%v) as "+
+			"initiated by the remote party", summary.ChanPoint)
+
+		err := putChannelCloseSummary(tx, []byte(chanID), summary)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package channeldb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestMigrateRemoteForceCloses tests that force closes recorded before the
+// initiator was tracked are re-classified as remote force closes if they can
+// only have been initiated by the remote party, while all other closes are
+// left untouched.
+func TestMigrateRemoteForceCloses(t *testing.T) {
+	t.Parallel()
+
+	summaries := []*ChannelCloseSummary{
+		// A local force close, recording our balance as time-locked.
+		{
+			ChanPoint:         wire.OutPoint{Index: 0},
+			SettledBalance:    btcutil.Amount(1000),
+			TimeLockedBalance: btcutil.Amount(1000),
+			CloseType:         ForceClose,
+		},
+		// A remote force close, recording our balance as settled.
+		{
+			ChanPoint:      wire.OutPoint{Index: 1},
+			SettledBalance: btcutil.Amount(1000),
+			CloseType:      ForceClose,
+		},
+		// A force close in which we had no balance, which can't be
+		// attributed to either party.
+		{
+			ChanPoint: wire.OutPoint{Index: 2},
+			CloseType: ForceClose,
+		},
+		// A cooperative close, which should be left untouched.
+		{
+			ChanPoint:      wire.OutPoint{Index: 3},
+			SettledBalance: btcutil.Amount(1000),
+			CloseType:      CooperativeClose,
+		},
+	}
+	expectedTypes := []ClosureType{
+		ForceClose, RemoteForceClose, ForceClose, CooperativeClose,
+	}
+
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			for _, summary := range summaries {
+				summary.RemotePub = pubKey
+
+				var b bytes.Buffer
+				err := writeOutpoint(&b, &summary.ChanPoint)
+				if err != nil {
+					return err
+				}
+
+				err = putChannelCloseSummary(tx, b.Bytes(), summary)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to store close summaries: %v", err)
+		}
+	}
+
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		closed, err := d.FetchClosedChannels(false)
+		if err != nil {
+			t.Fatalf("unable to fetch closed channels: %v", err)
+		}
+		if len(closed) != len(summaries) {
+			t.Fatalf("expected %v closed channels, got %v",
+				len(summaries), len(closed))
+		}
+
+		for _, summary := range closed {
+			index := summary.ChanPoint.Index
+			if summary.CloseType != expectedTypes[index] {
+				t.Fatalf("expected close type %v for "+
+					"ChannelPoint(%v), got %v",
+					expectedTypes[index], summary.ChanPoint,
+					summary.CloseType)
+			}
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateRemoteForceCloses,
+		false)
+}
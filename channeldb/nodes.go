@@ -58,6 +58,23 @@ type LinkNode struct {
 	// TODO(roasbeef): also need to support hidden service addrs
 	Addresses []*net.TCPAddr
 
+	// FirstSeen is the first time we established a connection with this
+	// node. Nodes written by prior versions of the database will have
+	// this set to the zero time until their next connection.
+	FirstSeen time.Time
+
+	// ConnectionCount is the number of times we've established a
+	// connection with this node.
+	ConnectionCount uint32
+
+	// Uptime is the cumulative amount of time we've been connected to
+	// this node, excluding the current connection, if any.
+	Uptime time.Duration
+
+	// LastDisconnectReason describes why our last connection to the node
+	// was terminated.
+	LastDisconnectReason string
+
 	db *DB
 }
 
@@ -66,11 +83,13 @@ type LinkNode struct {
 func (db *DB) NewLinkNode(bitNet wire.BitcoinNet, pub *btcec.PublicKey,
 	addr *net.TCPAddr) *LinkNode {
 
+	now := time.Now()
 	return &LinkNode{
 		Network:     bitNet,
 		IdentityPub: pub,
-		LastSeen:    time.Now(),
+		LastSeen:    now,
 		Addresses:   []*net.TCPAddr{addr},
+		FirstSeen:   now,
 		db:          db,
 	}
 }
@@ -97,6 +116,31 @@ func (l *LinkNode) AddAddress(addr *net.TCPAddr) error {
 	return l.Sync()
 }
 
+// RecordConnection updates the connection statistics of this node to reflect
+// a new connection being established at the passed time.
+func (l *LinkNode) RecordConnection(connectTime time.Time) error {
+	if l.FirstSeen.IsZero() {
+		l.FirstSeen = connectTime
+	}
+	l.LastSeen = connectTime
+	l.ConnectionCount++
+
+	return l.Sync()
+}
+
+// RecordDisconnection updates the connection statistics of this node to
+// reflect the termination of a connection which lasted for the passed
+// duration, along with the reason the connection was terminated.
+func (l *LinkNode) RecordDisconnection(uptime time.Duration,
+	reason string) error {
+
+	l.LastSeen = time.Now()
+	l.Uptime += uptime
+	l.LastDisconnectReason = reason
+
+	return l.Sync()
+}
+
 // Sync performs a full database sync which writes the current up-to-date data
 // within the struct to the database.
 func (l *LinkNode) Sync() error {
@@ -163,6 +207,7 @@ func (db *DB) FetchLinkNode(identity *btcec.PublicKey) (*LinkNode, error) {
 	if err != nil {
 		return nil, err
 	}
+	node.db = db
 
 	return node, nil
 }
@@ -189,6 +234,7 @@ func (db *DB) FetchAllLinkNodes() ([]*LinkNode, error) {
 			if err != nil {
 				return err
 			}
+			linkNode.db = db
 
 			linkNodes = append(linkNodes, linkNode)
 			return nil
@@ -233,7 +279,28 @@ func serializeLinkNode(w io.Writer, l *LinkNode) error {
 		}
 	}
 
-	return nil
+	// A zero first seen time is written as a zero timestamp so that it
+	// can be recovered as such when decoding.
+	var firstSeenUnix uint64
+	if !l.FirstSeen.IsZero() {
+		firstSeenUnix = uint64(l.FirstSeen.Unix())
+	}
+	byteOrder.PutUint64(buf[:], firstSeenUnix)
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(buf[:4], l.ConnectionCount)
+	if _, err := w.Write(buf[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(buf[:], uint64(l.Uptime))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarString(w, 0, l.LastDisconnectReason)
 }
 
 func deserializeLinkNode(r io.Reader) (*LinkNode, error) {
@@ -282,5 +349,32 @@ func deserializeLinkNode(r io.Reader) (*LinkNode, error) {
 		node.Addresses[i] = addr
 	}
 
+	// The connection statistics were added after the initial version of
+	// the LinkNode encoding, so we'll tolerate their absence for nodes
+	// written by prior versions.
+	if _, err := io.ReadFull(r, buf[:]); err == io.EOF {
+		return node, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if firstSeenUnix := byteOrder.Uint64(buf[:]); firstSeenUnix != 0 {
+		node.FirstSeen = time.Unix(int64(firstSeenUnix), 0)
+	}
+
+	if _, err := io.ReadFull(r, buf[:4]); err != nil {
+		return nil, err
+	}
+	node.ConnectionCount = byteOrder.Uint32(buf[:4])
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	node.Uptime = time.Duration(byteOrder.Uint64(buf[:]))
+
+	node.LastDisconnectReason, err = wire.ReadVarString(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	return node, nil
 }
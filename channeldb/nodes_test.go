@@ -107,4 +107,37 @@ func TestLinkNodeEncodeDecode(t *testing.T) {
 		t.Fatalf("wrong address for node: expected %v, got %v",
 			addr2.String(), node1DB.Addresses[1].String())
 	}
+
+	// Finally, we'll record a connection to the node followed by its
+	// disconnection, and ensure the node's connection statistics are
+	// updated accordingly.
+	if err := node1DB.RecordConnection(time.Now()); err != nil {
+		t.Fatalf("unable to record connection: %v", err)
+	}
+	const reason = "read tcp: connection reset by peer"
+	uptime := time.Hour
+	if err := node1DB.RecordDisconnection(uptime, reason); err != nil {
+		t.Fatalf("unable to record disconnection: %v", err)
+	}
+
+	node1DB, err = cdb.FetchLinkNode(pub1)
+	if err != nil {
+		t.Fatalf("unable to find node: %v", err)
+	}
+	if node1DB.FirstSeen.Unix() != node1.FirstSeen.Unix() {
+		t.Fatalf("first seen timestamps don't match: expected %v got %v",
+			node1.FirstSeen.Unix(), node1DB.FirstSeen.Unix())
+	}
+	if node1DB.ConnectionCount != 1 {
+		t.Fatalf("wrong connection count: expected %v, got %v", 1,
+			node1DB.ConnectionCount)
+	}
+	if node1DB.Uptime != uptime {
+		t.Fatalf("wrong uptime: expected %v, got %v", uptime,
+			node1DB.Uptime)
+	}
+	if node1DB.LastDisconnectReason != reason {
+		t.Fatalf("wrong disconnect reason: expected %v, got %v",
+			reason, node1DB.LastDisconnectReason)
+	}
 }
@@ -0,0 +1,501 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// paymentControlBucket is the name of the bucket within the database
+	// that tracks the lifecycle of each outgoing payment, from the moment
+	// it's initiated until it either succeeds or fails.
+	//
+	// Within the bucket, each payment record is keyed by its payment
+	// hash, which prevents the same payment hash from being paid twice.
+	paymentControlBucket = []byte("payment-control")
+)
+
+const (
+	// maxFailureReasonSize is the maximum size of the failure reasons
+	// stored for payments and their attempts. Longer reasons are
+	// truncated.
+	maxFailureReasonSize = 1024
+)
+
+// PaymentStatus represents the current state of an outgoing payment.
+type PaymentStatus uint8
+
+const (
+	// StatusUnknown is the status of a payment which isn't known to the
+	// database.
+	StatusUnknown PaymentStatus = 0
+
+	// StatusInitiated is the status of a payment which has been initiated,
+	// but which currently has no HTLC in flight. This is the case before
+	// the first attempt is made, and in between attempts.
+	StatusInitiated PaymentStatus = 1
+
+	// StatusInFlight is the status of a payment which has an HTLC in
+	// flight, the outcome of which isn't known yet.
+	StatusInFlight PaymentStatus = 2
+
+	// StatusSucceeded is the status of a payment which has been settled
+	// by its recipient.
+	StatusSucceeded PaymentStatus = 3
+
+	// StatusFailed is the status of a payment which has been abandoned
+	// after failing to reach its recipient.
+	StatusFailed PaymentStatus = 4
+)
+
+// String returns a human readable version of the payment status.
+func (s PaymentStatus) String() string {
+	switch s {
+	case StatusInitiated:
+		return "Initiated"
+	case StatusInFlight:
+		return "In Flight"
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// PaymentAttempt describes a single HTLC sent out in an attempt to complete a
+// payment, along with its failure if it didn't succeed.
+type PaymentAttempt struct {
+	// SessionKey is the ephemeral key used to construct the onion packet
+	// of the HTLC. It's needed to decrypt any failure sent back for the
+	// HTLC.
+	SessionKey *btcec.PrivateKey
+
+	// Path is the compressed public key of each node the HTLC was routed
+	// through, excluding our own node.
+	Path [][33]byte
+
+	// Amount is the value of the HTLC, including the fees paid to each
+	// of the intermediate nodes.
+	Amount lnwire.MilliSatoshi
+
+	// TimeLock is the absolute expiry height of the HTLC.
+	TimeLock uint32
+
+	// Failure is the reason the attempt failed. It's empty if the attempt
+	// succeeded, or its outcome isn't known yet.
+	Failure string
+}
+
+// PaymentRecord tracks the lifecycle of an outgoing payment, along with every
+// attempt made to complete it.
+type PaymentRecord struct {
+	// PaymentHash is the payment hash the payment is sent to.
+	PaymentHash [32]byte
+
+	// Value is the amount to be delivered to the recipient, excluding
+	// fees.
+	Value lnwire.MilliSatoshi
+
+	// CreationDate is the time the payment was initiated.
+	CreationDate time.Time
+
+	// Status is the current state of the payment.
+	Status PaymentStatus
+
+	// Attempts is the set of attempts made to complete the payment, in
+	// the order they were made.
+	Attempts []*PaymentAttempt
+
+	// Preimage is the preimage of the payment hash, which is only known
+	// once the payment has succeeded.
+	Preimage [32]byte
+
+	// FailureReason is the reason the payment was abandoned, which is
+	// only set once the payment has failed.
+	FailureReason string
+}
+
+// InitPayment records the intent to pay the target payment hash. An error is
+// returned if the payment hash has already been paid, or is currently being
+// paid. If a prior payment to the same hash has failed, then its record is
+// replaced, allowing the payment to be retried.
+func (db *DB) InitPayment(paymentHash [32]byte,
+	value lnwire.MilliSatoshi) error {
+
+	record := &PaymentRecord{
+		PaymentHash: paymentHash,
+		Value:       value,
+		// Use single second precision, as the monotonic component of
+		// the time isn't serialized.
+		CreationDate: time.Unix(time.Now().Unix(), 0),
+		Status:       StatusInitiated,
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		payments, err := tx.CreateBucketIfNotExists(
+			paymentControlBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		existing, err := fetchPaymentRecord(payments, paymentHash)
+		switch {
+		case err == ErrPaymentNotFound:
+		case err != nil:
+			return err
+
+		case existing.Status == StatusSucceeded:
+			return ErrAlreadyPaid
+
+		case existing.Status != StatusFailed:
+			return ErrPaymentInFlight
+		}
+
+		return putPaymentRecord(payments, record)
+	})
+}
+
+// RegisterAttempt records that an HTLC has been sent out in an attempt to
+// complete the target payment, which moves the payment into the in flight
+// state. Only a single attempt may be in flight at a time.
+func (db *DB) RegisterAttempt(paymentHash [32]byte,
+	attempt *PaymentAttempt) error {
+
+	return db.updatePayment(paymentHash, func(r *PaymentRecord) error {
+		if r.Status != StatusInitiated {
+			return ErrPaymentNotInitiated
+		}
+
+		r.Attempts = append(r.Attempts, attempt)
+		r.Status = StatusInFlight
+
+		return nil
+	})
+}
+
+// FailAttempt records the failure of the attempt which is currently in
+// flight for the target payment. The payment is moved back into the initiated
+// state, so another attempt can be made.
+func (db *DB) FailAttempt(paymentHash [32]byte, failure string) error {
+	return db.updatePayment(paymentHash, func(r *PaymentRecord) error {
+		if r.Status != StatusInFlight {
+			return ErrPaymentNotInitiated
+		}
+
+		r.Attempts[len(r.Attempts)-1].Failure = truncateReason(failure)
+		r.Status = StatusInitiated
+
+		return nil
+	})
+}
+
+// SucceedPayment marks the target payment as succeeded, storing the preimage
+// received for the attempt which is currently in flight.
+func (db *DB) SucceedPayment(paymentHash, preimage [32]byte) error {
+	return db.updatePayment(paymentHash, func(r *PaymentRecord) error {
+		if r.Status != StatusInFlight {
+			return ErrPaymentNotInitiated
+		}
+
+		r.Preimage = preimage
+		r.Status = StatusSucceeded
+
+		return nil
+	})
+}
+
+// FailPayment marks the target payment as failed, after which no more
+// attempts will be made to complete it. A payment can't be failed while it
+// has an attempt in flight. The payment may be initiated again later on.
+func (db *DB) FailPayment(paymentHash [32]byte, reason string) error {
+	return db.updatePayment(paymentHash, func(r *PaymentRecord) error {
+		if r.Status != StatusInitiated {
+			return ErrPaymentNotInitiated
+		}
+
+		r.FailureReason = truncateReason(reason)
+		r.Status = StatusFailed
+
+		return nil
+	})
+}
+
+// FetchPaymentRecord returns the record of the payment to the target payment
+// hash. If no such payment is known, then ErrPaymentNotFound is returned.
+func (db *DB) FetchPaymentRecord(paymentHash [32]byte) (*PaymentRecord, error) {
+	var record *PaymentRecord
+	err := db.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+
+		var err error
+		record, err = fetchPaymentRecord(payments, paymentHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FetchAllPaymentRecords returns the records of all payments known to the
+// database, regardless of their status.
+func (db *DB) FetchAllPaymentRecords() ([]*PaymentRecord, error) {
+	var records []*PaymentRecord
+	err := db.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return nil
+		}
+
+		return payments.ForEach(func(k, v []byte) error {
+			record, err := deserializePaymentRecord(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// updatePayment applies the passed modification to the record of the target
+// payment within a single database transaction.
+func (db *DB) updatePayment(paymentHash [32]byte,
+	modify func(*PaymentRecord) error) error {
+
+	return db.Update(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+
+		record, err := fetchPaymentRecord(payments, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		if err := modify(record); err != nil {
+			return err
+		}
+
+		return putPaymentRecord(payments, record)
+	})
+}
+
+// truncateReason truncates the passed failure reason, so that it doesn't
+// exceed the maximum size allowed within the database.
+func truncateReason(reason string) string {
+	if len(reason) > maxFailureReasonSize {
+		return reason[:maxFailureReasonSize]
+	}
+
+	return reason
+}
+
+func fetchPaymentRecord(payments *bolt.Bucket,
+	paymentHash [32]byte) (*PaymentRecord, error) {
+
+	recordBytes := payments.Get(paymentHash[:])
+	if recordBytes == nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	return deserializePaymentRecord(bytes.NewReader(recordBytes))
+}
+
+func putPaymentRecord(payments *bolt.Bucket, record *PaymentRecord) error {
+	var b bytes.Buffer
+	if err := serializePaymentRecord(&b, record); err != nil {
+		return err
+	}
+
+	return payments.Put(record.PaymentHash[:], b.Bytes())
+}
+
+func serializePaymentRecord(w io.Writer, r *PaymentRecord) error {
+	var scratch [8]byte
+
+	if _, err := w.Write(r.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(r.Value))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	birthBytes, err := r.CreationDate.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(w, 0, birthBytes); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{byte(r.Status)}); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(r.Attempts)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, attempt := range r.Attempts {
+		if err := serializePaymentAttempt(w, attempt); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(r.Preimage[:]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, []byte(r.FailureReason))
+}
+
+func deserializePaymentRecord(r io.Reader) (*PaymentRecord, error) {
+	var scratch [8]byte
+
+	record := &PaymentRecord{}
+
+	if _, err := io.ReadFull(r, record.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	record.Value = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	birthBytes, err := wire.ReadVarBytes(r, 0, 300, "birth")
+	if err != nil {
+		return nil, err
+	}
+	if err := record.CreationDate.UnmarshalBinary(birthBytes); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	record.Status = PaymentStatus(scratch[0])
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	numAttempts := byteOrder.Uint32(scratch[:4])
+	for i := uint32(0); i < numAttempts; i++ {
+		attempt, err := deserializePaymentAttempt(r)
+		if err != nil {
+			return nil, err
+		}
+		record.Attempts = append(record.Attempts, attempt)
+	}
+
+	if _, err := io.ReadFull(r, record.Preimage[:]); err != nil {
+		return nil, err
+	}
+
+	reason, err := wire.ReadVarBytes(r, 0, maxFailureReasonSize,
+		"reason")
+	if err != nil {
+		return nil, err
+	}
+	record.FailureReason = string(reason)
+
+	return record, nil
+}
+
+func serializePaymentAttempt(w io.Writer, a *PaymentAttempt) error {
+	var scratch [8]byte
+
+	if _, err := w.Write(a.SessionKey.Serialize()); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(a.Path)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, hop := range a.Path {
+		if _, err := w.Write(hop[:]); err != nil {
+			return err
+		}
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(a.Amount))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], a.TimeLock)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, []byte(a.Failure))
+}
+
+func deserializePaymentAttempt(r io.Reader) (*PaymentAttempt, error) {
+	var scratch [8]byte
+
+	a := &PaymentAttempt{}
+
+	var keyBytes [btcec.PrivKeyBytesLen]byte
+	if _, err := io.ReadFull(r, keyBytes[:]); err != nil {
+		return nil, err
+	}
+	a.SessionKey, _ = btcec.PrivKeyFromBytes(btcec.S256(), keyBytes[:])
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	pathLen := byteOrder.Uint32(scratch[:4])
+
+	a.Path = make([][33]byte, pathLen)
+	for i := uint32(0); i < pathLen; i++ {
+		if _, err := io.ReadFull(r, a.Path[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	a.Amount = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	a.TimeLock = byteOrder.Uint32(scratch[:4])
+
+	failure, err := wire.ReadVarBytes(r, 0, maxFailureReasonSize,
+		"failure")
+	if err != nil {
+		return nil, err
+	}
+	a.Failure = string(failure)
+
+	return a, nil
+}
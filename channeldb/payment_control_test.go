@@ -0,0 +1,180 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+func makeFakeAttempt(t *testing.T) *PaymentAttempt {
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+
+	fakePath := make([][33]byte, 3)
+	for i := 0; i < 3; i++ {
+		copy(fakePath[i][:], bytes.Repeat([]byte{byte(i)}, 33))
+	}
+
+	return &PaymentAttempt{
+		SessionKey: sessionKey,
+		Path:       fakePath,
+		Amount:     10101,
+		TimeLock:   1000,
+	}
+}
+
+func TestPaymentRecordSerialization(t *testing.T) {
+	t.Parallel()
+
+	record := &PaymentRecord{
+		PaymentHash:   sha256.Sum256(rev[:]),
+		Value:         lnwire.NewMSatFromSatoshis(10),
+		Status:        StatusFailed,
+		Attempts:      []*PaymentAttempt{makeFakeAttempt(t)},
+		FailureReason: "no route",
+	}
+	record.Attempts[0].Failure = "temporary channel failure"
+
+	var b bytes.Buffer
+	if err := serializePaymentRecord(&b, record); err != nil {
+		t.Fatalf("unable to serialize payment record: %v", err)
+	}
+
+	newRecord, err := deserializePaymentRecord(&b)
+	if err != nil {
+		t.Fatalf("unable to deserialize payment record: %v", err)
+	}
+
+	if !reflect.DeepEqual(record, newRecord) {
+		t.Fatalf("records do not match after "+
+			"serialization/deserialization %v vs %v",
+			spew.Sdump(record), spew.Sdump(newRecord))
+	}
+}
+
+// TestPaymentControlWorkflow checks that a payment moves through its
+// lifecycle as expected, and that a payment hash can't be paid twice.
+func TestPaymentControlWorkflow(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	paymentHash := sha256.Sum256(rev[:])
+	value := lnwire.NewMSatFromSatoshis(10)
+
+	// An attempt can't be registered before the payment is initiated.
+	attempt := makeFakeAttempt(t)
+	err = db.RegisterAttempt(paymentHash, attempt)
+	if err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+
+	if err := db.InitPayment(paymentHash, value); err != nil {
+		t.Fatalf("unable to initiate payment: %v", err)
+	}
+	assertPaymentStatus(t, db, paymentHash, StatusInitiated)
+
+	// The payment is already being paid, so it can't be initiated again.
+	if err := db.InitPayment(paymentHash, value); err != ErrPaymentInFlight {
+		t.Fatalf("expected ErrPaymentInFlight, got %v", err)
+	}
+
+	// Register an attempt and fail it, after which the payment should be
+	// ready for another attempt.
+	if err := db.RegisterAttempt(paymentHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	assertPaymentStatus(t, db, paymentHash, StatusInFlight)
+
+	if err := db.InitPayment(paymentHash, value); err != ErrPaymentInFlight {
+		t.Fatalf("expected ErrPaymentInFlight, got %v", err)
+	}
+
+	if err := db.FailAttempt(paymentHash, "failed"); err != nil {
+		t.Fatalf("unable to fail attempt: %v", err)
+	}
+	assertPaymentStatus(t, db, paymentHash, StatusInitiated)
+
+	// Fail the payment itself, after which it may be initiated again.
+	if err := db.FailPayment(paymentHash, "no route"); err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	record := assertPaymentStatus(t, db, paymentHash, StatusFailed)
+	if record.FailureReason != "no route" {
+		t.Fatalf("wrong failure reason: %v", record.FailureReason)
+	}
+	if len(record.Attempts) != 1 || record.Attempts[0].Failure != "failed" {
+		t.Fatalf("wrong attempts: %v", spew.Sdump(record.Attempts))
+	}
+
+	if err := db.InitPayment(paymentHash, value); err != nil {
+		t.Fatalf("unable to initiate payment: %v", err)
+	}
+	record = assertPaymentStatus(t, db, paymentHash, StatusInitiated)
+	if len(record.Attempts) != 0 {
+		t.Fatalf("expected no attempts, got %v", len(record.Attempts))
+	}
+
+	// Finally, register an attempt which succeeds. The payment can't be
+	// initiated again from here on.
+	if err := db.RegisterAttempt(paymentHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	if err := db.SucceedPayment(paymentHash, rev); err != nil {
+		t.Fatalf("unable to succeed payment: %v", err)
+	}
+	record = assertPaymentStatus(t, db, paymentHash, StatusSucceeded)
+	if record.Preimage != rev {
+		t.Fatalf("wrong preimage: %x", record.Preimage[:])
+	}
+
+	if err := db.InitPayment(paymentHash, value); err != ErrAlreadyPaid {
+		t.Fatalf("expected ErrAlreadyPaid, got %v", err)
+	}
+	err = db.RegisterAttempt(paymentHash, attempt)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	records, err := db.FetchAllPaymentRecords()
+	if err != nil {
+		t.Fatalf("unable to fetch payment records: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %v", len(records))
+	}
+
+	// Deleting all payments should remove the finalized record.
+	if err := db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+	_, err = db.FetchPaymentRecord(paymentHash)
+	if err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+}
+
+func assertPaymentStatus(t *testing.T, db *DB, paymentHash [32]byte,
+	status PaymentStatus) *PaymentRecord {
+
+	record, err := db.FetchPaymentRecord(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment record: %v", err)
+	}
+	if record.Status != status {
+		t.Fatalf("expected status %v, got %v", status, record.Status)
+	}
+
+	return record
+}
@@ -118,7 +118,9 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	return payments, nil
 }
 
-// DeleteAllPayments deletes all payments from DB.
+// DeleteAllPayments deletes all payments from DB. The records of payments
+// which are still being paid are kept, as they're needed to track their
+// outcome.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
 		err := tx.DeleteBucket(paymentBucket)
@@ -127,7 +129,44 @@ func (db *DB) DeleteAllPayments() error {
 		}
 
 		_, err = tx.CreateBucket(paymentBucket)
-		return err
+		if err != nil {
+			return err
+		}
+
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return nil
+		}
+
+		// Deleting keys while iterating over a bucket isn't safe, so
+		// we'll first gather the payments which have been finalized.
+		var finalized [][]byte
+		err = payments.ForEach(func(k, v []byte) error {
+			record, err := deserializePaymentRecord(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			switch record.Status {
+			case StatusSucceeded, StatusFailed:
+				finalized = append(finalized, k)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range finalized {
+			if err := payments.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -0,0 +1,238 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// closeResolutionBucket is the name of the bucket that stores the
+	// on-chain resolution of the outputs of all channels that have been
+	// closed. Within this bucket, we'll create a sub-bucket for each
+	// channel keyed by its channel point, which in turn stores each
+	// output's resolution keyed by the output's outpoint.
+	closeResolutionBucket = []byte("close-resolutions")
+)
+
+// ResolutionType denotes which kind of output of a closed channel a
+// resolution refers to.
+type ResolutionType uint8
+
+const (
+	// ResolutionCommit is our settled output on a commitment transaction.
+	ResolutionCommit ResolutionType = iota
+
+	// ResolutionAnchor is our anchor output on a commitment transaction.
+	ResolutionAnchor
+
+	// ResolutionIncomingHtlc is an HTLC that was extended to us.
+	ResolutionIncomingHtlc
+
+	// ResolutionOutgoingHtlc is an HTLC that we extended to the remote
+	// party.
+	ResolutionOutgoingHtlc
+
+	// ResolutionRevoked is an output of a revoked commitment transaction
+	// that we're entitled to claim as justice.
+	ResolutionRevoked
+)
+
+// String returns a human readable version of the resolution type.
+func (r ResolutionType) String() string {
+	switch r {
+	case ResolutionCommit:
+		return "Commit"
+	case ResolutionAnchor:
+		return "Anchor"
+	case ResolutionIncomingHtlc:
+		return "IncomingHtlc"
+	case ResolutionOutgoingHtlc:
+		return "OutgoingHtlc"
+	case ResolutionRevoked:
+		return "Revoked"
+	default:
+		return "Unknown"
+	}
+}
+
+// ResolutionOutcome denotes the current state of an output's on-chain
+// resolution.
+type ResolutionOutcome uint8
+
+const (
+	// ResolutionPending indicates that the output hasn't yet been resolved
+	// on-chain.
+	ResolutionPending ResolutionOutcome = iota
+
+	// ResolutionClaimed indicates that we swept the output back into our
+	// wallet.
+	ResolutionClaimed
+
+	// ResolutionLost indicates that the output was spent by the remote
+	// party.
+	ResolutionLost
+)
+
+// String returns a human readable version of the resolution outcome.
+func (r ResolutionOutcome) String() string {
+	switch r {
+	case ResolutionPending:
+		return "Pending"
+	case ResolutionClaimed:
+		return "Claimed"
+	case ResolutionLost:
+		return "Lost"
+	default:
+		return "Unknown"
+	}
+}
+
+// OutputResolution records how a single output of a closed channel was
+// resolved on-chain.
+type OutputResolution struct {
+	// OutPoint is the outpoint of the output being resolved.
+	OutPoint wire.OutPoint
+
+	// Type is the kind of output being resolved.
+	Type ResolutionType
+
+	// Outcome is the current state of the output's resolution.
+	Outcome ResolutionOutcome
+
+	// Amount is the value of the output.
+	Amount btcutil.Amount
+
+	// SweepTxid is the txid of the transaction that spent the output. This
+	// will be zero while the resolution is still pending.
+	SweepTxid chainhash.Hash
+}
+
+// PutCloseResolution adds or updates the resolution of an output belonging to
+// the closed channel identified by chanPoint. Resolutions are keyed by the
+// output's outpoint, so writing a resolution for an output that was
+// previously recorded will overwrite its prior state.
+func (d *DB) PutCloseResolution(chanPoint *wire.OutPoint,
+	res *OutputResolution) error {
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		resBucket, err := tx.CreateBucketIfNotExists(
+			closeResolutionBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var chanKey bytes.Buffer
+		if err := writeOutpoint(&chanKey, chanPoint); err != nil {
+			return err
+		}
+		chanBucket, err := resBucket.CreateBucketIfNotExists(
+			chanKey.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+
+		var outKey bytes.Buffer
+		if err := writeOutpoint(&outKey, &res.OutPoint); err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializeOutputResolution(&b, res); err != nil {
+			return err
+		}
+
+		return chanBucket.Put(outKey.Bytes(), b.Bytes())
+	})
+}
+
+// FetchCloseResolutions returns all the output resolutions recorded for the
+// closed channel identified by chanPoint. If no resolutions have been
+// recorded, then an empty slice is returned.
+func (d *DB) FetchCloseResolutions(
+	chanPoint *wire.OutPoint) ([]*OutputResolution, error) {
+
+	var resolutions []*OutputResolution
+	err := d.View(func(tx *bolt.Tx) error {
+		resBucket := tx.Bucket(closeResolutionBucket)
+		if resBucket == nil {
+			return nil
+		}
+
+		var chanKey bytes.Buffer
+		if err := writeOutpoint(&chanKey, chanPoint); err != nil {
+			return err
+		}
+		chanBucket := resBucket.Bucket(chanKey.Bytes())
+		if chanBucket == nil {
+			return nil
+		}
+
+		return chanBucket.ForEach(func(_, v []byte) error {
+			res, err := deserializeOutputResolution(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			resolutions = append(resolutions, res)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resolutions, nil
+}
+
+func serializeOutputResolution(w io.Writer, res *OutputResolution) error {
+	if err := writeOutpoint(w, &res.OutPoint); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte{byte(res.Type), byte(res.Outcome)})
+	if err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, res.Amount); err != nil {
+		return err
+	}
+
+	_, err = w.Write(res.SweepTxid[:])
+	return err
+}
+
+func deserializeOutputResolution(r io.Reader) (*OutputResolution, error) {
+	res := &OutputResolution{}
+
+	if err := readOutpoint(r, &res.OutPoint); err != nil {
+		return nil, err
+	}
+
+	var kind [2]byte
+	if _, err := io.ReadFull(r, kind[:]); err != nil {
+		return nil, err
+	}
+	res.Type = ResolutionType(kind[0])
+	res.Outcome = ResolutionOutcome(kind[1])
+
+	if err := binary.Read(r, byteOrder, &res.Amount); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, res.SweepTxid[:]); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
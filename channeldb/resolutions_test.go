@@ -0,0 +1,63 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestCloseResolutions tests that we're able to record the resolution of the
+// outputs of a closed channel, update them as they progress, and retrieve
+// them again.
+func TestCloseResolutions(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// Before any resolutions are recorded, we should get back an empty
+	// set.
+	resolutions, err := cdb.FetchCloseResolutions(testOutpoint)
+	if err != nil {
+		t.Fatalf("unable to fetch resolutions: %v", err)
+	}
+	if len(resolutions) != 0 {
+		t.Fatalf("expected no resolutions, got %v", len(resolutions))
+	}
+
+	// We'll record a pending resolution for our commitment output.
+	res := &OutputResolution{
+		OutPoint: wire.OutPoint{Hash: rev, Index: 1},
+		Type:     ResolutionCommit,
+		Outcome:  ResolutionPending,
+		Amount:   50000,
+	}
+	if err := cdb.PutCloseResolution(testOutpoint, res); err != nil {
+		t.Fatalf("unable to put resolution: %v", err)
+	}
+
+	// Once the output is swept, we'll update its resolution. This should
+	// replace the prior entry rather than add a new one.
+	res.Outcome = ResolutionClaimed
+	res.SweepTxid = key
+	if err := cdb.PutCloseResolution(testOutpoint, res); err != nil {
+		t.Fatalf("unable to put resolution: %v", err)
+	}
+
+	resolutions, err = cdb.FetchCloseResolutions(testOutpoint)
+	if err != nil {
+		t.Fatalf("unable to fetch resolutions: %v", err)
+	}
+	if len(resolutions) != 1 {
+		t.Fatalf("expected 1 resolution, got %v", len(resolutions))
+	}
+	if !reflect.DeepEqual(res, resolutions[0]) {
+		t.Fatalf("resolutions don't match: expected %v got %v",
+			spew.Sdump(res), spew.Sdump(resolutions[0]))
+	}
+}
@@ -0,0 +1,96 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+// TestWitnessCacheRetrieval tests that we're able to add and lookup new
+// witnesses to the witness cache.
+func TestWitnessCacheRetrieval(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	wCache := cdb.NewWitnessCache()
+
+	// We'll be attempting to add then lookup a simple hash witness
+	// within this test.
+	witness := rev[:]
+	witnessKey := sha256.Sum256(witness)
+
+	// First, we'll attempt to add the witness to the database.
+	err = wCache.AddWitness(Sha256HashWitness, witness)
+	if err != nil {
+		t.Fatalf("unable to add witness: %v", err)
+	}
+
+	// With the witness stored, we'll now attempt to look it up. We should
+	// get back the *exact* same witness as we originally stored.
+	dbWitness, err := wCache.LookupWitness(Sha256HashWitness, witnessKey[:])
+	if err != nil {
+		t.Fatalf("unable to look up witness: %v", err)
+	}
+
+	if !bytes.Equal(witness, dbWitness[:]) {
+		t.Fatalf("witnesses don't match: expected %x, got %x",
+			witness[:], dbWitness[:])
+	}
+}
+
+// TestWitnessCacheDeletion tests that we're able to delete a single witness,
+// and that a subsequent look up fails.
+func TestWitnessCacheDeletion(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	wCache := cdb.NewWitnessCache()
+
+	witness := rev[:]
+	witnessKey := sha256.Sum256(witness)
+	if err := wCache.AddWitness(Sha256HashWitness, witness); err != nil {
+		t.Fatalf("unable to add witness: %v", err)
+	}
+
+	// Once the witness has been deleted, we should no longer be able to
+	// find it within the cache.
+	err = wCache.DeleteWitness(Sha256HashWitness, witnessKey[:])
+	if err != nil {
+		t.Fatalf("unable to delete witness: %v", err)
+	}
+	_, err = wCache.LookupWitness(Sha256HashWitness, witnessKey[:])
+	if err != ErrNoWitnesses {
+		t.Fatalf("expected ErrNoWitnesses instead got: %v", err)
+	}
+}
+
+// TestWitnessCacheUnknownWitness tests that we get an error if we attempt to
+// query/add/delete an unknown witness.
+func TestWitnessCacheUnknownWitness(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	wCache := cdb.NewWitnessCache()
+
+	// We'll attempt to add a new, undefined witness type to the database.
+	// We should get an error.
+	err = wCache.AddWitness(234, rev[:])
+	if err != ErrUnknownWitnessType {
+		t.Fatalf("expected ErrUnknownWitnessType, got %v", err)
+	}
+}
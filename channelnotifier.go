@@ -0,0 +1,199 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// channelEventType denotes the kind of change to the state of a channel that a
+// channelEvent describes.
+type channelEventType uint8
+
+const (
+	// pendingOpenChannelEvent is sent once the funding flow of a channel
+	// has completed, and we're waiting for the funding transaction to
+	// confirm.
+	pendingOpenChannelEvent channelEventType = iota
+
+	// openChannelEvent is sent once the funding transaction of a channel
+	// has confirmed, and the channel has been marked as open.
+	openChannelEvent
+
+	// activeChannelEvent is sent once the link of a channel has been added
+	// to the switch, allowing HTLCs to be forwarded over the channel.
+	activeChannelEvent
+
+	// inactiveChannelEvent is sent once the link of a channel has been
+	// removed from the switch.
+	inactiveChannelEvent
+
+	// closingChannelEvent is sent once we've begun to close a channel, or
+	// have detected that the remote party has closed it on-chain.
+	closingChannelEvent
+
+	// closedChannelEvent is sent once a channel has been fully closed and
+	// all of its funds have been resolved.
+	closedChannelEvent
+)
+
+// channelEvent describes a change to the state of one of our channels. If the
+// channel is still open, then the channel field will be populated with its
+// current state, otherwise closeSummary will be.
+type channelEvent struct {
+	eventType channelEventType
+
+	chanPoint wire.OutPoint
+
+	channel *channeldb.OpenChannel
+
+	closeSummary *channeldb.ChannelCloseSummary
+}
+
+// channelNotifier dispatches notifications to all registered clients each
+// time one of our channels changes state, allowing them to track channels
+// through their entire lifecycle without polling.
+type channelNotifier struct {
+	cdb *channeldb.DB
+
+	clients *subscriptionServer
+
+	// closing is the set of channels we've already sent a closing event
+	// for. A channel may be reported as closing by several subsystems, so
+	// this ensures clients only receive a single closing event for each
+	// channel.
+	closingMtx sync.Mutex
+	closing    map[wire.OutPoint]struct{}
+}
+
+// newChannelNotifier creates a new channel notifier, which will look up the
+// channels referenced by each event within the passed database.
+func newChannelNotifier(cdb *channeldb.DB) *channelNotifier {
+	return &channelNotifier{
+		cdb:     cdb,
+		clients: newSubscriptionServer(),
+		closing: make(map[wire.OutPoint]struct{}),
+	}
+}
+
+// SubscribeChannelEvents returns an eventSubscription which allows the caller
+// to receive async notifications each time one of our channels changes state.
+// Each event is sent as a *channelEvent.
+func (c *channelNotifier) SubscribeChannelEvents() *eventSubscription {
+	return c.clients.subscribe()
+}
+
+// NotifyPendingOpenChannelEvent notifies all clients that the funding flow of
+// the passed channel has completed, and the channel is now pending open.
+func (c *channelNotifier) NotifyPendingOpenChannelEvent(
+	channel *channeldb.OpenChannel) {
+
+	c.clients.notifyClients(&channelEvent{
+		eventType: pendingOpenChannelEvent,
+		chanPoint: channel.FundingOutpoint,
+		channel:   channel,
+	})
+}
+
+// NotifyOpenChannelEvent notifies all clients that the funding transaction of
+// the passed channel has confirmed, and the channel is now open.
+func (c *channelNotifier) NotifyOpenChannelEvent(
+	channel *channeldb.OpenChannel) {
+
+	c.clients.notifyClients(&channelEvent{
+		eventType: openChannelEvent,
+		chanPoint: channel.FundingOutpoint,
+		channel:   channel,
+	})
+}
+
+// NotifyActiveChannelEvent notifies all clients that the link of the target
+// channel has been added to the switch.
+func (c *channelNotifier) NotifyActiveChannelEvent(chanID lnwire.ChannelID) {
+	c.notifyLinkEvent(activeChannelEvent, chanID)
+}
+
+// NotifyInactiveChannelEvent notifies all clients that the link of the target
+// channel has been removed from the switch.
+func (c *channelNotifier) NotifyInactiveChannelEvent(chanID lnwire.ChannelID) {
+	c.notifyLinkEvent(inactiveChannelEvent, chanID)
+}
+
+// NotifyClosingChannelEvent notifies all clients that the target channel is
+// in the process of being closed. Only the first call for each channel
+// results in an event being sent.
+func (c *channelNotifier) NotifyClosingChannelEvent(chanPoint wire.OutPoint) {
+	c.closingMtx.Lock()
+	if _, ok := c.closing[chanPoint]; ok {
+		c.closingMtx.Unlock()
+		return
+	}
+	c.closing[chanPoint] = struct{}{}
+	c.closingMtx.Unlock()
+
+	chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+	c.notifyLinkEvent(closingChannelEvent, chanID)
+}
+
+// NotifyClosedChannelEvent notifies all clients that the target channel has
+// been fully closed.
+func (c *channelNotifier) NotifyClosedChannelEvent(chanPoint wire.OutPoint) {
+	c.closingMtx.Lock()
+	delete(c.closing, chanPoint)
+	c.closingMtx.Unlock()
+
+	chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+	c.notifyLinkEvent(closedChannelEvent, chanID)
+}
+
+// notifyLinkEvent looks up the current state of the channel identified by the
+// passed channel ID, then notifies all clients of the event. As the channel
+// may have been closed by the time the event is dispatched, the channel is
+// searched for amongst both our open and closed channels.
+func (c *channelNotifier) notifyLinkEvent(eventType channelEventType,
+	chanID lnwire.ChannelID) {
+
+	if !c.clients.hasClients() {
+		return
+	}
+
+	openChannels, err := c.cdb.FetchAllChannels()
+	if err != nil {
+		ltndLog.Errorf("unable to fetch open channels: %v", err)
+		return
+	}
+	for _, channel := range openChannels {
+		if !chanID.IsChanPoint(&channel.FundingOutpoint) {
+			continue
+		}
+
+		c.clients.notifyClients(&channelEvent{
+			eventType: eventType,
+			chanPoint: channel.FundingOutpoint,
+			channel:   channel,
+		})
+		return
+	}
+
+	closedChannels, err := c.cdb.FetchClosedChannels(false)
+	if err != nil && err != channeldb.ErrNoClosedChannels {
+		ltndLog.Errorf("unable to fetch closed channels: %v", err)
+		return
+	}
+	for _, summary := range closedChannels {
+		if !chanID.IsChanPoint(&summary.ChanPoint) {
+			continue
+		}
+
+		c.clients.notifyClients(&channelEvent{
+			eventType:    eventType,
+			chanPoint:    summary.ChanPoint,
+			closeSummary: summary,
+		})
+		return
+	}
+
+	ltndLog.Warnf("unable to find ChannelID(%v) for channel event", chanID)
+}
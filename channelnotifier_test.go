@@ -0,0 +1,119 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestChannelNotifierEventOrder tests that a client receives the events of a
+// channel throughout its lifecycle in the order they were dispatched, and
+// that each event references the current state of the channel.
+func TestChannelNotifierEventOrder(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	p, _, _, cleanUp, err := createTestPeer(notifier, nil)
+	if err != nil {
+		t.Fatalf("unable to create test peer: %v", err)
+	}
+	defer cleanUp()
+
+	chanDB := p.server.chanDB
+	channels, err := chanDB.FetchAllChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch channels: %v", err)
+	}
+	if len(channels) != 1 {
+		t.Fatalf("expected 1 channel, got %v", len(channels))
+	}
+	channel := channels[0]
+	chanPoint := channel.FundingOutpoint
+	chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+
+	chanNotifier := newChannelNotifier(chanDB)
+	client := chanNotifier.SubscribeChannelEvents()
+	defer client.Cancel()
+
+	// We'll dispatch each event of the channel's lifecycle before reading
+	// any of them, to ensure they're delivered in order regardless of how
+	// quickly the client consumes them.
+	chanNotifier.NotifyPendingOpenChannelEvent(channel)
+	chanNotifier.NotifyOpenChannelEvent(channel)
+	chanNotifier.NotifyActiveChannelEvent(chanID)
+	chanNotifier.NotifyInactiveChannelEvent(chanID)
+
+	// The channel may be reported as closing by several subsystems, but
+	// only a single closing event should be sent.
+	chanNotifier.NotifyClosingChannelEvent(chanPoint)
+	chanNotifier.NotifyClosingChannelEvent(chanPoint)
+
+	// Once the channel has been fully closed, it'll only be found amongst
+	// our closed channels.
+	closeSummary := &channeldb.ChannelCloseSummary{
+		ChanPoint: chanPoint,
+		RemotePub: channel.IdentityPub,
+		Capacity:  channel.Capacity,
+		CloseType: channeldb.CooperativeClose,
+	}
+	if err := channel.CloseChannel(closeSummary); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+	chanNotifier.NotifyClosedChannelEvent(chanPoint)
+
+	expectedEvents := []channelEventType{
+		pendingOpenChannelEvent,
+		openChannelEvent,
+		activeChannelEvent,
+		inactiveChannelEvent,
+		closingChannelEvent,
+		closedChannelEvent,
+	}
+	for i, expectedType := range expectedEvents {
+		var event *channelEvent
+		select {
+		case update := <-client.Updates:
+			event = update.(*channelEvent)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event #%v not received", i)
+		}
+
+		if event.eventType != expectedType {
+			t.Fatalf("expected event #%v to be of type %v, got %v",
+				i, expectedType, event.eventType)
+		}
+		if event.chanPoint != chanPoint {
+			t.Fatalf("expected event for %v, got %v", chanPoint,
+				event.chanPoint)
+		}
+
+		// All but the final event should carry the state of the open
+		// channel, while the final one should carry its close
+		// summary.
+		if expectedType == closedChannelEvent {
+			if event.closeSummary == nil {
+				t.Fatalf("closed event has no close summary")
+			}
+			continue
+		}
+		if event.channel == nil {
+			t.Fatalf("event #%v has no channel state", i)
+		}
+	}
+
+	// No further events should be received, as the duplicate closing
+	// event should have been suppressed.
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("unexpected event of type %v",
+			event.(*channelEvent).eventType)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
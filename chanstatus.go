@@ -0,0 +1,209 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// chanStatusManager is responsible for announcing our channels as disabled to
+// the rest of the network once the peer on the other end of the channel has
+// been offline for a while, and re-enabling them once the peer returns and
+// the link of the channel becomes active again. Channels may also be disabled
+// manually, in which case they won't be automatically re-enabled.
+type chanStatusManager struct {
+	chanDB *channeldb.DB
+
+	// disableTimeout is the amount of time a peer must be offline before
+	// its channels are announced as disabled. A value of zero disables
+	// this behavior.
+	disableTimeout time.Duration
+
+	// propagateStatus signs and broadcasts a new ChannelUpdate announcing
+	// the target channel as either disabled or enabled.
+	propagateStatus func(chanPoint wire.OutPoint, disabled bool) error
+
+	// isPeerOnline returns true if we currently have a connection to the
+	// target peer. This guards against disabling the channels of a peer
+	// that reconnected before the termination of its prior connection
+	// was processed.
+	isPeerOnline func(pubKey *btcec.PublicKey) bool
+
+	mu sync.Mutex
+
+	// pendingDisables maps the public keys of peers which are currently
+	// offline to the timer which will disable their channels.
+	pendingDisables map[[33]byte]*time.Timer
+
+	// manuallyDisabled is the set of channels which were disabled by the
+	// user, and as a result won't be re-enabled automatically.
+	//
+	// NOTE: This set isn't persisted, so manually disabled channels will
+	// be re-enabled once their link becomes active after a restart.
+	manuallyDisabled map[wire.OutPoint]struct{}
+}
+
+// newChanStatusManager creates a new chanStatusManager which disables the
+// channels of peers which have been offline for longer than the passed
+// timeout.
+func newChanStatusManager(chanDB *channeldb.DB, disableTimeout time.Duration,
+	propagateStatus func(wire.OutPoint, bool) error,
+	isPeerOnline func(*btcec.PublicKey) bool) *chanStatusManager {
+
+	return &chanStatusManager{
+		chanDB:           chanDB,
+		disableTimeout:   disableTimeout,
+		propagateStatus:  propagateStatus,
+		isPeerOnline:     isPeerOnline,
+		pendingDisables:  make(map[[33]byte]*time.Timer),
+		manuallyDisabled: make(map[wire.OutPoint]struct{}),
+	}
+}
+
+// Stop cancels any pending channel disables.
+func (c *chanStatusManager) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for pubKey, timer := range c.pendingDisables {
+		timer.Stop()
+		delete(c.pendingDisables, pubKey)
+	}
+}
+
+// PeerOffline schedules the channels of the target peer to be disabled once
+// the peer has been offline for the configured timeout.
+func (c *chanStatusManager) PeerOffline(pubKey *btcec.PublicKey) {
+	if c.disableTimeout == 0 {
+		return
+	}
+
+	var pubBytes [33]byte
+	copy(pubBytes[:], pubKey.SerializeCompressed())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.pendingDisables[pubBytes]; ok {
+		return
+	}
+	c.pendingDisables[pubBytes] = time.AfterFunc(c.disableTimeout, func() {
+		c.disablePeerChans(pubKey, pubBytes)
+	})
+}
+
+// PeerOnline cancels the pending disable of the channels of the target peer,
+// if any. The channels themselves are re-enabled once their links become
+// active.
+func (c *chanStatusManager) PeerOnline(pubKey *btcec.PublicKey) {
+	var pubBytes [33]byte
+	copy(pubBytes[:], pubKey.SerializeCompressed())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timer, ok := c.pendingDisables[pubBytes]; ok {
+		timer.Stop()
+		delete(c.pendingDisables, pubBytes)
+	}
+}
+
+// disablePeerChans announces all open channels with the target peer as
+// disabled, unless the peer has come back online in the meantime.
+func (c *chanStatusManager) disablePeerChans(pubKey *btcec.PublicKey,
+	pubBytes [33]byte) {
+
+	c.mu.Lock()
+	if _, ok := c.pendingDisables[pubBytes]; !ok {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pendingDisables, pubBytes)
+	c.mu.Unlock()
+
+	if c.isPeerOnline(pubKey) {
+		return
+	}
+
+	channels, err := c.chanDB.FetchOpenChannels(pubKey)
+	if err != nil {
+		srvrLog.Errorf("unable to fetch channels for peer %x: %v",
+			pubBytes[:], err)
+		return
+	}
+
+	srvrLog.Infof("Peer %x has been offline for %v, disabling %v "+
+		"channels", pubBytes[:], c.disableTimeout, len(channels))
+
+	for _, channel := range channels {
+		err := c.propagateStatus(channel.FundingOutpoint, true)
+		if err != nil && err != discovery.ErrOutgoingChanNotFound {
+			srvrLog.Errorf("unable to disable ChannelPoint(%v): %v",
+				channel.FundingOutpoint, err)
+		}
+	}
+}
+
+// ChanActive re-enables the channel identified by the passed channel ID if it
+// was previously disabled, as its link is now active. Channels which were
+// disabled manually are left untouched.
+//
+// NOTE: This is called from within the switch, so the status update is
+// carried out asynchronously.
+func (c *chanStatusManager) ChanActive(chanID lnwire.ChannelID) {
+	go func() {
+		channels, err := c.chanDB.FetchAllChannels()
+		if err != nil {
+			srvrLog.Errorf("unable to fetch channels: %v", err)
+			return
+		}
+
+		for _, channel := range channels {
+			chanPoint := channel.FundingOutpoint
+			if !chanID.IsChanPoint(&chanPoint) {
+				continue
+			}
+
+			c.mu.Lock()
+			_, ok := c.manuallyDisabled[chanPoint]
+			c.mu.Unlock()
+			if ok {
+				return
+			}
+
+			// Newly opened channels won't be known to the router
+			// until they've been announced, in which case they
+			// can't have been disabled either.
+			err := c.propagateStatus(chanPoint, false)
+			if err != nil &&
+				err != discovery.ErrOutgoingChanNotFound {
+
+				srvrLog.Errorf("unable to enable "+
+					"ChannelPoint(%v): %v", chanPoint, err)
+			}
+			return
+		}
+	}()
+}
+
+// SetChanStatus manually disables or enables the target channel. Manually
+// disabled channels remain disabled until they're manually enabled again,
+// while enabling a channel announces it as enabled immediately.
+func (c *chanStatusManager) SetChanStatus(chanPoint wire.OutPoint,
+	disabled bool) error {
+
+	c.mu.Lock()
+	if disabled {
+		c.manuallyDisabled[chanPoint] = struct{}{}
+	} else {
+		delete(c.manuallyDisabled, chanPoint)
+	}
+	c.mu.Unlock()
+
+	return c.propagateStatus(chanPoint, disabled)
+}
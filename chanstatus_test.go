@@ -0,0 +1,215 @@
+// +build !rpctest
+
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// testDisableTimeout is the amount of time a peer must be offline
+	// before its channels are disabled within the tests below.
+	testDisableTimeout = 50 * time.Millisecond
+)
+
+// chanStatusUpdate is a status update propagated by the chanStatusManager
+// under test.
+type chanStatusUpdate struct {
+	chanPoint wire.OutPoint
+	disabled  bool
+}
+
+// chanStatusTestCtx houses the chanStatusManager under test, along with the
+// single channel it manages.
+type chanStatusTestCtx struct {
+	t *testing.T
+
+	mgr *chanStatusManager
+
+	chanPoint wire.OutPoint
+	peerKey   *btcec.PublicKey
+
+	updates chan *chanStatusUpdate
+
+	// peerOnline is the value returned by the isPeerOnline closure of the
+	// chanStatusManager.
+	mu         sync.Mutex
+	peerOnline bool
+}
+
+// newChanStatusTestCtx creates a chanStatusManager backed by a database
+// containing a single channel.
+func newChanStatusTestCtx(t *testing.T) (*chanStatusTestCtx, func()) {
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	p, _, _, cleanUp, err := createTestPeer(notifier, nil)
+	if err != nil {
+		t.Fatalf("unable to create test peer: %v", err)
+	}
+
+	chanDB := p.server.chanDB
+	channels, err := chanDB.FetchAllChannels()
+	if err != nil {
+		cleanUp()
+		t.Fatalf("unable to fetch channels: %v", err)
+	}
+	if len(channels) != 1 {
+		cleanUp()
+		t.Fatalf("expected 1 channel, got %v", len(channels))
+	}
+
+	ctx := &chanStatusTestCtx{
+		t:         t,
+		chanPoint: channels[0].FundingOutpoint,
+		peerKey:   channels[0].IdentityPub,
+		updates:   make(chan *chanStatusUpdate, 10),
+	}
+
+	propagateStatus := func(chanPoint wire.OutPoint, disabled bool) error {
+		ctx.updates <- &chanStatusUpdate{chanPoint, disabled}
+		return nil
+	}
+	isPeerOnline := func(*btcec.PublicKey) bool {
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+
+		return ctx.peerOnline
+	}
+	ctx.mgr = newChanStatusManager(chanDB, testDisableTimeout,
+		propagateStatus, isPeerOnline)
+
+	return ctx, func() {
+		ctx.mgr.Stop()
+		cleanUp()
+	}
+}
+
+// setPeerOnline sets whether the peer is reported as online to the
+// chanStatusManager.
+func (c *chanStatusTestCtx) setPeerOnline(online bool) {
+	c.mu.Lock()
+	c.peerOnline = online
+	c.mu.Unlock()
+}
+
+// assertUpdate asserts that our channel is announced with the passed status.
+func (c *chanStatusTestCtx) assertUpdate(disabled bool) {
+	select {
+	case update := <-c.updates:
+		if update.chanPoint != c.chanPoint {
+			c.t.Fatalf("expected update for %v, got %v",
+				c.chanPoint, update.chanPoint)
+		}
+		if update.disabled != disabled {
+			c.t.Fatalf("expected disabled=%v, got %v", disabled,
+				update.disabled)
+		}
+	case <-time.After(time.Second * 5):
+		c.t.Fatalf("channel status update not propagated")
+	}
+}
+
+// assertNoUpdate asserts that no status update is propagated for a while.
+func (c *chanStatusTestCtx) assertNoUpdate() {
+	select {
+	case update := <-c.updates:
+		c.t.Fatalf("unexpected status update: disabled=%v",
+			update.disabled)
+	case <-time.After(testDisableTimeout * 4):
+	}
+}
+
+// TestChanStatusManagerDisableOffline tests that the channels of a peer are
+// disabled once it has been offline for the disable timeout, and that a
+// peer reconnecting before then cancels the disable.
+func TestChanStatusManagerDisableOffline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanUp := newChanStatusTestCtx(t)
+	defer cleanUp()
+
+	// Once the peer goes offline, its channel should be disabled after
+	// the timeout.
+	ctx.mgr.PeerOffline(ctx.peerKey)
+	ctx.assertUpdate(true)
+
+	// If the peer comes back online before the timeout, then its channel
+	// should remain untouched.
+	ctx.mgr.PeerOffline(ctx.peerKey)
+	ctx.mgr.PeerOnline(ctx.peerKey)
+	ctx.assertNoUpdate()
+
+	// The same applies if the peer has reconnected by the time the
+	// timeout expires, but the reconnection hasn't yet been processed.
+	ctx.setPeerOnline(true)
+	ctx.mgr.PeerOffline(ctx.peerKey)
+	ctx.assertNoUpdate()
+}
+
+// TestChanStatusManagerNoTimeout tests that channels aren't disabled when
+// the disable timeout is zero.
+func TestChanStatusManagerNoTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanUp := newChanStatusTestCtx(t)
+	defer cleanUp()
+
+	ctx.mgr.disableTimeout = 0
+
+	ctx.mgr.PeerOffline(ctx.peerKey)
+	ctx.assertNoUpdate()
+}
+
+// TestChanStatusManagerChanActive tests that a channel is re-enabled once its
+// link becomes active, unless it was disabled manually.
+func TestChanStatusManagerChanActive(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanUp := newChanStatusTestCtx(t)
+	defer cleanUp()
+
+	chanID := lnwire.NewChanIDFromOutPoint(&ctx.chanPoint)
+
+	// A channel disabled while its peer was offline should be enabled
+	// once its link becomes active.
+	ctx.mgr.PeerOffline(ctx.peerKey)
+	ctx.assertUpdate(true)
+
+	ctx.setPeerOnline(true)
+	ctx.mgr.PeerOnline(ctx.peerKey)
+	ctx.mgr.ChanActive(chanID)
+	ctx.assertUpdate(false)
+
+	// A channel disabled manually should be disabled immediately, and
+	// remain disabled when its link becomes active.
+	if err := ctx.mgr.SetChanStatus(ctx.chanPoint, true); err != nil {
+		t.Fatalf("unable to disable channel: %v", err)
+	}
+	ctx.assertUpdate(true)
+
+	ctx.mgr.ChanActive(chanID)
+	ctx.assertNoUpdate()
+
+	// Once the channel is manually enabled again, it should be enabled
+	// immediately, and re-enabled automatically from then on.
+	if err := ctx.mgr.SetChanStatus(ctx.chanPoint, false); err != nil {
+		t.Fatalf("unable to enable channel: %v", err)
+	}
+	ctx.assertUpdate(false)
+
+	ctx.mgr.ChanActive(chanID)
+	ctx.assertUpdate(false)
+
+	// A link becoming active for an unknown channel shouldn't result in
+	// any updates.
+	ctx.mgr.ChanActive(lnwire.ChannelID{1})
+	ctx.assertNoUpdate()
+}
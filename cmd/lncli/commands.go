@@ -327,6 +327,12 @@ var openChannelCommand = cli.Command{
 			Name:  "block",
 			Usage: "block and wait until the channel is fully open",
 		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "(optional) an address to commit to as an " +
+				"upfront shutdown script, to which our funds " +
+				"must be paid upon a cooperative close",
+		},
 	},
 	Action: openChannel,
 }
@@ -395,6 +401,8 @@ func openChannel(ctx *cli.Context) error {
 		}
 	}
 
+	req.CloseAddress = ctx.String("close_address")
+
 	stream, err := client.OpenChannel(ctxb, req)
 	if err != nil {
 		return err
@@ -444,6 +452,82 @@ func openChannel(ctx *cli.Context) error {
 	}
 }
 
+var batchOpenChannelCommand = cli.Command{
+	Name:      "batchopenchannel",
+	Usage:     "Open multiple channels within a single funding transaction.",
+	ArgsUsage: "channels-json-string",
+	Description: "Attempt to open a new channel to each of the specified " +
+		"peers, all funded by a single transaction with a single " +
+		"change output. If any of the channels fails, then none of " +
+		"the channels are opened.\n" +
+		"   'channels-json-string' decodes the channels to open in " +
+		"the following format.\n" +
+		`   '[{"node_pubkey": "NodeKeyHex", "local_funding_amount": ` +
+		`Sats, "push_sat": Sats}, ...]'`,
+	Action: batchOpenChannel,
+}
+
+func batchOpenChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments provided
+	if ctx.NArg() == 0 {
+		cli.ShowCommandHelp(ctx, "batchopenchannel")
+		return nil
+	}
+
+	var jsonChannels []struct {
+		NodePubkey         string `json:"node_pubkey"`
+		LocalFundingAmount int64  `json:"local_funding_amount"`
+		PushSat            int64  `json:"push_sat"`
+	}
+	err := json.Unmarshal([]byte(ctx.Args().First()), &jsonChannels)
+	if err != nil {
+		return fmt.Errorf("unable to decode channels: %v", err)
+	}
+
+	req := &lnrpc.BatchOpenChannelRequest{}
+	for _, channel := range jsonChannels {
+		nodePubHex, err := hex.DecodeString(channel.NodePubkey)
+		if err != nil {
+			return fmt.Errorf("unable to decode node public key: %v", err)
+		}
+
+		req.Channels = append(req.Channels, &lnrpc.BatchOpenChannel{
+			NodePubkey:         nodePubHex,
+			LocalFundingAmount: channel.LocalFundingAmount,
+			PushSat:            channel.PushSat,
+		})
+	}
+
+	resp, err := client.BatchOpenChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	channelPoints := make([]string, 0, len(resp.PendingChannels))
+	for _, pending := range resp.PendingChannels {
+		txid, err := chainhash.NewHash(pending.Txid)
+		if err != nil {
+			return err
+		}
+
+		channelPoints = append(channelPoints,
+			fmt.Sprintf("%v:%v", txid, pending.OutputIndex))
+	}
+
+	printJSON(struct {
+		ChannelPoints []string `json:"channel_points"`
+	}{
+		ChannelPoints: channelPoints,
+	},
+	)
+
+	return nil
+}
+
 // TODO(roasbeef): also allow short relative channel ID.
 
 var closeChannelCommand = cli.Command{
@@ -476,6 +560,28 @@ var closeChannelCommand = cli.Command{
 			Name:  "block",
 			Usage: "block until the channel is closed",
 		},
+		cli.StringFlag{
+			Name: "delivery_addr",
+			Usage: "(optional) an address to pay our funds to " +
+				"upon a cooperative close, if an upfront " +
+				"shutdown script was committed to, then this " +
+				"must match it",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the " +
+				"closing transaction should confirm within",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee rate in sat/byte to " +
+				"use for the closing transaction",
+		},
+		cli.Int64Flag{
+			Name: "max_fee",
+			Usage: "(optional) the maximum fee in satoshis to " +
+				"pay for the closing transaction",
+		},
 	},
 	Action: closeChannel,
 }
@@ -499,8 +605,12 @@ func closeChannel(ctx *cli.Context) error {
 
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
-		ChannelPoint: &lnrpc.ChannelPoint{},
-		Force:        ctx.Bool("force"),
+		ChannelPoint:    &lnrpc.ChannelPoint{},
+		Force:           ctx.Bool("force"),
+		DeliveryAddress: ctx.String("delivery_addr"),
+		TargetConf:      int32(ctx.Int64("conf_target")),
+		SatPerByte:      ctx.Int64("sat_per_byte"),
+		MaxFeeSat:       ctx.Int64("max_fee"),
 	}
 
 	switch {
@@ -579,6 +689,207 @@ func closeChannel(ctx *cli.Context) error {
 	}
 }
 
+var abandonChannelCommand = cli.Command{
+	Name:  "abandonchannel",
+	Usage: "Abandon a pending or otherwise unresolvable channel.",
+	Description: "Remove a channel which is stuck pending, or otherwise " +
+		"can't be resolved, from the database. Any wallet inputs " +
+		"locked by its funding transaction are released. Channels " +
+		"whose commitment transaction may have been broadcast can " +
+		"only be abandoned with --force.",
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "abandon the channel even if it's active or its " +
+				"commitment transaction has been broadcast",
+		},
+	},
+	Action: abandonChannel,
+}
+
+func abandonChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+	var txid string
+
+	// Show command help if no arguments provieded
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "abandonchannel")
+		return nil
+	}
+
+	req := &lnrpc.AbandonChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{},
+		Force:        ctx.Bool("force"),
+	}
+
+	switch {
+	case ctx.IsSet("funding_txid"):
+		txid = ctx.String("funding_txid")
+	case args.Present():
+		txid = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("funding txid argument missing")
+	}
+
+	txidhash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return err
+	}
+	req.ChannelPoint.FundingTxid = txidhash[:]
+
+	switch {
+	case ctx.IsSet("output_index"):
+		req.ChannelPoint.OutputIndex = uint32(ctx.Int("output_index"))
+	case args.Present():
+		index, err := strconv.ParseInt(args.First(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unable to decode output index: %v", err)
+		}
+		req.ChannelPoint.OutputIndex = uint32(index)
+	default:
+		req.ChannelPoint.OutputIndex = 0
+	}
+
+	resp, err := client.AbandonChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var spliceChannelCommand = cli.Command{
+	Name:  "splicechannel",
+	Usage: "Splice funds into or out of an active channel.",
+	Description: "Add funds from the wallet to an active channel, or " +
+		"with --splice_out remove funds from the channel to an " +
+		"on-chain address, without closing the channel. The channel " +
+		"is paused until the splice transaction confirms, after " +
+		"which it continues under the returned channel point.",
+	ArgsUsage: "funding_txid [output_index] amt",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the number of satoshis to splice into or out of the channel",
+		},
+		cli.BoolFlag{
+			Name:  "splice_out",
+			Usage: "if set, funds are spliced out of the channel",
+		},
+		cli.StringFlag{
+			Name: "addr",
+			Usage: "(optional) the address to send spliced out funds " +
+				"to, if unset a fresh wallet address is used",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee rate in sat/byte to use " +
+				"for the splice transaction",
+		},
+	},
+	Action: spliceChannel,
+}
+
+func spliceChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+	var (
+		txid string
+		err  error
+	)
+
+	// Show command help if no arguments provieded
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "splicechannel")
+		return nil
+	}
+
+	req := &lnrpc.SpliceChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{},
+		SpliceOut:    ctx.Bool("splice_out"),
+		Address:      ctx.String("addr"),
+		SatPerByte:   ctx.Int64("sat_per_byte"),
+	}
+
+	switch {
+	case ctx.IsSet("funding_txid"):
+		txid = ctx.String("funding_txid")
+	case args.Present():
+		txid = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("funding txid argument missing")
+	}
+
+	txidhash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return err
+	}
+	req.ChannelPoint.FundingTxid = txidhash[:]
+
+	switch {
+	case ctx.IsSet("output_index"):
+		req.ChannelPoint.OutputIndex = uint32(ctx.Int("output_index"))
+	case args.Present() && !ctx.IsSet("amt") && len(args) > 1:
+		index, err := strconv.ParseInt(args.First(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unable to decode output index: %v", err)
+		}
+		req.ChannelPoint.OutputIndex = uint32(index)
+		args = args.Tail()
+	default:
+		req.ChannelPoint.OutputIndex = 0
+	}
+
+	switch {
+	case ctx.IsSet("amt"):
+		req.Amount = ctx.Int64("amt")
+	case args.Present():
+		req.Amount, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amount: %v", err)
+		}
+	default:
+		return fmt.Errorf("amount argument missing")
+	}
+
+	resp, err := client.SpliceChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var listPeersCommand = cli.Command{
 	Name:   "listpeers",
 	Usage:  "List all active, currently connected peers.",
@@ -690,16 +1001,184 @@ var pendingChannelsCommand = cli.Command{
 				"process of being opened or closed",
 		},
 	},
-	Action: pendingChannels,
+	Action: pendingChannels,
+}
+
+func pendingChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PendingChannelRequest{}
+	resp, err := client.PendingChannels(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var pendingSweepsCommand = cli.Command{
+	Name:  "pendingsweeps",
+	Usage: "display the outputs that are waiting to be swept into the wallet",
+	Description: "Lists all the outputs handed to the sweeper which " +
+		"haven't yet been swept within a confirmed transaction, " +
+		"along with the latest sweep transaction spending each output.",
+	Action: pendingSweeps,
+}
+
+func pendingSweeps(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PendingSweepsRequest{}
+	resp, err := client.PendingSweeps(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var bumpFeeCommand = cli.Command{
+	Name:      "bumpfee",
+	Usage:     "raise the fee of an unconfirmed transaction",
+	ArgsUsage: "txid | outpoint",
+	Description: "Raises the fee of an unconfirmed transaction identified " +
+		"by its txid, or by one of its outputs (txid:index). Sweep " +
+		"transactions are replaced with a transaction paying a higher " +
+		"fee (RBF), while for any other transaction, a child " +
+		"transaction spending one of our outputs is broadcast (CPFP). " +
+		"If neither --conf_target nor --sat_per_byte is set, then the " +
+		"transaction will target confirmation within the next block.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "txid",
+			Usage: "the txid of the transaction to bump the fee of",
+		},
+		cli.StringFlag{
+			Name: "outpoint",
+			Usage: "the outpoint (txid:index) of an output of " +
+				"the transaction to bump the fee of",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks that the transaction " +
+				"should confirm within",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "a manual fee rate expressed in sat/byte " +
+				"that the transaction should pay",
+		},
+	},
+	Action: bumpFee,
+}
+
+func bumpFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "bumpfee")
+		return nil
+	}
+
+	req := &lnrpc.BumpFeeRequest{
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	}
+
+	switch {
+	case ctx.IsSet("txid"):
+		req.Txid = ctx.String("txid")
+	case ctx.IsSet("outpoint"):
+		req.Outpoint = ctx.String("outpoint")
+	case ctx.Args().Present() && strings.Contains(ctx.Args().First(), ":"):
+		req.Outpoint = ctx.Args().First()
+	case ctx.Args().Present():
+		req.Txid = ctx.Args().First()
+	default:
+		return fmt.Errorf("txid or outpoint argument missing")
+	}
+
+	resp, err := client.BumpFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var updateChanStatusCommand = cli.Command{
+	Name:      "updatechanstatus",
+	Usage:     "disable or enable a channel for forwarding",
+	ArgsUsage: "chan_point",
+	Description: "Manually disables or enables one of our channels, " +
+		"identified by its channel point (txid:index), by " +
+		"announcing a new channel update to the rest of the " +
+		"network. Manually disabled channels aren't re-enabled " +
+		"automatically once their peer reconnects.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel point (txid:index) of the " +
+				"channel to disable or enable",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "disable the channel",
+		},
+		cli.BoolFlag{
+			Name:  "enable",
+			Usage: "enable the channel",
+		},
+	},
+	Action: updateChanStatus,
 }
 
-func pendingChannels(ctx *cli.Context) error {
+func updateChanStatus(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.PendingChannelRequest{}
-	resp, err := client.PendingChannels(ctxb, req)
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "updatechanstatus")
+		return nil
+	}
+
+	req := &lnrpc.UpdateChanStatusRequest{}
+
+	switch {
+	case ctx.IsSet("chan_point"):
+		req.ChanPoint = ctx.String("chan_point")
+	case ctx.Args().Present():
+		req.ChanPoint = ctx.Args().First()
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+
+	switch {
+	case ctx.Bool("disable") && ctx.Bool("enable"):
+		return fmt.Errorf("only one of --disable and --enable may " +
+			"be set")
+	case ctx.Bool("disable"):
+		req.Disable = true
+	case ctx.Bool("enable"):
+		req.Disable = false
+	default:
+		return fmt.Errorf("either --disable or --enable must be set")
+	}
+
+	resp, err := client.UpdateChanStatus(ctxb, req)
 	if err != nil {
 		return err
 	}
@@ -739,6 +1218,68 @@ func listChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var closedChannelsCommand = cli.Command{
+	Name:  "closedchannels",
+	Usage: "list all closed channels",
+	Description: "List the channels this node was a participant in that " +
+		"have since been closed, along with the on-chain resolution " +
+		"of each of their outputs. If no filters are set, then all " +
+		"closed channels are listed.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "cooperative",
+			Usage: "list channels that were closed cooperatively",
+		},
+		cli.BoolFlag{
+			Name:  "local_force",
+			Usage: "list channels that were force closed by us",
+		},
+		cli.BoolFlag{
+			Name: "remote_force",
+			Usage: "list channels that were force closed by the " +
+				"remote party",
+		},
+		cli.BoolFlag{
+			Name: "breach",
+			Usage: "list channels that were closed due to a breach " +
+				"by the remote party",
+		},
+		cli.BoolFlag{
+			Name: "funding_canceled",
+			Usage: "list channels whose funding flow was canceled " +
+				"before they were opened",
+		},
+		cli.BoolFlag{
+			Name:  "abandoned",
+			Usage: "list channels that were manually abandoned",
+		},
+	},
+	Action: closedChannels,
+}
+
+func closedChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ClosedChannelsRequest{
+		Cooperative:     ctx.Bool("cooperative"),
+		LocalForce:      ctx.Bool("local_force"),
+		RemoteForce:     ctx.Bool("remote_force"),
+		Breach:          ctx.Bool("breach"),
+		FundingCanceled: ctx.Bool("funding_canceled"),
+		Abandoned:       ctx.Bool("abandoned"),
+	}
+	resp, err := client.ClosedChannels(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var sendPaymentCommand = cli.Command{
 	Name:  "sendpayment",
 	Usage: "send a payment over lightning",
@@ -762,10 +1303,27 @@ var sendPaymentCommand = cli.Command{
 			Name:  "debug_send",
 			Usage: "use the debug rHash when sending the HTLC",
 		},
+		cli.BoolFlag{
+			Name: "keysend",
+			Usage: "send a spontaneous payment without an invoice, " +
+				"delivering a freshly generated preimage to " +
+				"the destination",
+		},
 		cli.StringFlag{
 			Name:  "pay_req",
 			Usage: "a zbase32-check encoded payment request to fulfill",
 		},
+		cli.Uint64Flag{
+			Name: "outgoing_chan_id",
+			Usage: "the short channel id of the channel the payment " +
+				"must be sent over as its first hop",
+		},
+		cli.StringFlag{
+			Name: "last_hop",
+			Usage: "the compressed identity pubkey of the node the " +
+				"payment must traverse right before reaching " +
+				"the destination",
+		},
 	},
 	Action: sendPayment,
 }
@@ -827,8 +1385,14 @@ func sendPayment(ctx *cli.Context) error {
 			Amt:  amount,
 		}
 
-		if ctx.Bool("debug_send") && (ctx.IsSet("payment_hash") || args.Present()) {
-			return fmt.Errorf("do not provide a payment hash with debug send")
+		keySend := ctx.Bool("keysend")
+		if (ctx.Bool("debug_send") || keySend) &&
+			(ctx.IsSet("payment_hash") || args.Present()) {
+
+			return fmt.Errorf("do not provide a payment hash with " +
+				"debug send or keysend")
+		} else if keySend {
+			req.KeySend = true
 		} else if !ctx.Bool("debug_send") {
 			var rHash []byte
 
@@ -852,6 +1416,15 @@ func sendPayment(ctx *cli.Context) error {
 		}
 	}
 
+	req.OutgoingChanId = ctx.Uint64("outgoing_chan_id")
+	if ctx.IsSet("last_hop") {
+		lastHop, err := parseLastHop(ctx.String("last_hop"))
+		if err != nil {
+			return err
+		}
+		req.LastHopPubkey = lastHop
+	}
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -881,6 +1454,232 @@ func sendPayment(ctx *cli.Context) error {
 	return nil
 }
 
+// parseLastHop decodes the hex-encoded pubkey of a last hop restriction.
+func parseLastHop(lastHopStr string) ([]byte, error) {
+	lastHop, err := hex.DecodeString(lastHopStr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode last hop: %v", err)
+	}
+	if len(lastHop) != 33 {
+		return nil, fmt.Errorf("last hop pubkey must be exactly 33 "+
+			"bytes, is instead: %v", len(lastHop))
+	}
+
+	return lastHop, nil
+}
+
+var sendToRouteCommand = cli.Command{
+	Name:      "sendtoroute",
+	Usage:     "send a payment over a predefined route",
+	ArgsUsage: "payment_hash routes",
+	Description: "Sends a payment along the routes provided, rather than " +
+		"those found by the daemon's path finding. The routes are " +
+		"attempted in order until the payment succeeds. They're " +
+		"expected in the JSON format output by queryroutes, and are " +
+		"read from stdin if not provided, allowing the output of " +
+		"queryroutes to be piped directly into this command.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash, r",
+			Usage: "the hash to use within the payment's HTLC",
+		},
+		cli.StringFlag{
+			Name: "routes",
+			Usage: "the JSON encoded routes to attempt, as output " +
+				"by queryroutes",
+		},
+	},
+	Action: sendToRoute,
+}
+
+func sendToRoute(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "sendtoroute")
+		return nil
+	}
+
+	var (
+		rHash []byte
+		err   error
+		args  = ctx.Args()
+	)
+
+	switch {
+	case ctx.IsSet("payment_hash"):
+		rHash, err = hex.DecodeString(ctx.String("payment_hash"))
+	case args.Present():
+		rHash, err = hex.DecodeString(args.First())
+		args = args.Tail()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+	if err != nil {
+		return err
+	}
+	if len(rHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, is "+
+			"instead %v", len(rHash))
+	}
+
+	var jsonRoutes string
+	switch {
+	case ctx.IsSet("routes"):
+		jsonRoutes = ctx.String("routes")
+	case args.Present():
+		jsonRoutes = args.First()
+	default:
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("unable to read routes from stdin: %v",
+				err)
+		}
+		jsonRoutes = string(b)
+	}
+
+	routes := &lnrpc.QueryRoutesResponse{}
+	if err := jsonpb.UnmarshalString(jsonRoutes, routes); err != nil {
+		return fmt.Errorf("unable to decode routes: %v", err)
+	}
+
+	req := &lnrpc.SendToRouteRequest{
+		PaymentHash: rHash,
+		Routes:      routes.Routes,
+	}
+	resp, err := client.SendToRouteSync(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		E string       `json:"payment_error"`
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+		C uint32       `json:"failure_code,omitempty"`
+		I uint32       `json:"failure_source_index,omitempty"`
+	}{
+		E: resp.PaymentError,
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+		C: resp.FailureCode,
+		I: resp.FailureSourceIndex,
+	})
+
+	return nil
+}
+
+var rebalanceCommand = cli.Command{
+	Name:      "rebalance",
+	Usage:     "move funds between two of our channels",
+	ArgsUsage: "outgoing_chan_id incoming_chan_id amt",
+	Description: "Moves funds out of the outgoing channel and into the " +
+		"incoming channel by paying an invoice to ourselves, routed " +
+		"through the network. The payment is aborted if no route " +
+		"within the fee limit can be found.",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "outgoing_chan_id",
+			Usage: "the short channel id of the channel to move " +
+				"funds out of",
+		},
+		cli.Uint64Flag{
+			Name: "incoming_chan_id",
+			Usage: "the short channel id of the channel to move " +
+				"funds into",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the number of satoshis to move",
+		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "the maximum total fee in satoshis to pay for the " +
+				"rebalance",
+		},
+	},
+	Action: rebalance,
+}
+
+func rebalance(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "rebalance")
+		return nil
+	}
+
+	var (
+		req  = &lnrpc.RebalanceRequest{}
+		args = ctx.Args()
+		err  error
+	)
+
+	switch {
+	case ctx.IsSet("outgoing_chan_id"):
+		req.OutgoingChanId = ctx.Uint64("outgoing_chan_id")
+	case args.Present():
+		req.OutgoingChanId, err = strconv.ParseUint(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode outgoing_chan_id: %v",
+				err)
+		}
+		args = args.Tail()
+	default:
+		return fmt.Errorf("outgoing_chan_id argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("incoming_chan_id"):
+		req.IncomingChanId = ctx.Uint64("incoming_chan_id")
+	case args.Present():
+		req.IncomingChanId, err = strconv.ParseUint(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode incoming_chan_id: %v",
+				err)
+		}
+		args = args.Tail()
+	default:
+		return fmt.Errorf("incoming_chan_id argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("amt"):
+		req.Amt = ctx.Int64("amt")
+	case args.Present():
+		req.Amt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt: %v", err)
+		}
+	default:
+		return fmt.Errorf("amt argument missing")
+	}
+
+	if !ctx.IsSet("fee_limit") {
+		return fmt.Errorf("fee_limit must be set")
+	}
+	req.FeeLimit = ctx.Int64("fee_limit")
+
+	resp, err := client.Rebalance(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+	}{
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+	})
+
+	return nil
+}
+
 var addInvoiceCommand = cli.Command{
 	Name:  "addinvoice",
 	Usage: "add a new invoice.",
@@ -1263,6 +2062,56 @@ func listPayments(ctx *cli.Context) error {
 	return nil
 }
 
+var trackPaymentCommand = cli.Command{
+	Name:  "trackpayment",
+	Usage: "track the progress of an outgoing payment",
+	Description: "prints out the current state of the target payment, " +
+		"followed by each update of its state until it either " +
+		"succeeds or fails",
+	ArgsUsage: "payment_hash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the hash of the payment to track",
+		},
+	},
+	Action: trackPayment,
+}
+
+func trackPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var paymentHash string
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash = ctx.String("payment_hash")
+	case ctx.Args().Present():
+		paymentHash = ctx.Args().First()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHashString: paymentHash,
+	}
+	stream, err := client.TrackPayment(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJSON(payment)
+	}
+}
+
 var getChanInfoCommand = cli.Command{
 	Name:  "getchaninfo",
 	Usage: "get the state of a channel",
@@ -1370,6 +2219,49 @@ var queryRoutesCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the amount to send expressed in satoshis",
 		},
+		cli.Uint64Flag{
+			Name: "outgoing_chan_id",
+			Usage: "the short channel id of the channel the route " +
+				"must take as its first hop",
+		},
+		cli.StringFlag{
+			Name: "last_hop",
+			Usage: "the compressed identity pubkey of the node the " +
+				"route must traverse right before reaching the " +
+				"destination",
+		},
+		cli.IntFlag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the time lock of the final " +
+				"hop should lie past the current height",
+		},
+		cli.IntFlag{
+			Name:  "num_routes",
+			Usage: "the maximum number of routes to return",
+		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "the maximum total fee in satoshis a returned " +
+				"route may pay",
+		},
+		cli.StringSliceFlag{
+			Name: "ignore_node",
+			Usage: "the compressed identity pubkey of a node the " +
+				"routes may not traverse, can be specified " +
+				"multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "ignore_edge",
+			Usage: "a channel the routes may not traverse, in the " +
+				"form chan_id:direction, where a direction of 1 " +
+				"ignores the channel from the node with the " +
+				"larger pubkey, can be specified multiple times",
+		},
+		cli.StringFlag{
+			Name: "source",
+			Usage: "the compressed identity pubkey of the node the " +
+				"routes should start from, instead of our own",
+		},
 	},
 	Action: queryRoutes,
 }
@@ -1410,8 +2302,36 @@ func queryRoutes(ctx *cli.Context) error {
 	}
 
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey: dest,
-		Amt:    amt,
+		PubKey:         dest,
+		Amt:            amt,
+		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+		FinalCltvDelta: int32(ctx.Int("final_cltv_delta")),
+		NumRoutes:      int32(ctx.Int("num_routes")),
+		FeeLimit:       ctx.Int64("fee_limit"),
+		SourcePubKey:   ctx.String("source"),
+	}
+	if ctx.IsSet("last_hop") {
+		req.LastHopPubkey, err = parseLastHop(ctx.String("last_hop"))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, node := range ctx.StringSlice("ignore_node") {
+		nodeBytes, err := hex.DecodeString(node)
+		if err != nil {
+			return fmt.Errorf("unable to decode ignored node: %v",
+				err)
+		}
+		req.IgnoredNodes = append(req.IgnoredNodes, nodeBytes)
+	}
+
+	for _, edge := range ctx.StringSlice("ignore_edge") {
+		edgeLocator, err := parseEdgeLocator(edge)
+		if err != nil {
+			return err
+		}
+		req.IgnoredEdges = append(req.IgnoredEdges, edgeLocator)
 	}
 
 	route, err := client.QueryRoutes(ctxb, req)
@@ -1423,6 +2343,36 @@ func queryRoutes(ctx *cli.Context) error {
 	return nil
 }
 
+// parseEdgeLocator parses a directed channel of the form chan_id:direction,
+// where the direction is either 0 or 1.
+func parseEdgeLocator(edge string) (*lnrpc.EdgeLocator, error) {
+	parts := strings.Split(edge, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("ignored edge %v must be of the form "+
+			"chan_id:direction", edge)
+	}
+
+	chanID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode channel id: %v", err)
+	}
+
+	var reverse bool
+	switch parts[1] {
+	case "0":
+	case "1":
+		reverse = true
+	default:
+		return nil, fmt.Errorf("edge direction must be either 0 or 1, "+
+			"is instead: %v", parts[1])
+	}
+
+	return &lnrpc.EdgeLocator{
+		ChannelId:        chanID,
+		DirectionReverse: reverse,
+	}, nil
+}
+
 var getNetworkInfoCommand = cli.Command{
 	Name:  "getnetworkinfo",
 	Usage: "getnetworkinfo",
@@ -152,18 +152,27 @@ func main() {
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
+		batchOpenChannelCommand,
 		closeChannelCommand,
+		abandonChannelCommand,
+		spliceChannelCommand,
 		listPeersCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
 		getInfoCommand,
 		pendingChannelsCommand,
+		pendingSweepsCommand,
+		bumpFeeCommand,
 		sendPaymentCommand,
+		sendToRouteCommand,
+		rebalanceCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
+		closedChannelsCommand,
 		listPaymentsCommand,
+		trackPaymentCommand,
 		describeGraphCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
@@ -177,6 +186,7 @@ func main() {
 		verifyMessageCommand,
 		feeReportCommand,
 		updateFeesCommand,
+		updateChanStatusCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -35,6 +35,14 @@ const (
 	defaultRPCHost            = "localhost"
 	defaultMaxPendingChannels = 1
 	defaultNumChanConfs       = 1
+	defaultInterceptTimeout   = 30 * time.Second
+	defaultChanDisableTimeout = 20 * time.Minute
+	defaultNumGraphSyncPeers  = 3
+	defaultChanPruneExpiry    = 14 * 24 * time.Hour
+	defaultGossipInterval     = time.Minute
+	defaultMaxGossipBurst     = 10
+	defaultGossipBanScore     = 100
+	defaultGossipBanDuration  = 24 * time.Hour
 )
 
 var (
@@ -116,6 +124,21 @@ type config struct {
 	HodlHTLC           bool `long:"hodlhtlc" description:"Activate the hodl HTLC mode.  With hodl HTLC mode, all incoming HTLCs will be accepted by the receiving node, but no attempt will be made to settle the payment with the sender."`
 	MaxPendingChannels int  `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 
+	InterceptTimeout time.Duration `long:"intercepttimeout" description:"The maximum amount of time a forwarded HTLC may be held by an HTLC interceptor before it's failed back. Valid time units are {s, m, h}. A value of 0 disables the timeout."`
+
+	ChanDisableTimeout time.Duration `long:"chandisabletimeout" description:"The amount of time a peer must be offline before our channels with it are announced to the network as disabled. Valid time units are {s, m, h}. A value of 0 disables automatic channel disabling."`
+
+	ChannelPruneExpiry time.Duration `long:"channelpruneexpiry" description:"The amount of time after which a channel is pruned from the graph as a zombie, if neither of its nodes has sent out an update for it. Pruned channels are only added back once a fresh update for them arrives. Valid time units are {s, m, h}. A value of 0 disables zombie pruning."`
+
+	NumGraphSyncPeers int `long:"numgraphsyncpeers" description:"The number of peers which support gossip queries that we'll actively query for new channels and updates. All other such peers are only answered when they query us. Peers which don't support gossip queries are always sent our entire view of the channel graph upon connection."`
+
+	GossipRateInterval time.Duration `long:"gossiprateinterval" description:"The interval at which each channel direction and node may send out a new update, once its burst of updates has been used up. Updates received beyond this rate are dropped. Valid time units are {s, m, h}. A value of 0 disables gossip rate limiting."`
+	MaxGossipBurst     int           `long:"maxgossipburst" description:"The maximum number of updates for a single channel direction or node that are accepted in a burst, before the update rate is limited."`
+	GossipBanThreshold uint32        `long:"gossipbanthreshold" description:"The ban score at which a peer sending us invalid or excessive gossip is banned. Each invalid message adds 25 to a peer's score, while each rate limited update of its own adds 1. A point of the score is forgiven every minute. A value of 0 disables banning."`
+	GossipBanDuration  time.Duration `long:"gossipbanduration" description:"The amount of time for which all gossip from a banned peer is ignored. Valid time units are {s, m, h}."`
+
+	AcceptKeySend bool `long:"acceptkeysend" description:"Accept spontaneous payments which carry their preimage within the onion, rather than paying to one of our invoices. An invoice is created on the fly for each such payment."`
+
 	Litecoin *chainConfig `group:"Litecoin" namespace:"litecoin"`
 	Bitcoin  *chainConfig `group:"Bitcoin" namespace:"bitcoin"`
 
@@ -151,6 +174,14 @@ func loadConfig() (*config, error) {
 		RESTPort:            defaultRESTPort,
 		MaxPendingChannels:  defaultMaxPendingChannels,
 		DefaultNumChanConfs: defaultNumChanConfs,
+		InterceptTimeout:    defaultInterceptTimeout,
+		ChanDisableTimeout:  defaultChanDisableTimeout,
+		NumGraphSyncPeers:   defaultNumGraphSyncPeers,
+		ChannelPruneExpiry:  defaultChanPruneExpiry,
+		GossipRateInterval:  defaultGossipInterval,
+		MaxGossipBurst:      defaultMaxGossipBurst,
+		GossipBanThreshold:  defaultGossipBanScore,
+		GossipBanDuration:   defaultGossipBanDuration,
 		Bitcoin: &chainConfig{
 			RPCHost: defaultRPCHost,
 			RPCCert: defaultBtcdRPCCertFile,
@@ -213,6 +244,15 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// If gossip rate limiting is enabled, then at least a single update
+	// must be allowed within each burst.
+	if cfg.GossipRateInterval != 0 && cfg.MaxGossipBurst < 1 {
+		str := "%s: maxgossipburst must be positive when gossip rate " +
+			"limiting is enabled"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+	}
+
 	// At this moment, multiple active chains are not supported.
 	if cfg.Litecoin.Active && cfg.Bitcoin.Active {
 		str := "%s: Currently both Bitcoin and Litecoin cannot be " +
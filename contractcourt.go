@@ -0,0 +1,198 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// defaultBroadcastDelta is the number of blocks before the expiry of
+	// an HTLC that we'll force close the channel the HTLC resides in.
+	// This gives our commitment transaction, and any second-level HTLC
+	// transactions time to confirm before the remote party is able to
+	// claim the HTLC.
+	defaultBroadcastDelta = 5
+)
+
+// ContractCourtConfig bundles the required subsystems used by the contract
+// court. An instance of ContractCourtConfig is passed to newContractCourt
+// during instantiation.
+type ContractCourtConfig struct {
+	// DB provides access to the user's channels, allowing the contract
+	// court to examine the HTLC's active within each channel.
+	DB *channeldb.DB
+
+	// Notifier is used to receive a notification for each new block
+	// connected to the main chain.
+	Notifier chainntnfs.ChainNotifier
+
+	// PreimageCache is the global preimage cache of the daemon. It's used
+	// to determine if we're able to claim an incoming HTLC on-chain.
+	PreimageCache lnwallet.PreimageCache
+
+	// BroadcastDelta is the number of blocks before the expiry of an HTLC
+	// that we'll force close the channel the HTLC resides in.
+	BroadcastDelta uint32
+
+	// ForceCloseChan force closes the channel identified by the passed
+	// channel point, handing off all the outputs we're able to claim to
+	// the utxoNursery.
+	ForceCloseChan func(wire.OutPoint) error
+}
+
+// contractCourt is a subsystem which watches the HTLC's active within each of
+// our open channels against the main chain. If an outgoing HTLC is close to
+// expiring, or an incoming HTLC that we know the preimage to is close to
+// expiring, then the channel is force closed so the HTLC can be resolved
+// on-chain. This ensures that we're able to resolve HTLC's in time even if the
+// remote party is unresponsive, or offline entirely.
+type contractCourt struct {
+	started uint32
+	stopped uint32
+
+	cfg *ContractCourtConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newContractCourt creates a new instance of the contractCourt backed by the
+// passed config.
+func newContractCourt(cfg *ContractCourtConfig) *contractCourt {
+	return &contractCourt{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start launches all goroutines the contractCourt needs to properly carry out
+// its duties.
+func (c *contractCourt) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	cnctLog.Tracef("Starting contract court")
+
+	blockEpochs, err := c.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.expiryWatcher(blockEpochs)
+
+	return nil
+}
+
+// Stop gracefully shuts down any lingering goroutines launched during normal
+// operation of the contractCourt.
+func (c *contractCourt) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	cnctLog.Infof("Contract court shutting down")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// expiryWatcher examines the HTLC's within all open channels at each new
+// block height, force closing any channels with HTLC's that need to be
+// resolved on-chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *contractCourt) expiryWatcher(blockEpochs *chainntnfs.BlockEpochEvent) {
+	defer c.wg.Done()
+	defer blockEpochs.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+			height := uint32(epoch.Height)
+			if err := c.checkExpiries(height); err != nil {
+				cnctLog.Errorf("unable to check HTLC expiries "+
+					"at height=%v: %v", height, err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkExpiries force closes any open channel which has an HTLC that must be
+// resolved on-chain at the passed block height.
+func (c *contractCourt) checkExpiries(height uint32) error {
+	channels, err := c.cfg.DB.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		if channel.IsPending {
+			continue
+		}
+
+		htlc := c.expiringHtlc(channel.Htlcs, height)
+		if htlc == nil {
+			continue
+		}
+
+		chanPoint := channel.FundingOutpoint
+		cnctLog.Warnf("HTLC(payment_hash=%x, incoming=%v) expires at "+
+			"height=%v, current height=%v, force closing "+
+			"ChannelPoint(%v)", htlc.RHash[:], htlc.Incoming,
+			htlc.RefundTimeout, height, chanPoint)
+
+		if err := c.cfg.ForceCloseChan(chanPoint); err != nil {
+			cnctLog.Errorf("unable to force close "+
+				"ChannelPoint(%v): %v", chanPoint, err)
+		}
+	}
+
+	return nil
+}
+
+// expiringHtlc returns the first HTLC within the passed set which is within
+// BroadcastDelta blocks of its expiry. Outgoing HTLC's must be timed out
+// on-chain before the corresponding incoming HTLC upstream expires, while
+// incoming HTLC's can only be claimed on-chain if we know the preimage. If no
+// such HTLC is found, then nil is returned.
+func (c *contractCourt) expiringHtlc(htlcs []*channeldb.HTLC,
+	height uint32) *channeldb.HTLC {
+
+	for _, htlc := range htlcs {
+		if height+c.cfg.BroadcastDelta < htlc.RefundTimeout {
+			continue
+		}
+
+		// If this is an incoming HTLC that we don't know the preimage
+		// to, then there's nothing to gain from going on-chain, as
+		// the remote party will simply time it out.
+		if htlc.Incoming {
+			_, ok := c.cfg.PreimageCache.LookupPreimage(
+				htlc.RHash[:],
+			)
+			if !ok {
+				continue
+			}
+		}
+
+		return htlc
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// ChannelGraphTimeSeries is an interface that provides time and block based
+// querying into our view of the channel graph. New channels will have
+// monotonically increasing block heights, and new channel updates will have
+// increasing timestamps. Once we connect to a peer, we'll use the methods in
+// this interface to determine if we're already in sync, or need to request
+// some new information from them.
+type ChannelGraphTimeSeries interface {
+	// HighestChanID should return the channel ID of the channel we know of
+	// that's furthest in the target chain. This channel will have a block
+	// height that's close to the current tip of the main chain as we
+	// know it. We'll use this to start our QueryChannelRange dance with
+	// the remote node.
+	HighestChanID() (*lnwire.ShortChannelID, error)
+
+	// UpdatesInHorizon returns all known channel and node updates with an
+	// update timestamp between the start time and end time. We'll use this
+	// to catch up a remote node to the set of channel updates that they
+	// may have missed out on within the target chain.
+	UpdatesInHorizon(startTime time.Time,
+		endTime time.Time) ([]lnwire.Message, error)
+
+	// FilterKnownChanIDs takes a target chain, and a set of channel ID's,
+	// and returns a filtered set of chan ID's. This filtered set of chan
+	// ID's represents the ID's that we don't know of which were in the
+	// passed superSet.
+	FilterKnownChanIDs(
+		superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error)
+
+	// FilterChannelRange returns the set of channels that we created
+	// between the start height and the end height. We'll use this to
+	// respond to a remote peer's QueryChannelRange message.
+	FilterChannelRange(startHeight,
+		endHeight uint32) ([]lnwire.ShortChannelID, error)
+
+	// FetchChanAnns returns a full set of channel announcements as well as
+	// their updates that match the set of specified short channel ID's.
+	// We'll use this to reply to a QueryShortChanIDs message sent by a
+	// remote peer. The response will contain a unique set of
+	// ChannelAnnouncements, the latest ChannelUpdate for each of the
+	// announcements, and a unique set of NodeAnnouncements.
+	FetchChanAnns(
+		shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error)
+}
+
+// ChanSeries is an implementation of the ChannelGraphTimeSeries interface
+// backed by the channeldb ChannelGraph database. We'll provide this
+// implementation to the AuthenticatedGossiper so it can properly use the
+// in-protocol channel range queries to quickly and efficiently synchronize
+// our channel state with all peers.
+type ChanSeries struct {
+	graph *channeldb.ChannelGraph
+}
+
+// NewChanSeries constructs a new ChanSeries backed by a channeldb.ChannelGraph.
+// The returned ChanSeries implements the ChannelGraphTimeSeries interface.
+func NewChanSeries(graph *channeldb.ChannelGraph) *ChanSeries {
+	return &ChanSeries{
+		graph: graph,
+	}
+}
+
+// A compile time check to ensure ChanSeries implements the
+// ChannelGraphTimeSeries interface.
+var _ ChannelGraphTimeSeries = (*ChanSeries)(nil)
+
+// HighestChanID should return the channel ID of the channel we know of that's
+// furthest in the target chain.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *ChanSeries) HighestChanID() (*lnwire.ShortChannelID, error) {
+	chanID, err := c.graph.HighestChanID()
+	if err != nil {
+		return nil, err
+	}
+
+	shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+	return &shortChanID, nil
+}
+
+// UpdatesInHorizon returns all known channel and node updates with an update
+// timestamp between the start time and end time. Channel announcements are
+// sent before the updates which refer to them, while node announcements are
+// sent last.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *ChanSeries) UpdatesInHorizon(startTime time.Time,
+	endTime time.Time) ([]lnwire.Message, error) {
+
+	inHorizon := func(update *lnwire.ChannelUpdate) bool {
+		timestamp := time.Unix(int64(update.Timestamp), 0)
+		return !timestamp.Before(startTime) && !timestamp.After(endTime)
+	}
+
+	var updates []lnwire.Message
+
+	// First, we'll query for all the set of channels that have an update
+	// that falls within the specified horizon.
+	chansInHorizon, err := c.graph.ChanUpdatesInHorizon(
+		startTime, endTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range chansInHorizon {
+		// If the channel hasn't been fully advertised yet, then we'll
+		// skip it as we can't construct a full authentication proof
+		// if one is requested.
+		if channel.Info.AuthProof == nil {
+			continue
+		}
+
+		chanAnn, edge1, edge2 := createChanAnnouncement(
+			channel.Info.AuthProof, channel.Info, channel.Policy1,
+			channel.Policy2,
+		)
+
+		// Only the updates which fall within the horizon are sent
+		// along with the announcement itself.
+		updates = append(updates, chanAnn)
+		if edge1 != nil && inHorizon(edge1) {
+			updates = append(updates, edge1)
+		}
+		if edge2 != nil && inHorizon(edge2) {
+			updates = append(updates, edge2)
+		}
+	}
+
+	// Next, we'll send out all the node announcements that have an update
+	// within the horizon as well.
+	nodesInHorizon, err := c.graph.NodeUpdatesInHorizon(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodesInHorizon {
+		nodeAnn, err := recreateNodeAnnouncement(&nodesInHorizon[i])
+		if err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, nodeAnn)
+	}
+
+	return updates, nil
+}
+
+// FilterKnownChanIDs takes a set of channel ID's and returns the subset of
+// those which we don't know of.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *ChanSeries) FilterKnownChanIDs(
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	chanIDs := make([]uint64, 0, len(superSet))
+	for _, chanID := range superSet {
+		chanIDs = append(chanIDs, chanID.ToUint64())
+	}
+
+	newChanIDs, err := c.graph.FilterKnownChanIDs(chanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredIDs := make([]lnwire.ShortChannelID, 0, len(newChanIDs))
+	for _, chanID := range newChanIDs {
+		filteredIDs = append(
+			filteredIDs, lnwire.NewShortChanIDFromInt(chanID),
+		)
+	}
+
+	return filteredIDs, nil
+}
+
+// FilterChannelRange returns the set of channels that we created between the
+// start height and the end height, inclusive.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *ChanSeries) FilterChannelRange(startHeight,
+	endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	chansInRange, err := c.graph.FilterChannelRange(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	chanResp := make([]lnwire.ShortChannelID, 0, len(chansInRange))
+	for _, chanID := range chansInRange {
+		chanResp = append(
+			chanResp, lnwire.NewShortChanIDFromInt(chanID),
+		)
+	}
+
+	return chanResp, nil
+}
+
+// FetchChanAnns returns a full set of channel announcements as well as their
+// updates that match the set of specified short channel ID's, followed by the
+// node announcements of the nodes of those channels.
+//
+// NOTE: This is part of the ChannelGraphTimeSeries interface.
+func (c *ChanSeries) FetchChanAnns(
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	chanIDs := make([]uint64, 0, len(shortChanIDs))
+	for _, chanID := range shortChanIDs {
+		chanIDs = append(chanIDs, chanID.ToUint64())
+	}
+
+	channels, err := c.graph.FetchChanInfos(chanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll use this map to ensure we don't send the same node
+	// announcement more than one time as one node may have many channel
+	// anns we'll need to send.
+	nodePubsSent := make(map[[33]byte]struct{})
+	var nodePubs []*btcec.PublicKey
+	addNode := func(pub *btcec.PublicKey) {
+		var nodePub [33]byte
+		copy(nodePub[:], pub.SerializeCompressed())
+		if _, ok := nodePubsSent[nodePub]; ok {
+			return
+		}
+
+		nodePubsSent[nodePub] = struct{}{}
+		nodePubs = append(nodePubs, pub)
+	}
+
+	chanAnns := make([]lnwire.Message, 0, len(channels)*3)
+	for _, channel := range channels {
+		// If the channel doesn't have an authentication proof, then we
+		// won't send it over as it may not yet be finalized, or be a
+		// non-advertised channel.
+		if channel.Info.AuthProof == nil {
+			continue
+		}
+
+		chanAnn, edge1, edge2 := createChanAnnouncement(
+			channel.Info.AuthProof, channel.Info, channel.Policy1,
+			channel.Policy2,
+		)
+
+		chanAnns = append(chanAnns, chanAnn)
+		if edge1 != nil {
+			chanAnns = append(chanAnns, edge1)
+		}
+		if edge2 != nil {
+			chanAnns = append(chanAnns, edge2)
+		}
+
+		addNode(channel.Info.NodeKey1)
+		addNode(channel.Info.NodeKey2)
+	}
+
+	// With the channels gathered, we'll follow up with the announcements
+	// of their nodes, skipping any node we never received an announcement
+	// for.
+	for _, pub := range nodePubs {
+		node, err := c.graph.FetchLightningNode(pub)
+		if err == channeldb.ErrGraphNodeNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if !node.HaveNodeAnnouncement {
+			continue
+		}
+
+		nodeAnn, err := recreateNodeAnnouncement(node)
+		if err != nil {
+			return nil, err
+		}
+
+		chanAnns = append(chanAnns, nodeAnn)
+	}
+
+	return chanAnns, nil
+}
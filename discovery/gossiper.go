@@ -19,6 +19,33 @@ import (
 	"github.com/roasbeef/btcd/wire"
 )
 
+// ErrOutgoingChanNotFound is returned when attempting to update the status of
+// a channel which isn't among the outgoing channels known to the router.
+var ErrOutgoingChanNotFound = errors.New("channel not found amongst " +
+	"outgoing channels")
+
+// ErrPeerBanned is returned when a gossip message is received from a peer
+// which is currently banned for sending us invalid or excessive gossip.
+var ErrPeerBanned = errors.New("peer is banned from gossiping")
+
+// ErrUpdateRateLimited is returned when a ChannelUpdate or NodeAnnouncement
+// is dropped as its channel or node has been updated too often recently.
+var ErrUpdateRateLimited = errors.New("update rate limited")
+
+const (
+	// minKeepAliveInterval is the minimum interval at which a node may
+	// re-announce a channel or node announcement with unchanged content,
+	// in order to keep it from being pruned by the rest of the network.
+	// Updates which don't change any content within this interval are
+	// considered duplicates.
+	minKeepAliveInterval = 24 * time.Hour
+
+	// gossipStatePruneInterval is the interval at which we prune the
+	// rate limiting and ban state of channels, nodes, and peers which
+	// haven't gossiped recently.
+	gossipStatePruneInterval = 10 * time.Minute
+)
+
 // networkMsg couples a routing related wire message with the peer that
 // originally sent it.
 type networkMsg struct {
@@ -47,6 +74,17 @@ type feeUpdateRequest struct {
 	errResp chan error
 }
 
+// chanStatusRequest is a request that is sent to the gossiper when a caller
+// wishes to announce one of our channels as either enabled or disabled. A new
+// ChannelUpdate will be crafted to be sent out during the next broadcast
+// epoch, and the new status committed to the lower layer.
+type chanStatusRequest struct {
+	chanPoint wire.OutPoint
+	disabled  bool
+
+	errResp chan error
+}
+
 // Config defines the configuration for the service. ALL elements within the
 // configuration MUST be non-nil for the service to carry out its duties.
 type Config struct {
@@ -74,10 +112,10 @@ type Config struct {
 	Notifier chainntnfs.ChainNotifier
 
 	// Broadcast broadcasts a particular set of announcements to all peers
-	// that the daemon is connected to. If supplied, the exclude parameter
-	// indicates that the target peer should be excluded from the
-	// broadcast.
-	Broadcast func(exclude *btcec.PublicKey, msg ...lnwire.Message) error
+	// that the daemon is connected to. If supplied, the skips parameter
+	// holds the serialized public keys of the peers which should be
+	// excluded from the broadcast.
+	Broadcast func(skips map[[33]byte]struct{}, msg ...lnwire.Message) error
 
 	// SendToPeer is a function which allows the service to send a set of
 	// messages to a particular peer identified by the target public key.
@@ -104,6 +142,60 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// ChannelSeries is an interface that provides time and block based
+	// querying into our view of the channel graph. It's used to answer
+	// the gossip queries of peers which support them, and to determine
+	// which channels we're missing.
+	ChannelSeries ChannelGraphTimeSeries
+
+	// NumActiveSyncers is the number of peers which support gossip queries
+	// that we'll actively query for new channels and updates. Gossip
+	// query peers beyond this number are only answered when they query
+	// us.
+	NumActiveSyncers int
+
+	// UpdateInterval is the interval at which each channel direction
+	// and node replenishes its allowance of updates. Any ChannelUpdate or
+	// NodeAnnouncement received beyond this allowance is dropped. If
+	// zero, then updates aren't rate limited.
+	UpdateInterval time.Duration
+
+	// MaxUpdateBurst is the maximum number of updates we'll accept for a
+	// channel direction or node in a burst, before rate limiting it.
+	MaxUpdateBurst int
+
+	// BanThreshold is the ban score at which a peer is banned for sending
+	// us invalid or excessive gossip. If zero, then peers are never
+	// banned.
+	BanThreshold uint32
+
+	// BanDuration is the duration for which all gossip from a banned peer
+	// is ignored.
+	BanDuration time.Duration
+}
+
+// Stats summarizes the number of remote gossip messages which the
+// AuthenticatedGossiper has dropped, broken down by the reason they were
+// dropped.
+type Stats struct {
+	// NumRateLimited is the number of updates dropped as their channel
+	// or node was being updated too often.
+	NumRateLimited uint64
+
+	// NumDuplicate is the number of updates dropped as they didn't change
+	// the content of the latest update we know of.
+	NumDuplicate uint64
+
+	// NumInvalid is the number of messages which failed validation.
+	NumInvalid uint64
+
+	// NumFromBannedPeers is the number of messages ignored as they were
+	// sent by a banned peer.
+	NumFromBannedPeers uint64
+
+	// NumBannedPeers is the number of peers which are currently banned.
+	NumBannedPeers uint32
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -115,6 +207,14 @@ type Config struct {
 // incoming message are expected to be well formed and signed. Invalid messages
 // will be rejected by this struct.
 type AuthenticatedGossiper struct {
+	// The following counters track the number of remote gossip messages
+	// that were dropped. They're placed at the start of the struct to
+	// ensure 64-bit alignment.
+	numRateLimited     uint64 // To be used atomically.
+	numDuplicate       uint64 // To be used atomically.
+	numInvalid         uint64 // To be used atomically.
+	numFromBannedPeers uint64 // To be used atomically.
+
 	// Parameters which are needed to properly handle the start and stop of
 	// the service.
 	started uint32
@@ -161,12 +261,35 @@ type AuthenticatedGossiper struct {
 	// a set of channels is sent over.
 	feeUpdates chan *feeUpdateRequest
 
+	// chanStatusUpdates is a channel that requests to enable or disable
+	// one of our channels is sent over.
+	chanStatusUpdates chan *chanStatusRequest
+
 	// bestHeight is the height of the block at the tip of the main chain
 	// as we know it.
 	bestHeight uint32
 
 	// selfKey is the identity public key of the backing Lighting node.
 	selfKey *btcec.PublicKey
+
+	// syncerMtx guards peerSyncers.
+	syncerMtx sync.RWMutex
+
+	// peerSyncers maps the serialized public key of each connected peer
+	// which supports gossip queries to the gossipSyncer which
+	// synchronizes our view of the channel graph with it. Such peers are
+	// never sent our entire graph, and only receive the broadcast
+	// announcements which fall within their update horizon.
+	peerSyncers map[[33]byte]*gossipSyncer
+
+	// rateLimiter limits the rate at which we accept updates for each
+	// channel direction and node. It's only accessed by the
+	// networkHandler.
+	rateLimiter *updateRateLimiter
+
+	// banMan tracks the ban score of each peer which sends us invalid or
+	// excessive gossip, banning those which misbehave too often.
+	banMan *banManager
 }
 
 // New creates a new AuthenticatedGossiper instance, initialized with the
@@ -184,8 +307,14 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		quit:                   make(chan struct{}),
 		syncRequests:           make(chan *syncRequest),
 		feeUpdates:             make(chan *feeUpdateRequest),
+		chanStatusUpdates:      make(chan *chanStatusRequest),
 		prematureAnnouncements: make(map[uint32][]*networkMsg),
 		waitingProofs:          storage,
+		peerSyncers:            make(map[[33]byte]*gossipSyncer),
+		rateLimiter: newUpdateRateLimiter(
+			cfg.UpdateInterval, cfg.MaxUpdateBurst,
+		),
+		banMan: newBanManager(cfg.BanThreshold, cfg.BanDuration),
 	}, nil
 }
 
@@ -227,6 +356,29 @@ func (d *AuthenticatedGossiper) PropagateFeeUpdate(newSchema routing.FeeSchema,
 	}
 }
 
+// PropagateChanStatus signals the AuthenticatedGossiper to announce the target
+// channel as either disabled or enabled. If the channel already has the
+// requested status, then no new update is announced. As with fee updates, the
+// new status is first committed to the router, then a newly signed
+// ChannelUpdate is broadcast to the network.
+func (d *AuthenticatedGossiper) PropagateChanStatus(chanPoint wire.OutPoint,
+	disabled bool) error {
+
+	errChan := make(chan error, 1)
+	statusUpdate := &chanStatusRequest{
+		chanPoint: chanPoint,
+		disabled:  disabled,
+		errResp:   errChan,
+	}
+
+	select {
+	case d.chanStatusUpdates <- statusUpdate:
+		return <-errChan
+	case <-d.quit:
+		return fmt.Errorf("AuthenticatedGossiper shutting down")
+	}
+}
+
 // Start spawns network messages handler goroutine and registers on new block
 // notifications in order to properly handle the premature announcements.
 func (d *AuthenticatedGossiper) Start() error {
@@ -265,10 +417,147 @@ func (d *AuthenticatedGossiper) Stop() {
 
 	log.Info("Authenticated Gossiper is stopping")
 
+	d.syncerMtx.Lock()
+	for _, syncer := range d.peerSyncers {
+		syncer.Stop()
+	}
+	d.syncerMtx.Unlock()
+
 	close(d.quit)
 	d.wg.Wait()
 }
 
+// InitSyncState is called by outside sub-systems when a connection is
+// established to a new peer that understands how to perform gossip queries.
+// A gossipSyncer is created for the peer, which either actively synchronizes
+// our view of the channel graph with it, or merely answers its queries,
+// depending on the number of active syncers we already have.
+func (d *AuthenticatedGossiper) InitSyncState(peer *btcec.PublicKey) {
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	// If we already have a syncer for this peer, then we'll exit early as
+	// we don't want to override it.
+	var nodeID [33]byte
+	copy(nodeID[:], peer.SerializeCompressed())
+	if _, ok := d.peerSyncers[nodeID]; ok {
+		return
+	}
+
+	var numActive int
+	for _, syncer := range d.peerSyncers {
+		if syncer.cfg.syncChanUpdates {
+			numActive++
+		}
+	}
+	syncChanUpdates := numActive < d.cfg.NumActiveSyncers
+
+	log.Infof("Creating new gossipSyncer for peer=%x, active=%v",
+		nodeID[:], syncChanUpdates)
+
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:       d.cfg.ChainHash,
+		peerPub:         nodeID,
+		syncChanUpdates: syncChanUpdates,
+		channelSeries:   d.cfg.ChannelSeries,
+		encodingType:    lnwire.EncodingSortedPlain,
+		chunkSize:       lnwire.MaxPlainShortChanIDs,
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			return d.cfg.SendToPeer(peer, msgs...)
+		},
+	})
+	d.peerSyncers[nodeID] = syncer
+
+	syncer.Start()
+}
+
+// PruneSyncState is called by outside sub-systems once a peer that we were
+// previously connected to has been disconnected. In this case we can stop
+// the existing gossipSyncer assigned to the peer and free up resources.
+func (d *AuthenticatedGossiper) PruneSyncState(peer *btcec.PublicKey) {
+	var nodeID [33]byte
+	copy(nodeID[:], peer.SerializeCompressed())
+
+	d.syncerMtx.Lock()
+	syncer, ok := d.peerSyncers[nodeID]
+	delete(d.peerSyncers, nodeID)
+	d.syncerMtx.Unlock()
+
+	if !ok {
+		return
+	}
+
+	log.Infof("Removing gossipSyncer for peer=%x", nodeID[:])
+
+	syncer.Stop()
+}
+
+// findGossipSyncer returns the gossipSyncer of the target peer, if it has
+// one.
+func (d *AuthenticatedGossiper) findGossipSyncer(
+	peer *btcec.PublicKey) (*gossipSyncer, bool) {
+
+	var nodeID [33]byte
+	copy(nodeID[:], peer.SerializeCompressed())
+
+	d.syncerMtx.RLock()
+	syncer, ok := d.peerSyncers[nodeID]
+	d.syncerMtx.RUnlock()
+
+	return syncer, ok
+}
+
+// Stats returns a snapshot of the number of remote gossip messages which have
+// been dropped, along with the number of currently banned peers.
+func (d *AuthenticatedGossiper) Stats() *Stats {
+	return &Stats{
+		NumRateLimited:     atomic.LoadUint64(&d.numRateLimited),
+		NumDuplicate:       atomic.LoadUint64(&d.numDuplicate),
+		NumInvalid:         atomic.LoadUint64(&d.numInvalid),
+		NumFromBannedPeers: atomic.LoadUint64(&d.numFromBannedPeers),
+		NumBannedPeers:     d.banMan.numBanned(time.Now()),
+	}
+}
+
+// penalizePeer adds the passed penalty to the ban score of the peer that sent
+// us the target message, banning it if its score reaches the ban threshold.
+func (d *AuthenticatedGossiper) penalizePeer(nMsg *networkMsg,
+	penalty uint32) {
+
+	if !nMsg.isRemote || nMsg.peer == nil {
+		return
+	}
+
+	var nodeID [33]byte
+	copy(nodeID[:], nMsg.peer.SerializeCompressed())
+
+	if d.banMan.penalize(nodeID, penalty, time.Now()) {
+		log.Warnf("Banning peer=%x for %v due to invalid or "+
+			"excessive gossip", nodeID[:], d.cfg.BanDuration)
+	}
+}
+
+// broadcast sends the passed announcements to all of our peers. Peers which
+// support gossip queries are handed only the announcements within their
+// update horizon by their gossipSyncer, while all other peers receive them
+// in full.
+func (d *AuthenticatedGossiper) broadcast(msgs []lnwire.Message) error {
+	d.syncerMtx.RLock()
+	syncers := make([]*gossipSyncer, 0, len(d.peerSyncers))
+	skips := make(map[[33]byte]struct{}, len(d.peerSyncers))
+	for nodeID, syncer := range d.peerSyncers {
+		syncers = append(syncers, syncer)
+		skips[nodeID] = struct{}{}
+	}
+	d.syncerMtx.RUnlock()
+
+	for _, syncer := range syncers {
+		syncer.FilterGossipMsgs(msgs...)
+	}
+
+	return d.cfg.Broadcast(skips, msgs...)
+}
+
 // ProcessRemoteAnnouncement sends a new remote announcement message along with
 // the peer that sent the routing message. The announcement will be processed
 // then added to a queue for batched trickled announcement to all connected
@@ -277,6 +566,44 @@ func (d *AuthenticatedGossiper) Stop() {
 func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	src *btcec.PublicKey) chan error {
 
+	// All gossip from peers which have been banned for misbehaving is
+	// ignored until the ban expires.
+	if src != nil {
+		var nodeID [33]byte
+		copy(nodeID[:], src.SerializeCompressed())
+
+		if d.banMan.isBanned(nodeID, time.Now()) {
+			atomic.AddUint64(&d.numFromBannedPeers, 1)
+
+			errChan := make(chan error, 1)
+			errChan <- ErrPeerBanned
+			return errChan
+		}
+	}
+
+	// Gossip queries are handled by the gossipSyncer of the peer which
+	// sent them, rather than the main network handler.
+	switch msg.(type) {
+	case *lnwire.QueryShortChanIDs,
+		*lnwire.QueryChannelRange,
+		*lnwire.ReplyChannelRange,
+		*lnwire.ReplyShortChanIDsEnd,
+		*lnwire.GossipTimestampRange:
+
+		errChan := make(chan error, 1)
+		syncer, ok := d.findGossipSyncer(src)
+		if !ok {
+			errChan <- fmt.Errorf("received gossip query %T from "+
+				"peer %x which doesn't support them", msg,
+				src.SerializeCompressed())
+			return errChan
+		}
+
+		syncer.ProcessQueryMsg(msg)
+		errChan <- nil
+		return errChan
+	}
+
 	nMsg := &networkMsg{
 		msg:      msg,
 		isRemote: true,
@@ -344,6 +671,9 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	trickleTimer := time.NewTicker(d.cfg.TrickleDelay)
 	defer trickleTimer.Stop()
 
+	pruneTimer := time.NewTicker(gossipStatePruneInterval)
+	defer pruneTimer.Stop()
+
 	for {
 		select {
 		// A new fee update has arrived. We'll commit it to the
@@ -368,6 +698,27 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 			feeUpdate.errResp <- nil
 
+		// A request to enable or disable one of our channels has
+		// arrived. As with fee updates, we'll commit the new status,
+		// then broadcast the newly signed ChannelUpdate.
+		case statusUpdate := <-d.chanStatusUpdates:
+			newChanUpdate, err := d.processChanStatusUpdate(
+				statusUpdate,
+			)
+			if err != nil {
+				log.Debugf("Unable to craft channel status "+
+					"update: %v", err)
+				statusUpdate.errResp <- err
+				continue
+			}
+
+			if newChanUpdate != nil {
+				announcementBatch = append(announcementBatch,
+					newChanUpdate)
+			}
+
+			statusUpdate.errResp <- nil
+
 		case announcement := <-d.networkMsgs:
 			// Process the network announcement to determine if
 			// this is either a new announcement from our PoV or an
@@ -436,7 +787,7 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 			// If we have new things to announce then broadcast
 			// them to all our immediately connected peers.
-			err := d.cfg.Broadcast(nil, announcementBatch...)
+			err := d.broadcast(announcementBatch)
 			if err != nil {
 				log.Errorf("unable to send batch "+
 					"announcements: %v", err)
@@ -489,11 +840,19 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			// With all the wire announcements properly crafted,
 			// we'll broadcast our known outgoing channel to all
 			// our immediate peers.
-			if err := d.cfg.Broadcast(nil, selfChans...); err != nil {
+			if err := d.broadcast(selfChans); err != nil {
 				log.Errorf("unable to re-broadcast "+
 					"channels: %v", err)
 			}
 
+		// The prune timer has ticked, so we'll discard the rate
+		// limiting and ban state of all channels, nodes and peers that
+		// haven't gossiped recently enough for it to matter.
+		case <-pruneTimer.C:
+			now := time.Now()
+			d.rateLimiter.prune(now)
+			d.banMan.prune(now)
+
 		// We've just received a new request to synchronize a peer with
 		// our latest lightning network topology state. This indicates
 		// that a peer has just connected for the first time, so for
@@ -577,49 +936,130 @@ func (d *AuthenticatedGossiper) processFeeChanUpdate(feeUpdate *feeUpdateRequest
 	signedAnns := make([]lnwire.Message, len(chanUpdates))
 	for i, chanUpdate := range chanUpdates {
 		edge := chanEdges[chanUpdate.ShortChannelID]
-		now := time.Now()
 
 		// First, we'll apply the new few schema update to the channel
 		// update and also the backing database struct.
 		chanUpdate.BaseFee = uint32(feeUpdate.newSchema.BaseFee)
 		chanUpdate.FeeRate = feeUpdate.newSchema.FeeRate
-		chanUpdate.Timestamp = uint32(now.Unix())
 		edge.FeeBaseMSat = feeUpdate.newSchema.BaseFee
 		edge.FeeProportionalMillionths = lnwire.MilliSatoshi(
 			feeUpdate.newSchema.FeeRate,
 		)
-		edge.LastUpdate = now
 
-		// With the update applied, we'll generate a new signature over
-		// a digest of the channel announcement itself.
-		sig, err := SignAnnouncement(d.cfg.AnnSigner, d.selfKey,
-			chanUpdate)
-		if err != nil {
+		// With the update applied, we'll sign it, and commit it to
+		// the router.
+		if err := d.signAndCommitChanUpdate(chanUpdate, edge); err != nil {
 			return nil, err
 		}
-
-		// Next, we'll set the new signature in place, and update the
-		// reference in the backing slice.
-		edge.Signature = sig
-		chanUpdate.Signature = sig
 		signedAnns[i] = chanUpdate
+	}
 
-		// To ensure that our signature is valid, we'll verify it
-		// ourself before committing it to the slice returned.
-		err = d.validateChannelUpdateAnn(d.selfKey, chanUpdate)
-		if err != nil {
-			return nil, fmt.Errorf("generated invalid channel update "+
-				"sig: %v", err)
+	return signedAnns, nil
+}
+
+// processChanStatusUpdate generates a new channel update for the channel
+// targeted by the passed request with the disabled bit set or cleared as
+// requested, and commits the new status to the backing ChannelGraphSource. If
+// the channel already has the requested status, then nil is returned.
+func (d *AuthenticatedGossiper) processChanStatusUpdate(
+	statusUpdate *chanStatusRequest) (*lnwire.ChannelUpdate, error) {
+
+	var (
+		chanUpdate *lnwire.ChannelUpdate
+		edge       *channeldb.ChannelEdgePolicy
+	)
+	err := d.cfg.Router.ForAllOutgoingChannels(func(info *channeldb.ChannelEdgeInfo,
+		p *channeldb.ChannelEdgePolicy) error {
+
+		if info.ChannelPoint != statusUpdate.chanPoint {
+			return nil
 		}
 
-		// Finally, we'll update the fee schema for this edge on disk.
-		edge.Node.PubKey.Curve = nil
-		if err := d.cfg.Router.UpdateEdge(edge); err != nil {
-			return nil, err
+		chanUpdate = &lnwire.ChannelUpdate{
+			Signature:       p.Signature,
+			ChainHash:       info.ChainHash,
+			ShortChannelID:  lnwire.NewShortChanIDFromInt(p.ChannelID),
+			Timestamp:       uint32(p.LastUpdate.Unix()),
+			Flags:           p.Flags,
+			TimeLockDelta:   p.TimeLockDelta,
+			HtlcMinimumMsat: p.MinHTLC,
+			BaseFee:         uint32(p.FeeBaseMSat),
+			FeeRate:         uint32(p.FeeProportionalMillionths),
 		}
+		edge = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if chanUpdate == nil {
+		return nil, ErrOutgoingChanNotFound
 	}
 
-	return signedAnns, nil
+	// If the channel already has the requested status, then there's
+	// nothing new to announce.
+	isDisabled := chanUpdate.Flags&lnwire.ChanUpdateDisabled != 0
+	if isDisabled == statusUpdate.disabled {
+		return nil, nil
+	}
+
+	if statusUpdate.disabled {
+		chanUpdate.Flags |= lnwire.ChanUpdateDisabled
+	} else {
+		chanUpdate.Flags &^= lnwire.ChanUpdateDisabled
+	}
+	edge.Flags = chanUpdate.Flags
+
+	log.Infof("Announcing ChannelPoint(%v) as disabled=%v",
+		statusUpdate.chanPoint, statusUpdate.disabled)
+
+	if err := d.signAndCommitChanUpdate(chanUpdate, edge); err != nil {
+		return nil, err
+	}
+
+	return chanUpdate, nil
+}
+
+// signAndCommitChanUpdate timestamps and signs a modified update for one of
+// our channels, then commits the matching edge policy to the router. The
+// passed edge policy MUST already reflect the changes made to the update.
+func (d *AuthenticatedGossiper) signAndCommitChanUpdate(
+	chanUpdate *lnwire.ChannelUpdate,
+	edge *channeldb.ChannelEdgePolicy) error {
+
+	// The router ignores any update which isn't newer than the one it
+	// already has, so we'll ensure the timestamp advances even if the
+	// channel was last updated within the same second.
+	now := time.Now()
+	if now.Unix() <= edge.LastUpdate.Unix() {
+		now = time.Unix(edge.LastUpdate.Unix()+1, 0)
+	}
+	chanUpdate.Timestamp = uint32(now.Unix())
+	edge.LastUpdate = now
+
+	// With the update applied, we'll generate a new signature over a
+	// digest of the channel announcement itself.
+	sig, err := SignAnnouncement(d.cfg.AnnSigner, d.selfKey, chanUpdate)
+	if err != nil {
+		return err
+	}
+
+	// Next, we'll set the new signature in place within both the update
+	// and the edge policy.
+	edge.Signature = sig
+	chanUpdate.Signature = sig
+
+	// To ensure that our signature is valid, we'll verify it ourself
+	// before committing it.
+	err = d.validateChannelUpdateAnn(d.selfKey, chanUpdate)
+	if err != nil {
+		return fmt.Errorf("generated invalid channel update sig: %v",
+			err)
+	}
+
+	// Finally, we'll update the policy for this edge on disk.
+	edge.Node.PubKey.Curve = nil
+	return d.cfg.Router.UpdateEdge(edge)
 }
 
 // processNetworkAnnouncement processes a new network relate authenticated
@@ -647,6 +1087,17 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				err := errors.Errorf("unable to validate "+
 					"node announcement: %v", err)
 				log.Error(err)
+
+				atomic.AddUint64(&d.numInvalid, 1)
+				d.penalizePeer(nMsg, invalidGossipPenalty)
+
+				nMsg.err <- err
+				return nil
+			}
+
+			// Only announcements which actually change the node's
+			// information are accepted, and only at a limited rate.
+			if drop, err := d.limitNodeAnn(nMsg, msg); drop {
 				nMsg.err <- err
 				return nil
 			}
@@ -724,6 +1175,10 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 					"announcement: %v", err)
 
 				log.Error(err)
+
+				atomic.AddUint64(&d.numInvalid, 1)
+				d.penalizePeer(nMsg, invalidGossipPenalty)
+
 				nMsg.err <- err
 				return nil
 			}
@@ -823,7 +1278,18 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// Get the node pub key as far as we don't have it in channel
 		// update announcement message. We'll need this to properly
 		// verify message signature.
-		chanInfo, _, _, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
+		chanInfo, edge1, edge2, err := d.cfg.Router.GetChannelByID(
+			msg.ShortChannelID,
+		)
+
+		// If we don't know of the channel, then it may have been
+		// pruned as a zombie. We'll still validate the update against
+		// the keys of the zombie, as a fresh update resurrects it.
+		if err == channeldb.ErrEdgeNotFound {
+			chanInfo, err = d.cfg.Router.GetZombieChannel(
+				msg.ShortChannelID,
+			)
+		}
 		if err != nil {
 			err := errors.Errorf("unable to validate "+
 				"channel update short_chan_id=%v: %v",
@@ -835,12 +1301,17 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 
 		// The flag on the channel update announcement tells us "which"
 		// side of the channels directed edge is being updated.
-		var pubKey *btcec.PublicKey
-		switch msg.Flags {
+		var (
+			pubKey     *btcec.PublicKey
+			prevPolicy *channeldb.ChannelEdgePolicy
+		)
+		switch msg.Flags & lnwire.ChanUpdateDirection {
 		case 0:
 			pubKey = chanInfo.NodeKey1
+			prevPolicy = edge1
 		case 1:
 			pubKey = chanInfo.NodeKey2
+			prevPolicy = edge2
 		}
 
 		// Validate the channel announcement with the expected public
@@ -852,10 +1323,26 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				spew.Sdump(msg.ShortChannelID), err)
 
 			log.Error(rErr)
+
+			atomic.AddUint64(&d.numInvalid, 1)
+			d.penalizePeer(nMsg, invalidGossipPenalty)
+
 			nMsg.err <- rErr
 			return nil
 		}
 
+		// Only updates which actually change the channel's policy are
+		// accepted from remote peers, and only at a limited rate.
+		if nMsg.isRemote {
+			drop, err := d.limitChanUpdate(
+				nMsg, msg, pubKey, prevPolicy,
+			)
+			if drop {
+				nMsg.err <- err
+				return nil
+			}
+		}
+
 		update := &channeldb.ChannelEdgePolicy{
 			Signature:                 msg.Signature,
 			ChannelID:                 shortChanID,
@@ -1117,6 +1604,105 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 	}
 }
 
+// limitNodeAnn determines whether a validated remote NodeAnnouncement should
+// be dropped, either as it doesn't change the node's current information, or
+// as the node is being updated too often. If the announcement is to be
+// dropped, then true is returned along with the error to report to the
+// caller.
+func (d *AuthenticatedGossiper) limitNodeAnn(nMsg *networkMsg,
+	msg *lnwire.NodeAnnouncement) (bool, error) {
+
+	var nodeID [33]byte
+	copy(nodeID[:], msg.NodeID.SerializeCompressed())
+
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+
+	node, err := d.cfg.Router.FetchLightningNode(msg.NodeID)
+	if err == nil && node.HaveNodeAnnouncement {
+		// Announcements which aren't newer than our current one will
+		// be rejected by the router without being rebroadcast, so
+		// there's no need to count them against the node.
+		if !timestamp.After(node.LastUpdate) {
+			return false, nil
+		}
+
+		// If the announcement doesn't change any of the node's
+		// information, and isn't a keep-alive for it, then it's
+		// merely a duplicate.
+		sinceLast := timestamp.Sub(node.LastUpdate)
+		if isSameNodeInfo(node, msg) && sinceLast < minKeepAliveInterval {
+			atomic.AddUint64(&d.numDuplicate, 1)
+			log.Debugf("Ignoring duplicate announcement for "+
+				"node=%x", nodeID[:])
+			return true, nil
+		}
+	}
+
+	if d.rateLimiter.allowNodeAnn(nodeID, time.Now()) {
+		return false, nil
+	}
+
+	atomic.AddUint64(&d.numRateLimited, 1)
+	log.Debugf("Rate limiting announcement for node=%x", nodeID[:])
+
+	// We'll only penalize the peer if the announcement is its own, as it
+	// may otherwise just be relaying the announcements of a noisy node.
+	if nMsg.peer != nil && nMsg.peer.IsEqual(msg.NodeID) {
+		d.penalizePeer(nMsg, excessiveGossipPenalty)
+	}
+
+	return true, ErrUpdateRateLimited
+}
+
+// limitChanUpdate determines whether a validated remote ChannelUpdate should
+// be dropped, either as it doesn't change the current policy of the channel
+// direction, or as the channel direction is being updated too often. If the
+// update is to be dropped, then true is returned along with the error to
+// report to the caller.
+func (d *AuthenticatedGossiper) limitChanUpdate(nMsg *networkMsg,
+	msg *lnwire.ChannelUpdate, pubKey *btcec.PublicKey,
+	prevPolicy *channeldb.ChannelEdgePolicy) (bool, error) {
+
+	shortChanID := msg.ShortChannelID.ToUint64()
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+
+	if prevPolicy != nil {
+		// Updates which aren't newer than our current one will be
+		// rejected by the router without being rebroadcast, so
+		// there's no need to count them against the channel.
+		if !timestamp.After(prevPolicy.LastUpdate) {
+			return false, nil
+		}
+
+		// If the update doesn't change the policy of the channel
+		// direction, and isn't a keep-alive for it, then it's merely
+		// a duplicate.
+		sinceLast := timestamp.Sub(prevPolicy.LastUpdate)
+		if isSamePolicy(prevPolicy, msg) && sinceLast < minKeepAliveInterval {
+			atomic.AddUint64(&d.numDuplicate, 1)
+			log.Debugf("Ignoring duplicate update for "+
+				"short_chan_id=%v", shortChanID)
+			return true, nil
+		}
+	}
+
+	direction := msg.Flags & lnwire.ChanUpdateDirection
+	if d.rateLimiter.allowChanUpdate(shortChanID, direction, time.Now()) {
+		return false, nil
+	}
+
+	atomic.AddUint64(&d.numRateLimited, 1)
+	log.Debugf("Rate limiting update for short_chan_id=%v", shortChanID)
+
+	// We'll only penalize the peer if the update is its own, as it may
+	// otherwise just be relaying the updates of a noisy node.
+	if nMsg.peer != nil && nMsg.peer.IsEqual(pubKey) {
+		d.penalizePeer(nMsg, excessiveGossipPenalty)
+	}
+
+	return true, ErrUpdateRateLimited
+}
+
 // synchronizeWithNode attempts to synchronize the target node in the syncReq
 // to the latest channel graph state. In order to accomplish this, (currently)
 // the entire network graph is read from disk, then serialized to the format
@@ -1173,18 +1759,10 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 			return nil
 		}
 
-		alias, err := lnwire.NewNodeAlias(node.Alias)
+		ann, err := recreateNodeAnnouncement(node)
 		if err != nil {
 			return err
 		}
-		ann := &lnwire.NodeAnnouncement{
-			Signature: node.AuthSig,
-			Timestamp: uint32(node.LastUpdate.Unix()),
-			Addresses: node.Addresses,
-			NodeID:    node.PubKey,
-			Alias:     alias,
-			Features:  node.Features,
-		}
 		announceMessages = append(announceMessages, ann)
 
 		numNodes++
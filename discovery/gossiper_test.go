@@ -159,8 +159,22 @@ func (r *mockGraphSource) ForEachNode(func(node *channeldb.LightningNode) error)
 	return nil
 }
 
+// ForAllOutgoingChannels treats the latest policy of each known channel as
+// our outgoing policy for that channel.
 func (r *mockGraphSource) ForAllOutgoingChannels(cb func(i *channeldb.ChannelEdgeInfo,
 	c *channeldb.ChannelEdgePolicy) error) error {
+
+	for chanID, info := range r.infos {
+		edges := r.edges[chanID]
+		if len(edges) == 0 {
+			continue
+		}
+
+		if err := cb(info, edges[len(edges)-1]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +205,24 @@ func (r *mockGraphSource) GetChannelByID(chanID lnwire.ShortChannelID) (
 	return chanInfo, edges[0], edges[1], nil
 }
 
+func (r *mockGraphSource) GetZombieChannel(chanID lnwire.ShortChannelID) (
+	*channeldb.ChannelEdgeInfo, error) {
+
+	return nil, channeldb.ErrEdgeNotFound
+}
+
+func (r *mockGraphSource) FetchLightningNode(
+	pub *btcec.PublicKey) (*channeldb.LightningNode, error) {
+
+	for i := len(r.nodes) - 1; i >= 0; i-- {
+		if r.nodes[i].PubKey.IsEqual(pub) {
+			return r.nodes[i], nil
+		}
+	}
+
+	return nil, channeldb.ErrGraphNodeNotFound
+}
+
 type mockNotifier struct {
 	clientCounter uint32
 	epochClients  map[uint32]chan *chainntnfs.BlockEpoch
@@ -426,7 +458,7 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 	broadcastedMessage := make(chan lnwire.Message, 10)
 	gossiper, err := New(Config{
 		Notifier: notifier,
-		Broadcast: func(_ *btcec.PublicKey, msgs ...lnwire.Message) error {
+		Broadcast: func(_ map[[33]byte]struct{}, msgs ...lnwire.Message) error {
 			for _, msg := range msgs {
 				broadcastedMessage <- msg
 			}
@@ -439,6 +471,7 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 		TrickleDelay:     trickleDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               db,
+		AnnSigner:        &mockSigner{nodeKeyPriv1},
 	}, nodeKeyPub1)
 	if err != nil {
 		cleanUpDb()
@@ -835,3 +868,267 @@ func TestOrphanSignatureAnnouncement(t *testing.T) {
 		t.Fatal("wrong number of objects in storage")
 	}
 }
+
+// TestRateLimitChannelUpdates checks that remote channel updates which don't
+// change the channel's policy are ignored, that updates beyond the allowed
+// burst are rate limited, and that peers sending us excessive or invalid
+// gossip are banned.
+func TestRateLimitChannelUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	// We'll allow a burst of two updates per hour for each channel
+	// direction, and ban peers once their ban score reaches two.
+	ctx.gossiper.rateLimiter = newUpdateRateLimiter(time.Hour, 2)
+	ctx.gossiper.banMan = newBanManager(2, time.Hour)
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// newUpdate creates a new update for the channel, signed by its
+	// first node.
+	newUpdate := func(timestamp, feeRate uint32,
+		priv *btcec.PrivateKey) *lnwire.ChannelUpdate {
+
+		a := &lnwire.ChannelUpdate{
+			Timestamp:     timestamp,
+			TimeLockDelta: 144,
+			FeeRate:       feeRate,
+			BaseFee:       1000,
+		}
+
+		signer := mockSigner{priv}
+		sig, err := SignAnnouncement(&signer, priv.PubKey(), a)
+		if err != nil {
+			t.Fatalf("can't sign update: %v", err)
+		}
+		a.Signature = sig
+
+		return a
+	}
+
+	// The first two updates fit within the allowed burst, so they should
+	// both be accepted.
+	for i := uint32(0); i < 2; i++ {
+		ua := newUpdate(100+i, 10+i, nodeKeyPriv1)
+		err := <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub1)
+		if err != nil {
+			t.Fatalf("can't process remote announcement: %v", err)
+		}
+	}
+	if len(ctx.router.edges[0]) != 2 {
+		t.Fatalf("expected 2 updates to be added to router, "+
+			"instead have %v", len(ctx.router.edges[0]))
+	}
+
+	// An update which only bumps the timestamp of the first update should
+	// be ignored as a duplicate.
+	ua := newUpdate(200, 10, nodeKeyPriv1)
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// The burst has been used up, so any further updates which change
+	// the channel's policy should be rate limited. As the updates
+	// originate from the peer itself, this should get it banned.
+	for i := uint32(0); i < 2; i++ {
+		ua := newUpdate(300+i, 20+i, nodeKeyPriv1)
+		err := <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub1)
+		if err != ErrUpdateRateLimited {
+			t.Fatalf("expected update to be rate limited, "+
+				"instead got: %v", err)
+		}
+	}
+	if len(ctx.router.edges[0]) != 2 {
+		t.Fatalf("expected 2 updates to be added to router, "+
+			"instead have %v", len(ctx.router.edges[0]))
+	}
+
+	ua = newUpdate(400, 30, nodeKeyPriv1)
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub1)
+	if err != ErrPeerBanned {
+		t.Fatalf("expected peer to be banned, instead got: %v", err)
+	}
+
+	// An update carrying an invalid signature should get the peer which
+	// sent it banned right away.
+	ua = newUpdate(500, 40, nodeKeyPriv2)
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+	if err == nil {
+		t.Fatal("expected invalid update to be rejected")
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != ErrPeerBanned {
+		t.Fatalf("expected peer to be banned, instead got: %v", err)
+	}
+
+	// Only the channel announcement and the first two updates should have
+	// been broadcast.
+	var numBroadcast int
+	timeout := time.After(2 * trickleDelay)
+loop:
+	for {
+		select {
+		case <-ctx.broadcastedMessage:
+			numBroadcast++
+		case <-timeout:
+			break loop
+		}
+	}
+	if numBroadcast != 3 {
+		t.Fatalf("expected 3 messages to be broadcast, instead "+
+			"%v were", numBroadcast)
+	}
+
+	expectedStats := &Stats{
+		NumRateLimited:     2,
+		NumDuplicate:       1,
+		NumInvalid:         1,
+		NumFromBannedPeers: 2,
+		NumBannedPeers:     2,
+	}
+	if stats := ctx.gossiper.Stats(); *stats != *expectedStats {
+		t.Fatalf("expected stats %v, instead got %v",
+			expectedStats, stats)
+	}
+}
+
+// TestPropagateChanStatus checks that requests to disable or enable one of
+// our channels result in a newly signed ChannelUpdate being committed to the
+// router and broadcast, unless the channel already has the requested status.
+func TestPropagateChanStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	// A request for a channel unknown to the router should be rejected.
+	err = ctx.gossiper.PropagateChanStatus(*outpoint, true)
+	if err != ErrOutgoingChanNotFound {
+		t.Fatalf("expected ErrOutgoingChanNotFound, got: %v", err)
+	}
+
+	// We'll now add one of our channels, along with our current policy
+	// for it, to the router. The public key of the policy is copied, as
+	// the gossiper strips its curve before committing the policy.
+	nodePub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+	info := &channeldb.ChannelEdgeInfo{
+		ChannelID:    1,
+		NodeKey1:     nodeKeyPub1,
+		NodeKey2:     nodeKeyPub2,
+		ChannelPoint: *outpoint,
+	}
+	if err := ctx.router.AddEdge(info); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+	lastUpdate := time.Unix(int64(prand.Int31()), 0)
+	edge := &channeldb.ChannelEdgePolicy{
+		ChannelID:                 1,
+		LastUpdate:                lastUpdate,
+		TimeLockDelta:             144,
+		MinHTLC:                   1000,
+		FeeBaseMSat:               1000,
+		FeeProportionalMillionths: 1,
+		Node: &channeldb.LightningNode{
+			PubKey: nodePub,
+		},
+	}
+	if err := ctx.router.UpdateEdge(edge); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+
+	// assertStatusUpdate propagates the given status for our channel, and
+	// asserts whether a ChannelUpdate is broadcast as a result.
+	assertStatusUpdate := func(disabled, expectUpdate bool) {
+		err := ctx.gossiper.PropagateChanStatus(*outpoint, disabled)
+		if err != nil {
+			t.Fatalf("unable to propagate channel status: %v", err)
+		}
+
+		select {
+		case msg := <-ctx.broadcastedMessage:
+			if !expectUpdate {
+				t.Fatalf("unexpected %T broadcast", msg)
+			}
+
+			chanUpdate, ok := msg.(*lnwire.ChannelUpdate)
+			if !ok {
+				t.Fatalf("expected ChannelUpdate, got %T", msg)
+			}
+			isDisabled := chanUpdate.Flags&lnwire.ChanUpdateDisabled != 0
+			if isDisabled != disabled {
+				t.Fatalf("expected disabled=%v, got %v",
+					disabled, isDisabled)
+			}
+			err := ctx.gossiper.validateChannelUpdateAnn(
+				nodeKeyPub1, chanUpdate,
+			)
+			if err != nil {
+				t.Fatalf("invalid channel update: %v", err)
+			}
+
+		case <-time.After(2 * trickleDelay):
+			if expectUpdate {
+				t.Fatalf("channel update wasn't broadcast")
+			}
+		}
+
+		// The status of the policy committed to the router should
+		// match the requested status regardless.
+		policy := ctx.router.edges[1][len(ctx.router.edges[1])-1]
+		isDisabled := policy.Flags&lnwire.ChanUpdateDisabled != 0
+		if isDisabled != disabled {
+			t.Fatalf("expected committed disabled=%v, got %v",
+				disabled, isDisabled)
+		}
+	}
+
+	// As the channel is currently enabled, enabling it shouldn't result
+	// in a new update.
+	assertStatusUpdate(false, false)
+	if len(ctx.router.edges[1]) != 1 {
+		t.Fatalf("unchanged status was committed to the router")
+	}
+
+	// Disabling the channel should result in a new update, which has a
+	// newer timestamp than our prior policy.
+	assertStatusUpdate(true, true)
+	if len(ctx.router.edges[1]) != 2 {
+		t.Fatalf("new status wasn't committed to the router")
+	}
+	policy := ctx.router.edges[1][1]
+	if !policy.LastUpdate.After(lastUpdate) {
+		t.Fatalf("policy timestamp wasn't advanced")
+	}
+
+	// Disabling the channel once more should be a no-op, while enabling
+	// it should once again result in a new update.
+	assertStatusUpdate(true, false)
+	if len(ctx.router.edges[1]) != 2 {
+		t.Fatalf("unchanged status was committed to the router")
+	}
+	assertStatusUpdate(false, true)
+	if len(ctx.router.edges[1]) != 3 {
+		t.Fatalf("new status wasn't committed to the router")
+	}
+}
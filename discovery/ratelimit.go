@@ -0,0 +1,305 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple token bucket rate limiter. A token is added to the
+// bucket once every interval, up to a maximum of burst tokens, and each
+// permitted event consumes a single token. As a result, bursts of up to burst
+// events are allowed, while the long term rate is limited to one event per
+// interval.
+//
+// NOTE: This struct isn't safe for concurrent use.
+type tokenBucket struct {
+	interval time.Duration
+	burst    float64
+
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newTokenBucket creates a new, full token bucket which replenishes a token
+// once every interval, and holds at most burst tokens.
+func newTokenBucket(interval time.Duration, burst int,
+	now time.Time) *tokenBucket {
+
+	return &tokenBucket{
+		interval:   interval,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: now,
+	}
+}
+
+// refill adds the tokens accumulated since the last refill to the bucket.
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens += float64(elapsed) / float64(b.interval)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastRefill = now
+}
+
+// allow consumes a token from the bucket, returning true if one was
+// available. If false is returned, then the event should be rate limited.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// isFull returns true if the bucket has replenished all of its tokens. A full
+// bucket behaves exactly as a newly created one, so it can safely be
+// discarded.
+func (b *tokenBucket) isFull(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// chanUpdateKey identifies a single direction of a channel, which is the
+// unit that ChannelUpdate messages are rate limited on.
+type chanUpdateKey struct {
+	chanID    uint64
+	direction uint16
+}
+
+// updateRateLimiter limits the rate at which updates for any particular
+// channel direction or node are accepted. Each channel direction and node is
+// tracked using its own token bucket, such that a single noisy channel or
+// node is unable to crowd out the updates of others.
+//
+// NOTE: This struct isn't safe for concurrent use.
+type updateRateLimiter struct {
+	interval time.Duration
+	burst    int
+
+	chanUpdates map[chanUpdateKey]*tokenBucket
+	nodeAnns    map[[33]byte]*tokenBucket
+}
+
+// newUpdateRateLimiter returns a new updateRateLimiter which allows a burst
+// of updates for each channel direction and node, after which only a single
+// update is allowed per interval. If the interval is zero, then rate limiting
+// is disabled.
+func newUpdateRateLimiter(interval time.Duration,
+	burst int) *updateRateLimiter {
+
+	return &updateRateLimiter{
+		interval:    interval,
+		burst:       burst,
+		chanUpdates: make(map[chanUpdateKey]*tokenBucket),
+		nodeAnns:    make(map[[33]byte]*tokenBucket),
+	}
+}
+
+// allowChanUpdate returns true if a ChannelUpdate for the target channel
+// direction should be accepted.
+func (r *updateRateLimiter) allowChanUpdate(chanID uint64, direction uint16,
+	now time.Time) bool {
+
+	if r.interval == 0 {
+		return true
+	}
+
+	key := chanUpdateKey{
+		chanID:    chanID,
+		direction: direction,
+	}
+	bucket, ok := r.chanUpdates[key]
+	if !ok {
+		bucket = newTokenBucket(r.interval, r.burst, now)
+		r.chanUpdates[key] = bucket
+	}
+
+	return bucket.allow(now)
+}
+
+// allowNodeAnn returns true if a NodeAnnouncement for the target node should
+// be accepted.
+func (r *updateRateLimiter) allowNodeAnn(node [33]byte, now time.Time) bool {
+	if r.interval == 0 {
+		return true
+	}
+
+	bucket, ok := r.nodeAnns[node]
+	if !ok {
+		bucket = newTokenBucket(r.interval, r.burst, now)
+		r.nodeAnns[node] = bucket
+	}
+
+	return bucket.allow(now)
+}
+
+// prune removes the buckets of all channel directions and nodes which
+// haven't been updated recently enough to be limited.
+func (r *updateRateLimiter) prune(now time.Time) {
+	for key, bucket := range r.chanUpdates {
+		if bucket.isFull(now) {
+			delete(r.chanUpdates, key)
+		}
+	}
+	for node, bucket := range r.nodeAnns {
+		if bucket.isFull(now) {
+			delete(r.nodeAnns, node)
+		}
+	}
+}
+
+const (
+	// invalidGossipPenalty is the ban score added to a peer each time it
+	// sends us a gossip message which fails validation.
+	invalidGossipPenalty = 25
+
+	// excessiveGossipPenalty is the ban score added to a peer each time
+	// one of its own updates is rate limited.
+	excessiveGossipPenalty = 1
+
+	// banScoreDecayInterval is the interval after which a single point of
+	// a peer's ban score is forgiven.
+	banScoreDecayInterval = time.Minute
+)
+
+// banScore tracks the misbehavior of a single peer.
+type banScore struct {
+	score       uint32
+	lastUpdate  time.Time
+	bannedUntil time.Time
+}
+
+// decay forgives a single point of the ban score for each
+// banScoreDecayInterval which has passed since it was last updated.
+func (s *banScore) decay(now time.Time) {
+	decayed := uint32(now.Sub(s.lastUpdate) / banScoreDecayInterval)
+	if decayed == 0 {
+		return
+	}
+
+	if decayed >= s.score {
+		s.score = 0
+	} else {
+		s.score -= decayed
+	}
+	s.lastUpdate = s.lastUpdate.Add(
+		time.Duration(decayed) * banScoreDecayInterval,
+	)
+}
+
+// banManager keeps a decaying ban score for each peer which sends us invalid
+// or excessive gossip. Once a peer's score reaches the ban threshold, the
+// peer is banned, and all of its gossip is ignored until the ban expires.
+type banManager struct {
+	threshold   uint32
+	banDuration time.Duration
+
+	mtx    sync.Mutex
+	scores map[[33]byte]*banScore
+}
+
+// newBanManager creates a new banManager which bans peers for banDuration
+// once their score reaches threshold. If the threshold is zero, then peers
+// are never banned.
+func newBanManager(threshold uint32, banDuration time.Duration) *banManager {
+	return &banManager{
+		threshold:   threshold,
+		banDuration: banDuration,
+		scores:      make(map[[33]byte]*banScore),
+	}
+}
+
+// isBanned returns true if the target peer is currently banned.
+func (b *banManager) isBanned(peer [33]byte, now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	score, ok := b.scores[peer]
+	if !ok {
+		return false
+	}
+
+	return now.Before(score.bannedUntil)
+}
+
+// penalize adds the given penalty to the ban score of the target peer. If
+// this causes the peer's score to reach the ban threshold, then the peer is
+// banned, and true is returned.
+func (b *banManager) penalize(peer [33]byte, penalty uint32,
+	now time.Time) bool {
+
+	if b.threshold == 0 {
+		return false
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	score, ok := b.scores[peer]
+	if !ok {
+		score = &banScore{
+			lastUpdate: now,
+		}
+		b.scores[peer] = score
+	}
+
+	// If the peer is already banned, then there's no need to track its
+	// score any further.
+	if now.Before(score.bannedUntil) {
+		return false
+	}
+
+	score.decay(now)
+	score.score += penalty
+	if score.score < b.threshold {
+		return false
+	}
+
+	score.score = 0
+	score.lastUpdate = now
+	score.bannedUntil = now.Add(b.banDuration)
+
+	return true
+}
+
+// numBanned returns the number of peers which are currently banned.
+func (b *banManager) numBanned(now time.Time) uint32 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var numBanned uint32
+	for _, score := range b.scores {
+		if now.Before(score.bannedUntil) {
+			numBanned++
+		}
+	}
+
+	return numBanned
+}
+
+// prune removes the state of all peers which are neither banned, nor have a
+// ban score left.
+func (b *banManager) prune(now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for peer, score := range b.scores {
+		if now.Before(score.bannedUntil) {
+			continue
+		}
+
+		score.decay(now)
+		if score.score == 0 {
+			delete(b.scores, peer)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTokenBucket checks that a token bucket allows an initial burst of
+// events, after which events are only allowed at the rate its tokens are
+// replenished.
+func TestTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	bucket := newTokenBucket(time.Minute, 3, now)
+
+	// The bucket starts out full, so the first three events should be
+	// allowed, while the fourth should be limited.
+	for i := 0; i < 3; i++ {
+		if !bucket.allow(now) {
+			t.Fatalf("event #%v should be allowed", i)
+		}
+	}
+	if bucket.allow(now) {
+		t.Fatal("event beyond burst should be limited")
+	}
+
+	// Half an interval later, no token has been replenished yet.
+	now = now.Add(30 * time.Second)
+	if bucket.allow(now) {
+		t.Fatal("event should be limited before interval elapses")
+	}
+
+	// Once the full interval has passed, a single event should be
+	// allowed again.
+	now = now.Add(30 * time.Second)
+	if !bucket.allow(now) {
+		t.Fatal("event should be allowed after interval elapses")
+	}
+	if bucket.allow(now) {
+		t.Fatal("second event should be limited")
+	}
+
+	// After a long period of inactivity, the bucket should be full again,
+	// but hold no more tokens than the burst.
+	now = now.Add(time.Hour)
+	if !bucket.isFull(now) {
+		t.Fatal("bucket should be full")
+	}
+	for i := 0; i < 3; i++ {
+		if !bucket.allow(now) {
+			t.Fatalf("event #%v should be allowed", i)
+		}
+	}
+	if bucket.allow(now) {
+		t.Fatal("event beyond burst should be limited")
+	}
+}
+
+// TestUpdateRateLimiter checks that channel directions and nodes are each
+// rate limited independently, and that idle buckets are pruned.
+func TestUpdateRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	limiter := newUpdateRateLimiter(time.Minute, 1)
+
+	if !limiter.allowChanUpdate(1, 0, now) {
+		t.Fatal("first update should be allowed")
+	}
+	if limiter.allowChanUpdate(1, 0, now) {
+		t.Fatal("second update should be limited")
+	}
+
+	// The other direction of the channel, and any other channel, should
+	// be unaffected.
+	if !limiter.allowChanUpdate(1, 1, now) {
+		t.Fatal("update for other direction should be allowed")
+	}
+	if !limiter.allowChanUpdate(2, 0, now) {
+		t.Fatal("update for other channel should be allowed")
+	}
+
+	node := [33]byte{1}
+	if !limiter.allowNodeAnn(node, now) {
+		t.Fatal("first announcement should be allowed")
+	}
+	if limiter.allowNodeAnn(node, now) {
+		t.Fatal("second announcement should be limited")
+	}
+
+	// Once all buckets have been replenished, they should all be pruned.
+	limiter.prune(now.Add(time.Minute))
+	if len(limiter.chanUpdates) != 0 || len(limiter.nodeAnns) != 0 {
+		t.Fatalf("expected all buckets to be pruned, %v channel and "+
+			"%v node buckets remain", len(limiter.chanUpdates),
+			len(limiter.nodeAnns))
+	}
+
+	// A zero interval disables rate limiting altogether.
+	limiter = newUpdateRateLimiter(0, 0)
+	for i := 0; i < 10; i++ {
+		if !limiter.allowChanUpdate(1, 0, now) {
+			t.Fatal("update should be allowed")
+		}
+	}
+}
+
+// TestBanManager checks that peers are banned once their ban score reaches
+// the threshold, that ban scores decay over time, and that bans expire.
+func TestBanManager(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	banMan := newBanManager(10, time.Hour)
+	peer := [33]byte{1}
+
+	if banMan.penalize(peer, 9, now) {
+		t.Fatal("peer shouldn't be banned below threshold")
+	}
+
+	// After two minutes, two points of the score should be forgiven, so
+	// the peer should stay below the threshold.
+	now = now.Add(2 * banScoreDecayInterval)
+	if banMan.penalize(peer, 2, now) {
+		t.Fatal("peer shouldn't be banned as its score decayed")
+	}
+	if banMan.isBanned(peer, now) {
+		t.Fatal("peer shouldn't be banned")
+	}
+
+	if !banMan.penalize(peer, 1, now) {
+		t.Fatal("peer should be banned once threshold is reached")
+	}
+	if !banMan.isBanned(peer, now) {
+		t.Fatal("peer should be banned")
+	}
+	if numBanned := banMan.numBanned(now); numBanned != 1 {
+		t.Fatalf("expected 1 banned peer, instead have %v", numBanned)
+	}
+
+	// Pruning shouldn't remove the peer while it's banned.
+	banMan.prune(now)
+	if !banMan.isBanned(peer, now) {
+		t.Fatal("peer should still be banned after pruning")
+	}
+
+	// Once the ban expires, the peer should be allowed to gossip again,
+	// and its state should be pruned.
+	now = now.Add(time.Hour)
+	if banMan.isBanned(peer, now) {
+		t.Fatal("ban should have expired")
+	}
+	banMan.prune(now)
+	if len(banMan.scores) != 0 {
+		t.Fatal("expected peer state to be pruned")
+	}
+
+	// A zero threshold disables banning altogether.
+	banMan = newBanManager(0, time.Hour)
+	if banMan.penalize(peer, invalidGossipPenalty, now) {
+		t.Fatal("peer shouldn't be banned with zero threshold")
+	}
+}
@@ -0,0 +1,378 @@
+package discovery
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// mockChannelGraphTimeSeries is a mock ChannelGraphTimeSeries which hands
+// each query to the test over a channel, and replies with whatever the test
+// sends back.
+type mockChannelGraphTimeSeries struct {
+	highestID lnwire.ShortChannelID
+
+	horizonReq  chan [2]time.Time
+	horizonResp chan []lnwire.Message
+
+	filterReq  chan []lnwire.ShortChannelID
+	filterResp chan []lnwire.ShortChannelID
+
+	filterRangeReqs chan [2]uint32
+	filterRangeResp chan []lnwire.ShortChannelID
+
+	annReq  chan []lnwire.ShortChannelID
+	annResp chan []lnwire.Message
+}
+
+func newMockChannelGraphTimeSeries(
+	hID lnwire.ShortChannelID) *mockChannelGraphTimeSeries {
+
+	return &mockChannelGraphTimeSeries{
+		highestID: hID,
+
+		horizonReq:  make(chan [2]time.Time, 1),
+		horizonResp: make(chan []lnwire.Message, 1),
+
+		filterReq:  make(chan []lnwire.ShortChannelID, 1),
+		filterResp: make(chan []lnwire.ShortChannelID, 1),
+
+		filterRangeReqs: make(chan [2]uint32, 1),
+		filterRangeResp: make(chan []lnwire.ShortChannelID, 1),
+
+		annReq:  make(chan []lnwire.ShortChannelID, 1),
+		annResp: make(chan []lnwire.Message, 1),
+	}
+}
+
+func (m *mockChannelGraphTimeSeries) HighestChanID() (*lnwire.ShortChannelID, error) {
+	return &m.highestID, nil
+}
+
+func (m *mockChannelGraphTimeSeries) UpdatesInHorizon(startTime time.Time,
+	endTime time.Time) ([]lnwire.Message, error) {
+
+	m.horizonReq <- [2]time.Time{startTime, endTime}
+	return <-m.horizonResp, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterKnownChanIDs(
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	m.filterReq <- superSet
+	return <-m.filterResp, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterChannelRange(startHeight,
+	endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	m.filterRangeReqs <- [2]uint32{startHeight, endHeight}
+	return <-m.filterRangeResp, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanAnns(
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	m.annReq <- shortChanIDs
+	return <-m.annResp, nil
+}
+
+var _ ChannelGraphTimeSeries = (*mockChannelGraphTimeSeries)(nil)
+
+// newTestSyncer creates a new gossipSyncer backed by a mock time series,
+// along with the channel over which it sends its messages to the peer.
+func newTestSyncer(hID lnwire.ShortChannelID, active bool,
+	chunkSize int32) (chan []lnwire.Message, *gossipSyncer,
+	*mockChannelGraphTimeSeries) {
+
+	msgChan := make(chan []lnwire.Message, 20)
+	chanSeries := newMockChannelGraphTimeSeries(hID)
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:       chainhash.Hash{},
+		syncChanUpdates: active,
+		channelSeries:   chanSeries,
+		encodingType:    lnwire.EncodingSortedPlain,
+		chunkSize:       chunkSize,
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			msgChan <- msgs
+			return nil
+		},
+	})
+
+	return msgChan, syncer, chanSeries
+}
+
+// TestGossipSyncerFilterGossipMsgs tests that we only send the messages
+// within the update horizon of the remote peer, and nothing at all before the
+// peer has set its horizon.
+func TestGossipSyncerFilterGossipMsgs(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), false, 100,
+	)
+
+	// Without an update horizon, nothing should be sent to the peer.
+	syncer.FilterGossipMsgs(&lnwire.NodeAnnouncement{Timestamp: 10})
+	select {
+	case msgs := <-msgChan:
+		t.Fatalf("no messages should be sent without a horizon, "+
+			"got %v", msgs)
+	default:
+	}
+
+	// Next, we'll apply an update horizon which spans the timestamps 5
+	// through 15.
+	syncer.remoteUpdateHorizon = &lnwire.GossipTimestampRange{
+		FirstTimestamp: 5,
+		TimestampRange: 10,
+	}
+
+	// The first channel only has an update within the horizon, so its
+	// announcement should be sent along with it. Both updates of the
+	// second channel are outside the horizon, so neither should be sent.
+	// The third channel has no updates in this batch, so it's a new
+	// channel which should be sent regardless.
+	chanID1 := lnwire.NewShortChanIDFromInt(1)
+	chanID2 := lnwire.NewShortChanIDFromInt(2)
+	chanID3 := lnwire.NewShortChanIDFromInt(3)
+	msgs := []lnwire.Message{
+		&lnwire.ChannelAnnouncement{ShortChannelID: chanID1},
+		&lnwire.ChannelUpdate{ShortChannelID: chanID1, Timestamp: 4},
+		&lnwire.ChannelUpdate{ShortChannelID: chanID1, Timestamp: 15},
+		&lnwire.ChannelAnnouncement{ShortChannelID: chanID2},
+		&lnwire.ChannelUpdate{ShortChannelID: chanID2, Timestamp: 2},
+		&lnwire.ChannelUpdate{ShortChannelID: chanID2, Timestamp: 16},
+		&lnwire.ChannelAnnouncement{ShortChannelID: chanID3},
+		&lnwire.NodeAnnouncement{Timestamp: 5},
+		&lnwire.NodeAnnouncement{Timestamp: 30},
+	}
+	expected := []lnwire.Message{msgs[0], msgs[2], msgs[6], msgs[7]}
+
+	syncer.FilterGossipMsgs(msgs...)
+	select {
+	case sent := <-msgChan:
+		if !reflect.DeepEqual(sent, expected) {
+			t.Fatalf("wrong messages sent: expected %v, got %v",
+				expected, sent)
+		}
+	default:
+		t.Fatalf("no messages sent")
+	}
+}
+
+// TestGossipSyncerReplyChanRangeQuery tests that we reply to a channel range
+// query in chunks, with only the final chunk marked as complete.
+func TestGossipSyncerReplyChanRangeQuery(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 2
+
+	msgChan, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), false, chunkSize,
+	)
+
+	query := &lnwire.QueryChannelRange{
+		FirstBlockHeight: 100,
+		NumBlocks:        50,
+	}
+	resp := []lnwire.ShortChannelID{
+		{BlockHeight: 100},
+		{BlockHeight: 110},
+		{BlockHeight: 120},
+		{BlockHeight: 130},
+		{BlockHeight: 140},
+	}
+	chanSeries.filterRangeResp <- resp
+
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to reply to query: %v", err)
+	}
+
+	req := <-chanSeries.filterRangeReqs
+	if req[0] != 100 || req[1] != 149 {
+		t.Fatalf("wrong range queried: expected [100, 149], got %v",
+			req)
+	}
+
+	// The five channels should be spread over three chunks.
+	var replied []lnwire.ShortChannelID
+	for i := 0; i < 3; i++ {
+		var msgs []lnwire.Message
+		select {
+		case msgs = <-msgChan:
+		default:
+			t.Fatalf("expected chunk %v to be sent", i)
+		}
+
+		reply, ok := msgs[0].(*lnwire.ReplyChannelRange)
+		if !ok {
+			t.Fatalf("expected ReplyChannelRange, got %T", msgs[0])
+		}
+
+		isFinal := i == 2
+		if (reply.Complete == 1) != isFinal {
+			t.Fatalf("chunk %v: wrong complete value %v", i,
+				reply.Complete)
+		}
+
+		replied = append(replied, reply.ShortChanIDs...)
+	}
+
+	if !reflect.DeepEqual(replied, resp) {
+		t.Fatalf("wrong channels sent: expected %v, got %v", resp,
+			replied)
+	}
+}
+
+// TestGossipSyncerReplyShortChanIDs tests that we reply to a query for a set
+// of channels with their announcements, followed by a ReplyShortChanIDsEnd
+// message.
+func TestGossipSyncerReplyShortChanIDs(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer, chanSeries := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), false, 100,
+	)
+
+	queryIDs := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(2),
+	}
+	anns := []lnwire.Message{
+		&lnwire.ChannelAnnouncement{ShortChannelID: queryIDs[0]},
+		&lnwire.ChannelAnnouncement{ShortChannelID: queryIDs[1]},
+	}
+	chanSeries.annResp <- anns
+
+	err := syncer.replyShortChanIDs(&lnwire.QueryShortChanIDs{
+		EncodingType: lnwire.EncodingSortedPlain,
+		ShortChanIDs: queryIDs,
+	})
+	if err != nil {
+		t.Fatalf("unable to reply to query: %v", err)
+	}
+
+	if req := <-chanSeries.annReq; !reflect.DeepEqual(req, queryIDs) {
+		t.Fatalf("wrong channels fetched: expected %v, got %v",
+			queryIDs, req)
+	}
+
+	msgs := <-msgChan
+	if len(msgs) != len(anns)+1 {
+		t.Fatalf("expected %v messages, got %v", len(anns)+1,
+			len(msgs))
+	}
+	if !reflect.DeepEqual(msgs[:len(anns)], anns) {
+		t.Fatalf("wrong announcements sent: expected %v, got %v",
+			anns, msgs[:len(anns)])
+	}
+	end, ok := msgs[len(anns)].(*lnwire.ReplyShortChanIDsEnd)
+	if !ok || end.Complete != 1 {
+		t.Fatalf("expected complete ReplyShortChanIDsEnd, got %v",
+			msgs[len(anns)])
+	}
+}
+
+// TestGossipSyncerSynchronizeChans tests that an active syncer queries the
+// remote peer for its channels, then queries for the channels it doesn't
+// know of in chunks, and finally sends its update horizon.
+func TestGossipSyncerSynchronizeChans(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 2
+
+	highestID := lnwire.ShortChannelID{BlockHeight: 1000}
+	msgChan, syncer, chanSeries := newTestSyncer(
+		highestID, true, chunkSize,
+	)
+	if err := syncer.Start(); err != nil {
+		t.Fatalf("unable to start syncer: %v", err)
+	}
+	defer syncer.Stop()
+
+	nextMsg := func() lnwire.Message {
+		select {
+		case msgs := <-msgChan:
+			return msgs[0]
+		case <-time.After(time.Second * 5):
+			t.Fatalf("no message sent")
+		}
+		return nil
+	}
+
+	// The syncer should first ask for all channels starting a day's
+	// worth of blocks before our newest channel.
+	rangeQuery, ok := nextMsg().(*lnwire.QueryChannelRange)
+	if !ok {
+		t.Fatalf("expected QueryChannelRange")
+	}
+	startHeight := highestID.BlockHeight - chanRangeQueryBuffer
+	if rangeQuery.FirstBlockHeight != startHeight ||
+		rangeQuery.NumBlocks != math.MaxUint32-startHeight {
+
+		t.Fatalf("wrong range query: %v", spew.Sdump(rangeQuery))
+	}
+
+	// We'll reply with the remote peer's channels over two chunks, three
+	// of which we don't know of yet.
+	remoteChans := []lnwire.ShortChannelID{
+		{BlockHeight: 900},
+		{BlockHeight: 950},
+		{BlockHeight: 1000},
+		{BlockHeight: 1001},
+	}
+	syncer.ProcessQueryMsg(&lnwire.ReplyChannelRange{
+		ShortChanIDs: remoteChans[:2],
+	})
+	syncer.ProcessQueryMsg(&lnwire.ReplyChannelRange{
+		Complete:     1,
+		ShortChanIDs: remoteChans[2:],
+	})
+
+	select {
+	case filter := <-chanSeries.filterReq:
+		if !reflect.DeepEqual(filter, remoteChans) {
+			t.Fatalf("wrong channels filtered: expected %v, "+
+				"got %v", remoteChans, filter)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channels weren't filtered")
+	}
+	newChans := remoteChans[1:]
+	chanSeries.filterResp <- newChans
+
+	// The new channels should be queried for in two chunks, the second
+	// only once the first has been answered.
+	var queried []lnwire.ShortChannelID
+	for i := 0; i < 2; i++ {
+		query, ok := nextMsg().(*lnwire.QueryShortChanIDs)
+		if !ok {
+			t.Fatalf("expected QueryShortChanIDs")
+		}
+		queried = append(queried, query.ShortChanIDs...)
+
+		syncer.ProcessQueryMsg(&lnwire.ReplyShortChanIDsEnd{
+			Complete: 1,
+		})
+	}
+	if !reflect.DeepEqual(queried, newChans) {
+		t.Fatalf("wrong channels queried: expected %v, got %v",
+			newChans, queried)
+	}
+
+	// Finally, once synchronized, the syncer should send our update
+	// horizon.
+	if _, ok := nextMsg().(*lnwire.GossipTimestampRange); !ok {
+		t.Fatalf("expected GossipTimestampRange")
+	}
+	if syncer.SyncState() != chansSynced {
+		t.Fatalf("expected state %v, got %v", chansSynced,
+			syncer.SyncState())
+	}
+}
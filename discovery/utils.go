@@ -1,6 +1,8 @@
 package discovery
 
 import (
+	"bytes"
+
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -74,6 +76,74 @@ func createChanAnnouncement(chanProof *channeldb.ChannelAuthProof,
 	return chanAnn, edge1Ann, edge2Ann
 }
 
+// recreateNodeAnnouncement is a helper function which re-creates the original
+// authenticated node announcement from the node stored within the database.
+// The node must have been announced, otherwise it carries no signature.
+func recreateNodeAnnouncement(
+	node *channeldb.LightningNode) (*lnwire.NodeAnnouncement, error) {
+
+	alias, err := lnwire.NewNodeAlias(node.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwire.NodeAnnouncement{
+		Signature: node.AuthSig,
+		Timestamp: uint32(node.LastUpdate.Unix()),
+		Addresses: node.Addresses,
+		NodeID:    node.PubKey,
+		Alias:     alias,
+		Features:  node.Features,
+	}, nil
+}
+
+// isSameNodeInfo returns true if the passed node announcement advertises
+// exactly the same information as the one of the stored node, disregarding
+// its timestamp and signature.
+func isSameNodeInfo(node *channeldb.LightningNode,
+	msg *lnwire.NodeAnnouncement) bool {
+
+	if node.Alias != msg.Alias.String() {
+		return false
+	}
+
+	if len(node.Addresses) != len(msg.Addresses) {
+		return false
+	}
+	for i, addr := range node.Addresses {
+		if addr.String() != msg.Addresses[i].String() {
+			return false
+		}
+	}
+
+	if node.Features == nil || msg.Features == nil {
+		return node.Features == nil && msg.Features == nil
+	}
+
+	var nodeFeatures, msgFeatures bytes.Buffer
+	if err := node.Features.Encode(&nodeFeatures); err != nil {
+		return false
+	}
+	if err := msg.Features.Encode(&msgFeatures); err != nil {
+		return false
+	}
+
+	return bytes.Equal(nodeFeatures.Bytes(), msgFeatures.Bytes())
+}
+
+// isSamePolicy returns true if the passed channel update advertises exactly
+// the same routing policy as the stored policy, disregarding its timestamp and
+// signature.
+func isSamePolicy(policy *channeldb.ChannelEdgePolicy,
+	msg *lnwire.ChannelUpdate) bool {
+
+	return policy.Flags == msg.Flags &&
+		policy.TimeLockDelta == msg.TimeLockDelta &&
+		policy.MinHTLC == msg.HtlcMinimumMsat &&
+		policy.FeeBaseMSat == lnwire.MilliSatoshi(msg.BaseFee) &&
+		policy.FeeProportionalMillionths == lnwire.MilliSatoshi(msg.FeeRate)
+}
+
 // copyPubKey performs a copy of the target public key, setting a fresh curve
 // parameter during the process.
 func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
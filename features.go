@@ -2,6 +2,26 @@ package main
 
 import "github.com/lightningnetwork/lnd/lnwire"
 
+// anchorOutputsFeature is the name of the local feature which signals that
+// we're able to open channels whose commitment transactions carry an anchor
+// output for each party. If both peers set it, then all new channels between
+// them will use the anchor output commitment format.
+const anchorOutputsFeature = "anchor-outputs"
+
+// staticRemoteKeyFeature is the name of the local feature which signals that
+// we're able to open channels whose to_remote outputs pay to the static
+// payment base point of the remote party. If both peers set it, then our
+// funds on any commitment broadcast by the remote party can be swept with
+// only our seed.
+const staticRemoteKeyFeature = "static-remote-key"
+
+// gossipQueriesFeature is the name of the local feature which signals that
+// we're able to answer queries for the channels and updates within our view
+// of the channel graph. If both peers set it, then rather than dumping our
+// entire graph on each other upon connection, we'll only exchange the
+// channels and updates the other side is missing.
+const gossipQueriesFeature = "gossip-queries"
+
 // globalFeatures feature vector which affects HTLCs and thus are also
 // advertised to other nodes.
 var globalFeatures = lnwire.NewFeatureVector([]lnwire.Feature{})
@@ -19,4 +39,16 @@ var localFeatures = lnwire.NewFeatureVector([]lnwire.Feature{
 		Name: "announce-graph",
 		Flag: lnwire.OptionalFlag,
 	},
+	{
+		Name: anchorOutputsFeature,
+		Flag: lnwire.OptionalFlag,
+	},
+	{
+		Name: staticRemoteKeyFeature,
+		Flag: lnwire.OptionalFlag,
+	},
+	{
+		Name: gossipQueriesFeature,
+		Flag: lnwire.OptionalFlag,
+	},
 })
@@ -62,6 +62,42 @@ type reservationWithCtx struct {
 
 	chanAmt btcutil.Amount
 
+	// batch is the funding batch this reservation is a part of. If nil,
+	// then this reservation is funded by a transaction of its own.
+	batch *fundingBatchCtx
+
+	updates chan *lnrpc.OpenStatusUpdate
+	err     chan error
+}
+
+// fundingBatchCtx tracks the progress of a set of funding workflows initiated
+// by us which share a single funding transaction. As the funding transaction
+// can only be assembled once all peers have accepted their channel, and can
+// only be broadcast once all peers have sent their commitment signature, the
+// workflows within a batch advance in lock step. If any single workflow
+// fails, then the entire batch is aborted.
+type fundingBatchCtx struct {
+	// batch is the wallet's funding batch which owns the inputs and
+	// change output of the shared funding transaction.
+	batch *lnwallet.FundingBatch
+
+	// members maps the pending channel ID of each workflow within the
+	// batch to its reservation context.
+	members map[[32]byte]*reservationWithCtx
+
+	// numAccepted is the number of peers which have responded with their
+	// contribution to the channel.
+	numAccepted int
+
+	// completeChans houses the completed channel of each workflow for
+	// which the remote peer's commitment signature has been verified,
+	// indexed by pending channel ID.
+	completeChans map[[32]byte]*channeldb.OpenChannel
+
+	// failed is set once the batch has been aborted, so any further
+	// messages related to the batch can be ignored.
+	failed bool
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -76,6 +112,16 @@ type initFundingMsg struct {
 	*openChanReq
 }
 
+// initBatchFundingMsg is sent by an outside subsystem to the funding manager
+// in order to kick off a batch of funding workflows, each with a distinct
+// target peer, which will all be funded by a single transaction.
+type initBatchFundingMsg struct {
+	reqs []*initFundingMsg
+
+	updates chan *lnrpc.OpenStatusUpdate
+	err     chan error
+}
+
 // fundingOpenMsg couples an lnwire.OpenChannel message with the peer who sent
 // the message. This allows the funding manager to queue a response directly to
 // the peer, progressing the funding workflow.
@@ -163,6 +209,11 @@ type fundingConfig struct {
 	// commitment transaction.
 	ArbiterChan chan<- *lnwallet.LightningChannel
 
+	// SettledContracts allows the FundingManager to notify the
+	// BreachArbiter that the funding output of a channel has been spent
+	// by a splice transaction, and no longer needs to be observed.
+	SettledContracts chan<- *wire.OutPoint
+
 	// Notifier is used by the FundingManager to determine when the
 	// channel's funding transaction has been confirmed on the blockchain
 	// so that the channel creation process can be completed.
@@ -220,6 +271,15 @@ type fundingConfig struct {
 	// in order to give us more time to claim funds in the case of a
 	// contract breach.
 	RequiredRemoteDelay func(btcutil.Amount) uint16
+
+	// NotifyPendingOpenChannelEvent is called once the funding flow of a
+	// channel has completed, and the channel has been written to disk as
+	// pending open.
+	NotifyPendingOpenChannelEvent func(*channeldb.OpenChannel)
+
+	// NotifyOpenChannelEvent is called once the funding transaction of a
+	// channel has confirmed, and the channel has been marked as open.
+	NotifyOpenChannelEvent func(*channeldb.OpenChannel)
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -276,6 +336,22 @@ type fundingManager struct {
 	// requests from a local subsystem within the daemon.
 	fundingRequests chan *initFundingMsg
 
+	// batchFundingRequests is a channel used to receive requests to
+	// initiate a batch of channels funded by a single transaction from a
+	// local subsystem within the daemon.
+	batchFundingRequests chan *initBatchFundingMsg
+
+	// spliceRequests is a channel used to receive requests to splice
+	// funds into or out of an active channel from a local subsystem
+	// within the daemon.
+	spliceRequests chan *initSpliceMsg
+
+	// activeSplices is a map which houses the state of all splices that
+	// are being negotiated with the remote peer, and have yet to be
+	// durably recorded. This map is only accessed from within the
+	// reservationCoordinator.
+	activeSplices map[lnwire.ChannelID]*spliceCtx
+
 	// newChanBarriers is a map from a channel ID to a 'barrier' which will
 	// be signalled once the channel is fully open. This barrier acts as a
 	// synchronization point for any incoming/outgoing HTLCs before the
@@ -286,6 +362,14 @@ type fundingManager struct {
 	localDiscoveryMtx     sync.Mutex
 	localDiscoverySignals map[lnwire.ChannelID]chan struct{}
 
+	// abandonSignals is a map from a channel ID to a signal which is
+	// closed if the channel is abandoned while we're still waiting for
+	// its funding transaction to confirm. This allows the confirmation
+	// watcher of an abandoned channel to exit, rather than attempting to
+	// open a channel which no longer exists.
+	abandonMtx     sync.Mutex
+	abandonSignals map[lnwire.ChannelID]chan struct{}
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -330,7 +414,11 @@ func newFundingManager(cfg fundingConfig) (*fundingManager, error) {
 		newChanBarriers:       make(map[lnwire.ChannelID]chan struct{}),
 		fundingMsgs:           make(chan interface{}, msgBufferSize),
 		fundingRequests:       make(chan *initFundingMsg, msgBufferSize),
+		batchFundingRequests:  make(chan *initBatchFundingMsg, msgBufferSize),
+		spliceRequests:        make(chan *initSpliceMsg, msgBufferSize),
+		activeSplices:         make(map[lnwire.ChannelID]*spliceCtx),
 		localDiscoverySignals: make(map[lnwire.ChannelID]chan struct{}),
+		abandonSignals:        make(map[lnwire.ChannelID]chan struct{}),
 		queries:               make(chan interface{}, 1),
 		quit:                  make(chan struct{}),
 	}, nil
@@ -451,7 +539,7 @@ func (f *fundingManager) Start() error {
 				defer f.wg.Done()
 
 				lnChannel, err := lnwallet.NewLightningChannel(
-					nil, nil, f.cfg.FeeEstimator, channel)
+					nil, nil, nil, f.cfg.FeeEstimator, channel)
 				if err != nil {
 					fndgLog.Errorf("error creating "+
 						"lightning channel: %v", err)
@@ -468,6 +556,12 @@ func (f *fundingManager) Start() error {
 		}
 	}
 
+	// Finally, we'll resume waiting for the confirmation of any splices
+	// that were pending when the daemon last went down.
+	if err := f.resumePendingSplices(); err != nil {
+		return err
+	}
+
 	f.wg.Add(1) // TODO(roasbeef): tune
 	go f.reservationCoordinator()
 
@@ -549,6 +643,15 @@ func (f *fundingManager) PendingChannels() ([]*pendingChannel, error) {
 func (f *fundingManager) failFundingFlow(peer *btcec.PublicKey,
 	tempChanID [32]byte, msg []byte) {
 
+	// If this funding flow is part of a batch, then the failure of this
+	// single flow aborts the entire batch, as the shared funding
+	// transaction can no longer be completed.
+	resCtx, err := f.getReservationCtx(peer, tempChanID)
+	if err == nil && resCtx.batch != nil {
+		f.failFundingBatch(resCtx.batch, errors.New(string(msg)))
+		return
+	}
+
 	errMsg := &lnwire.Error{
 		ChanID: tempChanID,
 		Data:   msg,
@@ -556,7 +659,7 @@ func (f *fundingManager) failFundingFlow(peer *btcec.PublicKey,
 
 	fndgLog.Errorf("Failing funding flow: %v", spew.Sdump(errMsg))
 
-	err := f.cfg.SendToPeer(peer, errMsg)
+	err = f.cfg.SendToPeer(peer, errMsg)
 	if err != nil {
 		fndgLog.Errorf("unable to send error message to peer %v", err)
 		return
@@ -566,6 +669,44 @@ func (f *fundingManager) failFundingFlow(peer *btcec.PublicKey,
 	return
 }
 
+// failFundingBatch aborts all funding flows within the target batch. An error
+// is sent to each of the peers within the batch, all reservations are
+// cancelled, and the inputs locked by the batch are released. Finally, the
+// error is returned to the caller that initiated the batch.
+func (f *fundingManager) failFundingBatch(batchCtx *fundingBatchCtx,
+	batchErr error) {
+
+	if batchCtx.failed {
+		return
+	}
+	batchCtx.failed = true
+
+	fndgLog.Errorf("Failing funding batch of %v channels: %v",
+		len(batchCtx.members), batchErr)
+
+	for pendingChanID, resCtx := range batchCtx.members {
+		peerKey := resCtx.peerAddress.IdentityKey
+
+		errMsg := &lnwire.Error{
+			ChanID: pendingChanID,
+			Data:   []byte(batchErr.Error()),
+		}
+		if err := f.cfg.SendToPeer(peerKey, errMsg); err != nil {
+			fndgLog.Errorf("unable to send error message to "+
+				"peer %x: %v", peerKey.SerializeCompressed(),
+				err)
+		}
+
+		f.deleteReservationCtx(peerKey, pendingChanID)
+	}
+
+	if err := batchCtx.batch.Cancel(); err != nil {
+		fndgLog.Errorf("unable to cancel funding batch: %v", err)
+	}
+
+	batchCtx.err <- batchErr
+}
+
 // reservationCoordinator is the primary goroutine tasked with progressing the
 // funding workflow between the wallet, and any outside peers or local callers.
 //
@@ -588,11 +729,19 @@ func (f *fundingManager) reservationCoordinator() {
 				f.handleFundingSigned(fmsg)
 			case *fundingLockedMsg:
 				go f.handleFundingLocked(fmsg)
+			case *spliceInitMsg:
+				f.handleSpliceInit(fmsg)
+			case *spliceAcceptMsg:
+				f.handleSpliceAccept(fmsg)
 			case *fundingErrorMsg:
 				f.handleErrorMsg(fmsg)
 			}
 		case req := <-f.fundingRequests:
 			f.handleInitFundingMsg(req)
+		case req := <-f.batchFundingRequests:
+			f.handleInitBatchFundingMsg(req)
+		case req := <-f.spliceRequests:
+			f.handleInitSplice(req)
 
 		case req := <-f.queries:
 			switch msg := req.(type) {
@@ -708,10 +857,11 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// TODO(roasbeef): assuming this was an inbound connection, replace
 	// port with default advertised port
 	chainHash := chainhash.Hash(msg.ChainHash)
+	peerKey := fmsg.peerAddress.IdentityKey
 	reservation, err := f.cfg.Wallet.InitChannelReservation(amt, 0,
 		msg.PushAmount, btcutil.Amount(msg.FeePerKiloWeight),
-		fmsg.peerAddress.IdentityKey, fmsg.peerAddress.Address,
-		&chainHash)
+		peerKey, fmsg.peerAddress.Address, &chainHash,
+		f.negotiatedCommitType(peerKey))
 	if err != nil {
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
@@ -727,6 +877,10 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	numConfsReq := f.cfg.NumRequiredConfs(msg.FundingAmount, msg.PushAmount)
 	reservation.SetNumConfsRequired(numConfsReq)
 
+	// If the initiator committed to an upfront shutdown script, then we'll
+	// record it so we can enforce it once the channel is closed.
+	reservation.SetRemoteShutdownScript(msg.UpfrontShutdownScript)
+
 	// We'll also validate and apply all the constraints the initiating
 	// party is attempting to dictate for our commitment transaction.
 	err = reservation.CommitConstraints(
@@ -858,6 +1012,7 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	// required confirmations, and also the set of channel constraints
 	// they've specified for commitment states we can create.
 	resCtx.reservation.SetNumConfsRequired(uint16(msg.MinAcceptDepth))
+	resCtx.reservation.SetRemoteShutdownScript(msg.UpfrontShutdownScript)
 	err = resCtx.reservation.CommitConstraints(
 		uint16(msg.CsvDelay), msg.MaxAcceptedHTLCs,
 		msg.MaxValueInFlight, msg.ChannelReserve,
@@ -912,18 +1067,64 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
 		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
 
+	// If this channel is part of a batch, then we're unable to construct
+	// the funding transaction until all other peers in the batch have
+	// accepted their channels as well.
+	if resCtx.batch != nil {
+		f.handleBatchAccept(resCtx.batch)
+		return
+	}
+
 	// Now that we have their contribution, we can extract, then send over
 	// both the funding out point and our signature for their version of
 	// the commitment transaction to the remote peer.
+	if err := f.sendFundingCreated(resCtx, pendingChanID); err != nil {
+		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
+			msg.PendingChannelID, []byte(err.Error()))
+		resCtx.err <- err
+		return
+	}
+}
+
+// handleBatchAccept is called each time a peer within a funding batch accepts
+// its channel. Once all peers have done so, the shared funding transaction is
+// assembled, and the funding created message sent to each of the peers.
+func (f *fundingManager) handleBatchAccept(batchCtx *fundingBatchCtx) {
+	batchCtx.numAccepted++
+	if batchCtx.numAccepted < len(batchCtx.members) {
+		return
+	}
+
+	fndgLog.Infof("All %v peers accepted funding batch, assembling "+
+		"funding transaction", len(batchCtx.members))
+
+	if err := batchCtx.batch.Finalize(); err != nil {
+		fndgLog.Errorf("Unable to finalize funding batch: %v", err)
+		f.failFundingBatch(batchCtx, err)
+		return
+	}
+
+	for pendingChanID, resCtx := range batchCtx.members {
+		if err := f.sendFundingCreated(resCtx, pendingChanID); err != nil {
+			f.failFundingBatch(batchCtx, err)
+			return
+		}
+	}
+}
+
+// sendFundingCreated sends the funding outpoint along with our signature for
+// the remote party's version of the commitment transaction to the peer of the
+// target reservation. This method MUST only be called once the funding
+// transaction of the reservation has been assembled.
+func (f *fundingManager) sendFundingCreated(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) error {
+
 	outPoint := resCtx.reservation.FundingOutpoint()
 	_, sig := resCtx.reservation.OurSignatures()
 	commitSig, err := btcec.ParseSignature(sig, btcec.S256())
 	if err != nil {
 		fndgLog.Errorf("Unable to parse signature: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, []byte(err.Error()))
-		resCtx.err <- err
-		return
+		return err
 	}
 
 	// A new channel has almost finished the funding process. In order to
@@ -952,14 +1153,13 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		FundingPoint:     *outPoint,
 		CommitSig:        commitSig,
 	}
-	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, fundingCreated)
+	err = f.cfg.SendToPeer(resCtx.peerAddress.IdentityKey, fundingCreated)
 	if err != nil {
 		fndgLog.Errorf("Unable to send funding complete message: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, []byte(err.Error()))
-		resCtx.err <- err
-		return
+		return err
 	}
+
+	return nil
 }
 
 // processFundingCreated queues a funding complete message coupled with the
@@ -1009,6 +1209,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 			pendingChanID, []byte(err.Error()))
 		return
 	}
+	f.cfg.NotifyPendingOpenChannelEvent(completeChan)
 
 	// If something goes wrong before the funding transaction is confirmed,
 	// we use this convenience method to delete the pending OpenChannel
@@ -1172,9 +1373,61 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		return
 	}
 
+	// If this channel is part of a batch, then the funding transaction
+	// can only be broadcast once all peers in the batch have sent us
+	// their signature.
+	if resCtx.batch != nil {
+		f.handleBatchSigned(resCtx.batch, pendingChanID, completeChan)
+		return
+	}
+
+	f.finalizeFundingFlow(resCtx, pendingChanID, completeChan)
+}
+
+// handleBatchSigned is called each time a peer within a funding batch sends
+// a valid signature for our version of the commitment transaction. Once all
+// peers have done so, the channels are committed to disk, and the shared
+// funding transaction is broadcast.
+func (f *fundingManager) handleBatchSigned(batchCtx *fundingBatchCtx,
+	pendingChanID [32]byte, completeChan *channeldb.OpenChannel) {
+
+	batchCtx.completeChans[pendingChanID] = completeChan
+	if len(batchCtx.completeChans) < len(batchCtx.members) {
+		return
+	}
+
+	fndgLog.Infof("All %v peers signed funding batch, broadcasting "+
+		"funding transaction", len(batchCtx.members))
+
+	if err := batchCtx.batch.Publish(); err != nil {
+		fndgLog.Errorf("Unable to publish funding batch: %v", err)
+		f.failFundingBatch(batchCtx, err)
+		return
+	}
+
+	for pendingChanID, resCtx := range batchCtx.members {
+		f.finalizeFundingFlow(
+			resCtx, pendingChanID,
+			batchCtx.completeChans[pendingChanID],
+		)
+	}
+}
+
+// finalizeFundingFlow notifies the caller that the funding transaction of the
+// target reservation has been broadcast, then waits for the funding
+// transaction to confirm in order to carry out the final stages of the
+// funding workflow.
+func (f *fundingManager) finalizeFundingFlow(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, completeChan *channeldb.OpenChannel) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+	fundingPoint := resCtx.reservation.FundingOutpoint()
+
 	fndgLog.Infof("Finalizing pendingID(%x) over ChannelPoint(%v), "+
 		"waiting for channel open on-chain", pendingChanID[:], fundingPoint)
 
+	f.cfg.NotifyPendingOpenChannelEvent(completeChan)
+
 	// Send an update to the upstream client that the negotiation process
 	// is over.
 	// TODO(roasbeef): add abstraction over updates to accommodate
@@ -1188,6 +1441,20 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		},
 	}
 
+	// We'll take hold of the channel's abandon signal before checking that
+	// it still exists, so an abandon which races with us is never missed.
+	abandonChan := f.abandonSignal(fundingPoint)
+	pending, err := f.isPendingChannel(fundingPoint)
+	if err != nil {
+		fndgLog.Errorf("Unable to look up ChannelPoint(%v): %v",
+			fundingPoint, err)
+	}
+	if err != nil || !pending {
+		f.clearAbandonSignal(fundingPoint, abandonChan)
+		f.deleteReservationCtx(peerKey, pendingChanID)
+		return
+	}
+
 	go func() {
 		doneChan := make(chan struct{})
 		cancelChan := make(chan struct{})
@@ -1209,6 +1476,15 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		case <-doneChan:
 		}
 
+		// If the channel was abandoned while we were waiting for the
+		// funding transaction to confirm, then it'll never be opened.
+		select {
+		case <-abandonChan:
+			f.deleteReservationCtx(peerKey, pendingChanID)
+			return
+		default:
+		}
+
 		// Finally give the caller a final update notifying them that
 		// the channel is now open.
 		// TODO(roasbeef): only notify after recv of funding locked?
@@ -1303,6 +1579,26 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 
 	// Register with the ChainNotifier for a notification once the funding
 	// transaction reaches `numConfs` confirmations.
+	// We'll also watch for the channel being abandoned, as we should stop
+	// waiting for the funding transaction to confirm if so.
+	abandonChan := f.abandonSignal(&completeChan.FundingOutpoint)
+	defer f.clearAbandonSignal(&completeChan.FundingOutpoint, abandonChan)
+
+	// If the channel was abandoned before we began watching for it, then
+	// it will already be missing from the database, and we'll never open
+	// it.
+	pending, err := f.isPendingChannel(&completeChan.FundingOutpoint)
+	if err != nil {
+		fndgLog.Errorf("Unable to look up ChannelPoint(%v): %v",
+			completeChan.FundingOutpoint, err)
+		return
+	}
+	if !pending {
+		fndgLog.Infof("ChannelPoint(%v) abandoned, stopping funding "+
+			"flow", completeChan.FundingOutpoint)
+		return
+	}
+
 	txid := completeChan.FundingOutpoint.Hash
 	numConfs := uint32(completeChan.NumConfsRequired)
 	confNtfn, err := f.cfg.Notifier.RegisterConfirmationsNtfn(&txid,
@@ -1329,6 +1625,10 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 			"stopping funding flow for ChannelPoint(%v)",
 			completeChan.FundingOutpoint)
 		return
+	case <-abandonChan:
+		fndgLog.Infof("ChannelPoint(%v) abandoned, stopping funding "+
+			"flow", completeChan.FundingOutpoint)
+		return
 	case <-f.quit:
 		fndgLog.Warnf("fundingManager shutting down, stopping funding "+
 			"flow for ChannelPoint(%v)", completeChan.FundingOutpoint)
@@ -1366,6 +1666,8 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 			"%v", err)
 		return
 	}
+	completeChan.ShortChanID = shortChanID
+	f.cfg.NotifyOpenChannelEvent(completeChan)
 
 	// TODO(roasbeef): ideally persistent state update for chan above
 	// should be abstracted
@@ -1401,7 +1703,7 @@ func (f *fundingManager) sendFundingLockedAndAnnounceChannel(
 
 	// With the channel marked open, we'll create the state-machine object
 	// which wraps the database state.
-	channel, err := lnwallet.NewLightningChannel(nil, nil,
+	channel, err := lnwallet.NewLightningChannel(nil, nil, nil,
 		f.cfg.FeeEstimator, completeChan)
 	if err != nil {
 		fndgLog.Errorf("error creating new lightning channel: %v", err)
@@ -1793,6 +2095,66 @@ func (f *fundingManager) initFundingWorkflow(peerAddress *lnwire.NetAddress,
 // wallet, then sends a funding request to the remote peer kicking off the
 // funding workflow.
 func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
+	if err := f.initFundingFlow(msg, nil); err != nil {
+		msg.err <- err
+	}
+}
+
+// initBatchFundingWorkflow sends a message to the funding manager instructing
+// it to initiate a batch of single funder workflows, all funded by a single
+// transaction.
+func (f *fundingManager) initBatchFundingWorkflow(reqs []*initFundingMsg,
+	updates chan *lnrpc.OpenStatusUpdate, errChan chan error) {
+
+	f.batchFundingRequests <- &initBatchFundingMsg{
+		reqs:    reqs,
+		updates: updates,
+		err:     errChan,
+	}
+}
+
+// handleInitBatchFundingMsg creates a funding batch within the daemon's
+// wallet which selects the coins for all channels in the batch at once. A
+// reservation is then created for each channel in the batch, and a funding
+// request sent to each of the remote peers.
+func (f *fundingManager) handleInitBatchFundingMsg(msg *initBatchFundingMsg) {
+	var totalAmt btcutil.Amount
+	for _, req := range msg.reqs {
+		totalAmt += req.localFundingAmt
+	}
+
+	fndgLog.Infof("Initiating funding batch of %v channels, total_amt=%v",
+		len(msg.reqs), totalAmt)
+
+	batch, err := f.cfg.Wallet.InitFundingBatch(totalAmt, len(msg.reqs))
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	batchCtx := &fundingBatchCtx{
+		batch:         batch,
+		members:       make(map[[32]byte]*reservationWithCtx),
+		completeChans: make(map[[32]byte]*channeldb.OpenChannel),
+		updates:       msg.updates,
+		err:           msg.err,
+	}
+
+	for _, req := range msg.reqs {
+		if err := f.initFundingFlow(req, batchCtx); err != nil {
+			f.failFundingBatch(batchCtx, err)
+			return
+		}
+	}
+}
+
+// initFundingFlow creates a channel reservation for the passed funding
+// request, and sends the funding request to the remote peer. If batchCtx is
+// non-nil, then the reservation is funded by the batch rather than by a
+// transaction of its own.
+func (f *fundingManager) initFundingFlow(msg *initFundingMsg,
+	batchCtx *fundingBatchCtx) error {
+
 	var (
 		// TODO(roasbeef): add delay
 		peerKey      = msg.peerAddress.IdentityKey
@@ -1821,15 +2183,33 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 
 	// Initialize a funding reservation with the local wallet. If the
 	// wallet doesn't have enough funds to commit to this channel, then the
-	// request will fail, and be aborted.
-	reservation, err := f.cfg.Wallet.InitChannelReservation(capacity,
-		localAmt, msg.pushAmt, feePerKw, peerKey,
-		msg.peerAddress.Address, &msg.chainHash)
+	// request will fail, and be aborted. Reservations within a batch draw
+	// their funds from the coins already selected by the batch.
+	var (
+		reservation *lnwallet.ChannelReservation
+		err         error
+	)
+	commitType := f.negotiatedCommitType(peerKey)
+	if batchCtx != nil {
+		reservation, err = f.cfg.Wallet.InitBatchedChannelReservation(
+			batchCtx.batch, capacity, localAmt, msg.pushAmt,
+			feePerKw, peerKey, msg.peerAddress.Address,
+			&msg.chainHash, commitType,
+		)
+	} else {
+		reservation, err = f.cfg.Wallet.InitChannelReservation(capacity,
+			localAmt, msg.pushAmt, feePerKw, peerKey,
+			msg.peerAddress.Address, &msg.chainHash, commitType)
+	}
 	if err != nil {
-		msg.err <- err
-		return
+		return err
 	}
 
+	// If the caller specified an upfront shutdown script, then we'll
+	// commit to it within the reservation, and advertise it to the remote
+	// peer so they can enforce it at closing time.
+	reservation.SetLocalShutdownScript(msg.shutdownScript)
+
 	// Obtain a new pending channel ID which is used to track this
 	// reservation throughout its lifetime.
 	chanID := f.nextPendingChanID()
@@ -1846,13 +2226,25 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		f.activeReservations[peerIDKey] = make(pendingChannels)
 	}
 
-	f.activeReservations[peerIDKey][chanID] = &reservationWithCtx{
+	resCtx := &reservationWithCtx{
 		chanAmt:     capacity,
 		reservation: reservation,
 		peerAddress: msg.peerAddress,
 		updates:     msg.updates,
 		err:         msg.err,
 	}
+
+	// Workflows within a batch report their progress via the batch
+	// itself, as the caller is only notified once all channels within the
+	// batch are pending.
+	if batchCtx != nil {
+		resCtx.batch = batchCtx
+		resCtx.updates = batchCtx.updates
+		resCtx.err = make(chan error, 1)
+		batchCtx.members[chanID] = resCtx
+	}
+
+	f.activeReservations[peerIDKey][chanID] = resCtx
 	f.resMtx.Unlock()
 
 	// Using the RequiredRemoteDelay closure, we'll compute the remote CSV
@@ -1872,28 +2264,53 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.peerAddress.Address, chanID)
 
 	fundingOpen := lnwire.OpenChannel{
-		ChainHash:            *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
-		PendingChannelID:     chanID,
-		FundingAmount:        capacity,
-		PushAmount:           msg.pushAmt,
-		DustLimit:            ourContribution.DustLimit,
-		MaxValueInFlight:     maxValue,
-		ChannelReserve:       chanReserve,
-		HtlcMinimum:          ourContribution.MinHTLC,
-		FeePerKiloWeight:     uint32(feePerKw),
-		CsvDelay:             uint16(remoteCsvDelay),
-		MaxAcceptedHTLCs:     maxHtlcs,
-		FundingKey:           ourContribution.MultiSigKey,
-		RevocationPoint:      ourContribution.RevocationBasePoint,
-		PaymentPoint:         ourContribution.PaymentBasePoint,
-		DelayedPaymentPoint:  ourContribution.DelayBasePoint,
-		FirstCommitmentPoint: ourContribution.FirstCommitmentPoint,
+		ChainHash:             *f.cfg.Wallet.Cfg.NetParams.GenesisHash,
+		PendingChannelID:      chanID,
+		FundingAmount:         capacity,
+		PushAmount:            msg.pushAmt,
+		DustLimit:             ourContribution.DustLimit,
+		MaxValueInFlight:      maxValue,
+		ChannelReserve:        chanReserve,
+		HtlcMinimum:           ourContribution.MinHTLC,
+		FeePerKiloWeight:      uint32(feePerKw),
+		CsvDelay:              uint16(remoteCsvDelay),
+		MaxAcceptedHTLCs:      maxHtlcs,
+		FundingKey:            ourContribution.MultiSigKey,
+		RevocationPoint:       ourContribution.RevocationBasePoint,
+		PaymentPoint:          ourContribution.PaymentBasePoint,
+		DelayedPaymentPoint:   ourContribution.DelayBasePoint,
+		FirstCommitmentPoint:  ourContribution.FirstCommitmentPoint,
+		UpfrontShutdownScript: msg.shutdownScript,
 	}
 	if err := f.cfg.SendToPeer(peerKey, &fundingOpen); err != nil {
 		fndgLog.Errorf("Unable to send funding request message: %v", err)
-		msg.err <- err
-		return
+		return err
+	}
+
+	return nil
+}
+
+// negotiatedCommitType returns the commitment format bits for a new channel
+// with the target peer, according to the features both of us signalled
+// within our init messages.
+func (f *fundingManager) negotiatedCommitType(
+	peerKey *btcec.PublicKey) channeldb.ChannelType {
+
+	var commitType channeldb.ChannelType
+
+	peer, err := f.cfg.FindPeer(peerKey)
+	if err != nil || peer == nil || peer.localSharedFeatures == nil {
+		return commitType
 	}
+
+	if peer.localSharedFeatures.IsActive(anchorOutputsFeature) {
+		commitType |= channeldb.AnchorOutputsBit
+	}
+	if peer.localSharedFeatures.IsActive(staticRemoteKeyFeature) {
+		commitType |= channeldb.StaticRemoteKeyBit
+	}
+
+	return commitType
 }
 
 // waitUntilChannelOpen is designed to prevent other lnd subsystems from
@@ -1917,6 +2334,125 @@ func (f *fundingManager) waitUntilChannelOpen(targetChan lnwire.ChannelID) {
 	}
 }
 
+// abandonChannel tears down any state the fundingManager holds for a channel
+// which is being abandoned, and notifies the remote peer that we no longer
+// consider the channel to be valid. Any goroutines blocked on the channel's
+// barrier are released so they don't wait on a channel that will never open.
+func (f *fundingManager) abandonChannel(channel *channeldb.OpenChannel) error {
+	chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+
+	f.barrierMtx.Lock()
+	if barrier, ok := f.newChanBarriers[chanID]; ok {
+		fndgLog.Tracef("Closing chan barrier for abandoned "+
+			"ChanID(%v)", chanID)
+		close(barrier)
+		delete(f.newChanBarriers, chanID)
+	}
+	f.barrierMtx.Unlock()
+
+	f.localDiscoveryMtx.Lock()
+	delete(f.localDiscoverySignals, chanID)
+	f.localDiscoveryMtx.Unlock()
+
+	// If we're still waiting for the funding transaction to confirm, then
+	// we'll stop doing so, as the channel no longer exists. A watcher
+	// which hasn't been launched yet will find the channel missing from
+	// the database once it is, so there's no need to leave anything
+	// behind for it.
+	f.abandonMtx.Lock()
+	if signal, ok := f.abandonSignals[chanID]; ok {
+		fndgLog.Tracef("Cancelling funding confirmation watcher for "+
+			"abandoned ChanID(%v)", chanID)
+		close(signal)
+		delete(f.abandonSignals, chanID)
+	}
+	f.abandonMtx.Unlock()
+
+	// If the channel had already confirmed, but hadn't yet been fully
+	// announced, then we'll also remove its opening state so we don't
+	// attempt to resume the announcement on restart.
+	_, _, err := f.getChannelOpeningState(&channel.FundingOutpoint)
+	switch {
+	case err == ErrChannelNotFound:
+	case err != nil:
+		return err
+	default:
+		err := f.deleteChannelOpeningState(&channel.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Finally, we'll let the remote peer know that we've abandoned the
+	// channel. As they may not currently be connected, a failure to
+	// deliver the message isn't considered fatal.
+	errMsg := &lnwire.Error{
+		ChanID: chanID,
+		Data:   []byte("channel abandoned"),
+	}
+	if err := f.cfg.SendToPeer(channel.IdentityPub, errMsg); err != nil {
+		fndgLog.Warnf("unable to notify peer of abandoned "+
+			"ChannelPoint(%v): %v", channel.FundingOutpoint, err)
+	}
+
+	return nil
+}
+
+// abandonSignal returns the signal which is closed once the channel
+// identified by chanPoint is abandoned, creating it if it doesn't yet exist.
+func (f *fundingManager) abandonSignal(
+	chanPoint *wire.OutPoint) <-chan struct{} {
+
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+
+	f.abandonMtx.Lock()
+	defer f.abandonMtx.Unlock()
+
+	signal, ok := f.abandonSignals[chanID]
+	if !ok {
+		signal = make(chan struct{})
+		f.abandonSignals[chanID] = signal
+	}
+
+	return signal
+}
+
+// clearAbandonSignal removes the abandon signal of the channel identified by
+// chanPoint, once we're no longer waiting for its funding transaction to
+// confirm. The entry is only removed if it's still the passed signal, so a
+// returning watcher never removes the signal of a newer one.
+func (f *fundingManager) clearAbandonSignal(chanPoint *wire.OutPoint,
+	signal <-chan struct{}) {
+
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+
+	f.abandonMtx.Lock()
+	if cur, ok := f.abandonSignals[chanID]; ok && cur == signal {
+		delete(f.abandonSignals, chanID)
+	}
+	f.abandonMtx.Unlock()
+}
+
+// isPendingChannel returns true if the channel identified by chanPoint is
+// still stored in the database as waiting for its funding transaction to
+// confirm.
+func (f *fundingManager) isPendingChannel(chanPoint *wire.OutPoint) (bool,
+	error) {
+
+	pendingChannels, err := f.cfg.Wallet.Cfg.Database.FetchPendingChannels()
+	if err != nil {
+		return false, err
+	}
+
+	for _, channel := range pendingChannels {
+		if channel.FundingOutpoint == *chanPoint {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // processErrorGeneric sends a message to the fundingManager allowing it to
 // process the occurred generic error.
 func (f *fundingManager) processFundingError(err *lnwire.Error,
@@ -1934,6 +2470,12 @@ func (f *fundingManager) handleErrorMsg(fmsg *fundingErrorMsg) {
 	peerKey := fmsg.peerAddress.IdentityKey
 	chanID := fmsg.err.ChanID
 
+	// If the error is in response to a splice we've initiated, then
+	// we'll abort the splice.
+	if f.handleSpliceError(fmsg) {
+		return
+	}
+
 	// First, we'll attempt to retrieve the funding workflow that this
 	// error was tied to. If we're unable to do so, then we'll exit early
 	// as this was an unwarranted error.
@@ -1950,6 +2492,17 @@ func (f *fundingManager) handleErrorMsg(fmsg *fundingErrorMsg) {
 	fndgLog.Errorf("Received funding error from %x: %v",
 		peerKey.SerializeCompressed(), lnErr,
 	)
+
+	// If the workflow is part of a funding batch, then the entire batch
+	// is now unable to complete, so we'll abort it in its entirety.
+	if resCtx.batch != nil {
+		f.failFundingBatch(
+			resCtx.batch,
+			grpc.Errorf(lnErr.ToGrpcCode(), lnErr.String()),
+		)
+		return
+	}
+
 	resCtx.err <- grpc.Errorf(lnErr.ToGrpcCode(), lnErr.String())
 
 	if _, err := f.cancelReservationCtx(peerKey, chanID); err != nil {
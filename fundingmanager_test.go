@@ -216,6 +216,8 @@ func createTestFundingManager(t *testing.T, pubKey *btcec.PublicKey,
 		RequiredRemoteDelay: func(amt btcutil.Amount) uint16 {
 			return 4
 		},
+		NotifyPendingOpenChannelEvent: func(*channeldb.OpenChannel) {},
+		NotifyOpenChannelEvent:        func(*channeldb.OpenChannel) {},
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -262,6 +264,9 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		},
 		TempChanIDSeed: oldCfg.TempChanIDSeed,
 		FindChannel:    oldCfg.FindChannel,
+		NotifyPendingOpenChannelEvent: oldCfg.
+			NotifyPendingOpenChannelEvent,
+		NotifyOpenChannelEvent: oldCfg.NotifyOpenChannelEvent,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -555,6 +560,18 @@ func TestFundingManagerNormalWorkflow(t *testing.T) {
 		t.Fatalf("expected state to be markedOpen, was %v", state)
 	}
 
+	// As neither party is waiting for the funding transaction to confirm
+	// anymore, their abandon signals for the channel should be removed.
+	for _, node := range []*testNode{alice, bob} {
+		node.fundingMgr.abandonMtx.Lock()
+		numSignals := len(node.fundingMgr.abandonSignals)
+		node.fundingMgr.abandonMtx.Unlock()
+		if numSignals != 0 {
+			t.Fatalf("expected no abandon signals, found %v",
+				numSignals)
+		}
+	}
+
 	// After the funding transaction is mined, Alice will send
 	// fundingLocked to Bob.
 	var fundingLockedAlice lnwire.Message
@@ -1016,3 +1033,298 @@ func TestFundingManagerFundingTimeout(t *testing.T) {
 			len(pendingChannels))
 	}
 }
+
+// TestFundingManagerAbandonChannel tests that abandoning a channel whose
+// funding transaction has yet to confirm stops the funding manager from
+// waiting on the confirmation, and that the inputs of the funding transaction
+// can be recovered from the channel stored on disk.
+func TestFundingManagerAbandonChannel(t *testing.T) {
+	disableFndgLogger(t)
+
+	shutdownChannel := make(chan struct{})
+
+	alice, bob := setupFundingManagers(t, shutdownChannel)
+	defer tearDownFundingManagers(t, alice, bob, shutdownChannel)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	fundingOutPoint := openChannel(t, alice, bob, 500000, 0, 1, updateChan)
+
+	// As Alice initiated the channel, she should've stored the funding
+	// transaction alongside the pending channel.
+	aliceDB := alice.fundingMgr.cfg.Wallet.Cfg.Database
+	pendingChannels, err := aliceDB.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch pending channels: %v", err)
+	}
+	if len(pendingChannels) != 1 {
+		t.Fatalf("expected alice to have 1 pending channel, had %v",
+			len(pendingChannels))
+	}
+	channel := pendingChannels[0]
+	if channel.FundingTxn == nil {
+		t.Fatalf("funding transaction not stored with channel")
+	}
+	if channel.FundingTxn.TxHash() != fundingOutPoint.Hash {
+		t.Fatalf("stored funding txid %v doesn't match %v",
+			channel.FundingTxn.TxHash(), fundingOutPoint.Hash)
+	}
+
+	// Next, Alice will abandon the channel, removing it from her
+	// database, then notifying her funding manager.
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChanPoint: channel.FundingOutpoint,
+		RemotePub: channel.IdentityPub,
+		Capacity:  channel.Capacity,
+		CloseType: channeldb.Abandoned,
+	}
+	if err := channel.CloseChannel(closeInfo); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- alice.fundingMgr.abandonChannel(channel)
+	}()
+
+	// Bob should be notified that the channel was abandoned.
+	select {
+	case msg := <-alice.msgChan:
+		if _, ok := msg.(*lnwire.Error); !ok {
+			t.Fatalf("expected Error to be sent from alice, "+
+				"instead got %T", msg)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send Error")
+	}
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("unable to abandon channel: %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("abandoning channel timed out")
+	}
+
+	// The input spent by the funding transaction should be released.
+	numReleased := alice.fundingMgr.cfg.Wallet.ReleaseFundingInputs(channel)
+	if numReleased != len(channel.FundingTxn.TxIn) {
+		t.Fatalf("expected %v inputs to be released, got %v",
+			len(channel.FundingTxn.TxIn), numReleased)
+	}
+
+	// Even if the funding transaction now confirms, Alice should no
+	// longer attempt to open the channel.
+	alice.mockNotifier.confChannel <- &chainntnfs.TxConfirmation{}
+
+	select {
+	case msg := <-alice.msgChan:
+		t.Fatalf("alice sent %T for abandoned channel", msg)
+	case update := <-updateChan:
+		t.Fatalf("alice sent update %v for abandoned channel", update)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	_, _, err = alice.fundingMgr.getChannelOpeningState(fundingOutPoint)
+	if err != ErrChannelNotFound {
+		t.Fatalf("expected channel opening state to not be found, "+
+			"got: %v", err)
+	}
+
+	// Nothing should be left behind for the abandoned channel's
+	// confirmation watcher.
+	alice.fundingMgr.abandonMtx.Lock()
+	numSignals := len(alice.fundingMgr.abandonSignals)
+	alice.fundingMgr.abandonMtx.Unlock()
+	if numSignals != 0 {
+		t.Fatalf("expected no abandon signals, found %v", numSignals)
+	}
+}
+
+// TestFundingManagerBatchWorkflow tests that a batch of channels initiated by
+// Alice is funded by a single transaction, which is only broadcast once the
+// remote party has signed the commitment transaction of every channel within
+// the batch.
+func TestFundingManagerBatchWorkflow(t *testing.T) {
+	disableFndgLogger(t)
+
+	shutdownChannel := make(chan struct{})
+
+	alice, bob := setupFundingManagers(t, shutdownChannel)
+	defer tearDownFundingManagers(t, alice, bob, shutdownChannel)
+
+	// Both channels of the batch will be opened with Bob, so he'll need
+	// to accept more than a single pending channel at a time.
+	cfg.MaxPendingChannels = 2
+
+	chanAmts := []btcutil.Amount{500000, 600000}
+
+	// All channels within the batch report their updates via the batch,
+	// so we'll buffer enough room for a pending update of each channel.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate, len(chanAmts))
+	errChan := make(chan error, 1)
+
+	reqs := make([]*initFundingMsg, 0, len(chanAmts))
+	for _, amt := range chanAmts {
+		reqs = append(reqs, &initFundingMsg{
+			peerAddress: bobAddr,
+			openChanReq: &openChanReq{
+				targetPubkey:    bob.privKey.PubKey(),
+				chainHash:       *activeNetParams.GenesisHash,
+				localFundingAmt: amt,
+			},
+		})
+	}
+
+	alice.fundingMgr.initBatchFundingWorkflow(reqs, updateChan, errChan)
+
+	// receiveMsg is a helper closure which waits for the next message sent
+	// by the target node, failing the test if it isn't sent in time.
+	receiveMsg := func(node *testNode, name string) lnwire.Message {
+		select {
+		case msg := <-node.msgChan:
+			if errMsg, ok := msg.(*lnwire.Error); ok {
+				t.Fatalf("%v sent error: %v", name,
+					string(errMsg.Data))
+			}
+			return msg
+		case err := <-errChan:
+			t.Fatalf("error during batch funding workflow: %v", err)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%v did not send message", name)
+		}
+		return nil
+	}
+
+	// Alice should send an OpenChannel message for each channel within the
+	// batch, each of which Bob will accept.
+	accepts := make([]*lnwire.AcceptChannel, 0, len(chanAmts))
+	for range chanAmts {
+		openChan, ok := receiveMsg(alice, "alice").(*lnwire.OpenChannel)
+		if !ok {
+			t.Fatalf("expected OpenChannel to be sent from alice")
+		}
+
+		bob.fundingMgr.processFundingOpen(openChan, aliceAddr)
+
+		accept, ok := receiveMsg(bob, "bob").(*lnwire.AcceptChannel)
+		if !ok {
+			t.Fatalf("expected AcceptChannel to be sent from bob")
+		}
+		accepts = append(accepts, accept)
+	}
+
+	// The funding transaction can only be assembled once all channels
+	// have been accepted, so Alice shouldn't send a FundingCreated
+	// message until she's processed the final AcceptChannel.
+	alice.fundingMgr.processFundingAccept(accepts[0], bobAddr)
+	select {
+	case msg := <-alice.msgChan:
+		t.Fatalf("alice sent %T before all channels were accepted", msg)
+	case <-time.After(300 * time.Millisecond):
+	}
+	alice.fundingMgr.processFundingAccept(accepts[1], bobAddr)
+
+	created := make([]*lnwire.FundingCreated, 0, len(chanAmts))
+	for range chanAmts {
+		fundingCreated, ok := receiveMsg(alice, "alice").(*lnwire.FundingCreated)
+		if !ok {
+			t.Fatalf("expected FundingCreated to be sent from alice")
+		}
+		created = append(created, fundingCreated)
+	}
+
+	// Every channel of the batch should be funded by the same
+	// transaction.
+	if created[0].FundingPoint.Hash != created[1].FundingPoint.Hash {
+		t.Fatalf("channels funded by distinct transactions: %v vs %v",
+			created[0].FundingPoint, created[1].FundingPoint)
+	}
+	if created[0].FundingPoint == created[1].FundingPoint {
+		t.Fatalf("channels share funding outpoint %v",
+			created[0].FundingPoint)
+	}
+
+	signed := make([]*lnwire.FundingSigned, 0, len(chanAmts))
+	for _, fundingCreated := range created {
+		bob.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+
+		fundingSigned, ok := receiveMsg(bob, "bob").(*lnwire.FundingSigned)
+		if !ok {
+			t.Fatalf("expected FundingSigned to be sent from bob")
+		}
+		signed = append(signed, fundingSigned)
+	}
+
+	// After processing only the first signature, Alice shouldn't yet
+	// broadcast the funding transaction, nor have written any of the
+	// batch's channels to disk.
+	alice.fundingMgr.processFundingSigned(signed[0], bobAddr)
+	select {
+	case <-alice.publTxChan:
+		t.Fatalf("alice published funding tx before all channels " +
+			"were signed")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	aliceDB := alice.fundingMgr.cfg.Wallet.Cfg.Database
+	pendingChannels, err := aliceDB.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch pending channels: %v", err)
+	}
+	if len(pendingChannels) != 0 {
+		t.Fatalf("expected alice to have 0 pending channels, had %v",
+			len(pendingChannels))
+	}
+
+	alice.fundingMgr.processFundingSigned(signed[1], bobAddr)
+
+	// With all signatures received, the shared funding transaction should
+	// be broadcast a single time.
+	var publ *wire.MsgTx
+	select {
+	case publ = <-alice.publTxChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish funding tx")
+	}
+	if publ.TxHash() != created[0].FundingPoint.Hash {
+		t.Fatalf("published tx %v doesn't match funding txid %v",
+			publ.TxHash(), created[0].FundingPoint.Hash)
+	}
+
+	// A pending update should be sent for each channel within the batch.
+	for range chanAmts {
+		var pendingUpdate *lnrpc.OpenStatusUpdate
+		select {
+		case pendingUpdate = <-updateChan:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("alice did not send OpenStatusUpdate_ChanPending")
+		}
+
+		update, ok := pendingUpdate.Update.(*lnrpc.OpenStatusUpdate_ChanPending)
+		if !ok {
+			t.Fatal("OpenStatusUpdate was not OpenStatusUpdate_ChanPending")
+		}
+		txid, err := chainhash.NewHash(update.ChanPending.Txid)
+		if err != nil {
+			t.Fatalf("unable to parse txid: %v", err)
+		}
+		if *txid != publ.TxHash() {
+			t.Fatalf("pending update txid %v doesn't match "+
+				"funding txid %v", txid, publ.TxHash())
+		}
+	}
+
+	// Finally, all channels within the batch should now be pending within
+	// Alice's database.
+	pendingChannels, err = aliceDB.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch pending channels: %v", err)
+	}
+	if len(pendingChannels) != len(chanAmts) {
+		t.Fatalf("expected alice to have %v pending channels, had %v",
+			len(chanAmts), len(pendingChannels))
+	}
+}
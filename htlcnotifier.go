@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/htlcswitch"
+)
+
+// htlcNotifier dispatches notifications to all registered clients each time
+// the switch or one of its links acts upon an HTLC.
+type htlcNotifier struct {
+	clients *subscriptionServer
+}
+
+// newHtlcNotifier creates a new HTLC notifier.
+func newHtlcNotifier() *htlcNotifier {
+	return &htlcNotifier{
+		clients: newSubscriptionServer(),
+	}
+}
+
+// SubscribeHtlcEvents returns an eventSubscription which allows the caller to
+// receive async notifications each time the switch acts upon an HTLC. Each
+// event is sent as a *htlcswitch.HtlcEvent.
+func (h *htlcNotifier) SubscribeHtlcEvents() *eventSubscription {
+	return h.clients.subscribe()
+}
+
+// NotifyHtlcEvent sends the passed event to all currently registered
+// notification clients. As this is called from within the switch's main event
+// loop, events are queued for each client so a slow client can't stall the
+// forwarding of HTLCs.
+func (h *htlcNotifier) NotifyHtlcEvent(event *htlcswitch.HtlcEvent) {
+	h.clients.notifyClients(event)
+}
@@ -0,0 +1,119 @@
+package htlcswitch
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// HtlcEventType denotes what happened to the HTLC described by an HtlcEvent.
+type HtlcEventType uint8
+
+const (
+	// HtlcEventForward is emitted once an HTLC received on one of our
+	// channels has been added to the commitment of the outgoing channel.
+	HtlcEventForward HtlcEventType = iota
+
+	// HtlcEventForwardFail is emitted when the switch is unable to
+	// forward an HTLC, for example because the next channel is unknown or
+	// lacks the bandwidth to carry the HTLC.
+	HtlcEventForwardFail
+
+	// HtlcEventLinkFail is emitted when a link rejects an HTLC, either
+	// because an incoming HTLC violates our forwarding policy, or because
+	// an outgoing HTLC couldn't be added to the channel.
+	HtlcEventLinkFail
+
+	// HtlcEventSettle is emitted once the settle of an HTLC we forwarded
+	// or sent is propagated back through the switch.
+	HtlcEventSettle
+
+	// HtlcEventFail is emitted once the failure of an HTLC we forwarded
+	// or sent is propagated back through the switch.
+	HtlcEventFail
+
+	// HtlcEventSend is emitted once a locally initiated HTLC has been
+	// added to the commitment of the first hop channel.
+	HtlcEventSend
+
+	// HtlcEventReceive is emitted once we settle an HTLC for which we're
+	// the final destination.
+	HtlcEventReceive
+)
+
+// String returns a human readable version of the HtlcEventType.
+func (h HtlcEventType) String() string {
+	switch h {
+	case HtlcEventForward:
+		return "Forward"
+	case HtlcEventForwardFail:
+		return "ForwardFail"
+	case HtlcEventLinkFail:
+		return "LinkFail"
+	case HtlcEventSettle:
+		return "Settle"
+	case HtlcEventFail:
+		return "Fail"
+	case HtlcEventSend:
+		return "Send"
+	case HtlcEventReceive:
+		return "Receive"
+	default:
+		return "Unknown"
+	}
+}
+
+// HtlcEvent describes a change to the state of a single HTLC handled by the
+// switch or one of its links. Fields which aren't relevant to a particular
+// event, such as the incoming channel of a locally initiated HTLC, are left
+// as their zero values.
+type HtlcEvent struct {
+	// Type denotes what happened to the HTLC.
+	Type HtlcEventType
+
+	// IncomingChanID is the channel the HTLC was received on.
+	IncomingChanID lnwire.ShortChannelID
+
+	// OutgoingChanID is the channel the HTLC was or would have been
+	// forwarded over.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// IncomingHtlcID is the index of the HTLC within the incoming
+	// channel.
+	IncomingHtlcID uint64
+
+	// OutgoingHtlcID is the index of the HTLC within the outgoing
+	// channel.
+	OutgoingHtlcID uint64
+
+	// IncomingAmt is the value of the HTLC within the incoming channel.
+	IncomingAmt lnwire.MilliSatoshi
+
+	// OutgoingAmt is the value of the HTLC within the outgoing channel.
+	OutgoingAmt lnwire.MilliSatoshi
+
+	// PaymentHash is the payment hash of the HTLC.
+	PaymentHash [sha256.Size]byte
+
+	// FailCode is the onion failure code of a failed HTLC, if known.
+	FailCode lnwire.FailCode
+
+	// RemoteFailure is true if the HTLC was failed by a downstream node
+	// rather than by ourselves.
+	RemoteFailure bool
+
+	// FailureDetail is an optional human readable description of why
+	// the HTLC failed.
+	FailureDetail string
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+}
+
+// notifyHtlcEvent timestamps the passed event, then hands it off to the
+// configured event handler.
+func (s *Switch) notifyHtlcEvent(event *HtlcEvent) {
+	event.Timestamp = time.Now()
+	s.cfg.NotifyHtlcEvent(event)
+}
@@ -5,15 +5,38 @@ import (
 
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
 )
 
+// ForwardingError wraps a failure message received from a remote node along
+// the route of a locally initiated payment, along with the identity of the
+// node which generated it.
+type ForwardingError struct {
+	// ErrorSource is the public key of the node which generated the
+	// failure.
+	ErrorSource *btcec.PublicKey
+
+	lnwire.FailureMessage
+}
+
+// Error returns the failure code of the wrapped failure message as a string.
+//
+// NOTE: Part of the error interface.
+func (f *ForwardingError) Error() string {
+	return f.Code().String()
+}
+
+// A compile time check to ensure ForwardingError implements the error
+// interface.
+var _ error = (*ForwardingError)(nil)
+
 // Deobfuscator is an interface that is used to decrypt the onion encrypted
 // failure reason an extra out a well formed error.
 type Deobfuscator interface {
 	// Deobfuscate peels off each layer of onion encryption from the first
-	// hop, to the source of the error. A fully populated
-	// lnwire.FailureMessage is returned.
-	Deobfuscate(lnwire.OpaqueReason) (lnwire.FailureMessage, error)
+	// hop, to the source of the error. A ForwardingError holding the fully
+	// populated lnwire.FailureMessage is returned.
+	Deobfuscate(lnwire.OpaqueReason) (*ForwardingError, error)
 }
 
 // Obfuscator is an interface that is used to encrypt HTLC related errors at
@@ -79,19 +102,27 @@ type FailureDeobfuscator struct {
 }
 
 // Deobfuscate peels off each layer of onion encryption from the first hop, to
-// the source of the error. A fully populated lnwire.FailureMessage is
-// returned.
+// the source of the error. A ForwardingError holding the fully populated
+// lnwire.FailureMessage is returned.
 //
 // NOTE: Part of the Obfuscator interface.
-func (o *FailureDeobfuscator) Deobfuscate(reason lnwire.OpaqueReason) (lnwire.FailureMessage, error) {
+func (o *FailureDeobfuscator) Deobfuscate(reason lnwire.OpaqueReason) (*ForwardingError, error) {
 
-	_, failureData, err := o.OnionDeobfuscator.Deobfuscate(reason)
+	errorSource, failureData, err := o.OnionDeobfuscator.Deobfuscate(reason)
 	if err != nil {
 		return nil, err
 	}
 
 	r := bytes.NewReader(failureData)
-	return lnwire.DecodeFailure(r, 0)
+	failure, err := lnwire.DecodeFailure(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForwardingError{
+		ErrorSource:    errorSource,
+		FailureMessage: failure,
+	}, nil
 }
 
 // A compile time check to ensure FailureDeobfuscator implements the
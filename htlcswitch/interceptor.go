@@ -0,0 +1,471 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrInterceptorActive is returned when attempting to register a
+	// forward interceptor while another one is already registered.
+	ErrInterceptorActive = errors.New("forward interceptor already " +
+		"registered")
+
+	// ErrForwardNotFound is returned when attempting to resolve an
+	// intercepted forward which has already been resolved or has timed
+	// out.
+	ErrForwardNotFound = errors.New("intercepted forward not found")
+)
+
+// ForwardInterceptor is handed each HTLC the switch is about to forward while
+// it's registered. The HTLC is held within the switch until it's resolved
+// using one of the methods of the passed InterceptedForward.
+//
+// NOTE: This is called from within the main event loop of the switch, so it
+// MUST NOT block.
+type ForwardInterceptor func(*InterceptedForward)
+
+// interceptAction denotes how an intercepted forward should be resolved.
+type interceptAction uint8
+
+const (
+	// interceptResume continues forwarding the HTLC as normal.
+	interceptResume interceptAction = iota
+
+	// interceptFail fails the HTLC back to the incoming channel.
+	interceptFail
+
+	// interceptSettle settles the HTLC back to the incoming channel.
+	interceptSettle
+)
+
+// interceptKey uniquely identifies an intercepted HTLC by the channel it was
+// received on, and its index within that channel.
+type interceptKey struct {
+	chanID lnwire.ShortChannelID
+	htlcID uint64
+}
+
+// InterceptedForward describes an HTLC received on one of our channels that
+// the switch is about to forward, and which is being held until the
+// registered ForwardInterceptor decides what to do with it.
+type InterceptedForward struct {
+	// IncomingChanID is the channel the HTLC was received on.
+	IncomingChanID lnwire.ShortChannelID
+
+	// IncomingHtlcID is the index of the HTLC within the incoming
+	// channel.
+	IncomingHtlcID uint64
+
+	// OutgoingChanID is the channel the HTLC is requested to be
+	// forwarded over.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// IncomingAmt is the value of the HTLC within the incoming channel.
+	IncomingAmt lnwire.MilliSatoshi
+
+	// OutgoingAmt is the value the HTLC is requested to carry within the
+	// outgoing channel.
+	OutgoingAmt lnwire.MilliSatoshi
+
+	// OutgoingExpiry is the absolute expiry height the HTLC is requested
+	// to carry within the outgoing channel.
+	OutgoingExpiry uint32
+
+	// PaymentHash is the payment hash of the HTLC.
+	PaymentHash [sha256.Size]byte
+
+	packet *htlcPacket
+	timer  *time.Timer
+	s      *Switch
+
+	// resolved is closed once the HTLC is no longer held by the switch.
+	resolved chan struct{}
+}
+
+// Resolved returns a channel which is closed once the HTLC is no longer held
+// by the switch, whether it was resolved by the interceptor, timed out, or
+// resumed as the interceptor was unregistered.
+func (f *InterceptedForward) Resolved() <-chan struct{} {
+	return f.resolved
+}
+
+// Resume continues forwarding the HTLC as if it had never been intercepted.
+func (f *InterceptedForward) Resume() error {
+	return f.s.resolveForward(&resolveForwardCmd{
+		key:    f.key(),
+		action: interceptResume,
+	})
+}
+
+// Fail fails the HTLC back to the incoming channel using the passed failure
+// code. Only failure codes which don't carry any additional data are
+// supported.
+func (f *InterceptedForward) Fail(code lnwire.FailCode) error {
+	failure, err := interceptFailure(code)
+	if err != nil {
+		return err
+	}
+
+	return f.s.resolveForward(&resolveForwardCmd{
+		key:     f.key(),
+		action:  interceptFail,
+		failure: failure,
+	})
+}
+
+// Settle settles the HTLC back to the incoming channel using the passed
+// preimage, which must match the payment hash of the HTLC.
+func (f *InterceptedForward) Settle(preimage [sha256.Size]byte) error {
+	if sha256.Sum256(preimage[:]) != f.PaymentHash {
+		return errors.Errorf("preimage %x doesn't match payment "+
+			"hash %x", preimage[:], f.PaymentHash[:])
+	}
+
+	return f.s.resolveForward(&resolveForwardCmd{
+		key:      f.key(),
+		action:   interceptSettle,
+		preimage: preimage,
+	})
+}
+
+// key returns the interceptKey which identifies this forward.
+func (f *InterceptedForward) key() interceptKey {
+	return interceptKey{
+		chanID: f.IncomingChanID,
+		htlcID: f.IncomingHtlcID,
+	}
+}
+
+// interceptFailure returns the failure message an intercepted HTLC is failed
+// with for the passed failure code.
+func interceptFailure(code lnwire.FailCode) (lnwire.FailureMessage, error) {
+	switch code {
+	case lnwire.CodeTemporaryChannelFailure:
+		return lnwire.NewTemporaryChannelFailure(nil), nil
+	case lnwire.CodeTemporaryNodeFailure:
+		return lnwire.FailTemporaryNodeFailure{}, nil
+	case lnwire.CodePermanentNodeFailure:
+		return lnwire.FailPermanentNodeFailure{}, nil
+	case lnwire.CodePermanentChannelFailure:
+		return lnwire.FailPermanentChannelFailure{}, nil
+	case lnwire.CodeUnknownNextPeer:
+		return lnwire.FailUnknownNextPeer{}, nil
+	case lnwire.CodeUnknownPaymentHash:
+		return lnwire.FailUnknownPaymentHash{}, nil
+	case lnwire.CodeIncorrectPaymentAmount:
+		return lnwire.FailIncorrectPaymentAmount{}, nil
+	default:
+		return nil, errors.Errorf("unsupported failure code for "+
+			"intercepted htlc: %v", code)
+	}
+}
+
+// setInterceptorCmd is a message sent to the switch to register or clear the
+// forward interceptor.
+type setInterceptorCmd struct {
+	interceptor ForwardInterceptor
+	err         chan error
+}
+
+// SetInterceptor registers the passed interceptor, which will be handed each
+// HTLC the switch is about to forward from now on. Passing a nil interceptor
+// unregisters the current one, in which case all HTLCs it still holds are
+// resumed. Only a single interceptor may be registered at a time.
+func (s *Switch) SetInterceptor(interceptor ForwardInterceptor) error {
+	command := &setInterceptorCmd{
+		interceptor: interceptor,
+		err:         make(chan error, 1),
+	}
+
+	select {
+	case s.linkControl <- command:
+		return <-command.err
+	case <-s.quit:
+		return errors.New("Htlc Switch was stopped")
+	}
+}
+
+// setInterceptor handles a setInterceptorCmd within the main event loop of
+// the switch.
+func (s *Switch) setInterceptor(interceptor ForwardInterceptor) error {
+	if interceptor != nil && s.interceptor != nil {
+		return ErrInterceptorActive
+	}
+	s.interceptor = interceptor
+
+	if interceptor != nil {
+		return nil
+	}
+
+	// With the interceptor gone, nobody is left to resolve the HTLCs it
+	// was holding, so we'll continue forwarding them as normal.
+	for key := range s.heldForwards {
+		err := s.handleResolveForward(&resolveForwardCmd{
+			key:    key,
+			action: interceptResume,
+		})
+		if err != nil {
+			log.Errorf("unable to resume intercepted htlc: %v",
+				err)
+		}
+	}
+
+	return nil
+}
+
+// resolveForwardCmd is a message sent to the switch to resolve an
+// intercepted forward.
+type resolveForwardCmd struct {
+	key      interceptKey
+	action   interceptAction
+	failure  lnwire.FailureMessage
+	preimage [sha256.Size]byte
+	err      chan error
+}
+
+// resolveForward sends the passed resolution to the main event loop of the
+// switch, and waits for it to be carried out.
+func (s *Switch) resolveForward(cmd *resolveForwardCmd) error {
+	cmd.err = make(chan error, 1)
+
+	select {
+	case s.linkControl <- cmd:
+		return <-cmd.err
+	case <-s.quit:
+		return errors.New("Htlc Switch was stopped")
+	}
+}
+
+// interceptForward hands the passed add packet to the registered interceptor,
+// holding it until it's resolved. If the interceptor doesn't resolve the HTLC
+// within the configured timeout, then it's failed back to the incoming
+// channel.
+func (s *Switch) interceptForward(packet *htlcPacket,
+	htlc *lnwire.UpdateAddHTLC) {
+
+	fwd := &InterceptedForward{
+		IncomingChanID: packet.src,
+		IncomingHtlcID: packet.incomingHTLCID,
+		OutgoingChanID: packet.dest,
+		IncomingAmt:    packet.incomingAmount,
+		OutgoingAmt:    htlc.Amount,
+		OutgoingExpiry: htlc.Expiry,
+		PaymentHash:    htlc.PaymentHash,
+		packet:         packet,
+		s:              s,
+		resolved:       make(chan struct{}),
+	}
+	s.heldForwards[fwd.key()] = fwd
+
+	// We'll persist the held HTLC, so it can be handed to the interceptor
+	// again if we restart before it's resolved.
+	if s.cfg.HeldForwards != nil {
+		err := s.cfg.HeldForwards.PutHeldForward(&channeldb.HeldForward{
+			IncomingChanID: packet.src,
+			IncomingHtlcID: packet.incomingHTLCID,
+			IncomingAmt:    packet.incomingAmount,
+			IncomingOnion:  packet.incomingOnion,
+			OutgoingChanID: packet.dest,
+			Htlc:           htlc,
+		})
+		if err != nil {
+			log.Errorf("unable to persist intercepted htlc(%x): %v",
+				htlc.PaymentHash[:], err)
+		}
+	}
+
+	if s.cfg.InterceptTimeout != 0 {
+		fwd.timer = time.AfterFunc(s.cfg.InterceptTimeout, func() {
+			log.Warnf("Intercepted htlc(%x) timed out, failing",
+				fwd.PaymentHash[:])
+
+			err := s.resolveForward(&resolveForwardCmd{
+				key:     fwd.key(),
+				action:  interceptFail,
+				failure: lnwire.NewTemporaryChannelFailure(nil),
+			})
+			if err != nil && err != ErrForwardNotFound {
+				log.Errorf("unable to fail timed out htlc: %v",
+					err)
+			}
+		})
+	}
+
+	log.Debugf("Intercepted htlc(%x) from %v, htlc_id=%v",
+		htlc.PaymentHash[:], packet.src, packet.incomingHTLCID)
+
+	s.interceptor(fwd)
+}
+
+// handleResolveForward carries out the resolution of an intercepted forward
+// within the main event loop of the switch.
+func (s *Switch) handleResolveForward(cmd *resolveForwardCmd) error {
+	fwd, ok := s.heldForwards[cmd.key]
+	if !ok {
+		return ErrForwardNotFound
+	}
+	delete(s.heldForwards, cmd.key)
+	close(fwd.resolved)
+
+	if fwd.timer != nil {
+		fwd.timer.Stop()
+	}
+
+	if s.cfg.HeldForwards != nil {
+		err := s.cfg.HeldForwards.DeleteHeldForward(
+			cmd.key.chanID, cmd.key.htlcID,
+		)
+		if err != nil {
+			log.Errorf("unable to remove intercepted htlc(%x): %v",
+				fwd.PaymentHash[:], err)
+		}
+	}
+
+	packet := fwd.packet
+	htlc := packet.htlc.(*lnwire.UpdateAddHTLC)
+
+	switch cmd.action {
+
+	// The HTLC should be forwarded as normal, so we'll re-process the
+	// packet, this time skipping the interceptor.
+	case interceptResume:
+		packet.isResumed = true
+		return s.handlePacketForward(packet)
+
+	// The HTLC should be failed, so we'll send the chosen failure back to
+	// the incoming link.
+	case interceptFail:
+		source, err := s.getLinkByShortID(packet.src)
+		if err != nil {
+			return err
+		}
+
+		reason, err := packet.obfuscator.InitialObfuscate(cmd.failure)
+		if err != nil {
+			return err
+		}
+
+		go source.HandleSwitchPacket(newFailPacket(
+			packet.src,
+			&lnwire.UpdateFailHTLC{
+				Reason: reason,
+			},
+			htlc.PaymentHash, 0, true,
+		))
+		s.notifyForwardFail(packet, htlc, cmd.failure.Code(),
+			"failed by interceptor")
+
+		return nil
+
+	// The interceptor knows the preimage of the HTLC, so we'll settle it
+	// back to the incoming link without forwarding it any further.
+	case interceptSettle:
+		source, err := s.getLinkByShortID(packet.src)
+		if err != nil {
+			return err
+		}
+
+		go source.HandleSwitchPacket(newSettlePacket(
+			packet.src,
+			&lnwire.UpdateFufillHTLC{
+				PaymentPreimage: cmd.preimage,
+			},
+			htlc.PaymentHash, packet.incomingAmount,
+		))
+		s.notifyHtlcEvent(&HtlcEvent{
+			Type:           HtlcEventSettle,
+			IncomingChanID: packet.src,
+			IncomingHtlcID: packet.incomingHTLCID,
+			IncomingAmt:    packet.incomingAmount,
+			PaymentHash:    htlc.PaymentHash,
+		})
+
+		return nil
+
+	default:
+		return errors.Errorf("unknown intercept action: %v",
+			cmd.action)
+	}
+}
+
+// restoreHeldForwards loads the HTLCs which were held for the interceptor when
+// the switch was last stopped. Each of them is forwarded again once the link
+// it was received on is added to the switch.
+func (s *Switch) restoreHeldForwards() error {
+	if s.cfg.HeldForwards == nil {
+		return nil
+	}
+
+	fwds, err := s.cfg.HeldForwards.FetchHeldForwards()
+	if err != nil {
+		return err
+	}
+
+	for _, fwd := range fwds {
+		// The obfuscator of the HTLC isn't persisted, so we'll recover
+		// it from the onion blob it was received with.
+		obfuscator, failCode := s.cfg.DecodeOnionObfuscator(
+			bytes.NewReader(fwd.IncomingOnion[:]),
+		)
+		if failCode != lnwire.CodeNone {
+			log.Errorf("unable to decode onion obfuscator of held "+
+				"htlc(%x): %v", fwd.Htlc.PaymentHash[:], failCode)
+			continue
+		}
+
+		packet := newAddPacket(
+			fwd.IncomingChanID, fwd.OutgoingChanID, fwd.Htlc,
+			obfuscator,
+		)
+		packet.incomingHTLCID = fwd.IncomingHtlcID
+		packet.incomingAmount = fwd.IncomingAmt
+		packet.incomingOnion = fwd.IncomingOnion
+
+		s.restoredForwards[fwd.IncomingChanID] = append(
+			s.restoredForwards[fwd.IncomingChanID], packet,
+		)
+	}
+
+	log.Infof("Restored %v held htlcs", len(fwds))
+
+	return nil
+}
+
+// forwardRestoredHtlcs forwards the HTLCs restored from the HeldForwards store
+// that were received over the passed channel. If an interceptor is
+// registered, then they're handed to it once more, otherwise they're
+// forwarded as normal, just as if the interceptor had been unregistered
+// while they were held.
+func (s *Switch) forwardRestoredHtlcs(chanID lnwire.ShortChannelID) {
+	packets := s.restoredForwards[chanID]
+	delete(s.restoredForwards, chanID)
+
+	for _, packet := range packets {
+		key := interceptKey{
+			chanID: packet.src,
+			htlcID: packet.incomingHTLCID,
+		}
+
+		if err := s.handlePacketForward(packet); err != nil {
+			log.Errorf("unable to forward restored htlc: %v", err)
+		}
+
+		// If the HTLC has been held once more, then it remains
+		// persisted until it's resolved. Otherwise, it's no longer
+		// held, so we'll remove it from the store.
+		if _, ok := s.heldForwards[key]; ok {
+			continue
+		}
+		err := s.cfg.HeldForwards.DeleteHeldForward(key.chanID,
+			key.htlcID)
+		if err != nil {
+			log.Errorf("unable to remove restored htlc: %v", err)
+		}
+	}
+}
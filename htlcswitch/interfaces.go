@@ -17,6 +17,25 @@ type InvoiceDatabase interface {
 	// SettleInvoice attempts to mark an invoice corresponding to the
 	// passed payment hash as fully settled.
 	SettleInvoice(chainhash.Hash) error
+
+	// AddInvoice adds a new invoice to the database. This is used to
+	// create an invoice on the fly for spontaneous payments.
+	AddInvoice(*channeldb.Invoice) error
+}
+
+// HeldForwardStore is an interface which represents the persistent storage of
+// the HTLCs held by the switch on behalf of the forward interceptor.
+type HeldForwardStore interface {
+	// PutHeldForward persists an HTLC which is now held by the switch.
+	PutHeldForward(*channeldb.HeldForward) error
+
+	// DeleteHeldForward removes a held HTLC, identified by the channel it
+	// was received on and its index within that channel, once it's no
+	// longer held.
+	DeleteHeldForward(lnwire.ShortChannelID, uint64) error
+
+	// FetchHeldForwards returns all HTLCs persisted as being held.
+	FetchHeldForwards() ([]*channeldb.HeldForward, error)
 }
 
 // ChannelLink is an interface which represents the subsystem for managing the
@@ -1,6 +1,7 @@
 package htlcswitch
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 	"io"
 
@@ -42,6 +43,41 @@ var (
 	exitHop lnwire.ShortChannelID
 )
 
+// KeySendRealm is the realm of the extra per-hop payload which carries the
+// preimage of a spontaneous (keysend) payment. The extra payload is encoded
+// within the onion as an additional hop following the receiving node, which
+// is addressed to the receiving node itself. As the payload of the real final
+// hop signals no next channel, nodes unaware of spontaneous payments will
+// treat the HTLC as a regular payment to themselves.
+const KeySendRealm = 0x4b
+
+// NewKeySendHopData returns the extra per-hop payload which delivers the
+// preimage of a spontaneous payment to the receiving node. The preimage is
+// spread across all fields of the payload following its realm.
+func NewKeySendHopData(preimage [sha256.Size]byte) sphinx.HopData {
+	hopData := sphinx.HopData{
+		Realm:         KeySendRealm,
+		ForwardAmount: binary.BigEndian.Uint64(preimage[8:16]),
+		OutgoingCltv:  binary.BigEndian.Uint32(preimage[16:20]),
+	}
+	copy(hopData.NextAddress[:], preimage[:8])
+	copy(hopData.ExtraBytes[:], preimage[20:])
+
+	return hopData
+}
+
+// parseKeySendHopData extracts the preimage of a spontaneous payment from the
+// passed per-hop payload, which is expected to be of the KeySendRealm.
+func parseKeySendHopData(hopData *sphinx.HopData) [sha256.Size]byte {
+	var preimage [sha256.Size]byte
+	copy(preimage[:8], hopData.NextAddress[:])
+	binary.BigEndian.PutUint64(preimage[8:16], hopData.ForwardAmount)
+	binary.BigEndian.PutUint32(preimage[16:20], hopData.OutgoingCltv)
+	copy(preimage[20:], hopData.ExtraBytes[:])
+
+	return preimage
+}
+
 // ForwardingInfo contains all the information that is necessary to forward and
 // incoming HTLC to the next hop encoded within a valid HopIterator instance.
 // Forwarding links are to use this information to authenticate the information
@@ -65,6 +101,11 @@ type ForwardingInfo struct {
 	// in the outgoing HTLC.
 	OutgoingCTLV uint32
 
+	// KeySendPreimage is the preimage of a spontaneous payment, which is
+	// only set if we're the exit hop, and the sender delivered the
+	// preimage within the onion.
+	KeySendPreimage *[sha256.Size]byte
+
 	// TODO(roasbeef): modify sphinx logic to not just discard the
 	// remaining bytes, instead should include the rest as excess
 }
@@ -98,6 +139,10 @@ type sphinxHopIterator struct {
 	// includes the information required to properly forward the packet to
 	// the next hop.
 	processedPacket *sphinx.ProcessedPacket
+
+	// keySendPreimage is the preimage of a spontaneous payment delivered
+	// within the onion, if any.
+	keySendPreimage *[sha256.Size]byte
 }
 
 // A compile time check to ensure sphinxHopIterator implements the HopIterator
@@ -134,6 +179,7 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 		NextHop:         nextHop,
 		AmountToForward: lnwire.MilliSatoshi(fwdInst.ForwardAmount),
 		OutgoingCTLV:    fwdInst.OutgoingCltv,
+		KeySendPreimage: r.keySendPreimage,
 	}
 }
 
@@ -192,10 +238,47 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte) (HopIterat
 		}
 	}
 
-	return &sphinxHopIterator{
+	iterator := &sphinxHopIterator{
 		nextPacket:      sphinxPacket.NextPacket,
 		processedPacket: sphinxPacket,
-	}, lnwire.CodeNone
+	}
+
+	// If the payload doesn't signal a next channel, yet the onion contains
+	// more hops, then the sender may have delivered the preimage of a
+	// spontaneous payment within an extra hop addressed to us.
+	fwdInst := sphinxPacket.ForwardingInstructions
+	if sphinxPacket.Action == sphinx.MoreHops &&
+		fwdInst.NextAddress == [8]byte{} {
+
+		iterator.keySendPreimage = p.decodeKeySendPreimage(
+			sphinxPacket.NextPacket, rHash,
+		)
+	}
+
+	return iterator, lnwire.CodeNone
+}
+
+// decodeKeySendPreimage attempts to extract the preimage of a spontaneous
+// payment from the onion packet following the payload of the final hop. If
+// the packet isn't addressed to us, or doesn't carry a preimage, then nil is
+// returned.
+func (p *OnionProcessor) decodeKeySendPreimage(onionPkt *sphinx.OnionPacket,
+	rHash []byte) *[sha256.Size]byte {
+
+	extraPacket, err := p.router.ProcessOnionPacket(onionPkt, rHash)
+	if err != nil {
+		return nil
+	}
+
+	hopData := extraPacket.ForwardingInstructions
+	if extraPacket.Action != sphinx.ExitNode ||
+		hopData.Realm != KeySendRealm {
+
+		return nil
+	}
+
+	preimage := parseKeySendHopData(&hopData)
+	return &preimage
 }
 
 // DecodeOnionObfuscator takes an io.Reader which should contain the onion
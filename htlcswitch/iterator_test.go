@@ -0,0 +1,133 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+)
+
+// TestKeySendHopData checks that the preimage of a spontaneous payment
+// survives being spread across the fields of a per-hop payload.
+func TestKeySendHopData(t *testing.T) {
+	t.Parallel()
+
+	var preimage [sha256.Size]byte
+	for i := range preimage {
+		preimage[i] = byte(i + 1)
+	}
+
+	hopData := NewKeySendHopData(preimage)
+	if hopData.Realm != KeySendRealm {
+		t.Fatalf("wrong realm: expected %v, got %v", KeySendRealm,
+			hopData.Realm)
+	}
+
+	if parsed := parseKeySendHopData(&hopData); parsed != preimage {
+		t.Fatalf("wrong preimage: expected %x, got %x", preimage[:],
+			parsed[:])
+	}
+}
+
+// TestKeySendOnion checks that the preimage of a spontaneous payment is only
+// extracted from the onion by the final hop, while intermediate hops forward
+// the HTLC as usual.
+func TestKeySendOnion(t *testing.T) {
+	t.Parallel()
+
+	bobKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	carolKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	preimage := [sha256.Size]byte{1, 2, 3}
+	rHash := sha256.Sum256(preimage[:])
+
+	// Bob forwards the HTLC to Carol, who is the final hop. The extra hop
+	// carrying the preimage is addressed to Carol herself.
+	nextChan := lnwire.NewShortChanIDFromInt(42)
+	bobPayload := sphinx.HopData{
+		ForwardAmount: 1000,
+		OutgoingCltv:  100,
+	}
+	binary.BigEndian.PutUint64(bobPayload.NextAddress[:],
+		nextChan.ToUint64())
+	carolPayload := sphinx.HopData{
+		ForwardAmount: 1000,
+		OutgoingCltv:  100,
+	}
+
+	nodes := []*btcec.PublicKey{
+		bobKey.PubKey(), carolKey.PubKey(), carolKey.PubKey(),
+	}
+	payloads := []sphinx.HopData{
+		bobPayload, carolPayload, NewKeySendHopData(preimage),
+	}
+	onion, err := sphinx.NewOnionPacket(nodes, sessionKey, payloads,
+		rHash[:])
+	if err != nil {
+		t.Fatalf("unable to create onion: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := onion.Encode(&b); err != nil {
+		t.Fatalf("unable to encode onion: %v", err)
+	}
+
+	bob := NewOnionProcessor(
+		sphinx.NewRouter(bobKey, &chaincfg.SimNetParams),
+	)
+	iterator, failCode := bob.DecodeHopIterator(&b, rHash[:])
+	if failCode != lnwire.CodeNone {
+		t.Fatalf("unable to decode onion: %v", failCode)
+	}
+
+	fwdInfo := iterator.ForwardingInstructions()
+	if fwdInfo.NextHop != nextChan {
+		t.Fatalf("wrong next hop: expected %v, got %v", nextChan,
+			fwdInfo.NextHop)
+	}
+	if fwdInfo.KeySendPreimage != nil {
+		t.Fatalf("intermediate hop shouldn't learn the preimage")
+	}
+
+	b.Reset()
+	if err := iterator.EncodeNextHop(&b); err != nil {
+		t.Fatalf("unable to encode next onion: %v", err)
+	}
+
+	carol := NewOnionProcessor(
+		sphinx.NewRouter(carolKey, &chaincfg.SimNetParams),
+	)
+	iterator, failCode = carol.DecodeHopIterator(&b, rHash[:])
+	if failCode != lnwire.CodeNone {
+		t.Fatalf("unable to decode onion: %v", failCode)
+	}
+
+	fwdInfo = iterator.ForwardingInstructions()
+	if fwdInfo.NextHop != exitHop {
+		t.Fatalf("expected exit hop, got %v", fwdInfo.NextHop)
+	}
+	if fwdInfo.AmountToForward != 1000 {
+		t.Fatalf("wrong amount: expected 1000, got %v",
+			fwdInfo.AmountToForward)
+	}
+	if fwdInfo.KeySendPreimage == nil ||
+		*fwdInfo.KeySendPreimage != preimage {
+
+		t.Fatalf("final hop didn't learn the preimage")
+	}
+}
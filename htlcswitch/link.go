@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -116,6 +117,13 @@ type ChannelLinkConfig struct {
 	// in thread-safe manner.
 	Registry InvoiceDatabase
 
+	// PreimageCache is a global witness beacon that houses any new
+	// preimages discovered by other links. We'll use this to add new
+	// witnesses that we discover which will notify any sub-systems
+	// subscribed to new events. If the channel is later closed on-chain,
+	// the cache is consulted in order to claim incoming HTLC's.
+	PreimageCache lnwallet.PreimageCache
+
 	// BlockEpochs is an active block epoch event stream backed by an
 	// active ChainNotifier instance. The ChannelLink will use new block
 	// notifications sent over this channel to decide when a _new_ HTLC is
@@ -138,6 +146,11 @@ type ChannelLinkConfig struct {
 	// the exit node.
 	// NOTE: HodlHTLC should be active in conjunction with DebugHTLC.
 	HodlHTLC bool
+
+	// AcceptKeySend should be active if we're to accept spontaneous
+	// payments, which carry their preimage within the onion rather than
+	// paying to one of our invoices.
+	AcceptKeySend bool
 }
 
 // channelLink is the service which drives a channel's commitment update
@@ -493,6 +506,7 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket) {
 				var (
 					isObfuscated bool
 					reason       lnwire.OpaqueReason
+					addErr       = err
 				)
 
 				// We'll parse the sphinx packet enclosed so we
@@ -536,7 +550,20 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket) {
 				)
 
 				go l.cfg.Switch.forward(failPkt)
-				log.Infof("Unable to handle downstream add HTLC: %v", err)
+				log.Infof("Unable to handle downstream add HTLC: %v",
+					addErr)
+
+				l.cfg.Switch.notifyHtlcEvent(&HtlcEvent{
+					Type:           HtlcEventLinkFail,
+					IncomingChanID: pkt.src,
+					OutgoingChanID: l.ShortChanID(),
+					IncomingHtlcID: pkt.incomingHTLCID,
+					IncomingAmt:    pkt.incomingAmount,
+					OutgoingAmt:    htlc.Amount,
+					PaymentHash:    htlc.PaymentHash,
+					FailCode:       failure.Code(),
+					FailureDetail:  addErr.Error(),
+				})
 				return
 			}
 		}
@@ -548,6 +575,23 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket) {
 		htlc.ID = index
 		l.cfg.Peer.SendMessage(htlc)
 
+		// Locally initiated HTLCs have no source channel, so we'll
+		// report them as sends rather than forwards.
+		eventType := HtlcEventForward
+		if pkt.src == (lnwire.ShortChannelID{}) {
+			eventType = HtlcEventSend
+		}
+		l.cfg.Switch.notifyHtlcEvent(&HtlcEvent{
+			Type:           eventType,
+			IncomingChanID: pkt.src,
+			OutgoingChanID: l.ShortChanID(),
+			IncomingHtlcID: pkt.incomingHTLCID,
+			OutgoingHtlcID: index,
+			IncomingAmt:    pkt.incomingAmount,
+			OutgoingAmt:    htlc.Amount,
+			PaymentHash:    htlc.PaymentHash,
+		})
+
 	case *lnwire.UpdateFufillHTLC:
 		// An HTLC we forward to the switch has just settled somewhere
 		// upstream. Therefore we settle the HTLC within the our local
@@ -560,6 +604,15 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket) {
 			return
 		}
 
+		// With the HTLC settled within our state machine, we'll add
+		// the preimage to the global cache so we're able to claim the
+		// HTLC on-chain if the channel is force closed before the
+		// settle is locked in.
+		if err := l.cfg.PreimageCache.AddPreimage(pre[:]); err != nil {
+			log.Errorf("unable to add preimage=%x to cache: %v",
+				pre[:], err)
+		}
+
 		// With the HTLC settled, we'll need to populate the wire
 		// message to target the specific channel and HTLC to be
 		// cancelled.
@@ -695,7 +748,15 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 	case *lnwire.CommitSig:
 		// We just received a new update to our local commitment chain,
 		// validate this new commitment, closing the link if invalid.
-		err := l.channel.ReceiveNewCommitment(msg.CommitSig, msg.HtlcSigs)
+		var spliceSigs *lnwallet.SpliceCommitSigs
+		if msg.SpliceCommitSig != nil {
+			spliceSigs = &lnwallet.SpliceCommitSigs{
+				CommitSig: msg.SpliceCommitSig,
+				HtlcSigs:  msg.SpliceHtlcSigs,
+			}
+		}
+		err := l.channel.ReceiveNewCommitment(msg.CommitSig,
+			msg.HtlcSigs, spliceSigs)
 		if err != nil {
 			l.fail("unable to accept new commitment: %v", err)
 			return
@@ -783,12 +844,22 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 // commitment to their commitment chain which includes all the latest updates
 // we've received+processed up to this point.
 func (l *channelLink) updateCommitTx() error {
-	theirCommitSig, htlcSigs, err := l.channel.SignNextCommitment()
-	if err == lnwallet.ErrNoWindow {
+	theirCommitSig, htlcSigs, spliceSigs, err := l.channel.SignNextCommitment()
+	switch {
+	case err == lnwallet.ErrNoWindow:
 		log.Tracef("revocation window exhausted, unable to send %v",
 			l.batchCounter)
 		return nil
-	} else if err != nil {
+
+	// If a splice of the channel is being negotiated, then we'll hold off
+	// on signing until the remote party has either accepted or rejected
+	// it.
+	case err == lnwallet.ErrSpliceNegotiating:
+		log.Tracef("splice being negotiated, unable to send %v",
+			l.batchCounter)
+		return nil
+
+	case err != nil:
 		return err
 	}
 
@@ -797,6 +868,10 @@ func (l *channelLink) updateCommitTx() error {
 		CommitSig: theirCommitSig,
 		HtlcSigs:  htlcSigs,
 	}
+	if spliceSigs != nil {
+		commitSig.SpliceCommitSig = spliceSigs.CommitSig
+		commitSig.SpliceHtlcSigs = spliceSigs.HtlcSigs
+	}
 	l.cfg.Peer.SendMessage(commitSig)
 
 	// We've just initiated a state transition, attempt to stop the
@@ -1040,7 +1115,7 @@ func (l *channelLink) processLockedInHtlcs(
 				// If we're unable to process the onion blob
 				// than we should send the malformed htlc error
 				// to payment sender.
-				l.sendMalformedHTLCError(pd.RHash, failureCode,
+				l.sendMalformedHTLCError(pd, failureCode,
 					onionBlob[:])
 				needUpdate = true
 
@@ -1068,7 +1143,7 @@ func (l *channelLink) processLockedInHtlcs(
 				// If we're unable to process the onion blob
 				// than we should send the malformed htlc error
 				// to payment sender.
-				l.sendMalformedHTLCError(pd.RHash, failureCode,
+				l.sendMalformedHTLCError(pd, failureCode,
 					onionBlob[:])
 				needUpdate = true
 
@@ -1093,7 +1168,7 @@ func (l *channelLink) processLockedInHtlcs(
 						pd.Timeout, heightNow)
 
 					failure := lnwire.FailFinalIncorrectCltvExpiry{}
-					l.sendHTLCError(pd.RHash, &failure, obfuscator)
+					l.sendHTLCError(pd, &failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1104,11 +1179,20 @@ func (l *channelLink) processLockedInHtlcs(
 				// this htlc.
 				invoiceHash := chainhash.Hash(pd.RHash)
 				invoice, err := l.cfg.Registry.LookupInvoice(invoiceHash)
+
+				// If we don't know of an invoice, then this
+				// may be a spontaneous payment, in which case
+				// we'll create an invoice for it on the fly.
+				if err != nil && fwdInfo.KeySendPreimage != nil {
+					invoice, err = l.addKeySendInvoice(
+						pd, fwdInfo,
+					)
+				}
 				if err != nil {
 					log.Errorf("unable to query invoice registry: "+
 						" %v", err)
 					failure := lnwire.FailUnknownPaymentHash{}
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1128,7 +1212,7 @@ func (l *channelLink) processLockedInHtlcs(
 						fwdInfo.AmountToForward)
 
 					failure := lnwire.FailIncorrectPaymentAmount{}
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1148,7 +1232,7 @@ func (l *channelLink) processLockedInHtlcs(
 						failure := lnwire.NewFinalIncorrectCltvExpiry(
 							fwdInfo.OutgoingCTLV,
 						)
-						l.sendHTLCError(pd.RHash, failure, obfuscator)
+						l.sendHTLCError(pd, failure, obfuscator)
 						needUpdate = true
 						continue
 					case pd.Timeout != fwdInfo.OutgoingCTLV:
@@ -1160,7 +1244,7 @@ func (l *channelLink) processLockedInHtlcs(
 						failure := lnwire.NewFinalIncorrectCltvExpiry(
 							fwdInfo.OutgoingCTLV,
 						)
-						l.sendHTLCError(pd.RHash, failure, obfuscator)
+						l.sendHTLCError(pd, failure, obfuscator)
 						needUpdate = true
 						continue
 					}
@@ -1177,7 +1261,7 @@ func (l *channelLink) processLockedInHtlcs(
 						"amount: expected %v, received %v",
 						invoice.Terms.Value, pd.Amount)
 					failure := lnwire.FailIncorrectPaymentAmount{}
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1196,6 +1280,15 @@ func (l *channelLink) processLockedInHtlcs(
 					return nil
 				}
 
+				// Add the preimage to the global cache so
+				// we're able to claim the HTLC on-chain if
+				// needed.
+				err = l.cfg.PreimageCache.AddPreimage(preimage[:])
+				if err != nil {
+					log.Errorf("unable to add preimage=%x "+
+						"to cache: %v", preimage[:], err)
+				}
+
 				// Notify the invoiceRegistry of the invoices
 				// we just settled with this latest commitment
 				// update.
@@ -1214,6 +1307,14 @@ func (l *channelLink) processLockedInHtlcs(
 				})
 				needUpdate = true
 
+				l.cfg.Switch.notifyHtlcEvent(&HtlcEvent{
+					Type:           HtlcEventReceive,
+					IncomingChanID: l.ShortChanID(),
+					IncomingHtlcID: pd.Index,
+					IncomingAmt:    pd.Amount,
+					PaymentHash:    pd.RHash,
+				})
+
 			// There are additional channels left within this
 			// route. So we'll verify that our forwarding
 			// constraints have been properly met by by this
@@ -1238,7 +1339,7 @@ func (l *channelLink) processLockedInHtlcs(
 						failure = lnwire.NewExpiryTooSoon(*update)
 					}
 
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1265,7 +1366,7 @@ func (l *channelLink) processLockedInHtlcs(
 							pd.Amount, *update)
 					}
 
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1307,7 +1408,7 @@ func (l *channelLink) processLockedInHtlcs(
 							*update)
 					}
 
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1338,7 +1439,7 @@ func (l *channelLink) processLockedInHtlcs(
 
 					failure := lnwire.NewIncorrectCltvExpiry(
 						pd.Timeout, *update)
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
@@ -1365,13 +1466,16 @@ func (l *channelLink) processLockedInHtlcs(
 						"remaining route %v", err)
 
 					failure := lnwire.NewTemporaryChannelFailure(nil)
-					l.sendHTLCError(pd.RHash, failure, obfuscator)
+					l.sendHTLCError(pd, failure, obfuscator)
 					needUpdate = true
 					continue
 				}
 
 				updatePacket := newAddPacket(l.ShortChanID(),
 					fwdInfo.NextHop, addMsg, obfuscator)
+				updatePacket.incomingHTLCID = pd.Index
+				updatePacket.incomingAmount = pd.Amount
+				updatePacket.incomingOnion = onionBlob
 				packetsToForward = append(packetsToForward, updatePacket)
 			}
 		}
@@ -1390,17 +1494,54 @@ func (l *channelLink) processLockedInHtlcs(
 	return packetsToForward
 }
 
+// addKeySendInvoice creates an invoice for the passed spontaneous payment, so
+// it can be settled like any other payment to one of our invoices. An error is
+// returned if we don't accept spontaneous payments, or the preimage delivered
+// by the sender doesn't match the payment hash of the HTLC.
+func (l *channelLink) addKeySendInvoice(pd *lnwallet.PaymentDescriptor,
+	fwdInfo ForwardingInfo) (*channeldb.Invoice, error) {
+
+	if !l.cfg.AcceptKeySend {
+		return nil, errors.Errorf("spontaneous payment htlc(%x) "+
+			"rejected, keysend isn't enabled", pd.RHash[:])
+	}
+
+	preimage := *fwdInfo.KeySendPreimage
+	if sha256.Sum256(preimage[:]) != pd.RHash {
+		return nil, errors.Errorf("preimage of spontaneous payment "+
+			"doesn't match htlc(%x)", pd.RHash[:])
+	}
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Memo:         []byte("keysend"),
+		Terms: channeldb.ContractTerm{
+			PaymentPreimage: preimage,
+			Value:           fwdInfo.AmountToForward,
+		},
+	}
+	if err := l.cfg.Registry.AddInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Created invoice for spontaneous payment htlc(%x) of %v",
+		pd.RHash[:], fwdInfo.AmountToForward)
+
+	return invoice, nil
+}
+
 // sendHTLCError functions cancels HTLC and send cancel message back to the
 // peer from which HTLC was received.
-func (l *channelLink) sendHTLCError(rHash [32]byte, failure lnwire.FailureMessage,
-	obfuscator Obfuscator) {
+func (l *channelLink) sendHTLCError(pd *lnwallet.PaymentDescriptor,
+	failure lnwire.FailureMessage, obfuscator Obfuscator) {
+
 	reason, err := obfuscator.InitialObfuscate(failure)
 	if err != nil {
 		log.Errorf("unable to obfuscate error: %v", err)
 		return
 	}
 
-	index, err := l.channel.FailHTLC(rHash)
+	index, err := l.channel.FailHTLC(pd.RHash)
 	if err != nil {
 		log.Errorf("unable cancel htlc: %v", err)
 		return
@@ -1411,13 +1552,16 @@ func (l *channelLink) sendHTLCError(rHash [32]byte, failure lnwire.FailureMessag
 		ID:     index,
 		Reason: reason,
 	})
+
+	l.notifyIncomingFail(pd, failure.Code())
 }
 
 // sendMalformedHTLCError helper function which sends the malformed HTLC update
 // to the payment sender.
-func (l *channelLink) sendMalformedHTLCError(rHash [32]byte, code lnwire.FailCode,
-	onionBlob []byte) {
-	index, err := l.channel.FailHTLC(rHash)
+func (l *channelLink) sendMalformedHTLCError(pd *lnwallet.PaymentDescriptor,
+	code lnwire.FailCode, onionBlob []byte) {
+
+	index, err := l.channel.FailHTLC(pd.RHash)
 	if err != nil {
 		log.Errorf("unable cancel htlc: %v", err)
 		return
@@ -1429,6 +1573,23 @@ func (l *channelLink) sendMalformedHTLCError(rHash [32]byte, code lnwire.FailCod
 		ShaOnionBlob: sha256.Sum256(onionBlob),
 		FailureCode:  code,
 	})
+
+	l.notifyIncomingFail(pd, code)
+}
+
+// notifyIncomingFail emits an HtlcEventLinkFail event for an incoming HTLC
+// which we've rejected with the passed failure code.
+func (l *channelLink) notifyIncomingFail(pd *lnwallet.PaymentDescriptor,
+	code lnwire.FailCode) {
+
+	l.cfg.Switch.notifyHtlcEvent(&HtlcEvent{
+		Type:           HtlcEventLinkFail,
+		IncomingChanID: l.ShortChanID(),
+		IncomingHtlcID: pd.Index,
+		IncomingAmt:    pd.Amount,
+		PaymentHash:    pd.RHash,
+		FailCode:       code,
+	})
 }
 
 // fail helper function which is used to encapsulate the action necessary for
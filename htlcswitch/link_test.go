@@ -762,8 +762,8 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 	}
 
 	// Send payment and expose err channel.
-	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
-		newMockDeobfuscator())
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(),
+		lnwire.ShortChannelID{}, htlc, newMockDeobfuscator())
 	if err.Error() != lnwire.CodeUnknownPaymentHash.String() {
 		t.Fatal("error haven't been received")
 	}
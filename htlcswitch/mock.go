@@ -24,6 +24,39 @@ import (
 	"github.com/roasbeef/btcd/wire"
 )
 
+type mockPreimageCache struct {
+	sync.Mutex
+	preimageMap map[[32]byte][]byte
+}
+
+func newMockPreimageCache() *mockPreimageCache {
+	return &mockPreimageCache{
+		preimageMap: make(map[[32]byte][]byte),
+	}
+}
+
+func (m *mockPreimageCache) LookupPreimage(hash []byte) ([]byte, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	var h [32]byte
+	copy(h[:], hash)
+
+	p, ok := m.preimageMap[h]
+	return p, ok
+}
+
+func (m *mockPreimageCache) AddPreimage(preimage []byte) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.preimageMap[sha256.Sum256(preimage[:])] = preimage
+
+	return nil
+}
+
+var _ lnwallet.PreimageCache = (*mockPreimageCache)(nil)
+
 type mockServer struct {
 	sync.Mutex
 
@@ -61,6 +94,9 @@ func newMockServer(t *testing.T, name string) *mockServer {
 			UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
 				return nil
 			},
+			NotifyActiveChannel:   func(lnwire.ChannelID) {},
+			NotifyInactiveChannel: func(lnwire.ChannelID) {},
+			NotifyHtlcEvent:       func(*HtlcEvent) {},
 		}),
 		recordFuncs: make([]func(lnwire.Message), 0),
 	}
@@ -184,14 +220,16 @@ func newMockDeobfuscator() Deobfuscator {
 	return &mockDeobfuscator{}
 }
 
-func (o *mockDeobfuscator) Deobfuscate(reason lnwire.OpaqueReason) (lnwire.FailureMessage,
+func (o *mockDeobfuscator) Deobfuscate(reason lnwire.OpaqueReason) (*ForwardingError,
 	error) {
 	r := bytes.NewReader(reason)
 	failure, err := lnwire.DecodeFailure(r, 0)
 	if err != nil {
 		return nil, err
 	}
-	return failure, nil
+	return &ForwardingError{
+		FailureMessage: failure,
+	}, nil
 }
 
 var _ Deobfuscator = (*mockDeobfuscator)(nil)
@@ -512,3 +550,50 @@ func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint) (*chainntnfs.S
 		Spend: make(chan *chainntnfs.SpendDetail),
 	}, nil
 }
+
+// mockHeldForwardStore is an in-memory HeldForwardStore which may be shared
+// between switches to emulate a restart.
+type mockHeldForwardStore struct {
+	sync.Mutex
+	fwds map[[2]uint64]*channeldb.HeldForward
+}
+
+func newMockHeldForwardStore() *mockHeldForwardStore {
+	return &mockHeldForwardStore{
+		fwds: make(map[[2]uint64]*channeldb.HeldForward),
+	}
+}
+
+func (m *mockHeldForwardStore) PutHeldForward(fwd *channeldb.HeldForward) error {
+	m.Lock()
+	defer m.Unlock()
+
+	key := [2]uint64{fwd.IncomingChanID.ToUint64(), fwd.IncomingHtlcID}
+	m.fwds[key] = fwd
+	return nil
+}
+
+func (m *mockHeldForwardStore) DeleteHeldForward(chanID lnwire.ShortChannelID,
+	htlcID uint64) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.fwds, [2]uint64{chanID.ToUint64(), htlcID})
+	return nil
+}
+
+func (m *mockHeldForwardStore) FetchHeldForwards() ([]*channeldb.HeldForward,
+	error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	fwds := make([]*channeldb.HeldForward, 0, len(m.fwds))
+	for _, fwd := range m.fwds {
+		fwds = append(fwds, fwd)
+	}
+	return fwds, nil
+}
+
+var _ HeldForwardStore = (*mockHeldForwardStore)(nil)
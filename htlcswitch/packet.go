@@ -20,7 +20,8 @@ type htlcPacket struct {
 	payHash [sha256.Size]byte
 
 	// dest is the destination of this packet identified by the short
-	// channel ID of the target link.
+	// channel ID of the target link. Within locally initiated add packets,
+	// this is only set if the HTLC must be sent over a particular channel.
 	dest lnwire.ShortChannelID
 
 	// src is the source of this packet identified by the short channel ID
@@ -30,6 +31,25 @@ type htlcPacket struct {
 	// amount is the value of the HTLC that is being created or modified.
 	amount lnwire.MilliSatoshi
 
+	// incomingHTLCID is the index of the HTLC within the channel it was
+	// received on.
+	//
+	// NOTE: This field is initialized only in forwarded add packets.
+	incomingHTLCID uint64
+
+	// incomingAmount is the value of the HTLC within the channel it was
+	// received on.
+	//
+	// NOTE: This field is initialized only in forwarded add packets.
+	incomingAmount lnwire.MilliSatoshi
+
+	// incomingOnion is the onion blob of the HTLC within the channel it
+	// was received on, which is persisted along with the HTLC if it's
+	// held for the forward interceptor.
+	//
+	// NOTE: This field is initialized only in forwarded add packets.
+	incomingOnion [lnwire.OnionPacketSize]byte
+
 	// htlc lnwire message type of which depends on switch request type.
 	htlc lnwire.Message
 
@@ -47,13 +67,20 @@ type htlcPacket struct {
 	// TODO(andrew.shvv) revisit after refactoring the way of returning
 	// errors inside the htlcswitch packet.
 	isObfuscated bool
+
+	// isResumed is set to true once the forward interceptor has resumed
+	// this packet, so it isn't intercepted a second time.
+	isResumed bool
 }
 
 // newInitPacket creates htlc switch add packet which encapsulates the add htlc
 // request and additional information for proper forwarding over htlc switch.
-func newInitPacket(destNode [33]byte, htlc *lnwire.UpdateAddHTLC) *htlcPacket {
+func newInitPacket(destNode [33]byte, outgoingChan lnwire.ShortChannelID,
+	htlc *lnwire.UpdateAddHTLC) *htlcPacket {
+
 	return &htlcPacket{
 		destNode: destNode,
+		dest:     outgoingChan,
 		htlc:     htlc,
 	}
 }
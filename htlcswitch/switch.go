@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -74,6 +75,22 @@ type ChanClose struct {
 	// ChanPoint represent the id of the channel which should be closed.
 	ChanPoint *wire.OutPoint
 
+	// TargetFeePerKw is the ideal fee that was specified by the caller.
+	// This value is only utilized if the closure type is CloseRegular.
+	// If zero, then the fee will be derived from the current fee
+	// estimate.
+	TargetFeePerKw btcutil.Amount
+
+	// MaxFee is the maximum fee the caller is willing to pay for the
+	// cooperative closing transaction. If zero, then no limit is
+	// enforced beyond the bounds of the fee negotiation itself.
+	MaxFee btcutil.Amount
+
+	// DeliveryScript is an optional delivery script to pay our funds to
+	// upon a cooperative close. If nil, then either our upfront shutdown
+	// script, or a fresh script from the wallet will be used.
+	DeliveryScript lnwire.DeliveryAddress
+
 	// Updates is used by request creator to receive the notifications about
 	// execution of the close channel request.
 	Updates chan *lnrpc.CloseStatusUpdate
@@ -95,6 +112,32 @@ type Config struct {
 	//
 	// TODO(roasbeef): remove
 	UpdateTopology func(msg *lnwire.ChannelUpdate) error
+
+	// NotifyActiveChannel is called each time the link of a channel is
+	// added to the switch, and the channel becomes usable for forwarding.
+	NotifyActiveChannel func(chanID lnwire.ChannelID)
+
+	// NotifyInactiveChannel is called each time the link of a channel is
+	// removed from the switch.
+	NotifyInactiveChannel func(chanID lnwire.ChannelID)
+
+	// NotifyHtlcEvent is called each time the switch or one of its links
+	// forwards, settles, fails, sends or receives an HTLC.
+	NotifyHtlcEvent func(event *HtlcEvent)
+
+	// InterceptTimeout is the amount of time an HTLC handed to the
+	// forward interceptor may be held before it's failed back. A value of
+	// zero means held HTLCs never time out.
+	InterceptTimeout time.Duration
+
+	// HeldForwards persists the HTLCs held for the forward interceptor,
+	// so they can be handed to it again once the switch restarts. If
+	// nil, held HTLCs are only kept in memory.
+	HeldForwards HeldForwardStore
+
+	// DecodeOnionObfuscator recovers the onion failure obfuscator of an
+	// HTLC restored from the HeldForwards store from its onion blob.
+	DecodeOnionObfuscator func(r io.Reader) (Obfuscator, lnwire.FailCode)
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -155,6 +198,20 @@ type Switch struct {
 	// linkControl is a channel used to propagate add/remove/get htlc
 	// switch handler commands.
 	linkControl chan interface{}
+
+	// interceptor, if set, is handed each HTLC the switch is about to
+	// forward, which is then held until the interceptor resolves it.
+	interceptor ForwardInterceptor
+
+	// heldForwards is the set of HTLCs currently held by the switch
+	// awaiting a resolution from the interceptor.
+	heldForwards map[interceptKey]*InterceptedForward
+
+	// restoredForwards is the set of HTLCs which were held when the
+	// switch was last stopped, indexed by the channel they were received
+	// on. Each of them is forwarded again once the link of its incoming
+	// channel is added, which hands it to the interceptor once more.
+	restoredForwards map[lnwire.ShortChannelID][]*htlcPacket
 }
 
 // New creates the new instance of htlc switch.
@@ -169,38 +226,81 @@ func New(cfg Config) *Switch {
 		htlcPlex:          make(chan *plexPacket),
 		chanCloseRequests: make(chan *ChanClose),
 		linkControl:       make(chan interface{}),
+		heldForwards:      make(map[interceptKey]*InterceptedForward),
+		restoredForwards:  make(map[lnwire.ShortChannelID][]*htlcPacket),
 		quit:              make(chan struct{}),
 	}
 }
 
 // SendHTLC is used by other subsystems which aren't belong to htlc switch
-// package in order to send the htlc update.
-func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
+// package in order to send the htlc update. If a non-zero outgoing channel is
+// passed, then the HTLC will only be sent over that channel, otherwise it's
+// sent over whichever channel with the next node has sufficient bandwidth.
+func (s *Switch) SendHTLC(nextNode [33]byte, outgoingChan lnwire.ShortChannelID,
+	htlc *lnwire.UpdateAddHTLC,
 	deobfuscator Deobfuscator) ([sha256.Size]byte, error) {
 
 	// Create payment and add to the map of payment in order later to be
 	// able to retrieve it and return response to the user.
+	payment := s.addPendingPayment(htlc.PaymentHash, htlc.Amount,
+		deobfuscator)
+
+	// Generate and send new update packet, if error will be received on
+	// this stage it means that packet haven't left boundaries of our
+	// system and something wrong happened.
+	packet := newInitPacket(nextNode, outgoingChan, htlc)
+	if err := s.forward(packet); err != nil {
+		s.removePendingPayment(payment.amount, payment.paymentHash)
+		return zeroPreimage, err
+	}
+
+	return s.waitForPayment(payment)
+}
+
+// ResumePayment registers a locally initiated payment whose HTLC was sent out
+// before the daemon restarted, so the switch hands its settle or fail back to
+// the caller rather than treating it as a forwarded HTLC. The payment is
+// registered before ResumePayment returns, and the returned closure blocks
+// until its outcome is known.
+//
+// NOTE: This should be called before the links of the switch are started, as
+// the HTLC may be resolved as soon as its channel is re-established.
+func (s *Switch) ResumePayment(paymentHash [sha256.Size]byte,
+	amount lnwire.MilliSatoshi,
+	deobfuscator Deobfuscator) func() ([sha256.Size]byte, error) {
+
+	payment := s.addPendingPayment(paymentHash, amount, deobfuscator)
+
+	return func() ([sha256.Size]byte, error) {
+		return s.waitForPayment(payment)
+	}
+}
+
+// addPendingPayment registers a new locally initiated payment, so its outcome
+// can be handed back to the caller once the switch receives it.
+func (s *Switch) addPendingPayment(paymentHash lnwallet.PaymentHash,
+	amount lnwire.MilliSatoshi, deobfuscator Deobfuscator) *pendingPayment {
+
 	payment := &pendingPayment{
 		err:          make(chan error, 1),
 		preimage:     make(chan [sha256.Size]byte, 1),
-		paymentHash:  htlc.PaymentHash,
-		amount:       htlc.Amount,
+		paymentHash:  paymentHash,
+		amount:       amount,
 		deobfuscator: deobfuscator,
 	}
 
 	s.pendingMutex.Lock()
-	s.pendingPayments[htlc.PaymentHash] = append(
-		s.pendingPayments[htlc.PaymentHash], payment)
+	s.pendingPayments[paymentHash] = append(
+		s.pendingPayments[paymentHash], payment)
 	s.pendingMutex.Unlock()
 
-	// Generate and send new update packet, if error will be received on
-	// this stage it means that packet haven't left boundaries of our
-	// system and something wrong happened.
-	packet := newInitPacket(nextNode, htlc)
-	if err := s.forward(packet); err != nil {
-		s.removePendingPayment(payment.amount, payment.paymentHash)
-		return zeroPreimage, err
-	}
+	return payment
+}
+
+// waitForPayment blocks until the passed pending payment has either been
+// settled or failed, or the switch shuts down.
+func (s *Switch) waitForPayment(
+	payment *pendingPayment) ([sha256.Size]byte, error) {
 
 	// Returns channels so that other subsystem might wait/skip the
 	// waiting of handling of payment.
@@ -224,6 +324,25 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 	return preimage, err
 }
 
+// SettleHTLC is used by other subsystems which learn of the preimage of an
+// HTLC outside of the switch, for example by observing the remote party sweep
+// an outgoing HTLC on-chain after a channel has been force closed. The settle
+// is propagated backwards to the link the HTLC was originally received on,
+// or returned to the local subsystem which initiated the payment.
+func (s *Switch) SettleHTLC(preimage [sha256.Size]byte,
+	amount lnwire.MilliSatoshi) error {
+
+	payHash := sha256.Sum256(preimage[:])
+	packet := newSettlePacket(lnwire.ShortChannelID{},
+		&lnwire.UpdateFufillHTLC{
+			PaymentPreimage: preimage,
+		},
+		payHash, amount,
+	)
+
+	return s.forward(packet)
+}
+
 // UpdateForwardingPolicies sends a message to the switch to update the
 // forwarding policies for the set of target channels. If the set of targeted
 // channels is nil, then the forwarding policies for all active channels with
@@ -344,9 +463,37 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 		if err != nil {
 			log.Errorf("unable to find links by "+
 				"destination %v", err)
+			s.notifyHtlcEvent(&HtlcEvent{
+				Type:          HtlcEventForwardFail,
+				OutgoingAmt:   htlc.Amount,
+				PaymentHash:   htlc.PaymentHash,
+				FailCode:      lnwire.CodeUnknownNextPeer,
+				FailureDetail: "unknown first hop",
+			})
 			return errors.New(lnwire.CodeUnknownNextPeer)
 		}
 
+		// If the caller requested that the HTLC be sent over a
+		// particular channel, then we'll only consider its link.
+		if packet.dest != (lnwire.ShortChannelID{}) {
+			link, err := s.getLinkByShortID(packet.dest)
+			if err != nil || link.Peer().PubKey() != packet.destNode {
+				log.Errorf("unable to find outgoing channel "+
+					"%v with destination %x", packet.dest,
+					packet.destNode[:])
+				s.notifyHtlcEvent(&HtlcEvent{
+					Type:           HtlcEventForwardFail,
+					OutgoingChanID: packet.dest,
+					OutgoingAmt:    htlc.Amount,
+					PaymentHash:    htlc.PaymentHash,
+					FailCode:       lnwire.CodeUnknownNextPeer,
+					FailureDetail:  "unknown outgoing channel",
+				})
+				return errors.New(lnwire.CodeUnknownNextPeer)
+			}
+			links = []ChannelLink{link}
+		}
+
 		// Try to find destination channel link with appropriate
 		// bandwidth.
 		var destination ChannelLink
@@ -363,6 +510,13 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 		if destination == nil {
 			log.Errorf("unable to find appropriate channel link "+
 				"insufficient capacity, need %v", htlc.Amount)
+			s.notifyHtlcEvent(&HtlcEvent{
+				Type:          HtlcEventForwardFail,
+				OutgoingAmt:   htlc.Amount,
+				PaymentHash:   htlc.PaymentHash,
+				FailCode:      lnwire.CodeTemporaryChannelFailure,
+				FailureDetail: "insufficient bandwidth",
+			})
 			return errors.New(lnwire.CodeTemporaryChannelFailure)
 		}
 
@@ -374,6 +528,13 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 	// We've just received a settle update which means we can finalize
 	// the user payment and return successful response.
 	case *lnwire.UpdateFufillHTLC:
+		s.notifyHtlcEvent(&HtlcEvent{
+			Type:           HtlcEventSettle,
+			OutgoingChanID: packet.src,
+			OutgoingAmt:    packet.amount,
+			PaymentHash:    packet.payHash,
+		})
+
 		// Notify the user that his payment was
 		// successfully proceed.
 		payment.err <- nil
@@ -385,6 +546,14 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 	case *lnwire.UpdateFailHTLC:
 		var userErr error
 
+		failEvent := &HtlcEvent{
+			Type:           HtlcEventFail,
+			OutgoingChanID: packet.src,
+			OutgoingAmt:    packet.amount,
+			PaymentHash:    packet.payHash,
+			RemoteFailure:  true,
+		}
+
 		// We'll attempt to fully decrypt the onion encrypted error. If
 		// we're unable to then we'll bail early.
 		failure, err := payment.deobfuscator.Deobfuscate(htlc.Reason)
@@ -401,7 +570,7 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 			// Only a few error message actually contain a channel
 			// update message, so we'll filter out for those that
 			// do.
-			switch failure := failure.(type) {
+			switch failure := failure.FailureMessage.(type) {
 			case *lnwire.FailTemporaryChannelFailure:
 				update = failure.Update
 			case *lnwire.FailAmountBelowMinimum:
@@ -433,8 +602,10 @@ func (s *Switch) handleLocalDispatch(payment *pendingPayment, packet *htlcPacket
 				}
 			}
 
-			userErr = errors.New(failure.Code())
+			userErr = failure
+			failEvent.FailCode = failure.Code()
 		}
+		s.notifyHtlcEvent(failEvent)
 
 		// Notify user that his payment was discarded.
 		payment.err <- userErr
@@ -466,6 +637,16 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return err
 		}
 
+		// If an interceptor is registered, then we'll hand the HTLC
+		// off to it before looking up the outgoing link, as the
+		// interceptor may wish to create the channel the HTLC is to
+		// be forwarded over. Packets which the interceptor has
+		// already resumed are forwarded as normal.
+		if s.interceptor != nil && !packet.isResumed {
+			s.interceptForward(packet, htlc)
+			return nil
+		}
+
 		targetLink, err := s.getLinkByShortID(packet.dest)
 		if err != nil {
 			// If packet was forwarded from another channel link
@@ -487,6 +668,9 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				},
 				htlc.PaymentHash, 0, true,
 			))
+			s.notifyForwardFail(packet, htlc, failure.Code(),
+				"unknown next channel")
+
 			err = errors.Errorf("unable to find link with "+
 				"destination %v", packet.dest)
 			log.Error(err)
@@ -528,6 +712,8 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				htlc.PaymentHash,
 				0, true,
 			))
+			s.notifyForwardFail(packet, htlc, failure.Code(),
+				"insufficient bandwidth")
 
 			err = errors.Errorf("unable to find appropriate "+
 				"channel link insufficient capacity, need "+
@@ -561,6 +747,9 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				},
 				htlc.PaymentHash, 0, true,
 			))
+			s.notifyForwardFail(packet, htlc, failure.Code(),
+				"unable to add circuit")
+
 			err = errors.Errorf("unable to add circuit: "+
 				"%v", err)
 			log.Error(err)
@@ -607,6 +796,24 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			"circuit for %x: %v<->%v", packet.payHash[:],
 			circuit.Src, circuit.Dest)
 
+		// With the circuit closed, we'll notify any subscribers of the
+		// outcome of the forwarded HTLC.
+		event := &HtlcEvent{
+			Type:           HtlcEventSettle,
+			IncomingChanID: circuit.Src,
+			OutgoingChanID: circuit.Dest,
+			OutgoingAmt:    packet.amount,
+			PaymentHash:    packet.payHash,
+		}
+		if _, ok := htlc.(*lnwire.UpdateFailHTLC); ok {
+			// Failures which were obfuscated before reaching the
+			// switch were generated by our outgoing link, which
+			// will have already emitted a link failure event.
+			event.Type = HtlcEventFail
+			event.RemoteFailure = !packet.isObfuscated
+		}
+		s.notifyHtlcEvent(event)
+
 		source.HandleSwitchPacket(packet)
 		return nil
 
@@ -615,19 +822,44 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 	}
 }
 
-// CloseLink creates and sends the close channel command.
+// notifyForwardFail emits an HtlcEventForwardFail event for an HTLC the switch
+// was unable to forward.
+func (s *Switch) notifyForwardFail(packet *htlcPacket,
+	htlc *lnwire.UpdateAddHTLC, failCode lnwire.FailCode, detail string) {
+
+	s.notifyHtlcEvent(&HtlcEvent{
+		Type:           HtlcEventForwardFail,
+		IncomingChanID: packet.src,
+		OutgoingChanID: packet.dest,
+		IncomingHtlcID: packet.incomingHTLCID,
+		IncomingAmt:    packet.incomingAmount,
+		OutgoingAmt:    htlc.Amount,
+		PaymentHash:    htlc.PaymentHash,
+		FailCode:       failCode,
+		FailureDetail:  detail,
+	})
+}
+
+// CloseLink creates and sends the close channel command. The target fee
+// rate, max fee and delivery script are only used for cooperative closures,
+// and may be left unset in order to use the default values.
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
-	closeType ChannelCloseType) (chan *lnrpc.CloseStatusUpdate, chan error) {
+	closeType ChannelCloseType, targetFeePerKw, maxFee btcutil.Amount,
+	deliveryScript lnwire.DeliveryAddress) (chan *lnrpc.CloseStatusUpdate,
+	chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
 	updateChan := make(chan *lnrpc.CloseStatusUpdate, 2)
 	errChan := make(chan error, 1)
 
 	command := &ChanClose{
-		CloseType: closeType,
-		ChanPoint: chanPoint,
-		Updates:   updateChan,
-		Err:       errChan,
+		CloseType:      closeType,
+		ChanPoint:      chanPoint,
+		TargetFeePerKw: targetFeePerKw,
+		MaxFee:         maxFee,
+		DeliveryScript: deliveryScript,
+		Updates:        updateChan,
+		Err:            errChan,
 	}
 
 	select {
@@ -810,6 +1042,10 @@ func (s *Switch) htlcForwarder() {
 				links, err := s.getLinks(cmd.peer)
 				cmd.done <- links
 				cmd.err <- err
+			case *setInterceptorCmd:
+				cmd.err <- s.setInterceptor(cmd.interceptor)
+			case *resolveForwardCmd:
+				cmd.err <- s.handleResolveForward(cmd)
 			}
 
 		case <-s.quit:
@@ -827,6 +1063,10 @@ func (s *Switch) Start() error {
 
 	log.Infof("Starting HTLC Switch")
 
+	if err := s.restoreHeldForwards(); err != nil {
+		return err
+	}
+
 	s.wg.Add(1)
 	go s.htlcForwarder()
 
@@ -894,10 +1134,17 @@ func (s *Switch) addLink(link ChannelLink) error {
 		return err
 	}
 
+	// If any HTLCs received over this link were held when the switch was
+	// last stopped, then we'll forward them again now that the link is
+	// back.
+	s.forwardRestoredHtlcs(link.ShortChanID())
+
 	log.Infof("Added channel link with chan_id=%v, short_chan_id=(%v), "+
 		"bandwidth=%v", link.ChanID(), spew.Sdump(link.ShortChanID()),
 		link.Bandwidth())
 
+	s.cfg.NotifyActiveChannel(link.ChanID())
+
 	return nil
 }
 
@@ -989,6 +1236,8 @@ func (s *Switch) removeLink(chanID lnwire.ChannelID) error {
 
 	go link.Stop()
 
+	s.cfg.NotifyInactiveChannel(chanID)
+
 	return nil
 }
 
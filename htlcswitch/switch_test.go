@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"bytes"
 	"crypto/sha256"
+	"io"
 	"testing"
 	"time"
 
@@ -44,6 +45,9 @@ func TestSwitchForward(t *testing.T) {
 		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
 			return nil
 		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
 	})
 	s.Start()
 	if err := s.AddLink(aliceChannelLink); err != nil {
@@ -109,6 +113,531 @@ func TestSwitchForward(t *testing.T) {
 	}
 }
 
+// TestSwitchHtlcEvents checks that the switch notifies its subscriber of
+// failed forwards, and of the settles of forwarded HTLCs.
+func TestSwitchHtlcEvents(t *testing.T) {
+	t.Parallel()
+
+	alicePeer := newMockServer(t, "alice")
+	bobPeer := newMockServer(t, "bob")
+
+	aliceChannelLink := newMockChannelLink(chanID1, aliceChanID, alicePeer)
+	bobChannelLink := newMockChannelLink(chanID2, bobChanID, bobPeer)
+
+	events := make(chan *HtlcEvent, 1)
+	s := New(Config{
+		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
+			return nil
+		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent: func(event *HtlcEvent) {
+			events <- event
+		},
+	})
+	s.Start()
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	// First, we'll attempt to forward an HTLC to a channel the switch
+	// doesn't know of. This should fail, and result in a forward failure
+	// event.
+	preimage := [sha256.Size]byte{1}
+	rhash := fastsha256.Sum256(preimage[:])
+	unknownChanID := lnwire.NewShortChanIDFromInt(3)
+	packet := newAddPacket(
+		aliceChannelLink.ShortChanID(),
+		unknownChanID,
+		&lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		}, newMockObfuscator(),
+	)
+	packet.incomingHTLCID = 7
+	if err := s.forward(packet); err == nil {
+		t.Fatal("forward to unknown channel should have failed")
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != HtlcEventForwardFail {
+			t.Fatalf("expected %v event, got %v",
+				HtlcEventForwardFail, event.Type)
+		}
+		if event.FailCode != lnwire.CodeUnknownNextPeer {
+			t.Fatalf("expected fail code %v, got %v",
+				lnwire.CodeUnknownNextPeer, event.FailCode)
+		}
+		if event.IncomingChanID != aliceChanID ||
+			event.OutgoingChanID != unknownChanID {
+			t.Fatalf("wrong channels in event: %v -> %v",
+				event.IncomingChanID, event.OutgoingChanID)
+		}
+		if event.IncomingHtlcID != 7 {
+			t.Fatalf("expected incoming htlc id 7, got %v",
+				event.IncomingHtlcID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("forward failure event not sent")
+	}
+
+	// The failure should also have been sent back to alice's link.
+	select {
+	case <-aliceChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("failure was not propagated to alice")
+	}
+
+	// Next, we'll forward the HTLC to bob's link, then settle it. This
+	// should result in a settle event referencing both channels.
+	packet = newAddPacket(
+		aliceChannelLink.ShortChanID(),
+		bobChannelLink.ShortChanID(),
+		&lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		}, newMockObfuscator(),
+	)
+	if err := s.forward(packet); err != nil {
+		t.Fatal(err)
+	}
+
+	packet = newSettlePacket(
+		bobChannelLink.ShortChanID(),
+		&lnwire.UpdateFufillHTLC{
+			PaymentPreimage: preimage,
+		},
+		rhash, 1)
+	if err := s.forward(packet); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != HtlcEventSettle {
+			t.Fatalf("expected %v event, got %v",
+				HtlcEventSettle, event.Type)
+		}
+		if event.IncomingChanID != aliceChanID ||
+			event.OutgoingChanID != bobChanID {
+			t.Fatalf("wrong channels in event: %v -> %v",
+				event.IncomingChanID, event.OutgoingChanID)
+		}
+		if event.PaymentHash != rhash {
+			t.Fatalf("wrong payment hash in event: %x",
+				event.PaymentHash)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("settle event not sent")
+	}
+}
+
+// TestSwitchInterceptForward checks that while an interceptor is registered,
+// forwarded HTLCs are held by the switch until they're resumed, failed or
+// settled, and that unregistering the interceptor resumes any HTLCs still
+// held.
+func TestSwitchInterceptForward(t *testing.T) {
+	t.Parallel()
+
+	alicePeer := newMockServer(t, "alice")
+	bobPeer := newMockServer(t, "bob")
+
+	aliceChannelLink := newMockChannelLink(chanID1, aliceChanID, alicePeer)
+	bobChannelLink := newMockChannelLink(chanID2, bobChanID, bobPeer)
+
+	s := New(Config{
+		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
+			return nil
+		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
+	})
+	s.Start()
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	intercepted := make(chan *InterceptedForward, 1)
+	err := s.SetInterceptor(func(fwd *InterceptedForward) {
+		intercepted <- fwd
+	})
+	if err != nil {
+		t.Fatalf("unable to set interceptor: %v", err)
+	}
+
+	// Only a single interceptor may be registered at a time.
+	err = s.SetInterceptor(func(*InterceptedForward) {})
+	if err != ErrInterceptorActive {
+		t.Fatalf("expected %v, got %v", ErrInterceptorActive, err)
+	}
+
+	preimage := [sha256.Size]byte{1}
+	rhash := fastsha256.Sum256(preimage[:])
+
+	// forwardHtlc forwards a new HTLC from alice to bob, and returns it
+	// once it has been handed to the interceptor.
+	forwardHtlc := func(htlcID uint64) *InterceptedForward {
+		packet := newAddPacket(
+			aliceChannelLink.ShortChanID(),
+			bobChannelLink.ShortChanID(),
+			&lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+			}, newMockObfuscator(),
+		)
+		packet.incomingHTLCID = htlcID
+		if err := s.forward(packet); err != nil {
+			t.Fatalf("unable to forward htlc: %v", err)
+		}
+
+		select {
+		case fwd := <-intercepted:
+			if fwd.IncomingChanID != aliceChanID ||
+				fwd.OutgoingChanID != bobChanID {
+				t.Fatalf("wrong channels in forward: %v -> %v",
+					fwd.IncomingChanID, fwd.OutgoingChanID)
+			}
+			if fwd.IncomingHtlcID != htlcID {
+				t.Fatalf("expected incoming htlc id %v, got %v",
+					htlcID, fwd.IncomingHtlcID)
+			}
+			return fwd
+		case <-time.After(time.Second):
+			t.Fatal("htlc was not intercepted")
+		}
+
+		return nil
+	}
+
+	// The intercepted HTLC shouldn't have been forwarded to bob.
+	fwd := forwardHtlc(1)
+	select {
+	case <-bobChannelLink.packets:
+		t.Fatal("intercepted htlc was forwarded")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Settling the HTLC with the wrong preimage should fail, while the
+	// correct preimage should settle it back to alice.
+	if err := fwd.Settle([sha256.Size]byte{2}); err == nil {
+		t.Fatal("settle with invalid preimage should have failed")
+	}
+	if err := fwd.Settle(preimage); err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if _, ok := packet.htlc.(*lnwire.UpdateFufillHTLC); !ok {
+			t.Fatalf("expected settle, got %T", packet.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("settle was not sent to alice")
+	}
+
+	// The HTLC has been resolved, so it can't be resolved again.
+	select {
+	case <-fwd.Resolved():
+	default:
+		t.Fatal("settled htlc not signalled as resolved")
+	}
+	if err := fwd.Resume(); err != ErrForwardNotFound {
+		t.Fatalf("expected %v, got %v", ErrForwardNotFound, err)
+	}
+
+	// Failing the next HTLC should send a failure back to alice.
+	fwd = forwardHtlc(2)
+	if err := fwd.Fail(lnwire.CodeUnknownPaymentHash); err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if _, ok := packet.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", packet.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failure was not sent to alice")
+	}
+
+	// Resuming the next HTLC should forward it to bob.
+	fwd = forwardHtlc(3)
+	if err := fwd.Resume(); err != nil {
+		t.Fatalf("unable to resume htlc: %v", err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("resumed htlc was not forwarded to bob")
+	}
+
+	// Finally, removing the interceptor should resume any HTLCs it still
+	// holds.
+	forwardHtlc(4)
+	if err := s.SetInterceptor(nil); err != nil {
+		t.Fatalf("unable to remove interceptor: %v", err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("held htlc was not forwarded to bob")
+	}
+}
+
+// TestSwitchInterceptTimeout checks that an HTLC which isn't resolved by the
+// interceptor within the timeout is failed back, and signalled as resolved.
+func TestSwitchInterceptTimeout(t *testing.T) {
+	t.Parallel()
+
+	alicePeer := newMockServer(t, "alice")
+	bobPeer := newMockServer(t, "bob")
+
+	aliceChannelLink := newMockChannelLink(chanID1, aliceChanID, alicePeer)
+	bobChannelLink := newMockChannelLink(chanID2, bobChanID, bobPeer)
+
+	s := New(Config{
+		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
+			return nil
+		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
+		InterceptTimeout:      50 * time.Millisecond,
+	})
+	s.Start()
+	defer s.Stop()
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	intercepted := make(chan *InterceptedForward, 1)
+	err := s.SetInterceptor(func(fwd *InterceptedForward) {
+		intercepted <- fwd
+	})
+	if err != nil {
+		t.Fatalf("unable to set interceptor: %v", err)
+	}
+
+	preimage := [sha256.Size]byte{1}
+	packet := newAddPacket(
+		aliceChannelLink.ShortChanID(),
+		bobChannelLink.ShortChanID(),
+		&lnwire.UpdateAddHTLC{
+			PaymentHash: fastsha256.Sum256(preimage[:]),
+			Amount:      1,
+		}, newMockObfuscator(),
+	)
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("unable to forward htlc: %v", err)
+	}
+
+	var fwd *InterceptedForward
+	select {
+	case fwd = <-intercepted:
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not intercepted")
+	}
+
+	// Without a resolution from the interceptor, the HTLC should be
+	// failed back to alice once the timeout expires.
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if _, ok := packet.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", packet.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out htlc was not failed back to alice")
+	}
+
+	select {
+	case <-fwd.Resolved():
+	case <-time.After(time.Second):
+		t.Fatal("timed out htlc not signalled as resolved")
+	}
+	if err := fwd.Resume(); err != ErrForwardNotFound {
+		t.Fatalf("expected %v, got %v", ErrForwardNotFound, err)
+	}
+}
+
+// TestSwitchInterceptRestart checks that HTLCs held for the interceptor when
+// the switch is stopped are handed to the interceptor once more after a
+// restart, and are forwarded as normal if no interceptor is registered by the
+// time their incoming link is added.
+func TestSwitchInterceptRestart(t *testing.T) {
+	t.Parallel()
+
+	alicePeer := newMockServer(t, "alice")
+	bobPeer := newMockServer(t, "bob")
+
+	aliceChannelLink := newMockChannelLink(chanID1, aliceChanID, alicePeer)
+	bobChannelLink := newMockChannelLink(chanID2, bobChanID, bobPeer)
+
+	// Each switch created below shares the same store, emulating a single
+	// switch being restarted.
+	store := newMockHeldForwardStore()
+	newSwitch := func() *Switch {
+		s := New(Config{
+			UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
+				return nil
+			},
+			NotifyActiveChannel:   func(lnwire.ChannelID) {},
+			NotifyInactiveChannel: func(lnwire.ChannelID) {},
+			NotifyHtlcEvent:       func(*HtlcEvent) {},
+			HeldForwards:          store,
+			DecodeOnionObfuscator: func(io.Reader) (Obfuscator,
+				lnwire.FailCode) {
+
+				return newMockObfuscator(), lnwire.CodeNone
+			},
+		})
+		if err := s.Start(); err != nil {
+			t.Fatalf("unable to start switch: %v", err)
+		}
+		return s
+	}
+
+	// Bob's link is added first, so that restored HTLCs can be forwarded
+	// to him as soon as alice's link is added.
+	addLinks := func(s *Switch) {
+		if err := s.AddLink(bobChannelLink); err != nil {
+			t.Fatalf("unable to add bob link: %v", err)
+		}
+		if err := s.AddLink(aliceChannelLink); err != nil {
+			t.Fatalf("unable to add alice link: %v", err)
+		}
+	}
+
+	setInterceptor := func(s *Switch) chan *InterceptedForward {
+		intercepted := make(chan *InterceptedForward, 1)
+		err := s.SetInterceptor(func(fwd *InterceptedForward) {
+			intercepted <- fwd
+		})
+		if err != nil {
+			t.Fatalf("unable to set interceptor: %v", err)
+		}
+		return intercepted
+	}
+
+	assertIntercepted := func(intercepted chan *InterceptedForward,
+		htlcID uint64) *InterceptedForward {
+
+		select {
+		case fwd := <-intercepted:
+			if fwd.IncomingHtlcID != htlcID {
+				t.Fatalf("expected incoming htlc id %v, got %v",
+					htlcID, fwd.IncomingHtlcID)
+			}
+			return fwd
+		case <-time.After(time.Second):
+			t.Fatal("htlc was not intercepted")
+		}
+
+		return nil
+	}
+
+	assertStored := func(numFwds int) {
+		fwds, err := store.FetchHeldForwards()
+		if err != nil {
+			t.Fatalf("unable to fetch held forwards: %v", err)
+		}
+		if len(fwds) != numFwds {
+			t.Fatalf("expected %v held forwards, got %v", numFwds,
+				len(fwds))
+		}
+	}
+
+	s := newSwitch()
+	addLinks(s)
+	intercepted := setInterceptor(s)
+
+	// We'll hold two HTLCs from alice, which should both be persisted.
+	preimage := [sha256.Size]byte{1}
+	rhash := fastsha256.Sum256(preimage[:])
+	for htlcID := uint64(1); htlcID <= 2; htlcID++ {
+		packet := newAddPacket(
+			aliceChannelLink.ShortChanID(),
+			bobChannelLink.ShortChanID(),
+			&lnwire.UpdateAddHTLC{
+				PaymentHash: rhash,
+				Amount:      1,
+			}, newMockObfuscator(),
+		)
+		packet.incomingHTLCID = htlcID
+		if err := s.forward(packet); err != nil {
+			t.Fatalf("unable to forward htlc: %v", err)
+		}
+		assertIntercepted(intercepted, htlcID)
+	}
+	assertStored(2)
+
+	if err := s.Stop(); err != nil {
+		t.Fatalf("unable to stop switch: %v", err)
+	}
+
+	// After a restart, both HTLCs should be handed to the new interceptor
+	// once alice's link has been added.
+	s = newSwitch()
+	intercepted = setInterceptor(s)
+	addLinks(s)
+
+	fwd := assertIntercepted(intercepted, 1)
+	if fwd.PaymentHash != rhash || fwd.IncomingChanID != aliceChanID ||
+		fwd.OutgoingChanID != bobChanID {
+
+		t.Fatalf("restored htlc doesn't match the held htlc")
+	}
+	secondFwd := assertIntercepted(intercepted, 2)
+
+	// Failing the first HTLC should send a failure back to alice, and
+	// remove it from the store.
+	if err := fwd.Fail(lnwire.CodeTemporaryChannelFailure); err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+	select {
+	case packet := <-aliceChannelLink.packets:
+		if _, ok := packet.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", packet.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failure was not sent to alice")
+	}
+	assertStored(1)
+
+	if err := s.Stop(); err != nil {
+		t.Fatalf("unable to stop switch: %v", err)
+	}
+
+	// If no interceptor is registered after the restart, then the
+	// remaining HTLC should be forwarded to bob as normal once alice's
+	// link has been added.
+	s = newSwitch()
+	defer s.Stop()
+	addLinks(s)
+
+	select {
+	case packet := <-bobChannelLink.packets:
+		add, ok := packet.htlc.(*lnwire.UpdateAddHTLC)
+		if !ok {
+			t.Fatalf("expected add, got %T", packet.htlc)
+		}
+		if add.PaymentHash != secondFwd.PaymentHash {
+			t.Fatalf("wrong htlc forwarded to bob")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("restored htlc was not forwarded to bob")
+	}
+	assertStored(0)
+}
+
 // TestSwitchCancel checks that if htlc was rejected we remove unused
 // circuits.
 func TestSwitchCancel(t *testing.T) {
@@ -126,6 +655,9 @@ func TestSwitchCancel(t *testing.T) {
 		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
 			return nil
 		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
 	})
 	s.Start()
 	if err := s.AddLink(aliceChannelLink); err != nil {
@@ -206,6 +738,9 @@ func TestSwitchAddSamePayment(t *testing.T) {
 		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
 			return nil
 		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
 	})
 	s.Start()
 	if err := s.AddLink(aliceChannelLink); err != nil {
@@ -306,6 +841,9 @@ func TestSwitchSendPayment(t *testing.T) {
 		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
 			return nil
 		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
 	})
 	s.Start()
 	if err := s.AddLink(aliceChannelLink); err != nil {
@@ -324,16 +862,16 @@ func TestSwitchSendPayment(t *testing.T) {
 	// Handle the request and checks that bob channel link received it.
 	errChan := make(chan error)
 	go func() {
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			lnwire.ShortChannelID{}, update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
 	go func() {
 		// Send the payment with the same payment hash and same
 		// amount and check that it will be propagated successfully
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			lnwire.ShortChannelID{}, update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
@@ -412,3 +950,75 @@ func TestSwitchSendPayment(t *testing.T) {
 		t.Fatal("wrong amount of pending payments")
 	}
 }
+
+// TestSwitchResumePayment checks that the outcome of a payment resumed after a
+// restart is handed back to the caller, rather than being treated as the
+// resolution of a forwarded HTLC.
+func TestSwitchResumePayment(t *testing.T) {
+	t.Parallel()
+
+	alicePeer := newMockServer(t, "alice")
+	aliceChannelLink := newMockChannelLink(chanID1, aliceChanID, alicePeer)
+
+	s := New(Config{
+		UpdateTopology: func(msg *lnwire.ChannelUpdate) error {
+			return nil
+		},
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*HtlcEvent) {},
+	})
+	s.Start()
+
+	preimage := [sha256.Size]byte{1}
+	rhash := fastsha256.Sum256(preimage[:])
+
+	// Resume the payment before the link is added, as would happen upon
+	// startup, after which it should be pending within the switch.
+	wait := s.ResumePayment(rhash, 1, newMockDeobfuscator())
+	if s.numPendingPayments() != 1 {
+		t.Fatal("wrong amount of pending payments")
+	}
+
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	type result struct {
+		preimage [sha256.Size]byte
+		err      error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		p, err := wait()
+		resultChan <- result{p, err}
+	}()
+
+	// Pretend that the link settled the HTLC once the channel was
+	// re-established.
+	packet := newSettlePacket(aliceChannelLink.ShortChanID(),
+		&lnwire.UpdateFufillHTLC{
+			PaymentPreimage: preimage,
+		},
+		rhash, 1)
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("can't forward htlc packet: %v", err)
+	}
+
+	select {
+	case res := <-resultChan:
+		if res.err != nil {
+			t.Fatalf("unable to resume payment: %v", res.err)
+		}
+		if res.preimage != preimage {
+			t.Fatalf("wrong preimage: expected %x, got %x",
+				preimage[:], res.preimage[:])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payment result wasn't received")
+	}
+
+	if s.numPendingPayments() != 0 {
+		t.Fatal("wrong amount of pending payments")
+	}
+}
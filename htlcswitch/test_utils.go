@@ -147,7 +147,7 @@ func createTestChannel(alicePrivKey, bobPrivKey []byte,
 
 	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(aliceAmount,
 		bobAmount, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		fundingTxIn)
+		fundingTxIn, channeldb.SingleFunder)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -222,12 +222,12 @@ func createTestChannel(alicePrivKey, bobPrivKey []byte,
 	bobSigner := &mockSigner{bobKeyPriv}
 
 	channelAlice, err := lnwallet.NewLightningChannel(aliceSigner,
-		nil, estimator, aliceChannelState)
+		nil, nil, estimator, aliceChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	channelBob, err := lnwallet.NewLightningChannel(bobSigner, nil,
-		estimator, bobChannelState)
+		nil, estimator, bobChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -441,8 +441,8 @@ func (n *threeHopNetwork) makePayment(sendingPeer, receivingPeer Peer,
 	// Send payment and expose err channel.
 	errChan := make(chan error)
 	go func() {
-		_, err := sender.htlcSwitch.SendHTLC(firstHopPub, htlc,
-			newMockDeobfuscator())
+		_, err := sender.htlcSwitch.SendHTLC(firstHopPub,
+			lnwire.ShortChannelID{}, htlc, newMockDeobfuscator())
 		errChan <- err
 	}()
 
@@ -548,6 +548,7 @@ func newThreeHopNetwork(t *testing.T, aliceToBob,
 		TimeLockDelta: 6,
 	}
 	obfuscator := newMockObfuscator()
+	pCache := newMockPreimageCache()
 	aliceChannelLink := NewChannelLink(
 		ChannelLinkConfig{
 			FwrdingPolicy:     globalPolicy,
@@ -561,6 +562,7 @@ func newThreeHopNetwork(t *testing.T, aliceToBob,
 			GetLastChannelUpdate: mockGetChanUpdateMessage,
 			Registry:             aliceServer.registry,
 			BlockEpochs:          globalEpoch,
+			PreimageCache:        pCache,
 		},
 		aliceChannel,
 		startingHeight,
@@ -582,6 +584,7 @@ func newThreeHopNetwork(t *testing.T, aliceToBob,
 			GetLastChannelUpdate: mockGetChanUpdateMessage,
 			Registry:             bobServer.registry,
 			BlockEpochs:          globalEpoch,
+			PreimageCache:        pCache,
 		},
 		firstBobChannel,
 		startingHeight,
@@ -603,6 +606,7 @@ func newThreeHopNetwork(t *testing.T, aliceToBob,
 			GetLastChannelUpdate: mockGetChanUpdateMessage,
 			Registry:             bobServer.registry,
 			BlockEpochs:          globalEpoch,
+			PreimageCache:        pCache,
 		},
 		secondBobChannel,
 		startingHeight,
@@ -624,6 +628,7 @@ func newThreeHopNetwork(t *testing.T, aliceToBob,
 			GetLastChannelUpdate: mockGetChanUpdateMessage,
 			Registry:             carolServer.registry,
 			BlockEpochs:          globalEpoch,
+			PreimageCache:        pCache,
 		},
 		carolChannel,
 		startingHeight,
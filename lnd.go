@@ -186,10 +186,11 @@ func lndMain() error {
 				idPrivKey.PubKey())
 			return <-errChan
 		},
-		ArbiterChan:    server.breachArbiter.newContracts,
-		SendToPeer:     server.SendToPeer,
-		FindPeer:       server.FindPeer,
-		TempChanIDSeed: chanIDSeed,
+		ArbiterChan:      server.breachArbiter.newContracts,
+		SettledContracts: server.breachArbiter.settledContracts,
+		SendToPeer:       server.SendToPeer,
+		FindPeer:         server.FindPeer,
+		TempChanIDSeed:   chanIDSeed,
 		FindChannel: func(chanID lnwire.ChannelID) (*lnwallet.LightningChannel, error) {
 			dbChannels, err := chanDB.FetchAllChannels()
 			if err != nil {
@@ -200,6 +201,7 @@ func lndMain() error {
 				if chanID.IsChanPoint(&channel.FundingOutpoint) {
 					return lnwallet.NewLightningChannel(
 						activeChainControl.signer,
+						server.witnessBeacon,
 						activeChainControl.chainNotifier,
 						activeChainControl.feeEstimator,
 						channel)
@@ -220,6 +222,10 @@ func lndMain() error {
 			// configuration
 			return 4
 		},
+		NotifyPendingOpenChannelEvent: server.channelNotifier.
+			NotifyPendingOpenChannelEvent,
+		NotifyOpenChannelEvent: server.channelNotifier.
+			NotifyOpenChannelEvent,
 	})
 	if err != nil {
 		return err
@@ -13,6 +13,9 @@ It has these top-level messages:
 	TransactionDetails
 	SendRequest
 	SendResponse
+	SendToRouteRequest
+	RebalanceRequest
+	RebalanceResponse
 	ChannelPoint
 	LightningAddress
 	SendManyRequest
@@ -34,26 +37,51 @@ It has these top-level messages:
 	ActiveChannel
 	ListChannelsRequest
 	ListChannelsResponse
+	ClosedChannelsRequest
+	Resolution
+	ChannelCloseSummary
+	ClosedChannelsResponse
+	ChannelEventSubscription
+	HtlcEventSubscription
+	HtlcEvent
+	ForwardHtlcInterceptRequest
+	ForwardHtlcInterceptResponse
+	ChannelEventUpdate
 	Peer
 	ListPeersRequest
 	ListPeersResponse
+	PeerEventSubscription
+	PeerEvent
 	GetInfoRequest
 	GetInfoResponse
 	ConfirmationUpdate
 	ChannelOpenUpdate
 	ChannelCloseUpdate
 	CloseChannelRequest
+	AbandonChannelRequest
+	AbandonChannelResponse
+	SpliceChannelRequest
+	SpliceChannelResponse
 	CloseStatusUpdate
 	PendingUpdate
 	OpenChannelRequest
+	BatchOpenChannel
+	BatchOpenChannelRequest
+	BatchOpenChannelResponse
 	OpenStatusUpdate
 	PendingChannelRequest
 	PendingChannelResponse
+	PendingSweepsRequest
+	PendingSweep
+	PendingSweepsResponse
+	BumpFeeRequest
+	BumpFeeResponse
 	WalletBalanceRequest
 	WalletBalanceResponse
 	ChannelBalanceRequest
 	ChannelBalanceResponse
 	QueryRoutesRequest
+	EdgeLocator
 	QueryRoutesResponse
 	Hop
 	Route
@@ -84,10 +112,12 @@ It has these top-level messages:
 	ListInvoiceResponse
 	InvoiceSubscription
 	Payment
+	PaymentAttempt
 	ListPaymentsRequest
 	ListPaymentsResponse
 	DeleteAllPaymentsRequest
 	DeleteAllPaymentsResponse
+	TrackPaymentRequest
 	DebugLevelRequest
 	DebugLevelResponse
 	PayReqString
@@ -97,6 +127,8 @@ It has these top-level messages:
 	FeeReportResponse
 	FeeUpdateRequest
 	FeeUpdateResponse
+	UpdateChanStatusRequest
+	UpdateChanStatusResponse
 */
 package lnrpc
 
@@ -144,7 +176,254 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{11, 0}
+	return fileDescriptor0, []int{14, 0}
+}
+
+type Resolution_ResolutionType int32
+
+const (
+	Resolution_COMMIT        Resolution_ResolutionType = 0
+	Resolution_ANCHOR        Resolution_ResolutionType = 1
+	Resolution_INCOMING_HTLC Resolution_ResolutionType = 2
+	Resolution_OUTGOING_HTLC Resolution_ResolutionType = 3
+	Resolution_REVOKED       Resolution_ResolutionType = 4
+)
+
+var Resolution_ResolutionType_name = map[int32]string{
+	0: "COMMIT",
+	1: "ANCHOR",
+	2: "INCOMING_HTLC",
+	3: "OUTGOING_HTLC",
+	4: "REVOKED",
+}
+var Resolution_ResolutionType_value = map[string]int32{
+	"COMMIT":        0,
+	"ANCHOR":        1,
+	"INCOMING_HTLC": 2,
+	"OUTGOING_HTLC": 3,
+	"REVOKED":       4,
+}
+
+func (x Resolution_ResolutionType) String() string {
+	return proto.EnumName(Resolution_ResolutionType_name, int32(x))
+}
+func (Resolution_ResolutionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{30, 0}
+}
+
+type Resolution_ResolutionOutcome int32
+
+const (
+	Resolution_PENDING Resolution_ResolutionOutcome = 0
+	Resolution_CLAIMED Resolution_ResolutionOutcome = 1
+	Resolution_LOST    Resolution_ResolutionOutcome = 2
+)
+
+var Resolution_ResolutionOutcome_name = map[int32]string{
+	0: "PENDING",
+	1: "CLAIMED",
+	2: "LOST",
+}
+var Resolution_ResolutionOutcome_value = map[string]int32{
+	"PENDING": 0,
+	"CLAIMED": 1,
+	"LOST":    2,
+}
+
+func (x Resolution_ResolutionOutcome) String() string {
+	return proto.EnumName(Resolution_ResolutionOutcome_name, int32(x))
+}
+func (Resolution_ResolutionOutcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{30, 1}
+}
+
+type ChannelCloseSummary_ClosureType int32
+
+const (
+	ChannelCloseSummary_COOPERATIVE_CLOSE  ChannelCloseSummary_ClosureType = 0
+	ChannelCloseSummary_LOCAL_FORCE_CLOSE  ChannelCloseSummary_ClosureType = 1
+	ChannelCloseSummary_REMOTE_FORCE_CLOSE ChannelCloseSummary_ClosureType = 2
+	ChannelCloseSummary_BREACH_CLOSE       ChannelCloseSummary_ClosureType = 3
+	ChannelCloseSummary_FUNDING_CANCELED   ChannelCloseSummary_ClosureType = 4
+	ChannelCloseSummary_ABANDONED          ChannelCloseSummary_ClosureType = 5
+)
+
+var ChannelCloseSummary_ClosureType_name = map[int32]string{
+	0: "COOPERATIVE_CLOSE",
+	1: "LOCAL_FORCE_CLOSE",
+	2: "REMOTE_FORCE_CLOSE",
+	3: "BREACH_CLOSE",
+	4: "FUNDING_CANCELED",
+	5: "ABANDONED",
+}
+var ChannelCloseSummary_ClosureType_value = map[string]int32{
+	"COOPERATIVE_CLOSE":  0,
+	"LOCAL_FORCE_CLOSE":  1,
+	"REMOTE_FORCE_CLOSE": 2,
+	"BREACH_CLOSE":       3,
+	"FUNDING_CANCELED":   4,
+	"ABANDONED":          5,
+}
+
+func (x ChannelCloseSummary_ClosureType) String() string {
+	return proto.EnumName(ChannelCloseSummary_ClosureType_name, int32(x))
+}
+func (ChannelCloseSummary_ClosureType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{31, 0}
+}
+
+type HtlcEvent_EventType int32
+
+const (
+	HtlcEvent_FORWARD      HtlcEvent_EventType = 0
+	HtlcEvent_FORWARD_FAIL HtlcEvent_EventType = 1
+	HtlcEvent_LINK_FAIL    HtlcEvent_EventType = 2
+	HtlcEvent_SETTLE       HtlcEvent_EventType = 3
+	HtlcEvent_FAIL         HtlcEvent_EventType = 4
+	HtlcEvent_SEND         HtlcEvent_EventType = 5
+	HtlcEvent_RECEIVE      HtlcEvent_EventType = 6
+)
+
+var HtlcEvent_EventType_name = map[int32]string{
+	0: "FORWARD",
+	1: "FORWARD_FAIL",
+	2: "LINK_FAIL",
+	3: "SETTLE",
+	4: "FAIL",
+	5: "SEND",
+	6: "RECEIVE",
+}
+var HtlcEvent_EventType_value = map[string]int32{
+	"FORWARD":      0,
+	"FORWARD_FAIL": 1,
+	"LINK_FAIL":    2,
+	"SETTLE":       3,
+	"FAIL":         4,
+	"SEND":         5,
+	"RECEIVE":      6,
+}
+
+func (x HtlcEvent_EventType) String() string {
+	return proto.EnumName(HtlcEvent_EventType_name, int32(x))
+}
+func (HtlcEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{35, 0}
+}
+
+type ForwardHtlcInterceptResponse_ResolveAction int32
+
+const (
+	ForwardHtlcInterceptResponse_RESUME ForwardHtlcInterceptResponse_ResolveAction = 0
+	ForwardHtlcInterceptResponse_FAIL   ForwardHtlcInterceptResponse_ResolveAction = 1
+	ForwardHtlcInterceptResponse_SETTLE ForwardHtlcInterceptResponse_ResolveAction = 2
+)
+
+var ForwardHtlcInterceptResponse_ResolveAction_name = map[int32]string{
+	0: "RESUME",
+	1: "FAIL",
+	2: "SETTLE",
+}
+var ForwardHtlcInterceptResponse_ResolveAction_value = map[string]int32{
+	"RESUME": 0,
+	"FAIL":   1,
+	"SETTLE": 2,
+}
+
+func (x ForwardHtlcInterceptResponse_ResolveAction) String() string {
+	return proto.EnumName(ForwardHtlcInterceptResponse_ResolveAction_name, int32(x))
+}
+func (ForwardHtlcInterceptResponse_ResolveAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37, 0}
+}
+
+type ChannelEventUpdate_UpdateType int32
+
+const (
+	ChannelEventUpdate_PENDING_OPEN_CHANNEL ChannelEventUpdate_UpdateType = 0
+	ChannelEventUpdate_OPEN_CHANNEL         ChannelEventUpdate_UpdateType = 1
+	ChannelEventUpdate_ACTIVE_CHANNEL       ChannelEventUpdate_UpdateType = 2
+	ChannelEventUpdate_INACTIVE_CHANNEL     ChannelEventUpdate_UpdateType = 3
+	ChannelEventUpdate_CLOSING_CHANNEL      ChannelEventUpdate_UpdateType = 4
+	ChannelEventUpdate_CLOSED_CHANNEL       ChannelEventUpdate_UpdateType = 5
+)
+
+var ChannelEventUpdate_UpdateType_name = map[int32]string{
+	0: "PENDING_OPEN_CHANNEL",
+	1: "OPEN_CHANNEL",
+	2: "ACTIVE_CHANNEL",
+	3: "INACTIVE_CHANNEL",
+	4: "CLOSING_CHANNEL",
+	5: "CLOSED_CHANNEL",
+}
+var ChannelEventUpdate_UpdateType_value = map[string]int32{
+	"PENDING_OPEN_CHANNEL": 0,
+	"OPEN_CHANNEL":         1,
+	"ACTIVE_CHANNEL":       2,
+	"INACTIVE_CHANNEL":     3,
+	"CLOSING_CHANNEL":      4,
+	"CLOSED_CHANNEL":       5,
+}
+
+func (x ChannelEventUpdate_UpdateType) String() string {
+	return proto.EnumName(ChannelEventUpdate_UpdateType_name, int32(x))
+}
+func (ChannelEventUpdate_UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{38, 0}
+}
+
+type PeerEvent_EventType int32
+
+const (
+	PeerEvent_PEER_ONLINE  PeerEvent_EventType = 0
+	PeerEvent_PEER_OFFLINE PeerEvent_EventType = 1
+)
+
+var PeerEvent_EventType_name = map[int32]string{
+	0: "PEER_ONLINE",
+	1: "PEER_OFFLINE",
+}
+var PeerEvent_EventType_value = map[string]int32{
+	"PEER_ONLINE":  0,
+	"PEER_OFFLINE": 1,
+}
+
+func (x PeerEvent_EventType) String() string {
+	return proto.EnumName(PeerEvent_EventType_name, int32(x))
+}
+func (PeerEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{43, 0}
+}
+
+type Payment_PaymentStatus int32
+
+const (
+	Payment_UNKNOWN   Payment_PaymentStatus = 0
+	Payment_INITIATED Payment_PaymentStatus = 1
+	Payment_IN_FLIGHT Payment_PaymentStatus = 2
+	Payment_SUCCEEDED Payment_PaymentStatus = 3
+	Payment_FAILED    Payment_PaymentStatus = 4
+)
+
+var Payment_PaymentStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INITIATED",
+	2: "IN_FLIGHT",
+	3: "SUCCEEDED",
+	4: "FAILED",
+}
+var Payment_PaymentStatus_value = map[string]int32{
+	"UNKNOWN":   0,
+	"INITIATED": 1,
+	"IN_FLIGHT": 2,
+	"SUCCEEDED": 3,
+	"FAILED":    4,
+}
+
+func (x Payment_PaymentStatus) String() string {
+	return proto.EnumName(Payment_PaymentStatus_name, int32(x))
+}
+func (Payment_PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{103, 0}
 }
 
 type Transaction struct {
@@ -259,6 +538,20 @@ type SendRequest struct {
 	// details of the invoice, the sender has all the data necessary to send a
 	// payment to the recipient.
 	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
+	// *
+	// The channel id of the channel that must be taken to the first hop. If zero,
+	// any channel may be used.
+	OutgoingChanId uint64 `protobuf:"varint,7,opt,name=outgoing_chan_id,json=outgoingChanId" json:"outgoing_chan_id,omitempty"`
+	// *
+	// The pubkey of the node the payment must traverse right before reaching the
+	// destination. If empty, any node may be used.
+	LastHopPubkey []byte `protobuf:"bytes,8,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
+	// *
+	// If set, a spontaneous payment is sent without an invoice. The preimage of
+	// the payment is generated by the sender, and delivered to the destination
+	// within the onion. The payment hash must be left empty, and the destination
+	// must accept spontaneous payments.
+	KeySend bool `protobuf:"varint,9,opt,name=key_send,json=keySend" json:"key_send,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -308,10 +601,41 @@ func (m *SendRequest) GetPaymentRequest() string {
 	return ""
 }
 
+func (m *SendRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *SendRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
+func (m *SendRequest) GetKeySend() bool {
+	if m != nil {
+		return m.KeySend
+	}
+	return false
+}
+
 type SendResponse struct {
 	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
 	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
 	PaymentRoute    *Route `protobuf:"bytes,3,opt,name=payment_route" json:"payment_route,omitempty"`
+	// *
+	// The failure code of the error returned by the node which failed the
+	// payment. Only set by SendToRoute, and only if the failure was generated by
+	// a remote node.
+	FailureCode uint32 `protobuf:"varint,4,opt,name=failure_code" json:"failure_code,omitempty"`
+	// *
+	// The index of the node which failed the payment within the route, where
+	// zero denotes our own node and one the node of the first hop. Only set by
+	// SendToRoute.
+	FailureSourceIndex uint32 `protobuf:"varint,5,opt,name=failure_source_index" json:"failure_source_index,omitempty"`
 }
 
 func (m *SendResponse) Reset()                    { *m = SendResponse{} }
@@ -340,6 +664,123 @@ func (m *SendResponse) GetPaymentRoute() *Route {
 	return nil
 }
 
+func (m *SendResponse) GetFailureCode() uint32 {
+	if m != nil {
+		return m.FailureCode
+	}
+	return 0
+}
+
+func (m *SendResponse) GetFailureSourceIndex() uint32 {
+	if m != nil {
+		return m.FailureSourceIndex
+	}
+	return 0
+}
+
+type SendToRouteRequest struct {
+	// / The hash to use within the payment's HTLC
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// / The hex-encoded hash to use within the payment's HTLC
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string,json=paymentHashString" json:"payment_hash_string,omitempty"`
+	// / The routes to attempt, in order, until the payment succeeds
+	Routes []*Route `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendToRouteRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type RebalanceRequest struct {
+	// / The channel id of the channel to move funds out of
+	OutgoingChanId uint64 `protobuf:"varint,1,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The channel id of the channel to move funds into
+	IncomingChanId uint64 `protobuf:"varint,2,opt,name=incoming_chan_id" json:"incoming_chan_id,omitempty"`
+	// / The number of satoshis to move
+	Amt int64 `protobuf:"varint,3,opt,name=amt" json:"amt,omitempty"`
+	// / The maximum total fee in satoshis to pay for the rebalance
+	FeeLimit int64 `protobuf:"varint,4,opt,name=fee_limit" json:"fee_limit,omitempty"`
+}
+
+func (m *RebalanceRequest) Reset()                    { *m = RebalanceRequest{} }
+func (m *RebalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*RebalanceRequest) ProtoMessage()               {}
+func (*RebalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *RebalanceRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
+type RebalanceResponse struct {
+	PaymentPreimage []byte `protobuf:"bytes,1,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
+	PaymentRoute    *Route `protobuf:"bytes,2,opt,name=payment_route" json:"payment_route,omitempty"`
+}
+
+func (m *RebalanceResponse) Reset()                    { *m = RebalanceResponse{} }
+func (m *RebalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*RebalanceResponse) ProtoMessage()               {}
+func (*RebalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *RebalanceResponse) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
+}
+
+func (m *RebalanceResponse) GetPaymentRoute() *Route {
+	if m != nil {
+		return m.PaymentRoute
+	}
+	return nil
+}
+
 type ChannelPoint struct {
 	// / Txid of the funding transaction
 	FundingTxid []byte `protobuf:"bytes,1,opt,name=funding_txid,proto3" json:"funding_txid,omitempty"`
@@ -352,7 +793,7 @@ type ChannelPoint struct {
 func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
 func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
 func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *ChannelPoint) GetFundingTxid() []byte {
 	if m != nil {
@@ -385,7 +826,7 @@ type LightningAddress struct {
 func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
 func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
 func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *LightningAddress) GetPubkey() string {
 	if m != nil {
@@ -409,7 +850,7 @@ type SendManyRequest struct {
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
 func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	if m != nil {
@@ -426,7 +867,7 @@ type SendManyResponse struct {
 func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
 func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *SendManyResponse) GetTxid() string {
 	if m != nil {
@@ -445,7 +886,7 @@ type SendCoinsRequest struct {
 func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
 func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *SendCoinsRequest) GetAddr() string {
 	if m != nil {
@@ -469,7 +910,7 @@ type SendCoinsResponse struct {
 func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
 func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *SendCoinsResponse) GetTxid() string {
 	if m != nil {
@@ -492,7 +933,7 @@ type NewAddressRequest struct {
 func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
 	if m != nil {
@@ -507,7 +948,7 @@ type NewWitnessAddressRequest struct {
 func (m *NewWitnessAddressRequest) Reset()                    { *m = NewWitnessAddressRequest{} }
 func (m *NewWitnessAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewWitnessAddressRequest) ProtoMessage()               {}
-func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 type NewAddressResponse struct {
 	// / The newly generated wallet address
@@ -517,7 +958,7 @@ type NewAddressResponse struct {
 func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
@@ -534,7 +975,7 @@ type SignMessageRequest struct {
 func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
 func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageRequest) ProtoMessage()               {}
-func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *SignMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -551,7 +992,7 @@ type SignMessageResponse struct {
 func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
 func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageResponse) ProtoMessage()               {}
-func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *SignMessageResponse) GetSignature() string {
 	if m != nil {
@@ -570,7 +1011,7 @@ type VerifyMessageRequest struct {
 func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
 func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageRequest) ProtoMessage()               {}
-func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *VerifyMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -596,7 +1037,7 @@ type VerifyMessageResponse struct {
 func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
 func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageResponse) ProtoMessage()               {}
-func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *VerifyMessageResponse) GetValid() bool {
 	if m != nil {
@@ -623,7 +1064,7 @@ type ConnectPeerRequest struct {
 func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
 func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
 	if m != nil {
@@ -647,7 +1088,7 @@ type ConnectPeerResponse struct {
 func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
 func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ConnectPeerResponse) GetPeerId() int32 {
 	if m != nil {
@@ -664,7 +1105,7 @@ type DisconnectPeerRequest struct {
 func (m *DisconnectPeerRequest) Reset()                    { *m = DisconnectPeerRequest{} }
 func (m *DisconnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerRequest) ProtoMessage()               {}
-func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *DisconnectPeerRequest) GetPubKey() string {
 	if m != nil {
@@ -679,7 +1120,7 @@ type DisconnectPeerResponse struct {
 func (m *DisconnectPeerResponse) Reset()                    { *m = DisconnectPeerResponse{} }
 func (m *DisconnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerResponse) ProtoMessage()               {}
-func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 type HTLC struct {
 	Incoming         bool   `protobuf:"varint,1,opt,name=incoming" json:"incoming,omitempty"`
@@ -691,7 +1132,7 @@ type HTLC struct {
 func (m *HTLC) Reset()                    { *m = HTLC{} }
 func (m *HTLC) String() string            { return proto.CompactTextString(m) }
 func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *HTLC) GetIncoming() bool {
 	if m != nil {
@@ -774,7 +1215,7 @@ type ActiveChannel struct {
 func (m *ActiveChannel) Reset()                    { *m = ActiveChannel{} }
 func (m *ActiveChannel) String() string            { return proto.CompactTextString(m) }
 func (*ActiveChannel) ProtoMessage()               {}
-func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *ActiveChannel) GetActive() bool {
 	if m != nil {
@@ -887,7 +1328,7 @@ type ListChannelsRequest struct {
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
 func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsRequest) ProtoMessage()               {}
-func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 type ListChannelsResponse struct {
 	// / The list of active channels
@@ -897,7 +1338,7 @@ type ListChannelsResponse struct {
 func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
 func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsResponse) ProtoMessage()               {}
-func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *ListChannelsResponse) GetChannels() []*ActiveChannel {
 	if m != nil {
@@ -906,118 +1347,736 @@ func (m *ListChannelsResponse) GetChannels() []*ActiveChannel {
 	return nil
 }
 
-type Peer struct {
-	// / The identity pubkey of the peer
-	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
-	// / The peer's id from the local point of view
-	PeerId int32 `protobuf:"varint,2,opt,name=peer_id" json:"peer_id,omitempty"`
-	// / Network address of the peer; eg `127.0.0.1:10011`
-	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
-	// / Bytes of data transmitted to this peer
-	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
-	// / Bytes of data transmitted from this peer
-	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
-	// / Satoshis sent to this peer
-	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
-	// / Satoshis received from this peer
-	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
-	// / A channel is inbound if the counterparty initiated the channel
-	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
-	// / Ping time to this peer
-	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+type ClosedChannelsRequest struct {
+	// / Include channels closed cooperatively. If no filters are set, then all closed channels are returned.
+	Cooperative bool `protobuf:"varint,1,opt,name=cooperative" json:"cooperative,omitempty"`
+	// / Include channels we force closed
+	LocalForce bool `protobuf:"varint,2,opt,name=local_force" json:"local_force,omitempty"`
+	// / Include channels the remote party force closed
+	RemoteForce bool `protobuf:"varint,3,opt,name=remote_force" json:"remote_force,omitempty"`
+	// / Include channels closed due to a breach by the remote party
+	Breach bool `protobuf:"varint,4,opt,name=breach" json:"breach,omitempty"`
+	// / Include channels whose funding flow was canceled
+	FundingCanceled bool `protobuf:"varint,5,opt,name=funding_canceled" json:"funding_canceled,omitempty"`
+	// / Include channels that were manually abandoned
+	Abandoned bool `protobuf:"varint,6,opt,name=abandoned" json:"abandoned,omitempty"`
 }
 
-func (m *Peer) Reset()                    { *m = Peer{} }
-func (m *Peer) String() string            { return proto.CompactTextString(m) }
-func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (m *ClosedChannelsRequest) Reset()                    { *m = ClosedChannelsRequest{} }
+func (m *ClosedChannelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClosedChannelsRequest) ProtoMessage()               {}
+func (*ClosedChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-func (m *Peer) GetPubKey() string {
+func (m *ClosedChannelsRequest) GetCooperative() bool {
 	if m != nil {
-		return m.PubKey
+		return m.Cooperative
 	}
-	return ""
+	return false
 }
 
-func (m *Peer) GetPeerId() int32 {
+func (m *ClosedChannelsRequest) GetLocalForce() bool {
 	if m != nil {
-		return m.PeerId
+		return m.LocalForce
 	}
-	return 0
+	return false
 }
 
-func (m *Peer) GetAddress() string {
+func (m *ClosedChannelsRequest) GetRemoteForce() bool {
 	if m != nil {
-		return m.Address
+		return m.RemoteForce
 	}
-	return ""
+	return false
 }
 
-func (m *Peer) GetBytesSent() uint64 {
+func (m *ClosedChannelsRequest) GetBreach() bool {
 	if m != nil {
-		return m.BytesSent
+		return m.Breach
 	}
-	return 0
+	return false
 }
 
-func (m *Peer) GetBytesRecv() uint64 {
+func (m *ClosedChannelsRequest) GetFundingCanceled() bool {
 	if m != nil {
-		return m.BytesRecv
+		return m.FundingCanceled
 	}
-	return 0
+	return false
 }
 
-func (m *Peer) GetSatSent() int64 {
+func (m *ClosedChannelsRequest) GetAbandoned() bool {
 	if m != nil {
-		return m.SatSent
+		return m.Abandoned
 	}
-	return 0
+	return false
 }
 
-func (m *Peer) GetSatRecv() int64 {
+type Resolution struct {
+	// / The outpoint of the output being resolved
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The kind of channel output being resolved
+	ResolutionType Resolution_ResolutionType `protobuf:"varint,2,opt,name=resolution_type,enum=lnrpc.Resolution_ResolutionType" json:"resolution_type,omitempty"`
+	// / The current state of the output's resolution
+	Outcome Resolution_ResolutionOutcome `protobuf:"varint,3,opt,name=outcome,enum=lnrpc.Resolution_ResolutionOutcome" json:"outcome,omitempty"`
+	// / The value of the output in satoshis
+	AmountSat int64 `protobuf:"varint,4,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	// / The txid of the transaction that spent the output, if any
+	SweepTxid string `protobuf:"bytes,5,opt,name=sweep_txid" json:"sweep_txid,omitempty"`
+}
+
+func (m *Resolution) Reset()                    { *m = Resolution{} }
+func (m *Resolution) String() string            { return proto.CompactTextString(m) }
+func (*Resolution) ProtoMessage()               {}
+func (*Resolution) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Resolution) GetOutpoint() string {
 	if m != nil {
-		return m.SatRecv
+		return m.Outpoint
 	}
-	return 0
+	return ""
 }
 
-func (m *Peer) GetInbound() bool {
+func (m *Resolution) GetResolutionType() Resolution_ResolutionType {
 	if m != nil {
-		return m.Inbound
+		return m.ResolutionType
 	}
-	return false
+	return Resolution_COMMIT
 }
 
-func (m *Peer) GetPingTime() int64 {
+func (m *Resolution) GetOutcome() Resolution_ResolutionOutcome {
 	if m != nil {
-		return m.PingTime
+		return m.Outcome
 	}
-	return 0
+	return Resolution_PENDING
 }
 
-type ListPeersRequest struct {
+func (m *Resolution) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
+	}
+	return 0
 }
 
-func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
-func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
-
-type ListPeersResponse struct {
-	// / The list of currently connected peers
-	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+func (m *Resolution) GetSweepTxid() string {
+	if m != nil {
+		return m.SweepTxid
+	}
+	return ""
 }
 
-func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
-func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
-
-func (m *ListPeersResponse) GetPeers() []*Peer {
+type ChannelCloseSummary struct {
+	// / The outpoint (txid:index) of the funding transaction
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The txid of the transaction which closed the channel
+	ClosingTxHash string `protobuf:"bytes,2,opt,name=closing_tx_hash" json:"closing_tx_hash,omitempty"`
+	// / The identity pubkey of the remote node
+	RemotePubkey string `protobuf:"bytes,3,opt,name=remote_pubkey" json:"remote_pubkey,omitempty"`
+	// / The total amount of funds held in the channel
+	Capacity int64 `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
+	// / The height at which the closing transaction confirmed, zero if it hasn't yet confirmed
+	CloseHeight uint32 `protobuf:"varint,5,opt,name=close_height" json:"close_height,omitempty"`
+	// / Our settled balance at the time of closure
+	SettledBalance int64 `protobuf:"varint,6,opt,name=settled_balance" json:"settled_balance,omitempty"`
+	// / The sum of our time-locked outputs at the time of closure
+	TimeLockedBalance int64 `protobuf:"varint,7,opt,name=time_locked_balance" json:"time_locked_balance,omitempty"`
+	// / How the channel was closed
+	CloseType ChannelCloseSummary_ClosureType `protobuf:"varint,8,opt,name=close_type,enum=lnrpc.ChannelCloseSummary_ClosureType" json:"close_type,omitempty"`
+	// / The on-chain resolution of each of our outputs of the channel
+	Resolutions []*Resolution `protobuf:"bytes,9,rep,name=resolutions" json:"resolutions,omitempty"`
+}
+
+func (m *ChannelCloseSummary) Reset()                    { *m = ChannelCloseSummary{} }
+func (m *ChannelCloseSummary) String() string            { return proto.CompactTextString(m) }
+func (*ChannelCloseSummary) ProtoMessage()               {}
+func (*ChannelCloseSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ChannelCloseSummary) GetChannelPoint() string {
 	if m != nil {
-		return m.Peers
+		return m.ChannelPoint
 	}
-	return nil
+	return ""
+}
+
+func (m *ChannelCloseSummary) GetClosingTxHash() string {
+	if m != nil {
+		return m.ClosingTxHash
+	}
+	return ""
+}
+
+func (m *ChannelCloseSummary) GetRemotePubkey() string {
+	if m != nil {
+		return m.RemotePubkey
+	}
+	return ""
+}
+
+func (m *ChannelCloseSummary) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetCloseHeight() uint32 {
+	if m != nil {
+		return m.CloseHeight
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetSettledBalance() int64 {
+	if m != nil {
+		return m.SettledBalance
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetTimeLockedBalance() int64 {
+	if m != nil {
+		return m.TimeLockedBalance
+	}
+	return 0
+}
+
+func (m *ChannelCloseSummary) GetCloseType() ChannelCloseSummary_ClosureType {
+	if m != nil {
+		return m.CloseType
+	}
+	return ChannelCloseSummary_COOPERATIVE_CLOSE
+}
+
+func (m *ChannelCloseSummary) GetResolutions() []*Resolution {
+	if m != nil {
+		return m.Resolutions
+	}
+	return nil
+}
+
+type ClosedChannelsResponse struct {
+	// / The list of closed channels
+	Channels []*ChannelCloseSummary `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *ClosedChannelsResponse) Reset()                    { *m = ClosedChannelsResponse{} }
+func (m *ClosedChannelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ClosedChannelsResponse) ProtoMessage()               {}
+func (*ClosedChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ClosedChannelsResponse) GetChannels() []*ChannelCloseSummary {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ChannelEventSubscription struct {
+}
+
+func (m *ChannelEventSubscription) Reset()                    { *m = ChannelEventSubscription{} }
+func (m *ChannelEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventSubscription) ProtoMessage()               {}
+func (*ChannelEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type HtlcEventSubscription struct {
+}
+
+func (m *HtlcEventSubscription) Reset()                    { *m = HtlcEventSubscription{} }
+func (m *HtlcEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*HtlcEventSubscription) ProtoMessage()               {}
+func (*HtlcEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type HtlcEvent struct {
+	// / What happened to the HTLC
+	EventType HtlcEvent_EventType `protobuf:"varint,1,opt,name=event_type,enum=lnrpc.HtlcEvent_EventType" json:"event_type,omitempty"`
+	// / The short channel id of the channel the HTLC was received on
+	IncomingChanId uint64 `protobuf:"varint,2,opt,name=incoming_chan_id" json:"incoming_chan_id,omitempty"`
+	// / The short channel id of the channel the HTLC was sent over
+	OutgoingChanId uint64 `protobuf:"varint,3,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The index of the HTLC within the incoming channel
+	IncomingHtlcId uint64 `protobuf:"varint,4,opt,name=incoming_htlc_id" json:"incoming_htlc_id,omitempty"`
+	// / The index of the HTLC within the outgoing channel
+	OutgoingHtlcId uint64 `protobuf:"varint,5,opt,name=outgoing_htlc_id" json:"outgoing_htlc_id,omitempty"`
+	// / The value of the HTLC within the incoming channel in millisatoshis
+	IncomingAmtMsat int64 `protobuf:"varint,6,opt,name=incoming_amt_msat" json:"incoming_amt_msat,omitempty"`
+	// / The value of the HTLC within the outgoing channel in millisatoshis
+	OutgoingAmtMsat int64 `protobuf:"varint,7,opt,name=outgoing_amt_msat" json:"outgoing_amt_msat,omitempty"`
+	// / The payment hash of the HTLC
+	PaymentHash []byte `protobuf:"bytes,8,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The onion failure code of a failed HTLC, if known
+	FailureCode string `protobuf:"bytes,9,opt,name=failure_code" json:"failure_code,omitempty"`
+	// / Whether the HTLC was failed by a downstream node
+	RemoteFailure bool `protobuf:"varint,10,opt,name=remote_failure" json:"remote_failure,omitempty"`
+	// / A human readable description of why the HTLC failed
+	FailureDetail string `protobuf:"bytes,11,opt,name=failure_detail" json:"failure_detail,omitempty"`
+	// / The unix timestamp in nanoseconds at which the event occurred
+	TimestampNs int64 `protobuf:"varint,12,opt,name=timestamp_ns" json:"timestamp_ns,omitempty"`
+}
+
+func (m *HtlcEvent) Reset()                    { *m = HtlcEvent{} }
+func (m *HtlcEvent) String() string            { return proto.CompactTextString(m) }
+func (*HtlcEvent) ProtoMessage()               {}
+func (*HtlcEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *HtlcEvent) GetEventType() HtlcEvent_EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return HtlcEvent_FORWARD
+}
+
+func (m *HtlcEvent) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetIncomingHtlcId() uint64 {
+	if m != nil {
+		return m.IncomingHtlcId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingHtlcId() uint64 {
+	if m != nil {
+		return m.OutgoingHtlcId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetIncomingAmtMsat() int64 {
+	if m != nil {
+		return m.IncomingAmtMsat
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingAmtMsat() int64 {
+	if m != nil {
+		return m.OutgoingAmtMsat
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetFailureCode() string {
+	if m != nil {
+		return m.FailureCode
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetRemoteFailure() bool {
+	if m != nil {
+		return m.RemoteFailure
+	}
+	return false
+}
+
+func (m *HtlcEvent) GetFailureDetail() string {
+	if m != nil {
+		return m.FailureDetail
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetTimestampNs() int64 {
+	if m != nil {
+		return m.TimestampNs
+	}
+	return 0
+}
+
+type ForwardHtlcInterceptRequest struct {
+	// / The short channel id of the channel the HTLC was received on
+	IncomingChanId uint64 `protobuf:"varint,1,opt,name=incoming_chan_id" json:"incoming_chan_id,omitempty"`
+	// / The index of the HTLC within the incoming channel
+	IncomingHtlcId uint64 `protobuf:"varint,2,opt,name=incoming_htlc_id" json:"incoming_htlc_id,omitempty"`
+	// / The short channel id of the channel the HTLC is to be forwarded over
+	OutgoingChanId uint64 `protobuf:"varint,3,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The payment hash of the HTLC
+	PaymentHash []byte `protobuf:"bytes,4,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The value of the HTLC within the incoming channel in millisatoshis
+	IncomingAmtMsat int64 `protobuf:"varint,5,opt,name=incoming_amt_msat" json:"incoming_amt_msat,omitempty"`
+	// / The value the HTLC is to carry within the outgoing channel in millisatoshis
+	OutgoingAmtMsat int64 `protobuf:"varint,6,opt,name=outgoing_amt_msat" json:"outgoing_amt_msat,omitempty"`
+	// / The absolute expiry height the HTLC is to carry within the outgoing channel
+	OutgoingExpiry uint32 `protobuf:"varint,7,opt,name=outgoing_expiry" json:"outgoing_expiry,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptRequest) Reset()                    { *m = ForwardHtlcInterceptRequest{} }
+func (m *ForwardHtlcInterceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptRequest) ProtoMessage()               {}
+func (*ForwardHtlcInterceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingHtlcId() uint64 {
+	if m != nil {
+		return m.IncomingHtlcId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingAmtMsat() int64 {
+	if m != nil {
+		return m.IncomingAmtMsat
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingAmtMsat() int64 {
+	if m != nil {
+		return m.OutgoingAmtMsat
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingExpiry() uint32 {
+	if m != nil {
+		return m.OutgoingExpiry
+	}
+	return 0
+}
+
+type ForwardHtlcInterceptResponse struct {
+	// / The short channel id of the channel the intercepted HTLC was received on
+	IncomingChanId uint64 `protobuf:"varint,1,opt,name=incoming_chan_id" json:"incoming_chan_id,omitempty"`
+	// / The index of the intercepted HTLC within the incoming channel
+	IncomingHtlcId uint64 `protobuf:"varint,2,opt,name=incoming_htlc_id" json:"incoming_htlc_id,omitempty"`
+	// / How the intercepted HTLC should be resolved
+	Action ForwardHtlcInterceptResponse_ResolveAction `protobuf:"varint,3,opt,name=action,enum=lnrpc.ForwardHtlcInterceptResponse_ResolveAction" json:"action,omitempty"`
+	// / The preimage to settle the HTLC with, required by the SETTLE action
+	Preimage []byte `protobuf:"bytes,4,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	// / The BOLT #4 failure code to fail the HTLC with, used by the FAIL action. Defaults to temporary_channel_failure
+	FailureCode uint32 `protobuf:"varint,5,opt,name=failure_code" json:"failure_code,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptResponse) Reset()                    { *m = ForwardHtlcInterceptResponse{} }
+func (m *ForwardHtlcInterceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptResponse) ProtoMessage()               {}
+func (*ForwardHtlcInterceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ForwardHtlcInterceptResponse) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptResponse) GetIncomingHtlcId() uint64 {
+	if m != nil {
+		return m.IncomingHtlcId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptResponse) GetAction() ForwardHtlcInterceptResponse_ResolveAction {
+	if m != nil {
+		return m.Action
+	}
+	return ForwardHtlcInterceptResponse_RESUME
+}
+
+func (m *ForwardHtlcInterceptResponse) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptResponse) GetFailureCode() uint32 {
+	if m != nil {
+		return m.FailureCode
+	}
+	return 0
+}
+
+type ChannelEventUpdate struct {
+	// / The kind of state change the channel went through
+	Type ChannelEventUpdate_UpdateType `protobuf:"varint,1,opt,name=type,enum=lnrpc.ChannelEventUpdate_UpdateType" json:"type,omitempty"`
+	// / The outpoint (txid:index) of the funding transaction
+	ChannelPoint string `protobuf:"bytes,2,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The current state of the channel, if it hasn't yet been closed
+	Channel *ActiveChannel `protobuf:"bytes,3,opt,name=channel" json:"channel,omitempty"`
+	// / A summary of the channel's closure, if it has been closed
+	ClosedChannel *ChannelCloseSummary `protobuf:"bytes,4,opt,name=closed_channel" json:"closed_channel,omitempty"`
+}
+
+func (m *ChannelEventUpdate) Reset()                    { *m = ChannelEventUpdate{} }
+func (m *ChannelEventUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventUpdate) ProtoMessage()               {}
+func (*ChannelEventUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ChannelEventUpdate) GetType() ChannelEventUpdate_UpdateType {
+	if m != nil {
+		return m.Type
+	}
+	return ChannelEventUpdate_PENDING_OPEN_CHANNEL
+}
+
+func (m *ChannelEventUpdate) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelEventUpdate) GetChannel() *ActiveChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *ChannelEventUpdate) GetClosedChannel() *ChannelCloseSummary {
+	if m != nil {
+		return m.ClosedChannel
+	}
+	return nil
+}
+
+type Peer struct {
+	// / The identity pubkey of the peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / The peer's id from the local point of view
+	PeerId int32 `protobuf:"varint,2,opt,name=peer_id" json:"peer_id,omitempty"`
+	// / Network address of the peer; eg `127.0.0.1:10011`
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	// / Bytes of data transmitted to this peer
+	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent" json:"bytes_sent,omitempty"`
+	// / Bytes of data transmitted from this peer
+	BytesRecv uint64 `protobuf:"varint,5,opt,name=bytes_recv" json:"bytes_recv,omitempty"`
+	// / Satoshis sent to this peer
+	SatSent int64 `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
+	// / Satoshis received from this peer
+	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
+	// / A channel is inbound if the counterparty initiated the channel
+	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
+	// / Ping time to this peer
+	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+	// / The unix timestamp of our first connection to this peer
+	FirstSeen int64 `protobuf:"varint,10,opt,name=first_seen" json:"first_seen,omitempty"`
+	// / The number of times we've established a connection with this peer
+	ConnectionCount uint32 `protobuf:"varint,11,opt,name=connection_count" json:"connection_count,omitempty"`
+	// / The cumulative time in seconds we've been connected to this peer
+	Uptime int64 `protobuf:"varint,12,opt,name=uptime" json:"uptime,omitempty"`
+	// / The reason our last connection to this peer was terminated
+	LastDisconnectReason string `protobuf:"bytes,13,opt,name=last_disconnect_reason" json:"last_disconnect_reason,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *Peer) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *Peer) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+func (m *Peer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Peer) GetBytesSent() uint64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *Peer) GetBytesRecv() uint64 {
+	if m != nil {
+		return m.BytesRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetSatSent() int64 {
+	if m != nil {
+		return m.SatSent
+	}
+	return 0
+}
+
+func (m *Peer) GetSatRecv() int64 {
+	if m != nil {
+		return m.SatRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *Peer) GetPingTime() int64 {
+	if m != nil {
+		return m.PingTime
+	}
+	return 0
+}
+
+func (m *Peer) GetFirstSeen() int64 {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return 0
+}
+
+func (m *Peer) GetConnectionCount() uint32 {
+	if m != nil {
+		return m.ConnectionCount
+	}
+	return 0
+}
+
+func (m *Peer) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *Peer) GetLastDisconnectReason() string {
+	if m != nil {
+		return m.LastDisconnectReason
+	}
+	return ""
+}
+
+type ListPeersRequest struct {
+}
+
+func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()               {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+type ListPeersResponse struct {
+	// / The list of currently connected peers
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()               {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type PeerEventSubscription struct {
+}
+
+func (m *PeerEventSubscription) Reset()                    { *m = PeerEventSubscription{} }
+func (m *PeerEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*PeerEventSubscription) ProtoMessage()               {}
+func (*PeerEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+type PeerEvent struct {
+	// / The identity pubkey of the peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / Whether the peer came online or went offline
+	Type PeerEvent_EventType `protobuf:"varint,2,opt,name=type,enum=lnrpc.PeerEvent_EventType" json:"type,omitempty"`
+	// / Network address of the peer; eg `127.0.0.1:10011`
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	// / Whether the connection was initiated by the peer
+	Inbound bool `protobuf:"varint,4,opt,name=inbound" json:"inbound,omitempty"`
+	// / The reason the connection was terminated, set for offline events
+	Reason string `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *PeerEvent) Reset()                    { *m = PeerEvent{} }
+func (m *PeerEvent) String() string            { return proto.CompactTextString(m) }
+func (*PeerEvent) ProtoMessage()               {}
+func (*PeerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *PeerEvent) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetType() PeerEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return PeerEvent_PEER_ONLINE
+}
+
+func (m *PeerEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *PeerEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
 type GetInfoRequest struct {
@@ -1026,7 +2085,7 @@ type GetInfoRequest struct {
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 type GetInfoResponse struct {
 	// / The identity pubkey of the current node.
@@ -1054,7 +2113,7 @@ type GetInfoResponse struct {
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -1135,7 +2194,7 @@ type ConfirmationUpdate struct {
 func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
 func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *ConfirmationUpdate) GetBlockSha() []byte {
 	if m != nil {
@@ -1165,7 +2224,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1182,7 +2241,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -1206,12 +2265,20 @@ type CloseChannelRequest struct {
 	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
 	// / If true, then the channel will be closed forcibly. This means the current commitment transaction will be signed and broadcast.
 	Force bool `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+	// / An optional address to pay our funds to upon a cooperative close. If an upfront shutdown script was committed to when the channel was opened, then this must match it
+	DeliveryAddress string `protobuf:"bytes,3,opt,name=delivery_address" json:"delivery_address,omitempty"`
+	// / The target number of blocks that the cooperative closing transaction should be confirmed within
+	TargetConf int32 `protobuf:"varint,4,opt,name=target_conf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the cooperative closing transaction
+	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	// / The maximum fee in satoshis we're willing to pay for the cooperative closing transaction. If zero, then no limit is enforced
+	MaxFeeSat int64 `protobuf:"varint,6,opt,name=max_fee_sat" json:"max_fee_sat,omitempty"`
 }
 
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1227,6 +2294,147 @@ func (m *CloseChannelRequest) GetForce() bool {
 	return false
 }
 
+func (m *CloseChannelRequest) GetDeliveryAddress() string {
+	if m != nil {
+		return m.DeliveryAddress
+	}
+	return ""
+}
+
+func (m *CloseChannelRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *CloseChannelRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *CloseChannelRequest) GetMaxFeeSat() int64 {
+	if m != nil {
+		return m.MaxFeeSat
+	}
+	return 0
+}
+
+type AbandonChannelRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the channel to abandon.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / If true, then the channel will be abandoned even if it's active, or its commitment transaction has been broadcast.
+	Force bool `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *AbandonChannelRequest) Reset()                    { *m = AbandonChannelRequest{} }
+func (m *AbandonChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelRequest) ProtoMessage()               {}
+func (*AbandonChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *AbandonChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *AbandonChannelRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type AbandonChannelResponse struct {
+	// / The number of wallet inputs released by abandoning the channel.
+	NumReleasedInputs uint32 `protobuf:"varint,1,opt,name=num_released_inputs" json:"num_released_inputs,omitempty"`
+}
+
+func (m *AbandonChannelResponse) Reset()                    { *m = AbandonChannelResponse{} }
+func (m *AbandonChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelResponse) ProtoMessage()               {}
+func (*AbandonChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *AbandonChannelResponse) GetNumReleasedInputs() uint32 {
+	if m != nil {
+		return m.NumReleasedInputs
+	}
+	return 0
+}
+
+type SpliceChannelRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the channel to splice.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The amount in satoshis to splice into or out of the channel.
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// / If true, then the amount is spliced out of the channel rather than into it.
+	SpliceOut bool `protobuf:"varint,3,opt,name=splice_out" json:"splice_out,omitempty"`
+	// / The address to send spliced out funds to. If unset, a fresh wallet address is used.
+	Address string `protobuf:"bytes,4,opt,name=address" json:"address,omitempty"`
+	// / A manual fee rate in satoshis per byte to use for the splice transaction.
+	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *SpliceChannelRequest) Reset()                    { *m = SpliceChannelRequest{} }
+func (m *SpliceChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*SpliceChannelRequest) ProtoMessage()               {}
+func (*SpliceChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *SpliceChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *SpliceChannelRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SpliceChannelRequest) GetSpliceOut() bool {
+	if m != nil {
+		return m.SpliceOut
+	}
+	return false
+}
+
+func (m *SpliceChannelRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SpliceChannelRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type SpliceChannelResponse struct {
+	// / The new channel point of the channel once the splice transaction confirms.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+}
+
+func (m *SpliceChannelResponse) Reset()                    { *m = SpliceChannelResponse{} }
+func (m *SpliceChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*SpliceChannelResponse) ProtoMessage()               {}
+func (*SpliceChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *SpliceChannelResponse) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
 type CloseStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*CloseStatusUpdate_ClosePending
@@ -1238,7 +2446,7 @@ type CloseStatusUpdate struct {
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 type isCloseStatusUpdate_Update interface {
 	isCloseStatusUpdate_Update()
@@ -1387,7 +2595,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -1414,12 +2622,14 @@ type OpenChannelRequest struct {
 	LocalFundingAmount int64 `protobuf:"varint,4,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
 	// / The number of satoshis to push to the remote side as part of the initial commitment state
 	PushSat int64 `protobuf:"varint,5,opt,name=push_sat" json:"push_sat,omitempty"`
+	// / An optional address to commit to as an upfront shutdown script. If set, the funds of any future cooperative close must be paid to this address
+	CloseAddress string `protobuf:"bytes,6,opt,name=close_address" json:"close_address,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *OpenChannelRequest) GetTargetPeerId() int32 {
 	if m != nil {
@@ -1442,18 +2652,94 @@ func (m *OpenChannelRequest) GetNodePubkeyString() string {
 	return ""
 }
 
-func (m *OpenChannelRequest) GetLocalFundingAmount() int64 {
+func (m *OpenChannelRequest) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetPushSat() int64 {
+	if m != nil {
+		return m.PushSat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
+type BatchOpenChannel struct {
+	// / The pubkey of the node to open a channel with
+	NodePubkey []byte `protobuf:"bytes,1,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	// / The number of satoshis the wallet should commit to the channel
+	LocalFundingAmount int64 `protobuf:"varint,2,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
+	// / The number of satoshis to push to the remote side as part of the initial commitment state
+	PushSat int64 `protobuf:"varint,3,opt,name=push_sat" json:"push_sat,omitempty"`
+}
+
+func (m *BatchOpenChannel) Reset()                    { *m = BatchOpenChannel{} }
+func (m *BatchOpenChannel) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannel) ProtoMessage()               {}
+func (*BatchOpenChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *BatchOpenChannel) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
+	}
+	return nil
+}
+
+func (m *BatchOpenChannel) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
+	}
+	return 0
+}
+
+func (m *BatchOpenChannel) GetPushSat() int64 {
+	if m != nil {
+		return m.PushSat
+	}
+	return 0
+}
+
+type BatchOpenChannelRequest struct {
+	// / The list of channels to open within a single funding transaction
+	Channels []*BatchOpenChannel `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *BatchOpenChannelRequest) Reset()                    { *m = BatchOpenChannelRequest{} }
+func (m *BatchOpenChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelRequest) ProtoMessage()               {}
+func (*BatchOpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *BatchOpenChannelRequest) GetChannels() []*BatchOpenChannel {
 	if m != nil {
-		return m.LocalFundingAmount
+		return m.Channels
 	}
-	return 0
+	return nil
 }
 
-func (m *OpenChannelRequest) GetPushSat() int64 {
+type BatchOpenChannelResponse struct {
+	// / The pending update of each channel within the batch
+	PendingChannels []*PendingUpdate `protobuf:"bytes,1,rep,name=pending_channels" json:"pending_channels,omitempty"`
+}
+
+func (m *BatchOpenChannelResponse) Reset()                    { *m = BatchOpenChannelResponse{} }
+func (m *BatchOpenChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchOpenChannelResponse) ProtoMessage()               {}
+func (*BatchOpenChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *BatchOpenChannelResponse) GetPendingChannels() []*PendingUpdate {
 	if m != nil {
-		return m.PushSat
+		return m.PendingChannels
 	}
-	return 0
+	return nil
 }
 
 type OpenStatusUpdate struct {
@@ -1467,7 +2753,7 @@ type OpenStatusUpdate struct {
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 type isOpenStatusUpdate_Update interface {
 	isOpenStatusUpdate_Update()
@@ -1614,7 +2900,7 @@ type PendingChannelRequest struct {
 func (m *PendingChannelRequest) Reset()                    { *m = PendingChannelRequest{} }
 func (m *PendingChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelRequest) ProtoMessage()               {}
-func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
 type PendingChannelResponse struct {
 	// / The balance in satoshis encumbered in pending channels
@@ -1630,7 +2916,7 @@ type PendingChannelResponse struct {
 func (m *PendingChannelResponse) Reset()                    { *m = PendingChannelResponse{} }
 func (m *PendingChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelResponse) ProtoMessage()               {}
-func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
 
 func (m *PendingChannelResponse) GetTotalLimboBalance() int64 {
 	if m != nil {
@@ -1672,7 +2958,7 @@ func (m *PendingChannelResponse_PendingChannel) Reset()         { *m = PendingCh
 func (m *PendingChannelResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{40, 0}
+	return fileDescriptor0, []int{62, 0}
 }
 
 func (m *PendingChannelResponse_PendingChannel) GetRemoteNodePub() string {
@@ -1739,7 +3025,7 @@ func (m *PendingChannelResponse_PendingOpenChannel) Reset() {
 func (m *PendingChannelResponse_PendingOpenChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_PendingOpenChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_PendingOpenChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{40, 1}
+	return fileDescriptor0, []int{62, 1}
 }
 
 func (m *PendingChannelResponse_PendingOpenChannel) GetChannel() *PendingChannelResponse_PendingChannel {
@@ -1795,7 +3081,7 @@ func (m *PendingChannelResponse_ClosedChannel) Reset()         { *m = PendingCha
 func (m *PendingChannelResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_ClosedChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_ClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{40, 2}
+	return fileDescriptor0, []int{62, 2}
 }
 
 func (m *PendingChannelResponse_ClosedChannel) GetChannel() *PendingChannelResponse_PendingChannel {
@@ -1831,7 +3117,7 @@ func (m *PendingChannelResponse_ForceClosedChannel) Reset() {
 func (m *PendingChannelResponse_ForceClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_ForceClosedChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_ForceClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{40, 3}
+	return fileDescriptor0, []int{62, 3}
 }
 
 func (m *PendingChannelResponse_ForceClosedChannel) GetChannel() *PendingChannelResponse_PendingChannel {
@@ -1869,6 +3155,181 @@ func (m *PendingChannelResponse_ForceClosedChannel) GetBlocksTilMaturity() uint3
 	return 0
 }
 
+type PendingSweepsRequest struct {
+}
+
+func (m *PendingSweepsRequest) Reset()                    { *m = PendingSweepsRequest{} }
+func (m *PendingSweepsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PendingSweepsRequest) ProtoMessage()               {}
+func (*PendingSweepsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+type PendingSweep struct {
+	// / The outpoint of the output being swept
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The value of the output in satoshis
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// / The type of witness used to spend the output
+	WitnessType string `protobuf:"bytes,3,opt,name=witness_type" json:"witness_type,omitempty"`
+	// / The block height by which the output should be swept
+	DeadlineHeight uint32 `protobuf:"varint,4,opt,name=deadline_height" json:"deadline_height,omitempty"`
+	// / The transaction id of the latest sweep transaction spending the output, if any
+	SweepTxid string `protobuf:"bytes,5,opt,name=sweep_txid" json:"sweep_txid,omitempty"`
+	// / The fee rate of the latest sweep transaction in satoshis per weight unit
+	SatPerWeight int64 `protobuf:"varint,6,opt,name=sat_per_weight" json:"sat_per_weight,omitempty"`
+	// / The number of versions of the sweep transaction that have been broadcast
+	BroadcastAttempts uint32 `protobuf:"varint,7,opt,name=broadcast_attempts" json:"broadcast_attempts,omitempty"`
+}
+
+func (m *PendingSweep) Reset()                    { *m = PendingSweep{} }
+func (m *PendingSweep) String() string            { return proto.CompactTextString(m) }
+func (*PendingSweep) ProtoMessage()               {}
+func (*PendingSweep) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *PendingSweep) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *PendingSweep) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *PendingSweep) GetWitnessType() string {
+	if m != nil {
+		return m.WitnessType
+	}
+	return ""
+}
+
+func (m *PendingSweep) GetDeadlineHeight() uint32 {
+	if m != nil {
+		return m.DeadlineHeight
+	}
+	return 0
+}
+
+func (m *PendingSweep) GetSweepTxid() string {
+	if m != nil {
+		return m.SweepTxid
+	}
+	return ""
+}
+
+func (m *PendingSweep) GetSatPerWeight() int64 {
+	if m != nil {
+		return m.SatPerWeight
+	}
+	return 0
+}
+
+func (m *PendingSweep) GetBroadcastAttempts() uint32 {
+	if m != nil {
+		return m.BroadcastAttempts
+	}
+	return 0
+}
+
+type PendingSweepsResponse struct {
+	// / The outputs that are waiting to be swept
+	PendingSweeps []*PendingSweep `protobuf:"bytes,1,rep,name=pending_sweeps" json:"pending_sweeps,omitempty"`
+}
+
+func (m *PendingSweepsResponse) Reset()                    { *m = PendingSweepsResponse{} }
+func (m *PendingSweepsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PendingSweepsResponse) ProtoMessage()               {}
+func (*PendingSweepsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *PendingSweepsResponse) GetPendingSweeps() []*PendingSweep {
+	if m != nil {
+		return m.PendingSweeps
+	}
+	return nil
+}
+
+type BumpFeeRequest struct {
+	// / The txid of the transaction to bump the fee of. Either this or outpoint must be set.
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	// / The outpoint (txid:index) of an output of the transaction to bump the fee of. If the output is being swept, then its sweep transaction is replaced, otherwise it's spent by the child transaction.
+	Outpoint string `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The target number of blocks that the transaction should be confirmed within
+	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that the transaction should pay
+	SatPerByte int64 `protobuf:"varint,4,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeRequest) Reset()                    { *m = BumpFeeRequest{} }
+func (m *BumpFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeRequest) ProtoMessage()               {}
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *BumpFeeRequest) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *BumpFeeRequest) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *BumpFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BumpFeeRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BumpFeeResponse struct {
+	// / The txid of the replacement or child transaction that was broadcast
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	// / Whether the fee was bumped by replacing the transaction (rbf), or spending one of its outputs (cpfp)
+	Method string `protobuf:"bytes,2,opt,name=method" json:"method,omitempty"`
+	// / The fee rate in satoshis per weight unit paid by the new transaction, or by the parent and child together
+	SatPerWeight int64 `protobuf:"varint,3,opt,name=sat_per_weight" json:"sat_per_weight,omitempty"`
+}
+
+func (m *BumpFeeResponse) Reset()                    { *m = BumpFeeResponse{} }
+func (m *BumpFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeResponse) ProtoMessage()               {}
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *BumpFeeResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *BumpFeeResponse) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *BumpFeeResponse) GetSatPerWeight() int64 {
+	if m != nil {
+		return m.SatPerWeight
+	}
+	return 0
+}
+
 type WalletBalanceRequest struct {
 	// / If only witness outputs should be considered when calculating the wallet's balance
 	WitnessOnly bool `protobuf:"varint,1,opt,name=witness_only,json=witnessOnly" json:"witness_only,omitempty"`
@@ -1877,7 +3338,7 @@ type WalletBalanceRequest struct {
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
 
 func (m *WalletBalanceRequest) GetWitnessOnly() bool {
 	if m != nil {
@@ -1894,7 +3355,7 @@ type WalletBalanceResponse struct {
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 func (m *WalletBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -1909,7 +3370,7 @@ type ChannelBalanceRequest struct {
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 type ChannelBalanceResponse struct {
 	// / Sum of channels balances denominated in satoshis
@@ -1919,7 +3380,7 @@ type ChannelBalanceResponse struct {
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -1933,12 +3394,39 @@ type QueryRoutesRequest struct {
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
 	// / The amount to send expressed in satoshis
 	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// *
+	// The channel id of the channel that must be taken to the first hop. If set,
+	// only a single route is returned.
+	OutgoingChanId uint64 `protobuf:"varint,3,opt,name=outgoing_chan_id,json=outgoingChanId" json:"outgoing_chan_id,omitempty"`
+	// *
+	// The pubkey of the node the route must traverse right before reaching the
+	// destination. If set, only a single route is returned.
+	LastHopPubkey []byte `protobuf:"bytes,4,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
+	// *
+	// The CLTV delta from the current height that should be used for the time
+	// lock of the final hop. If zero, the time lock delta of the final channel
+	// is used.
+	FinalCltvDelta int32 `protobuf:"varint,5,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// / The maximum number of routes to return. If zero, all routes are returned
+	NumRoutes int32 `protobuf:"varint,6,opt,name=num_routes,json=numRoutes" json:"num_routes,omitempty"`
+	// *
+	// The maximum total fee in satoshis that a returned route may pay. If zero,
+	// the fees of the returned routes aren't limited.
+	FeeLimit int64 `protobuf:"varint,7,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	// / The pubkeys of the nodes that may not be traversed by the routes
+	IgnoredNodes [][]byte `protobuf:"bytes,8,rep,name=ignored_nodes,json=ignoredNodes,proto3" json:"ignored_nodes,omitempty"`
+	// / The directed channels that may not be traversed by the routes
+	IgnoredEdges []*EdgeLocator `protobuf:"bytes,9,rep,name=ignored_edges,json=ignoredEdges" json:"ignored_edges,omitempty"`
+	// *
+	// The hex-encoded pubkey of the node the routes should start from. If empty,
+	// the routes start from our own node.
+	SourcePubKey string `protobuf:"bytes,10,opt,name=source_pub_key,json=sourcePubKey" json:"source_pub_key,omitempty"`
 }
 
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
 func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesRequest) ProtoMessage()               {}
-func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *QueryRoutesRequest) GetPubKey() string {
 	if m != nil {
@@ -1954,6 +3442,91 @@ func (m *QueryRoutesRequest) GetAmt() int64 {
 	return 0
 }
 
+func (m *QueryRoutesRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetNumRoutes() int32 {
+	if m != nil {
+		return m.NumRoutes
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetIgnoredNodes() [][]byte {
+	if m != nil {
+		return m.IgnoredNodes
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetIgnoredEdges() []*EdgeLocator {
+	if m != nil {
+		return m.IgnoredEdges
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetSourcePubKey() string {
+	if m != nil {
+		return m.SourcePubKey
+	}
+	return ""
+}
+
+type EdgeLocator struct {
+	// / The unique channel ID of the channel
+	ChannelId uint64 `protobuf:"varint,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	// *
+	// The direction in which the channel is traversed. If false, the channel is
+	// traversed from the node with the lexicographically smaller pubkey towards
+	// the other node. If true, it's traversed in the opposite direction.
+	DirectionReverse bool `protobuf:"varint,2,opt,name=direction_reverse,json=directionReverse" json:"direction_reverse,omitempty"`
+}
+
+func (m *EdgeLocator) Reset()                    { *m = EdgeLocator{} }
+func (m *EdgeLocator) String() string            { return proto.CompactTextString(m) }
+func (*EdgeLocator) ProtoMessage()               {}
+func (*EdgeLocator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *EdgeLocator) GetChannelId() uint64 {
+	if m != nil {
+		return m.ChannelId
+	}
+	return 0
+}
+
+func (m *EdgeLocator) GetDirectionReverse() bool {
+	if m != nil {
+		return m.DirectionReverse
+	}
+	return false
+}
+
 type QueryRoutesResponse struct {
 	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
 }
@@ -1961,7 +3534,7 @@ type QueryRoutesResponse struct {
 func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
 func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesResponse) ProtoMessage()               {}
-func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *QueryRoutesResponse) GetRoutes() []*Route {
 	if m != nil {
@@ -1980,12 +3553,20 @@ type Hop struct {
 	AmtToForward int64  `protobuf:"varint,3,opt,name=amt_to_forward" json:"amt_to_forward,omitempty"`
 	Fee          int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
 	Expiry       uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// *
+	// The amount to forward in milli-satoshis. If set, this takes precedence
+	// over amt_to_forward within routes passed to SendToRoute.
+	AmtToForwardMsat int64 `protobuf:"varint,6,opt,name=amt_to_forward_msat" json:"amt_to_forward_msat,omitempty"`
+	// *
+	// The fee in milli-satoshis. If set, this takes precedence over fee within
+	// routes passed to SendToRoute.
+	FeeMsat int64 `protobuf:"varint,7,opt,name=fee_msat" json:"fee_msat,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -2022,6 +3603,20 @@ func (m *Hop) GetExpiry() uint32 {
 	return 0
 }
 
+func (m *Hop) GetAmtToForwardMsat() int64 {
+	if m != nil {
+		return m.AmtToForwardMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
 // *
 // A path through the channel graph which runs over one or more channels in
 // succession. This struct carries all the information required to craft the
@@ -2050,12 +3645,22 @@ type Route struct {
 	// *
 	// Contains details concerning the specific forwarding details at each hop.
 	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
+	// *
+	// The sum of the fees paid at each hop within the final route, in
+	// milli-satoshis. If set, this takes precedence over total_fees within
+	// routes passed to SendToRoute.
+	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+	// *
+	// The total amount of funds required to complete a payment over this route,
+	// in milli-satoshis. If set, this takes precedence over total_amt within
+	// routes passed to SendToRoute.
+	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
 }
 
 func (m *Route) Reset()                    { *m = Route{} }
 func (m *Route) String() string            { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *Route) GetTotalTimeLock() uint32 {
 	if m != nil {
@@ -2085,6 +3690,20 @@ func (m *Route) GetHops() []*Hop {
 	return nil
 }
 
+func (m *Route) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
+}
+
+func (m *Route) GetTotalAmtMsat() int64 {
+	if m != nil {
+		return m.TotalAmtMsat
+	}
+	return 0
+}
+
 type NodeInfoRequest struct {
 	// / The 33-byte hex-encoded compressed public of the target node
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
@@ -2093,7 +3712,7 @@ type NodeInfoRequest struct {
 func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *NodeInfoRequest) GetPubKey() string {
 	if m != nil {
@@ -2116,7 +3735,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -2154,7 +3773,7 @@ type LightningNode struct {
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -2192,7 +3811,7 @@ type NodeAddress struct {
 func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
 func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()               {}
-func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
 func (m *NodeAddress) GetNetwork() string {
 	if m != nil {
@@ -2218,7 +3837,7 @@ type RoutingPolicy struct {
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -2272,7 +3891,7 @@ type ChannelEdge struct {
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -2336,7 +3955,7 @@ type ChannelGraphRequest struct {
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
@@ -2349,7 +3968,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -2376,7 +3995,7 @@ type ChanInfoRequest struct {
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -2391,24 +4010,29 @@ type NetworkInfoRequest struct {
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
 type NetworkInfo struct {
-	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
-	AvgOutDegree         float64 `protobuf:"fixed64,2,opt,name=avg_out_degree" json:"avg_out_degree,omitempty"`
-	MaxOutDegree         uint32  `protobuf:"varint,3,opt,name=max_out_degree" json:"max_out_degree,omitempty"`
-	NumNodes             uint32  `protobuf:"varint,4,opt,name=num_nodes" json:"num_nodes,omitempty"`
-	NumChannels          uint32  `protobuf:"varint,5,opt,name=num_channels" json:"num_channels,omitempty"`
-	TotalNetworkCapacity int64   `protobuf:"varint,6,opt,name=total_network_capacity" json:"total_network_capacity,omitempty"`
-	AvgChannelSize       float64 `protobuf:"fixed64,7,opt,name=avg_channel_size" json:"avg_channel_size,omitempty"`
-	MinChannelSize       int64   `protobuf:"varint,8,opt,name=min_channel_size" json:"min_channel_size,omitempty"`
-	MaxChannelSize       int64   `protobuf:"varint,9,opt,name=max_channel_size" json:"max_channel_size,omitempty"`
+	GraphDiameter         uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
+	AvgOutDegree          float64 `protobuf:"fixed64,2,opt,name=avg_out_degree" json:"avg_out_degree,omitempty"`
+	MaxOutDegree          uint32  `protobuf:"varint,3,opt,name=max_out_degree" json:"max_out_degree,omitempty"`
+	NumNodes              uint32  `protobuf:"varint,4,opt,name=num_nodes" json:"num_nodes,omitempty"`
+	NumChannels           uint32  `protobuf:"varint,5,opt,name=num_channels" json:"num_channels,omitempty"`
+	TotalNetworkCapacity  int64   `protobuf:"varint,6,opt,name=total_network_capacity" json:"total_network_capacity,omitempty"`
+	AvgChannelSize        float64 `protobuf:"fixed64,7,opt,name=avg_channel_size" json:"avg_channel_size,omitempty"`
+	MinChannelSize        int64   `protobuf:"varint,8,opt,name=min_channel_size" json:"min_channel_size,omitempty"`
+	MaxChannelSize        int64   `protobuf:"varint,9,opt,name=max_channel_size" json:"max_channel_size,omitempty"`
+	NumRateLimitedUpdates uint64  `protobuf:"varint,10,opt,name=num_rate_limited_updates" json:"num_rate_limited_updates,omitempty"`
+	NumDuplicateUpdates   uint64  `protobuf:"varint,11,opt,name=num_duplicate_updates" json:"num_duplicate_updates,omitempty"`
+	NumInvalidGossip      uint64  `protobuf:"varint,12,opt,name=num_invalid_gossip" json:"num_invalid_gossip,omitempty"`
+	NumBannedPeerGossip   uint64  `protobuf:"varint,13,opt,name=num_banned_peer_gossip" json:"num_banned_peer_gossip,omitempty"`
+	NumBannedPeers        uint32  `protobuf:"varint,14,opt,name=num_banned_peers" json:"num_banned_peers,omitempty"`
 }
 
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -2473,13 +4097,48 @@ func (m *NetworkInfo) GetMaxChannelSize() int64 {
 	return 0
 }
 
+func (m *NetworkInfo) GetNumRateLimitedUpdates() uint64 {
+	if m != nil {
+		return m.NumRateLimitedUpdates
+	}
+	return 0
+}
+
+func (m *NetworkInfo) GetNumDuplicateUpdates() uint64 {
+	if m != nil {
+		return m.NumDuplicateUpdates
+	}
+	return 0
+}
+
+func (m *NetworkInfo) GetNumInvalidGossip() uint64 {
+	if m != nil {
+		return m.NumInvalidGossip
+	}
+	return 0
+}
+
+func (m *NetworkInfo) GetNumBannedPeerGossip() uint64 {
+	if m != nil {
+		return m.NumBannedPeerGossip
+	}
+	return 0
+}
+
+func (m *NetworkInfo) GetNumBannedPeers() uint32 {
+	if m != nil {
+		return m.NumBannedPeers
+	}
+	return 0
+}
+
 type StopRequest struct {
 }
 
 func (m *StopRequest) Reset()                    { *m = StopRequest{} }
 func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 type StopResponse struct {
 }
@@ -2487,7 +4146,7 @@ type StopResponse struct {
 func (m *StopResponse) Reset()                    { *m = StopResponse{} }
 func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 type GraphTopologySubscription struct {
 }
@@ -2495,7 +4154,7 @@ type GraphTopologySubscription struct {
 func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
 func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologySubscription) ProtoMessage()               {}
-func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 type GraphTopologyUpdate struct {
 	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
@@ -2506,7 +4165,7 @@ type GraphTopologyUpdate struct {
 func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
 func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologyUpdate) ProtoMessage()               {}
-func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
 	if m != nil {
@@ -2539,7 +4198,7 @@ type NodeUpdate struct {
 func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
 func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*NodeUpdate) ProtoMessage()               {}
-func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
 func (m *NodeUpdate) GetAddresses() []string {
 	if m != nil {
@@ -2585,7 +4244,7 @@ type ChannelEdgeUpdate struct {
 func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
 func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdgeUpdate) ProtoMessage()               {}
-func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 func (m *ChannelEdgeUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -2643,7 +4302,7 @@ type ClosedChannelUpdate struct {
 func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
 func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelUpdate) ProtoMessage()               {}
-func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *ClosedChannelUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -2680,7 +4339,7 @@ type SetAliasRequest struct {
 func (m *SetAliasRequest) Reset()                    { *m = SetAliasRequest{} }
 func (m *SetAliasRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetAliasRequest) ProtoMessage()               {}
-func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 func (m *SetAliasRequest) GetNewAlias() string {
 	if m != nil {
@@ -2695,7 +4354,7 @@ type SetAliasResponse struct {
 func (m *SetAliasResponse) Reset()                    { *m = SetAliasResponse{} }
 func (m *SetAliasResponse) String() string            { return proto.CompactTextString(m) }
 func (*SetAliasResponse) ProtoMessage()               {}
-func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
 
 type Invoice struct {
 	// / An optional memo to attach along with the invoice
@@ -2726,7 +4385,7 @@ type Invoice struct {
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -2803,7 +4462,7 @@ type AddInvoiceResponse struct {
 func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
 func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
 func (m *AddInvoiceResponse) GetRHash() []byte {
 	if m != nil {
@@ -2831,7 +4490,7 @@ type PaymentHash struct {
 func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
 func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
 func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
 
 func (m *PaymentHash) GetRHashStr() string {
 	if m != nil {
@@ -2855,7 +4514,7 @@ type ListInvoiceRequest struct {
 func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
 func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
 
 func (m *ListInvoiceRequest) GetPendingOnly() bool {
 	if m != nil {
@@ -2871,7 +4530,7 @@ type ListInvoiceResponse struct {
 func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
 func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
 
 func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 	if m != nil {
@@ -2886,7 +4545,7 @@ type InvoiceSubscription struct {
 func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
 func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
 func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
 
 type Payment struct {
 	// / The payment hash
@@ -2899,55 +4558,117 @@ type Payment struct {
 	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
 	// / The fee paid for this payment in satoshis
 	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
+	// / The current state of the payment
+	Status Payment_PaymentStatus `protobuf:"varint,6,opt,name=status,enum=lnrpc.Payment_PaymentStatus" json:"status,omitempty"`
+	// / The reason the payment failed, if it has failed
+	FailureReason string `protobuf:"bytes,7,opt,name=failure_reason" json:"failure_reason,omitempty"`
+	// / The attempts made to complete the payment, in the order they were made
+	Attempts []*PaymentAttempt `protobuf:"bytes,8,rep,name=attempts" json:"attempts,omitempty"`
 }
 
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
 
 func (m *Payment) GetPaymentHash() string {
 	if m != nil {
-		return m.PaymentHash
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *Payment) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Payment) GetCreationDate() int64 {
+	if m != nil {
+		return m.CreationDate
+	}
+	return 0
+}
+
+func (m *Payment) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *Payment) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *Payment) GetStatus() Payment_PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return Payment_UNKNOWN
+}
+
+func (m *Payment) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
 	}
 	return ""
 }
 
-func (m *Payment) GetValue() int64 {
+func (m *Payment) GetAttempts() []*PaymentAttempt {
 	if m != nil {
-		return m.Value
+		return m.Attempts
 	}
-	return 0
+	return nil
 }
 
-func (m *Payment) GetCreationDate() int64 {
-	if m != nil {
-		return m.CreationDate
-	}
-	return 0
+type PaymentAttempt struct {
+	// / The path the HTLC of this attempt was sent along
+	Path []string `protobuf:"bytes,1,rep,name=path" json:"path,omitempty"`
+	// / The value of the HTLC including fees in satoshis
+	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// / The reason this attempt failed, if it has failed
+	FailureReason string `protobuf:"bytes,3,opt,name=failure_reason" json:"failure_reason,omitempty"`
 }
 
-func (m *Payment) GetPath() []string {
+func (m *PaymentAttempt) Reset()                    { *m = PaymentAttempt{} }
+func (m *PaymentAttempt) String() string            { return proto.CompactTextString(m) }
+func (*PaymentAttempt) ProtoMessage()               {}
+func (*PaymentAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *PaymentAttempt) GetPath() []string {
 	if m != nil {
 		return m.Path
 	}
 	return nil
 }
 
-func (m *Payment) GetFee() int64 {
+func (m *PaymentAttempt) GetAmt() int64 {
 	if m != nil {
-		return m.Fee
+		return m.Amt
 	}
 	return 0
 }
 
+func (m *PaymentAttempt) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
 type ListPaymentsRequest struct {
 }
 
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
 
 type ListPaymentsResponse struct {
 	// / The list of payments
@@ -2957,7 +4678,7 @@ type ListPaymentsResponse struct {
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -2972,7 +4693,7 @@ type DeleteAllPaymentsRequest struct {
 func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
 func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
 type DeleteAllPaymentsResponse struct {
 }
@@ -2980,7 +4701,33 @@ type DeleteAllPaymentsResponse struct {
 func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
 func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+type TrackPaymentRequest struct {
+	// / The hash of the payment to track
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The hex-encoded hash of the payment to track
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string" json:"payment_hash_string,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *TrackPaymentRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
 
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
@@ -2990,7 +4737,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -3013,7 +4760,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -3030,7 +4777,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -3048,7 +4795,7 @@ type PayReq struct {
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -3077,7 +4824,7 @@ type FeeReportRequest struct {
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -3093,7 +4840,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -3131,7 +4878,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -3154,7 +4901,7 @@ type FeeUpdateRequest struct {
 func (m *FeeUpdateRequest) Reset()                    { *m = FeeUpdateRequest{} }
 func (m *FeeUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeUpdateRequest) ProtoMessage()               {}
-func (*FeeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+func (*FeeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
 
 type isFeeUpdateRequest_Scope interface {
 	isFeeUpdateRequest_Scope()
@@ -3284,7 +5031,41 @@ type FeeUpdateResponse struct {
 func (m *FeeUpdateResponse) Reset()                    { *m = FeeUpdateResponse{} }
 func (m *FeeUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeUpdateResponse) ProtoMessage()               {}
-func (*FeeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (*FeeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+type UpdateChanStatusRequest struct {
+	// / The channel point (txid:index) of the channel to disable or enable
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// / If true, the channel will be disabled, otherwise it will be enabled
+	Disable bool `protobuf:"varint,2,opt,name=disable" json:"disable,omitempty"`
+}
+
+func (m *UpdateChanStatusRequest) Reset()                    { *m = UpdateChanStatusRequest{} }
+func (m *UpdateChanStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusRequest) ProtoMessage()               {}
+func (*UpdateChanStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+func (m *UpdateChanStatusRequest) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *UpdateChanStatusRequest) GetDisable() bool {
+	if m != nil {
+		return m.Disable
+	}
+	return false
+}
+
+type UpdateChanStatusResponse struct {
+}
+
+func (m *UpdateChanStatusResponse) Reset()                    { *m = UpdateChanStatusResponse{} }
+func (m *UpdateChanStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusResponse) ProtoMessage()               {}
+func (*UpdateChanStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
 func init() {
 	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
@@ -3292,6 +5073,9 @@ func init() {
 	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
 	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
 	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
+	proto.RegisterType((*RebalanceRequest)(nil), "lnrpc.RebalanceRequest")
+	proto.RegisterType((*RebalanceResponse)(nil), "lnrpc.RebalanceResponse")
 	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
 	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
 	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
@@ -3313,18 +5097,37 @@ func init() {
 	proto.RegisterType((*ActiveChannel)(nil), "lnrpc.ActiveChannel")
 	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
 	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*ClosedChannelsRequest)(nil), "lnrpc.ClosedChannelsRequest")
+	proto.RegisterType((*Resolution)(nil), "lnrpc.Resolution")
+	proto.RegisterType((*ChannelCloseSummary)(nil), "lnrpc.ChannelCloseSummary")
+	proto.RegisterType((*ClosedChannelsResponse)(nil), "lnrpc.ClosedChannelsResponse")
+	proto.RegisterType((*ChannelEventSubscription)(nil), "lnrpc.ChannelEventSubscription")
+	proto.RegisterType((*HtlcEventSubscription)(nil), "lnrpc.HtlcEventSubscription")
+	proto.RegisterType((*HtlcEvent)(nil), "lnrpc.HtlcEvent")
+	proto.RegisterType((*ForwardHtlcInterceptRequest)(nil), "lnrpc.ForwardHtlcInterceptRequest")
+	proto.RegisterType((*ForwardHtlcInterceptResponse)(nil), "lnrpc.ForwardHtlcInterceptResponse")
+	proto.RegisterType((*ChannelEventUpdate)(nil), "lnrpc.ChannelEventUpdate")
 	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
 	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
 	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*PeerEventSubscription)(nil), "lnrpc.PeerEventSubscription")
+	proto.RegisterType((*PeerEvent)(nil), "lnrpc.PeerEvent")
 	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
 	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
 	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
 	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
 	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
 	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*AbandonChannelRequest)(nil), "lnrpc.AbandonChannelRequest")
+	proto.RegisterType((*AbandonChannelResponse)(nil), "lnrpc.AbandonChannelResponse")
+	proto.RegisterType((*SpliceChannelRequest)(nil), "lnrpc.SpliceChannelRequest")
+	proto.RegisterType((*SpliceChannelResponse)(nil), "lnrpc.SpliceChannelResponse")
 	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
 	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
 	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*BatchOpenChannel)(nil), "lnrpc.BatchOpenChannel")
+	proto.RegisterType((*BatchOpenChannelRequest)(nil), "lnrpc.BatchOpenChannelRequest")
+	proto.RegisterType((*BatchOpenChannelResponse)(nil), "lnrpc.BatchOpenChannelResponse")
 	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
 	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
 	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
@@ -3332,11 +5135,17 @@ func init() {
 	proto.RegisterType((*PendingChannelResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelResponse.PendingOpenChannel")
 	proto.RegisterType((*PendingChannelResponse_ClosedChannel)(nil), "lnrpc.PendingChannelResponse.ClosedChannel")
 	proto.RegisterType((*PendingChannelResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelResponse.ForceClosedChannel")
+	proto.RegisterType((*PendingSweepsRequest)(nil), "lnrpc.PendingSweepsRequest")
+	proto.RegisterType((*PendingSweep)(nil), "lnrpc.PendingSweep")
+	proto.RegisterType((*PendingSweepsResponse)(nil), "lnrpc.PendingSweepsResponse")
+	proto.RegisterType((*BumpFeeRequest)(nil), "lnrpc.BumpFeeRequest")
+	proto.RegisterType((*BumpFeeResponse)(nil), "lnrpc.BumpFeeResponse")
 	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
 	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
 	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
 	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
 	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
+	proto.RegisterType((*EdgeLocator)(nil), "lnrpc.EdgeLocator")
 	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
 	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
 	proto.RegisterType((*Route)(nil), "lnrpc.Route")
@@ -3367,10 +5176,12 @@ func init() {
 	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
 	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
 	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*PaymentAttempt)(nil), "lnrpc.PaymentAttempt")
 	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
 	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
 	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
 	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
 	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
 	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
 	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
@@ -3380,7 +5191,17 @@ func init() {
 	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
 	proto.RegisterType((*FeeUpdateRequest)(nil), "lnrpc.FeeUpdateRequest")
 	proto.RegisterType((*FeeUpdateResponse)(nil), "lnrpc.FeeUpdateResponse")
+	proto.RegisterType((*UpdateChanStatusRequest)(nil), "lnrpc.UpdateChanStatusRequest")
+	proto.RegisterType((*UpdateChanStatusResponse)(nil), "lnrpc.UpdateChanStatusResponse")
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Resolution_ResolutionType", Resolution_ResolutionType_name, Resolution_ResolutionType_value)
+	proto.RegisterEnum("lnrpc.Resolution_ResolutionOutcome", Resolution_ResolutionOutcome_name, Resolution_ResolutionOutcome_value)
+	proto.RegisterEnum("lnrpc.ChannelCloseSummary_ClosureType", ChannelCloseSummary_ClosureType_name, ChannelCloseSummary_ClosureType_value)
+	proto.RegisterEnum("lnrpc.HtlcEvent_EventType", HtlcEvent_EventType_name, HtlcEvent_EventType_value)
+	proto.RegisterEnum("lnrpc.ForwardHtlcInterceptResponse_ResolveAction", ForwardHtlcInterceptResponse_ResolveAction_name, ForwardHtlcInterceptResponse_ResolveAction_value)
+	proto.RegisterEnum("lnrpc.ChannelEventUpdate_UpdateType", ChannelEventUpdate_UpdateType_name, ChannelEventUpdate_UpdateType_value)
+	proto.RegisterEnum("lnrpc.PeerEvent_EventType", PeerEvent_EventType_name, PeerEvent_EventType_value)
+	proto.RegisterEnum("lnrpc.Payment_PaymentStatus", Payment_PaymentStatus_name, Payment_PaymentStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3450,6 +5271,11 @@ type LightningClient interface {
 	// * lncli: `listpeers`
 	// ListPeers returns a verbose listing of all currently active peers.
 	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// *
+	// SubscribePeerEvents creates a uni-directional stream from the server to
+	// the client in which any events relevant to the state of peers are sent
+	// over. Events include peers coming online and going offline.
+	SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error)
 	// * lncli: `getinfo`
 	// GetInfo returns general information concerning the lightning node including
 	// it's identity pubkey, alias, the chains it is connected to, and information
@@ -3461,10 +5287,49 @@ type LightningClient interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error)
+	// * lncli: `pendingsweeps`
+	// PendingSweeps returns a list of all the outputs that have been handed to
+	// the sweeper, but haven't yet been swept within a confirmed transaction.
+	// This includes the outputs of force closed channels, and any outputs being
+	// claimed due to a channel breach.
+	PendingSweeps(ctx context.Context, in *PendingSweepsRequest, opts ...grpc.CallOption) (*PendingSweepsResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee raises the fee of an unconfirmed transaction. If the transaction
+	// was crafted by the sweeper, then it's replaced by a transaction paying a
+	// higher fee (RBF). Otherwise, a child transaction spending one of our
+	// outputs of the transaction is broadcast, which pays enough fee for the
+	// pair to confirm at the requested fee rate (CPFP).
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
 	// * lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// * lncli: `closedchannels`
+	// ClosedChannels returns a description of all the closed channels that
+	// this node was a participant in, along with the on-chain resolution of
+	// each of their outputs.
+	ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error)
+	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which any updates relevant to the state of the channels are
+	// sent over. Events include new pending channels being opened, channels
+	// becoming open, active or inactive, and channels being closed.
+	SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error)
+	// *
+	// SubscribeHtlcEvents creates a uni-directional stream from the server to
+	// the client in which events describing each HTLC handled by the switch are
+	// sent over. Events include HTLCs being forwarded, settled or failed, as
+	// well as HTLCs we send or receive as a payment endpoint.
+	SubscribeHtlcEvents(ctx context.Context, in *HtlcEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error)
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which each
+	// HTLC our node is about to forward is sent to the client, and held until
+	// the client responds with a resolution. The HTLC may be resumed, failed
+	// with a chosen failure code, or settled using its preimage. Only a single
+	// interceptor may be active at a time. HTLCs which aren't resolved within
+	// the configured timeout are failed back, and any HTLCs still held once the
+	// stream is closed are resumed.
+	HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error)
 	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
@@ -3475,12 +5340,34 @@ type LightningClient interface {
 	// OpenChannel attempts to open a singly funded channel specified in the
 	// request to a remote peer.
 	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open a set of singly funded channels to
+	// distinct remote peers, all funded by a single transaction with a single
+	// change output. The funding transaction is only broadcast once every peer
+	// has accepted and signed its channel. If any channel fails, then none of the
+	// channels in the batch are opened.
+	BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
 	// augmented to attempt a force close after a timeout period in the case of an
 	// inactive peer.
 	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
+	// * lncli: `abandonchannel`
+	// AbandonChannel removes a channel that is pending, or otherwise can't be
+	// resolved, from the database. A close summary of type abandoned is recorded,
+	// any wallet inputs locked by the channel's funding transaction are released,
+	// and the remote peer is notified. Channels whose commitment transaction may
+	// have been broadcast can only be abandoned if force is set.
+	AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error)
+	// * lncli: `splicechannel`
+	// SpliceChannel adds funds from the wallet to an active channel (splice-in),
+	// or removes funds from an active channel to an on-chain address
+	// (splice-out), without closing the channel. The channel is paused until the
+	// splice transaction confirms, at which point it continues under the new
+	// channel point returned by this call. The splice initiator pays the full fee
+	// of the splice transaction.
+	SpliceChannel(ctx context.Context, in *SpliceChannelRequest, opts ...grpc.CallOption) (*SpliceChannelResponse, error)
 	// * lncli: `sendpayment`
 	// SendPayment dispatches a bi-directional streaming RPC for sending payments
 	// through the Lightning Network. A single RPC invocation creates a persistent
@@ -3493,6 +5380,22 @@ type LightningClient interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// *
+	// SendToRoute is a bi-directional streaming RPC for sending payments along
+	// one or more routes constructed by the caller, rather than found by the
+	// daemon's path finding. The routes of each request are attempted in the
+	// order given, each over the exact channel specified by its first hop.
+	SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error)
+	// * lncli: `sendtoroute`
+	// SendToRouteSync is the synchronous non-streaming version of SendToRoute.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance moves funds from one of our channels into another by paying an
+	// invoice to ourselves, routed out through the outgoing channel and back in
+	// through the incoming channel. The payment is aborted if no route within the
+	// specified fee limit can be found.
+	Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error)
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -3522,6 +5425,13 @@ type LightningClient interface {
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment creates a uni-directional stream from the server to the
+	// client over which the state of the target outgoing payment is sent. The
+	// current state of the payment is sent immediately, followed by an update
+	// each time an attempt is made or the payment succeeds or fails. The stream
+	// is closed by the server once the payment has either succeeded or failed.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
 	// * lncli: `describegraph`
 	// DescribeGraph returns a description of the latest graph state from the
 	// point of view of the node. The graph information is partitioned into two
@@ -3580,6 +5490,12 @@ type LightningClient interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(ctx context.Context, in *FeeUpdateRequest, opts ...grpc.CallOption) (*FeeUpdateResponse, error)
+	// * lncli: `updatechanstatus`
+	// UpdateChanStatus manually disables or enables one of our channels for
+	// forwarding, by announcing a new channel update with the disable flag set
+	// or cleared to the rest of the network. Manually disabled channels aren't
+	// re-enabled automatically once their peer reconnects.
+	UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error)
 }
 
 type lightningClient struct {
@@ -3730,6 +5646,38 @@ func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, o
 	return out, nil
 }
 
+func (c *lightningClient) SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/SubscribePeerEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribePeerEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribePeerEventsClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribePeerEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribePeerEventsClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
 	out := new(GetInfoResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
@@ -3748,6 +5696,24 @@ func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChanne
 	return out, nil
 }
 
+func (c *lightningClient) PendingSweeps(ctx context.Context, in *PendingSweepsRequest, opts ...grpc.CallOption) (*PendingSweepsResponse, error) {
+	out := new(PendingSweepsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingSweeps", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
 	out := new(ListChannelsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
@@ -3757,6 +5723,110 @@ func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequ
 	return out, nil
 }
 
+func (c *lightningClient) ClosedChannels(ctx context.Context, in *ClosedChannelsRequest, opts ...grpc.CallOption) (*ClosedChannelsResponse, error) {
+	out := new(ClosedChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ClosedChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/SubscribeChannelEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelEventsClient interface {
+	Recv() (*ChannelEventUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelEventsClient) Recv() (*ChannelEventUpdate, error) {
+	m := new(ChannelEventUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SubscribeHtlcEvents(ctx context.Context, in *HtlcEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SubscribeHtlcEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeHtlcEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeHtlcEventsClient interface {
+	Recv() (*HtlcEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeHtlcEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeHtlcEventsClient) Recv() (*HtlcEvent, error) {
+	m := new(HtlcEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/HtlcInterceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningHtlcInterceptorClient{stream}
+	return x, nil
+}
+
+type Lightning_HtlcInterceptorClient interface {
+	Send(*ForwardHtlcInterceptResponse) error
+	Recv() (*ForwardHtlcInterceptRequest, error)
+	grpc.ClientStream
+}
+
+type lightningHtlcInterceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningHtlcInterceptorClient) Send(m *ForwardHtlcInterceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningHtlcInterceptorClient) Recv() (*ForwardHtlcInterceptRequest, error) {
+	m := new(ForwardHtlcInterceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
 	out := new(ChannelPoint)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
@@ -3767,7 +5837,7 @@ func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRe
 }
 
 func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3798,8 +5868,17 @@ func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error) {
+	out := new(BatchOpenChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BatchOpenChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3830,8 +5909,26 @@ func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error) {
+	out := new(AbandonChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AbandonChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SpliceChannel(ctx context.Context, in *SpliceChannelRequest, opts ...grpc.CallOption) (*SpliceChannelResponse, error) {
+	out := new(SpliceChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SpliceChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3870,6 +5967,55 @@ func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest,
 	return out, nil
 }
 
+func (c *lightningClient) SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/SendToRoute", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendToRouteClient{stream}
+	return x, nil
+}
+
+type Lightning_SendToRouteClient interface {
+	Send(*SendToRouteRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendToRouteClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendToRouteClient) Send(m *SendToRouteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendToRouteClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRouteSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error) {
+	out := new(RebalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/Rebalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
 	out := new(AddInvoiceResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
@@ -3898,7 +6044,7 @@ func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, op
 }
 
 func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[9], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3956,6 +6102,38 @@ func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPa
 	return out, nil
 }
 
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[10], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*Payment, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*Payment, error) {
+	m := new(Payment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
 	out := new(ChannelGraph)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
@@ -4011,7 +6189,7 @@ func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts
 }
 
 func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[11], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4069,9 +6247,18 @@ func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, o
 	return out, nil
 }
 
-func (c *lightningClient) UpdateFees(ctx context.Context, in *FeeUpdateRequest, opts ...grpc.CallOption) (*FeeUpdateResponse, error) {
-	out := new(FeeUpdateResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateFees", in, out, c.cc, opts...)
+func (c *lightningClient) UpdateFees(ctx context.Context, in *FeeUpdateRequest, opts ...grpc.CallOption) (*FeeUpdateResponse, error) {
+	out := new(FeeUpdateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateFees", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error) {
+	out := new(UpdateChanStatusResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChanStatus", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4137,6 +6324,11 @@ type LightningServer interface {
 	// * lncli: `listpeers`
 	// ListPeers returns a verbose listing of all currently active peers.
 	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// *
+	// SubscribePeerEvents creates a uni-directional stream from the server to
+	// the client in which any events relevant to the state of peers are sent
+	// over. Events include peers coming online and going offline.
+	SubscribePeerEvents(*PeerEventSubscription, Lightning_SubscribePeerEventsServer) error
 	// * lncli: `getinfo`
 	// GetInfo returns general information concerning the lightning node including
 	// it's identity pubkey, alias, the chains it is connected to, and information
@@ -4148,10 +6340,49 @@ type LightningServer interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
+	// * lncli: `pendingsweeps`
+	// PendingSweeps returns a list of all the outputs that have been handed to
+	// the sweeper, but haven't yet been swept within a confirmed transaction.
+	// This includes the outputs of force closed channels, and any outputs being
+	// claimed due to a channel breach.
+	PendingSweeps(context.Context, *PendingSweepsRequest) (*PendingSweepsResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee raises the fee of an unconfirmed transaction. If the transaction
+	// was crafted by the sweeper, then it's replaced by a transaction paying a
+	// higher fee (RBF). Otherwise, a child transaction spending one of our
+	// outputs of the transaction is broadcast, which pays enough fee for the
+	// pair to confirm at the requested fee rate (CPFP).
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
 	// * lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// * lncli: `closedchannels`
+	// ClosedChannels returns a description of all the closed channels that
+	// this node was a participant in, along with the on-chain resolution of
+	// each of their outputs.
+	ClosedChannels(context.Context, *ClosedChannelsRequest) (*ClosedChannelsResponse, error)
+	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which any updates relevant to the state of the channels are
+	// sent over. Events include new pending channels being opened, channels
+	// becoming open, active or inactive, and channels being closed.
+	SubscribeChannelEvents(*ChannelEventSubscription, Lightning_SubscribeChannelEventsServer) error
+	// *
+	// SubscribeHtlcEvents creates a uni-directional stream from the server to
+	// the client in which events describing each HTLC handled by the switch are
+	// sent over. Events include HTLCs being forwarded, settled or failed, as
+	// well as HTLCs we send or receive as a payment endpoint.
+	SubscribeHtlcEvents(*HtlcEventSubscription, Lightning_SubscribeHtlcEventsServer) error
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which each
+	// HTLC our node is about to forward is sent to the client, and held until
+	// the client responds with a resolution. The HTLC may be resumed, failed
+	// with a chosen failure code, or settled using its preimage. Only a single
+	// interceptor may be active at a time. HTLCs which aren't resolved within
+	// the configured timeout are failed back, and any HTLCs still held once the
+	// stream is closed are resumed.
+	HtlcInterceptor(Lightning_HtlcInterceptorServer) error
 	// *
 	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 	// call is meant to be consumed by clients to the REST proxy. As with all
@@ -4162,12 +6393,34 @@ type LightningServer interface {
 	// OpenChannel attempts to open a singly funded channel specified in the
 	// request to a remote peer.
 	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// * lncli: `batchopenchannel`
+	// BatchOpenChannel attempts to open a set of singly funded channels to
+	// distinct remote peers, all funded by a single transaction with a single
+	// change output. The funding transaction is only broadcast once every peer
+	// has accepted and signed its channel. If any channel fails, then none of the
+	// channels in the batch are opened.
+	BatchOpenChannel(context.Context, *BatchOpenChannelRequest) (*BatchOpenChannelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
 	// augmented to attempt a force close after a timeout period in the case of an
 	// inactive peer.
 	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	// * lncli: `abandonchannel`
+	// AbandonChannel removes a channel that is pending, or otherwise can't be
+	// resolved, from the database. A close summary of type abandoned is recorded,
+	// any wallet inputs locked by the channel's funding transaction are released,
+	// and the remote peer is notified. Channels whose commitment transaction may
+	// have been broadcast can only be abandoned if force is set.
+	AbandonChannel(context.Context, *AbandonChannelRequest) (*AbandonChannelResponse, error)
+	// * lncli: `splicechannel`
+	// SpliceChannel adds funds from the wallet to an active channel (splice-in),
+	// or removes funds from an active channel to an on-chain address
+	// (splice-out), without closing the channel. The channel is paused until the
+	// splice transaction confirms, at which point it continues under the new
+	// channel point returned by this call. The splice initiator pays the full fee
+	// of the splice transaction.
+	SpliceChannel(context.Context, *SpliceChannelRequest) (*SpliceChannelResponse, error)
 	// * lncli: `sendpayment`
 	// SendPayment dispatches a bi-directional streaming RPC for sending payments
 	// through the Lightning Network. A single RPC invocation creates a persistent
@@ -4180,6 +6433,22 @@ type LightningServer interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// *
+	// SendToRoute is a bi-directional streaming RPC for sending payments along
+	// one or more routes constructed by the caller, rather than found by the
+	// daemon's path finding. The routes of each request are attempted in the
+	// order given, each over the exact channel specified by its first hop.
+	SendToRoute(Lightning_SendToRouteServer) error
+	// * lncli: `sendtoroute`
+	// SendToRouteSync is the synchronous non-streaming version of SendToRoute.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	SendToRouteSync(context.Context, *SendToRouteRequest) (*SendResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance moves funds from one of our channels into another by paying an
+	// invoice to ourselves, routed out through the outgoing channel and back in
+	// through the incoming channel. The payment is aborted if no route within the
+	// specified fee limit can be found.
+	Rebalance(context.Context, *RebalanceRequest) (*RebalanceResponse, error)
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -4209,6 +6478,13 @@ type LightningServer interface {
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment creates a uni-directional stream from the server to the
+	// client over which the state of the target outgoing payment is sent. The
+	// current state of the payment is sent immediately, followed by an update
+	// each time an attempt is made or the payment succeeds or fails. The stream
+	// is closed by the server once the payment has either succeeded or failed.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
 	// * lncli: `describegraph`
 	// DescribeGraph returns a description of the latest graph state from the
 	// point of view of the node. The graph information is partitioned into two
@@ -4267,6 +6543,12 @@ type LightningServer interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(context.Context, *FeeUpdateRequest) (*FeeUpdateResponse, error)
+	// * lncli: `updatechanstatus`
+	// UpdateChanStatus manually disables or enables one of our channels for
+	// forwarding, by announcing a new channel update with the disable flag set
+	// or cleared to the rest of the network. Manually disabled channels aren't
+	// re-enabled automatically once their peer reconnects.
+	UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error)
 }
 
 func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
@@ -4510,6 +6792,27 @@ func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SubscribePeerEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribePeerEvents(m, &lightningSubscribePeerEventsServer{stream})
+}
+
+type Lightning_SubscribePeerEventsServer interface {
+	Send(*PeerEvent) error
+	grpc.ServerStream
+}
+
+type lightningSubscribePeerEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribePeerEventsServer) Send(m *PeerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInfoRequest)
 	if err := dec(in); err != nil {
@@ -4546,6 +6849,42 @@ func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_PendingSweeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingSweepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PendingSweeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingSweeps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingSweeps(ctx, req.(*PendingSweepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListChannelsRequest)
 	if err := dec(in); err != nil {
@@ -4564,6 +6903,92 @@ func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ClosedChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosedChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ClosedChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ClosedChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ClosedChannels(ctx, req.(*ClosedChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeChannelEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChannelEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeChannelEvents(m, &lightningSubscribeChannelEventsServer{stream})
+}
+
+type Lightning_SubscribeChannelEventsServer interface {
+	Send(*ChannelEventUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeChannelEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeChannelEventsServer) Send(m *ChannelEventUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SubscribeHtlcEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HtlcEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeHtlcEvents(m, &lightningSubscribeHtlcEventsServer{stream})
+}
+
+type Lightning_SubscribeHtlcEventsServer interface {
+	Send(*HtlcEvent) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeHtlcEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeHtlcEventsServer) Send(m *HtlcEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_HtlcInterceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).HtlcInterceptor(&lightningHtlcInterceptorServer{stream})
+}
+
+type Lightning_HtlcInterceptorServer interface {
+	Send(*ForwardHtlcInterceptRequest) error
+	Recv() (*ForwardHtlcInterceptResponse, error)
+	grpc.ServerStream
+}
+
+type lightningHtlcInterceptorServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningHtlcInterceptorServer) Send(m *ForwardHtlcInterceptRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningHtlcInterceptorServer) Recv() (*ForwardHtlcInterceptResponse, error) {
+	m := new(ForwardHtlcInterceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OpenChannelRequest)
 	if err := dec(in); err != nil {
@@ -4603,6 +7028,24 @@ func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_BatchOpenChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BatchOpenChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BatchOpenChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BatchOpenChannel(ctx, req.(*BatchOpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(CloseChannelRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -4624,6 +7067,42 @@ func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AbandonChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AbandonChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AbandonChannel(ctx, req.(*AbandonChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SpliceChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpliceChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SpliceChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SpliceChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SpliceChannel(ctx, req.(*SpliceChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
 }
@@ -4668,6 +7147,68 @@ func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SendToRoute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendToRoute(&lightningSendToRouteServer{stream})
+}
+
+type Lightning_SendToRouteServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendToRouteRequest, error)
+	grpc.ServerStream
+}
+
+type lightningSendToRouteServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendToRouteServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningSendToRouteServer) Recv() (*SendToRouteRequest, error) {
+	m := new(SendToRouteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_SendToRouteSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendToRouteSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendToRouteSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendToRouteSync(ctx, req.(*SendToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_Rebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).Rebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/Rebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).Rebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Invoice)
 	if err := dec(in); err != nil {
@@ -4797,6 +7338,27 @@ func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
+}
+
+type Lightning_TrackPaymentServer interface {
+	Send(*Payment) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentServer) Send(m *Payment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChannelGraphRequest)
 	if err := dec(in); err != nil {
@@ -4998,6 +7560,24 @@ func _Lightning_UpdateFees_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_UpdateChanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChanStatus(ctx, req.(*UpdateChanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Lightning_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lnrpc.Lightning",
 	HandlerType: (*LightningServer)(nil),
@@ -5058,18 +7638,50 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
 		},
+		{
+			MethodName: "PendingSweeps",
+			Handler:    _Lightning_PendingSweeps_Handler,
+		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Lightning_BumpFee_Handler,
+		},
 		{
 			MethodName: "ListChannels",
 			Handler:    _Lightning_ListChannels_Handler,
 		},
+		{
+			MethodName: "ClosedChannels",
+			Handler:    _Lightning_ClosedChannels_Handler,
+		},
 		{
 			MethodName: "OpenChannelSync",
 			Handler:    _Lightning_OpenChannelSync_Handler,
 		},
+		{
+			MethodName: "BatchOpenChannel",
+			Handler:    _Lightning_BatchOpenChannel_Handler,
+		},
+		{
+			MethodName: "AbandonChannel",
+			Handler:    _Lightning_AbandonChannel_Handler,
+		},
+		{
+			MethodName: "SpliceChannel",
+			Handler:    _Lightning_SpliceChannel_Handler,
+		},
 		{
 			MethodName: "SendPaymentSync",
 			Handler:    _Lightning_SendPaymentSync_Handler,
 		},
+		{
+			MethodName: "SendToRouteSync",
+			Handler:    _Lightning_SendToRouteSync_Handler,
+		},
+		{
+			MethodName: "Rebalance",
+			Handler:    _Lightning_Rebalance_Handler,
+		},
 		{
 			MethodName: "AddInvoice",
 			Handler:    _Lightning_AddInvoice_Handler,
@@ -5134,6 +7746,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFees",
 			Handler:    _Lightning_UpdateFees_Handler,
 		},
+		{
+			MethodName: "UpdateChanStatus",
+			Handler:    _Lightning_UpdateChanStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -5141,6 +7757,27 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeTransactions_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribePeerEvents",
+			Handler:       _Lightning_SubscribePeerEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeChannelEvents",
+			Handler:       _Lightning_SubscribeChannelEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeHtlcEvents",
+			Handler:       _Lightning_SubscribeHtlcEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "HtlcInterceptor",
+			Handler:       _Lightning_HtlcInterceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "OpenChannel",
 			Handler:       _Lightning_OpenChannel_Handler,
@@ -5157,11 +7794,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SendToRoute",
+			Handler:       _Lightning_SendToRoute_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SubscribeInvoices",
 			Handler:       _Lightning_SubscribeInvoices_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeChannelGraph",
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
@@ -5174,287 +7822,434 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 4501 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7b, 0x5d, 0x6f, 0x1c, 0x4b,
-	0x5a, 0x7f, 0x7a, 0x3c, 0x63, 0xcf, 0x3c, 0x33, 0xe3, 0x97, 0xf2, 0xdb, 0x64, 0x92, 0x93, 0x4d,
-	0x6a, 0xa3, 0x13, 0xff, 0xbd, 0x2b, 0x3b, 0xf1, 0xfe, 0xf7, 0x90, 0x4d, 0x80, 0x23, 0xe7, 0xd5,
-	0x87, 0xf5, 0xc9, 0xf1, 0xb6, 0x73, 0x4e, 0x60, 0x57, 0xa8, 0x69, 0x4f, 0x97, 0xc7, 0xbd, 0xe9,
-	0xe9, 0xee, 0xd3, 0x5d, 0x13, 0x67, 0x36, 0x8a, 0x84, 0x0e, 0x08, 0x6e, 0x40, 0x2b, 0xb4, 0x08,
-	0xc4, 0x0d, 0x5a, 0x09, 0x71, 0x09, 0x5f, 0x80, 0x6f, 0x80, 0x40, 0x42, 0xda, 0x2b, 0x6e, 0xb8,
-	0xe2, 0x0b, 0x70, 0xc1, 0x3d, 0xaa, 0xd7, 0xae, 0xea, 0x6e, 0x27, 0x41, 0x20, 0xae, 0x3c, 0xf5,
-	0xab, 0xa7, 0x9f, 0xaa, 0x7a, 0xea, 0xa9, 0xe7, 0xad, 0xca, 0xd0, 0xc9, 0xd2, 0xd1, 0x4e, 0x9a,
-	0x25, 0x34, 0x41, 0xad, 0x28, 0xce, 0xd2, 0xd1, 0xf0, 0xea, 0x38, 0x49, 0xc6, 0x11, 0xd9, 0xf5,
-	0xd3, 0x70, 0xd7, 0x8f, 0xe3, 0x84, 0xfa, 0x34, 0x4c, 0xe2, 0x5c, 0x10, 0xe1, 0xff, 0x70, 0xa0,
-	0xfb, 0x3c, 0xf3, 0xe3, 0xdc, 0x1f, 0x31, 0x18, 0x0d, 0x60, 0x81, 0xbe, 0xf6, 0xce, 0xfc, 0xfc,
-	0x6c, 0xe0, 0x5c, 0x77, 0xb6, 0x3a, 0xae, 0x6a, 0xa2, 0x0d, 0x98, 0xf7, 0x27, 0xc9, 0x34, 0xa6,
-	0x83, 0xc6, 0x75, 0x67, 0x6b, 0xce, 0x95, 0x2d, 0xf4, 0x5d, 0x58, 0x89, 0xa7, 0x13, 0x6f, 0x94,
-	0xc4, 0xa7, 0x61, 0x36, 0x11, 0xcc, 0x07, 0x73, 0xd7, 0x9d, 0xad, 0x96, 0x5b, 0xed, 0x40, 0xd7,
-	0x00, 0x4e, 0xa2, 0x64, 0xf4, 0x52, 0x0c, 0xd1, 0xe4, 0x43, 0x18, 0x08, 0xc2, 0xd0, 0x93, 0x2d,
-	0x12, 0x8e, 0xcf, 0xe8, 0xa0, 0xc5, 0x19, 0x59, 0x18, 0xe3, 0x41, 0xc3, 0x09, 0xf1, 0x72, 0xea,
-	0x4f, 0xd2, 0xc1, 0x3c, 0x9f, 0x8d, 0x81, 0xf0, 0xfe, 0x84, 0xfa, 0x91, 0x77, 0x4a, 0x48, 0x3e,
-	0x58, 0x90, 0xfd, 0x1a, 0xc1, 0x03, 0xd8, 0x78, 0x4a, 0xa8, 0xb1, 0xea, 0xdc, 0x25, 0x5f, 0x4f,
-	0x49, 0x4e, 0xf1, 0x21, 0x20, 0x03, 0x7e, 0x44, 0xa8, 0x1f, 0x46, 0x39, 0xfa, 0x04, 0x7a, 0xd4,
-	0x20, 0x1e, 0x38, 0xd7, 0xe7, 0xb6, 0xba, 0x7b, 0x68, 0x87, 0xcb, 0x77, 0xc7, 0xf8, 0xc0, 0xb5,
-	0xe8, 0xf0, 0xbf, 0x38, 0xd0, 0x3d, 0x26, 0x71, 0x20, 0xb9, 0x23, 0x04, 0xcd, 0x80, 0xe4, 0x94,
-	0x0b, 0xb6, 0xe7, 0xf2, 0xdf, 0xe8, 0x5b, 0xd0, 0x65, 0x7f, 0xbd, 0x9c, 0x66, 0x61, 0x3c, 0xe6,
-	0xa2, 0xed, 0xb8, 0xc0, 0xa0, 0x63, 0x8e, 0xa0, 0x65, 0x98, 0xf3, 0x27, 0x94, 0x0b, 0x74, 0xce,
-	0x65, 0x3f, 0xd1, 0x0d, 0xe8, 0xa5, 0xfe, 0x6c, 0x42, 0x62, 0x5a, 0x08, 0xb1, 0xe7, 0x76, 0x25,
-	0x76, 0xc0, 0xa4, 0xb8, 0x03, 0xab, 0x26, 0x89, 0xe2, 0xde, 0xe2, 0xdc, 0x57, 0x0c, 0x4a, 0x39,
-	0xc8, 0x2d, 0x58, 0x52, 0xf4, 0x99, 0x98, 0x2c, 0x17, 0x6b, 0xc7, 0x5d, 0x94, 0xb0, 0x12, 0xd0,
-	0x9f, 0x3b, 0xd0, 0x13, 0x4b, 0xca, 0xd3, 0x24, 0xce, 0x09, 0xba, 0x09, 0x7d, 0xf5, 0x25, 0xc9,
-	0xb2, 0x24, 0x93, 0x5a, 0x63, 0x83, 0x68, 0x1b, 0x96, 0x15, 0x90, 0x66, 0x24, 0x9c, 0xf8, 0x63,
-	0xc2, 0x97, 0xda, 0x73, 0x2b, 0x38, 0xda, 0x2b, 0x38, 0x66, 0xc9, 0x94, 0x12, 0xbe, 0xf4, 0xee,
-	0x5e, 0x4f, 0x8a, 0xdb, 0x65, 0x98, 0x6b, 0x93, 0xe0, 0x6f, 0x1c, 0xe8, 0x3d, 0x3c, 0xf3, 0xe3,
-	0x98, 0x44, 0x47, 0x49, 0x18, 0x53, 0xa6, 0x46, 0xa7, 0xd3, 0x38, 0x08, 0xe3, 0xb1, 0x47, 0x5f,
-	0x87, 0x81, 0x14, 0xb9, 0x85, 0xb1, 0x49, 0x99, 0x6d, 0x26, 0x24, 0x29, 0xff, 0x0a, 0xce, 0xf8,
-	0x25, 0x53, 0x9a, 0x4e, 0xa9, 0x17, 0xc6, 0x01, 0x79, 0xcd, 0xe7, 0xd4, 0x77, 0x2d, 0x0c, 0xff,
-	0x26, 0x2c, 0x1f, 0x32, 0xfd, 0x8c, 0xc3, 0x78, 0xbc, 0x1f, 0x04, 0x19, 0xc9, 0x73, 0x76, 0x68,
-	0xd2, 0xe9, 0xc9, 0x4b, 0x32, 0x93, 0x72, 0x91, 0x2d, 0xa6, 0x0a, 0x67, 0x49, 0x4e, 0xe5, 0x78,
-	0xfc, 0x37, 0xfe, 0xa5, 0x03, 0x4b, 0x4c, 0xb6, 0x9f, 0xfb, 0xf1, 0x4c, 0xa9, 0xcc, 0x21, 0xf4,
-	0x18, 0xab, 0xe7, 0xc9, 0xbe, 0x38, 0x7a, 0x42, 0xf5, 0xb6, 0xa4, 0x2c, 0x4a, 0xd4, 0x3b, 0x26,
-	0xe9, 0xe3, 0x98, 0x66, 0x33, 0xd7, 0xfa, 0x7a, 0xf8, 0x29, 0xac, 0x54, 0x48, 0x98, 0x82, 0x15,
-	0xf3, 0x63, 0x3f, 0xd1, 0x1a, 0xb4, 0x5e, 0xf9, 0xd1, 0x94, 0xc8, 0x83, 0x2e, 0x1a, 0xf7, 0x1a,
-	0x77, 0x1d, 0xfc, 0x31, 0x2c, 0x17, 0x63, 0x4a, 0x0d, 0x40, 0xd0, 0xd4, 0x22, 0xee, 0xb8, 0xfc,
-	0x37, 0x13, 0x05, 0xa3, 0x7b, 0x98, 0x84, 0xfa, 0x6c, 0x31, 0x3a, 0x3f, 0x08, 0x94, 0x82, 0xf0,
-	0xdf, 0x17, 0xd9, 0x14, 0x7c, 0x0b, 0x56, 0x8c, 0xef, 0xdf, 0x31, 0xd0, 0x5f, 0x3b, 0xb0, 0xf2,
-	0x8c, 0x9c, 0x4b, 0x71, 0xab, 0xa1, 0xee, 0x42, 0x93, 0xce, 0x52, 0xc2, 0x29, 0x17, 0xf7, 0x6e,
-	0x4a, 0x69, 0x55, 0xe8, 0x76, 0x64, 0xf3, 0xf9, 0x2c, 0x25, 0x2e, 0xff, 0x02, 0x7f, 0x01, 0x5d,
-	0x03, 0x44, 0x9b, 0xb0, 0xfa, 0xe2, 0xb3, 0xe7, 0xcf, 0x1e, 0x1f, 0x1f, 0x7b, 0x47, 0x5f, 0x3e,
-	0xf8, 0xe1, 0xe3, 0xdf, 0xf1, 0x0e, 0xf6, 0x8f, 0x0f, 0x96, 0x2f, 0xa1, 0x0d, 0x40, 0xcf, 0x1e,
-	0x1f, 0x3f, 0x7f, 0xfc, 0xc8, 0xc2, 0x1d, 0xb4, 0x04, 0x5d, 0x13, 0x68, 0xe0, 0x21, 0x0c, 0x9e,
-	0x91, 0xf3, 0x17, 0x21, 0x8d, 0x49, 0x9e, 0xdb, 0xc3, 0xe3, 0x1d, 0x40, 0xe6, 0x9c, 0xe4, 0x32,
-	0x07, 0xb0, 0xe0, 0x0b, 0x48, 0x59, 0x60, 0xd9, 0xc4, 0x1f, 0x03, 0x3a, 0x0e, 0xc7, 0xf1, 0xe7,
-	0x24, 0xcf, 0xfd, 0x31, 0x51, 0x8b, 0x5d, 0x86, 0xb9, 0x49, 0x3e, 0x96, 0x1a, 0xce, 0x7e, 0xe2,
-	0xef, 0xc1, 0xaa, 0x45, 0x27, 0x19, 0x5f, 0x85, 0x4e, 0x1e, 0x8e, 0x63, 0x9f, 0x4e, 0x33, 0x22,
-	0x59, 0x17, 0x00, 0x7e, 0x02, 0x6b, 0x5f, 0x91, 0x2c, 0x3c, 0x9d, 0xbd, 0x8f, 0xbd, 0xcd, 0xa7,
-	0x51, 0xe6, 0xf3, 0x18, 0xd6, 0x4b, 0x7c, 0xe4, 0xf0, 0x42, 0xab, 0xe4, 0xfe, 0xb5, 0x5d, 0xd1,
-	0x30, 0x0e, 0x48, 0xc3, 0x3c, 0x20, 0xf8, 0x4b, 0x40, 0x0f, 0x93, 0x38, 0x26, 0x23, 0x7a, 0x44,
-	0x48, 0xa6, 0x26, 0xf3, 0x1d, 0x43, 0x87, 0xba, 0x7b, 0x9b, 0x72, 0x63, 0xcb, 0xa7, 0x4e, 0x2a,
-	0x17, 0x82, 0x66, 0x4a, 0xb2, 0x09, 0x67, 0xdc, 0x76, 0xf9, 0x6f, 0xbc, 0x0b, 0xab, 0x16, 0xdb,
-	0x42, 0xe6, 0x29, 0x21, 0x99, 0x27, 0x67, 0xd7, 0x72, 0x55, 0x13, 0xdf, 0x81, 0xf5, 0x47, 0x61,
-	0x3e, 0xaa, 0x4e, 0x85, 0x7d, 0x32, 0x3d, 0xf1, 0x8a, 0xa3, 0xa3, 0x9a, 0xcc, 0xbd, 0x94, 0x3f,
-	0x11, 0xc3, 0xe0, 0x3f, 0x72, 0xa0, 0x79, 0xf0, 0xfc, 0xf0, 0x21, 0x1a, 0x42, 0x3b, 0x8c, 0x47,
-	0xc9, 0x84, 0x19, 0x65, 0x21, 0x0e, 0xdd, 0xbe, 0xd0, 0xcf, 0x5e, 0x85, 0x0e, 0xb7, 0xe5, 0xcc,
-	0x13, 0x72, 0xfb, 0xd3, 0x73, 0x0b, 0x80, 0x79, 0x61, 0xf2, 0x3a, 0x0d, 0x33, 0xee, 0x66, 0x95,
-	0xf3, 0x6c, 0x72, 0x2b, 0x55, 0xed, 0xc0, 0xff, 0xd4, 0x84, 0xfe, 0xfe, 0x88, 0x86, 0xaf, 0x88,
-	0xb4, 0x9a, 0x7c, 0x54, 0x0e, 0xc8, 0xf9, 0xc8, 0x16, 0xb3, 0xef, 0x19, 0x99, 0x24, 0x94, 0x78,
-	0xd6, 0x36, 0xd9, 0x20, 0xa3, 0x1a, 0x09, 0x46, 0x5e, 0xca, 0xec, 0x2f, 0x9f, 0x5f, 0xc7, 0xb5,
-	0x41, 0x26, 0x32, 0x06, 0x30, 0x29, 0xb3, 0x99, 0x35, 0x5d, 0xd5, 0x64, 0xf2, 0x18, 0xf9, 0xa9,
-	0x3f, 0x0a, 0xe9, 0x8c, 0x3b, 0xa9, 0x39, 0x57, 0xb7, 0x19, 0xef, 0x28, 0x19, 0xf9, 0x91, 0x77,
-	0xe2, 0x47, 0x7e, 0x3c, 0x22, 0xd2, 0xe1, 0xdb, 0x20, 0xfa, 0x18, 0x16, 0xe5, 0x94, 0x14, 0x99,
-	0xf0, 0xfb, 0x25, 0x94, 0xc5, 0x06, 0xa3, 0x64, 0x32, 0x09, 0x29, 0x0b, 0x05, 0x06, 0x6d, 0x11,
-	0x1b, 0x14, 0x08, 0x5f, 0x89, 0x68, 0x9d, 0x0b, 0x19, 0x76, 0xc4, 0x68, 0x16, 0xc8, 0xb8, 0x9c,
-	0x12, 0xe2, 0xa5, 0x24, 0xf3, 0x5e, 0x9e, 0x0f, 0x40, 0x70, 0x29, 0x10, 0xb6, 0x1b, 0xd3, 0x38,
-	0x27, 0x94, 0x46, 0x24, 0xd0, 0x13, 0xea, 0x72, 0xb2, 0x6a, 0x07, 0xba, 0x0d, 0xab, 0x22, 0x3a,
-	0xc9, 0x7d, 0x9a, 0xe4, 0x67, 0x61, 0xee, 0xe5, 0x24, 0xa6, 0x83, 0x1e, 0xa7, 0xaf, 0xeb, 0x42,
-	0x77, 0x61, 0xb3, 0x04, 0x67, 0x64, 0x44, 0xc2, 0x57, 0x24, 0x18, 0xf4, 0xf9, 0x57, 0x17, 0x75,
-	0xa3, 0xeb, 0xd0, 0x65, 0x41, 0xd9, 0x34, 0x0d, 0x7c, 0x4a, 0xf2, 0xc1, 0x22, 0xdf, 0x07, 0x13,
-	0x42, 0x77, 0xa0, 0x9f, 0x12, 0xe1, 0xfe, 0xce, 0x68, 0x34, 0xca, 0x07, 0x4b, 0xdc, 0xe7, 0x74,
-	0xe5, 0x61, 0x63, 0xfa, 0xeb, 0xda, 0x14, 0x78, 0x1d, 0x56, 0x0f, 0xc3, 0x9c, 0x4a, 0x5d, 0xd2,
-	0xf6, 0xed, 0x00, 0xd6, 0x6c, 0x58, 0x9e, 0xb6, 0xdb, 0xd0, 0x96, 0x8a, 0x91, 0x0f, 0xba, 0x9c,
-	0xf9, 0x9a, 0x64, 0x6e, 0xe9, 0xa4, 0xab, 0xa9, 0xf0, 0x1f, 0x36, 0xa0, 0xc9, 0x4e, 0xd2, 0xc5,
-	0xa7, 0xce, 0x3c, 0xc2, 0x0d, 0xeb, 0x08, 0x9b, 0x06, 0x75, 0xce, 0x32, 0xa8, 0x3c, 0x18, 0x9d,
-	0x51, 0x22, 0xe5, 0x2d, 0x74, 0xd2, 0x40, 0x8a, 0xfe, 0x8c, 0x8c, 0x5e, 0x71, 0xc5, 0xd4, 0xfd,
-	0x0c, 0x61, 0x6a, 0x9b, 0xfb, 0x54, 0x7c, 0x2d, 0xb4, 0x52, 0xb7, 0x55, 0x1f, 0xff, 0x72, 0xa1,
-	0xe8, 0xe3, 0xdf, 0x0d, 0x60, 0x21, 0x8c, 0x4f, 0x92, 0x69, 0x1c, 0x70, 0x0d, 0x6c, 0xbb, 0xaa,
-	0xc9, 0x0e, 0x79, 0xca, 0x03, 0x8f, 0x70, 0x42, 0xa4, 0xea, 0x15, 0x00, 0x46, 0x2c, 0xc2, 0xc8,
-	0xb9, 0x4d, 0xd1, 0x42, 0xfe, 0x04, 0x56, 0x0c, 0x4c, 0x4a, 0xf8, 0x06, 0xb4, 0xd8, 0xea, 0x55,
-	0xa8, 0xaa, 0xf6, 0x8e, 0x1b, 0x23, 0xd1, 0x83, 0x97, 0x61, 0xf1, 0x29, 0xa1, 0x9f, 0xc5, 0xa7,
-	0x89, 0xe2, 0xf4, 0x9f, 0x0d, 0x58, 0xd2, 0x90, 0x64, 0xb4, 0x05, 0x4b, 0x61, 0x40, 0x62, 0x1a,
-	0xd2, 0x99, 0x67, 0x05, 0x32, 0x65, 0x98, 0x99, 0x77, 0x3f, 0x0a, 0xfd, 0x5c, 0x1a, 0x08, 0xd1,
-	0x40, 0x7b, 0xb0, 0xc6, 0x74, 0x4b, 0xa9, 0x8b, 0xde, 0x76, 0x11, 0x3f, 0xd5, 0xf6, 0xb1, 0xe3,
-	0xc0, 0x70, 0x61, 0x80, 0x8a, 0x4f, 0x84, 0x31, 0xab, 0xeb, 0x62, 0x52, 0x13, 0x9c, 0xd8, 0x92,
-	0x5b, 0x9c, 0xae, 0x00, 0x2a, 0x29, 0xc5, 0xbc, 0x88, 0xdd, 0xca, 0x29, 0x85, 0x91, 0x96, 0xb4,
-	0x2b, 0x69, 0xc9, 0x16, 0x2c, 0xe5, 0xb3, 0x78, 0x44, 0x02, 0x8f, 0x26, 0x6c, 0xdc, 0x30, 0xe6,
-	0xbb, 0xd3, 0x76, 0xcb, 0x30, 0x4f, 0xa0, 0x48, 0x4e, 0x63, 0x42, 0xb9, 0x5d, 0x68, 0xbb, 0xaa,
-	0xc9, 0x4c, 0x2c, 0x27, 0x11, 0x4a, 0xdf, 0x71, 0x65, 0x0b, 0xff, 0x8c, 0xbb, 0x3a, 0x9d, 0x23,
-	0x7d, 0xc9, 0xcf, 0x21, 0xba, 0x02, 0x1d, 0x31, 0x7e, 0x7e, 0xe6, 0x4b, 0xef, 0xdb, 0xe6, 0xc0,
-	0xf1, 0x99, 0xcf, 0x52, 0x00, 0x6b, 0x49, 0x42, 0xe3, 0xbb, 0x1c, 0x3b, 0x10, 0x2b, 0xba, 0x09,
-	0x8b, 0x2a, 0xfb, 0xca, 0xbd, 0x88, 0x9c, 0x52, 0x15, 0xb3, 0xc6, 0xd3, 0x09, 0x1b, 0x2e, 0x3f,
-	0x24, 0xa7, 0x14, 0x3f, 0x83, 0x15, 0x79, 0xda, 0xbe, 0x48, 0x89, 0x1a, 0xfa, 0x07, 0x65, 0x6b,
-	0x2e, 0xdc, 0xed, 0xaa, 0xd4, 0x22, 0x33, 0xd0, 0x2e, 0x99, 0x78, 0xec, 0x02, 0x92, 0xdd, 0x0f,
-	0xa3, 0x24, 0x27, 0x92, 0x21, 0x86, 0xde, 0x28, 0x4a, 0xf2, 0x72, 0x34, 0x6e, 0x62, 0x4c, 0x6e,
-	0xf9, 0x74, 0x34, 0x62, 0xa7, 0x54, 0x38, 0x6c, 0xd5, 0xc4, 0x04, 0x56, 0x39, 0x33, 0x65, 0x16,
-	0x74, 0x90, 0xf7, 0xe1, 0xb3, 0xec, 0x8d, 0xcc, 0xe4, 0x60, 0x0d, 0x5a, 0xa7, 0x49, 0x36, 0x22,
-	0x72, 0x20, 0xd1, 0xc0, 0xff, 0xea, 0xc0, 0x0a, 0x1f, 0xe7, 0x98, 0xfa, 0x74, 0x9a, 0xcb, 0xa9,
-	0xff, 0x3a, 0xf4, 0xd9, 0x34, 0x89, 0x52, 0x53, 0x39, 0xca, 0x9a, 0x3e, 0x51, 0x1c, 0x15, 0xc4,
-	0x07, 0x97, 0x5c, 0x9b, 0x18, 0x7d, 0x0a, 0x3d, 0x33, 0xfd, 0xe5, 0x03, 0x76, 0xf7, 0x2e, 0xab,
-	0x29, 0x56, 0x76, 0xfd, 0xe0, 0x92, 0x6b, 0x7d, 0x80, 0xee, 0x03, 0x70, 0x1f, 0xc9, 0xd9, 0xca,
-	0x4c, 0xe8, 0xb2, 0xbd, 0x42, 0x43, 0xd0, 0x07, 0x97, 0x5c, 0x83, 0xfc, 0x41, 0x1b, 0xe6, 0x85,
-	0x51, 0xc7, 0x4f, 0xa1, 0x6f, 0xcd, 0xd4, 0x8a, 0xa5, 0x7b, 0x22, 0x96, 0xae, 0xe4, 0x38, 0x8d,
-	0x9a, 0x1c, 0xe7, 0xdf, 0x1c, 0x40, 0x4c, 0x53, 0x4a, 0x7b, 0xf1, 0x31, 0x2c, 0x52, 0x3f, 0x1b,
-	0x13, 0xea, 0xd9, 0x61, 0x54, 0x09, 0xe5, 0xde, 0x27, 0x09, 0xac, 0x58, 0xa2, 0xe7, 0x9a, 0x10,
-	0xda, 0x01, 0x64, 0x34, 0x55, 0xe2, 0x2a, 0xec, 0x76, 0x4d, 0x0f, 0x33, 0x30, 0x22, 0x10, 0x50,
-	0x29, 0x9b, 0x8c, 0x9d, 0x9a, 0xdc, 0x76, 0xd6, 0xf6, 0x31, 0xd3, 0x9c, 0x4e, 0x59, 0x56, 0xec,
-	0x53, 0x15, 0x6d, 0xa8, 0x36, 0xfe, 0x95, 0x03, 0xcb, 0x6c, 0x81, 0x96, 0x12, 0xdc, 0x03, 0xae,
-	0x40, 0x1f, 0xa8, 0x03, 0x16, 0xed, 0xff, 0x5c, 0x05, 0xee, 0x42, 0x87, 0x33, 0x4c, 0x52, 0x12,
-	0x4b, 0x0d, 0x18, 0xd8, 0x1a, 0x50, 0x1c, 0xdd, 0x83, 0x4b, 0x6e, 0x41, 0x6c, 0xec, 0xff, 0x26,
-	0xac, 0xcb, 0x59, 0xda, 0x1b, 0x87, 0xff, 0x18, 0x60, 0xa3, 0xdc, 0xa3, 0xbd, 0xb4, 0x0c, 0x3d,
-	0xa2, 0x70, 0x72, 0x92, 0xe8, 0x28, 0xc6, 0x31, 0xa3, 0x12, 0xab, 0x0b, 0x9d, 0xc2, 0xba, 0x32,
-	0xe6, 0x6c, 0xfc, 0xc2, 0x74, 0x37, 0xb8, 0x17, 0xba, 0x6d, 0xcb, 0xab, 0x34, 0x9e, 0x82, 0x4d,
-	0xed, 0xaa, 0x67, 0x87, 0xc6, 0x30, 0xd0, 0x4e, 0x43, 0x9a, 0x10, 0xc3, 0xb1, 0xb0, 0xa1, 0xbe,
-	0xf3, 0xee, 0xa1, 0xf8, 0x91, 0x09, 0x14, 0x7a, 0x21, 0x33, 0xf4, 0x1a, 0xae, 0xa9, 0x3e, 0x6e,
-	0x23, 0xaa, 0xc3, 0x35, 0x3f, 0x64, 0x65, 0x4f, 0xd8, 0xb7, 0xf6, 0x98, 0xef, 0xe1, 0x3b, 0xfc,
-	0x47, 0x07, 0x16, 0x6d, 0x6e, 0xcc, 0x05, 0xc9, 0x58, 0x56, 0x1d, 0x03, 0xe5, 0x8a, 0x4b, 0x70,
-	0x35, 0x1a, 0x6f, 0xd4, 0x45, 0xe3, 0x66, 0xcc, 0x3d, 0xf7, 0xbe, 0x98, 0xbb, 0xf9, 0x61, 0x31,
-	0x77, 0xab, 0x2e, 0xe6, 0x1e, 0xfe, 0xb2, 0x01, 0xa8, 0xba, 0xbb, 0xe8, 0x89, 0x48, 0x07, 0x62,
-	0x12, 0xc9, 0x03, 0xf5, 0xdd, 0x0f, 0x52, 0x10, 0x05, 0xab, 0x8f, 0x99, 0xa2, 0x9a, 0x07, 0xc6,
-	0xf4, 0x89, 0x7d, 0xb7, 0xae, 0x0b, 0x6d, 0xc3, 0x32, 0x77, 0x95, 0xb9, 0x47, 0xc3, 0x28, 0x2a,
-	0x4e, 0x56, 0xdf, 0xad, 0xe0, 0xa5, 0x84, 0xa1, 0xf9, 0xfe, 0x84, 0xa1, 0xf5, 0xfe, 0x84, 0x61,
-	0xbe, 0x9c, 0x30, 0x0c, 0xdf, 0x40, 0xdf, 0x52, 0x90, 0xff, 0x35, 0xe1, 0x94, 0x5d, 0xaf, 0x50,
-	0x05, 0x0b, 0x1b, 0x7e, 0xd3, 0x00, 0x54, 0xd5, 0xd1, 0xff, 0xcb, 0x29, 0x70, 0x85, 0xb3, 0xcc,
-	0xcc, 0x9c, 0x54, 0x38, 0xcb, 0xc0, 0x6c, 0xc1, 0xd2, 0xc4, 0xa7, 0xd3, 0x8c, 0x85, 0x9d, 0x56,
-	0x8a, 0x5b, 0x86, 0x99, 0x4e, 0x14, 0x3b, 0xe9, 0xa9, 0x5e, 0x19, 0x1b, 0xd6, 0x75, 0xe1, 0x1f,
-	0xc0, 0xda, 0x0b, 0x3f, 0x8a, 0x08, 0x7d, 0x20, 0x06, 0x53, 0xae, 0xed, 0x06, 0xf4, 0xce, 0x45,
-	0xf5, 0xc6, 0x4b, 0xe2, 0x68, 0x26, 0xd3, 0xe3, 0xae, 0xc4, 0xbe, 0x88, 0xa3, 0x19, 0xbe, 0x03,
-	0xeb, 0xa5, 0x4f, 0x8b, 0xb2, 0x82, 0x6d, 0x36, 0x55, 0x93, 0x19, 0x64, 0x29, 0x27, 0x7b, 0x38,
-	0xbc, 0x07, 0x1b, 0xe5, 0x8e, 0xf7, 0x32, 0xfb, 0x14, 0xd0, 0x8f, 0xa6, 0x24, 0x9b, 0xf1, 0xd2,
-	0xa8, 0x2e, 0x82, 0x6d, 0x96, 0x53, 0xa5, 0xf9, 0x74, 0x7a, 0xf2, 0x43, 0x32, 0x53, 0x15, 0xe5,
-	0x86, 0xae, 0x28, 0xe3, 0xfb, 0xb0, 0x6a, 0x31, 0xd0, 0xb5, 0xdd, 0x79, 0x5e, 0x5e, 0x55, 0x69,
-	0x84, 0x5d, 0x82, 0x95, 0x7d, 0xf8, 0x2f, 0x1d, 0x98, 0x3b, 0x48, 0x52, 0x33, 0xbb, 0x77, 0xec,
-	0xec, 0x5e, 0xda, 0x23, 0x4f, 0x9b, 0x9b, 0x86, 0x3c, 0x22, 0x26, 0xc8, 0xac, 0x89, 0x3f, 0xa1,
-	0x2c, 0x90, 0x3e, 0x4d, 0xb2, 0x73, 0x3f, 0x0b, 0xa4, 0x0e, 0x94, 0x50, 0x36, 0xfd, 0xe2, 0x24,
-	0xb2, 0x9f, 0x2c, 0xb0, 0xe6, 0x25, 0x0e, 0xb5, 0xbf, 0xb2, 0x85, 0x7f, 0xee, 0x40, 0x8b, 0xcf,
-	0x95, 0x29, 0x8e, 0x70, 0x58, 0xfc, 0x96, 0x80, 0x57, 0x50, 0x1c, 0xa1, 0x38, 0x25, 0xb8, 0x74,
-	0x77, 0xd0, 0x28, 0xdf, 0x1d, 0xb0, 0x54, 0x43, 0xb4, 0x8a, 0xa2, 0x7c, 0x01, 0xa0, 0x6b, 0xd0,
-	0x3c, 0x4b, 0x52, 0xe5, 0x16, 0x40, 0xa5, 0xcc, 0x49, 0xea, 0x72, 0x1c, 0x6f, 0xc3, 0xd2, 0xb3,
-	0x24, 0x20, 0x46, 0xd6, 0x75, 0xe1, 0x36, 0xe1, 0xdf, 0x77, 0xa0, 0xad, 0x88, 0xd1, 0x16, 0x34,
-	0x99, 0x79, 0x2f, 0x45, 0x1e, 0xba, 0xf0, 0xc5, 0xe8, 0x5c, 0x4e, 0xc1, 0x4e, 0x1b, 0x8f, 0xfb,
-	0x0b, 0xdf, 0xab, 0xa2, 0xfe, 0xc2, 0xaf, 0xb1, 0x70, 0x8d, 0xcf, 0xb9, 0xe4, 0x00, 0x4a, 0x28,
-	0xfe, 0x85, 0x03, 0x7d, 0x6b, 0x0c, 0x16, 0xc0, 0x45, 0x7e, 0x4e, 0x65, 0xb1, 0x40, 0x0a, 0xd1,
-	0x84, 0xcc, 0x0c, 0xbd, 0x61, 0x67, 0xe8, 0x3a, 0x43, 0x9c, 0x33, 0x33, 0xc4, 0xdb, 0xd0, 0x91,
-	0xe9, 0x38, 0x51, 0x72, 0x53, 0x37, 0x2b, 0x6c, 0x44, 0x55, 0xd2, 0x2b, 0x88, 0xf0, 0x7d, 0xe8,
-	0x1a, 0x3d, 0x6c, 0xc0, 0x98, 0xd0, 0xf3, 0x24, 0x7b, 0xa9, 0x4a, 0x02, 0xb2, 0xa9, 0x2b, 0xce,
-	0x8d, 0xa2, 0xe2, 0x8c, 0xff, 0xce, 0x81, 0x3e, 0xd3, 0x89, 0x30, 0x1e, 0x1f, 0x25, 0x51, 0x38,
-	0x9a, 0x71, 0xdd, 0x50, 0xdb, 0xef, 0x05, 0x24, 0xa2, 0xbe, 0xd6, 0x0d, 0x1b, 0x66, 0x1e, 0x73,
-	0x12, 0xc6, 0xbc, 0xe6, 0x21, 0x35, 0x43, 0xb7, 0x99, 0x8e, 0x33, 0x73, 0x7e, 0xe2, 0xe7, 0xc4,
-	0x9b, 0xb0, 0xc0, 0x52, 0x1a, 0x30, 0x0b, 0x64, 0x66, 0x89, 0x01, 0x99, 0x4f, 0x89, 0x37, 0x09,
-	0xa3, 0x28, 0x14, 0xb4, 0x42, 0x97, 0xeb, 0xba, 0xf0, 0x3f, 0x34, 0xa0, 0x2b, 0x0d, 0xc2, 0xe3,
-	0x60, 0x2c, 0xea, 0x57, 0xd2, 0x8d, 0xeb, 0x83, 0x66, 0x20, 0xaa, 0xdf, 0x72, 0xfc, 0x06, 0x52,
-	0xde, 0xc0, 0xb9, 0xea, 0x06, 0xb2, 0x64, 0x3a, 0x09, 0xc8, 0x1d, 0x1e, 0x61, 0x88, 0x0b, 0xba,
-	0x02, 0x50, 0xbd, 0x7b, 0xbc, 0xb7, 0x55, 0xf4, 0x72, 0xc0, 0x8a, 0x29, 0xe6, 0x4b, 0x31, 0xc5,
-	0x5d, 0xe8, 0x49, 0x36, 0x5c, 0xee, 0xbc, 0x28, 0x52, 0xa8, 0xb2, 0xb5, 0x27, 0xae, 0x45, 0xa9,
-	0xbe, 0xdc, 0x53, 0x5f, 0xb6, 0xdf, 0xf7, 0xa5, 0xa2, 0xc4, 0xeb, 0xb0, 0x2a, 0x85, 0xf7, 0x34,
-	0xf3, 0xd3, 0x33, 0x65, 0x64, 0x03, 0x7d, 0x5b, 0xc4, 0x61, 0xb4, 0x0d, 0x2d, 0xf6, 0x99, 0xb2,
-	0x73, 0xf5, 0xc7, 0x4b, 0x90, 0xa0, 0x2d, 0x68, 0x91, 0x60, 0x4c, 0x54, 0x50, 0x8b, 0xec, 0x50,
-	0x9c, 0xed, 0x91, 0x2b, 0x08, 0xd8, 0x61, 0x67, 0x68, 0xe9, 0xb0, 0xdb, 0x36, 0x72, 0x9e, 0x35,
-	0x3f, 0x0b, 0xf0, 0x1a, 0xa0, 0x67, 0x42, 0x6b, 0xcd, 0x8a, 0xcc, 0x1f, 0xcc, 0x41, 0xd7, 0x80,
-	0xd9, 0xb9, 0x1d, 0xb3, 0x09, 0x7b, 0x41, 0xe8, 0x4f, 0x08, 0x25, 0x99, 0xd4, 0xd4, 0x12, 0xca,
-	0x4d, 0xe9, 0xab, 0xb1, 0x97, 0x4c, 0xa9, 0x17, 0x90, 0x71, 0x46, 0x44, 0xa6, 0xeb, 0xb8, 0x25,
-	0x94, 0xd1, 0x4d, 0xfc, 0xd7, 0x26, 0x9d, 0xd0, 0x87, 0x12, 0xaa, 0xea, 0x2b, 0x42, 0x46, 0xcd,
-	0xa2, 0xbe, 0x22, 0x24, 0x52, 0xb6, 0x38, 0xad, 0x1a, 0x8b, 0xf3, 0x09, 0x6c, 0x08, 0xdb, 0x22,
-	0xcf, 0xa6, 0x57, 0x52, 0x93, 0x0b, 0x7a, 0x59, 0xa4, 0xc6, 0xe6, 0xac, 0x14, 0x3c, 0x0f, 0x7f,
-	0x26, 0x0a, 0xbb, 0x8e, 0x5b, 0xc1, 0x19, 0x2d, 0x3b, 0x8e, 0x16, 0xad, 0x28, 0xf0, 0x56, 0x70,
-	0x4e, 0xeb, 0xbf, 0xb6, 0x69, 0x3b, 0x92, 0xb6, 0x84, 0xe3, 0x3e, 0x74, 0x8f, 0x69, 0x92, 0xaa,
-	0x4d, 0x59, 0x84, 0x9e, 0x68, 0xca, 0xa2, 0xfe, 0x15, 0xb8, 0xcc, 0xb5, 0xe8, 0x79, 0x92, 0x26,
-	0x51, 0x32, 0x9e, 0x1d, 0x4f, 0x4f, 0xf2, 0x51, 0x16, 0xa6, 0x2c, 0xe0, 0xc4, 0xff, 0xec, 0xc0,
-	0xaa, 0xd5, 0x2b, 0x33, 0xca, 0xff, 0x2f, 0x54, 0x5a, 0xd7, 0x61, 0x85, 0xe2, 0xad, 0x18, 0x86,
-	0x4f, 0x10, 0x8a, 0xe4, 0xf8, 0x4b, 0x59, 0x9a, 0xdd, 0x87, 0x25, 0x35, 0x33, 0xf5, 0xa1, 0xd0,
-	0xc2, 0x41, 0x55, 0x0b, 0xe5, 0xf7, 0x8b, 0xf2, 0x03, 0xc5, 0xe2, 0x37, 0x44, 0x30, 0x46, 0x02,
-	0xbe, 0x46, 0x95, 0x2f, 0x0d, 0xd5, 0xf7, 0x66, 0x00, 0xa8, 0x66, 0x30, 0xd2, 0x60, 0x8e, 0xff,
-	0xc4, 0x01, 0x28, 0x66, 0xc7, 0x14, 0xa3, 0x30, 0xde, 0x0e, 0xaf, 0x6a, 0x15, 0x00, 0x0b, 0x9d,
-	0x74, 0x95, 0xb0, 0xf0, 0x07, 0x5d, 0x85, 0xb1, 0x58, 0xe4, 0x16, 0x2c, 0x8d, 0xa3, 0xe4, 0x84,
-	0x7b, 0x57, 0x7e, 0x7f, 0x94, 0xcb, 0xab, 0x8d, 0x45, 0x01, 0x3f, 0x91, 0x68, 0xe1, 0x3c, 0x9a,
-	0x86, 0xf3, 0xc0, 0x7f, 0xda, 0xd0, 0xf5, 0xab, 0x62, 0xcd, 0x17, 0x9e, 0x32, 0xb4, 0x57, 0x31,
-	0x8e, 0x17, 0xd4, 0x8b, 0x78, 0x12, 0x7d, 0xf4, 0xde, 0x34, 0xe9, 0x3e, 0x2c, 0x66, 0xc2, 0xfa,
-	0x28, 0xd3, 0xd4, 0x7c, 0x87, 0x69, 0xea, 0x67, 0x96, 0xdf, 0xf9, 0x7f, 0xb0, 0xec, 0x07, 0xaf,
-	0x48, 0x46, 0x43, 0x1e, 0x06, 0x73, 0xf7, 0x2e, 0x0c, 0xea, 0x92, 0x81, 0x73, 0xaf, 0x7b, 0x0b,
-	0x96, 0xe4, 0x75, 0x92, 0xa6, 0x94, 0xd7, 0xf3, 0x05, 0xcc, 0x08, 0xf1, 0xdf, 0x38, 0xb2, 0x56,
-	0x66, 0xef, 0xe1, 0xc5, 0x12, 0x31, 0x57, 0xd7, 0x28, 0xad, 0xee, 0xdb, 0xb2, 0xf4, 0x15, 0xa8,
-	0x58, 0x5b, 0x16, 0x10, 0x05, 0x28, 0xcb, 0x8c, 0xb6, 0x48, 0x9b, 0x1f, 0x22, 0x52, 0xbc, 0x03,
-	0x4b, 0xc7, 0x84, 0xee, 0xb3, 0x1d, 0x54, 0x86, 0xf1, 0x0a, 0x74, 0x62, 0x72, 0xee, 0x89, 0x2d,
-	0x16, 0x6e, 0xbc, 0x1d, 0x93, 0x73, 0x4e, 0x83, 0x11, 0x2c, 0x17, 0xf4, 0xf2, 0xd4, 0xfd, 0x59,
-	0x03, 0x16, 0x3e, 0x8b, 0x5f, 0x25, 0xe1, 0x88, 0x17, 0xb3, 0x26, 0x64, 0x92, 0xa8, 0x8b, 0x61,
-	0xf6, 0x9b, 0x45, 0x05, 0xfc, 0xce, 0x23, 0xa5, 0xb2, 0xca, 0xa4, 0x9a, 0xcc, 0x43, 0x66, 0xc5,
-	0x2b, 0x04, 0xa1, 0x6d, 0x06, 0xc2, 0xa2, 0xc9, 0xcc, 0x7c, 0x58, 0x21, 0x5b, 0xc5, 0xad, 0x78,
-	0xcb, 0xb8, 0x15, 0xe7, 0x65, 0x4b, 0x71, 0x9d, 0xc3, 0xb7, 0xa4, 0xed, 0xaa, 0x26, 0x8f, 0x7a,
-	0x33, 0x22, 0xf2, 0x4e, 0xee, 0x6b, 0x17, 0x64, 0xd4, 0x6b, 0x82, 0xcc, 0x1f, 0x8b, 0x0f, 0x04,
-	0x8d, 0xb0, 0x57, 0x26, 0xc4, 0xe2, 0x93, 0xf2, 0xdb, 0x8c, 0x8e, 0x50, 0x93, 0x12, 0x8c, 0xbf,
-	0x02, 0xb4, 0x1f, 0x04, 0x52, 0x2a, 0x3a, 0x8a, 0x2f, 0xd6, 0xe3, 0x58, 0xeb, 0xa9, 0xe1, 0xdb,
-	0xa8, 0xe7, 0xfb, 0x18, 0xba, 0x47, 0xc6, 0xe3, 0x12, 0x2e, 0x40, 0xf5, 0xac, 0x44, 0x0a, 0xdd,
-	0x40, 0x8c, 0x01, 0x1b, 0xe6, 0x80, 0xf8, 0xd7, 0x00, 0x1d, 0x86, 0x39, 0xd5, 0xf3, 0xd3, 0xf9,
-	0x95, 0xae, 0xf2, 0x18, 0xf9, 0x95, 0xc4, 0x78, 0x7e, 0xb5, 0x2f, 0xae, 0x97, 0xca, 0x0b, 0xdb,
-	0x86, 0x76, 0x28, 0x20, 0x65, 0x3f, 0x17, 0xa5, 0xe2, 0x29, 0x4a, 0xdd, 0xcf, 0x02, 0x01, 0x09,
-	0x5a, 0xe6, 0xf9, 0xe7, 0x0e, 0x2c, 0xc8, 0xa5, 0x31, 0x37, 0x66, 0x3d, 0xab, 0x11, 0x0b, 0xb3,
-	0xb0, 0xfa, 0x97, 0x11, 0xd5, 0x9d, 0x9e, 0xab, 0xdb, 0x69, 0x04, 0xcd, 0xd4, 0xa7, 0x67, 0x3c,
-	0xc6, 0xed, 0xb8, 0xfc, 0xb7, 0xca, 0x65, 0x5a, 0x3a, 0x97, 0x51, 0x57, 0x69, 0x72, 0x52, 0xfa,
-	0x96, 0xe7, 0x81, 0xb8, 0x4a, 0x2b, 0xe0, 0x42, 0x06, 0x72, 0x82, 0x65, 0x19, 0x48, 0x52, 0x57,
-	0xf7, 0xe3, 0x21, 0x0c, 0x1e, 0x91, 0x88, 0x50, 0xb2, 0x1f, 0x45, 0x65, 0xfe, 0x57, 0xe0, 0x72,
-	0x4d, 0x9f, 0x3c, 0x6b, 0x4f, 0x60, 0xe5, 0x11, 0x39, 0x99, 0x8e, 0x0f, 0xc9, 0xab, 0xa2, 0xe4,
-	0x8b, 0xa0, 0x99, 0x9f, 0x25, 0xe7, 0x72, 0xbf, 0xf8, 0x6f, 0xf4, 0x11, 0x40, 0xc4, 0x68, 0xbc,
-	0x3c, 0x25, 0x23, 0xf5, 0x34, 0x80, 0x23, 0xc7, 0x29, 0x19, 0xe1, 0x4f, 0x00, 0x99, 0x7c, 0xe4,
-	0x12, 0xd8, 0x09, 0x98, 0x9e, 0x78, 0xf9, 0x2c, 0xa7, 0x64, 0xa2, 0x0e, 0xbf, 0x09, 0xe1, 0x5b,
-	0xd0, 0x3b, 0xf2, 0x67, 0x2e, 0xf9, 0x5a, 0xbe, 0x56, 0x62, 0x29, 0x93, 0x3f, 0x63, 0xea, 0xa9,
-	0x53, 0x26, 0xde, 0x8d, 0x33, 0x98, 0x17, 0x84, 0x8c, 0x69, 0x40, 0x72, 0x1a, 0xc6, 0xa2, 0xe8,
-	0x2a, 0x99, 0x1a, 0x50, 0x65, 0xbb, 0x1b, 0x35, 0xdb, 0x2d, 0x23, 0x1b, 0x75, 0x8b, 0x2a, 0xf7,
-	0xd5, 0xc2, 0x98, 0x71, 0x7a, 0x42, 0x88, 0x4b, 0xd2, 0x24, 0xd3, 0xaf, 0xa4, 0xfe, 0xca, 0x81,
-	0x65, 0x69, 0xfc, 0x74, 0x1f, 0xba, 0x61, 0x59, 0x4a, 0xa7, 0xae, 0x24, 0x77, 0x13, 0xfa, 0x3c,
-	0x57, 0x60, 0x89, 0x00, 0x4f, 0x0c, 0x64, 0xa2, 0x6c, 0x81, 0x6c, 0x6d, 0xaa, 0x72, 0x34, 0x09,
-	0x23, 0x39, 0x29, 0x13, 0x62, 0x56, 0x5d, 0xe5, 0x12, 0xdc, 0x88, 0x39, 0xae, 0x6e, 0xe3, 0x23,
-	0x58, 0x31, 0xe6, 0x2b, 0xf7, 0xe0, 0x3e, 0xa8, 0x1b, 0x12, 0x91, 0xf7, 0x0a, 0x55, 0xda, 0xb4,
-	0xed, 0x78, 0xf1, 0x99, 0x45, 0x8c, 0xff, 0xde, 0xe1, 0x22, 0x90, 0xe1, 0x82, 0x7e, 0x1e, 0x31,
-	0x2f, 0x3c, 0xb8, 0x50, 0x90, 0x83, 0x4b, 0xae, 0x6c, 0xa3, 0xef, 0x7f, 0xa0, 0x13, 0xd6, 0x97,
-	0x19, 0x17, 0xc8, 0x66, 0xae, 0x4e, 0x36, 0xef, 0x58, 0xf9, 0x83, 0x05, 0x68, 0xe5, 0xa3, 0x24,
-	0x25, 0x78, 0x95, 0x8b, 0x40, 0xcd, 0x57, 0x88, 0x60, 0xef, 0x6f, 0xaf, 0x40, 0x47, 0x07, 0xfc,
-	0xe8, 0xa7, 0xd0, 0xb7, 0x4a, 0x3a, 0xe8, 0x8a, 0x9c, 0x61, 0x5d, 0x8d, 0x68, 0x78, 0xb5, 0xbe,
-	0x53, 0x1e, 0x9f, 0x6b, 0xdf, 0xfc, 0xea, 0xdf, 0x7f, 0xd1, 0x18, 0xa0, 0x8d, 0xdd, 0x57, 0x77,
-	0x76, 0x65, 0xcd, 0x66, 0x97, 0x97, 0xa0, 0xc4, 0x8d, 0xe1, 0x4b, 0x58, 0xb4, 0x4b, 0x3e, 0xe8,
-	0xaa, 0x2d, 0x8e, 0xd2, 0x68, 0x1f, 0x5d, 0xd0, 0x2b, 0x87, 0xbb, 0xca, 0x87, 0xdb, 0x40, 0x6b,
-	0xe6, 0x70, 0x3a, 0x10, 0x27, 0xfc, 0x8e, 0xd7, 0x7c, 0xfb, 0x88, 0x14, 0xbf, 0xfa, 0x37, 0x91,
-	0xc3, 0xcb, 0xd5, 0x77, 0x8e, 0xf2, 0x61, 0x24, 0x1e, 0xf0, 0xa1, 0x10, 0x5a, 0x66, 0x43, 0x99,
-	0x4f, 0x1f, 0xd1, 0x4f, 0xa0, 0xa3, 0x1f, 0x70, 0xa1, 0x4d, 0xe3, 0xb9, 0x9a, 0xf9, 0x24, 0x6c,
-	0x38, 0xa8, 0x76, 0xa8, 0xa0, 0x9a, 0x73, 0x5e, 0xc7, 0x15, 0xce, 0xf7, 0x9c, 0x6d, 0x74, 0x08,
-	0xeb, 0xd2, 0x8a, 0x9f, 0x90, 0xff, 0xce, 0x4a, 0x6a, 0x5e, 0x6c, 0xde, 0x76, 0xd0, 0x7d, 0x68,
-	0xab, 0x37, 0x6d, 0x68, 0xa3, 0xfe, 0x61, 0xdd, 0x70, 0xb3, 0x82, 0xcb, 0x83, 0xb3, 0x0f, 0x50,
-	0x3c, 0xe1, 0x42, 0x83, 0x8b, 0x5e, 0x9a, 0x69, 0x21, 0xd6, 0xbc, 0xf7, 0x1a, 0xf3, 0x17, 0x6c,
-	0xf6, 0x0b, 0x31, 0xf4, 0xad, 0x82, 0xbe, 0xf6, 0xed, 0xd8, 0x3b, 0x18, 0xe2, 0x0d, 0x2e, 0xbb,
-	0x65, 0xb4, 0xc8, 0x64, 0x17, 0x93, 0x73, 0xf5, 0xda, 0xe1, 0x11, 0x74, 0x8d, 0x67, 0x61, 0x48,
-	0x71, 0xa8, 0x3e, 0x29, 0x1b, 0x0e, 0xeb, 0xba, 0xe4, 0x74, 0x7f, 0x0b, 0xfa, 0xd6, 0xfb, 0x2e,
-	0x7d, 0x32, 0xea, 0x5e, 0x8f, 0xe9, 0x93, 0x51, 0xff, 0x24, 0xec, 0xc7, 0xd0, 0x35, 0x5e, 0x63,
-	0x21, 0xe3, 0x52, 0xac, 0xf4, 0xda, 0x4a, 0xcf, 0xa8, 0xe6, 0xf1, 0x16, 0x5e, 0xe3, 0xeb, 0x5d,
-	0xc4, 0x1d, 0xb6, 0x5e, 0x7e, 0xe5, 0xcf, 0x94, 0xe4, 0xa7, 0xb0, 0x68, 0xbf, 0xc2, 0xd2, 0xa7,
-	0xaa, 0xf6, 0x3d, 0x97, 0x3e, 0x55, 0x17, 0x3c, 0xdd, 0x92, 0x0a, 0xb9, 0xbd, 0xaa, 0x07, 0xd9,
-	0x7d, 0x23, 0x0b, 0x5b, 0x6f, 0xd1, 0x8f, 0x98, 0xe9, 0x90, 0x6f, 0x30, 0x50, 0xf1, 0x2a, 0xcd,
-	0x7e, 0xa9, 0xa1, 0xb5, 0xbd, 0xf2, 0x5c, 0x03, 0xaf, 0x70, 0xe6, 0x5d, 0x54, 0xac, 0x00, 0x7d,
-	0x0e, 0x0b, 0xf2, 0x2d, 0x06, 0x5a, 0x2f, 0xb4, 0xda, 0x28, 0x0e, 0x0c, 0x37, 0xca, 0xb0, 0x64,
-	0xb6, 0xca, 0x99, 0xf5, 0x51, 0x97, 0x31, 0x1b, 0x13, 0x1a, 0x32, 0x1e, 0x11, 0x2c, 0xd9, 0xe5,
-	0xf9, 0x5c, 0x8b, 0xa3, 0xf6, 0x62, 0x50, 0x8b, 0xa3, 0xbe, 0xd6, 0x6f, 0x1b, 0x19, 0x65, 0x5c,
-	0x76, 0xd5, 0x9d, 0xe7, 0xef, 0x42, 0xcf, 0x7c, 0xf8, 0x83, 0x86, 0xc6, 0xca, 0x4b, 0x8f, 0x84,
-	0x86, 0x57, 0x6a, 0xfb, 0xec, 0xad, 0x45, 0x3d, 0x73, 0x18, 0xf4, 0x63, 0x58, 0x32, 0xee, 0x91,
-	0x8e, 0x67, 0xf1, 0x48, 0xab, 0x4e, 0xf5, 0x6e, 0x7a, 0x58, 0xe7, 0x5b, 0xf0, 0x26, 0x67, 0xbc,
-	0x82, 0x2d, 0xc6, 0x4c, 0x6d, 0x1e, 0x42, 0xd7, 0xbc, 0xa3, 0x7a, 0x07, 0xdf, 0x4d, 0xa3, 0xcb,
-	0xbc, 0x2d, 0xbe, 0xed, 0xa0, 0xbf, 0x70, 0xa0, 0x67, 0x3e, 0x59, 0x40, 0x56, 0x7e, 0x5d, 0xe2,
-	0x33, 0x30, 0xfb, 0x4c, 0x46, 0xf8, 0x19, 0x9f, 0xe4, 0xc1, 0xf6, 0x13, 0x4b, 0xc8, 0x6f, 0xac,
-	0x98, 0x61, 0xc7, 0x7c, 0xaa, 0xfc, 0xb6, 0xdc, 0x69, 0xde, 0xdd, 0xbf, 0xbd, 0xed, 0xa0, 0x7b,
-	0xe2, 0x41, 0xba, 0x0a, 0x79, 0x91, 0x61, 0xd6, 0xca, 0xe2, 0x32, 0x5f, 0x79, 0x6f, 0x39, 0xb7,
-	0x1d, 0xf4, 0x7b, 0xe2, 0x75, 0xb2, 0xfc, 0x96, 0x4b, 0xfd, 0x43, 0xbf, 0xc7, 0x37, 0xf9, 0x4a,
-	0xae, 0xe1, 0xcb, 0xd6, 0x4a, 0xca, 0x76, 0xfd, 0x08, 0xa0, 0xc8, 0x5f, 0x50, 0x29, 0x98, 0xd7,
-	0x16, 0xaf, 0x9a, 0xe2, 0xd8, 0xbb, 0xa9, 0x62, 0x7e, 0x61, 0x04, 0x7a, 0x46, 0xe6, 0x90, 0xeb,
-	0xed, 0xac, 0xe6, 0x21, 0xc3, 0x61, 0x5d, 0x97, 0xe4, 0xff, 0x6d, 0xce, 0xff, 0x23, 0x74, 0xc5,
-	0xe4, 0xbf, 0xfb, 0xc6, 0xcc, 0x5b, 0xde, 0xa2, 0xaf, 0xa0, 0x7f, 0x98, 0x24, 0x2f, 0xa7, 0xa9,
-	0x4e, 0x4b, 0xed, 0x48, 0x9c, 0xe5, 0x4e, 0xc3, 0xd2, 0xa2, 0xf0, 0x0d, 0xce, 0xf9, 0x0a, 0xba,
-	0x6c, 0x73, 0x2e, 0xb2, 0xa9, 0xb7, 0xc8, 0x87, 0x15, 0xed, 0xed, 0xf4, 0x42, 0x86, 0x36, 0x1f,
-	0x33, 0xa9, 0xa9, 0x8c, 0x61, 0xc5, 0x1f, 0x7a, 0x8c, 0x5c, 0xf1, 0xbc, 0xed, 0xa0, 0x23, 0xe8,
-	0x3d, 0x22, 0xa3, 0x24, 0x20, 0x32, 0x7a, 0x5e, 0x2d, 0x66, 0xae, 0xa3, 0xee, 0x61, 0xdf, 0x02,
-	0x6d, 0x0b, 0x90, 0xfa, 0xb3, 0x8c, 0x7c, 0xbd, 0xfb, 0x46, 0x86, 0xe5, 0x6f, 0x95, 0x05, 0x50,
-	0xa9, 0x84, 0x65, 0x01, 0x4a, 0xb9, 0x87, 0x65, 0x01, 0x2a, 0xb9, 0x87, 0x65, 0x01, 0x54, 0x2a,
-	0x83, 0x22, 0x96, 0x91, 0x94, 0xd2, 0x15, 0xed, 0x33, 0x2f, 0x4a, 0x72, 0x86, 0xd7, 0x2f, 0x26,
-	0xb0, 0x47, 0xdb, 0xb6, 0x47, 0x3b, 0x86, 0xfe, 0x23, 0x22, 0x84, 0x25, 0xea, 0xc5, 0x43, 0xdb,
-	0xa4, 0x98, 0xb5, 0xe5, 0xb2, 0xb9, 0xe1, 0x7d, 0xb6, 0x81, 0xe7, 0xc5, 0x5a, 0xf4, 0x13, 0xe8,
-	0x3e, 0x25, 0x54, 0x15, 0x88, 0x75, 0xe4, 0x51, 0xaa, 0x18, 0x0f, 0x6b, 0xea, 0xcb, 0xf8, 0x3a,
-	0xe7, 0x36, 0x44, 0x03, 0xcd, 0x6d, 0x97, 0x04, 0x63, 0x22, 0x0e, 0xbf, 0x17, 0x06, 0x6f, 0xd1,
-	0x6f, 0x73, 0xe6, 0xfa, 0xf6, 0x68, 0xc3, 0xa8, 0x2b, 0x9a, 0xcc, 0x97, 0x4a, 0x78, 0x1d, 0xe7,
-	0x38, 0x09, 0x88, 0xe1, 0xea, 0x62, 0xe8, 0x1a, 0x57, 0x85, 0xfa, 0x40, 0x55, 0xef, 0x1f, 0xf5,
-	0x81, 0xaa, 0xb9, 0x59, 0xc4, 0x5b, 0x7c, 0x1c, 0x8c, 0xae, 0x17, 0xe3, 0x88, 0xdb, 0xc4, 0x62,
-	0xa4, 0xdd, 0x37, 0xfe, 0x84, 0xbe, 0x45, 0x2f, 0xf8, 0x33, 0x45, 0xb3, 0x08, 0x5e, 0x44, 0x3e,
-	0xe5, 0x7a, 0xb9, 0x16, 0x96, 0xd1, 0x65, 0x47, 0x43, 0x62, 0x28, 0xee, 0x11, 0xbf, 0x0f, 0x70,
-	0x4c, 0x93, 0xf4, 0x91, 0x4f, 0x26, 0x49, 0x5c, 0x58, 0xb2, 0xa2, 0xd0, 0x5b, 0x58, 0x32, 0xa3,
-	0xda, 0x8b, 0x5e, 0x18, 0xb1, 0xa7, 0x75, 0x87, 0xa0, 0x94, 0xeb, 0xc2, 0x5a, 0xb0, 0x16, 0x48,
-	0x4d, 0x3d, 0x58, 0x85, 0xa1, 0xa2, 0xc8, 0x65, 0x84, 0xa1, 0x56, 0x95, 0xcc, 0x08, 0x43, 0xed,
-	0x6a, 0x18, 0x0b, 0x43, 0x8b, 0xcc, 0x5a, 0x87, 0xa1, 0x95, 0xa4, 0x5d, 0xdb, 0xd0, 0x9a, 0x34,
-	0xfc, 0x08, 0x3a, 0x45, 0xae, 0xaa, 0x06, 0x2a, 0x67, 0xb6, 0xda, 0x59, 0x55, 0x52, 0x48, 0xbc,
-	0xcc, 0xe5, 0x0c, 0xa8, 0xcd, 0xe4, 0xcc, 0xaf, 0x4a, 0x9f, 0x03, 0x88, 0xd5, 0x3d, 0x61, 0x2d,
-	0x83, 0xa5, 0x95, 0x29, 0x9a, 0x2c, 0xed, 0x94, 0x4c, 0x45, 0x32, 0x58, 0xb3, 0xbc, 0xe7, 0x6c,
-	0x9f, 0xcc, 0xf3, 0xff, 0x5b, 0xfb, 0xde, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x99, 0xff, 0x53,
-	0x74, 0xe9, 0x36, 0x00, 0x00,
+	// 6862 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0x4d, 0x6c, 0x1c, 0xc9,
+	0x75, 0xbf, 0x7a, 0x66, 0xf8, 0x31, 0x6f, 0x66, 0xc8, 0x61, 0xf1, 0x43, 0xa3, 0x96, 0x76, 0x57,
+	0xdb, 0x2b, 0xec, 0xf2, 0x2f, 0x1b, 0x94, 0x56, 0x6b, 0xaf, 0xd7, 0xbb, 0xfe, 0xf8, 0x53, 0xe4,
+	0x50, 0xa4, 0x97, 0x22, 0xe9, 0x26, 0xb5, 0x1b, 0xdb, 0x89, 0x3b, 0xcd, 0x99, 0xe2, 0xb0, 0xad,
+	0x99, 0xee, 0x71, 0x77, 0x0f, 0xb5, 0xf4, 0x42, 0x40, 0x60, 0x18, 0x49, 0x9c, 0xc4, 0x87, 0xc0,
+	0x40, 0x80, 0xe4, 0x10, 0x18, 0xc8, 0x39, 0x01, 0x02, 0xe4, 0x16, 0xe4, 0x1c, 0x20, 0x1f, 0x87,
+	0xc0, 0xa7, 0x1c, 0x03, 0x24, 0xb7, 0x5c, 0x12, 0x20, 0x09, 0x82, 0x5c, 0x82, 0x57, 0x5f, 0x5d,
+	0xd5, 0xdd, 0x23, 0xd1, 0xb1, 0x93, 0x8b, 0xc4, 0xfa, 0xbd, 0xea, 0x57, 0x5f, 0xaf, 0x5e, 0xbd,
+	0x7a, 0xef, 0xd5, 0x40, 0x3d, 0x1e, 0xf7, 0x36, 0xc6, 0x71, 0x94, 0x46, 0x64, 0x66, 0x18, 0xc6,
+	0xe3, 0x9e, 0x7d, 0x6b, 0x10, 0x45, 0x83, 0x21, 0xbd, 0xe7, 0x8f, 0x83, 0x7b, 0x7e, 0x18, 0x46,
+	0xa9, 0x9f, 0x06, 0x51, 0x98, 0xf0, 0x4a, 0xce, 0xbf, 0x58, 0xd0, 0x38, 0x89, 0xfd, 0x30, 0xf1,
+	0x7b, 0x08, 0x93, 0x0e, 0xcc, 0xa5, 0x9f, 0x78, 0xe7, 0x7e, 0x72, 0xde, 0xb1, 0x6e, 0x5b, 0xeb,
+	0x75, 0x57, 0x16, 0xc9, 0x1a, 0xcc, 0xfa, 0xa3, 0x68, 0x12, 0xa6, 0x9d, 0xca, 0x6d, 0x6b, 0xbd,
+	0xea, 0x8a, 0x12, 0xf9, 0x2c, 0x2c, 0x85, 0x93, 0x91, 0xd7, 0x8b, 0xc2, 0xb3, 0x20, 0x1e, 0x71,
+	0xe6, 0x9d, 0xea, 0x6d, 0x6b, 0x7d, 0xc6, 0x2d, 0x12, 0xc8, 0xab, 0x00, 0xa7, 0xc3, 0xa8, 0xf7,
+	0x94, 0x37, 0x51, 0x63, 0x4d, 0x68, 0x08, 0x71, 0xa0, 0x29, 0x4a, 0x34, 0x18, 0x9c, 0xa7, 0x9d,
+	0x19, 0xc6, 0xc8, 0xc0, 0x90, 0x47, 0x1a, 0x8c, 0xa8, 0x97, 0xa4, 0xfe, 0x68, 0xdc, 0x99, 0x65,
+	0xbd, 0xd1, 0x10, 0x46, 0x8f, 0x52, 0x7f, 0xe8, 0x9d, 0x51, 0x9a, 0x74, 0xe6, 0x04, 0x5d, 0x21,
+	0x4e, 0x07, 0xd6, 0x1e, 0xd1, 0x54, 0x1b, 0x75, 0xe2, 0xd2, 0xef, 0x4e, 0x68, 0x92, 0x3a, 0xfb,
+	0x40, 0x34, 0x78, 0x9b, 0xa6, 0x7e, 0x30, 0x4c, 0xc8, 0xbb, 0xd0, 0x4c, 0xb5, 0xca, 0x1d, 0xeb,
+	0x76, 0x75, 0xbd, 0xf1, 0x80, 0x6c, 0xb0, 0xf9, 0xdd, 0xd0, 0x3e, 0x70, 0x8d, 0x7a, 0xce, 0x5f,
+	0x54, 0xa0, 0x71, 0x4c, 0xc3, 0xbe, 0xe0, 0x4e, 0x08, 0xd4, 0xfa, 0x34, 0x49, 0xd9, 0xc4, 0x36,
+	0x5d, 0xf6, 0x37, 0x79, 0x0d, 0x1a, 0xf8, 0xbf, 0x97, 0xa4, 0x71, 0x10, 0x0e, 0xd8, 0xd4, 0xd6,
+	0x5d, 0x40, 0xe8, 0x98, 0x21, 0xa4, 0x0d, 0x55, 0x7f, 0x94, 0xb2, 0x09, 0xad, 0xba, 0xf8, 0x27,
+	0x79, 0x1d, 0x9a, 0x63, 0xff, 0x72, 0x44, 0xc3, 0x34, 0x9b, 0xc4, 0xa6, 0xdb, 0x10, 0xd8, 0x2e,
+	0xce, 0xe2, 0x06, 0x2c, 0xeb, 0x55, 0x24, 0xf7, 0x19, 0xc6, 0x7d, 0x49, 0xab, 0x29, 0x1a, 0x79,
+	0x0b, 0x16, 0x65, 0xfd, 0x98, 0x77, 0x96, 0x4d, 0x6b, 0xdd, 0x5d, 0x10, 0xb0, 0x1c, 0xc2, 0x3a,
+	0xb4, 0xa3, 0x49, 0x3a, 0x88, 0x82, 0x70, 0xe0, 0xf5, 0xce, 0xfd, 0xd0, 0x0b, 0xfa, 0x6c, 0x82,
+	0x6b, 0xee, 0x82, 0xc4, 0xb7, 0xce, 0xfd, 0x70, 0xaf, 0x4f, 0xde, 0x84, 0xc5, 0xa1, 0x9f, 0xa4,
+	0xde, 0x79, 0x34, 0xf6, 0xc6, 0x93, 0xd3, 0xa7, 0xf4, 0xb2, 0x33, 0xcf, 0x3a, 0xda, 0x42, 0x78,
+	0x37, 0x1a, 0x1f, 0x31, 0x90, 0xdc, 0x80, 0xf9, 0xa7, 0xf4, 0xd2, 0x4b, 0x68, 0xd8, 0xef, 0xd4,
+	0x6f, 0x5b, 0xeb, 0xf3, 0xee, 0xdc, 0x53, 0x7a, 0x89, 0xd3, 0xe6, 0xfc, 0xb3, 0x05, 0x4d, 0x3e,
+	0x7f, 0xc9, 0x38, 0x0a, 0x13, 0x4a, 0xee, 0x40, 0x4b, 0x76, 0x93, 0xc6, 0x71, 0x14, 0x0b, 0x11,
+	0x35, 0x41, 0x72, 0x17, 0xda, 0x12, 0x18, 0xc7, 0x34, 0x18, 0xf9, 0x03, 0xca, 0xe6, 0xb5, 0xe9,
+	0x16, 0x70, 0xf2, 0x20, 0xe3, 0x18, 0x47, 0x93, 0x94, 0xb2, 0x79, 0x6e, 0x3c, 0x68, 0x8a, 0xb5,
+	0x75, 0x11, 0x73, 0xcd, 0x2a, 0x28, 0xa2, 0x67, 0x7e, 0x30, 0x9c, 0xc4, 0xd4, 0xeb, 0x45, 0x7d,
+	0xca, 0xe6, 0xbf, 0xe5, 0x1a, 0x18, 0x79, 0x00, 0x2b, 0xb2, 0x9c, 0x44, 0x93, 0xb8, 0x47, 0xbd,
+	0x20, 0xec, 0xd3, 0x4f, 0xd8, 0x0a, 0xb4, 0xdc, 0x52, 0x9a, 0xf3, 0x23, 0x0b, 0x08, 0x0e, 0xf7,
+	0x24, 0xe2, 0xcd, 0x8a, 0x29, 0xcf, 0x2f, 0xb7, 0x75, 0xe5, 0xe5, 0xae, 0x4c, 0x5b, 0xee, 0x3b,
+	0x30, 0xcb, 0x86, 0x82, 0xfb, 0xb4, 0x5a, 0x18, 0xae, 0xa0, 0x39, 0x7f, 0x60, 0x41, 0xdb, 0xa5,
+	0xa7, 0xfe, 0xd0, 0x0f, 0x7b, 0xaa, 0x37, 0x77, 0x4b, 0x04, 0xc0, 0x62, 0x02, 0x50, 0xc0, 0xb1,
+	0x6e, 0x10, 0xf6, 0xa2, 0x91, 0x5e, 0xb7, 0xc2, 0xeb, 0xe6, 0xf1, 0x12, 0x31, 0xbf, 0x05, 0xf5,
+	0x33, 0x4a, 0xbd, 0x61, 0x30, 0x0a, 0x52, 0x36, 0xc7, 0x55, 0x37, 0x03, 0x9c, 0x04, 0x96, 0xb4,
+	0xbe, 0x09, 0xf9, 0x28, 0x5b, 0x79, 0xeb, 0xaa, 0x2b, 0x5f, 0x79, 0xe9, 0xca, 0x3b, 0xdf, 0xb7,
+	0xa0, 0x89, 0xe2, 0x1d, 0xd2, 0xe1, 0x51, 0x14, 0x84, 0x29, 0x13, 0x85, 0x49, 0xd8, 0xc7, 0x81,
+	0xa4, 0x9f, 0x88, 0x99, 0x68, 0xba, 0x06, 0x86, 0x9d, 0xd2, 0xcb, 0xb8, 0x38, 0x62, 0x65, 0x0a,
+	0x38, 0xf2, 0x8b, 0x26, 0xe9, 0x78, 0x92, 0x0a, 0x71, 0xa9, 0x72, 0xd1, 0xd2, 0x31, 0xe7, 0x2b,
+	0xd0, 0xde, 0x47, 0x35, 0x18, 0x06, 0xe1, 0x60, 0xb3, 0xdf, 0x8f, 0x69, 0x92, 0xa0, 0x6e, 0x16,
+	0x7b, 0x8c, 0xef, 0x08, 0x51, 0x42, 0x8d, 0x73, 0x1e, 0x25, 0xa9, 0x68, 0x8f, 0xfd, 0xed, 0xfc,
+	0xc4, 0x82, 0x45, 0x14, 0xb3, 0xc7, 0x7e, 0x78, 0x29, 0x57, 0x75, 0x1f, 0x9a, 0xc8, 0xea, 0x24,
+	0xda, 0xe4, 0x1a, 0x9e, 0x6b, 0xb8, 0x75, 0x31, 0x17, 0xb9, 0xda, 0x1b, 0x7a, 0xd5, 0x6e, 0x98,
+	0xc6, 0x97, 0xae, 0xf1, 0xb5, 0xfd, 0x55, 0x58, 0x2a, 0x54, 0xc1, 0x05, 0xce, 0xfa, 0x87, 0x7f,
+	0x92, 0x15, 0x98, 0xb9, 0xf0, 0x87, 0x13, 0x2a, 0xce, 0x13, 0x5e, 0x78, 0xbf, 0xf2, 0x9e, 0xe5,
+	0xbc, 0x09, 0xed, 0xac, 0x4d, 0xb1, 0xb6, 0x04, 0x6a, 0x6a, 0x8a, 0xeb, 0x2e, 0xfb, 0xdb, 0xf9,
+	0x0a, 0xaf, 0xb7, 0x15, 0x05, 0x4a, 0x85, 0x63, 0x3d, 0xbf, 0xdf, 0x97, 0xaa, 0x81, 0xfd, 0x3d,
+	0xed, 0xe8, 0x72, 0xde, 0x82, 0x25, 0xed, 0xfb, 0x17, 0x34, 0xf4, 0x87, 0x16, 0x2c, 0x1d, 0xd0,
+	0x67, 0x62, 0xba, 0x65, 0x53, 0xef, 0x41, 0x2d, 0xbd, 0x1c, 0x73, 0x11, 0x5b, 0x78, 0x70, 0x47,
+	0xcc, 0x56, 0xa1, 0xde, 0x86, 0x28, 0x9e, 0x5c, 0x8e, 0xa9, 0xcb, 0xbe, 0x70, 0x0e, 0xa1, 0xa1,
+	0x81, 0xe4, 0x3a, 0x2c, 0x7f, 0xbc, 0x77, 0x72, 0xd0, 0x3d, 0x3e, 0xf6, 0x8e, 0x9e, 0x3c, 0xfc,
+	0xb0, 0xfb, 0x0d, 0x6f, 0x77, 0xf3, 0x78, 0xb7, 0x7d, 0x8d, 0xac, 0x01, 0x39, 0xe8, 0x1e, 0x9f,
+	0x74, 0xb7, 0x0d, 0xdc, 0x22, 0x8b, 0xd0, 0xd0, 0x81, 0x8a, 0x63, 0x43, 0xe7, 0x80, 0x3e, 0xfb,
+	0x38, 0x48, 0x43, 0x9a, 0x24, 0x66, 0xf3, 0xce, 0x06, 0x10, 0xbd, 0x4f, 0x62, 0x98, 0x1d, 0x98,
+	0xf3, 0x39, 0x24, 0x0f, 0x7a, 0x51, 0x74, 0xde, 0x04, 0x72, 0x1c, 0x0c, 0xc2, 0xc7, 0x34, 0x49,
+	0xfc, 0x81, 0xda, 0xf8, 0x6d, 0xa8, 0x8e, 0x92, 0x81, 0x90, 0x70, 0xfc, 0xd3, 0x79, 0x07, 0x96,
+	0x8d, 0x7a, 0x82, 0xf1, 0x2d, 0xa8, 0x27, 0xc1, 0x20, 0xf4, 0xd3, 0x49, 0x4c, 0x05, 0xeb, 0x0c,
+	0x70, 0x76, 0x60, 0xe5, 0x23, 0x1a, 0x07, 0x67, 0x97, 0x2f, 0x63, 0x6f, 0xf2, 0xa9, 0xe4, 0xf9,
+	0x74, 0x61, 0x35, 0xc7, 0x47, 0x34, 0xcf, 0xa5, 0x4a, 0xac, 0xdf, 0xbc, 0xcb, 0x0b, 0xda, 0x06,
+	0xa9, 0xe8, 0x1b, 0xc4, 0x79, 0x02, 0x64, 0x2b, 0x0a, 0x43, 0xda, 0x4b, 0x8f, 0x28, 0x8d, 0x65,
+	0x67, 0x3e, 0xa3, 0xc9, 0x50, 0xe3, 0xc1, 0x75, 0xb1, 0xb0, 0xf9, 0x5d, 0x27, 0x84, 0x8b, 0x40,
+	0x6d, 0x4c, 0xe3, 0x11, 0x63, 0x3c, 0xef, 0xb2, 0xbf, 0x9d, 0x7b, 0xb0, 0x6c, 0xb0, 0xcd, 0xe6,
+	0x7c, 0x4c, 0x69, 0x2c, 0x75, 0xe6, 0x8c, 0x2b, 0x8b, 0xce, 0xdb, 0xb0, 0xba, 0x1d, 0x24, 0xbd,
+	0x62, 0x57, 0xf0, 0x93, 0xc9, 0xa9, 0x97, 0x6d, 0x1d, 0x59, 0x44, 0x2b, 0x26, 0xff, 0x09, 0x6f,
+	0xc6, 0xf9, 0x75, 0x0b, 0x6a, 0xbb, 0x27, 0xfb, 0x5b, 0xc4, 0x86, 0x79, 0xa9, 0x68, 0xc5, 0x74,
+	0xa8, 0xf2, 0x54, 0x73, 0xee, 0x16, 0xd4, 0xd9, 0x19, 0x82, 0x06, 0x17, 0xd3, 0x3f, 0x4d, 0x37,
+	0x03, 0xd0, 0xd8, 0xa3, 0x9f, 0x8c, 0x83, 0x98, 0x59, 0x73, 0xd2, 0x46, 0xe3, 0x07, 0x60, 0x91,
+	0xe0, 0xfc, 0x4d, 0x0d, 0x5a, 0x9b, 0xbd, 0x34, 0xb8, 0xa0, 0x42, 0x6b, 0xb2, 0x56, 0x19, 0x20,
+	0xfa, 0x23, 0x4a, 0x78, 0xb2, 0xc7, 0x74, 0x14, 0xa5, 0xd4, 0x33, 0x96, 0xc9, 0x04, 0xb1, 0x56,
+	0x8f, 0x33, 0xf2, 0xc6, 0xa8, 0x7f, 0x59, 0xff, 0xea, 0xae, 0x09, 0xe2, 0x94, 0xc9, 0xd3, 0xa6,
+	0xc6, 0x4e, 0x1b, 0x59, 0xc4, 0xf9, 0xe8, 0xf9, 0x63, 0xbf, 0x17, 0xa4, 0x97, 0xec, 0x24, 0xae,
+	0xba, 0xaa, 0x8c, 0xbc, 0x87, 0x51, 0xcf, 0x1f, 0x7a, 0xe2, 0x50, 0x11, 0x76, 0xa5, 0x09, 0x92,
+	0x37, 0x61, 0x41, 0x74, 0x49, 0x56, 0xe3, 0xe6, 0x65, 0x0e, 0x45, 0x13, 0xb4, 0x17, 0x8d, 0x46,
+	0x41, 0x8a, 0x16, 0x27, 0x33, 0x7c, 0xaa, 0xae, 0x86, 0xb0, 0x91, 0xf0, 0xd2, 0x33, 0x3e, 0x87,
+	0x75, 0xde, 0x9a, 0x01, 0x22, 0x17, 0x3c, 0xf1, 0xc6, 0x34, 0xf6, 0x9e, 0x3e, 0xeb, 0x00, 0xe7,
+	0x92, 0x21, 0xb8, 0x1a, 0x93, 0x30, 0xa1, 0x69, 0x3a, 0xa4, 0x7d, 0xd5, 0xa1, 0x06, 0xab, 0x56,
+	0x24, 0x90, 0xfb, 0xb0, 0xcc, 0x8d, 0xe0, 0xc4, 0x4f, 0xa3, 0xe4, 0x3c, 0x48, 0xd0, 0xe8, 0x4a,
+	0x3b, 0x4d, 0x56, 0xbf, 0x8c, 0x44, 0xde, 0x83, 0xeb, 0x39, 0x38, 0xa6, 0x3d, 0x1a, 0x5c, 0xd0,
+	0x7e, 0xa7, 0xc5, 0xbe, 0x9a, 0x46, 0x26, 0xb7, 0xa1, 0x81, 0xb6, 0xff, 0x64, 0xdc, 0xf7, 0xd1,
+	0xcc, 0x58, 0x60, 0xeb, 0xa0, 0x43, 0xe4, 0x6d, 0x68, 0x8d, 0x29, 0x3f, 0xfe, 0xce, 0xd3, 0x61,
+	0x2f, 0xe9, 0x2c, 0xb2, 0x33, 0xa7, 0x21, 0x36, 0x1b, 0xca, 0xaf, 0x6b, 0xd6, 0x70, 0x56, 0x61,
+	0x79, 0x3f, 0x48, 0x52, 0x21, 0x4b, 0x4a, 0xbf, 0xed, 0xc2, 0x8a, 0x09, 0x8b, 0xdd, 0x76, 0x1f,
+	0xe6, 0x85, 0x60, 0x24, 0x9d, 0x06, 0x63, 0xbe, 0x22, 0x98, 0x1b, 0x32, 0xe9, 0xaa, 0x5a, 0xce,
+	0x3f, 0x58, 0xb0, 0xba, 0x35, 0x8c, 0x12, 0xda, 0xcf, 0xb5, 0x81, 0xe3, 0xe9, 0x45, 0xd1, 0x98,
+	0xc6, 0xbe, 0x26, 0xbc, 0x3a, 0x84, 0x35, 0xb8, 0xa8, 0x9c, 0x45, 0x71, 0x8f, 0x0a, 0x6d, 0xa0,
+	0x43, 0x78, 0xb8, 0x0b, 0x29, 0xe1, 0x55, 0xaa, 0xac, 0x8a, 0x81, 0xe1, 0xfe, 0x38, 0x8d, 0xa9,
+	0xdf, 0xe3, 0x56, 0xfd, 0xbc, 0x2b, 0x4a, 0xba, 0x11, 0xd1, 0xc3, 0xc5, 0x1c, 0xd2, 0x3e, 0x93,
+	0xe0, 0x79, 0xb7, 0x80, 0xe3, 0x0e, 0xf6, 0x4f, 0xfd, 0xb0, 0x1f, 0x85, 0xb4, 0xcf, 0xa4, 0x78,
+	0xde, 0xcd, 0x00, 0xe7, 0x87, 0x55, 0x00, 0x97, 0x26, 0xd1, 0x70, 0xc2, 0xee, 0x7b, 0x36, 0xcc,
+	0xa3, 0x75, 0xc1, 0x76, 0x13, 0x57, 0x30, 0xaa, 0x4c, 0xbe, 0x06, 0x8b, 0xb1, 0xaa, 0xe9, 0xb1,
+	0xa3, 0xae, 0xc2, 0x8e, 0xba, 0xdb, 0xd2, 0x48, 0x52, 0x54, 0xed, 0x4f, 0x76, 0xcc, 0xe5, 0x3f,
+	0x24, 0x5f, 0x86, 0xb9, 0x68, 0x92, 0xf6, 0xa2, 0x11, 0x1f, 0xf7, 0xc2, 0x83, 0x37, 0x5e, 0xc4,
+	0xe3, 0x90, 0x57, 0x75, 0xe5, 0x37, 0xb8, 0x13, 0xb8, 0x7e, 0x42, 0x59, 0x13, 0xd6, 0xa0, 0x86,
+	0x20, 0x3d, 0x79, 0x46, 0xe9, 0x98, 0x9b, 0x61, 0xfc, 0x9e, 0xa3, 0x21, 0xce, 0xb7, 0x60, 0xc1,
+	0xec, 0x21, 0x01, 0x98, 0xdd, 0x3a, 0x7c, 0xfc, 0x78, 0xef, 0xa4, 0x7d, 0x0d, 0xff, 0xde, 0x3c,
+	0xd8, 0xda, 0x3d, 0x74, 0xdb, 0x16, 0x59, 0x82, 0xd6, 0xde, 0xc1, 0xd6, 0xe1, 0xe3, 0xbd, 0x83,
+	0x47, 0x1e, 0x0a, 0x61, 0xbb, 0x82, 0xd0, 0xe1, 0x93, 0x93, 0x47, 0x87, 0x0a, 0xaa, 0x92, 0x06,
+	0xcc, 0xb9, 0xdd, 0x8f, 0x0e, 0x3f, 0xec, 0x6e, 0xb7, 0x6b, 0xce, 0x17, 0x60, 0x29, 0x63, 0x2e,
+	0xba, 0x8e, 0x35, 0x8e, 0xba, 0x07, 0xdb, 0x7b, 0x07, 0x8f, 0xda, 0xd7, 0xb0, 0xb0, 0xb5, 0xbf,
+	0xb9, 0xf7, 0xb8, 0xbb, 0xdd, 0xb6, 0xc8, 0x3c, 0xd4, 0xf6, 0x0f, 0x8f, 0x4f, 0xda, 0x15, 0xe7,
+	0x4f, 0x6b, 0xb0, 0x2c, 0x24, 0x8d, 0x89, 0xdd, 0xf1, 0x64, 0x34, 0xf2, 0xe3, 0x12, 0x3d, 0x67,
+	0x95, 0xe9, 0xb9, 0x75, 0x58, 0xec, 0x0d, 0xa3, 0x84, 0x1b, 0x90, 0xfc, 0x6e, 0xc0, 0xb5, 0x66,
+	0x1e, 0x2e, 0x6a, 0xd7, 0x6a, 0x99, 0x76, 0xd5, 0xb5, 0x63, 0x2d, 0xa7, 0x1d, 0x1d, 0x68, 0x22,
+	0x53, 0xaa, 0x5f, 0xcb, 0x5b, 0xae, 0x81, 0x61, 0x7f, 0xf2, 0xba, 0x88, 0xeb, 0xd0, 0xc5, 0x32,
+	0x4d, 0x84, 0xd7, 0x75, 0x3c, 0x52, 0x68, 0x3f, 0xa7, 0x4a, 0xcb, 0x48, 0x64, 0x07, 0x80, 0xb7,
+	0xc5, 0xa4, 0x70, 0x9e, 0x49, 0xd0, 0x9b, 0x42, 0x82, 0x4a, 0x66, 0x70, 0x03, 0x0b, 0x93, 0x98,
+	0x32, 0x59, 0xd4, 0xbe, 0x24, 0xef, 0x40, 0x23, 0x93, 0xcc, 0xa4, 0x53, 0x67, 0x6a, 0x61, 0xa9,
+	0x20, 0x8a, 0xae, 0x5e, 0xcb, 0xf9, 0x2d, 0x0b, 0x1a, 0x1a, 0x43, 0xb2, 0x0a, 0x4b, 0x5b, 0x87,
+	0x87, 0x47, 0x5d, 0x77, 0xf3, 0x64, 0xef, 0xa3, 0xae, 0xb7, 0xb5, 0x7f, 0x78, 0xdc, 0x6d, 0x5f,
+	0x43, 0x78, 0xff, 0x70, 0x6b, 0x73, 0xdf, 0xdb, 0x39, 0x74, 0xb7, 0x24, 0x6c, 0xa1, 0x0d, 0xe7,
+	0x76, 0x1f, 0x1f, 0x9e, 0x74, 0x0d, 0xbc, 0x42, 0xda, 0xd0, 0x7c, 0xe8, 0x76, 0x37, 0xb7, 0x76,
+	0x05, 0x52, 0x25, 0x2b, 0xd0, 0xde, 0x79, 0xc2, 0x44, 0xc6, 0xdb, 0xda, 0x3c, 0xd8, 0xea, 0xee,
+	0xa3, 0x74, 0x91, 0x16, 0xd4, 0x37, 0x1f, 0x6e, 0x1e, 0x6c, 0x1f, 0x1e, 0x74, 0xb7, 0xdb, 0x33,
+	0xce, 0x11, 0xac, 0xe5, 0x55, 0x94, 0xd0, 0x77, 0xef, 0x6a, 0xfa, 0x8e, 0x1b, 0xf0, 0xf6, 0xf4,
+	0x19, 0xd2, 0xb4, 0x9e, 0x0d, 0x1d, 0x51, 0xa1, 0x7b, 0x41, 0xc3, 0xf4, 0x78, 0x72, 0x9a, 0xf4,
+	0xe2, 0x60, 0x8c, 0x63, 0x77, 0xae, 0xc3, 0xea, 0x6e, 0x3a, 0xec, 0x15, 0x09, 0xff, 0x51, 0x83,
+	0xba, 0xa2, 0x90, 0xf7, 0x01, 0x28, 0xfe, 0xe1, 0x69, 0xf6, 0xb0, 0x6c, 0x5c, 0xd5, 0xda, 0x60,
+	0xff, 0xf2, 0x25, 0xc9, 0x6a, 0xff, 0x4c, 0xb7, 0xc4, 0xb2, 0xdb, 0x67, 0xf5, 0x0a, 0xb7, 0x4f,
+	0x3c, 0x3f, 0x32, 0x7b, 0xa0, 0x80, 0x1b, 0x7c, 0x65, 0xdd, 0x99, 0x1c, 0x5f, 0x59, 0xf7, 0xb3,
+	0xb0, 0xa4, 0xbe, 0xf7, 0x47, 0xa9, 0x37, 0x42, 0x8d, 0xc4, 0x05, 0xbd, 0x48, 0xc0, 0xda, 0x8a,
+	0x83, 0xaa, 0xcd, 0x05, 0xbd, 0x48, 0xc0, 0x6d, 0x66, 0xdc, 0xf5, 0xb9, 0xc7, 0xc4, 0xc0, 0x0a,
+	0xee, 0x87, 0x3a, 0xdb, 0xcb, 0x06, 0xa6, 0x99, 0x29, 0x02, 0x66, 0xc6, 0xc3, 0xbc, 0x9b, 0x43,
+	0xb1, 0x9e, 0xfc, 0xae, 0xcf, 0x9c, 0x5d, 0xcc, 0x7a, 0xa8, 0xbb, 0x39, 0x14, 0xdb, 0xc4, 0x5d,
+	0xc9, 0xdc, 0x6b, 0x5e, 0x98, 0x08, 0x9b, 0xc1, 0xc0, 0x9c, 0x33, 0xa8, 0xab, 0x05, 0x46, 0x85,
+	0xb7, 0x73, 0xe8, 0x7e, 0xbc, 0xe9, 0x6e, 0xb7, 0xaf, 0xa1, 0xa4, 0x8b, 0x82, 0xb7, 0xb3, 0xb9,
+	0xb7, 0xdf, 0xb6, 0x50, 0xa6, 0xf7, 0xf7, 0x0e, 0x3e, 0xe4, 0xc5, 0x0a, 0xea, 0xdf, 0xe3, 0xee,
+	0xc9, 0xc9, 0x3e, 0x6e, 0x82, 0x79, 0xa8, 0x31, 0xb4, 0x86, 0x7f, 0x1d, 0x77, 0x0f, 0xb6, 0xdb,
+	0x33, 0x5c, 0xdb, 0x6e, 0x75, 0xf7, 0x3e, 0xea, 0xb6, 0x67, 0x9d, 0xbf, 0xae, 0xc0, 0xcd, 0x9d,
+	0x28, 0x7e, 0xe6, 0xc7, 0x7d, 0x14, 0xad, 0xbd, 0x30, 0xa5, 0x71, 0x8f, 0x8e, 0x53, 0xcd, 0x43,
+	0x51, 0x90, 0x27, 0x6b, 0xba, 0x3c, 0x15, 0x64, 0xa4, 0x72, 0x05, 0x19, 0x79, 0x99, 0xec, 0x39,
+	0xa5, 0x2e, 0x3a, 0x73, 0x1d, 0x4b, 0xe5, 0x68, 0xe6, 0x67, 0x92, 0xa3, 0xd9, 0x69, 0x72, 0xb4,
+	0x0e, 0x8b, 0x0a, 0x64, 0x66, 0xf9, 0x25, 0x93, 0xb9, 0x96, 0x9b, 0x87, 0x9d, 0x3f, 0xab, 0xc0,
+	0xad, 0xf2, 0xd9, 0xcc, 0x7c, 0x2a, 0xff, 0x2b, 0xd3, 0xb9, 0xc7, 0x6f, 0x02, 0x51, 0x28, 0xec,
+	0x81, 0xb7, 0x85, 0xba, 0x78, 0x51, 0x67, 0xb8, 0x86, 0xbe, 0xa0, 0x9b, 0xec, 0x43, 0x57, 0x30,
+	0xc0, 0x83, 0x4b, 0xb9, 0x7b, 0xf8, 0x4c, 0xab, 0x72, 0x61, 0xb7, 0xcc, 0x14, 0x9d, 0x75, 0xce,
+	0xdb, 0xd0, 0x32, 0x18, 0xa3, 0x3c, 0xba, 0xdd, 0xe3, 0x27, 0x8f, 0x51, 0xab, 0x4b, 0x79, 0xb4,
+	0x34, 0x29, 0xad, 0x38, 0xff, 0x5e, 0x01, 0xa2, 0x2b, 0xcd, 0x27, 0xcc, 0xaa, 0x9d, 0xe2, 0x11,
+	0x28, 0x56, 0xdc, 0xe0, 0xff, 0x65, 0x1e, 0x81, 0xe2, 0x91, 0x5f, 0x29, 0x3b, 0xf2, 0x37, 0xf8,
+	0xd5, 0x26, 0xa4, 0x43, 0xe1, 0xa8, 0x2c, 0xb7, 0x68, 0x65, 0x25, 0xf2, 0x10, 0x16, 0xd8, 0xe1,
+	0xd7, 0xf7, 0xe4, 0x67, 0x35, 0xf6, 0xd9, 0x8b, 0x0e, 0x86, 0xdc, 0x17, 0xce, 0x6f, 0x5b, 0x00,
+	0x59, 0x77, 0x49, 0x07, 0x56, 0x84, 0x5d, 0xe3, 0x1d, 0x1e, 0x75, 0x0f, 0xbc, 0xad, 0xdd, 0xcd,
+	0x83, 0x83, 0xee, 0x3e, 0xdf, 0xe6, 0x06, 0x62, 0x11, 0x02, 0x0b, 0x9b, 0x5b, 0xfc, 0x8c, 0x14,
+	0x58, 0x05, 0x0f, 0xb9, 0xbd, 0x83, 0x1c, 0x5a, 0x25, 0xcb, 0xb0, 0x88, 0xa7, 0x20, 0x3b, 0xfa,
+	0x04, 0x58, 0xc3, 0xcf, 0xd9, 0xd1, 0xb8, 0xad, 0xb0, 0x19, 0xe7, 0x27, 0x55, 0xa8, 0xe1, 0x65,
+	0x77, 0xfa, 0xc5, 0x58, 0xbf, 0x65, 0x57, 0x8c, 0x5b, 0xb6, 0xee, 0xf3, 0xa8, 0x1a, 0x3e, 0x0f,
+	0x16, 0x96, 0xb8, 0x4c, 0xa9, 0xb8, 0x12, 0xf1, 0x63, 0x42, 0x43, 0x32, 0x7a, 0x4c, 0x7b, 0x17,
+	0xe2, 0x68, 0xd0, 0x10, 0x14, 0xc1, 0xc4, 0x4f, 0xf9, 0xd7, 0x7c, 0x57, 0xaa, 0xb2, 0xa4, 0xb1,
+	0x2f, 0xe7, 0x32, 0x1a, 0xfb, 0xae, 0x03, 0x73, 0x41, 0x78, 0x1a, 0x4d, 0xc2, 0x3e, 0xd3, 0xf5,
+	0xf3, 0xae, 0x2c, 0xa2, 0x15, 0x3f, 0x66, 0x36, 0x5c, 0x30, 0xa2, 0xe2, 0x76, 0x98, 0x01, 0xec,
+	0x66, 0x18, 0xc4, 0x18, 0x37, 0xa0, 0x34, 0x54, 0x37, 0x43, 0x85, 0xe0, 0x4e, 0x14, 0x9e, 0x01,
+	0xb4, 0xc0, 0x7b, 0xec, 0x9e, 0xdf, 0x60, 0xa2, 0x5f, 0xc0, 0xf1, 0xce, 0x31, 0x19, 0xb3, 0x66,
+	0xb8, 0x5a, 0x17, 0x25, 0xf2, 0x2e, 0xac, 0x31, 0x0f, 0x7e, 0x5f, 0x79, 0x19, 0xbc, 0x98, 0xfa,
+	0x49, 0x14, 0xb2, 0xcb, 0x5f, 0xdd, 0x9d, 0x42, 0x75, 0x08, 0x3a, 0x28, 0x13, 0xe6, 0x92, 0x50,
+	0x77, 0xb4, 0x77, 0x61, 0x49, 0xc3, 0x84, 0x6a, 0x79, 0x1d, 0x66, 0x70, 0x65, 0xa4, 0xb5, 0x22,
+	0xaf, 0x7e, 0x58, 0xc9, 0xe5, 0x14, 0xb4, 0x3f, 0xb0, 0x58, 0xb4, 0x3f, 0xfe, 0xce, 0x82, 0xba,
+	0xa2, 0xbc, 0x40, 0x18, 0x36, 0xc4, 0x8e, 0xac, 0x18, 0x36, 0x89, 0xfa, 0x52, 0xb3, 0x49, 0xf8,
+	0x3e, 0x9c, 0x2e, 0x22, 0xda, 0x52, 0xd5, 0xcc, 0xa5, 0x5a, 0x83, 0x59, 0x31, 0x31, 0xfc, 0xe2,
+	0x21, 0x4a, 0xce, 0x86, 0x7e, 0x22, 0xa2, 0xcb, 0xae, 0xdb, 0x75, 0xbd, 0xc3, 0x83, 0xfd, 0xbd,
+	0x83, 0x2e, 0xdf, 0x2e, 0x1c, 0xd8, 0xd9, 0x61, 0x88, 0xe5, 0xb4, 0x61, 0xe1, 0x11, 0x4d, 0xf7,
+	0xc2, 0xb3, 0x48, 0x4e, 0xdb, 0xbf, 0x55, 0x60, 0x51, 0x41, 0x62, 0xd6, 0xd6, 0x61, 0x31, 0xe8,
+	0xd3, 0x30, 0x0d, 0xd2, 0x4b, 0xcf, 0x70, 0xfa, 0xe6, 0x61, 0x74, 0x85, 0xf9, 0xc3, 0xc0, 0x4f,
+	0x84, 0x2e, 0xe1, 0x05, 0x0c, 0x4d, 0xe0, 0x3d, 0x5c, 0x5e, 0xad, 0x95, 0xc9, 0xc8, 0x7d, 0xcd,
+	0xa5, 0x34, 0x34, 0xd8, 0x11, 0xe7, 0xce, 0x9a, 0xec, 0x13, 0xee, 0xf8, 0x29, 0x23, 0xa1, 0xf8,
+	0x72, 0x4e, 0xb8, 0xbe, 0x5c, 0xe9, 0x66, 0x40, 0x21, 0xca, 0x37, 0xcb, 0xb5, 0x72, 0x3e, 0xca,
+	0xa7, 0x45, 0x0a, 0xe7, 0x0b, 0x91, 0x42, 0xbc, 0x6e, 0x5c, 0x86, 0x3d, 0xda, 0xf7, 0xd2, 0x08,
+	0xdb, 0x0d, 0x42, 0x11, 0x3f, 0xca, 0xc3, 0xb8, 0x72, 0x29, 0x4d, 0xd2, 0x90, 0xa6, 0xc2, 0x0c,
+	0x92, 0x45, 0x5c, 0x39, 0x56, 0x85, 0x3b, 0x08, 0xea, 0xae, 0x28, 0x39, 0xdf, 0x63, 0x6e, 0x41,
+	0x15, 0xb6, 0x14, 0xda, 0xfd, 0x26, 0xd4, 0x79, 0xfb, 0xc9, 0xb9, 0x2f, 0x3c, 0x95, 0xf3, 0x0c,
+	0x38, 0x3e, 0xf7, 0x31, 0x4c, 0x63, 0x0c, 0x89, 0xab, 0x9e, 0x06, 0xc3, 0x76, 0xf9, 0x88, 0xee,
+	0xc0, 0x82, 0x0c, 0x88, 0x26, 0xde, 0x90, 0x9e, 0xa5, 0xd2, 0xbf, 0x1f, 0x4e, 0x46, 0xd8, 0x5c,
+	0xb2, 0x4f, 0xcf, 0x52, 0xe7, 0x00, 0x96, 0x84, 0x5a, 0x3e, 0x1c, 0x53, 0xd9, 0xf4, 0x17, 0xcb,
+	0x6e, 0x84, 0x8d, 0x07, 0xcb, 0xa6, 0x1e, 0x67, 0x41, 0x89, 0xdc, 0x99, 0xe1, 0xb8, 0x40, 0x74,
+	0x35, 0x2f, 0x18, 0x8a, 0x0b, 0x5d, 0x3e, 0x72, 0xa1, 0x63, 0x38, 0x6f, 0xc9, 0xa4, 0xd7, 0xc3,
+	0xbd, 0xc0, 0xdd, 0x19, 0xb2, 0xe8, 0xfc, 0xa7, 0x05, 0xcb, 0x8c, 0x9b, 0x3c, 0x71, 0x94, 0x47,
+	0xfc, 0xea, 0xdd, 0x6c, 0xf6, 0xb4, 0x12, 0xca, 0xaa, 0xee, 0x38, 0xe1, 0x05, 0x54, 0x63, 0x7d,
+	0x3a, 0x0c, 0x2e, 0x68, 0x7c, 0xe9, 0x99, 0xdb, 0xb2, 0x80, 0xa3, 0x03, 0x26, 0xf5, 0xe3, 0x01,
+	0x4d, 0xd9, 0x04, 0x33, 0xd9, 0x9c, 0x71, 0x75, 0x08, 0xc7, 0x8c, 0x8a, 0x17, 0x9d, 0x67, 0xa8,
+	0xba, 0x85, 0xb1, 0x65, 0x60, 0xc8, 0x65, 0xe4, 0x7f, 0x82, 0x3e, 0x3a, 0x2f, 0xb3, 0xb0, 0x74,
+	0xc8, 0x39, 0x87, 0xd5, 0x4d, 0xee, 0x4d, 0xc9, 0x0d, 0xfe, 0x7f, 0xbe, 0x46, 0xe5, 0xa3, 0x77,
+	0xbe, 0x06, 0x6b, 0xf9, 0x96, 0x94, 0x6b, 0x8b, 0x6d, 0xba, 0x98, 0x0e, 0xa9, 0x8f, 0x67, 0x75,
+	0x10, 0x8e, 0x27, 0x29, 0x77, 0xe4, 0xb7, 0xdc, 0x32, 0x92, 0xf3, 0x97, 0x16, 0xac, 0x1c, 0x8f,
+	0x87, 0x41, 0x8f, 0xfe, 0xe2, 0x7a, 0x3d, 0xcd, 0x85, 0x8c, 0xce, 0x18, 0xd6, 0x94, 0x17, 0x4d,
+	0x52, 0xe1, 0xe6, 0xd2, 0x10, 0x5d, 0xc7, 0xd6, 0x4c, 0x1d, 0x7b, 0x85, 0x15, 0x72, 0x5c, 0x58,
+	0xcd, 0x0d, 0x44, 0x4c, 0xca, 0xcf, 0xb1, 0x47, 0xfe, 0xde, 0x82, 0x25, 0x6e, 0x04, 0xa5, 0x7e,
+	0x3a, 0x49, 0xc4, 0x1e, 0xf9, 0x12, 0xb4, 0xb8, 0xeb, 0x40, 0xe8, 0xc3, 0x8e, 0x65, 0xd8, 0x5c,
+	0x47, 0x1c, 0xe5, 0x95, 0x77, 0xaf, 0xb9, 0x66, 0x65, 0xf2, 0x55, 0x68, 0xea, 0xa9, 0x0f, 0x22,
+	0xbe, 0x78, 0x43, 0xf6, 0xa6, 0xa0, 0x5e, 0x76, 0xaf, 0xb9, 0xc6, 0x07, 0xe4, 0x03, 0x00, 0x66,
+	0x58, 0x33, 0xb6, 0x9d, 0xaa, 0xf9, 0x79, 0x61, 0x47, 0xef, 0x5e, 0x73, 0xb5, 0xea, 0x0f, 0xe7,
+	0xf1, 0x50, 0x47, 0xdc, 0x79, 0x04, 0x2d, 0xa3, 0xa7, 0x46, 0x80, 0xab, 0xc9, 0x03, 0x5c, 0x85,
+	0xc0, 0x63, 0xa5, 0x24, 0xf0, 0xf8, 0x83, 0x0a, 0x10, 0x54, 0x49, 0x39, 0x01, 0x7a, 0x13, 0x16,
+	0xc4, 0x26, 0x33, 0x63, 0x1b, 0x39, 0x94, 0xb9, 0x84, 0xa3, 0xbe, 0xe1, 0xe0, 0x6f, 0xba, 0x3a,
+	0x44, 0x36, 0x80, 0x68, 0x45, 0x19, 0xc5, 0xe6, 0xfb, 0xbd, 0x84, 0x82, 0x27, 0x99, 0xf0, 0xaf,
+	0x0a, 0x17, 0xa8, 0x90, 0x46, 0xee, 0xbc, 0x2a, 0xa5, 0xb1, 0xbb, 0xc2, 0x04, 0x43, 0xe4, 0xea,
+	0xb2, 0xa5, 0xca, 0xcc, 0x06, 0x67, 0x4b, 0x28, 0xa5, 0x73, 0x56, 0xd8, 0xe0, 0x3a, 0xe8, 0xfc,
+	0xc0, 0x82, 0xf6, 0x43, 0x3f, 0xed, 0x9d, 0x6b, 0x73, 0x91, 0x1f, 0x9c, 0x55, 0x1c, 0xdc, 0xb4,
+	0xce, 0x56, 0xae, 0xd8, 0xd9, 0xaa, 0xd9, 0x59, 0xe7, 0x00, 0xae, 0xe7, 0x7b, 0x21, 0x57, 0xe4,
+	0x9d, 0x82, 0x23, 0x48, 0x86, 0xb0, 0x0a, 0x5f, 0xa8, 0x8a, 0xce, 0x2f, 0x43, 0xa7, 0xc8, 0x4f,
+	0xec, 0xac, 0xff, 0x0f, 0xed, 0x82, 0xb9, 0x60, 0x19, 0x1e, 0x75, 0x43, 0xc2, 0xdc, 0x42, 0x6d,
+	0xe7, 0xa7, 0x16, 0xb4, 0x91, 0xb3, 0xb1, 0xbf, 0xde, 0x07, 0x76, 0x06, 0x5c, 0x71, 0x7b, 0x19,
+	0x75, 0x7f, 0xfe, 0xdd, 0xf5, 0x1e, 0xd4, 0x19, 0xc3, 0x68, 0x4c, 0x43, 0xb1, 0xb9, 0x3a, 0xe6,
+	0xe6, 0xca, 0x8e, 0xdf, 0xdd, 0x6b, 0x6e, 0x56, 0x59, 0xdb, 0x5a, 0xcc, 0x3a, 0x65, 0xfd, 0x31,
+	0x57, 0xc0, 0xf9, 0x0d, 0x80, 0xb5, 0x3c, 0x25, 0x53, 0xdd, 0x3c, 0x68, 0x32, 0x0c, 0x46, 0xa7,
+	0x91, 0xf2, 0x7d, 0x5a, 0x7a, 0x14, 0xc6, 0x20, 0x91, 0x33, 0x58, 0x95, 0xf3, 0x89, 0xed, 0x67,
+	0x4b, 0x50, 0x61, 0x4b, 0x70, 0xdf, 0x9c, 0xaf, 0x5c, 0x7b, 0x12, 0xd6, 0x97, 0xb5, 0x9c, 0x1d,
+	0x19, 0x40, 0x47, 0xad, 0x9b, 0x30, 0x03, 0x34, 0xe3, 0x10, 0x9b, 0xfa, 0xcc, 0x8b, 0x9b, 0x32,
+	0xfc, 0x92, 0xee, 0x54, 0x66, 0xe4, 0x13, 0x78, 0x55, 0xd2, 0xd8, 0x41, 0x57, 0x6c, 0xae, 0x76,
+	0x95, 0x91, 0xed, 0xe0, 0xb7, 0x66, 0x9b, 0x2f, 0xe1, 0x6b, 0xff, 0x95, 0x05, 0x0b, 0x26, 0x37,
+	0x34, 0x23, 0x85, 0x53, 0x4c, 0xee, 0x56, 0x69, 0x4e, 0xe7, 0xe0, 0x2b, 0x5e, 0xd1, 0x75, 0x2f,
+	0x7a, 0xf5, 0x65, 0x31, 0xc6, 0xda, 0xd5, 0x62, 0x8c, 0x33, 0x65, 0x31, 0x46, 0xfb, 0x27, 0x15,
+	0x20, 0xc5, 0xd5, 0x25, 0x3b, 0x99, 0x8f, 0x80, 0x6f, 0xa8, 0xcf, 0x5e, 0x49, 0x40, 0x0a, 0xbe,
+	0x83, 0xfb, 0xb0, 0xac, 0x6f, 0x18, 0xdd, 0xae, 0x6d, 0xb9, 0x65, 0x24, 0xb4, 0xd6, 0x98, 0xb9,
+	0x9b, 0x78, 0x69, 0x30, 0x1c, 0x66, 0x3b, 0xab, 0xe5, 0x16, 0xf0, 0x5c, 0x80, 0xb4, 0xf6, 0xf2,
+	0x00, 0xe9, 0xcc, 0xcb, 0x03, 0xa4, 0xb3, 0xf9, 0x00, 0xa9, 0xfd, 0x29, 0xb4, 0x0c, 0x01, 0xf9,
+	0x85, 0x4d, 0x4e, 0xde, 0x7c, 0xe6, 0xa2, 0x60, 0x60, 0xf6, 0xf7, 0x2b, 0x40, 0x8a, 0x32, 0xfa,
+	0x7f, 0xd9, 0x05, 0x26, 0x70, 0x86, 0x9a, 0xa9, 0x0a, 0x81, 0xd3, 0x41, 0xdc, 0x02, 0x23, 0x3f,
+	0x9d, 0xc4, 0x78, 0x75, 0x34, 0x42, 0xfa, 0x79, 0x18, 0x65, 0x22, 0x5b, 0x49, 0x4f, 0x52, 0xc5,
+	0xfd, 0xae, 0x8c, 0xe4, 0xac, 0xc1, 0x8a, 0x18, 0xc0, 0x31, 0x46, 0xe3, 0x94, 0x43, 0xe0, 0x37,
+	0x2b, 0xd0, 0xd4, 0x09, 0x2f, 0x0c, 0x44, 0x4e, 0x33, 0x34, 0x1d, 0x68, 0x3e, 0xe3, 0x29, 0x2f,
+	0x3c, 0xf0, 0xc0, 0x4d, 0x05, 0x03, 0xc3, 0xc1, 0xf5, 0xa9, 0xdf, 0x1f, 0x06, 0x21, 0xcd, 0x0d,
+	0x2e, 0x07, 0xbf, 0x2c, 0x86, 0x88, 0xfb, 0x52, 0x1a, 0xa2, 0xcf, 0xb2, 0x6b, 0x6b, 0xd5, 0xcd,
+	0xa1, 0x68, 0xc6, 0x9c, 0xc6, 0x91, 0xdf, 0xef, 0xf9, 0x49, 0xea, 0xf9, 0x69, 0x4a, 0x47, 0xe3,
+	0x34, 0x11, 0xfe, 0xd7, 0x12, 0x8a, 0x73, 0x02, 0xab, 0xfa, 0x4c, 0x64, 0xfe, 0x91, 0x0f, 0x60,
+	0x41, 0xea, 0x33, 0xd6, 0x0d, 0x79, 0xe8, 0x2e, 0x9b, 0x02, 0xc3, 0xbe, 0x72, 0x73, 0x55, 0x31,
+	0x09, 0x64, 0xe1, 0xe1, 0x64, 0x34, 0xde, 0xa1, 0x54, 0x4b, 0x8d, 0xca, 0x67, 0x36, 0x19, 0xd3,
+	0x5e, 0xc9, 0x4d, 0x7b, 0xee, 0x46, 0x55, 0x7d, 0xf9, 0x8d, 0xaa, 0x56, 0x62, 0xaf, 0x53, 0x58,
+	0x54, 0xfd, 0x98, 0x9e, 0x62, 0x85, 0x6b, 0x3c, 0xa2, 0xe9, 0x79, 0x24, 0x05, 0x59, 0x94, 0x4a,
+	0x66, 0xbd, 0x5a, 0x36, 0xeb, 0xce, 0x17, 0x61, 0xe5, 0x63, 0x7f, 0x38, 0xa4, 0xe9, 0x43, 0x33,
+	0x61, 0xf1, 0xf5, 0x4c, 0x46, 0xa2, 0x70, 0x78, 0x29, 0x43, 0xf7, 0x02, 0x3b, 0x0c, 0x87, 0x97,
+	0x98, 0x7c, 0x93, 0xfb, 0x34, 0xcb, 0xd7, 0x31, 0xcf, 0x67, 0x59, 0xc4, 0x93, 0x5f, 0x6c, 0x48,
+	0xb3, 0x39, 0xe7, 0x01, 0xac, 0xe5, 0x09, 0x2f, 0x65, 0xf6, 0x5f, 0x15, 0x20, 0x5f, 0x9f, 0xd0,
+	0xf8, 0x92, 0x25, 0x1d, 0xaa, 0x9c, 0x83, 0xeb, 0x79, 0xa7, 0x16, 0x26, 0x2d, 0x7d, 0x48, 0x2f,
+	0x65, 0xae, 0x64, 0x25, 0xcb, 0x95, 0x5c, 0x9f, 0x1a, 0x9b, 0xb8, 0x42, 0x5a, 0x6e, 0xad, 0x2c,
+	0x2d, 0x77, 0x1d, 0xda, 0x67, 0x41, 0xe8, 0x0f, 0xbd, 0xde, 0x30, 0xbd, 0xf0, 0xfa, 0x74, 0x98,
+	0xfa, 0x22, 0x17, 0x7b, 0x81, 0xe1, 0x5b, 0xc3, 0xf4, 0x62, 0x1b, 0x51, 0xf2, 0x0a, 0x00, 0xbb,
+	0x70, 0xb2, 0xbe, 0xb3, 0x2d, 0x31, 0xc3, 0x5c, 0x3d, 0x7c, 0x30, 0xe8, 0x34, 0xc9, 0xd2, 0x38,
+	0x85, 0xfb, 0xf3, 0x8c, 0xd2, 0x7d, 0x2c, 0x93, 0x37, 0xa0, 0x15, 0x0c, 0xc2, 0x28, 0xa6, 0x7d,
+	0x76, 0xcc, 0x26, 0x9d, 0xf9, 0xdb, 0x55, 0x74, 0x43, 0x08, 0xf0, 0x00, 0x31, 0xf2, 0x85, 0xac,
+	0x12, 0xed, 0x0f, 0xa8, 0x8c, 0xda, 0xca, 0xfc, 0xeb, 0x6e, 0x7f, 0x40, 0xf7, 0xa3, 0x9e, 0x9f,
+	0x46, 0xb1, 0xfa, 0x10, 0xb1, 0x04, 0xfd, 0x2d, 0x22, 0xc1, 0x56, 0xce, 0x23, 0x70, 0x05, 0xc1,
+	0xd1, 0x23, 0x36, 0x9b, 0xce, 0x37, 0xa0, 0xa1, 0xb1, 0xc0, 0xe1, 0xc8, 0x03, 0x5d, 0x45, 0x3a,
+	0xea, 0x02, 0xd9, 0xeb, 0x93, 0xcf, 0xc0, 0x52, 0x3f, 0x88, 0x85, 0xff, 0x34, 0xa6, 0x17, 0x34,
+	0x4e, 0xe4, 0xad, 0xbd, 0xad, 0x08, 0x2e, 0xc7, 0x9d, 0x0f, 0x60, 0xd9, 0x58, 0x57, 0x95, 0xc6,
+	0x2c, 0xd3, 0x6f, 0xad, 0x17, 0xa4, 0xdf, 0xfe, 0x93, 0x05, 0xd5, 0xdd, 0x68, 0xac, 0xa7, 0x33,
+	0x59, 0x66, 0x3a, 0x93, 0x30, 0x48, 0x3c, 0x65, 0x6f, 0x54, 0xc4, 0x19, 0xa9, 0x83, 0xb8, 0x81,
+	0x30, 0x2e, 0x94, 0x46, 0xde, 0x19, 0x0f, 0xad, 0xc8, 0x0d, 0x64, 0xa2, 0x28, 0x55, 0xd9, 0x51,
+	0x8c, 0x7f, 0xe2, 0x96, 0x14, 0xc1, 0x23, 0xae, 0xe0, 0x45, 0x09, 0x4f, 0x01, 0xf3, 0x5b, 0x3d,
+	0x1a, 0x55, 0x46, 0x42, 0x2d, 0x83, 0x42, 0xa0, 0x05, 0x3f, 0x55, 0x19, 0x93, 0x6e, 0x66, 0xd8,
+	0xc8, 0x51, 0x55, 0x73, 0xfb, 0x57, 0x65, 0x00, 0x08, 0x8f, 0x46, 0x1e, 0xce, 0x65, 0xf8, 0x57,
+	0xf2, 0x19, 0xfe, 0xe8, 0x7d, 0xe4, 0xa5, 0x2c, 0xa7, 0x38, 0x03, 0xc8, 0xab, 0x98, 0x15, 0x3b,
+	0x96, 0x56, 0x26, 0xc8, 0x38, 0x75, 0x34, 0x76, 0x19, 0x9e, 0xf5, 0x03, 0x79, 0xe9, 0x71, 0xb9,
+	0x3c, 0xcc, 0xee, 0xbe, 0x92, 0xad, 0x3e, 0x09, 0x39, 0xd4, 0xb9, 0x0b, 0x8b, 0x28, 0xcb, 0x9a,
+	0x6b, 0x77, 0xea, 0xee, 0x76, 0x7e, 0xcd, 0x82, 0x79, 0x59, 0x99, 0xac, 0x43, 0x0d, 0x37, 0x46,
+	0xee, 0x6a, 0xa4, 0x32, 0x11, 0xb1, 0x9e, 0xcb, 0x6a, 0xa0, 0x2a, 0x66, 0xce, 0xc5, 0xec, 0x72,
+	0x20, 0x5d, 0x8b, 0x0a, 0xcb, 0xba, 0x9b, 0xb3, 0x50, 0x73, 0xa8, 0xf3, 0x63, 0x0b, 0x5a, 0x46,
+	0x1b, 0x2c, 0xbb, 0x09, 0xd5, 0x06, 0xbf, 0xf8, 0x88, 0x65, 0xd1, 0x21, 0xdd, 0x05, 0x5f, 0x31,
+	0x5d, 0xf0, 0xca, 0x0d, 0x5d, 0xd5, 0xdd, 0xd0, 0xf7, 0xa1, 0x2e, 0x6e, 0xd4, 0x54, 0xae, 0x84,
+	0xdc, 0xd1, 0xd8, 0xa2, 0xcc, 0xb1, 0xcc, 0x2a, 0x39, 0x1f, 0x40, 0x43, 0xa3, 0x60, 0x83, 0x21,
+	0x4d, 0x9f, 0x45, 0xf1, 0x53, 0xe9, 0xf3, 0x17, 0x45, 0x95, 0x02, 0x5c, 0xc9, 0x52, 0x80, 0x9d,
+	0x3f, 0xb6, 0xa0, 0x85, 0x52, 0x16, 0x84, 0x83, 0xa3, 0x68, 0x18, 0xf4, 0x2e, 0xd9, 0x2a, 0x4b,
+	0x81, 0x12, 0x0a, 0x4e, 0x4a, 0x9b, 0x09, 0xa3, 0xf4, 0x8e, 0x82, 0x90, 0x45, 0x2e, 0x85, 0xac,
+	0xa9, 0x32, 0xee, 0x41, 0x94, 0xe4, 0x53, 0x3f, 0x11, 0xe2, 0x2d, 0x2c, 0x2c, 0x03, 0xc4, 0x1d,
+	0x83, 0x40, 0xec, 0xa7, 0xd4, 0x1b, 0x05, 0xc3, 0x61, 0xc0, 0xeb, 0xf2, 0xbd, 0x56, 0x46, 0x72,
+	0xfe, 0xbc, 0x02, 0x0d, 0x19, 0x37, 0xec, 0x0f, 0x78, 0x42, 0x61, 0x5e, 0x2d, 0x69, 0x88, 0xa4,
+	0x1b, 0x37, 0x13, 0x0d, 0xc9, 0x2f, 0x60, 0xb5, 0xb8, 0x80, 0xe8, 0xb1, 0x8f, 0xfa, 0xf4, 0x6d,
+	0x76, 0x05, 0xe2, 0x7e, 0xb9, 0x0c, 0x90, 0xd4, 0x07, 0x8c, 0x3a, 0x93, 0x51, 0x19, 0x60, 0x5c,
+	0x7a, 0x66, 0x73, 0x97, 0x9e, 0xf7, 0xa0, 0x29, 0xd8, 0xb0, 0x79, 0xef, 0xcc, 0x19, 0xa2, 0x6c,
+	0xac, 0x89, 0x6b, 0xd4, 0x94, 0x5f, 0x3e, 0x90, 0x5f, 0xce, 0xbf, 0xec, 0x4b, 0x59, 0x13, 0x33,
+	0x05, 0xc5, 0xe4, 0x3d, 0x8a, 0xfd, 0xf1, 0xb9, 0x3c, 0x9c, 0xfb, 0xd0, 0xd4, 0x61, 0x72, 0x17,
+	0x66, 0xf8, 0xb1, 0x63, 0x3a, 0x33, 0xcc, 0xed, 0xc5, 0xab, 0x90, 0x75, 0x98, 0xe1, 0xa7, 0x4f,
+	0xc5, 0x90, 0x55, 0x6d, 0x8d, 0x5c, 0x5e, 0x01, 0x37, 0x3b, 0x3b, 0x6c, 0xcd, 0xcd, 0x6e, 0xea,
+	0x70, 0x0c, 0x34, 0x84, 0x7b, 0x7d, 0x67, 0x05, 0x73, 0xb3, 0x99, 0xd4, 0x6a, 0xd5, 0x9d, 0x1f,
+	0xce, 0x40, 0x43, 0x83, 0x71, 0xdf, 0x0e, 0xb0, 0xc3, 0x5e, 0x3f, 0xf0, 0x47, 0x34, 0xa5, 0xb1,
+	0x90, 0xd4, 0x1c, 0x8a, 0xf5, 0xfc, 0x8b, 0x01, 0xfa, 0x58, 0xbd, 0x3e, 0x1d, 0xc4, 0x94, 0x9f,
+	0x4c, 0x96, 0x9b, 0x43, 0xb1, 0x1e, 0x7a, 0xb4, 0xb5, 0x7a, 0x5c, 0x1e, 0x72, 0xa8, 0x0c, 0xe2,
+	0xf0, 0x39, 0xaa, 0x65, 0x41, 0x1c, 0x3e, 0x23, 0x79, 0x8d, 0x33, 0x53, 0xa2, 0x71, 0xde, 0x85,
+	0x35, 0xae, 0x5b, 0xc4, 0xde, 0xf4, 0x72, 0x62, 0x32, 0x85, 0x8a, 0x57, 0x49, 0xec, 0xb3, 0x14,
+	0xf0, 0x24, 0xf8, 0x1e, 0x4f, 0x0f, 0xb3, 0xdc, 0x02, 0x8e, 0x75, 0x71, 0x3b, 0x1a, 0x75, 0x79,
+	0xc6, 0x6d, 0x01, 0x67, 0x75, 0xfd, 0x4f, 0xcc, 0xba, 0x75, 0x51, 0x37, 0x87, 0x93, 0xf7, 0xa1,
+	0xc3, 0x0c, 0x1b, 0x3f, 0x15, 0xe6, 0x0b, 0xed, 0xab, 0x84, 0x56, 0x60, 0xab, 0x38, 0x95, 0x4e,
+	0x3e, 0x07, 0xab, 0x48, 0xeb, 0x4f, 0xd0, 0x53, 0x8d, 0x15, 0xe4, 0x87, 0x0d, 0xf6, 0x61, 0x39,
+	0x91, 0x39, 0x40, 0x27, 0x23, 0x2f, 0x08, 0x59, 0xd2, 0xba, 0x37, 0x88, 0x92, 0x24, 0x18, 0xb3,
+	0xa8, 0x6c, 0xcd, 0x2d, 0xa1, 0xe0, 0xec, 0x22, 0x7a, 0x8a, 0x9d, 0xee, 0x73, 0x47, 0xab, 0xf8,
+	0xa6, 0xc5, 0xbe, 0x99, 0x42, 0xc5, 0x59, 0xc8, 0x51, 0x78, 0x8a, 0x6e, 0xcb, 0x2d, 0xe0, 0x4e,
+	0x0b, 0x1a, 0xc7, 0x69, 0x34, 0x96, 0xa2, 0xb9, 0x00, 0x4d, 0x5e, 0x14, 0xb9, 0xe6, 0x37, 0xe1,
+	0x06, 0xdb, 0x4b, 0x27, 0xd1, 0x38, 0x1a, 0x46, 0x83, 0x4b, 0x23, 0x48, 0xfb, 0xb7, 0x16, 0x2c,
+	0x1b, 0x54, 0xe1, 0xf8, 0xfb, 0x1c, 0xdf, 0xd8, 0x6a, 0x52, 0x2c, 0x23, 0x0d, 0x0f, 0x77, 0x1d,
+	0xaf, 0xc8, 0x3d, 0xa8, 0x4f, 0xc4, 0xec, 0x6c, 0xc2, 0xa2, 0x5c, 0x1f, 0xf9, 0x21, 0xdf, 0x8b,
+	0x9d, 0xe2, 0x5e, 0x14, 0xdf, 0x2f, 0x88, 0x0f, 0x24, 0x8b, 0x2f, 0x43, 0x53, 0xcb, 0x6e, 0x90,
+	0x6e, 0x2d, 0x95, 0x0d, 0xa1, 0xdf, 0xd3, 0x65, 0x0f, 0x7a, 0x0a, 0x4c, 0x9c, 0xdf, 0xb1, 0x00,
+	0xb2, 0xde, 0xe1, 0xf6, 0xc8, 0x8e, 0x30, 0x8b, 0x05, 0x10, 0x33, 0x00, 0x2f, 0x1e, 0x2a, 0x20,
+	0x9b, 0x9d, 0x8a, 0x0d, 0x89, 0xa1, 0x21, 0xff, 0x16, 0x2c, 0x0e, 0x86, 0xd1, 0x29, 0x33, 0x29,
+	0xd8, 0xb3, 0x86, 0x44, 0x64, 0xdc, 0x2f, 0x70, 0x78, 0x47, 0xa0, 0xd9, 0x11, 0x5a, 0xd3, 0x8e,
+	0x50, 0xe7, 0x47, 0x15, 0x58, 0x2a, 0x8c, 0x79, 0xaa, 0xae, 0x21, 0x0f, 0x0a, 0x47, 0xc4, 0x94,
+	0xe0, 0x08, 0x33, 0x77, 0x8f, 0x5e, 0xea, 0xcd, 0xfa, 0x00, 0x16, 0x62, 0xae, 0x83, 0xa5, 0x82,
+	0xae, 0xbd, 0x40, 0x41, 0xb7, 0x62, 0xbd, 0x48, 0xfe, 0x1f, 0xb4, 0xfd, 0xfe, 0x05, 0x8d, 0xd3,
+	0x80, 0x79, 0x2b, 0x42, 0x99, 0x9b, 0x53, 0x77, 0x17, 0x35, 0x9c, 0xd9, 0x1e, 0x6f, 0xc1, 0xa2,
+	0xcc, 0x59, 0x90, 0x35, 0xc5, 0xe3, 0xc4, 0x0c, 0xc6, 0x8a, 0xce, 0x1f, 0xc9, 0xa8, 0xa4, 0xb9,
+	0x86, 0xd3, 0x67, 0x44, 0x1f, 0x5d, 0x25, 0x37, 0xba, 0x37, 0x44, 0x30, 0xa0, 0x2f, 0xbd, 0x06,
+	0x55, 0x2d, 0xe5, 0xb5, 0x2f, 0x22, 0xba, 0xe6, 0x94, 0xd6, 0xae, 0x32, 0xa5, 0xce, 0x06, 0x3e,
+	0xbf, 0x4a, 0x37, 0x71, 0x05, 0xe5, 0xf1, 0x70, 0x13, 0xea, 0x21, 0x7d, 0xe6, 0xf1, 0x25, 0x16,
+	0xce, 0x8f, 0x90, 0x3e, 0x63, 0x75, 0x30, 0x9d, 0x22, 0xab, 0x2f, 0x76, 0xdd, 0xef, 0x56, 0x60,
+	0x6e, 0x2f, 0xbc, 0x88, 0x82, 0x1e, 0xbb, 0x4c, 0x8f, 0xe8, 0x28, 0x92, 0x97, 0x69, 0xfc, 0x1b,
+	0x6d, 0x23, 0x96, 0x8a, 0x3f, 0x4e, 0x45, 0x9c, 0x45, 0x16, 0xd1, 0x4e, 0x88, 0xb3, 0xc7, 0x71,
+	0x5c, 0xda, 0x34, 0x84, 0xe5, 0x32, 0xe8, 0x39, 0x6b, 0xa2, 0x94, 0x3d, 0xd6, 0x9a, 0xd1, 0x1e,
+	0x6b, 0x61, 0x3b, 0x22, 0xb7, 0x57, 0x24, 0x9a, 0xcb, 0x22, 0xbb, 0x9b, 0xc4, 0x94, 0xbb, 0x07,
+	0x99, 0xc5, 0x31, 0x27, 0xee, 0x26, 0x3a, 0x88, 0x56, 0x09, 0xff, 0x80, 0xd7, 0xe1, 0x5a, 0x5b,
+	0x87, 0xd0, 0x4a, 0xcb, 0xbf, 0x4c, 0xe5, 0x09, 0x8f, 0x79, 0xd8, 0xf9, 0x08, 0xc8, 0x66, 0xbf,
+	0x2f, 0x66, 0x45, 0xdd, 0xb5, 0xb2, 0xf1, 0x58, 0xc6, 0x78, 0x4a, 0xf8, 0x56, 0xca, 0xf9, 0x76,
+	0xa1, 0x71, 0xa4, 0xbd, 0xb5, 0x64, 0x13, 0x28, 0x5f, 0x59, 0x8a, 0x49, 0xd7, 0x10, 0xad, 0xc1,
+	0x8a, 0xde, 0xa0, 0xf3, 0x05, 0x20, 0x98, 0x01, 0xa3, 0xfa, 0x97, 0x3d, 0xee, 0x94, 0xce, 0x78,
+	0xcd, 0x3b, 0x21, 0x30, 0xe6, 0x9d, 0xd8, 0x84, 0x65, 0xe3, 0x43, 0x95, 0x97, 0x37, 0x1f, 0x70,
+	0x48, 0xea, 0xcf, 0x05, 0x21, 0x78, 0xb2, 0xa6, 0xa2, 0xa3, 0x39, 0x24, 0x40, 0x33, 0x87, 0xb7,
+	0x02, 0x73, 0x62, 0x68, 0x85, 0x8c, 0x45, 0x3e, 0x30, 0x03, 0x2b, 0x7f, 0xb0, 0x57, 0x5c, 0xe9,
+	0x6a, 0xd9, 0x4a, 0xe3, 0x2b, 0x29, 0x3f, 0x3d, 0x67, 0x96, 0x7e, 0xdd, 0x65, 0x7f, 0xcb, 0x1b,
+	0xe7, 0x4c, 0x76, 0xe3, 0xfc, 0x1c, 0xcc, 0x26, 0x2c, 0x42, 0xc4, 0xc4, 0x69, 0xe1, 0xc1, 0x2d,
+	0xe9, 0xe9, 0xe2, 0xdd, 0x90, 0xff, 0xf3, 0x28, 0x92, 0x2b, 0xea, 0xea, 0x59, 0xac, 0x22, 0x0f,
+	0x67, 0xce, 0xcc, 0x62, 0xe5, 0x28, 0x79, 0x1b, 0xe6, 0x95, 0x3b, 0x6e, 0x9e, 0x4d, 0xd9, 0xaa,
+	0xc9, 0x7f, 0x93, 0x53, 0x5d, 0x55, 0xcd, 0x79, 0x02, 0x2d, 0xa3, 0x4d, 0x4c, 0x45, 0x7d, 0x72,
+	0xf0, 0xe1, 0xc1, 0xe1, 0xc7, 0x07, 0xed, 0x6b, 0x98, 0xc6, 0xba, 0x77, 0xb0, 0x77, 0xb2, 0xb7,
+	0x79, 0xc2, 0x12, 0xfb, 0x59, 0xd1, 0xdb, 0xd9, 0xdf, 0x7b, 0xb4, 0x7b, 0xd2, 0xae, 0x60, 0xf1,
+	0xf8, 0xc9, 0xd6, 0x56, 0xb7, 0xbb, 0xdd, 0xdd, 0x6e, 0x57, 0x31, 0x7d, 0x10, 0x13, 0x09, 0xd9,
+	0x8b, 0x81, 0x6f, 0xc3, 0x82, 0xd9, 0xa4, 0x9a, 0x1f, 0xcb, 0x9c, 0x9f, 0x9c, 0x9f, 0xa7, 0x38,
+	0xd2, 0x6a, 0xd9, 0x48, 0xe5, 0x4b, 0x19, 0xd1, 0x86, 0x72, 0xba, 0x3e, 0x84, 0x15, 0x13, 0xce,
+	0x64, 0x49, 0x2c, 0x74, 0x5e, 0x96, 0x44, 0x55, 0x57, 0xd1, 0x31, 0x5b, 0x7c, 0x9b, 0x0e, 0x69,
+	0x4a, 0x37, 0x87, 0xc3, 0x3c, 0xff, 0x9b, 0x70, 0xa3, 0x84, 0x26, 0x74, 0xd6, 0x53, 0x58, 0x3e,
+	0x89, 0xfd, 0xde, 0xd3, 0x23, 0xf3, 0x45, 0xb9, 0x53, 0xfa, 0xbc, 0xd9, 0xc0, 0xf0, 0xfa, 0x34,
+	0xfd, 0x7d, 0x73, 0x19, 0xc9, 0xd9, 0x81, 0xa5, 0x6d, 0x7a, 0x3a, 0x19, 0xec, 0xd3, 0x8b, 0x2c,
+	0x2e, 0x4a, 0xa0, 0x96, 0x9c, 0x47, 0xcf, 0xc4, 0x26, 0x63, 0x7f, 0xa3, 0xbb, 0x67, 0x88, 0x75,
+	0xbc, 0x64, 0x4c, 0x7b, 0x82, 0x63, 0x9d, 0x21, 0xc7, 0x63, 0xda, 0x73, 0xde, 0x05, 0xa2, 0xf3,
+	0x11, 0xf3, 0x85, 0x6a, 0x6b, 0x72, 0xea, 0x25, 0x97, 0x49, 0x4a, 0x47, 0x52, 0x63, 0xeb, 0x90,
+	0xf3, 0x16, 0x34, 0x8f, 0x7c, 0x7c, 0x30, 0x2b, 0x5e, 0x5c, 0xe3, 0x6d, 0xdf, 0xbf, 0x44, 0x9d,
+	0xa2, 0x6e, 0xfb, 0x8c, 0xec, 0xc4, 0x30, 0xcb, 0x2b, 0x22, 0xd3, 0x3e, 0x4d, 0xd2, 0x20, 0xe4,
+	0x01, 0x4d, 0xc1, 0x54, 0x83, 0x0a, 0x53, 0x55, 0x29, 0xd9, 0xa3, 0xc2, 0x28, 0x97, 0x2f, 0xb2,
+	0xc4, 0x66, 0x34, 0x30, 0x3c, 0x51, 0x98, 0x37, 0x76, 0x1c, 0xc5, 0x72, 0x19, 0x9c, 0xdf, 0xb7,
+	0xa0, 0x2d, 0x4e, 0x2c, 0x45, 0x23, 0xaf, 0x1b, 0xc7, 0x5b, 0xe9, 0x23, 0x94, 0x3b, 0xd0, 0x62,
+	0xd7, 0x5c, 0xe5, 0xde, 0x11, 0x3e, 0x28, 0x03, 0xc4, 0xb1, 0xc9, 0xa8, 0xcc, 0x28, 0x18, 0x8a,
+	0x4e, 0xe9, 0x90, 0xf4, 0x10, 0xc5, 0xbe, 0xf0, 0x22, 0x5b, 0xae, 0x2a, 0x3b, 0x47, 0xb0, 0xa4,
+	0xf5, 0x57, 0x39, 0xc7, 0x65, 0x02, 0x11, 0x77, 0x02, 0x99, 0x81, 0xee, 0xfc, 0x50, 0x5c, 0xa3,
+	0xb2, 0xf3, 0x27, 0x16, 0x9b, 0x02, 0x61, 0xe3, 0xa9, 0xa7, 0x96, 0xb3, 0xdc, 0xec, 0xe2, 0x02,
+	0xb2, 0x7b, 0xcd, 0x15, 0x65, 0xf2, 0xf9, 0x2b, 0x5a, 0x4e, 0x2a, 0x07, 0x63, 0xca, 0xdc, 0x54,
+	0xcb, 0xe6, 0xe6, 0x05, 0x23, 0x7f, 0x38, 0x07, 0x33, 0x49, 0x2f, 0x1a, 0x53, 0x67, 0x19, 0x96,
+	0xb4, 0xfe, 0x8a, 0x1d, 0x75, 0x0c, 0xd7, 0x39, 0x82, 0x7d, 0x10, 0x3a, 0x51, 0x8c, 0xe5, 0xd5,
+	0x92, 0x95, 0xd3, 0xbb, 0xd6, 0x81, 0xb9, 0x7e, 0x90, 0xf8, 0xa7, 0x43, 0xe9, 0xbc, 0x94, 0x45,
+	0xdc, 0xdf, 0x45, 0xa6, 0xbc, 0xc1, 0x07, 0xff, 0x7a, 0x07, 0xea, 0xea, 0x72, 0x4c, 0xbe, 0x03,
+	0x2d, 0xc3, 0x6d, 0x4e, 0x6e, 0x8a, 0x29, 0x29, 0xf3, 0xc3, 0xdb, 0xb7, 0xca, 0x89, 0x62, 0x28,
+	0xaf, 0x7e, 0xff, 0xa7, 0xff, 0xf8, 0xe3, 0x4a, 0x87, 0xac, 0xdd, 0xbb, 0x78, 0xfb, 0x9e, 0xf0,
+	0x8b, 0xdf, 0x63, 0xf1, 0x24, 0x9e, 0xc2, 0xf7, 0x14, 0x16, 0x4c, 0xb7, 0x3a, 0xb9, 0x65, 0xce,
+	0x7f, 0xae, 0xb5, 0x57, 0xa6, 0x50, 0x45, 0x73, 0xb7, 0x58, 0x73, 0x6b, 0x64, 0x45, 0x6f, 0x4e,
+	0x5d, 0x5a, 0x29, 0x4b, 0xba, 0xd4, 0x7f, 0x1f, 0x84, 0x48, 0x7e, 0xe5, 0xbf, 0x1b, 0x62, 0xdf,
+	0x28, 0xfe, 0x16, 0x88, 0xf8, 0xf1, 0x10, 0xa7, 0xc3, 0x9a, 0x22, 0xa4, 0x8d, 0x4d, 0xe9, 0x3f,
+	0x0f, 0x42, 0xbe, 0x05, 0x75, 0xf5, 0xfa, 0x9c, 0x5c, 0xd7, 0xde, 0xda, 0xeb, 0xef, 0xd9, 0xed,
+	0x4e, 0x91, 0x20, 0xaf, 0x5e, 0x8c, 0xf3, 0xaa, 0x53, 0xe0, 0xfc, 0xbe, 0x75, 0x97, 0xec, 0xc3,
+	0xaa, 0x38, 0xeb, 0x4f, 0xe9, 0xcf, 0x32, 0x92, 0x92, 0x5f, 0x35, 0xb9, 0x6f, 0x91, 0x0f, 0x60,
+	0x5e, 0x3e, 0xc8, 0x27, 0x6b, 0xe5, 0xbf, 0x0a, 0x60, 0x5f, 0x2f, 0xe0, 0x62, 0xa7, 0x6e, 0x02,
+	0x64, 0xef, 0xcf, 0x49, 0x67, 0xda, 0x33, 0x79, 0xfb, 0x46, 0x09, 0x45, 0xb0, 0x18, 0xc0, 0x52,
+	0xe1, 0x79, 0x3b, 0x79, 0x2d, 0xab, 0x5f, 0xfa, 0xf0, 0xfd, 0x05, 0x0c, 0x9d, 0x35, 0x36, 0x77,
+	0x6d, 0xb2, 0x80, 0x73, 0x17, 0xd2, 0x67, 0x32, 0x01, 0x6d, 0x1b, 0x1a, 0xda, 0x9b, 0x76, 0x22,
+	0x39, 0x14, 0xdf, 0xc3, 0xdb, 0x76, 0x19, 0x49, 0x74, 0xf7, 0x6b, 0xd0, 0x32, 0x1e, 0xa7, 0xab,
+	0x9d, 0x51, 0xf6, 0xf4, 0xdd, 0xbe, 0x55, 0x4e, 0x14, 0xbc, 0xbe, 0x09, 0x0d, 0xed, 0x29, 0x39,
+	0xd1, 0x32, 0x5c, 0x72, 0x4f, 0xc5, 0x6d, 0xbb, 0x8c, 0x24, 0xc6, 0xbb, 0xc2, 0xc6, 0xbb, 0xe0,
+	0xd4, 0x71, 0xbc, 0xec, 0x62, 0x8f, 0x42, 0xf2, 0x1d, 0x58, 0x30, 0x9f, 0x90, 0xab, 0x5d, 0x55,
+	0xfa, 0x18, 0xdd, 0x7e, 0x65, 0x0a, 0xd5, 0x14, 0xc8, 0xbb, 0xcb, 0xaa, 0x91, 0x7b, 0x9f, 0x0a,
+	0x27, 0xf0, 0x73, 0xf2, 0x75, 0xa8, 0xab, 0x0c, 0x70, 0x92, 0x3d, 0xa9, 0x37, 0xf3, 0xc4, 0xed,
+	0x4e, 0x91, 0x20, 0x98, 0x2f, 0x31, 0xe6, 0x0d, 0x92, 0x8d, 0x80, 0x3c, 0x82, 0x65, 0x25, 0xe3,
+	0x2a, 0xa3, 0x3b, 0x51, 0x63, 0x28, 0x4d, 0x1c, 0xb7, 0xdb, 0x79, 0xea, 0x7d, 0x8b, 0x3c, 0x86,
+	0x39, 0x91, 0x65, 0x4d, 0x56, 0xb3, 0xed, 0xa1, 0x79, 0xe4, 0xec, 0xb5, 0x3c, 0x2c, 0x7a, 0xb5,
+	0xcc, 0x7a, 0xd5, 0x22, 0x0d, 0xec, 0xd5, 0x80, 0xa6, 0x01, 0xf2, 0x18, 0xc2, 0xa2, 0x19, 0xb4,
+	0xd7, 0xfb, 0x54, 0x92, 0x2e, 0x64, 0xbf, 0x32, 0x85, 0x5a, 0xa6, 0xad, 0xa4, 0x96, 0xba, 0x27,
+	0x33, 0xa1, 0xce, 0xa0, 0xa5, 0x07, 0x82, 0x13, 0x25, 0x6c, 0x65, 0x71, 0x77, 0xfb, 0x56, 0x39,
+	0x51, 0xb4, 0x64, 0xb3, 0x96, 0x56, 0x08, 0xc1, 0x96, 0x78, 0x20, 0x59, 0xb5, 0xf3, 0x1e, 0xcc,
+	0x89, 0x38, 0xae, 0x9a, 0x24, 0x33, 0xbe, 0x6c, 0xaf, 0xe5, 0x61, 0x21, 0xc2, 0xbf, 0x02, 0x4d,
+	0xfd, 0x81, 0x36, 0xb1, 0xb5, 0x45, 0xce, 0x3d, 0xb4, 0xb6, 0x6f, 0x96, 0xd2, 0x4c, 0x29, 0x26,
+	0x4d, 0x7d, 0x22, 0x50, 0x8a, 0xcd, 0x17, 0x91, 0xd9, 0xd9, 0x50, 0xf6, 0x96, 0xdb, 0x7e, 0x65,
+	0x0a, 0xd5, 0x94, 0x62, 0xb2, 0x6c, 0xcc, 0x36, 0xbf, 0xf6, 0x93, 0x8f, 0x60, 0x4d, 0x89, 0x9c,
+	0xfe, 0xac, 0x27, 0xd3, 0x46, 0xd3, 0x9e, 0x52, 0xda, 0x37, 0xa6, 0xbe, 0x06, 0xba, 0x6f, 0x19,
+	0xa2, 0xac, 0x1e, 0x4c, 0x66, 0x03, 0x29, 0x7d, 0x83, 0x69, 0xb7, 0xf3, 0xd4, 0xfb, 0x16, 0xf9,
+	0x36, 0x2c, 0x1a, 0x4f, 0xa7, 0xa2, 0x98, 0xbc, 0x71, 0x85, 0x97, 0x55, 0xb6, 0xf3, 0xc2, 0x4a,
+	0x6c, 0xe2, 0xd6, 0xad, 0xfb, 0x16, 0xf9, 0x26, 0x2c, 0x6a, 0xc9, 0x46, 0xc7, 0x97, 0x61, 0x4f,
+	0xa9, 0xa4, 0x62, 0x26, 0xa2, 0x5d, 0x66, 0x24, 0x39, 0xd7, 0xd9, 0x04, 0x2f, 0x39, 0xc6, 0x2a,
+	0xa2, 0x3a, 0xda, 0x82, 0x86, 0xc6, 0xe3, 0x45, 0x7c, 0xaf, 0x6b, 0x24, 0x3d, 0xa5, 0xf0, 0xbe,
+	0x45, 0x8e, 0x4b, 0xb2, 0x33, 0x5f, 0x9d, 0x96, 0xfe, 0x28, 0xd8, 0xbd, 0x36, 0x95, 0x2e, 0x24,
+	0xf8, 0xf7, 0xf0, 0x87, 0x7f, 0xb4, 0x7c, 0x77, 0x62, 0xb8, 0x0c, 0x73, 0xdc, 0x3a, 0x3a, 0x4d,
+	0xef, 0x9d, 0x73, 0xc0, 0x46, 0xbe, 0x7b, 0x77, 0xc7, 0x10, 0xad, 0x4f, 0x0d, 0x8b, 0x7a, 0x43,
+	0xff, 0x51, 0xa0, 0xe7, 0x79, 0xa2, 0x9e, 0x90, 0xfb, 0x9c, 0x69, 0xae, 0x05, 0x33, 0x45, 0x5c,
+	0x89, 0x4c, 0x69, 0x8e, 0xba, 0xfd, 0xca, 0x14, 0x6a, 0x76, 0x70, 0x19, 0xb9, 0xd5, 0x4a, 0x97,
+	0x94, 0xa5, 0x8e, 0xdb, 0xb7, 0xca, 0x89, 0x82, 0xd7, 0xfb, 0xfc, 0xc7, 0xcf, 0xa4, 0x83, 0x81,
+	0x68, 0xe6, 0x41, 0x5e, 0x3c, 0xf4, 0x1f, 0xf9, 0x62, 0x52, 0xf6, 0xab, 0xb0, 0xa8, 0x7d, 0xcb,
+	0xa4, 0xec, 0xaa, 0xdf, 0x3b, 0x77, 0xd8, 0x24, 0xbf, 0xea, 0xdc, 0x30, 0x26, 0x39, 0x6f, 0x1f,
+	0x6d, 0x42, 0x43, 0xfb, 0xad, 0xad, 0xec, 0xa0, 0x2f, 0xfc, 0xfe, 0xd6, 0xf4, 0x4e, 0x8e, 0x60,
+	0x51, 0xab, 0x6e, 0x6c, 0x85, 0x2b, 0xb2, 0x71, 0xee, 0xb2, 0xbe, 0xde, 0x71, 0x5e, 0x9b, 0xda,
+	0xd7, 0x7b, 0x2c, 0x1b, 0x00, 0x7b, 0xfc, 0x15, 0xa8, 0xab, 0x5f, 0xbc, 0x52, 0x07, 0x68, 0xfe,
+	0xf7, 0xb9, 0xec, 0x4e, 0x91, 0x20, 0xd6, 0xe3, 0x08, 0x20, 0xf3, 0x8f, 0x91, 0x9c, 0xb3, 0x48,
+	0x69, 0xa7, 0xa2, 0x0b, 0xcd, 0xdc, 0xaf, 0xd2, 0xa7, 0xc4, 0xcd, 0x87, 0xa6, 0xe6, 0x99, 0x4a,
+	0xd4, 0xe8, 0x8b, 0x7e, 0x2e, 0xdb, 0x2e, 0x23, 0x09, 0xfe, 0x6f, 0x30, 0xfe, 0xaf, 0x90, 0x9b,
+	0x3a, 0xff, 0x7b, 0x9f, 0xea, 0x7e, 0xb1, 0xe7, 0xe4, 0x23, 0x68, 0xed, 0x47, 0xd1, 0xd3, 0xc9,
+	0x58, 0x0e, 0x80, 0x98, 0x1e, 0x0a, 0xf4, 0xcd, 0xd9, 0xb9, 0x41, 0x39, 0xaf, 0x33, 0xce, 0x37,
+	0xc9, 0x0d, 0x93, 0x73, 0xe6, 0xad, 0x7b, 0x4e, 0x7c, 0x58, 0x52, 0x8a, 0x57, 0x0d, 0xc4, 0x36,
+	0xf9, 0x18, 0x4a, 0x37, 0xdf, 0x86, 0x71, 0x73, 0x51, 0x6d, 0x24, 0x92, 0xe7, 0x7d, 0x8b, 0x1c,
+	0x41, 0x73, 0x9b, 0xe2, 0x43, 0x53, 0x71, 0xd1, 0x5f, 0xce, 0x7a, 0xae, 0x1c, 0x04, 0x76, 0xcb,
+	0x00, 0xcd, 0x23, 0x7f, 0xec, 0x5f, 0xc6, 0xf4, 0xbb, 0xf7, 0x3e, 0x15, 0x1e, 0x84, 0xe7, 0xf2,
+	0x40, 0x15, 0x43, 0x37, 0x0f, 0xd4, 0x9c, 0x4f, 0xc6, 0xbe, 0x59, 0x4a, 0x2b, 0x3b, 0x50, 0xa5,
+	0x8b, 0x87, 0x0c, 0x61, 0xa9, 0xe0, 0xc6, 0x51, 0xe7, 0xdb, 0x34, 0xe7, 0x8f, 0x7d, 0x7b, 0x7a,
+	0x05, 0xb3, 0xb5, 0xbb, 0x66, 0x6b, 0x5f, 0x82, 0xa6, 0xee, 0x17, 0x52, 0x83, 0x29, 0x71, 0x16,
+	0xd9, 0x39, 0xb7, 0x14, 0x53, 0xf7, 0xad, 0x6d, 0xca, 0xa7, 0x9a, 0xc7, 0x74, 0x73, 0x4f, 0x5b,
+	0xf5, 0xf8, 0xaf, 0xbd, 0x5c, 0x42, 0x33, 0x0d, 0x4b, 0x16, 0x50, 0x25, 0xdf, 0x82, 0xc6, 0x23,
+	0x9a, 0xca, 0x20, 0xae, 0xba, 0xf1, 0xe4, 0xa2, 0xba, 0x76, 0x49, 0x0c, 0xd8, 0xb9, 0xcd, 0xb8,
+	0xd9, 0xa4, 0xa3, 0xb8, 0xdd, 0xc3, 0xa8, 0x30, 0xd7, 0xe3, 0x5e, 0xd0, 0x7f, 0x4e, 0x7e, 0x89,
+	0x31, 0x57, 0x19, 0x1e, 0x6b, 0x5a, 0xd4, 0x4b, 0x67, 0xbe, 0x98, 0xc3, 0xcb, 0x38, 0x63, 0x2c,
+	0x44, 0x33, 0xb1, 0x43, 0x68, 0x68, 0xe9, 0x46, 0x6a, 0x3b, 0x16, 0x53, 0xcb, 0x6c, 0xbb, 0x8c,
+	0x24, 0x56, 0x69, 0x9d, 0xb5, 0xe3, 0x90, 0xdb, 0x59, 0x3b, 0x3c, 0x23, 0x29, 0x6b, 0xe9, 0xde,
+	0xa7, 0xfe, 0x28, 0x7d, 0x4e, 0x3e, 0x66, 0xef, 0x15, 0xf5, 0x40, 0x75, 0x76, 0xe3, 0xca, 0xc7,
+	0xb4, 0x6d, 0x52, 0x24, 0x99, 0xb7, 0x30, 0xde, 0x14, 0x33, 0xa0, 0x3f, 0x0f, 0x80, 0x41, 0xc6,
+	0x6d, 0x9f, 0x8e, 0xa2, 0x30, 0xd3, 0xfc, 0x59, 0x18, 0xd2, 0x5e, 0x36, 0x30, 0xa1, 0xe1, 0x3e,
+	0xd6, 0xee, 0xbc, 0x46, 0x9c, 0x5f, 0x8a, 0xe6, 0xd4, 0x48, 0xa5, 0x6d, 0x97, 0xd5, 0x50, 0x36,
+	0x05, 0xbb, 0xfe, 0xf2, 0x10, 0x8c, 0x76, 0xfd, 0x35, 0x62, 0x38, 0xf6, 0xf5, 0x02, 0x9e, 0x5d,
+	0x7f, 0x33, 0x17, 0xa2, 0xba, 0xfe, 0x16, 0xbc, 0x93, 0xf6, 0x8d, 0x12, 0x8a, 0x52, 0xdd, 0xf5,
+	0xcc, 0x29, 0x27, 0x1b, 0xca, 0xbb, 0xf0, 0xec, 0x4e, 0x91, 0x20, 0x96, 0xb4, 0xcd, 0xe6, 0x19,
+	0xc8, 0x3c, 0xce, 0x33, 0x4b, 0x90, 0x3a, 0x91, 0x6f, 0xba, 0x77, 0xb0, 0xa4, 0xb1, 0x34, 0x5c,
+	0x62, 0x76, 0xa7, 0x48, 0x30, 0x2f, 0x3e, 0x8e, 0x62, 0x89, 0x07, 0xc2, 0x31, 0xb4, 0xf3, 0xbe,
+	0x23, 0x65, 0x7b, 0x4d, 0xf1, 0x54, 0xd9, 0xaf, 0x4d, 0xa5, 0xf3, 0x96, 0x4e, 0x67, 0xd9, 0x0f,
+	0xd5, 0xbe, 0xf3, 0xdf, 0x03, 0x00, 0x45, 0x3b, 0x0b, 0xab, 0xda, 0x56, 0x00, 0x00,
 }
@@ -0,0 +1,456 @@
+package lnwallet
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+// FundingBatch allows a set of single funder channels to be opened with
+// several distinct peers using a single, shared funding transaction. The
+// batch owns the inputs selected to fund all of its channels, as well as the
+// single change output of the funding transaction. Channel reservations are
+// added to the batch via InitBatchedChannelReservation.
+//
+// The lifetime of a batch mirrors that of a regular reservation:
+//  1. LightningWallet.InitFundingBatch
+//     * Coins sufficient to fund all channels within the batch are selected
+//     and locked.
+//  2. FundingBatch.Finalize
+//     * Once the contributions of all remote parties have been processed,
+//     the shared funding transaction is assembled, and the commitment
+//     transactions of each channel are created and signed.
+//  3. FundingBatch.Publish
+//     * Once the commitment signatures of all remote parties have been
+//     verified, each channel is committed to disk as pending, and the
+//     funding transaction is broadcast.
+//
+// If at any point a single channel within the batch fails, the entire batch
+// MUST be cancelled via FundingBatch.Cancel, which cancels all reservations
+// and releases the locked inputs.
+type FundingBatch struct {
+	// This mutex MUST be held when either reading or modifying any of the
+	// fields below.
+	sync.Mutex
+
+	// totalAmt is the total amount of satoshis that will be committed to
+	// channels within this batch.
+	totalAmt btcutil.Amount
+
+	// numChannels is the number of channels this batch was created for.
+	numChannels int
+
+	// contribution houses the inputs and change output selected to fund
+	// the entire batch.
+	contribution *ChannelContribution
+
+	// reservations is the set of channel reservations which are to be
+	// funded by this batch.
+	reservations []*ChannelReservation
+
+	// fundingTx is the shared funding transaction of the batch. This will
+	// only be populated once the batch has been finalized.
+	fundingTx *wire.MsgTx
+
+	// ourFundingInputScripts are the input scripts which spend the
+	// wallet's inputs to the funding transaction in order of the sorted
+	// inputs.
+	ourFundingInputScripts []*InputScript
+
+	wallet *LightningWallet
+}
+
+// initFundingBatchMsg is sent to the wallet in order to create a new funding
+// batch. Coin selection for all channels of the batch is performed while
+// processing this message.
+type initFundingBatchMsg struct {
+	// totalAmt is the sum of the funding amounts of all channels within
+	// the batch.
+	totalAmt btcutil.Amount
+
+	// numChannels is the number of channels that will be funded by the
+	// batch, each of which will add a funding output to the transaction.
+	numChannels int
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	resp chan *FundingBatch
+}
+
+// finalizeFundingBatchMsg is sent to the wallet once the contributions of all
+// remote parties in a batch have been processed. Upon receipt, the wallet
+// will assemble and sign the shared funding transaction.
+type finalizeFundingBatchMsg struct {
+	batch *FundingBatch
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+}
+
+// publishFundingBatchMsg is sent to the wallet once the commitment signatures
+// of all remote parties in a batch have been verified. Upon receipt, each
+// channel will be written to disk, and the funding transaction broadcast.
+type publishFundingBatchMsg struct {
+	batch *FundingBatch
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+}
+
+// cancelFundingBatchMsg is sent to the wallet in order to abort a pending
+// funding batch, cancelling all of its reservations.
+type cancelFundingBatchMsg struct {
+	batch *FundingBatch
+
+	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
+	err chan error
+}
+
+// InitFundingBatch creates a new funding batch capable of funding numChannels
+// channels with a combined amount of totalAmt satoshis. The coins selected to
+// fund the batch are locked until the batch is either published, or
+// cancelled.
+func (l *LightningWallet) InitFundingBatch(totalAmt btcutil.Amount,
+	numChannels int) (*FundingBatch, error) {
+
+	errChan := make(chan error, 1)
+	respChan := make(chan *FundingBatch, 1)
+
+	l.msgChan <- &initFundingBatchMsg{
+		totalAmt:    totalAmt,
+		numChannels: numChannels,
+		err:         errChan,
+		resp:        respChan,
+	}
+
+	return <-respChan, <-errChan
+}
+
+// InitBatchedChannelReservation is identical to InitChannelReservation, with
+// the exception that the funds for the channel are drawn from the passed
+// funding batch rather than from a distinct round of coin selection.
+func (l *LightningWallet) InitBatchedChannelReservation(batch *FundingBatch,
+	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
+	feePerKw btcutil.Amount, theirID *btcec.PublicKey,
+	theirAddr *net.TCPAddr, chainHash *chainhash.Hash,
+	commitType channeldb.ChannelType) (*ChannelReservation, error) {
+
+	errChan := make(chan error, 1)
+	respChan := make(chan *ChannelReservation, 1)
+
+	l.msgChan <- &initFundingReserveMsg{
+		chainHash:     chainHash,
+		nodeID:        theirID,
+		nodeAddr:      theirAddr,
+		fundingAmount: ourFundAmt,
+		capacity:      capacity,
+		feePerKw:      feePerKw,
+		pushMSat:      pushMSat,
+		commitType:    commitType,
+		batch:         batch,
+		err:           errChan,
+		resp:          respChan,
+	}
+
+	return <-respChan, <-errChan
+}
+
+// Finalize assembles the shared funding transaction of the batch, and
+// generates our signatures for the initial commitment transactions of the
+// remote party of each channel. This method MUST only be called once the
+// contributions of all remote parties have been processed via
+// ChannelReservation.ProcessContribution.
+func (b *FundingBatch) Finalize() error {
+	errChan := make(chan error, 1)
+
+	b.wallet.msgChan <- &finalizeFundingBatchMsg{
+		batch: b,
+		err:   errChan,
+	}
+
+	return <-errChan
+}
+
+// Publish commits all channels within the batch to disk as pending channels,
+// then broadcasts the shared funding transaction. This method MUST only be
+// called once the commitment signatures of all remote parties have been
+// verified via ChannelReservation.CompleteReservation.
+func (b *FundingBatch) Publish() error {
+	errChan := make(chan error, 1)
+
+	b.wallet.msgChan <- &publishFundingBatchMsg{
+		batch: b,
+		err:   errChan,
+	}
+
+	return <-errChan
+}
+
+// Cancel abandons the funding batch, cancelling all of its reservations and
+// returning the inputs selected to fund the batch to the free pool.
+func (b *FundingBatch) Cancel() error {
+	errChan := make(chan error, 1)
+
+	b.wallet.msgChan <- &cancelFundingBatchMsg{
+		batch: b,
+		err:   errChan,
+	}
+
+	return <-errChan
+}
+
+// FundingTx returns the shared funding transaction of the batch.
+//
+// NOTE: This will only be populated once the batch has been finalized.
+func (b *FundingBatch) FundingTx() *wire.MsgTx {
+	b.Lock()
+	defer b.Unlock()
+	return b.fundingTx
+}
+
+// handleInitFundingBatch processes a request to create a new funding batch by
+// performing coin selection for the total amount of the batch.
+func (l *LightningWallet) handleInitFundingBatch(req *initFundingBatchMsg) {
+	if req.numChannels == 0 || req.totalAmt == 0 {
+		req.err <- fmt.Errorf("cannot create an empty funding batch")
+		req.resp <- nil
+		return
+	}
+
+	batch := &FundingBatch{
+		totalAmt:    req.totalAmt,
+		numChannels: req.numChannels,
+		contribution: &ChannelContribution{
+			FundingAmount: req.totalAmt,
+		},
+		wallet: l,
+	}
+
+	// Coin selection for the batch is carried out in the same manner as
+	// for a single channel, with the exception that the fee estimate
+	// takes into account a funding output for each channel.
+	//
+	// TODO(roasbeef): shouldn't be targeting next block
+	satPerByte := l.Cfg.FeeEstimator.EstimateFeePerByte(1)
+	err := l.selectCoinsAndChange(satPerByte, req.totalAmt,
+		req.numChannels, batch.contribution)
+	if err != nil {
+		req.err <- err
+		req.resp <- nil
+		return
+	}
+
+	req.resp <- batch
+	req.err <- nil
+}
+
+// handleFinalizeFundingBatch assembles the shared funding transaction of a
+// batch, signs the wallet's inputs, and creates and signs the commitment
+// transactions of each channel within the batch.
+func (l *LightningWallet) handleFinalizeFundingBatch(req *finalizeFundingBatchMsg) {
+	batch := req.batch
+
+	batch.Lock()
+	defer batch.Unlock()
+
+	if len(batch.reservations) != batch.numChannels {
+		req.err <- fmt.Errorf("batch has %v reservations, expected %v",
+			len(batch.reservations), batch.numChannels)
+		return
+	}
+
+	fundingTx := wire.NewMsgTx(1)
+	for _, input := range batch.contribution.Inputs {
+		fundingTx.AddTxIn(input)
+	}
+	for _, changeOutput := range batch.contribution.ChangeOutputs {
+		fundingTx.AddTxOut(changeOutput)
+	}
+
+	// With the inputs and change in place, we'll now add the 2-of-2
+	// multi-sig output of each channel in the batch. We'll hold onto the
+	// scripts as we'll need them to locate the outputs once the
+	// transaction has been sorted.
+	witnessScripts := make([][]byte, len(batch.reservations))
+	multiSigOuts := make([]*wire.TxOut, len(batch.reservations))
+	for i, res := range batch.reservations {
+		res.Lock()
+		ourKey := res.ourContribution.MultiSigKey
+		theirKey := res.theirContribution.MultiSigKey
+		capacity := int64(res.partialState.Capacity)
+		res.Unlock()
+
+		if theirKey == nil {
+			req.err <- fmt.Errorf("contribution for reservation %v "+
+				"not yet processed", res.reservationID)
+			return
+		}
+
+		witnessScript, multiSigOut, err := GenFundingPkScript(
+			ourKey.SerializeCompressed(),
+			theirKey.SerializeCompressed(), capacity,
+		)
+		if err != nil {
+			req.err <- err
+			return
+		}
+
+		witnessScripts[i] = witnessScript
+		multiSigOuts[i] = multiSigOut
+		fundingTx.AddTxOut(multiSigOut)
+	}
+
+	// Sort the transaction. Each of the remote parties will construct the
+	// funding outpoint of their channel from the txid and index that we
+	// send them, so the canonical ordering is only a matter of
+	// consistency with the single funder workflow.
+	txsort.InPlaceSort(fundingTx)
+
+	// Next, sign all inputs that are ours. As the batch is entirely
+	// funded by us, this will be every input of the transaction.
+	inputScripts, err := l.signFundingInputs(fundingTx)
+	if err != nil {
+		req.err <- err
+		return
+	}
+
+	batch.fundingTx = fundingTx
+	batch.ourFundingInputScripts = inputScripts
+
+	// Finally, with the funding transaction complete, we'll create and
+	// sign the initial commitment transactions of each channel.
+	for i, res := range batch.reservations {
+		res.Lock()
+		res.fundingTx = fundingTx
+		res.partialState.FundingTxn = fundingTx
+		res.ourFundingInputScripts = inputScripts
+		err := l.signInitialCommitments(
+			res, witnessScripts[i], multiSigOuts[i],
+		)
+		res.Unlock()
+		if err != nil {
+			req.err <- err
+			return
+		}
+	}
+
+	req.err <- nil
+}
+
+// handlePublishFundingBatch commits each channel of a finalized batch to
+// disk, then broadcasts the shared funding transaction.
+func (l *LightningWallet) handlePublishFundingBatch(req *publishFundingBatchMsg) {
+	batch := req.batch
+
+	batch.Lock()
+	defer batch.Unlock()
+
+	if batch.fundingTx == nil {
+		req.err <- fmt.Errorf("funding batch has not been finalized")
+		return
+	}
+
+	// Before we write anything to disk, we'll ensure that we hold a valid
+	// signature for our commitment transaction of every channel within
+	// the batch.
+	for _, res := range batch.reservations {
+		res.RLock()
+		commitSig := res.partialState.CommitSig
+		res.RUnlock()
+
+		if commitSig == nil {
+			req.err <- fmt.Errorf("missing commitment signature for "+
+				"reservation %v", res.reservationID)
+			return
+		}
+	}
+
+	// As we're about to broadcast the funding transaction, we'll take note
+	// of the current height for record keeping purposes.
+	_, bestHeight, err := l.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		req.err <- err
+		return
+	}
+
+	// With all signatures verified, we'll now mark each channel as
+	// pending within the database. All channels are written atomically,
+	// so a failure here won't leave a subset of the batch's channels
+	// behind without the funding transaction having been broadcast.
+	channels := make([]*channeldb.OpenChannel, 0, len(batch.reservations))
+	addrs := make([]*net.TCPAddr, 0, len(batch.reservations))
+	for _, res := range batch.reservations {
+		res.Lock()
+		channels = append(channels, res.partialState)
+		addrs = append(addrs, res.nodeAddr)
+	}
+	err = l.Cfg.Database.SyncPendingBatch(channels, addrs, uint32(bestHeight))
+	for _, res := range batch.reservations {
+		res.Unlock()
+	}
+	if err != nil {
+		req.err <- err
+		return
+	}
+
+	// Now that the channels have been persisted, we'll remove their
+	// reservations from limbo.
+	l.limboMtx.Lock()
+	for _, res := range batch.reservations {
+		delete(l.fundingLimbo, res.reservationID)
+	}
+	l.limboMtx.Unlock()
+
+	fundingTx := batch.fundingTx
+	walletLog.Infof("Broadcasting batch funding tx for %v channels: %v",
+		len(batch.reservations), spew.Sdump(fundingTx))
+
+	// Broadcast the finalized funding transaction to the network.
+	if err := l.PublishTransaction(fundingTx); err != nil {
+		// TODO(roasbeef): need to make this into a concrete error
+		if !strings.Contains(err.Error(), "already have") {
+			req.err <- err
+			return
+		}
+	}
+
+	req.err <- nil
+}
+
+// handleCancelFundingBatch cancels all reservations within a funding batch,
+// and unlocks the inputs selected to fund the batch.
+func (l *LightningWallet) handleCancelFundingBatch(req *cancelFundingBatchMsg) {
+	batch := req.batch
+
+	batch.Lock()
+	defer batch.Unlock()
+
+	l.limboMtx.Lock()
+	for _, res := range batch.reservations {
+		delete(l.fundingLimbo, res.reservationID)
+	}
+	l.limboMtx.Unlock()
+
+	// Mark all previously locked outpoints as usable for future funding
+	// requests.
+	for _, unusedInput := range batch.contribution.Inputs {
+		delete(l.lockedOutPoints, unusedInput.PreviousOutPoint)
+		l.UnlockOutpoint(unusedInput.PreviousOutPoint)
+	}
+
+	batch.reservations = nil
+
+	req.err <- nil
+}
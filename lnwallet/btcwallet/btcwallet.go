@@ -406,6 +406,18 @@ func extractBalanceDelta(txSummary base.TransactionSummary) (btcutil.Amount, err
 	return balanceDelta, nil
 }
 
+// deserializeSummaryTx deserializes the raw transaction contained within the
+// passed TransactionSummary.
+func deserializeSummaryTx(txSummary base.TransactionSummary) (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(1)
+	txReader := bytes.NewReader(txSummary.Transaction)
+	if err := tx.Deserialize(txReader); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
 // minedTransactionsToDetails is a helper function which converts a summary
 // information about mined transactions to a TransactionDetail.
 func minedTransactionsToDetails(currentHeight int32,
@@ -428,6 +440,12 @@ func minedTransactionsToDetails(currentHeight int32,
 		}
 		txDetail.Value = balanceDelta
 
+		rawTx, err := deserializeSummaryTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		txDetail.RawTx = rawTx
+
 		details = append(details, txDetail)
 	}
 
@@ -449,6 +467,12 @@ func unminedTransactionsToDetail(summary base.TransactionSummary) (*lnwallet.Tra
 	}
 	txDetail.Value = balanceDelta
 
+	rawTx, err := deserializeSummaryTx(summary)
+	if err != nil {
+		return nil, err
+	}
+	txDetail.RawTx = rawTx
+
 	return txDetail, nil
 }
 
@@ -92,7 +92,9 @@ func (b *BtcWallet) fetchPrivKey(pub *btcec.PublicKey) (*btcec.PrivateKey, error
 
 // maybeTweakPrivKey examines the single and double tweak parameters on the
 // passed sign descriptor and may perform a mapping on the passed private key
-// in order to utilize the tweaks, if populated.
+// in order to utilize the tweaks, if populated. If neither tweak is populated,
+// as is the case for the to_remote output of a channel using a static remote
+// key, then the private key is returned as is.
 func maybeTweakPrivKey(signDesc *lnwallet.SignDescriptor,
 	privKey *btcec.PrivateKey) (*btcec.PrivateKey, error) {
 
@@ -290,6 +290,12 @@ type commitment struct {
 	// outputs int he local commitment view.
 	outgoignHTLCIndex map[int32]*PaymentDescriptor
 	incomingHTLCIndex map[int32]*PaymentDescriptor
+
+	// splice is the version of this commitment which spends the funding
+	// output of the pending splice of the channel, if any. It carries
+	// the same HTLCs, but the balances of both parties are adjusted by
+	// the splice.
+	splice *commitment
 }
 
 // locateOutputIndex is a small helper function to locate the output index of a
@@ -707,6 +713,13 @@ type LightningChannel struct {
 	// machine.
 	signer Signer
 
+	// pCache is the global preimage cache shared across all other
+	// LightningChannel instances. We'll use this cache either when we force
+	// close, or we detect that the remote party has force closed. If the
+	// preimage for an incoming HTLC is found in the cache, then we'll try
+	// to claim it on chain.
+	pCache PreimageCache
+
 	// signDesc is the primary sign descriptor that is capable of signing
 	// the commitment transaction that spends the multi-sig output.
 	signDesc *SignDescriptor
@@ -777,6 +790,12 @@ type LightningChannel struct {
 	// initiated.
 	pendingAckFeeUpdate *btcutil.Amount
 
+	// pendingSplice is the splice of the channel which is either being
+	// negotiated, or whose splice transaction has yet to confirm. While
+	// set, each new commitment is also signed in a version which spends
+	// the new funding output of the splice.
+	pendingSplice *Splice
+
 	// rHashMap is a map with PaymentHashes pointing to their respective
 	// PaymentDescriptors. We insert *PaymentDescriptors whenever we
 	// receive HTLCs. When a state transition happens (settling or
@@ -837,8 +856,9 @@ type LightningChannel struct {
 // settled channel state. Throughout state transitions, then channel will
 // automatically persist pertinent state to the database in an efficient
 // manner.
-func NewLightningChannel(signer Signer, events chainntnfs.ChainNotifier,
-	fe FeeEstimator, state *channeldb.OpenChannel) (*LightningChannel, error) {
+func NewLightningChannel(signer Signer, pCache PreimageCache,
+	events chainntnfs.ChainNotifier, fe FeeEstimator,
+	state *channeldb.OpenChannel) (*LightningChannel, error) {
 
 	localKey := state.LocalChanCfg.MultiSigKey.SerializeCompressed()
 	remoteKey := state.RemoteChanCfg.MultiSigKey.SerializeCompressed()
@@ -864,6 +884,7 @@ func NewLightningChannel(signer Signer, events chainntnfs.ChainNotifier,
 		// TODO(roasbeef): tune num sig workers?
 		sigPool:               newSigPool(runtime.NumCPU(), signer),
 		signer:                signer,
+		pCache:                pCache,
 		channelEvents:         events,
 		feeEstimator:          fe,
 		stateHintObsfucator:   stateHint,
@@ -994,6 +1015,17 @@ func NewLightningChannel(signer Signer, events chainntnfs.ChainNotifier,
 	s := lc.StateSnapshot()
 	lc.availableLocalBalance = s.LocalBalance
 
+	// If the channel is in the midst of a splice, then we'll continue to
+	// sign each new commitment against the new funding output until the
+	// splice transaction has confirmed.
+	pendingSplice, err := state.FetchPendingSplice()
+	switch {
+	case err == nil:
+		lc.restorePendingSplice(pendingSplice)
+	case err != channeldb.ErrNoPendingSplice:
+		return nil, err
+	}
+
 	// Finally, we'll kick of the signature job pool to handle any upcoming
 	// commitment state generation and validation.
 	if lc.sigPool.Start(); err != nil {
@@ -1082,6 +1114,11 @@ type BreachRetribution struct {
 	// HtlcRetributions is a slice of HTLC retributions for each output
 	// active HTLC output within the breached commitment transaction.
 	HtlcRetributions []HtlcRetribution
+
+	// AnchorResolution contains the information required to sweep our
+	// anchor output within the breach transaction. If the channel doesn't
+	// use anchor outputs, then this will be nil.
+	AnchorResolution *AnchorResolution
 }
 
 // newBreachRetribution creates a new fully populated BreachRetribution for the
@@ -1139,7 +1176,13 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	if err != nil {
 		return nil, err
 	}
-	localPkScript, err := commitScriptUnencumbered(localKey)
+	// Our output on the breach transaction pays to our payment key, which
+	// is only tweaked if the channel doesn't use a static remote key.
+	localPayBase := chanState.LocalChanCfg.PaymentBasePoint
+	localPaymentKey := toRemoteKey(
+		chanState.ChanType, localPayBase, commitmentPoint,
+	)
+	localPkScript, err := commitScriptUnencumbered(localPaymentKey)
 	if err != nil {
 		return nil, err
 	}
@@ -1149,19 +1192,27 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	}
 
 	// In order to fully populate the breach retribution struct, we'll need
-	// to find the exact index of the local+remote commitment outputs.
+	// to find the exact index of the local+remote commitment outputs. The
+	// values of the outputs are also taken from the broadcast commitment,
+	// as a revoked state may have been signed in a version spending the
+	// funding output of a splice, which carries different balances than
+	// those recorded within the revocation log.
 	localOutpoint := wire.OutPoint{
 		Hash: commitHash,
 	}
 	remoteOutpoint := wire.OutPoint{
 		Hash: commitHash,
 	}
+	localAmt := int64(revokedSnapshot.LocalBalance.ToSatoshis())
+	remoteAmt := int64(revokedSnapshot.RemoteBalance.ToSatoshis())
 	for i, txOut := range broadcastCommitment.TxOut {
 		switch {
 		case bytes.Equal(txOut.PkScript, localPkScript):
 			localOutpoint.Index = uint32(i)
+			localAmt = txOut.Value
 		case bytes.Equal(txOut.PkScript, remoteWitnessHash):
 			remoteOutpoint.Index = uint32(i)
+			remoteAmt = txOut.Value
 		}
 	}
 
@@ -1169,6 +1220,7 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	// retribution structs for each of the HTLC transactions active on the
 	// remote commitment transaction.
 	htlcRetributions := make([]HtlcRetribution, len(revokedSnapshot.Htlcs))
+	dups := make(map[int32]struct{})
 	for i, htlc := range revokedSnapshot.Htlcs {
 		var (
 			htlcScript []byte
@@ -1198,6 +1250,28 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 			}
 		}
 
+		// For the same reason as above, we'll locate the HTLC output
+		// within the broadcast commitment, falling back to the index
+		// recorded within the revocation log.
+		htlcPkScript, err := witnessScriptHash(htlcScript)
+		if err != nil {
+			return nil, err
+		}
+		outputIndex := htlc.OutputIndex
+		for j, txOut := range broadcastCommitment.TxOut {
+			if _, ok := dups[int32(j)]; ok {
+				continue
+			}
+
+			if bytes.Equal(txOut.PkScript, htlcPkScript) &&
+				txOut.Value == int64(htlc.Amt.ToSatoshis()) {
+
+				outputIndex = int32(j)
+				dups[outputIndex] = struct{}{}
+				break
+			}
+		}
+
 		htlcRetributions[i] = HtlcRetribution{
 			SignDesc: SignDescriptor{
 				PubKey:        chanState.LocalChanCfg.RevocationBasePoint,
@@ -1210,7 +1284,7 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 			},
 			OutPoint: wire.OutPoint{
 				Hash:  commitHash,
-				Index: uint32(htlc.OutputIndex),
+				Index: uint32(outputIndex),
 			},
 			IsIncoming: htlc.Incoming,
 		}
@@ -1218,12 +1292,20 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 
 	// We'll need to reconstruct the single tweak so we can sweep our
 	// non-delayed pay-to-self output self.
-	singleTweak := SingleTweakBytes(commitmentPoint,
-		chanState.LocalChanCfg.PaymentBasePoint)
+	singleTweak := toRemoteTweak(
+		chanState.ChanType, commitmentPoint, localPayBase,
+	)
 
 	// Finally, with all the necessary data constructed, we can create the
 	// BreachRetribution struct which houses all the data necessary to
 	// swiftly bring justice to the cheating remote party.
+	anchorResolution, err := newAnchorResolution(
+		chanState, broadcastCommitment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BreachRetribution{
 		BreachTransaction: broadcastCommitment,
 		RevokedStateNum:   stateNum,
@@ -1235,7 +1317,7 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 			WitnessScript: localPkScript,
 			Output: &wire.TxOut{
 				PkScript: localWitnessHash,
-				Value:    int64(revokedSnapshot.LocalBalance.ToSatoshis()),
+				Value:    localAmt,
 			},
 			HashType: txscript.SigHashAll,
 		},
@@ -1246,11 +1328,12 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 			WitnessScript: remotePkScript,
 			Output: &wire.TxOut{
 				PkScript: remoteWitnessHash,
-				Value:    int64(revokedSnapshot.RemoteBalance.ToSatoshis()),
+				Value:    remoteAmt,
 			},
 			HashType: txscript.SigHashAll,
 		},
 		HtlcRetributions: htlcRetributions,
+		AnchorResolution: anchorResolution,
 	}, nil
 }
 
@@ -1303,6 +1386,20 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 	}
 	lc.RUnlock()
 
+	// If the funding output was spent by a splice transaction we've
+	// negotiated, then the channel isn't being closed. Instead, it'll
+	// continue on the new funding output once the splice has confirmed.
+	splice, err := lc.channelState.FetchPendingSplice()
+	if err == nil {
+		spliceTxid := splice.SpliceTx.TxHash()
+		if commitSpend.SpenderTxHash.IsEqual(&spliceTxid) {
+			walletLog.Infof("ChannelPoint(%v) spliced into %v",
+				lc.channelState.FundingOutpoint,
+				splice.FundingOutpoint)
+			return
+		}
+	}
+
 	// Otherwise, the remote party might have broadcast a prior revoked
 	// state...!!!
 	commitTxBroadcast := commitSpend.SpendingTx
@@ -1358,7 +1455,7 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 			RemotePub:      lc.channelState.IdentityPub,
 			Capacity:       lc.Capacity,
 			SettledBalance: lc.channelState.LocalBalance.ToSatoshis(),
-			CloseType:      channeldb.ForceClose,
+			CloseType:      channeldb.RemoteForceClose,
 			IsPending:      true,
 		}
 		if err := lc.DeleteState(&closeSummary); err != nil {
@@ -1376,12 +1473,12 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 		)
 
 		// Next, we'll obtain HTLC resolutions for all the outgoing
-		// HTLC's we had on their commitment transaction.
+		// HTLC's we had on their commitment transaction, as well as
+		// any incoming HTLC's we know the preimage to.
 		htlcResolutions, localKey, err := extractHtlcResolutions(
-			lc.channelState.FeePerKw, false, lc.signer,
-			lc.channelState.Htlcs, commitPoint,
-			revokeKey, lc.localChanCfg, lc.remoteChanCfg,
-			*commitSpend.SpenderTxHash)
+			lc.channelState.FeePerKw, false, lc.signer, lc.pCache,
+			lc.channelState.Htlcs, commitPoint, revokeKey,
+			lc.localChanCfg, lc.remoteChanCfg, commitTxBroadcast)
 		if err != nil {
 			walletLog.Errorf("unable to create htlc "+
 				"resolutions: %v", err)
@@ -1390,7 +1487,14 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 
 		// Before we can generate the proper sign descriptor, we'll
 		// need to locate the output index of our non-delayed output on
-		// the commitment transaction.
+		// the commitment transaction. If the channel uses a static
+		// remote key, then this output pays to our untweaked payment
+		// base point rather than the key used within HTLC scripts.
+		chanType := lc.channelState.ChanType
+		localPayBase := lc.localChanCfg.PaymentBasePoint
+		if chanType.IsTweakless() {
+			localKey = localPayBase
+		}
 		selfP2WKH, err := commitScriptUnencumbered(localKey)
 		if err != nil {
 			walletLog.Errorf("unable to create self commit "+
@@ -1413,10 +1517,11 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 		// only if we had a non-trimmed balance.
 		var selfSignDesc *SignDescriptor
 		if selfPoint != nil {
-			localPayBase := lc.localChanCfg.PaymentBasePoint
 			selfSignDesc = &SignDescriptor{
-				PubKey:        localPayBase,
-				SingleTweak:   SingleTweakBytes(commitPoint, localPayBase),
+				PubKey: localPayBase,
+				SingleTweak: toRemoteTweak(
+					chanType, commitPoint, localPayBase,
+				),
 				WitnessScript: selfP2WKH,
 				Output: &wire.TxOut{
 					Value:    int64(lc.channelState.LocalBalance.ToSatoshis()),
@@ -1426,6 +1531,17 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 			}
 		}
 
+		// If the channel uses anchor outputs, then we'll also locate
+		// our anchor on the remote commitment so it can be swept.
+		anchorResolution, err := newAnchorResolution(
+			lc.channelState, commitTxBroadcast,
+		)
+		if err != nil {
+			walletLog.Errorf("unable to create anchor resolution: "+
+				"%v", err)
+			return
+		}
+
 		// TODO(roasbeef): send msg before writing to disk
 		//  * need to ensure proper fault tolerance in all cases
 		//  * get ACK from the consumer of the ntfn before writing to disk?
@@ -1443,6 +1559,7 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 			SelfOutPoint:        selfPoint,
 			SelfOutputSignDesc:  selfSignDesc,
 			HtlcResolutions:     htlcResolutions,
+			AnchorResolution:    anchorResolution,
 		}
 
 	// If the state number broadcast is lower than the remote node's
@@ -1610,7 +1727,7 @@ func (lc *LightningChannel) restoreStateLogs() error {
 		isDustRemote := htlcIsDust(htlc.Incoming, false, feeRate,
 			htlc.Amt.ToSatoshis(), remoteChanCfg.DustLimit)
 		if !isDustLocal {
-			ourP2WSH, ourWitnessScript, err = lc.genHtlcScript(
+			ourP2WSH, ourWitnessScript, err = genHtlcScript(
 				htlc.Incoming, true, htlc.RefundTimeout, htlc.RHash,
 				localCommitLocalKey, localCommitRemoteKey,
 				localRevocation)
@@ -1619,7 +1736,7 @@ func (lc *LightningChannel) restoreStateLogs() error {
 			}
 		}
 		if !isDustRemote {
-			theirP2WSH, theirWitnessScript, err = lc.genHtlcScript(
+			theirP2WSH, theirWitnessScript, err = genHtlcScript(
 				htlc.Incoming, false, htlc.RefundTimeout, htlc.RHash,
 				remoteCommitLocalKey, remoteCommitRemoteKey,
 				remoteRevocation)
@@ -1812,19 +1929,94 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	// on its total weight. Once we have the total weight, we'll multiply
 	// by the current fee-per-kw, then divide by 1000 to get the proper
 	// fee.
-	totalCommitWeight := commitWeight + (htlcWeight * numHTLCs)
+	chanType := lc.channelState.ChanType
+	totalCommitWeight := baseCommitWeight(chanType) + (htlcWeight * numHTLCs)
 
 	// With the weight known, we can now calculate the commitment fee,
 	// ensuring that we account for any dust outputs trimmed above.
 	commitFee := btcutil.Amount((int64(feePerKw) * totalCommitWeight) / 1000)
 
-	// Currently, within the protocol, the initiator always pays the fees.
-	// So we'll subtract the fee amount from the balance of the current
-	// initiator.
+	// Currently, within the protocol, the initiator always pays the fees,
+	// along with the value of any anchor outputs. So we'll subtract the
+	// fee amount from the balance of the current initiator.
+	initiatorCost := lnwire.NewMSatFromSatoshis(
+		commitFee + anchorsValue(chanType),
+	)
 	if lc.channelState.IsInitiator {
-		ourBalance -= lnwire.NewMSatFromSatoshis(commitFee)
+		ourBalance -= initiatorCost
 	} else if !lc.channelState.IsInitiator {
-		theirBalance -= lnwire.NewMSatFromSatoshis(commitFee)
+		theirBalance -= initiatorCost
+	}
+
+	commitTx, err := lc.createCommitmentTx(remoteChain, lc.fundingTxIn,
+		ourBalance, theirBalance, filteredHTLCView, feePerKw,
+		commitPoint, nextHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &commitment{
+		txn:               commitTx,
+		height:            nextHeight,
+		ourBalance:        ourBalance,
+		ourMessageIndex:   ourLogIndex,
+		theirMessageIndex: theirLogIndex,
+		theirBalance:      theirBalance,
+		fee:               commitFee,
+		feePerKw:          feePerKw,
+	}
+
+	// In order to ensure _none_ of the HTLC's associated with this new
+	// commitment are mutated, we'll manually copy over each HTLC to its
+	// respective slice.
+	c.outgoingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.ourUpdates))
+	for i, htlc := range filteredHTLCView.ourUpdates {
+		c.outgoingHTLCs[i] = *htlc
+	}
+	c.incomingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.theirUpdates))
+	for i, htlc := range filteredHTLCView.theirUpdates {
+		c.incomingHTLCs[i] = *htlc
+	}
+
+	// Next, we'll populate all the HTLC indexes so we can track the
+	// locations of each HTLC in the commitment state.
+	if err := c.populateHtlcIndexes(ourCommitTx, dustLimit); err != nil {
+		return nil, err
+	}
+
+	// Finally, if the channel is in the midst of a splice, then we'll
+	// also construct the version of this commitment which spends the
+	// new funding output of the splice.
+	if lc.pendingSplice != nil {
+		c.splice, err = lc.fetchSpliceCommitmentView(remoteChain, c,
+			filteredHTLCView, commitPoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// createCommitmentTx creates the commitment transaction for the local or
+// remote chain at the given height, which spends the passed funding input,
+// and pays out the given balances along with each non-dust HTLC within the
+// passed view.
+//
+// NOTE: This method MUST be called with the channel's mutex held.
+func (lc *LightningChannel) createCommitmentTx(remoteChain bool,
+	fundingTxIn *wire.TxIn, ourBalance, theirBalance lnwire.MilliSatoshi,
+	filteredHTLCView *htlcView, feePerKw btcutil.Amount,
+	commitPoint *btcec.PublicKey, height uint64) (*wire.MsgTx, error) {
+
+	ourCommitTx := !remoteChain
+	chanType := lc.channelState.ChanType
+
+	var dustLimit btcutil.Amount
+	if remoteChain {
+		dustLimit = lc.remoteChanCfg.DustLimit
+	} else {
+		dustLimit = lc.localChanCfg.DustLimit
 	}
 
 	var (
@@ -1841,8 +2033,8 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	if remoteChain {
 		delayKey = TweakPubKey(lc.remoteChanCfg.DelayBasePoint,
 			commitPoint)
-		paymentKey = TweakPubKey(lc.localChanCfg.PaymentBasePoint,
-			commitPoint)
+		paymentKey = toRemoteKey(chanType,
+			lc.localChanCfg.PaymentBasePoint, commitPoint)
 		revocationKey = DeriveRevocationPubkey(
 			lc.localChanCfg.RevocationBasePoint,
 			commitPoint,
@@ -1854,8 +2046,8 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	} else {
 		delayKey = TweakPubKey(lc.localChanCfg.DelayBasePoint,
 			commitPoint)
-		paymentKey = TweakPubKey(lc.remoteChanCfg.PaymentBasePoint,
-			commitPoint)
+		paymentKey = toRemoteKey(chanType,
+			lc.remoteChanCfg.PaymentBasePoint, commitPoint)
 		revocationKey = DeriveRevocationPubkey(
 			lc.remoteChanCfg.RevocationBasePoint,
 			commitPoint,
@@ -1871,8 +2063,11 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 
 	// Generate a new commitment transaction with all the latest
 	// unsettled/un-timed out HTLCs.
-	commitTx, err := CreateCommitTx(lc.fundingTxIn, delayKey, paymentKey,
-		revocationKey, delay, delayBalance, p2wkhBalance, dustLimit)
+	anchors := anchorKeys(chanType, remoteChain, lc.localChanCfg,
+		lc.remoteChanCfg)
+	commitTx, err := CreateCommitTx(fundingTxIn, delayKey, paymentKey,
+		revocationKey, delay, delayBalance, p2wkhBalance, dustLimit,
+		anchors)
 	if err != nil {
 		return nil, err
 	}
@@ -1913,7 +2108,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	// quickly recovering the necessary penalty state in the case of an
 	// uncooperative broadcast.
 	obsfucator := lc.stateHintObsfucator
-	stateNum := nextHeight
+	stateNum := height
 	if err := SetStateNumHint(commitTx, stateNum, obsfucator); err != nil {
 		return nil, err
 	}
@@ -1922,36 +2117,8 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	// ordering. This lets us skip sending the entire transaction over,
 	// instead we'll just send signatures.
 	txsort.InPlaceSort(commitTx)
-	c := &commitment{
-		txn:               commitTx,
-		height:            nextHeight,
-		ourBalance:        ourBalance,
-		ourMessageIndex:   ourLogIndex,
-		theirMessageIndex: theirLogIndex,
-		theirBalance:      theirBalance,
-		fee:               commitFee,
-		feePerKw:          feePerKw,
-	}
-
-	// In order to ensure _none_ of the HTLC's associated with this new
-	// commitment are mutated, we'll manually copy over each HTLC to its
-	// respective slice.
-	c.outgoingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.ourUpdates))
-	for i, htlc := range filteredHTLCView.ourUpdates {
-		c.outgoingHTLCs[i] = *htlc
-	}
-	c.incomingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.theirUpdates))
-	for i, htlc := range filteredHTLCView.theirUpdates {
-		c.incomingHTLCs[i] = *htlc
-	}
 
-	// Finally, we'll populate all the HTLC indexes so we can track the
-	// locations of each HTLC in the commitment state.
-	if err := c.populateHtlcIndexes(ourCommitTx, dustLimit); err != nil {
-		return nil, err
-	}
-
-	return c, nil
+	return commitTx, nil
 }
 
 // evaluateHTLCView processes all update entries in both HTLC update logs,
@@ -2273,6 +2440,68 @@ func genRemoteHtlcSigJobs(commitPoint *btcec.PublicKey,
 	return sigBatch, cancelChan, nil
 }
 
+// signCommitment generates our signature for the passed commitment view of
+// the remote party using the given sign descriptor, along with a signature
+// for each of its non-dust HTLCs. The HTLC signatures are sorted according to
+// the BIP 69 order of the HTLC's on the commitment transaction.
+//
+// NOTE: This method MUST be called with the channel's mutex held.
+func (lc *LightningChannel) signCommitment(signDesc *SignDescriptor,
+	commitPoint *btcec.PublicKey,
+	commitView *commitment) (*btcec.Signature, []*btcec.Signature, error) {
+
+	// With the commitment view constructed, if there are any HTLC's, we'll
+	// need to generate signatures of each of them for the remote party's
+	// commitment state. We do so in two phases: first we generate and
+	// submit the set of signature jobs to the worker pool.
+	sigBatch, cancelChan, err := genRemoteHtlcSigJobs(commitPoint,
+		lc.localChanCfg, lc.remoteChanCfg, commitView,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	lc.sigPool.SubmitSignBatch(sigBatch)
+
+	// While the jobs are being carried out, we'll Sign their version of
+	// the new commitment transaction while we're waiting for the rest of
+	// the HTLC signatures to be processed.
+	signDesc.SigHashes = txscript.NewTxSigHashes(commitView.txn)
+	rawSig, err := lc.signer.SignOutputRaw(commitView.txn, signDesc)
+	if err != nil {
+		close(cancelChan)
+		return nil, nil, err
+	}
+	sig, err := btcec.ParseSignature(rawSig, btcec.S256())
+	if err != nil {
+		close(cancelChan)
+		return nil, nil, err
+	}
+
+	// We'll need to send over the signatures to the remote party in the
+	// order as they appear on the commitment transaction after BIP 69
+	// sorting.
+	sortedSigs := sortableSignBatch(sigBatch)
+	sort.Sort(sortedSigs)
+
+	// With the jobs sorted, we'll now iterate through all the responses to
+	// gather each of the signatures in order.
+	htlcSigs := make([]*btcec.Signature, 0, len(sigBatch))
+	for _, htlcSigJob := range sortedSigs {
+		jobResp := <-htlcSigJob.resp
+
+		// If an error occurred, then we'll cancel any other active
+		// jobs.
+		if jobResp.err != nil {
+			close(cancelChan)
+			return nil, nil, jobResp.err
+		}
+
+		htlcSigs = append(htlcSigs, jobResp.sig)
+	}
+
+	return sig, htlcSigs, nil
+}
+
 // SignNextCommitment signs a new commitment which includes any previous
 // unsettled HTLCs, any new HTLCs, and any modifications to prior HTLCs
 // committed in previous commitment updates. Signing a new commitment
@@ -2282,8 +2511,12 @@ func genRemoteHtlcSigJobs(commitPoint *btcec.PublicKey,
 // The first return parameter it he signature for the commitment transaction
 // itself, while the second parameter is a slice of all HTLC signatures (if
 // any). The HTLC signatures are sorted according to the BIP 69 order of the
-// HTLC's on the commitment transaction.
-func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Signature, error) {
+// HTLC's on the commitment transaction. If the channel has a pending splice,
+// then the third parameter holds the signatures for the version of the
+// commitment which spends the new funding output of the splice.
+func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature,
+	[]*btcec.Signature, *SpliceCommitSigs, error) {
+
 	lc.Lock()
 	defer lc.Unlock()
 
@@ -2291,7 +2524,14 @@ func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Sig
 	// unable to create new states as we don't have any revocations we can
 	// use.
 	if lc.pendingACK {
-		return nil, nil, ErrNoWindow
+		return nil, nil, nil, ErrNoWindow
+	}
+
+	// If a splice of the channel is still being negotiated, then we don't
+	// yet know whether the remote party expects us to sign the version of
+	// the commitment spending the new funding output, so we'll hold off.
+	if lc.pendingSplice != nil && !lc.pendingSplice.active {
+		return nil, nil, nil, ErrSpliceNegotiating
 	}
 
 	// Before we extend this new commitment to the remote commitment chain,
@@ -2301,7 +2541,7 @@ func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Sig
 	err := lc.validateCommitmentSanity(lc.remoteUpdateLog.ackedIndex,
 		lc.localUpdateLog.logIndex, false, true, true)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Grab the next commitment point for the remote party. This well be
@@ -2320,7 +2560,7 @@ func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Sig
 		lc.localUpdateLog.logIndex, lc.remoteUpdateLog.ackedIndex,
 		commitPoint)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	walletLog.Tracef("ChannelPoint(%v): extending remote chain to height %v",
@@ -2335,53 +2575,30 @@ func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Sig
 		}),
 	)
 
-	// With the commitment view constructed, if there are any HTLC's, we'll
-	// need to generate signatures of each of them for the remote party's
-	// commitment state. We do so in two phases: first we generate and
-	// submit the set of signature jobs to the worker pool.
-	sigBatch, cancelChan, err := genRemoteHtlcSigJobs(commitPoint,
-		lc.localChanCfg, lc.remoteChanCfg, newCommitView,
-	)
-	if err != nil {
-		return nil, nil, err
-	}
-	lc.sigPool.SubmitSignBatch(sigBatch)
-
-	// While the jobs are being carried out, we'll Sign their version of
-	// the new commitment transaction while we're waiting for the rest of
-	// the HTLC signatures to be processed.
-	lc.signDesc.SigHashes = txscript.NewTxSigHashes(newCommitView.txn)
-	rawSig, err := lc.signer.SignOutputRaw(newCommitView.txn, lc.signDesc)
-	if err != nil {
-		close(cancelChan)
-		return nil, nil, err
-	}
-	sig, err := btcec.ParseSignature(rawSig, btcec.S256())
+	sig, htlcSigs, err := lc.signCommitment(lc.signDesc, commitPoint,
+		newCommitView)
 	if err != nil {
-		close(cancelChan)
-		return nil, nil, err
-	}
-
-	// We'll need to send over the signatures to the remote party in the
-	// order as they appear on the commitment transaction after BIP 69
-	// sorting.
-	sortedSigs := sortableSignBatch(sigBatch)
-	sort.Sort(sortedSigs)
-
-	// With the jobs sorted, we'll now iterate through all the responses to
-	// gather each of the signatures in order.
-	htlcSigs := make([]*btcec.Signature, 0, len(sigBatch))
-	for _, htlcSigJob := range sortedSigs {
-		jobResp := <-htlcSigJob.resp
-
-		// If an error occurred, then we'll cancel any other active
-		// jobs.
-		if jobResp.err != nil {
-			close(cancelChan)
-			return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	// If the channel is in the midst of a splice, then we'll also sign
+	// the version of the new commitment which spends the new funding
+	// output, ensuring the remote party is able to broadcast its latest
+	// state regardless of whether the splice transaction confirms.
+	var spliceSigs *SpliceCommitSigs
+	if newCommitView.splice != nil {
+		spliceSig, spliceHtlcSigs, err := lc.signCommitment(
+			lc.pendingSplice.signDesc, commitPoint,
+			newCommitView.splice,
+		)
+		if err != nil {
+			return nil, nil, nil, err
 		}
 
-		htlcSigs = append(htlcSigs, jobResp.sig)
+		spliceSigs = &SpliceCommitSigs{
+			CommitSig: spliceSig,
+			HtlcSigs:  spliceHtlcSigs,
+		}
 	}
 
 	// Extend the remote commitment chain by one with the addition of our
@@ -2407,7 +2624,7 @@ func (lc *LightningChannel) SignNextCommitment() (*btcec.Signature, []*btcec.Sig
 	// properly track which changes have been ACK'd.
 	lc.localUpdateLog.initiateTransition()
 
-	return sig, htlcSigs, nil
+	return sig, htlcSigs, spliceSigs, nil
 }
 
 // validateCommitmentSanity is used to validate that on current state the commitment
@@ -2620,72 +2837,26 @@ func genHtlcSigValidationJobs(localCommitmentView *commitment,
 	return verifyJobs
 }
 
-// ReceiveNewCommitment process a signature for a new commitment state sent by
-// the remote party. This method will should be called in response to the
-// remote party initiating a new change, or when the remote party sends a
-// signature fully accepting a new state we've initiated. If we are able to
-// successfully validate the signature, then the generated commitment is added
-// to our local commitment chain. Once we send a revocation for our prior
-// state, then this newly added commitment becomes our current accepted channel
-// state.
-func (lc *LightningChannel) ReceiveNewCommitment(commitSig *btcec.Signature,
-	htlcSigs []*btcec.Signature) error {
-
-	lc.Lock()
-	defer lc.Unlock()
-
-	// Ensure that this new local update from the remote node respects all
-	// the constraints we specified during initial channel setup. If not,
-	// then we'll abort the channel as they've violated our constraints.
-	err := lc.validateCommitmentSanity(lc.remoteUpdateLog.logIndex,
-		lc.localUpdateLog.ackedIndex, false, true, true)
-	if err != nil {
-		return err
-	}
+// verifyCommitment validates the remote party's signature for the passed
+// commitment view of our own, which spends a funding output of the given
+// capacity, along with their signature for each of its non-dust HTLCs. If
+// valid, the HTLC signatures are stored within the view.
+//
+// NOTE: This method MUST be called with the channel's mutex held.
+func (lc *LightningChannel) verifyCommitment(localCommitmentView *commitment,
+	commitPoint *btcec.PublicKey, capacity btcutil.Amount,
+	commitSig *btcec.Signature, htlcSigs []*btcec.Signature) error {
 
-	// We're receiving a new commitment which attempts to extend our local
-	// commitment chain height by one, so fetch the proper commitment point
-	// as this well be needed to derive the keys required to construct the
-	// commitment.
-	nextHeight := lc.currentHeight + 1
-	commitSecret, err := lc.channelState.RevocationProducer.AtIndex(nextHeight)
+	// Construct the sighash of the commitment transaction corresponding to
+	// this newly proposed state update.
+	localCommitTx := localCommitmentView.txn
+	multiSigScript := lc.FundingWitnessScript
+	hashCache := txscript.NewTxSigHashes(localCommitTx)
+	sigHash, err := txscript.CalcWitnessSigHash(multiSigScript, hashCache,
+		txscript.SigHashAll, localCommitTx, 0, int64(capacity))
 	if err != nil {
-		return err
-	}
-	commitPoint := ComputeCommitmentPoint(commitSecret[:])
-
-	// With the current commitment point re-calculated, construct the new
-	// commitment view which includes all the entries we know of in their
-	// HTLC log, and up to ourLogIndex in our HTLC log.
-	localCommitmentView, err := lc.fetchCommitmentView(false,
-		lc.localUpdateLog.ackedIndex, lc.remoteUpdateLog.logIndex,
-		commitPoint)
-	if err != nil {
-		return err
-	}
-
-	walletLog.Tracef("ChannelPoint(%v): extending local chain to height %v",
-		lc.channelState.FundingOutpoint, localCommitmentView.height)
-
-	walletLog.Tracef("ChannelPoint(%v): local chain: our_balance=%v, "+
-		"their_balance=%v, commit_tx: %v", lc.channelState.FundingOutpoint,
-		localCommitmentView.ourBalance, localCommitmentView.theirBalance,
-		newLogClosure(func() string {
-			return spew.Sdump(localCommitmentView.txn)
-		}),
-	)
-
-	// Construct the sighash of the commitment transaction corresponding to
-	// this newly proposed state update.
-	localCommitTx := localCommitmentView.txn
-	multiSigScript := lc.FundingWitnessScript
-	hashCache := txscript.NewTxSigHashes(localCommitTx)
-	sigHash, err := txscript.CalcWitnessSigHash(multiSigScript, hashCache,
-		txscript.SigHashAll, localCommitTx, 0,
-		int64(lc.channelState.Capacity))
-	if err != nil {
-		// TODO(roasbeef): fetchview has already mutated the HTLCs...
-		//  * need to either roll-back, or make pure
+		// TODO(roasbeef): fetchview has already mutated the HTLCs...
+		//  * need to either roll-back, or make pure
 		return err
 	}
 
@@ -2722,6 +2893,105 @@ func (lc *LightningChannel) ReceiveNewCommitment(commitSig *btcec.Signature,
 		}
 	}
 
+	return nil
+}
+
+// ReceiveNewCommitment process a signature for a new commitment state sent by
+// the remote party. This method will should be called in response to the
+// remote party initiating a new change, or when the remote party sends a
+// signature fully accepting a new state we've initiated. If we are able to
+// successfully validate the signature, then the generated commitment is added
+// to our local commitment chain. Once we send a revocation for our prior
+// state, then this newly added commitment becomes our current accepted channel
+// state. If the channel has a pending splice, then spliceSigs MUST hold the
+// remote party's signatures for the version of the new commitment which
+// spends the new funding output of the splice.
+func (lc *LightningChannel) ReceiveNewCommitment(commitSig *btcec.Signature,
+	htlcSigs []*btcec.Signature, spliceSigs *SpliceCommitSigs) error {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	// Ensure that this new local update from the remote node respects all
+	// the constraints we specified during initial channel setup. If not,
+	// then we'll abort the channel as they've violated our constraints.
+	err := lc.validateCommitmentSanity(lc.remoteUpdateLog.logIndex,
+		lc.localUpdateLog.ackedIndex, false, true, true)
+	if err != nil {
+		return err
+	}
+
+	// If a splice of the channel is still being negotiated, then the
+	// presence of signatures for the splice version of the commitment
+	// tells us whether the remote party has committed to the splice. If
+	// it hasn't, then it can no longer accept the splice as negotiated,
+	// so we'll abort it.
+	splice := lc.pendingSplice
+	switch {
+	case splice != nil && !splice.active && spliceSigs == nil:
+		lc.abortSplice(splice)
+
+	case splice != nil && splice.active && spliceSigs == nil:
+		return fmt.Errorf("missing signature for splice commitment")
+
+	case splice == nil && spliceSigs != nil:
+		return fmt.Errorf("unexpected signature for splice commitment")
+	}
+
+	// We're receiving a new commitment which attempts to extend our local
+	// commitment chain height by one, so fetch the proper commitment point
+	// as this well be needed to derive the keys required to construct the
+	// commitment.
+	nextHeight := lc.currentHeight + 1
+	commitSecret, err := lc.channelState.RevocationProducer.AtIndex(nextHeight)
+	if err != nil {
+		return err
+	}
+	commitPoint := ComputeCommitmentPoint(commitSecret[:])
+
+	// With the current commitment point re-calculated, construct the new
+	// commitment view which includes all the entries we know of in their
+	// HTLC log, and up to ourLogIndex in our HTLC log.
+	localCommitmentView, err := lc.fetchCommitmentView(false,
+		lc.localUpdateLog.ackedIndex, lc.remoteUpdateLog.logIndex,
+		commitPoint)
+	if err != nil {
+		return err
+	}
+
+	walletLog.Tracef("ChannelPoint(%v): extending local chain to height %v",
+		lc.channelState.FundingOutpoint, localCommitmentView.height)
+
+	walletLog.Tracef("ChannelPoint(%v): local chain: our_balance=%v, "+
+		"their_balance=%v, commit_tx: %v", lc.channelState.FundingOutpoint,
+		localCommitmentView.ourBalance, localCommitmentView.theirBalance,
+		newLogClosure(func() string {
+			return spew.Sdump(localCommitmentView.txn)
+		}),
+	)
+
+	err = lc.verifyCommitment(localCommitmentView, commitPoint,
+		lc.channelState.Capacity, commitSig, htlcSigs)
+	if err != nil {
+		return err
+	}
+
+	// If the channel is in the midst of a splice, then we'll also verify
+	// the version of the new commitment which spends the new funding
+	// output. Once verified, we know the remote party has committed to
+	// the splice.
+	if localCommitmentView.splice != nil {
+		err := lc.verifyCommitment(localCommitmentView.splice,
+			commitPoint, splice.Capacity, spliceSigs.CommitSig,
+			spliceSigs.HtlcSigs)
+		if err != nil {
+			return fmt.Errorf("invalid splice commitment: %v", err)
+		}
+
+		localCommitmentView.splice.sig = spliceSigs.CommitSig.Serialize()
+		splice.active = true
+	}
+
 	// The signature checks out, so we can now add the new commitment to
 	// our local commitment chain.
 	localCommitmentView.sig = commitSig.Serialize()
@@ -2753,16 +3023,7 @@ func (lc *LightningChannel) FullySynced() bool {
 	lc.RLock()
 	defer lc.RUnlock()
 
-	oweCommitment := (lc.localCommitChain.tip().height >
-		lc.remoteCommitChain.tip().height)
-
-	localUpdatesSynced := (lc.localCommitChain.tip().ourMessageIndex ==
-		lc.remoteCommitChain.tip().ourMessageIndex)
-
-	remoteUpdatesSynced := (lc.localCommitChain.tip().theirMessageIndex ==
-		lc.remoteCommitChain.tip().theirMessageIndex)
-
-	return !oweCommitment && localUpdatesSynced && remoteUpdatesSynced
+	return lc.fullySynced()
 }
 
 // RevokeCurrentCommitment revokes the next lowest unrevoked commitment
@@ -2823,7 +3084,28 @@ func (lc *LightningChannel) RevokeCurrentCommitment() (*lnwire.RevokeAndAck, err
 	if err != nil {
 		return nil, err
 	}
-	err = lc.channelState.UpdateCommitment(tail.txn, tail.sig, delta)
+
+	// If we've committed to a splice of the channel, then the version of
+	// the new state which spends the new funding output is persisted
+	// along with it.
+	var spliceCommit *channeldb.SpliceCommitment
+	if tail.splice != nil && lc.pendingSplice != nil &&
+		lc.pendingSplice.committed {
+
+		spliceDelta, err := tail.splice.toChannelDelta(true)
+		if err != nil {
+			return nil, err
+		}
+
+		spliceCommit = &channeldb.SpliceCommitment{
+			CommitTx:  tail.splice.txn,
+			CommitSig: tail.splice.sig,
+			Htlcs:     spliceDelta.Htlcs,
+		}
+	}
+
+	err = lc.channelState.UpdateCommitment(tail.txn, tail.sig, delta,
+		spliceCommit)
 	if err != nil {
 		return nil, err
 	}
@@ -3202,10 +3484,22 @@ func (lc *LightningChannel) ShortChanID() lnwire.ShortChannelID {
 	return lc.channelState.ShortChanID
 }
 
+// UpfrontShutdownScripts returns the local and remote shutdown scripts, if
+// any, that each party committed to paying their funds to upon a cooperative
+// close during the funding workflow. A nil script indicates that the party
+// made no such commitment.
+func (lc *LightningChannel) UpfrontShutdownScripts() ([]byte, []byte) {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.channelState.LocalShutdownScript,
+		lc.channelState.RemoteShutdownScript
+}
+
 // genHtlcScript generates the proper P2WSH public key scripts for the
 // HTLC output modified by two-bits denoting if this is an incoming HTLC, and
 // if the HTLC is being applied to their commitment transaction or ours.
-func (lc *LightningChannel) genHtlcScript(isIncoming, ourCommit bool,
+func genHtlcScript(isIncoming, ourCommit bool,
 	timeout uint32, rHash [32]byte, localKey, remoteKey *btcec.PublicKey,
 	revocationKey *btcec.PublicKey) ([]byte, []byte, error) {
 
@@ -3274,7 +3568,7 @@ func (lc *LightningChannel) addHTLC(commitTx *wire.MsgTx, ourCommit bool,
 	timeout := paymentDesc.Timeout
 	rHash := paymentDesc.RHash
 
-	p2wsh, witnessScript, err := lc.genHtlcScript(isIncoming,
+	p2wsh, witnessScript, err := genHtlcScript(isIncoming,
 		ourCommit, timeout, rHash, localKey, remoteKey, revocationKey)
 	if err != nil {
 		return err
@@ -3333,8 +3627,9 @@ func (lc *LightningChannel) getSignedCommitTx() (*wire.MsgTx, error) {
 // channel closure. Additionally, if we had a commitment output above dust on
 // the remote party's commitment transaction, the necessary a SignDescriptor
 // with the material necessary to seep the output are returned. Finally, if we
-// had any outgoing HTLC's within the commitment transaction, then an
-// OutgoingHtlcResolution for each output will included.
+// had any outgoing HTLC's, or incoming HTLC's we know the preimage to within
+// the commitment transaction, then the HtlcResolutions needed to sweep them
+// will be included.
 type UnilateralCloseSummary struct {
 	// SpendDetail is a struct that describes how and when the commitment
 	// output was spent.
@@ -3350,61 +3645,213 @@ type UnilateralCloseSummary struct {
 	SelfOutPoint *wire.OutPoint
 
 	// SelfOutputSignDesc is a fully populated sign descriptor capable of
-	// generating a valid signature to sweep the output paying to us
+	// generating a valid signature to sweep the output paying to us. If
+	// the channel uses a static remote key, then the sign descriptor
+	// carries no tweak, as the output pays to our payment base point.
 	SelfOutputSignDesc *SignDescriptor
 
-	// HtlcResolutions is a slice of HTLC resolutions which allows the
-	// local node to sweep any outgoing HTLC"s after the timeout period has
-	// passed.
-	HtlcResolutions []OutgoingHtlcResolution
+	// HtlcResolutions contains a fully populated HtlcResolutions struct
+	// which contains all the data required to sweep any outgoing HTLC's
+	// after they've timed out, and any incoming HTLC's we know the
+	// preimage to.
+	HtlcResolutions *HtlcResolutions
+
+	// AnchorResolution contains the information required to sweep our
+	// anchor output within the remote party's commitment transaction. If
+	// the channel doesn't use anchor outputs, then this will be nil.
+	AnchorResolution *AnchorResolution
 }
 
-// OutgoingHtlcResolution houses the information necessary to sweep any outging
-// HTLC's after their contract has expired. This struct will be needed in one
-// of tow cases: the local party force closes the commitment transaction or the
-// remote party unilaterally closes with their version of the commitment
-// transaction.
+// IncomingHtlcResolution houses the information required to sweep any incoming
+// HTLC's that we know the preimage to. We'll need to sweep an HTLC manually
+// using this type if either of the following cases hold: we force close the
+// commitment transaction, or the remote party force closes with their version
+// of the commitment transaction. In either case, we must claim the HTLC with
+// the preimage before the sender is able to time it out.
+type IncomingHtlcResolution struct {
+	// Preimage is the preimage that will be used to satisfy the contract
+	// of the HTLC.
+	Preimage [32]byte
+
+	// SignedSuccessTx is the fully signed HTLC success transaction. This
+	// transaction (if non-nil) can be broadcast immediately. After a csv
+	// delay (included below), then the output created by this transactions
+	// can be swept on-chain.
+	//
+	// NOTE: If this field is nil, then this indicates that we don't need
+	// to go to the second level to claim this HTLC. Instead, it can be
+	// claimed directly from the outpoint listed below.
+	SignedSuccessTx *wire.MsgTx
+
+	// CsvDelay is the relative time lock (expressed in blocks) that must
+	// pass after the SignedSuccessTx is confirmed in the chain before the
+	// output can be swept.
+	//
+	// NOTE: If SignedSuccessTx is nil, then this field isn't needed.
+	CsvDelay uint32
+
+	// ClaimOutpoint is the final outpoint that needs to be spent in order
+	// to fully sweep the HTLC. If SignedSuccessTx is nil, then this is the
+	// HTLC output on the commitment transaction itself. Otherwise, it's
+	// the sole output of the second-level success transaction.
+	ClaimOutpoint wire.OutPoint
+
+	// SweepSignDesc is a sign descriptor that has been populated with the
+	// necessary items required to spend the sole output of the above
+	// transaction, or the HTLC output on the commitment transaction
+	// directly.
+	SweepSignDesc SignDescriptor
+}
+
+// OutgoingHtlcResolution houses the information necessary to sweep any
+// outgoing HTLC's after their contract has expired. This struct will be
+// needed in one of two cases: the local party force closes the commitment
+// transaction or the remote party unilaterally closes with their version of
+// the commitment transaction.
 type OutgoingHtlcResolution struct {
+	// PaymentHash is the payment hash of the outgoing HTLC. If the remote
+	// party sweeps the HTLC on-chain using the preimage, then we'll use
+	// this to recognize the preimage within their witness.
+	PaymentHash [32]byte
+
+	// Amount is the value of the HTLC. This is required in order to
+	// properly settle the HTLC backwards within the switch if the remote
+	// party reveals the preimage on-chain.
+	Amount lnwire.MilliSatoshi
+
 	// Expiry the absolute timeout of the HTLC. This value is expressed in
 	// block height, meaning after this height the HLTC can be swept.
 	Expiry uint32
 
+	// HtlcOutpoint is the outpoint of the HTLC output on the commitment
+	// transaction. The remote party is able to sweep this output using
+	// the preimage up until we've timed it out, so this outpoint should be
+	// watched in order to learn of the preimage.
+	HtlcOutpoint wire.OutPoint
+
 	// SignedTimeoutTx is the fully signed HTLC timeout transaction. This
 	// must be broadcast immediately after timeout has passed. Once this
 	// has been confirmed, the HTLC output will transition into the
 	// delay+claim state.
+	//
+	// NOTE: If this field is nil, then this indicates that we don't need
+	// to go to the second level to claim this HTLC. Instead, it can be
+	// claimed directly from the outpoint listed below after the expiry.
 	SignedTimeoutTx *wire.MsgTx
 
+	// CsvDelay is the relative time lock (expressed in blocks) that must
+	// pass after the SignedTimeoutTx is confirmed in the chain before the
+	// output can be swept.
+	//
+	// NOTE: If SignedTimeoutTx is nil, then this field isn't needed.
+	CsvDelay uint32
+
+	// ClaimOutpoint is the final outpoint that needs to be spent in order
+	// to fully sweep the HTLC. If SignedTimeoutTx is nil, then this is
+	// the same as HtlcOutpoint. Otherwise, it's the sole output of the
+	// second-level timeout transaction.
+	ClaimOutpoint wire.OutPoint
+
 	// SweepSignDesc is a sign descriptor that has been populated with the
 	// necessary items required to spend the sole output of the above
-	// transaction.
+	// transaction, or the HTLC output on the commitment transaction
+	// directly.
 	SweepSignDesc SignDescriptor
 }
 
-// newHtlcResolution generates a new HTLC resolution capable of allowing the
-// caller to sweep an outgoing HTLC present on either their, or the remote
-// party's commitment transaction.
-func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
-	commitHash chainhash.Hash, htlc *channeldb.HTLC, commitTweak []byte,
-	delayKey, localKey, remoteKey *btcec.PublicKey, revokeKey *btcec.PublicKey,
-	feePewKw, dustLimit btcutil.Amount) (*OutgoingHtlcResolution, error) {
+// HtlcResolutions contains the items necessary to sweep HTLC's on chain
+// directly from a commitment transaction. We'll use this in case either party
+// broadcasts a commitment transaction with live HTLC's.
+type HtlcResolutions struct {
+	// IncomingHTLCs contains a set of structs that can be used to sweep
+	// all the incoming HTLC's that we know the preimage to.
+	IncomingHTLCs []IncomingHtlcResolution
+
+	// OutgoingHTLCs contains a set of structs that contains all the info
+	// needed to sweep an outgoing HTLC we've sent to the remote party
+	// after an absolute delay has expired.
+	OutgoingHTLCs []OutgoingHtlcResolution
+}
+
+// htlcKeyRing houses the set of keys required to re-construct the HTLC
+// scripts within a particular commitment transaction. All keys are tweaked
+// with the commitment point of the party that owns the commitment.
+type htlcKeyRing struct {
+	// commitTweak is the single tweak applied to our payment base point
+	// in order to derive localKey.
+	commitTweak []byte
+
+	// delayTweak is the single tweak applied to our delay base point in
+	// order to derive delayKey.
+	delayTweak []byte
+
+	localKey  *btcec.PublicKey
+	remoteKey *btcec.PublicKey
+	delayKey  *btcec.PublicKey
+	revokeKey *btcec.PublicKey
+}
+
+// newOutgoingHtlcResolution generates a new HTLC resolution capable of
+// allowing the caller to sweep an outgoing HTLC present on either their, or
+// the remote party's commitment transaction.
+func newOutgoingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
+	commitHash chainhash.Hash, outputIndex uint32, htlc *channeldb.HTLC,
+	keyRing *htlcKeyRing, feePerKw btcutil.Amount,
+	ourCommit bool) (*OutgoingHtlcResolution, error) {
 
 	op := wire.OutPoint{
 		Hash:  commitHash,
-		Index: uint32(htlc.OutputIndex),
+		Index: outputIndex,
 	}
 
-	// In order to properly reconstruct the HTLC transaction, we'll need to
-	// re-calculate the fee required at this state, so we can add the
-	// correct output value amount to the transaction.
-	htlcFee := htlcTimeoutFee(feePewKw)
+	// If we're spending this HTLC output from the remote node's
+	// commitment, then we won't need to go to the second level as our
+	// outputs don't have a CSV delay. Instead, we'll be able to sweep the
+	// output directly with our payment key once the absolute timeout has
+	// passed.
+	if !ourCommit {
+		htlcScript, err := receiverHTLCScript(htlc.RefundTimeout,
+			keyRing.localKey, keyRing.remoteKey, keyRing.revokeKey,
+			htlc.RHash[:])
+		if err != nil {
+			return nil, err
+		}
+		htlcP2WSH, err := witnessScriptHash(htlcScript)
+		if err != nil {
+			return nil, err
+		}
+
+		return &OutgoingHtlcResolution{
+			PaymentHash:   htlc.RHash,
+			Amount:        htlc.Amt,
+			Expiry:        htlc.RefundTimeout,
+			HtlcOutpoint:  op,
+			ClaimOutpoint: op,
+			SweepSignDesc: SignDescriptor{
+				PubKey:        localChanCfg.PaymentBasePoint,
+				SingleTweak:   keyRing.commitTweak,
+				WitnessScript: htlcScript,
+				Output: &wire.TxOut{
+					PkScript: htlcP2WSH,
+					Value:    int64(htlc.Amt.ToSatoshis()),
+				},
+				HashType: txscript.SigHashAll,
+			},
+		}, nil
+	}
+
+	// Otherwise, in order to properly reconstruct the HTLC transaction,
+	// we'll need to re-calculate the fee required at this state, so we
+	// can add the correct output value amount to the transaction.
+	htlcFee := htlcTimeoutFee(feePerKw)
 	secondLevelOutputAmt := htlc.Amt.ToSatoshis() - htlcFee
 
 	// With the fee calculated, re-construct the second level timeout
 	// transaction.
+	csvDelay := uint32(localChanCfg.CsvDelay)
 	timeoutTx, err := createHtlcTimeoutTx(op, secondLevelOutputAmt,
-		htlc.RefundTimeout, uint32(localChanCfg.CsvDelay),
-		revokeKey, localKey,
+		htlc.RefundTimeout, csvDelay, keyRing.revokeKey,
+		keyRing.delayKey,
 	)
 	if err != nil {
 		return nil, err
@@ -3413,14 +3860,14 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 	// With the transaction created, we can generate a sign descriptor
 	// that's capable of generating the signature required to spend the
 	// HTLC output using the timeout transaction.
-	htlcCreationScript, err := senderHTLCScript(localKey, remoteKey,
-		revokeKey, htlc.RHash[:])
+	htlcCreationScript, err := senderHTLCScript(keyRing.localKey,
+		keyRing.remoteKey, keyRing.revokeKey, htlc.RHash[:])
 	if err != nil {
 		return nil, err
 	}
 	timeoutSignDesc := SignDescriptor{
 		PubKey:        localChanCfg.PaymentBasePoint,
-		SingleTweak:   commitTweak,
+		SingleTweak:   keyRing.commitTweak,
 		WitnessScript: htlcCreationScript,
 		Output: &wire.TxOut{
 			Value: int64(htlc.Amt.ToSatoshis()),
@@ -3442,21 +3889,152 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 	// Finally, we'll generate the script output that the timeout
 	// transaction creates so we can generate the signDesc required to
 	// complete the claim process after a delay period.
-	htlcSweepScript, err := secondLevelHtlcScript(revokeKey,
-		delayKey, uint32(localChanCfg.CsvDelay))
+	htlcSweepScript, err := secondLevelHtlcScript(keyRing.revokeKey,
+		keyRing.delayKey, csvDelay)
+	if err != nil {
+		return nil, err
+	}
+	htlcSweepP2WSH, err := witnessScriptHash(htlcSweepScript)
 	if err != nil {
 		return nil, err
 	}
 
 	return &OutgoingHtlcResolution{
+		PaymentHash:     htlc.RHash,
+		Amount:          htlc.Amt,
 		Expiry:          htlc.RefundTimeout,
+		HtlcOutpoint:    op,
 		SignedTimeoutTx: timeoutTx,
+		CsvDelay:        csvDelay,
+		ClaimOutpoint: wire.OutPoint{
+			Hash:  timeoutTx.TxHash(),
+			Index: 0,
+		},
 		SweepSignDesc: SignDescriptor{
 			PubKey:        localChanCfg.DelayBasePoint,
-			SingleTweak:   commitTweak,
+			SingleTweak:   keyRing.delayTweak,
+			WitnessScript: htlcSweepScript,
+			Output: &wire.TxOut{
+				PkScript: htlcSweepP2WSH,
+				Value:    int64(secondLevelOutputAmt),
+			},
+			HashType: txscript.SigHashAll,
+		},
+	}, nil
+}
+
+// newIncomingHtlcResolution creates a new HTLC resolution capable of allowing
+// the caller to sweep an incoming HTLC (that we know the preimage to) present
+// on either their, or the remote party's commitment transaction.
+func newIncomingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
+	commitHash chainhash.Hash, outputIndex uint32, htlc *channeldb.HTLC,
+	preimage [32]byte, keyRing *htlcKeyRing, feePerKw btcutil.Amount,
+	ourCommit bool) (*IncomingHtlcResolution, error) {
+
+	op := wire.OutPoint{
+		Hash:  commitHash,
+		Index: outputIndex,
+	}
+
+	// If we're claiming this HTLC from the remote party's commitment
+	// transaction, then we can sweep it directly with the preimage and a
+	// signature under our payment key, no second-level transaction is
+	// required.
+	if !ourCommit {
+		htlcScript, err := senderHTLCScript(keyRing.remoteKey,
+			keyRing.localKey, keyRing.revokeKey, htlc.RHash[:])
+		if err != nil {
+			return nil, err
+		}
+		htlcP2WSH, err := witnessScriptHash(htlcScript)
+		if err != nil {
+			return nil, err
+		}
+
+		return &IncomingHtlcResolution{
+			Preimage:      preimage,
+			ClaimOutpoint: op,
+			SweepSignDesc: SignDescriptor{
+				PubKey:        localChanCfg.PaymentBasePoint,
+				SingleTweak:   keyRing.commitTweak,
+				WitnessScript: htlcScript,
+				Output: &wire.TxOut{
+					PkScript: htlcP2WSH,
+					Value:    int64(htlc.Amt.ToSatoshis()),
+				},
+				HashType: txscript.SigHashAll,
+			},
+		}, nil
+	}
+
+	// Otherwise, we'll need to go to the second level, so we'll
+	// reconstruct the success transaction the remote party signed for
+	// this state, paying the fee required at this fee rate.
+	htlcFee := htlcSuccessFee(feePerKw)
+	secondLevelOutputAmt := htlc.Amt.ToSatoshis() - htlcFee
+
+	csvDelay := uint32(localChanCfg.CsvDelay)
+	successTx, err := createHtlcSuccessTx(op, secondLevelOutputAmt,
+		csvDelay, keyRing.revokeKey, keyRing.delayKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Once we've created the second-level transaction, we'll generate the
+	// sign descriptor required to spend the HTLC output with the preimage
+	// and the covenant signature of the remote party.
+	htlcCreationScript, err := receiverHTLCScript(htlc.RefundTimeout,
+		keyRing.remoteKey, keyRing.localKey, keyRing.revokeKey,
+		htlc.RHash[:])
+	if err != nil {
+		return nil, err
+	}
+	successSignDesc := SignDescriptor{
+		PubKey:        localChanCfg.PaymentBasePoint,
+		SingleTweak:   keyRing.commitTweak,
+		WitnessScript: htlcCreationScript,
+		Output: &wire.TxOut{
+			Value: int64(htlc.Amt.ToSatoshis()),
+		},
+		HashType:   txscript.SigHashAll,
+		SigHashes:  txscript.NewTxSigHashes(successTx),
+		InputIndex: 0,
+	}
+
+	successWitness, err := receiverHtlcSpendRedeem(htlc.Signature,
+		preimage[:], signer, &successSignDesc, successTx)
+	if err != nil {
+		return nil, err
+	}
+	successTx.TxIn[0].Witness = successWitness
+
+	// Finally, we'll generate the sign descriptor required to sweep the
+	// output of the success transaction after the CSV delay has passed.
+	htlcSweepScript, err := secondLevelHtlcScript(keyRing.revokeKey,
+		keyRing.delayKey, csvDelay)
+	if err != nil {
+		return nil, err
+	}
+	htlcSweepP2WSH, err := witnessScriptHash(htlcSweepScript)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IncomingHtlcResolution{
+		Preimage:        preimage,
+		SignedSuccessTx: successTx,
+		CsvDelay:        csvDelay,
+		ClaimOutpoint: wire.OutPoint{
+			Hash:  successTx.TxHash(),
+			Index: 0,
+		},
+		SweepSignDesc: SignDescriptor{
+			PubKey:        localChanCfg.DelayBasePoint,
+			SingleTweak:   keyRing.delayTweak,
 			WitnessScript: htlcSweepScript,
 			Output: &wire.TxOut{
-				Value: int64(secondLevelOutputAmt),
+				PkScript: htlcSweepP2WSH,
+				Value:    int64(secondLevelOutputAmt),
 			},
 			HashType: txscript.SigHashAll,
 		},
@@ -3464,38 +4042,73 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 }
 
 // extractHtlcResolutions creates a series of outgoing HTLC resolutions, and
-// the local key used when generating the HTLC scrips. This function is to be
-// used in two cases: force close, or a unilateral close.
+// incoming HTLC resolutions for all HTLC's we know the preimage to, along
+// with the local key used when generating the HTLC scripts. This function is
+// to be used in two cases: force close, or a unilateral close.
 func extractHtlcResolutions(feePerKw btcutil.Amount, ourCommit bool,
-	signer Signer, htlcs []*channeldb.HTLC,
+	signer Signer, pCache PreimageCache, htlcs []*channeldb.HTLC,
 	commitPoint, revokeKey *btcec.PublicKey,
 	localChanCfg, remoteChanCfg *channeldb.ChannelConfig,
-	commitHash chainhash.Hash) ([]OutgoingHtlcResolution, *btcec.PublicKey, error) {
+	commitTx *wire.MsgTx) (*HtlcResolutions, *btcec.PublicKey, error) {
 
-	// As uusal, we start by re-generating the key-ring required to
+	// As usual, we start by re-generating the key-ring required to
 	// reconstruct the pkScripts used, and sign any transactions or inputs
 	// required to sweep all funds.
-	commitTweak := SingleTweakBytes(commitPoint,
-		localChanCfg.PaymentBasePoint)
-	localKey := TweakPubKey(localChanCfg.PaymentBasePoint, commitPoint)
-	delayKey := TweakPubKey(localChanCfg.DelayBasePoint, commitPoint)
-	remoteKey := TweakPubKey(remoteChanCfg.PaymentBasePoint, commitPoint)
+	keyRing := &htlcKeyRing{
+		commitTweak: SingleTweakBytes(commitPoint,
+			localChanCfg.PaymentBasePoint),
+		delayTweak: SingleTweakBytes(commitPoint,
+			localChanCfg.DelayBasePoint),
+		localKey:  TweakPubKey(localChanCfg.PaymentBasePoint, commitPoint),
+		remoteKey: TweakPubKey(remoteChanCfg.PaymentBasePoint, commitPoint),
+		delayKey:  TweakPubKey(localChanCfg.DelayBasePoint, commitPoint),
+		revokeKey: revokeKey,
+	}
 
 	dustLimit := remoteChanCfg.DustLimit
 	if ourCommit {
 		dustLimit = localChanCfg.DustLimit
 	}
 
-	htlcResolutions := make([]OutgoingHtlcResolution, len(htlcs))
-	for i, htlc := range htlcs {
-		// Skip any incoming HTLC's, as unless we have the pre-image to
-		// spend them, they'll eventually be swept by the party that
-		// offered the HTLC after the timeout.
-		if htlc.Incoming {
-			continue
+	commitHash := commitTx.TxHash()
+
+	// The HTLC's stored within the channel state carry the output
+	// indexes of our version of the commitment transaction. If we're
+	// resolving the remote party's commitment, then we'll need to locate
+	// each HTLC by its script instead. We track the indexes we've already
+	// claimed in order to properly handle multiple HTLC's which share the
+	// same script.
+	usedIndexes := make(map[uint32]struct{})
+	locateHtlc := func(htlc *channeldb.HTLC) (uint32, bool) {
+		if ourCommit {
+			if htlc.OutputIndex < 0 {
+				return 0, false
+			}
+			return uint32(htlc.OutputIndex), true
 		}
 
-		// We'll also skip any HTLC's which were dust on the commitment
+		htlcP2WSH, _, err := genHtlcScript(htlc.Incoming, false,
+			htlc.RefundTimeout, htlc.RHash, keyRing.localKey,
+			keyRing.remoteKey, revokeKey)
+		if err != nil {
+			return 0, false
+		}
+		for i, txOut := range commitTx.TxOut {
+			if _, ok := usedIndexes[uint32(i)]; ok {
+				continue
+			}
+			if bytes.Equal(txOut.PkScript, htlcP2WSH) {
+				usedIndexes[uint32(i)] = struct{}{}
+				return uint32(i), true
+			}
+		}
+
+		return 0, false
+	}
+
+	resolutions := &HtlcResolutions{}
+	for _, htlc := range htlcs {
+		// We'll skip any HTLC's which were dust on the commitment
 		// transaction, as these don't have a corresponding output
 		// within the commitment transaction.
 		if htlcIsDust(htlc.Incoming, ourCommit, feePerKw,
@@ -3503,18 +4116,52 @@ func extractHtlcResolutions(feePerKw btcutil.Amount, ourCommit bool,
 			continue
 		}
 
-		ohr, err := newHtlcResolution(signer, localChanCfg, commitHash,
-			htlc, commitTweak, delayKey, localKey, remoteKey,
-			revokeKey, feePerKw, dustLimit)
+		outputIndex, ok := locateHtlc(htlc)
+		if !ok {
+			continue
+		}
+
+		// If this is an incoming HTLC, then we'll only be able to
+		// claim it if we know the preimage. Otherwise, it'll
+		// eventually be swept by the party that offered the HTLC
+		// after the timeout.
+		if htlc.Incoming {
+			if pCache == nil {
+				continue
+			}
+			preimageBytes, ok := pCache.LookupPreimage(htlc.RHash[:])
+			if !ok {
+				continue
+			}
+			var preimage [32]byte
+			copy(preimage[:], preimageBytes)
+
+			ihr, err := newIncomingHtlcResolution(signer,
+				localChanCfg, commitHash, outputIndex, htlc,
+				preimage, keyRing, feePerKw, ourCommit)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			resolutions.IncomingHTLCs = append(
+				resolutions.IncomingHTLCs, *ihr,
+			)
+			continue
+		}
+
+		ohr, err := newOutgoingHtlcResolution(signer, localChanCfg,
+			commitHash, outputIndex, htlc, keyRing, feePerKw,
+			ourCommit)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		// TODO(roasbeef): needs to point to proper amount including
-		htlcResolutions[i] = *ohr
+		resolutions.OutgoingHTLCs = append(
+			resolutions.OutgoingHTLCs, *ohr,
+		)
 	}
 
-	return htlcResolutions, localKey, nil
+	return resolutions, keyRing.localKey, nil
 }
 
 // ForceCloseSummary describes the final commitment state before the channel is
@@ -3547,10 +4194,102 @@ type ForceCloseSummary struct {
 	// output can be claimed.
 	SelfOutputMaturity uint32
 
-	// HtlcResolutions is a slice of HTLC resolutions which allows the
-	// local node to sweep any outgoing HTLC"s after the timeout period has
-	// passed.
-	HtlcResolutions []OutgoingHtlcResolution
+	// HtlcResolutions contains all the data required to sweep any
+	// outgoing HTLC's and incoming HTLC's we know the preimage to. For
+	// each of these HTLC's, we'll need to go to the second level to sweep
+	// them fully.
+	HtlcResolutions *HtlcResolutions
+
+	// AnchorResolution contains the information required to sweep our
+	// anchor output, which can be used to bump the fee of CloseTx. If the
+	// channel doesn't use anchor outputs, then this will be nil.
+	AnchorResolution *AnchorResolution
+}
+
+// AnchorResolution houses the information required to spend our anchor output
+// within a commitment transaction. The anchor can be used to CPFP the
+// commitment transaction while it's unconfirmed, or simply swept once the
+// commitment has confirmed.
+type AnchorResolution struct {
+	// ChanPoint is the outpoint of the funding transaction of the channel
+	// the commitment transaction belongs to.
+	ChanPoint wire.OutPoint
+
+	// CommitAnchor is the outpoint of our anchor output within the
+	// commitment transaction.
+	CommitAnchor wire.OutPoint
+
+	// AnchorSignDesc is a fully populated sign descriptor capable of
+	// generating a valid signature to spend the anchor output.
+	AnchorSignDesc SignDescriptor
+
+	// CommitWeight is the weight of the commitment transaction the anchor
+	// belongs to.
+	CommitWeight int64
+
+	// CommitFee is the fee paid by the commitment transaction the anchor
+	// belongs to.
+	CommitFee btcutil.Amount
+}
+
+// newAnchorResolution locates our anchor output within the passed commitment
+// transaction, and returns an AnchorResolution which can be used to spend it.
+// If the channel doesn't use anchor outputs, then nil is returned.
+func newAnchorResolution(chanState *channeldb.OpenChannel,
+	commitTx *wire.MsgTx) (*AnchorResolution, error) {
+
+	if !chanState.ChanType.HasAnchors() {
+		return nil, nil
+	}
+
+	localKey := chanState.LocalChanCfg.MultiSigKey
+	anchorScript, err := CommitScriptAnchor(localKey)
+	if err != nil {
+		return nil, err
+	}
+	anchorScriptHash, err := witnessScriptHash(anchorScript)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both the anchor output and the fee paid by the commitment are
+	// required in order to properly CPFP it, so we'll gather them both in
+	// a single pass over the outputs.
+	var (
+		anchorIndex int
+		found       bool
+		outputTotal btcutil.Amount
+	)
+	for i, txOut := range commitTx.TxOut {
+		outputTotal += btcutil.Amount(txOut.Value)
+
+		if bytes.Equal(txOut.PkScript, anchorScriptHash) {
+			anchorIndex = i
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unable to find anchor output in "+
+			"commitment %v", commitTx.TxHash())
+	}
+
+	commitWeight := blockchain.GetTransactionWeight(btcutil.NewTx(commitTx))
+
+	return &AnchorResolution{
+		ChanPoint: chanState.FundingOutpoint,
+		CommitAnchor: wire.OutPoint{
+			Hash:  commitTx.TxHash(),
+			Index: uint32(anchorIndex),
+		},
+		AnchorSignDesc: SignDescriptor{
+			PubKey:        localKey,
+			WitnessScript: anchorScript,
+			Output:        commitTx.TxOut[anchorIndex],
+			HashType:      txscript.SigHashAll,
+		},
+		CommitWeight: commitWeight,
+		CommitFee:    chanState.Capacity - outputTotal,
+	}, nil
 }
 
 // ForceClose executes a unilateral closure of the transaction at the current
@@ -3644,10 +4383,18 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 	// Once the delay output has been found (if it exists), then we'll also
 	// need to create a series of sign descriptors for any lingering
 	// outgoing HTLC's that we'll need to claim as well.
-	txHash := commitTx.TxHash()
 	htlcResolutions, _, err := extractHtlcResolutions(
-		lc.channelState.FeePerKw, true, lc.signer, lc.channelState.Htlcs,
-		commitPoint, revokeKey, lc.localChanCfg, lc.remoteChanCfg, txHash)
+		lc.channelState.FeePerKw, true, lc.signer, lc.pCache,
+		lc.channelState.Htlcs, commitPoint, revokeKey,
+		lc.localChanCfg, lc.remoteChanCfg, commitTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the channel uses anchor outputs, then we'll also return the
+	// information required to spend ours, allowing the caller to CPFP the
+	// commitment transaction.
+	anchorResolution, err := newAnchorResolution(lc.channelState, commitTx)
 	if err != nil {
 		return nil, err
 	}
@@ -3668,6 +4415,7 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		SelfOutputSignDesc: selfSignDesc,
 		SelfOutputMaturity: csvTimeout,
 		HtlcResolutions:    htlcResolutions,
+		AnchorResolution:   anchorResolution,
 	}, nil
 }
 
@@ -3699,10 +4447,14 @@ func (lc *LightningChannel) CreateCloseProposal(proposedFee uint64,
 	ourBalance := lc.channelState.LocalBalance.ToSatoshis()
 	theirBalance := lc.channelState.RemoteBalance.ToSatoshis()
 
+	// The anchor outputs aren't present on the cooperative close
+	// transaction, so their value is returned to the initiator who
+	// funded them.
+	anchors := anchorsValue(lc.channelState.ChanType)
 	if lc.channelState.IsInitiator {
-		ourBalance = ourBalance - btcutil.Amount(proposedFee)
+		ourBalance = ourBalance + anchors - btcutil.Amount(proposedFee)
 	} else {
-		theirBalance = theirBalance - btcutil.Amount(proposedFee)
+		theirBalance = theirBalance + anchors - btcutil.Amount(proposedFee)
 	}
 
 	closeTx := CreateCooperativeCloseTx(lc.fundingTxIn,
@@ -3760,10 +4512,14 @@ func (lc *LightningChannel) CompleteCooperativeClose(localSig, remoteSig,
 	ourBalance := lc.channelState.LocalBalance.ToSatoshis()
 	theirBalance := lc.channelState.RemoteBalance.ToSatoshis()
 
+	// The anchor outputs aren't present on the cooperative close
+	// transaction, so their value is returned to the initiator who
+	// funded them.
+	anchors := anchorsValue(lc.channelState.ChanType)
 	if lc.channelState.IsInitiator {
-		ourBalance = ourBalance - btcutil.Amount(proposedFee)
+		ourBalance = ourBalance + anchors - btcutil.Amount(proposedFee)
 	} else {
-		theirBalance = theirBalance - btcutil.Amount(proposedFee)
+		theirBalance = theirBalance + anchors - btcutil.Amount(proposedFee)
 	}
 
 	// Create the transaction used to return the current settled balance
@@ -3864,14 +4620,78 @@ func (lc *LightningChannel) ReceiveUpdateFee(feePerKw btcutil.Amount) error {
 	return nil
 }
 
+// AnchorKeys are the funding keys of both parties which guard the anchor
+// outputs of a commitment transaction.
+type AnchorKeys struct {
+	// SelfKey is the funding key of the "owner" of the commitment
+	// transaction.
+	SelfKey *btcec.PublicKey
+
+	// TheirKey is the funding key of the counterparty.
+	TheirKey *btcec.PublicKey
+}
+
+// anchorKeys returns the keys guarding the anchor outputs of the local or
+// remote commitment transaction, or nil if the channel doesn't use anchor
+// outputs.
+func anchorKeys(chanType channeldb.ChannelType, remoteChain bool,
+	localChanCfg, remoteChanCfg *channeldb.ChannelConfig) *AnchorKeys {
+
+	if !chanType.HasAnchors() {
+		return nil
+	}
+
+	if remoteChain {
+		return &AnchorKeys{
+			SelfKey:  remoteChanCfg.MultiSigKey,
+			TheirKey: localChanCfg.MultiSigKey,
+		}
+	}
+
+	return &AnchorKeys{
+		SelfKey:  localChanCfg.MultiSigKey,
+		TheirKey: remoteChanCfg.MultiSigKey,
+	}
+}
+
+// toRemoteKey returns the key paid to by the to_remote output of a commitment
+// transaction, given the payment base point of the party the output pays, and
+// the per-commitment point of the commitment. If the channel uses a static
+// remote key, then the base point itself is returned.
+func toRemoteKey(chanType channeldb.ChannelType,
+	basePoint, commitPoint *btcec.PublicKey) *btcec.PublicKey {
+
+	if chanType.IsTweakless() {
+		return basePoint
+	}
+
+	return TweakPubKey(basePoint, commitPoint)
+}
+
+// toRemoteTweak returns the single tweak required to sign for the to_remote
+// output of a commitment transaction. If the channel uses a static remote key,
+// then no tweak is required, so nil is returned.
+func toRemoteTweak(chanType channeldb.ChannelType,
+	commitPoint, basePoint *btcec.PublicKey) []byte {
+
+	if chanType.IsTweakless() {
+		return nil
+	}
+
+	return SingleTweakBytes(commitPoint, basePoint)
+}
+
 // CreateCommitTx creates a commitment transaction, spending from specified
 // funding output. The commitment transaction contains two outputs: one paying
 // to the "owner" of the commitment transaction which can be spent after a
 // relative block delay or revocation event, and the other paying the
-// counterparty within the channel, which can be spent immediately.
+// counterparty within the channel, which can be spent immediately. If anchor
+// keys are passed, then an anchor output is also added for each party, which
+// can be spent to bump the fee of the commitment transaction.
 func CreateCommitTx(fundingOutput *wire.TxIn, delayKey, paymentKey *btcec.PublicKey,
 	revokeKey *btcec.PublicKey, csvTimeout uint32, amountToSelf,
-	amountToThem, dustLimit btcutil.Amount) (*wire.MsgTx, error) {
+	amountToThem, dustLimit btcutil.Amount,
+	anchors *AnchorKeys) (*wire.MsgTx, error) {
 
 	// First, we create the script for the delayed "pay-to-self" output.
 	// This output has 2 main redemption clauses: either we can redeem the
@@ -3915,6 +4735,30 @@ func CreateCommitTx(fundingOutput *wire.TxIn, delayKey, paymentKey *btcec.Public
 		})
 	}
 
+	// Finally, if the channel uses anchor outputs, then we'll add an
+	// anchor for each party. The anchors are always present, regardless
+	// of the balances of either party, ensuring the commitment
+	// transaction can always be bumped by either side.
+	if anchors != nil {
+		for _, key := range []*btcec.PublicKey{
+			anchors.SelfKey, anchors.TheirKey,
+		} {
+			anchorScript, err := CommitScriptAnchor(key)
+			if err != nil {
+				return nil, err
+			}
+			anchorScriptHash, err := witnessScriptHash(anchorScript)
+			if err != nil {
+				return nil, err
+			}
+
+			commitTx.AddTxOut(&wire.TxOut{
+				PkScript: anchorScriptHash,
+				Value:    int64(AnchorOutputValue()),
+			})
+		}
+	}
+
 	return commitTx, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -113,6 +114,31 @@ func (m *mockSigner) ComputeInputScript(tx *wire.MsgTx, signDesc *SignDescriptor
 	}, nil
 }
 
+type mockPreimageCache struct {
+	sync.Mutex
+	preimageMap map[[32]byte][]byte
+}
+
+func (m *mockPreimageCache) LookupPreimage(hash []byte) ([]byte, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	var h [32]byte
+	copy(h[:], hash)
+
+	p, ok := m.preimageMap[h]
+	return p, ok
+}
+
+func (m *mockPreimageCache) AddPreimage(preimage []byte) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.preimageMap[sha256.Sum256(preimage[:])] = preimage
+
+	return nil
+}
+
 type mockNotfier struct {
 }
 
@@ -165,11 +191,13 @@ func initRevocationWindows(chanA, chanB *LightningChannel, windowSize int) error
 // commitment state machines to transition to a new state locking in any
 // pending updates.
 func forceStateTransition(chanA, chanB *LightningChannel) error {
-	aliceSig, aliceHtlcSigs, err := chanA.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, aliceSpliceSigs, err := chanA.SignNextCommitment()
 	if err != nil {
 		return err
 	}
-	if err = chanB.ReceiveNewCommitment(aliceSig, aliceHtlcSigs); err != nil {
+	err = chanB.ReceiveNewCommitment(aliceSig, aliceHtlcSigs,
+		aliceSpliceSigs)
+	if err != nil {
 		return err
 	}
 
@@ -177,7 +205,7 @@ func forceStateTransition(chanA, chanB *LightningChannel) error {
 	if err != nil {
 		return err
 	}
-	bobSig, bobHtlcSigs, err := chanB.SignNextCommitment()
+	bobSig, bobHtlcSigs, bobSpliceSigs, err := chanB.SignNextCommitment()
 	if err != nil {
 		return err
 	}
@@ -185,7 +213,8 @@ func forceStateTransition(chanA, chanB *LightningChannel) error {
 	if _, err := chanA.ReceiveRevocation(bobRevocation); err != nil {
 		return err
 	}
-	if err := chanA.ReceiveNewCommitment(bobSig, bobHtlcSigs); err != nil {
+	err = chanA.ReceiveNewCommitment(bobSig, bobHtlcSigs, bobSpliceSigs)
+	if err != nil {
 		return err
 	}
 
@@ -203,6 +232,15 @@ func forceStateTransition(chanA, chanB *LightningChannel) error {
 // createTestChannels creates two test channels funded with 10 BTC, with 5 BTC
 // allocated to each side. Within the channel, Alice is the initiator.
 func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChannel, func(), error) {
+	return createTestChannelsWithType(revocationWindow, channeldb.SingleFunder)
+}
+
+// createTestChannelsWithType is identical to createTestChannels, but the
+// channels are created with the passed channel type.
+func createTestChannelsWithType(revocationWindow int,
+	chanType channeldb.ChannelType) (*LightningChannel, *LightningChannel,
+	func(), error) {
+
 	aliceKeyPriv, aliceKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
 		testWalletPrivKey)
 	bobKeyPriv, bobKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
@@ -268,7 +306,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 
 	aliceCommitTx, bobCommitTx, err := CreateCommitmentTxns(channelBal,
 		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		fundingTxIn)
+		fundingTxIn, chanType)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -297,7 +335,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 		IdentityPub:             aliceKeyPub,
 		CommitFee:               commitFee,
 		FundingOutpoint:         *prevOut,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		FeePerKw:                feePerKw,
 		IsInitiator:             true,
 		Capacity:                channelCapacity,
@@ -317,7 +355,7 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 		FeePerKw:                feePerKw,
 		CommitFee:               commitFee,
 		FundingOutpoint:         *prevOut,
-		ChanType:                channeldb.SingleFunder,
+		ChanType:                chanType,
 		IsInitiator:             false,
 		Capacity:                channelCapacity,
 		LocalBalance:            lnwire.NewMSatFromSatoshis(channelBal),
@@ -338,15 +376,20 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 	aliceSigner := &mockSigner{aliceKeyPriv}
 	bobSigner := &mockSigner{bobKeyPriv}
 
+	pCache := &mockPreimageCache{
+		// hash -> preimage
+		preimageMap: make(map[[32]byte][]byte),
+	}
+
 	notifier := &mockNotfier{}
 
-	channelAlice, err := NewLightningChannel(aliceSigner, notifier,
-		estimator, aliceChannelState)
+	channelAlice, err := NewLightningChannel(aliceSigner, pCache,
+		notifier, estimator, aliceChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	channelBob, err := NewLightningChannel(bobSigner, notifier,
-		estimator, bobChannelState)
+	channelBob, err := NewLightningChannel(bobSigner, pCache,
+		notifier, estimator, bobChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -449,7 +492,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	// we expect the messages to be ordered, Bob will receive the HTLC we
 	// just sent before he receives this signature, so the signature will
 	// cover the HTLC.
-	aliceSig, aliceHtlcSigs, err := aliceChannel.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
@@ -457,7 +500,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	// Bob receives this signature message, and checks that this covers the
 	// state he has in his remote log. This includes the HTLC just sent
 	// from Alice.
-	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("bob unable to process alice's new commitment: %v", err)
 	}
@@ -473,7 +516,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	// This signature will cover the HTLC, since Bob will first send the
 	// revocation just created. The revocation also acks every received
 	// HTLC up to the point where Alice sent here signature.
-	bobSig, bobHtlcSigs, err := bobChannel.SignNextCommitment()
+	bobSig, bobHtlcSigs, _, err := bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("bob unable to sign alice's commitment: %v", err)
 	}
@@ -490,7 +533,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	// Alice then processes bob's signature, and since she just received
 	// the revocation, she expect this signature to cover everything up to
 	// the point where she sent her signature, including the HTLC.
-	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
@@ -570,11 +613,11 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 		t.Fatalf("alice unable to accept settle of outbound htlc: %v", err)
 	}
 
-	bobSig2, bobHtlcSigs2, err := bobChannel.SignNextCommitment()
+	bobSig2, bobHtlcSigs2, _, err := bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("bob unable to sign settle commitment: %v", err)
 	}
-	err = aliceChannel.ReceiveNewCommitment(bobSig2, bobHtlcSigs2)
+	err = aliceChannel.ReceiveNewCommitment(bobSig2, bobHtlcSigs2, nil)
 	if err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
@@ -583,7 +626,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("alice unable to generate revocation: %v", err)
 	}
-	aliceSig2, aliceHtlcSigs2, err := aliceChannel.SignNextCommitment()
+	aliceSig2, aliceHtlcSigs2, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign new commitment: %v", err)
 	}
@@ -594,7 +637,7 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 		t.Fatalf("bob shouldn't forward any HTLCs after outgoing settle, "+
 			"instead can forward: %v", spew.Sdump(htlcs))
 	}
-	err = bobChannel.ReceiveNewCommitment(aliceSig2, aliceHtlcSigs2)
+	err = bobChannel.ReceiveNewCommitment(aliceSig2, aliceHtlcSigs2, nil)
 	if err != nil {
 		t.Fatalf("bob unable to process alice's new commitment: %v", err)
 	}
@@ -995,6 +1038,166 @@ func TestForceClose(t *testing.T) {
 	}
 }
 
+// TestForceCloseStaticRemoteKey tests that if a channel uses a static remote
+// key, then our output on the remote party's commitment transaction pays
+// directly to our payment base point, and so can be swept without knowledge
+// of the remote party's per-commitment point.
+func TestForceCloseStaticRemoteKey(t *testing.T) {
+	t.Parallel()
+
+	chanType := channeldb.SingleFunder | channeldb.StaticRemoteKeyBit
+	aliceChannel, bobChannel, cleanUp, err := createTestChannelsWithType(
+		3, chanType,
+	)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll have Bob force close the channel, broadcasting his commitment
+	// transaction, which carries Alice's to_remote output.
+	closeSummary, err := bobChannel.ForceClose()
+	if err != nil {
+		t.Fatalf("unable to force close channel: %v", err)
+	}
+
+	alicePayBase := aliceChannel.channelState.LocalChanCfg.PaymentBasePoint
+	staticScript, err := commitScriptUnencumbered(alicePayBase)
+	if err != nil {
+		t.Fatalf("unable to create to_remote script: %v", err)
+	}
+
+	// Alice's to_remote output should pay to her untweaked payment base
+	// point.
+	var found bool
+	for _, txOut := range closeSummary.CloseTx.TxOut {
+		if bytes.Equal(txOut.PkScript, staticScript) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("to_remote output doesn't pay to static payment " +
+			"base point")
+	}
+}
+
+// TestForceCloseHtlcResolutions tests that when a channel with an active HTLC
+// is force closed, the sender of the HTLC receives an outgoing resolution
+// which allows it to time out the HTLC, and the receiver of the HTLC receives
+// an incoming resolution only once it knows the preimage of the HTLC.
+func TestForceCloseHtlcResolutions(t *testing.T) {
+	t.Parallel()
+
+	// Create a test channel which will be used for the duration of this
+	// unittest. The channel will be funded evenly with Alice having 5 BTC,
+	// and Bob having 5 BTC.
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// Alice will offer an HTLC to Bob that's well above the dust limit of
+	// both parties, then lock it in with a full state transition.
+	htlcAmount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlc, preimage := createHTLC(0, htlcAmount)
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("bob unable to receive htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("Can't update the channel state: %v", err)
+	}
+
+	// Alice should have a single outgoing HTLC resolution, which times out
+	// the HTLC using the second-level timeout transaction.
+	closeSummary, err := aliceChannel.ForceClose()
+	if err != nil {
+		t.Fatalf("unable to force close channel: %v", err)
+	}
+	htlcResolutions := closeSummary.HtlcResolutions
+	if len(htlcResolutions.IncomingHTLCs) != 0 {
+		t.Fatalf("alice shouldn't have any incoming resolutions, "+
+			"instead has %v", len(htlcResolutions.IncomingHTLCs))
+	}
+	if len(htlcResolutions.OutgoingHTLCs) != 1 {
+		t.Fatalf("alice should have a single outgoing resolution, "+
+			"instead has %v", len(htlcResolutions.OutgoingHTLCs))
+	}
+	outgoingRes := htlcResolutions.OutgoingHTLCs[0]
+	if outgoingRes.PaymentHash != htlc.PaymentHash {
+		t.Fatalf("incorrect payment hash: expected %x, got %x",
+			htlc.PaymentHash[:], outgoingRes.PaymentHash[:])
+	}
+	if outgoingRes.Expiry != htlc.Expiry {
+		t.Fatalf("incorrect expiry: expected %v, got %v", htlc.Expiry,
+			outgoingRes.Expiry)
+	}
+	timeoutTx := outgoingRes.SignedTimeoutTx
+	if timeoutTx == nil {
+		t.Fatalf("alice should have a signed timeout transaction")
+	}
+	if timeoutTx.LockTime != htlc.Expiry {
+		t.Fatalf("incorrect timeout tx lock time: expected %v, got %v",
+			htlc.Expiry, timeoutTx.LockTime)
+	}
+	aliceCommitHash := closeSummary.CloseTx.TxHash()
+	if timeoutTx.TxIn[0].PreviousOutPoint != outgoingRes.HtlcOutpoint ||
+		outgoingRes.HtlcOutpoint.Hash != aliceCommitHash {
+
+		t.Fatalf("timeout tx doesn't spend the HTLC output")
+	}
+	if outgoingRes.ClaimOutpoint.Hash != timeoutTx.TxHash() {
+		t.Fatalf("claim outpoint doesn't reference the timeout tx")
+	}
+
+	// As Bob doesn't know the preimage yet, he shouldn't have any
+	// incoming resolutions. Once the preimage is added to his cache, he
+	// should be able to claim the HTLC with the second-level success
+	// transaction.
+	if err := bobChannel.pCache.AddPreimage(preimage[:]); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+	closeSummary, err = bobChannel.ForceClose()
+	if err != nil {
+		t.Fatalf("unable to force close channel: %v", err)
+	}
+	htlcResolutions = closeSummary.HtlcResolutions
+	if len(htlcResolutions.OutgoingHTLCs) != 0 {
+		t.Fatalf("bob shouldn't have any outgoing resolutions, "+
+			"instead has %v", len(htlcResolutions.OutgoingHTLCs))
+	}
+	if len(htlcResolutions.IncomingHTLCs) != 1 {
+		t.Fatalf("bob should have a single incoming resolution, "+
+			"instead has %v", len(htlcResolutions.IncomingHTLCs))
+	}
+	incomingRes := htlcResolutions.IncomingHTLCs[0]
+	if incomingRes.Preimage != preimage {
+		t.Fatalf("incorrect preimage: expected %x, got %x",
+			preimage[:], incomingRes.Preimage[:])
+	}
+	successTx := incomingRes.SignedSuccessTx
+	if successTx == nil {
+		t.Fatalf("bob should have a signed success transaction")
+	}
+	bobCommitTx := closeSummary.CloseTx
+	htlcOutpoint := successTx.TxIn[0].PreviousOutPoint
+	if htlcOutpoint.Hash != bobCommitTx.TxHash() {
+		t.Fatalf("success tx doesn't spend bob's commitment tx")
+	}
+	htlcOutput := bobCommitTx.TxOut[htlcOutpoint.Index]
+	if htlcOutput.Value != int64(htlcAmount.ToSatoshis()) {
+		t.Fatalf("success tx spends incorrect output: expected value "+
+			"%v, got %v", htlcAmount.ToSatoshis(), htlcOutput.Value)
+	}
+	if incomingRes.CsvDelay != uint32(bobChannel.localChanCfg.CsvDelay) {
+		t.Fatalf("incorrect csv delay: expected %v, got %v",
+			bobChannel.localChanCfg.CsvDelay, incomingRes.CsvDelay)
+	}
+}
+
 // TestDustHTLCFees checks that fees are calculated correctly when HTLCs fall
 // below the nodes' dust limit. In these cases, the amount of the dust HTLCs
 // should be applied to the commitment transaction fee.
@@ -1317,12 +1520,12 @@ func TestStateUpdatePersistence(t *testing.T) {
 	}
 	notifier := aliceChannel.channelEvents
 	aliceChannelNew, err := NewLightningChannel(aliceChannel.signer,
-		notifier, aliceChannel.feeEstimator, aliceChannels[0])
+		aliceChannel.pCache, notifier, aliceChannel.feeEstimator, aliceChannels[0])
 	if err != nil {
 		t.Fatalf("unable to create new channel: %v", err)
 	}
-	bobChannelNew, err := NewLightningChannel(bobChannel.signer, notifier,
-		bobChannel.feeEstimator, bobChannels[0])
+	bobChannelNew, err := NewLightningChannel(bobChannel.signer,
+		bobChannel.pCache, notifier, bobChannel.feeEstimator, bobChannels[0])
 	if err != nil {
 		t.Fatalf("unable to create new channel: %v", err)
 	}
@@ -1763,7 +1966,7 @@ func TestUpdateFeeFail(t *testing.T) {
 
 	// Alice sends signature for commitment that does not cover any fee
 	// update.
-	aliceSig, aliceHtlcSigs, err := aliceChannel.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
@@ -1771,7 +1974,7 @@ func TestUpdateFeeFail(t *testing.T) {
 	// Bob verifies this commit, meaning that he checks that it is
 	// consistent everything he has received. This should fail, since he got
 	// the fee update, but Alice never sent it.
-	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
 	if err == nil {
 		t.Fatalf("expected bob to fail receiving alice's signature")
 	}
@@ -1817,7 +2020,7 @@ func TestUpdateFeeSenderCommits(t *testing.T) {
 	// Alice signs a commitment, which will cover everything sent to Bob
 	// (the HTLC and the fee update), and everything acked by Bob (nothing
 	// so far).
-	aliceSig, aliceHtlcSigs, err := aliceChannel.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
@@ -1825,7 +2028,7 @@ func TestUpdateFeeSenderCommits(t *testing.T) {
 	// Bob receives this signature message, and verifies that it is
 	// consistent with the state he had for Alice, including the received
 	// HTLC and fee update.
-	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("bob unable to process alice's new commitment: %v", err)
 	}
@@ -1847,7 +2050,7 @@ func TestUpdateFeeSenderCommits(t *testing.T) {
 
 	// Bob commits to all updates he has received from Alice. This includes
 	// the HTLC he received, and the fee update.
-	bobSig, bobHtlcSigs, err := bobChannel.SignNextCommitment()
+	bobSig, bobHtlcSigs, _, err := bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("bob unable to sign alice's commitment: %v", err)
 	}
@@ -1861,7 +2064,7 @@ func TestUpdateFeeSenderCommits(t *testing.T) {
 
 	// Alice receives new signature from Bob, and assumes this covers the
 	// changes.
-	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
@@ -1927,14 +2130,14 @@ func TestUpdateFeeReceiverCommits(t *testing.T) {
 	// Bob commits to every change he has sent since last time (none). He
 	// does not commit to the received HTLC and fee update, since Alice
 	// cannot know if he has received them.
-	bobSig, bobHtlcSigs, err := bobChannel.SignNextCommitment()
+	bobSig, bobHtlcSigs, _, err := bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
 
 	// Alice receives this signature message, and verifies that it is
 	// consistent with the remote state, not including any of the updates.
-	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("bob unable to process alice's new commitment: %v", err)
 	}
@@ -1955,14 +2158,14 @@ func TestUpdateFeeReceiverCommits(t *testing.T) {
 	// Alice will sign next commitment. Since she sent the revocation, she
 	// also ack'ed everything received, but in this case this is nothing.
 	// Since she sent the two updates, this signature will cover those two.
-	aliceSig, aliceHtlcSigs, err := aliceChannel.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("bob unable to sign alice's commitment: %v", err)
 	}
 
 	// Bob gets the signature for the new commitment from Alice. He assumes
 	// this covers everything received from alice, including the two updates.
-	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
@@ -1985,7 +2188,7 @@ func TestUpdateFeeReceiverCommits(t *testing.T) {
 
 	// Bob will send a new signature, which will cover what he just acked:
 	// the HTLC and fee update.
-	bobSig, bobHtlcSigs, err = bobChannel.SignNextCommitment()
+	bobSig, bobHtlcSigs, _, err = bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
@@ -1998,7 +2201,7 @@ func TestUpdateFeeReceiverCommits(t *testing.T) {
 
 	// Alice will receive the signature from Bob, which will cover what was
 	// just acked by his revocation.
-	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
@@ -2075,7 +2278,7 @@ func TestUpdateFeeMultipleUpdates(t *testing.T) {
 	// Alice signs a commitment, which will cover everything sent to Bob
 	// (the HTLC and the fee update), and everything acked by Bob (nothing
 	// so far).
-	aliceSig, aliceHtlcSigs, err := aliceChannel.SignNextCommitment()
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("alice unable to sign commitment: %v", err)
 	}
@@ -2087,7 +2290,7 @@ func TestUpdateFeeMultipleUpdates(t *testing.T) {
 	// Bob receives this signature message, and verifies that it is
 	// consistent with the state he had for Alice, including the received
 	// HTLC and fee update.
-	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
 	if err != nil {
 		t.Fatalf("bob unable to process alice's new commitment: %v", err)
 	}
@@ -2121,7 +2324,7 @@ func TestUpdateFeeMultipleUpdates(t *testing.T) {
 
 	// Bob commits to all updates he has received from Alice. This includes
 	// the HTLC he received, and the fee update.
-	bobSig, bobHtlcSigs, err := bobChannel.SignNextCommitment()
+	bobSig, bobHtlcSigs, _, err := bobChannel.SignNextCommitment()
 	if err != nil {
 		t.Fatalf("bob unable to sign alice's commitment: %v", err)
 	}
@@ -2135,7 +2338,7 @@ func TestUpdateFeeMultipleUpdates(t *testing.T) {
 
 	// Alice receives new signature from Bob, and assumes this covers the
 	// changes.
-	if err := aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs); err != nil {
+	if err := aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs, nil); err != nil {
 		t.Fatalf("alice unable to process bob's new commitment: %v", err)
 	}
 
@@ -2159,3 +2362,345 @@ func TestUpdateFeeMultipleUpdates(t *testing.T) {
 		t.Fatalf("bob unable to process alive's revocation: %v", err)
 	}
 }
+
+// TestChannelSplice tests that both parties of a channel are able to
+// negotiate a splice-in: each side should construct identical versions of
+// the new commitment transactions, accept the other's signature for them, and
+// the splice initiator should be able to fully sign the funding input of the
+// splice transaction.
+func TestChannelSplice(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(1)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// First, we'll move both channels past their initial state by adding
+	// and settling a single HTLC, so the splice is negotiated at a
+	// non-zero height.
+	htlcAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlc, preimage := createHTLC(0, htlcAmt)
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to complete state update: %v", err)
+	}
+
+	// While the HTLC is still active, neither side should allow a splice.
+	spliceAmt := btcutil.Amount(btcutil.SatoshiPerBitcoin)
+	spliceTx := wire.NewMsgTx(2)
+	spliceTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: aliceChannel.channelState.FundingOutpoint,
+	})
+	spliceTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+	})
+	spliceTx.AddTxOut(&wire.TxOut{
+		PkScript: aliceChannel.fundingP2WSH,
+		Value:    int64(aliceChannel.Capacity + spliceAmt),
+	})
+	_, err = aliceChannel.NewSplice(spliceTx, spliceAmt, 0)
+	if err != ErrSpliceNotQuiescent {
+		t.Fatalf("expected ErrSpliceNotQuiescent, got: %v", err)
+	}
+
+	settleIndex, err := bobChannel.SettleHTLC(preimage)
+	if err != nil {
+		t.Fatalf("bob unable to settle inbound htlc: %v", err)
+	}
+	err = aliceChannel.ReceiveHTLCSettle(preimage, settleIndex)
+	if err != nil {
+		t.Fatalf("alice unable to accept settle of outbound htlc: %v", err)
+	}
+	if err := forceStateTransition(bobChannel, aliceChannel); err != nil {
+		t.Fatalf("unable to complete state update: %v", err)
+	}
+
+	// With the channel now quiescent, both sides should accept the
+	// splice, with the full amount credited to Alice.
+	aliceSplice, err := aliceChannel.NewSplice(spliceTx, spliceAmt, 0)
+	if err != nil {
+		t.Fatalf("alice unable to create splice: %v", err)
+	}
+	bobSplice, err := bobChannel.NewSplice(spliceTx, 0, spliceAmt)
+	if err != nil {
+		t.Fatalf("bob unable to create splice: %v", err)
+	}
+
+	if aliceSplice.LocalBalance != bobSplice.RemoteBalance {
+		t.Fatalf("balance mismatch: alice has %v, bob thinks alice "+
+			"has %v", aliceSplice.LocalBalance, bobSplice.RemoteBalance)
+	}
+	expectedBalance := aliceChannel.channelState.LocalBalance +
+		lnwire.NewMSatFromSatoshis(spliceAmt)
+	if aliceSplice.LocalBalance != expectedBalance {
+		t.Fatalf("expected alice balance of %v, got %v",
+			expectedBalance, aliceSplice.LocalBalance)
+	}
+	if aliceSplice.ourCommit.txn.TxHash() != bobSplice.theirCommit.txn.TxHash() {
+		t.Fatalf("alice's commitment doesn't match bob's view")
+	}
+	if bobSplice.ourCommit.txn.TxHash() != aliceSplice.theirCommit.txn.TxHash() {
+		t.Fatalf("bob's commitment doesn't match alice's view")
+	}
+
+	// Next, both sides should accept each other's signature for their
+	// new commitment transaction.
+	aliceCommitSig, err := aliceChannel.SignSpliceCommitment(aliceSplice)
+	if err != nil {
+		t.Fatalf("alice unable to sign commitment: %v", err)
+	}
+	bobCommitSig, err := bobChannel.SignSpliceCommitment(bobSplice)
+	if err != nil {
+		t.Fatalf("bob unable to sign commitment: %v", err)
+	}
+	err = bobChannel.ReceiveSpliceCommitment(bobSplice, aliceCommitSig)
+	if err != nil {
+		t.Fatalf("bob unable to verify commitment sig: %v", err)
+	}
+	err = aliceChannel.ReceiveSpliceCommitment(aliceSplice, bobCommitSig)
+	if err != nil {
+		t.Fatalf("alice unable to verify commitment sig: %v", err)
+	}
+
+	// A signature for the wrong commitment should be rejected.
+	err = aliceChannel.ReceiveSpliceCommitment(aliceSplice, aliceCommitSig)
+	if err == nil {
+		t.Fatalf("alice accepted invalid commitment sig")
+	}
+
+	// Finally, Alice should be able to complete the funding input of the
+	// splice transaction using Bob's signature.
+	bobFundingSig, err := bobChannel.SignSpliceFunding(bobSplice)
+	if err != nil {
+		t.Fatalf("bob unable to sign funding input: %v", err)
+	}
+	err = aliceChannel.CompleteSpliceFunding(aliceSplice, bobFundingSig)
+	if err != nil {
+		t.Fatalf("alice unable to complete funding input: %v", err)
+	}
+
+	// Until the splice has been committed, neither side should sign a new
+	// commitment, as it isn't yet known whether the other side expects
+	// the splice version of the commitment to be signed.
+	_, _, _, err = aliceChannel.SignNextCommitment()
+	if err != ErrSpliceNegotiating {
+		t.Fatalf("expected ErrSpliceNegotiating, got: %v", err)
+	}
+
+	// Once recorded, the splice should be retrievable from the database.
+	if err := aliceChannel.channelState.FullSync(); err != nil {
+		t.Fatalf("unable to sync channel: %v", err)
+	}
+	if err := aliceChannel.CommitSplice(aliceSplice, true, 100); err != nil {
+		t.Fatalf("unable to commit splice: %v", err)
+	}
+	pendingSplice, err := aliceChannel.channelState.FetchPendingSplice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending splice: %v", err)
+	}
+	if pendingSplice.FundingOutpoint != aliceSplice.FundingOutpoint {
+		t.Fatalf("expected funding outpoint %v, got %v",
+			aliceSplice.FundingOutpoint, pendingSplice.FundingOutpoint)
+	}
+	if pendingSplice.Capacity != aliceChannel.Capacity+spliceAmt {
+		t.Fatalf("expected capacity %v, got %v",
+			aliceChannel.Capacity+spliceAmt, pendingSplice.Capacity)
+	}
+}
+
+// TestChannelSpliceStateTransition tests that once both parties have
+// committed to a splice, the channel remains active: each new state is signed
+// against both the current funding output and the new funding output of the
+// splice, and the splice version of our latest state is persisted such that
+// it survives a restart.
+func TestChannelSpliceStateTransition(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(1)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	if err := aliceChannel.channelState.FullSync(); err != nil {
+		t.Fatalf("unable to sync channel: %v", err)
+	}
+	if err := bobChannel.channelState.FullSync(); err != nil {
+		t.Fatalf("unable to sync channel: %v", err)
+	}
+
+	// Alice and Bob will negotiate a splice-in funded entirely by Alice.
+	spliceAmt := btcutil.Amount(btcutil.SatoshiPerBitcoin)
+	spliceTx := wire.NewMsgTx(2)
+	spliceTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: aliceChannel.channelState.FundingOutpoint,
+	})
+	spliceTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+	})
+	spliceTx.AddTxOut(&wire.TxOut{
+		PkScript: aliceChannel.fundingP2WSH,
+		Value:    int64(aliceChannel.Capacity + spliceAmt),
+	})
+	aliceSplice, err := aliceChannel.NewSplice(spliceTx, spliceAmt, 0)
+	if err != nil {
+		t.Fatalf("alice unable to create splice: %v", err)
+	}
+	bobSplice, err := bobChannel.NewSplice(spliceTx, 0, spliceAmt)
+	if err != nil {
+		t.Fatalf("bob unable to create splice: %v", err)
+	}
+
+	// A second splice shouldn't be accepted while the first is pending.
+	if _, err := aliceChannel.NewSplice(spliceTx, spliceAmt, 0); err != ErrSplicePending {
+		t.Fatalf("expected ErrSplicePending, got: %v", err)
+	}
+
+	aliceCommitSig, err := aliceChannel.SignSpliceCommitment(aliceSplice)
+	if err != nil {
+		t.Fatalf("alice unable to sign commitment: %v", err)
+	}
+	bobCommitSig, err := bobChannel.SignSpliceCommitment(bobSplice)
+	if err != nil {
+		t.Fatalf("bob unable to sign commitment: %v", err)
+	}
+	err = bobChannel.ReceiveSpliceCommitment(bobSplice, aliceCommitSig)
+	if err != nil {
+		t.Fatalf("bob unable to verify commitment sig: %v", err)
+	}
+	err = aliceChannel.ReceiveSpliceCommitment(aliceSplice, bobCommitSig)
+	if err != nil {
+		t.Fatalf("alice unable to verify commitment sig: %v", err)
+	}
+	if err := bobChannel.CommitSplice(bobSplice, false, 100); err != nil {
+		t.Fatalf("bob unable to commit splice: %v", err)
+	}
+	if err := aliceChannel.CommitSplice(aliceSplice, true, 100); err != nil {
+		t.Fatalf("alice unable to commit splice: %v", err)
+	}
+
+	// With the splice committed, Alice will now add an HTLC. Her new
+	// commitment should also be signed in its splice version.
+	htlcAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlc, _ := createHTLC(0, htlcAmt)
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	aliceSig, aliceHtlcSigs, aliceSpliceSigs, err := aliceChannel.SignNextCommitment()
+	if err != nil {
+		t.Fatalf("alice unable to sign commitment: %v", err)
+	}
+	if aliceSpliceSigs == nil {
+		t.Fatalf("alice didn't sign splice commitment")
+	}
+	if len(aliceSpliceSigs.HtlcSigs) != len(aliceHtlcSigs) {
+		t.Fatalf("expected %v splice htlc sigs, got %v",
+			len(aliceHtlcSigs), len(aliceSpliceSigs.HtlcSigs))
+	}
+
+	// Bob should reject the new commitment if it lacks the signatures for
+	// the splice version, and accept it otherwise.
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs, nil)
+	if err == nil {
+		t.Fatalf("bob accepted commitment without splice sigs")
+	}
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs,
+		aliceSpliceSigs)
+	if err != nil {
+		t.Fatalf("bob unable to receive commitment: %v", err)
+	}
+
+	// We'll now complete the state transition.
+	bobRevocation, err := bobChannel.RevokeCurrentCommitment()
+	if err != nil {
+		t.Fatalf("bob unable to revoke commitment: %v", err)
+	}
+	bobSig, bobHtlcSigs, bobSpliceSigs, err := bobChannel.SignNextCommitment()
+	if err != nil {
+		t.Fatalf("bob unable to sign commitment: %v", err)
+	}
+	if _, err := aliceChannel.ReceiveRevocation(bobRevocation); err != nil {
+		t.Fatalf("alice unable to receive revocation: %v", err)
+	}
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs,
+		bobSpliceSigs)
+	if err != nil {
+		t.Fatalf("alice unable to receive commitment: %v", err)
+	}
+	aliceRevocation, err := aliceChannel.RevokeCurrentCommitment()
+	if err != nil {
+		t.Fatalf("alice unable to revoke commitment: %v", err)
+	}
+	if _, err := bobChannel.ReceiveRevocation(aliceRevocation); err != nil {
+		t.Fatalf("bob unable to receive revocation: %v", err)
+	}
+
+	// Bob's persisted splice commitment should now be the splice version
+	// of his latest state, spending the new funding output and carrying
+	// the HTLC.
+	pendingSplice, err := bobChannel.channelState.FetchPendingSplice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending splice: %v", err)
+	}
+	bobTail := bobChannel.localCommitChain.tail()
+	if bobTail.splice == nil {
+		t.Fatalf("bob's latest state has no splice version")
+	}
+	if pendingSplice.CommitTx.TxHash() != bobTail.splice.txn.TxHash() {
+		t.Fatalf("splice commitment wasn't updated")
+	}
+	if pendingSplice.CommitTx.TxIn[0].PreviousOutPoint !=
+		bobSplice.FundingOutpoint {
+
+		t.Fatalf("splice commitment doesn't spend %v",
+			bobSplice.FundingOutpoint)
+	}
+	if len(pendingSplice.Htlcs) != 1 {
+		t.Fatalf("expected 1 htlc on splice commitment, got %v",
+			len(pendingSplice.Htlcs))
+	}
+
+	// The splice version should credit the spliced in amount to Alice.
+	expectedBalance := bobTail.theirBalance +
+		lnwire.NewMSatFromSatoshis(spliceAmt)
+	if bobTail.splice.theirBalance != expectedBalance {
+		t.Fatalf("expected alice splice balance of %v, got %v",
+			expectedBalance, bobTail.splice.theirBalance)
+	}
+
+	// Finally, after a restart Bob should continue to sign each new state
+	// against both funding outputs.
+	bobPub := bobChannel.channelState.IdentityPub
+	bobChannels, err := bobChannel.channelState.Db.FetchOpenChannels(bobPub)
+	if err != nil {
+		t.Fatalf("unable to fetch channel: %v", err)
+	}
+	notifier := bobChannel.channelEvents
+	bobChannelNew, err := NewLightningChannel(bobChannel.signer,
+		bobChannel.pCache, notifier, bobChannel.feeEstimator, bobChannels[0])
+	if err != nil {
+		t.Fatalf("unable to create new channel: %v", err)
+	}
+	defer bobChannelNew.Stop()
+
+	if bobChannelNew.pendingSplice == nil ||
+		!bobChannelNew.pendingSplice.active {
+
+		t.Fatalf("pending splice wasn't restored")
+	}
+	if bobChannelNew.pendingSplice.FundingOutpoint != bobSplice.FundingOutpoint {
+		t.Fatalf("expected splice of %v, got %v",
+			bobSplice.FundingOutpoint,
+			bobChannelNew.pendingSplice.FundingOutpoint)
+	}
+}
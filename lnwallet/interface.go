@@ -71,6 +71,10 @@ type TransactionDetail struct {
 
 	// TotalFees is the total fee in satoshis paid by this transaction.
 	TotalFees int64
+
+	// RawTx is the serialized transaction. This may be nil if the
+	// WalletController is unable to supply the raw transaction.
+	RawTx *wire.MsgTx
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -280,6 +284,22 @@ type FeeEstimator interface {
 	EstimateConfirmation(satPerByte int64) uint32
 }
 
+// PreimageCache is an interface that represents a global cache for all known
+// payment preimages. The cache is consulted when the channel needs to claim
+// an incoming HTLC on-chain, and populated whenever a preimage is learned,
+// either from a settle off-chain, or by observing the remote party sweeping
+// one of our outgoing HTLC's on-chain.
+type PreimageCache interface {
+	// LookupPreimage attempts to look up a preimage according to its hash.
+	// If found, the preimage is returned along with true for the second
+	// argument. Otherwise, it'll return false.
+	LookupPreimage(hash []byte) ([]byte, bool)
+
+	// AddPreimage attempts to add a new preimage to the global cache. If
+	// successful a nil error will be returned.
+	AddPreimage(preimage []byte) error
+}
+
 // WalletDriver represents a "driver" for a particular concrete
 // WalletController implementation. A driver is identified by a globally unique
 // string identifier along with a 'New()' method which is responsible for
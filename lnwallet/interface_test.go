@@ -256,7 +256,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	feePerKw := feePerWeight * 1000
 	aliceChanReservation, err := alice.InitChannelReservation(
 		fundingAmount*2, fundingAmount, 0, feePerKw,
-		bobPub, bobAddr, chainHash)
+		bobPub, bobAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -280,7 +280,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	// the funding process.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmount*2,
 		fundingAmount, 0, feePerKw, alicePub, aliceAddr,
-		chainHash)
+		chainHash, 0)
 	if err != nil {
 		t.Fatalf("bob unable to init channel reservation: %v", err)
 	}
@@ -401,7 +401,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	feePerWeight := btcutil.Amount(alice.Cfg.FeeEstimator.EstimateFeePerWeight(1))
 	feePerKw := feePerWeight * 1000
 	_, err := alice.InitChannelReservation(fundingAmount,
-		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash)
+		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation 1: %v", err)
 	}
@@ -411,7 +411,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	// that aren't locked, so this should fail.
 	amt := btcutil.Amount(900 * 1e8)
 	failedReservation, err := alice.InitChannelReservation(amt, amt, 0,
-		feePerKw, bobPub, bobAddr, chainHash)
+		feePerKw, bobPub, bobAddr, chainHash, 0)
 	if err == nil {
 		t.Fatalf("not error returned, should fail on coin selection")
 	}
@@ -432,14 +432,14 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	// Create a reservation for 44 BTC.
 	fundingAmount := btcutil.Amount(44 * 1e8)
 	chanReservation, err := alice.InitChannelReservation(fundingAmount,
-		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash)
+		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
 
 	// Attempt to create another channel with 44 BTC, this should fail.
 	_, err = alice.InitChannelReservation(fundingAmount,
-		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash)
+		fundingAmount, 0, feePerKw, bobPub, bobAddr, chainHash, 0)
 	if _, ok := err.(*lnwallet.ErrInsufficientFunds); !ok {
 		t.Fatalf("coin selection succeded should have insufficient funds: %v",
 			err)
@@ -469,7 +469,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 
 	// Request to fund a new channel should now succeed.
 	_, err = alice.InitChannelReservation(fundingAmount, fundingAmount, 0,
-		feePerKw, bobPub, bobAddr, chainHash)
+		feePerKw, bobPub, bobAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -482,7 +482,7 @@ func testCancelNonExistantReservation(miner *rpctest.Harness,
 
 	// Create our own reservation, give it some ID.
 	res := lnwallet.NewChannelReservation(1000, 1000, feeRate, alice,
-		22, 10, &testHdSeed)
+		22, 10, &testHdSeed, 0)
 
 	// Attempt to cancel this reservation. This should fail, we know
 	// nothing of it.
@@ -548,7 +548,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	feePerWeight := btcutil.Amount(alice.Cfg.FeeEstimator.EstimateFeePerWeight(1))
 	feePerKw := feePerWeight * 1000
 	aliceChanReservation, err := alice.InitChannelReservation(fundingAmt,
-		fundingAmt, pushAmt, feePerKw, bobPub, bobAddr, chainHash)
+		fundingAmt, pushAmt, feePerKw, bobPub, bobAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to init channel reservation: %v", err)
 	}
@@ -572,7 +572,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	// Next, Bob receives the initial request, generates a corresponding
 	// reservation initiation, then consume Alice's contribution.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmt, 0,
-		pushAmt, feePerKw, alicePub, aliceAddr, chainHash)
+		pushAmt, feePerKw, alicePub, aliceAddr, chainHash, 0)
 	if err != nil {
 		t.Fatalf("unable to create bob reservation: %v", err)
 	}
@@ -723,6 +723,212 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	assertReservationDeleted(bobChanReservation, t)
 }
 
+func testBatchFundingWorkflow(miner *rpctest.Harness,
+	alice, bob *lnwallet.LightningWallet, t *testing.T) {
+
+	// For this scenario, Alice will open two channels to Bob within a
+	// single funding transaction.
+	const numChannels = 2
+	fundingAmt := btcutil.Amount(2 * 1e8)
+	feePerWeight := btcutil.Amount(alice.Cfg.FeeEstimator.EstimateFeePerWeight(1))
+	feePerKw := feePerWeight * 1000
+
+	// First, Alice will create the batch which selects the coins for both
+	// channels at once.
+	batch, err := alice.InitFundingBatch(fundingAmt*numChannels, numChannels)
+	if err != nil {
+		t.Fatalf("unable to init funding batch: %v", err)
+	}
+
+	var (
+		aliceReservations [numChannels]*lnwallet.ChannelReservation
+		bobReservations   [numChannels]*lnwallet.ChannelReservation
+	)
+	for i := 0; i < numChannels; i++ {
+		aliceRes, err := alice.InitBatchedChannelReservation(batch,
+			fundingAmt, fundingAmt, 0, feePerKw, bobPub, bobAddr,
+			chainHash, 0)
+		if err != nil {
+			t.Fatalf("unable to init batched reservation: %v", err)
+		}
+		aliceRes.SetNumConfsRequired(numReqConfs)
+		aliceRes.CommitConstraints(csvDelay, lnwallet.MaxHTLCNumber/2,
+			lnwire.NewMSatFromSatoshis(fundingAmt), 10)
+
+		// As the batch owns the inputs, the reservation itself
+		// shouldn't have selected any coins.
+		aliceContribution := aliceRes.OurContribution()
+		if len(aliceContribution.Inputs) != 0 {
+			t.Fatalf("batched reservation shouldn't have inputs, "+
+				"instead has %v", len(aliceContribution.Inputs))
+		}
+		aliceContribution.CsvDelay = csvDelay
+
+		bobRes, err := bob.InitChannelReservation(fundingAmt, 0, 0,
+			feePerKw, alicePub, aliceAddr, chainHash, 0)
+		if err != nil {
+			t.Fatalf("unable to create bob reservation: %v", err)
+		}
+		bobRes.CommitConstraints(csvDelay, lnwallet.MaxHTLCNumber/2,
+			lnwire.NewMSatFromSatoshis(fundingAmt), 10)
+		bobRes.SetNumConfsRequired(numReqConfs)
+		bobContribution := bobRes.OurContribution()
+		bobContribution.CsvDelay = csvDelay
+
+		err = bobRes.ProcessSingleContribution(aliceContribution)
+		if err != nil {
+			t.Fatalf("bob unable to process alice's contribution: %v",
+				err)
+		}
+		err = aliceRes.ProcessContribution(bobContribution)
+		if err != nil {
+			t.Fatalf("alice unable to process bob's contribution: %v",
+				err)
+		}
+
+		// The funding transaction can't be created until all
+		// contributions within the batch are known.
+		if aliceRes.FinalFundingTx() != nil {
+			t.Fatalf("funding tx created before batch finalized")
+		}
+
+		aliceReservations[i] = aliceRes
+		bobReservations[i] = bobRes
+	}
+
+	// With all contributions processed, Alice can now assemble the shared
+	// funding transaction.
+	if err := batch.Finalize(); err != nil {
+		t.Fatalf("unable to finalize batch: %v", err)
+	}
+	fundingTx := batch.FundingTx()
+	if fundingTx == nil {
+		t.Fatalf("batch funding transaction never created")
+	}
+
+	// The transaction should carry a funding output for each channel,
+	// along with a single change output.
+	if len(fundingTx.TxOut) != numChannels+1 {
+		t.Fatalf("expected %v outputs, instead have %v",
+			numChannels+1, len(fundingTx.TxOut))
+	}
+
+	fundingSha := fundingTx.TxHash()
+	seenIndexes := make(map[uint32]struct{})
+	for i := 0; i < numChannels; i++ {
+		aliceRes := aliceReservations[i]
+		bobRes := bobReservations[i]
+
+		fundingPoint := aliceRes.FundingOutpoint()
+		if fundingPoint.Hash != fundingSha {
+			t.Fatalf("reservation doesn't spend from batch tx")
+		}
+		if _, ok := seenIndexes[fundingPoint.Index]; ok {
+			t.Fatalf("duplicate funding output index %v",
+				fundingPoint.Index)
+		}
+		seenIndexes[fundingPoint.Index] = struct{}{}
+
+		_, aliceCommitSig := aliceRes.OurSignatures()
+		_, err = bobRes.CompleteReservationSingle(
+			fundingPoint, aliceCommitSig,
+		)
+		if err != nil {
+			t.Fatalf("bob unable to consume single reservation: %v",
+				err)
+		}
+
+		_, bobCommitSig := bobRes.OurSignatures()
+		_, err = aliceRes.CompleteReservation(nil, bobCommitSig)
+		if err != nil {
+			t.Fatalf("alice unable to complete reservation: %v", err)
+		}
+	}
+
+	// Until the batch has been published, Alice shouldn't have written
+	// any of the channels to disk.
+	aliceChannels, err := alice.Cfg.Database.FetchOpenChannels(bobPub)
+	if err != nil {
+		t.Fatalf("unable to retrieve channels from DB: %v", err)
+	}
+	if len(aliceChannels) != 0 {
+		t.Fatalf("channels written before batch published")
+	}
+
+	if err := batch.Publish(); err != nil {
+		t.Fatalf("unable to publish batch: %v", err)
+	}
+
+	aliceChannels, err = alice.Cfg.Database.FetchOpenChannels(bobPub)
+	if err != nil {
+		t.Fatalf("unable to retrieve channels from DB: %v", err)
+	}
+	if len(aliceChannels) != numChannels {
+		t.Fatalf("alice should have %v channels, instead has %v",
+			numChannels, len(aliceChannels))
+	}
+
+	// Mine a single block, the batch funding transaction should be
+	// included within this block.
+	blockHashes, err := miner.Node.Generate(1)
+	if err != nil {
+		t.Fatalf("unable to generate block: %v", err)
+	}
+	block, err := miner.Node.GetBlock(blockHashes[0])
+	if err != nil {
+		t.Fatalf("unable to find block: %v", err)
+	}
+	if len(block.Transactions) != 2 {
+		t.Fatalf("funding transaction wasn't mined: %v", err)
+	}
+	if block.Transactions[1].TxHash() != fundingSha {
+		t.Fatalf("incorrect transaction was mined")
+	}
+
+	for i := 0; i < numChannels; i++ {
+		assertReservationDeleted(aliceReservations[i], t)
+		assertReservationDeleted(bobReservations[i], t)
+	}
+}
+
+func testFundingBatchCancellation(miner *rpctest.Harness,
+	alice, _ *lnwallet.LightningWallet, t *testing.T) {
+
+	feePerWeight := btcutil.Amount(alice.Cfg.FeeEstimator.EstimateFeePerWeight(1))
+	feePerKw := feePerWeight * 1000
+
+	// Create a batch of two channels, each with 4 BTC.
+	fundingAmt := btcutil.Amount(4 * 1e8)
+	batch, err := alice.InitFundingBatch(fundingAmt*2, 2)
+	if err != nil {
+		t.Fatalf("unable to init funding batch: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err := alice.InitBatchedChannelReservation(batch, fundingAmt,
+			fundingAmt, 0, feePerKw, bobPub, bobAddr, chainHash, 0)
+		if err != nil {
+			t.Fatalf("unable to init batched reservation: %v", err)
+		}
+	}
+
+	if len(alice.LockedOutpoints()) == 0 {
+		t.Fatalf("batch inputs weren't locked")
+	}
+
+	// Cancelling the batch should release all of its inputs, and remove
+	// all of its reservations.
+	if err := batch.Cancel(); err != nil {
+		t.Fatalf("unable to cancel batch: %v", err)
+	}
+	if len(alice.LockedOutpoints()) != 0 {
+		t.Fatalf("outpoints still locked")
+	}
+	if len(alice.ActiveReservations()) != 0 {
+		t.Fatalf("should have 0 reservations, instead have %v",
+			len(alice.ActiveReservations()))
+	}
+}
+
 func testListTransactionDetails(miner *rpctest.Harness,
 	alice, _ *lnwallet.LightningWallet, t *testing.T) {
 
@@ -1090,6 +1296,14 @@ var walletTests = []walletTestCase{
 		name: "single funding workflow",
 		test: testSingleFunderReservationWorkflow,
 	},
+	{
+		name: "batch funding workflow",
+		test: testBatchFundingWorkflow,
+	},
+	{
+		name: "batch funding cancellation",
+		test: testFundingBatchCancellation,
+	},
 	{
 		name: "dual funder workflow",
 		test: testDualFundingReservationWorkflow,
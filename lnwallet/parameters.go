@@ -1,6 +1,7 @@
 package lnwallet
 
 import (
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcutil"
 	"github.com/roasbeef/btcwallet/wallet/txrules"
 )
@@ -10,3 +11,21 @@ import (
 func DefaultDustLimit() btcutil.Amount {
 	return txrules.GetDustThreshold(P2WSHSize, txrules.DefaultRelayFeePerKb)
 }
+
+// AnchorOutputValue returns the value of each of the anchor outputs on the
+// commitment transactions of channels with anchor outputs. The anchors are
+// valued at the dust limit, ensuring the commitment transaction is relayed.
+func AnchorOutputValue() btcutil.Amount {
+	return DefaultDustLimit()
+}
+
+// anchorsValue returns the total value of the anchor outputs on a commitment
+// transaction of the passed channel type. This value is deducted from the
+// balance of the channel initiator, alongside the commitment fee.
+func anchorsValue(chanType channeldb.ChannelType) btcutil.Amount {
+	if !chanType.HasAnchors() {
+		return 0
+	}
+
+	return 2 * AnchorOutputValue()
+}
@@ -127,6 +127,11 @@ type ChannelReservation struct {
 	chanOpen    chan *openChanDetails
 	chanOpenErr chan error
 
+	// batch is the funding batch this reservation belongs to, if any. If
+	// non-nil, then the funding transaction of this reservation is shared
+	// with all other reservations within the batch.
+	batch *FundingBatch
+
 	wallet *LightningWallet
 }
 
@@ -136,7 +141,8 @@ type ChannelReservation struct {
 // lnwallet.InitChannelReservation interface.
 func NewChannelReservation(capacity, fundingAmt, feePerKw btcutil.Amount,
 	wallet *LightningWallet, id uint64, pushMSat lnwire.MilliSatoshi,
-	chainHash *chainhash.Hash) *ChannelReservation {
+	chainHash *chainhash.Hash,
+	commitType channeldb.ChannelType) *ChannelReservation {
 
 	var (
 		ourBalance   lnwire.MilliSatoshi
@@ -144,11 +150,19 @@ func NewChannelReservation(capacity, fundingAmt, feePerKw btcutil.Amount,
 		initiator    bool
 	)
 
-	commitFee := btcutil.Amount((int64(feePerKw) * commitWeight) / 1000)
+	// If the anchor output format was negotiated, then the commitment
+	// transaction carries two additional outputs. Their weight is
+	// accounted for within the commitment fee, and their value is paid
+	// for by the party that pays the fee, so we'll fold it into the fee
+	// deducted below.
+	weight := baseCommitWeight(commitType)
+	commitFee := btcutil.Amount((int64(feePerKw) * weight) / 1000)
 
 	fundingMSat := lnwire.NewMSatFromSatoshis(fundingAmt)
 	capacityMSat := lnwire.NewMSatFromSatoshis(capacity)
-	feeMSat := lnwire.NewMSatFromSatoshis(commitFee)
+	feeMSat := lnwire.NewMSatFromSatoshis(
+		commitFee + anchorsValue(commitType),
+	)
 
 	// If we're the responder to a single-funder reservation, then we have
 	// no initial balance in the channel unless the remote party is pushing
@@ -195,6 +209,10 @@ func NewChannelReservation(capacity, fundingAmt, feePerKw btcutil.Amount,
 		chanType = channeldb.DualFunder
 	}
 
+	// Finally, we'll apply the commitment format negotiated with the
+	// remote party.
+	chanType |= commitType
+
 	return &ChannelReservation{
 		ourContribution: &ChannelContribution{
 			FundingAmount: ourBalance.ToSatoshis(),
@@ -236,6 +254,26 @@ func (r *ChannelReservation) SetNumConfsRequired(numConfs uint16) {
 	r.partialState.NumConfsRequired = numConfs
 }
 
+// SetLocalShutdownScript sets the upfront shutdown script we commit to during
+// the funding workflow. Once committed, any future cooperative close must pay
+// our funds to this script.
+func (r *ChannelReservation) SetLocalShutdownScript(script []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.LocalShutdownScript = script
+}
+
+// SetRemoteShutdownScript sets the upfront shutdown script the remote party
+// committed to during the funding workflow. Once set, we MUST reject any
+// cooperative close which pays the remote party's funds elsewhere.
+func (r *ChannelReservation) SetRemoteShutdownScript(script []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.RemoteShutdownScript = script
+}
+
 // CommitConstraints takes the constraints that the remote party specifies for
 // the type of commitments that we can generate for them. These constraints
 // include several parameters that serve as flow control restricting the amount
@@ -339,6 +339,17 @@ func senderHtlcSpendRedeem(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// SenderHtlcSpendRedeem constructs a valid witness allowing the receiver of
+// an HTLC to redeem the pending output directly from the commitment
+// transaction of the sender of the HTLC using the payment preimage. The
+// passed SignDescriptor should be populated with our raw payment base point,
+// and the single tweak derived from the sender's commitment point.
+func SenderHtlcSpendRedeem(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx, paymentPreimage []byte) (wire.TxWitness, error) {
+
+	return senderHtlcSpendRedeem(signer, signDesc, sweepTx, paymentPreimage)
+}
+
 // senderHtlcSpendTimeout constructs a valid witness allowing the sender of an
 // HTLC to activate the time locked covenant clause of a soon to be expired
 // HTLC.  This script simply spends the multi-sig output using the
@@ -597,6 +608,20 @@ func receiverHtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// ReceiverHtlcSpendTimeout constructs a valid witness allowing the sender of
+// an HTLC to recover the pending funds from the commitment transaction of the
+// receiver after the absolute timeout of the HTLC has passed.
+//
+// NOTE: The caller MUST set the lock time of the passed transaction to the
+// expiry of the HTLC (or greater), and ensure that the target input doesn't
+// have a final sequence number before invocation.
+func ReceiverHtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	return receiverHtlcSpendTimeout(signer, signDesc, sweepTx,
+		sweepTx.LockTime)
+}
+
 // createHtlcTimeoutTx creates a transaction that spends the HTLC output on the
 // commitment transaction of the peer that created an HTLC (the sender). This
 // transaction essentially acts as an off-chain covenant as it spends a 2-of-2
@@ -804,6 +829,33 @@ func htlcSpendSuccess(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// HtlcSecondLevelSpend exposes the public witness generation function for
+// spending the output of either an HTLC success or HTLC timeout transaction
+// after the relative time lock of the output has passed. Unlike
+// htlcSpendSuccess, the passed transaction isn't modified, allowing the
+// output to be swept within a transaction that has several inputs.
+//
+// NOTE: The caller MUST set the txn version, sequence number, and sign
+// descriptor's sig hash cache before invocation.
+func HtlcSecondLevelSpend(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// We set a zero as the first element the witness stack (ignoring the
+	// witness script), in order to force execution to the delay clause of
+	// the second level HTLC script.
+	witnessStack := wire.TxWitness(make([][]byte, 3))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = nil
+	witnessStack[2] = signDesc.WitnessScript
+
+	return witnessStack, nil
+}
+
 // htlcTimeoutRevoke spends a second-level HTLC output. This function is to be
 // used by the sender or receiver of an HTLC to claim the HTLC after a revoked
 // commitment transaction was broadcast.
@@ -909,6 +961,78 @@ func commitScriptUnencumbered(key *btcec.PublicKey) ([]byte, error) {
 	return builder.Script()
 }
 
+// AnchorCsvDelay is the number of confirmations of a commitment transaction
+// after which anyone is able to spend its anchor outputs. This allows the
+// anchors to be cleaned up from the UTXO set if they aren't spent by their
+// owners.
+const AnchorCsvDelay = 16
+
+// CommitScriptAnchor constructs the script for an anchor output on the
+// commitment transaction. The output can be spent immediately by the owner of
+// the funding key, allowing it to bump the fee of the commitment transaction
+// via CPFP, or by anyone once the commitment transaction has been confirmed
+// for AnchorCsvDelay blocks.
+//
+// Possible Input Scripts:
+//     OWNER:  <sig>
+//     ANYONE: <emptyvector> (after AnchorCsvDelay confirmations)
+//
+// Output Script:
+//     <fundingKey> OP_CHECKSIG OP_IFDUP
+//     OP_NOTIF
+//         OP_16 OP_CHECKSEQUENCEVERIFY
+//     OP_ENDIF
+func CommitScriptAnchor(fundingKey *btcec.PublicKey) ([]byte, error) {
+	builder := txscript.NewScriptBuilder()
+
+	// If a valid signature under the funding key is presented, then the
+	// output may be spent immediately.
+	builder.AddData(fundingKey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	builder.AddOp(txscript.OP_IFDUP)
+
+	// Otherwise, anyone may spend the output once the relative delay
+	// has passed.
+	builder.AddOp(txscript.OP_NOTIF)
+	builder.AddInt64(AnchorCsvDelay)
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_ENDIF)
+
+	return builder.Script()
+}
+
+// CommitSpendAnchor constructs a valid witness allowing the owner of an anchor
+// output to spend it.
+//
+// NOTE: The passed SignDescriptor should include the funding key of the owner
+// of the anchor output.
+func CommitSpendAnchor(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = signDesc.WitnessScript
+
+	return witnessStack, nil
+}
+
+// CommitSpendAnchorAnyone constructs a witness allowing anyone to spend an
+// anchor output once the commitment transaction has been confirmed for
+// AnchorCsvDelay blocks. The input spending the anchor must have its sequence
+// set accordingly.
+func CommitSpendAnchorAnyone(script []byte) (wire.TxWitness, error) {
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = nil
+	witnessStack[1] = script
+
+	return witnessStack, nil
+}
+
 // CommitSpendTimeout constructs a valid witness allowing the owner of a
 // particular commitment transaction to spend the output returning settled
 // funds back to themselves after a relative block timeout.  In order to
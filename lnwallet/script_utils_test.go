@@ -72,7 +72,7 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	// immediately with either the revocation key, or his regular key.
 	commitmentTx, err := CreateCommitTx(fakeFundingTxIn, aliceDelayKey,
 		bobPayKey, revokePubKey, csvTimeout, channelBalance,
-		channelBalance, DefaultDustLimit())
+		channelBalance, DefaultDustLimit(), nil)
 	if err != nil {
 		t.Fatalf("unable to create commitment transaction: %v", nil)
 	}
@@ -210,6 +210,138 @@ func TestCommitmentSpendValidation(t *testing.T) {
 // TestRevocationKeyDerivation tests that given a public key, and a revocation
 // hash, the homomorphic revocation public and private key derivation work
 // properly.
+// TestCommitmentAnchorSpendValidation tests that the anchor outputs of a
+// commitment transaction can be spent by their owner immediately, and by
+// anyone once the anchor's relative delay has passed.
+func TestCommitmentAnchorSpendValidation(t *testing.T) {
+	t.Parallel()
+
+	txid, err := chainhash.NewHash(testHdSeed.CloneBytes())
+	if err != nil {
+		t.Fatalf("unable to create txid: %v", err)
+	}
+	fundingOut := &wire.OutPoint{
+		Hash:  *txid,
+		Index: 50,
+	}
+	fakeFundingTxIn := wire.NewTxIn(fundingOut, nil, nil)
+
+	const channelBalance = btcutil.Amount(1 * 10e8)
+	const csvTimeout = uint32(5)
+
+	aliceKeyPriv, aliceKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		testWalletPrivKey)
+	_, bobKeyPub := btcec.PrivKeyFromBytes(btcec.S256(), bobsPrivKey)
+
+	_, commitPoint := btcec.PrivKeyFromBytes(btcec.S256(),
+		testHdSeed.CloneBytes())
+	revokePubKey := DeriveRevocationPubkey(bobKeyPub, commitPoint)
+	aliceDelayKey := TweakPubKey(aliceKeyPub, commitPoint)
+	bobPayKey := TweakPubKey(bobKeyPub, commitPoint)
+
+	// We'll create Alice's commitment transaction with an anchor output
+	// for each party, keyed by their funding keys.
+	commitmentTx, err := CreateCommitTx(fakeFundingTxIn, aliceDelayKey,
+		bobPayKey, revokePubKey, csvTimeout, channelBalance,
+		channelBalance, DefaultDustLimit(), &AnchorKeys{
+			SelfKey:  aliceKeyPub,
+			TheirKey: bobKeyPub,
+		})
+	if err != nil {
+		t.Fatalf("unable to create commitment transaction: %v", err)
+	}
+	if len(commitmentTx.TxOut) != 4 {
+		t.Fatalf("expected 4 outputs, instead got %v",
+			len(commitmentTx.TxOut))
+	}
+
+	anchorScript, err := CommitScriptAnchor(aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to create anchor script: %v", err)
+	}
+	anchorPkScript, err := witnessScriptHash(anchorScript)
+	if err != nil {
+		t.Fatalf("unable to create anchor pkScript: %v", err)
+	}
+
+	var anchorOutput *wire.TxOut
+	anchorIndex := -1
+	for i, txOut := range commitmentTx.TxOut {
+		if bytes.Equal(txOut.PkScript, anchorPkScript) {
+			anchorOutput = txOut
+			anchorIndex = i
+		}
+	}
+	if anchorOutput == nil {
+		t.Fatalf("unable to find alice's anchor output")
+	}
+	if btcutil.Amount(anchorOutput.Value) != AnchorOutputValue() {
+		t.Fatalf("expected anchor value of %v, instead got %v",
+			AnchorOutputValue(), anchorOutput.Value)
+	}
+
+	targetOutput, err := commitScriptUnencumbered(aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to create target output: %v", err)
+	}
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{
+		Hash:  commitmentTx.TxHash(),
+		Index: uint32(anchorIndex),
+	}, nil, nil))
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: targetOutput,
+		Value:    anchorOutput.Value / 2,
+	})
+
+	validateSpend := func() error {
+		vm, err := txscript.NewEngine(anchorOutput.PkScript,
+			sweepTx, 0, txscript.StandardVerifyFlags, nil,
+			nil, anchorOutput.Value)
+		if err != nil {
+			t.Fatalf("unable to create engine: %v", err)
+		}
+		return vm.Execute()
+	}
+
+	// First, Alice should be able to spend her anchor immediately using
+	// her funding key.
+	signDesc := &SignDescriptor{
+		WitnessScript: anchorScript,
+		PubKey:        aliceKeyPub,
+		SigHashes:     txscript.NewTxSigHashes(sweepTx),
+		Output:        anchorOutput,
+		HashType:      txscript.SigHashAll,
+		InputIndex:    0,
+	}
+	aliceWitness, err := CommitSpendAnchor(&mockSigner{aliceKeyPriv},
+		signDesc, sweepTx)
+	if err != nil {
+		t.Fatalf("unable to generate anchor spend witness: %v", err)
+	}
+	sweepTx.TxIn[0].Witness = aliceWitness
+	if err := validateSpend(); err != nil {
+		t.Fatalf("anchor spend by owner is invalid: %v", err)
+	}
+
+	// Anyone else shouldn't be able to spend the anchor before its
+	// relative delay has passed.
+	anyoneWitness, err := CommitSpendAnchorAnyone(anchorScript)
+	if err != nil {
+		t.Fatalf("unable to generate anchor spend witness: %v", err)
+	}
+	sweepTx.TxIn[0].Witness = anyoneWitness
+	if err := validateSpend(); err == nil {
+		t.Fatalf("anchor spend before delay should be invalid")
+	}
+
+	// Once the delay has passed, anyone may spend the anchor.
+	sweepTx.TxIn[0].Sequence = lockTimeToSequence(false, AnchorCsvDelay)
+	if err := validateSpend(); err != nil {
+		t.Fatalf("anchor spend after delay is invalid: %v", err)
+	}
+}
+
 func TestRevocationKeyDerivation(t *testing.T) {
 	t.Parallel()
 
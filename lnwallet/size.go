@@ -1,6 +1,7 @@
 package lnwallet
 
 import (
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcd/blockchain"
 )
 
@@ -118,6 +119,27 @@ const (
 	//	- LockTime: 4 bytes
 	BaseSweepTxSize = 4 + 2 + 1 + P2WKHOutputSize + 4
 
+	// AnchorScriptSize 40 bytes
+	//	- OP_DATA: 1 byte (funding_pubkey length)
+	//	- funding_pubkey: 33 bytes
+	//	- OP_CHECKSIG: 1 byte
+	//	- OP_IFDUP: 1 byte
+	//	- OP_NOTIF: 1 byte
+	//	- OP_16: 1 byte
+	//	- OP_CHECKSEQUENCEVERIFY: 1 byte
+	//	- OP_ENDIF: 1 byte
+	AnchorScriptSize = 1 + 33 + 6
+
+	// AnchorOutputSize 43 bytes
+	//	- Value: 8 bytes
+	//	- VarInt: 1 byte (PkScript length)
+	//	- PkScript (P2WSH)
+	AnchorOutputSize = 8 + 1 + P2WSHSize
+
+	// AnchorsWeight 344 weight
+	//	- Both the local and remote anchor outputs
+	AnchorsWeight = 2 * blockchain.WitnessScaleFactor * AnchorOutputSize
+
 	// BaseCommitmentTxSize 125 + 43 * num-htlc-outputs bytes
 	//	- Version: 4 bytes
 	//	- WitnessHeader <---- part of the witness data
@@ -283,6 +305,44 @@ const (
 	//      - witness_script_length: 1 byte
 	//      - witness_script (offered_htlc_script)
 	OfferedHtlcPenaltyWitnessSize = 1 + 1 + 73 + 1 + 1 + OfferedHtlcScriptSize
+
+	// AnchorWitnessSize 116 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - sig_length: 1 byte
+	//      - sig: 73 bytes
+	//      - witness_script_length: 1 byte
+	//      - witness_script (anchor_script)
+	AnchorWitnessSize = 1 + 1 + 73 + 1 + AnchorScriptSize
+
+	// ToLocalTimeoutWitnessSize 160 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - local_delay_sig_length: 1 byte
+	//      - local_delay_sig: 73 bytes
+	//      - zero_length: 1 byte
+	//      - witness_script_length: 1 byte
+	//      - witness_script (to_local_script)
+	ToLocalTimeoutWitnessSize = 1 + 1 + 73 + 1 + 1 + ToLocalPenaltyScriptSize
+
+	// AcceptedHtlcTimeoutWitnessSize 216 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - sender_sig_length: 1 byte
+	//      - sender_sig: 73 bytes
+	//      - zero_length: 1 byte
+	//      - witness_script_length: 1 byte
+	//      - witness_script (accepted_htlc_script)
+	AcceptedHtlcTimeoutWitnessSize = 1 + 1 + 73 + 1 + 1 +
+		AcceptedHtlcPenaltyScriptSize
+
+	// OfferedHtlcSuccessWitnessSize 242 bytes
+	//      - number_of_witness_elements: 1 byte
+	//      - receiver_sig_length: 1 byte
+	//      - receiver_sig: 73 bytes
+	//      - payment_preimage_length: 1 byte
+	//      - payment_preimage: 32 bytes
+	//      - witness_script_length: 1 byte
+	//      - witness_script (offered_htlc_script)
+	OfferedHtlcSuccessWitnessSize = 1 + 1 + 73 + 1 + 32 + 1 +
+		OfferedHtlcScriptSize
 )
 
 // estimateCommitTxWeight estimate commitment transaction weight depending on
@@ -301,3 +361,13 @@ func estimateCommitTxWeight(count int, prediction bool) int64 {
 
 	return htlcWeight + baseWeight + witnessWeight
 }
+
+// baseCommitWeight returns the weight of a commitment transaction of the
+// passed channel type, excluding any HTLC outputs.
+func baseCommitWeight(chanType channeldb.ChannelType) int64 {
+	if chanType.HasAnchors() {
+		return commitWeight + AnchorsWeight
+	}
+
+	return commitWeight
+}
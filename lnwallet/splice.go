@@ -0,0 +1,732 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+var (
+	// ErrSpliceNotQuiescent is returned when a splice is attempted on a
+	// channel that still has un-acked updates or active HTLCs.
+	ErrSpliceNotQuiescent = fmt.Errorf("channel must be fully synced " +
+		"with no active HTLCs in order to splice")
+
+	// ErrSplicePending is returned when a splice is attempted on a
+	// channel which is already being spliced.
+	ErrSplicePending = fmt.Errorf("channel already has a pending splice")
+
+	// ErrSpliceNegotiating is returned when attempting to sign a new
+	// commitment while a splice of the channel is being negotiated, as
+	// it's not yet known whether the remote party expects the splice
+	// version of the commitment to be signed.
+	ErrSpliceNegotiating = fmt.Errorf("unable to sign new commitment " +
+		"while a splice is being negotiated")
+
+	// ErrSpliceBalance is returned when a new commitment would result in
+	// a negative balance for either party once the pending splice of the
+	// channel has been applied.
+	ErrSpliceBalance = fmt.Errorf("commitment would result in a " +
+		"negative balance after splice")
+)
+
+// Splice houses the state of a splice of an active channel. A splice spends
+// the current funding output of the channel along with any additional
+// inputs, and re-creates the funding output (with the same multi-sig keys) at
+// a new value. The channel remains active while the splice transaction
+// confirms: each new state is signed in two versions, one spending the
+// current funding output, and one spending the new funding output. Once the
+// splice transaction has confirmed, the latter version becomes the state of
+// the channel.
+type Splice struct {
+	// SpliceTx is the transaction which spends the current funding output
+	// and creates the new one.
+	SpliceTx *wire.MsgTx
+
+	// FundingOutpoint is the outpoint of the new funding output within
+	// the splice transaction.
+	FundingOutpoint wire.OutPoint
+
+	// Capacity is the capacity of the channel after the splice.
+	Capacity btcutil.Amount
+
+	// LocalBalance is our balance after the splice, as of the state at
+	// which the splice was negotiated.
+	LocalBalance lnwire.MilliSatoshi
+
+	// RemoteBalance is the balance of the remote party after the splice,
+	// as of the state at which the splice was negotiated.
+	RemoteBalance lnwire.MilliSatoshi
+
+	// localDelta and remoteDelta are the amounts (which may be negative)
+	// added to or removed from our balance and the balance of the remote
+	// party by the splice. These are applied to each new state of the
+	// channel while the splice is pending.
+	localDelta  btcutil.Amount
+	remoteDelta btcutil.Amount
+
+	// fundingInputIndex is the index of the input within the splice
+	// transaction that spends the current funding output.
+	fundingInputIndex int
+
+	// fundingTxIn is the input which spends the new funding output within
+	// each commitment transaction of the splice.
+	fundingTxIn *wire.TxIn
+
+	// signDesc is the sign descriptor used to sign commitment
+	// transactions which spend the new funding output.
+	signDesc *SignDescriptor
+
+	// ourCommit is our version of the commitment spending the new
+	// funding output at the state the splice was negotiated at. Its sig
+	// is populated once the remote party's signature has been verified.
+	ourCommit *commitment
+
+	// theirCommit is the remote party's version of the commitment
+	// spending the new funding output at the state the splice was
+	// negotiated at.
+	theirCommit *commitment
+
+	// active denotes that both parties have committed to the splice, so
+	// each new commitment must also be signed in its version spending
+	// the new funding output. Until then, no new commitments may be
+	// signed.
+	active bool
+
+	// committed denotes that we've durably recorded the splice, after
+	// which the splice version of each new local commitment is persisted
+	// along with it.
+	committed bool
+}
+
+// SpliceCommitSigs houses the signatures for the version of a new commitment
+// which spends the new funding output of a pending splice.
+type SpliceCommitSigs struct {
+	// CommitSig is the signature for the commitment transaction itself.
+	CommitSig *btcec.Signature
+
+	// HtlcSigs is a signature for each non-dust HTLC on the commitment
+	// transaction, sorted according to the BIP 69 order of the HTLCs.
+	HtlcSigs []*btcec.Signature
+}
+
+// fullySynced is identical to FullySynced, but expects the caller to hold
+// the channel's mutex.
+func (lc *LightningChannel) fullySynced() bool {
+	oweCommitment := (lc.localCommitChain.tip().height >
+		lc.remoteCommitChain.tip().height)
+
+	localUpdatesSynced := (lc.localCommitChain.tip().ourMessageIndex ==
+		lc.remoteCommitChain.tip().ourMessageIndex)
+
+	remoteUpdatesSynced := (lc.localCommitChain.tip().theirMessageIndex ==
+		lc.remoteCommitChain.tip().theirMessageIndex)
+
+	return !oweCommitment && localUpdatesSynced && remoteUpdatesSynced
+}
+
+// newSpliceSignDesc returns a sign descriptor capable of signing commitment
+// transactions which spend a funding output of the given capacity, re-created
+// by a splice of the channel.
+func (lc *LightningChannel) newSpliceSignDesc(
+	capacity btcutil.Amount) *SignDescriptor {
+
+	signDesc := *lc.signDesc
+	signDesc.Output = &wire.TxOut{
+		PkScript: lc.fundingP2WSH,
+		Value:    int64(capacity),
+	}
+	signDesc.InputIndex = 0
+
+	return &signDesc
+}
+
+// NewSplice validates a proposed splice transaction for this channel, and
+// constructs the new commitment transactions for both parties which spend
+// the re-created funding output. The localDelta and remoteDelta are the
+// amounts (which may be negative) added to or removed from our balance and
+// the remote party's balance respectively. The commitment fee of the channel
+// is unchanged by a splice. The channel must be quiescent and carry no HTLCs
+// while the splice is negotiated, and no new commitments can be signed until
+// the splice is either committed via CommitSplice, or aborted via
+// AbortSplice.
+func (lc *LightningChannel) NewSplice(spliceTx *wire.MsgTx, localDelta,
+	remoteDelta btcutil.Amount) (*Splice, error) {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	if lc.status == channelClosing || lc.status == channelClosed ||
+		lc.status == channelDispute {
+
+		return nil, ErrChanClosing
+	}
+
+	if lc.pendingSplice != nil {
+		return nil, ErrSplicePending
+	}
+
+	// In order to splice, both commitment chains must be in sync, and
+	// there must be no outstanding updates of any kind, including those
+	// not yet covered by a commitment. This ensures that the new
+	// commitments are able to simply carry over the current balances.
+	if !lc.fullySynced() || lc.pendingACK || lc.pendingFeeUpdate != nil ||
+		lc.pendingAckFeeUpdate != nil {
+
+		return nil, ErrSpliceNotQuiescent
+	}
+	if lc.localCommitChain.tip().height != lc.localCommitChain.tail().height ||
+		lc.remoteCommitChain.tip().height != lc.remoteCommitChain.tail().height ||
+		lc.localUpdateLog.logIndex != lc.remoteCommitChain.tip().ourMessageIndex ||
+		lc.remoteUpdateLog.logIndex != lc.localCommitChain.tip().theirMessageIndex {
+
+		return nil, ErrSpliceNotQuiescent
+	}
+	localCommit := lc.localCommitChain.tail()
+	remoteCommit := lc.remoteCommitChain.tail()
+	if len(localCommit.outgoingHTLCs) != 0 ||
+		len(localCommit.incomingHTLCs) != 0 ||
+		len(remoteCommit.outgoingHTLCs) != 0 ||
+		len(remoteCommit.incomingHTLCs) != 0 {
+
+		return nil, ErrSpliceNotQuiescent
+	}
+
+	// Ensure that the transaction doesn't violate any consensus rules
+	// before we go any further.
+	if err := blockchain.CheckTransactionSanity(btcutil.NewTx(spliceTx)); err != nil {
+		return nil, err
+	}
+
+	// The splice transaction MUST spend the current funding output of
+	// the channel.
+	fundingInputIndex := -1
+	for i, txIn := range spliceTx.TxIn {
+		if txIn.PreviousOutPoint == lc.fundingTxIn.PreviousOutPoint {
+			fundingInputIndex = i
+			break
+		}
+	}
+	if fundingInputIndex == -1 {
+		return nil, fmt.Errorf("splice transaction doesn't spend "+
+			"funding outpoint %v", lc.channelState.FundingOutpoint)
+	}
+
+	// As the multi-sig keys are unchanged, the new funding output will
+	// have the very same output script as the current one.
+	found, fundingOutputIndex := FindScriptOutputIndex(spliceTx,
+		lc.fundingP2WSH)
+	if !found {
+		return nil, fmt.Errorf("splice transaction doesn't re-create " +
+			"the funding output")
+	}
+
+	// The value of the new funding output must exactly reflect the
+	// balance changes of both parties.
+	capacity := btcutil.Amount(spliceTx.TxOut[fundingOutputIndex].Value)
+	if capacity != lc.channelState.Capacity+localDelta+remoteDelta {
+		return nil, fmt.Errorf("splice capacity mismatch: expected %v, "+
+			"got %v", lc.channelState.Capacity+localDelta+remoteDelta,
+			capacity)
+	}
+
+	ourBalance := lc.channelState.LocalBalance.ToSatoshis() + localDelta
+	theirBalance := lc.channelState.RemoteBalance.ToSatoshis() + remoteDelta
+	if ourBalance < 0 || theirBalance < 0 {
+		return nil, ErrSpliceBalance
+	}
+
+	splice := &Splice{
+		SpliceTx: spliceTx,
+		FundingOutpoint: wire.OutPoint{
+			Hash:  spliceTx.TxHash(),
+			Index: fundingOutputIndex,
+		},
+		Capacity: capacity,
+		LocalBalance: lc.channelState.LocalBalance +
+			lnwire.NewMSatFromSatoshis(localDelta),
+		RemoteBalance: lc.channelState.RemoteBalance +
+			lnwire.NewMSatFromSatoshis(remoteDelta),
+		localDelta:        localDelta,
+		remoteDelta:       remoteDelta,
+		fundingInputIndex: fundingInputIndex,
+		signDesc:          lc.newSpliceSignDesc(capacity),
+	}
+	splice.fundingTxIn = wire.NewTxIn(&splice.FundingOutpoint, nil, nil)
+
+	// With the splice validated, we'll now construct the new commitment
+	// transactions for both parties at their current heights.
+	ourCommit, err := lc.newSpliceCommitment(false, localCommit, splice)
+	if err != nil {
+		return nil, err
+	}
+	theirCommit, err := lc.newSpliceCommitment(true, remoteCommit, splice)
+	if err != nil {
+		return nil, err
+	}
+	splice.ourCommit = ourCommit
+	splice.theirCommit = theirCommit
+
+	// Until the splice has been either committed or aborted, no new
+	// commitments can be signed. Any funds we splice out are no longer
+	// available to be sent within HTLCs.
+	lc.pendingSplice = splice
+	if localDelta < 0 {
+		lc.availableLocalBalance -= lnwire.NewMSatFromSatoshis(-localDelta)
+	}
+
+	walletLog.Debugf("ChannelPoint(%v): new splice to %v: %v",
+		lc.channelState.FundingOutpoint, splice.FundingOutpoint,
+		newLogClosure(func() string {
+			return spew.Sdump(splice.SpliceTx)
+		}),
+	)
+
+	return splice, nil
+}
+
+// newSpliceCommitment creates the version of the passed commitment at the
+// tail of the local or remote commitment chain which spends the new funding
+// output of the splice. As a splice can only be negotiated within a quiescent
+// channel, no HTLC outputs are present.
+//
+// NOTE: This method MUST be called with the channel's mutex held.
+func (lc *LightningChannel) newSpliceCommitment(remoteChain bool,
+	tail *commitment, splice *Splice) (*commitment, error) {
+
+	var commitPoint *btcec.PublicKey
+	if remoteChain {
+		commitPoint = lc.channelState.RemoteCurrentRevocation
+	} else {
+		commitSecret, err := lc.channelState.RevocationProducer.AtIndex(
+			tail.height,
+		)
+		if err != nil {
+			return nil, err
+		}
+		commitPoint = ComputeCommitmentPoint(commitSecret[:])
+	}
+
+	ourBalance := tail.ourBalance +
+		lnwire.NewMSatFromSatoshis(splice.localDelta)
+	theirBalance := tail.theirBalance +
+		lnwire.NewMSatFromSatoshis(splice.remoteDelta)
+
+	commitTx, err := lc.createCommitmentTx(remoteChain, splice.fundingTxIn,
+		ourBalance, theirBalance, &htlcView{}, tail.feePerKw,
+		commitPoint, tail.height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitment{
+		txn:               commitTx,
+		height:            tail.height,
+		ourBalance:        ourBalance,
+		ourMessageIndex:   tail.ourMessageIndex,
+		theirMessageIndex: tail.theirMessageIndex,
+		theirBalance:      theirBalance,
+		fee:               tail.fee,
+		feePerKw:          tail.feePerKw,
+	}, nil
+}
+
+// fetchSpliceCommitmentView returns the version of the passed commitment
+// view which spends the new funding output of the pending splice. It carries
+// the very same HTLCs and fee, with the balances of both parties adjusted by
+// the splice.
+//
+// NOTE: This method MUST be called with the channel's mutex held.
+func (lc *LightningChannel) fetchSpliceCommitmentView(remoteChain bool,
+	c *commitment, filteredHTLCView *htlcView,
+	commitPoint *btcec.PublicKey) (*commitment, error) {
+
+	splice := lc.pendingSplice
+
+	ourBalance := int64(c.ourBalance) + int64(splice.localDelta)*1000
+	theirBalance := int64(c.theirBalance) + int64(splice.remoteDelta)*1000
+	if ourBalance < 0 || theirBalance < 0 {
+		return nil, ErrSpliceBalance
+	}
+
+	commitTx, err := lc.createCommitmentTx(remoteChain, splice.fundingTxIn,
+		lnwire.MilliSatoshi(ourBalance), lnwire.MilliSatoshi(theirBalance),
+		filteredHTLCView, c.feePerKw, commitPoint, c.height)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &commitment{
+		txn:               commitTx,
+		height:            c.height,
+		ourBalance:        lnwire.MilliSatoshi(ourBalance),
+		ourMessageIndex:   c.ourMessageIndex,
+		theirMessageIndex: c.theirMessageIndex,
+		theirBalance:      lnwire.MilliSatoshi(theirBalance),
+		fee:               c.fee,
+		feePerKw:          c.feePerKw,
+	}
+
+	// The HTLCs are copied over once again, as their output indexes
+	// within the splice commitment may differ.
+	sc.outgoingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.ourUpdates))
+	for i, htlc := range filteredHTLCView.ourUpdates {
+		sc.outgoingHTLCs[i] = *htlc
+	}
+	sc.incomingHTLCs = make([]PaymentDescriptor, len(filteredHTLCView.theirUpdates))
+	for i, htlc := range filteredHTLCView.theirUpdates {
+		sc.incomingHTLCs[i] = *htlc
+	}
+
+	dustLimit := lc.localChanCfg.DustLimit
+	if remoteChain {
+		dustLimit = lc.remoteChanCfg.DustLimit
+	}
+	if err := sc.populateHtlcIndexes(!remoteChain, dustLimit); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// SignSpliceCommitment generates our signature for the remote party's
+// version of the commitment transaction spending the new funding output of
+// the splice.
+func (lc *LightningChannel) SignSpliceCommitment(
+	splice *Splice) (*btcec.Signature, error) {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	signDesc := *splice.signDesc
+	signDesc.SigHashes = txscript.NewTxSigHashes(splice.theirCommit.txn)
+
+	rawSig, err := lc.signer.SignOutputRaw(splice.theirCommit.txn, &signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParseSignature(rawSig, btcec.S256())
+}
+
+// verifySpliceCommitSig verifies the remote party's signature for our
+// version of a commitment transaction which spends the new funding output of
+// the splice.
+func (lc *LightningChannel) verifySpliceCommitSig(splice *Splice,
+	commitTx *wire.MsgTx, commitSig *btcec.Signature) error {
+
+	hashCache := txscript.NewTxSigHashes(commitTx)
+	sigHash, err := txscript.CalcWitnessSigHash(lc.FundingWitnessScript,
+		hashCache, txscript.SigHashAll, commitTx, 0,
+		int64(splice.Capacity))
+	if err != nil {
+		return err
+	}
+
+	verifyKey := btcec.PublicKey{
+		X:     lc.remoteChanCfg.MultiSigKey.X,
+		Y:     lc.remoteChanCfg.MultiSigKey.Y,
+		Curve: btcec.S256(),
+	}
+	if !commitSig.Verify(sigHash, &verifyKey) {
+		return fmt.Errorf("invalid splice commitment signature")
+	}
+
+	return nil
+}
+
+// ReceiveSpliceCommitment verifies the remote party's signature for our
+// version of the commitment transaction spending the new funding output of
+// the splice. If the signature is valid, it's stored within the splice.
+func (lc *LightningChannel) ReceiveSpliceCommitment(splice *Splice,
+	commitSig *btcec.Signature) error {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	err := lc.verifySpliceCommitSig(splice, splice.ourCommit.txn, commitSig)
+	if err != nil {
+		return err
+	}
+
+	splice.ourCommit.sig = commitSig.Serialize()
+
+	return nil
+}
+
+// SignSpliceFunding generates our signature for the input of the splice
+// transaction that spends the current funding output of the channel.
+func (lc *LightningChannel) SignSpliceFunding(
+	splice *Splice) (*btcec.Signature, error) {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	signDesc := *lc.signDesc
+	signDesc.InputIndex = splice.fundingInputIndex
+	signDesc.SigHashes = txscript.NewTxSigHashes(splice.SpliceTx)
+
+	rawSig, err := lc.signer.SignOutputRaw(splice.SpliceTx, &signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParseSignature(rawSig, btcec.S256())
+}
+
+// CompleteSpliceFunding combines our signature for the funding input of the
+// splice transaction with the one sent by the remote party, and populates the
+// witness of the input. The resulting witness is validated to ensure the
+// remote party supplied a valid signature.
+func (lc *LightningChannel) CompleteSpliceFunding(splice *Splice,
+	remoteSig *btcec.Signature) error {
+
+	ourSig, err := lc.SignSpliceFunding(splice)
+	if err != nil {
+		return err
+	}
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	// Construct the witness stack minding the order of the pubkeys+sigs
+	// on the stack.
+	ourKey := lc.localChanCfg.MultiSigKey.SerializeCompressed()
+	theirKey := lc.remoteChanCfg.MultiSigKey.SerializeCompressed()
+	ourRawSig := append(ourSig.Serialize(), byte(txscript.SigHashAll))
+	theirRawSig := append(remoteSig.Serialize(), byte(txscript.SigHashAll))
+	txIn := splice.SpliceTx.TxIn[splice.fundingInputIndex]
+	txIn.Witness = SpendMultiSig(lc.FundingWitnessScript, ourKey,
+		ourRawSig, theirKey, theirRawSig)
+
+	// Validate the finalized input to ensure the output script is
+	// properly met, and that the remote peer supplied a valid signature.
+	hashCache := txscript.NewTxSigHashes(splice.SpliceTx)
+	vm, err := txscript.NewEngine(lc.fundingP2WSH, splice.SpliceTx,
+		splice.fundingInputIndex, txscript.StandardVerifyFlags, nil,
+		hashCache, int64(lc.channelState.Capacity))
+	if err != nil {
+		return err
+	}
+
+	return vm.Execute()
+}
+
+// CommitSplice durably records the splice, along with our signed version of
+// the latest commitment transaction spending the new funding output. From
+// this point on, each new state of the channel is signed in two versions: one
+// spending the current funding output, and one spending the new funding
+// output. Once the splice transaction has confirmed, the channel state is
+// migrated over to the new funding output via channeldb.OpenChannel's
+// CompleteSplice method.
+func (lc *LightningChannel) CommitSplice(splice *Splice, isInitiator bool,
+	broadcastHeight uint32) error {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	if lc.pendingSplice != splice {
+		return fmt.Errorf("splice of ChannelPoint(%v) has been aborted",
+			lc.channelState.FundingOutpoint)
+	}
+	if splice.ourCommit.sig == nil {
+		return fmt.Errorf("remote signature for splice commitment " +
+			"not yet received")
+	}
+
+	// If we've already accepted a new state from the remote party which
+	// was signed in both versions, then we'll record the splice version
+	// of that state rather than the one the splice was negotiated at.
+	spliceCommit := splice.ourCommit
+	if tail := lc.localCommitChain.tail(); tail.splice != nil {
+		spliceCommit = tail.splice
+	}
+	delta, err := spliceCommit.toChannelDelta(true)
+	if err != nil {
+		return err
+	}
+
+	err = lc.channelState.SetPendingSplice(&channeldb.PendingSplice{
+		SpliceTx:        splice.SpliceTx,
+		FundingOutpoint: splice.FundingOutpoint,
+		Capacity:        splice.Capacity,
+		LocalDelta:      splice.localDelta,
+		RemoteDelta:     splice.remoteDelta,
+		CommitTx:        spliceCommit.txn,
+		CommitSig:       spliceCommit.sig,
+		Htlcs:           delta.Htlcs,
+		IsInitiator:     isInitiator,
+		BroadcastHeight: broadcastHeight,
+	})
+	if err != nil {
+		return err
+	}
+
+	splice.committed = true
+	splice.active = true
+
+	return nil
+}
+
+// AbortSplice discards a splice which has yet to be committed, allowing new
+// commitments to be signed once again.
+func (lc *LightningChannel) AbortSplice(splice *Splice) {
+	lc.Lock()
+	defer lc.Unlock()
+
+	lc.abortSplice(splice)
+}
+
+// abortSplice is identical to AbortSplice, but expects the caller to hold
+// the channel's mutex.
+func (lc *LightningChannel) abortSplice(splice *Splice) {
+	if lc.pendingSplice != splice || splice.committed {
+		return
+	}
+
+	walletLog.Infof("ChannelPoint(%v): aborting splice to %v",
+		lc.channelState.FundingOutpoint, splice.FundingOutpoint)
+
+	lc.pendingSplice = nil
+	if splice.localDelta < 0 {
+		lc.availableLocalBalance += lnwire.NewMSatFromSatoshis(
+			-splice.localDelta,
+		)
+	}
+}
+
+// restorePendingSplice re-creates the in-memory state of a splice which was
+// committed to prior to a restart, such that each new state continues to be
+// signed in both versions until the splice transaction has confirmed.
+func (lc *LightningChannel) restorePendingSplice(
+	pending *channeldb.PendingSplice) {
+
+	splice := &Splice{
+		SpliceTx:        pending.SpliceTx,
+		FundingOutpoint: pending.FundingOutpoint,
+		Capacity:        pending.Capacity,
+		localDelta:      pending.LocalDelta,
+		remoteDelta:     pending.RemoteDelta,
+		signDesc:        lc.newSpliceSignDesc(pending.Capacity),
+		active:          true,
+		committed:       true,
+	}
+	splice.fundingTxIn = wire.NewTxIn(&splice.FundingOutpoint, nil, nil)
+
+	lc.pendingSplice = splice
+	if splice.localDelta < 0 {
+		lc.availableLocalBalance -= lnwire.NewMSatFromSatoshis(
+			-splice.localDelta,
+		)
+	}
+}
+
+// CreateSpliceTx constructs an unsigned splice transaction which spends the
+// passed funding output of a channel, and re-creates it at a new value. If
+// spliceOutScript is nil, then amt is spliced into the channel by selecting
+// additional coins from the wallet, with any change returned to a fresh
+// address. Otherwise, amt is spliced out of the channel to the passed output
+// script. In either case, the fee of the transaction is paid by the wallet
+// at the given fee rate (in sat/byte); in the case of a splice-out, the fee
+// is deducted from the spliced out amount. The amount by which the capacity
+// of the channel changes is returned along with the transaction.
+func (l *LightningWallet) CreateSpliceTx(fundingOutpoint wire.OutPoint,
+	capacity btcutil.Amount, fundingPkScript []byte, amt btcutil.Amount,
+	spliceOutScript []byte, feeRate uint64) (*wire.MsgTx, btcutil.Amount, error) {
+
+	spliceTx := wire.NewMsgTx(2)
+	spliceTx.AddTxIn(wire.NewTxIn(&fundingOutpoint, nil, nil))
+
+	// The spent funding output will carry a full 2-of-2 multi-sig
+	// witness, which must be accounted for within the fee.
+	fundingInputFee := btcutil.Amount(
+		feeRate * uint64(InputSize+WitnessSize),
+	)
+
+	var delta btcutil.Amount
+	if spliceOutScript == nil {
+		// We'll perform coin selection for the amount to be spliced
+		// in, along with the fee for the funding input. The coin
+		// selection itself accounts for the new funding output, and
+		// our inputs and change.
+		contribution := &ChannelContribution{}
+		err := l.selectCoinsAndChange(feeRate, amt+fundingInputFee, 1,
+			contribution)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, txIn := range contribution.Inputs {
+			spliceTx.AddTxIn(txIn)
+		}
+		for _, txOut := range contribution.ChangeOutputs {
+			spliceTx.AddTxOut(txOut)
+		}
+
+		delta = amt
+	} else {
+		// The fee for a splice-out covers the funding input, the new
+		// funding output, and the splice-out output itself.
+		txSize := 4 + 1 + InputSize + WitnessSize + 1 +
+			P2WSHOutputSize + 8 + 1 + len(spliceOutScript) + 4
+		fee := btcutil.Amount(feeRate * uint64(txSize))
+		if amt-fee < DefaultDustLimit() {
+			return nil, 0, fmt.Errorf("splice-out amount %v too "+
+				"small to pay fee of %v", amt, fee)
+		}
+		if amt >= capacity {
+			return nil, 0, fmt.Errorf("splice-out amount %v "+
+				"exceeds channel capacity %v", amt, capacity)
+		}
+
+		spliceTx.AddTxOut(&wire.TxOut{
+			Value:    int64(amt - fee),
+			PkScript: spliceOutScript,
+		})
+
+		delta = -amt
+	}
+
+	spliceTx.AddTxOut(&wire.TxOut{
+		Value:    int64(capacity + delta),
+		PkScript: fundingPkScript,
+	})
+
+	txsort.InPlaceSort(spliceTx)
+
+	return spliceTx, delta, nil
+}
+
+// SignSpliceInputs signs each input of the splice transaction which belongs
+// to the wallet. The input spending the channel's funding output is left
+// untouched.
+func (l *LightningWallet) SignSpliceInputs(spliceTx *wire.MsgTx) error {
+	_, err := l.signFundingInputs(spliceTx)
+	return err
+}
+
+// ReleaseSpliceInputs unlocks any of our outputs which were selected as
+// inputs to the passed splice transaction, making them eligible for coin
+// selection once again. This should be called if a splice fails to be
+// negotiated.
+func (l *LightningWallet) ReleaseSpliceInputs(spliceTx *wire.MsgTx) {
+	l.limboMtx.Lock()
+	defer l.limboMtx.Unlock()
+
+	for _, txIn := range spliceTx.TxIn {
+		if _, ok := l.lockedOutPoints[txIn.PreviousOutPoint]; !ok {
+			continue
+		}
+
+		delete(l.lockedOutPoints, txIn.PreviousOutPoint)
+		l.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+}
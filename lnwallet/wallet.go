@@ -107,6 +107,17 @@ type initFundingReserveMsg struct {
 	// the responder as part of the initial channel creation.
 	pushMSat lnwire.MilliSatoshi
 
+	// commitType is the set of commitment format bits, such as
+	// channeldb.AnchorOutputsBit, that both sides have agreed to use for
+	// this channel.
+	commitType channeldb.ChannelType
+
+	// batch, if non-nil, is the funding batch this reservation will be a
+	// part of. Batched reservations don't perform their own coin
+	// selection, as the inputs and change of the shared funding
+	// transaction are owned by the batch.
+	batch *FundingBatch
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -375,6 +386,41 @@ func (l *LightningWallet) ResetReservations() {
 	l.lockedOutPoints = make(map[wire.OutPoint]struct{})
 }
 
+// ReleaseFundingInputs unlocks any of our outputs which were spent by the
+// funding transaction of the target channel, making them eligible for coin
+// selection once again. The inputs are recovered from the funding transaction
+// persisted alongside the channel, so this also applies to channels funded
+// before the last restart. This should only be called once a channel has been
+// abandoned, and its funding transaction is known to never confirm. The
+// number of released outputs is returned.
+func (l *LightningWallet) ReleaseFundingInputs(
+	channel *channeldb.OpenChannel) int {
+
+	// Only channels we funded will have their funding transaction
+	// stored, if it's not present, then none of the inputs are ours.
+	if channel.FundingTxn == nil {
+		return 0
+	}
+
+	l.limboMtx.Lock()
+	defer l.limboMtx.Unlock()
+
+	var numReleased int
+	for _, txIn := range channel.FundingTxn.TxIn {
+		// We'll skip any inputs that don't belong to our wallet.
+		prevOut := txIn.PreviousOutPoint
+		if _, err := l.FetchInputInfo(&prevOut); err != nil {
+			continue
+		}
+
+		delete(l.lockedOutPoints, prevOut)
+		l.UnlockOutpoint(prevOut)
+		numReleased++
+	}
+
+	return numReleased
+}
+
 // ActiveReservations returns a slice of all the currently active
 // (non-cancalled) reservations.
 func (l *LightningWallet) ActiveReservations() []*ChannelReservation {
@@ -417,6 +463,14 @@ out:
 				l.handleSingleFunderSigs(msg)
 			case *addCounterPartySigsMsg:
 				l.handleFundingCounterPartySigs(msg)
+			case *initFundingBatchMsg:
+				l.handleInitFundingBatch(msg)
+			case *finalizeFundingBatchMsg:
+				l.handleFinalizeFundingBatch(msg)
+			case *publishFundingBatchMsg:
+				l.handlePublishFundingBatch(msg)
+			case *cancelFundingBatchMsg:
+				l.handleCancelFundingBatch(msg)
 			}
 		case <-l.quit:
 			// TODO: do some clean up
@@ -448,7 +502,8 @@ func (l *LightningWallet) InitChannelReservation(
 	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
 	feePerKw btcutil.Amount,
 	theirID *btcec.PublicKey, theirAddr *net.TCPAddr,
-	chainHash *chainhash.Hash) (*ChannelReservation, error) {
+	chainHash *chainhash.Hash,
+	commitType channeldb.ChannelType) (*ChannelReservation, error) {
 
 	errChan := make(chan error, 1)
 	respChan := make(chan *ChannelReservation, 1)
@@ -461,6 +516,7 @@ func (l *LightningWallet) InitChannelReservation(
 		capacity:      capacity,
 		feePerKw:      feePerKw,
 		pushMSat:      pushMSat,
+		commitType:    commitType,
 		err:           errChan,
 		resp:          respChan,
 	}
@@ -491,7 +547,8 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 
 	id := atomic.AddUint64(&l.nextFundingID, 1)
 	reservation := NewChannelReservation(req.capacity, req.fundingAmount,
-		req.feePerKw, l, id, req.pushMSat, l.Cfg.NetParams.GenesisHash)
+		req.feePerKw, l, id, req.pushMSat, l.Cfg.NetParams.GenesisHash,
+		req.commitType)
 
 	// Grab the mutex on the ChannelReservation to ensure thread-safety
 	reservation.Lock()
@@ -501,9 +558,19 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	reservation.partialState.IdentityPub = req.nodeID
 
 	// If we're on the receiving end of a single funder channel then we
-	// don't need to perform any coin selection. Otherwise, attempt to
-	// obtain enough coins to meet the required funding amount.
-	if req.fundingAmount != 0 {
+	// don't need to perform any coin selection. The same is true if this
+	// reservation is part of a funding batch, as the batch has already
+	// selected and locked the coins for all of its channels. Otherwise,
+	// attempt to obtain enough coins to meet the required funding amount.
+	if req.batch != nil {
+		reservation.batch = req.batch
+
+		req.batch.Lock()
+		req.batch.reservations = append(
+			req.batch.reservations, reservation,
+		)
+		req.batch.Unlock()
+	} else if req.fundingAmount != 0 {
 		// Coin selection is done on the basis of sat-per-byte, so
 		// we'll query the fee estimator for a fee to use to ensure the
 		// funding transaction gets into the _next_  block.
@@ -511,7 +578,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 		// TODO(roasbeef): shouldn't be targeting next block
 		satPerByte := l.Cfg.FeeEstimator.EstimateFeePerByte(1)
 		err := l.selectCoinsAndChange(satPerByte, req.fundingAmount,
-			reservation.ourContribution)
+			1, reservation.ourContribution)
 		if err != nil {
 			req.err <- err
 			req.resp <- nil
@@ -647,7 +714,8 @@ func (l *LightningWallet) handleFundingCancelRequest(req *fundingReserveCancelMs
 func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 	ourChanCfg, theirChanCfg *channeldb.ChannelConfig,
 	localCommitPoint, remoteCommitPoint *btcec.PublicKey,
-	fundingTxIn *wire.TxIn) (*wire.MsgTx, *wire.MsgTx, error) {
+	fundingTxIn *wire.TxIn,
+	chanType channeldb.ChannelType) (*wire.MsgTx, *wire.MsgTx, error) {
 
 	remoteRevocation := DeriveRevocationPubkey(
 		ourChanCfg.RevocationBasePoint,
@@ -667,15 +735,16 @@ func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 	// swap the commitment points we use. As in the remote payment key will
 	// be used within our commitment transaction, and the local payment key
 	// used within the remote commitment transaction.
-	remotePaymentKey := TweakPubKey(theirChanCfg.PaymentBasePoint,
-		localCommitPoint)
-	localPaymentKey := TweakPubKey(ourChanCfg.PaymentBasePoint,
-		remoteCommitPoint)
+	remotePaymentKey := toRemoteKey(chanType,
+		theirChanCfg.PaymentBasePoint, localCommitPoint)
+	localPaymentKey := toRemoteKey(chanType,
+		ourChanCfg.PaymentBasePoint, remoteCommitPoint)
 
 	ourCommitTx, err := CreateCommitTx(fundingTxIn,
 		localDelayKey, remotePaymentKey, localRevocation,
 		uint32(ourChanCfg.CsvDelay), localBalance, remoteBalance,
-		ourChanCfg.DustLimit)
+		ourChanCfg.DustLimit,
+		anchorKeys(chanType, false, ourChanCfg, theirChanCfg))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -688,7 +757,8 @@ func CreateCommitmentTxns(localBalance, remoteBalance btcutil.Amount,
 	theirCommitTx, err := CreateCommitTx(fundingTxIn,
 		remoteDelayKey, localPaymentKey, remoteRevocation,
 		uint32(theirChanCfg.CsvDelay), remoteBalance, localBalance,
-		theirChanCfg.DustLimit)
+		theirChanCfg.DustLimit,
+		anchorKeys(chanType, true, ourChanCfg, theirChanCfg))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -720,6 +790,17 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	pendingReservation.Lock()
 	defer pendingReservation.Unlock()
 
+	// If this reservation is part of a funding batch, then we're unable to
+	// construct the funding transaction until the contributions of all
+	// other members of the batch are known. As a result, we'll only record
+	// their contribution for now, deferring the construction of the
+	// funding and commitment transactions until the batch is finalized.
+	if pendingReservation.batch != nil {
+		pendingReservation.theirContribution = req.contribution
+		req.err <- nil
+		return
+	}
+
 	// Create a blank, fresh transaction. Soon to be a complete funding
 	// transaction which will allow opening a lightning channel.
 	pendingReservation.fundingTx = wire.NewMsgTx(1)
@@ -765,8 +846,31 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Next, sign all inputs that are ours, collecting the signatures in
 	// order of the inputs.
-	pendingReservation.ourFundingInputScripts = make([]*InputScript, 0,
-		len(ourContribution.Inputs))
+	inputScripts, err := l.signFundingInputs(fundingTx)
+	if err != nil {
+		req.err <- err
+		return
+	}
+	pendingReservation.ourFundingInputScripts = inputScripts
+
+	// With the funding transaction fully assembled, we can now construct
+	// both commitment transactions and sign the remote party's version.
+	if err := l.signInitialCommitments(pendingReservation, witnessScript,
+		multiSigOut); err != nil {
+
+		req.err <- err
+		return
+	}
+
+	req.err <- nil
+}
+
+// signFundingInputs signs all the inputs within the passed funding
+// transaction which belong to the wallet, attaching the generated witnesses
+// and sigScripts to the transaction itself. The input scripts are returned in
+// the order of the (sorted) inputs.
+func (l *LightningWallet) signFundingInputs(fundingTx *wire.MsgTx) ([]*InputScript, error) {
+	var inputScripts []*InputScript
 	signDesc := SignDescriptor{
 		HashType:  txscript.SigHashAll,
 		SigHashes: txscript.NewTxSigHashes(fundingTx),
@@ -776,8 +880,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		if err == ErrNotMine {
 			continue
 		} else if err != nil {
-			req.err <- err
-			return
+			return nil, err
 		}
 
 		signDesc.Output = info
@@ -786,36 +889,48 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		inputScript, err := l.Cfg.Signer.ComputeInputScript(fundingTx,
 			&signDesc)
 		if err != nil {
-			req.err <- err
-			return
+			return nil, err
 		}
 
 		txIn.SignatureScript = inputScript.ScriptSig
 		txIn.Witness = inputScript.Witness
-		pendingReservation.ourFundingInputScripts = append(
-			pendingReservation.ourFundingInputScripts,
-			inputScript,
-		)
+		inputScripts = append(inputScripts, inputScript)
 	}
 
+	return inputScripts, nil
+}
+
+// signInitialCommitments locates the multi-sig output of the reservation
+// within its (fully assembled) funding transaction, then creates both
+// versions of the initial commitment transaction and generates our signature
+// for the remote party's version. The reservation's mutex MUST be held by the
+// caller.
+func (l *LightningWallet) signInitialCommitments(res *ChannelReservation,
+	witnessScript []byte, multiSigOut *wire.TxOut) error {
+
+	fundingTx := res.fundingTx
+	ourContribution := res.ourContribution
+	theirContribution := res.theirContribution
+	ourKey := ourContribution.MultiSigKey
+
 	// Locate the index of the multi-sig outpoint in order to record it
 	// since the outputs are canonically sorted. If this is a single funder
 	// workflow, then we'll also need to send this to the remote node.
 	fundingTxID := fundingTx.TxHash()
 	_, multiSigIndex := FindScriptOutputIndex(fundingTx, multiSigOut.PkScript)
 	fundingOutpoint := wire.NewOutPoint(&fundingTxID, multiSigIndex)
-	pendingReservation.partialState.FundingOutpoint = *fundingOutpoint
+	res.partialState.FundingOutpoint = *fundingOutpoint
 
 	// Initialize an empty sha-chain for them, tracking the current pending
 	// revocation hash (we don't yet know the preimage so we can't add it
 	// to the chain).
 	s := shachain.NewRevocationStore()
-	pendingReservation.partialState.RevocationStore = s
+	res.partialState.RevocationStore = s
 
 	// Store their current commitment point. We'll need this after the
 	// first state transition in order to verify the authenticity of the
 	// revocation.
-	chanState := pendingReservation.partialState
+	chanState := res.partialState
 	chanState.RemoteCurrentRevocation = theirContribution.FirstCommitmentPoint
 
 	// Create the txin to our commitment transaction; required to construct
@@ -828,24 +943,24 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 
 	// With the funding tx complete, create both commitment transactions.
-	localBalance := pendingReservation.partialState.LocalBalance.ToSatoshis()
-	remoteBalance := pendingReservation.partialState.RemoteBalance.ToSatoshis()
+	localBalance := res.partialState.LocalBalance.ToSatoshis()
+	remoteBalance := res.partialState.RemoteBalance.ToSatoshis()
 	ourCommitTx, theirCommitTx, err := CreateCommitmentTxns(
 		localBalance, remoteBalance, ourContribution.ChannelConfig,
 		theirContribution.ChannelConfig,
 		ourContribution.FirstCommitmentPoint,
 		theirContribution.FirstCommitmentPoint, fundingTxIn,
+		res.partialState.ChanType,
 	)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// With both commitment transactions constructed, generate the state
 	// obsfucator then use it to encode the current state number within
 	// both commitment transactions.
 	var stateObsfucator [StateHintSize]byte
-	if chanState.ChanType == channeldb.SingleFunder {
+	if chanState.ChanType.IsSingleFunder() {
 		stateObsfucator = deriveStateHintObfuscator(
 			ourContribution.PaymentBasePoint,
 			theirContribution.PaymentBasePoint,
@@ -868,8 +983,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	err = initStateHints(ourCommitTx, theirCommitTx, stateObsfucator)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
 
 	// Sort both transactions according to the agreed upon canonical
@@ -884,7 +998,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Generate a signature for their version of the initial commitment
 	// transaction.
-	signDesc = SignDescriptor{
+	signDesc := SignDescriptor{
 		WitnessScript: witnessScript,
 		PubKey:        ourKey,
 		Output:        multiSigOut,
@@ -894,12 +1008,11 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	sigTheirCommit, err := l.Cfg.Signer.SignOutputRaw(theirCommitTx, &signDesc)
 	if err != nil {
-		req.err <- err
-		return
+		return err
 	}
-	pendingReservation.ourCommitmentSig = sigTheirCommit
+	res.ourCommitmentSig = sigTheirCommit
 
-	req.err <- nil
+	return nil
 }
 
 // handleSingleContribution is called as the second step to a single funder
@@ -1065,6 +1178,20 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 	}
 	res.partialState.CommitSig = theirCommitSig
 
+	// If this reservation is part of a funding batch, then we MUST NOT
+	// commit the channel to disk nor broadcast the shared funding
+	// transaction until the remote commitment signatures for _all_
+	// channels within the batch have been verified. Both of these actions
+	// are instead carried out once the batch itself is published.
+	if res.batch != nil {
+		res.partialState.LocalChanCfg = res.ourContribution.toChanConfig()
+		res.partialState.RemoteChanCfg = res.theirContribution.toChanConfig()
+
+		msg.completeChan <- res.partialState
+		msg.err <- nil
+		return
+	}
+
 	// Funding complete, this entry can be removed from limbo.
 	l.limboMtx.Lock()
 	delete(l.fundingLimbo, res.reservationID)
@@ -1088,6 +1215,10 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 	res.partialState.LocalChanCfg = res.ourContribution.toChanConfig()
 	res.partialState.RemoteChanCfg = res.theirContribution.toChanConfig()
 
+	// We'll also store the funding transaction itself, in case the inputs
+	// it spends need to be released if the channel is abandoned.
+	res.partialState.FundingTxn = fundingTx
+
 	// Add the complete funding transaction to the DB, in it's open bucket
 	// which will be used for the lifetime of this channel.
 	// TODO(roasbeef):
@@ -1151,7 +1282,7 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 		pendingReservation.theirContribution.ChannelConfig,
 		pendingReservation.ourContribution.FirstCommitmentPoint,
 		pendingReservation.theirContribution.FirstCommitmentPoint,
-		fundingTxIn,
+		fundingTxIn, pendingReservation.partialState.ChanType,
 	)
 
 	// With both commitment transactions constructed, we can now use the
@@ -1266,10 +1397,12 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 // outputs which sum to at least 'numCoins' amount of satoshis. If coin
 // selection is successful/possible, then the selected coins are available
 // within the passed contribution's inputs. If necessary, a change address will
-// also be generated.
+// also be generated. The numFundingOutputs parameter denotes the number of
+// p2wsh funding outputs the final transaction will carry, which is greater
+// than one when funding a batch of channels within a single transaction.
 // TODO(roasbeef): remove hardcoded fees and req'd confs for outputs.
 func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amount,
-	contribution *ChannelContribution) error {
+	numFundingOutputs int, contribution *ChannelContribution) error {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -1291,7 +1424,9 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 	// Perform coin selection over our available, unlocked unspent outputs
 	// in order to find enough coins to meet the funding amount
 	// requirements.
-	selectedCoins, changeAmt, err := coinSelect(feeRate, amt, coins)
+	selectedCoins, changeAmt, err := coinSelect(
+		feeRate, amt, numFundingOutputs, coins,
+	)
 	if err != nil {
 		return err
 	}
@@ -1418,8 +1553,9 @@ func selectInputs(amt btcutil.Amount, coins []*Utxo) (btcutil.Amount, []*wire.Ou
 // coinSelect attempts to select a sufficient amount of coins, including a
 // change output to fund amt satoshis, adhering to the specified fee rate. The
 // specified fee rate should be expressed in sat/byte for coin selection to
-// function properly.
-func coinSelect(feeRate uint64, amt btcutil.Amount,
+// function properly. The size estimate accounts for numFundingOutputs p2wsh
+// funding outputs.
+func coinSelect(feeRate uint64, amt btcutil.Amount, numFundingOutputs int,
 	coins []*Utxo) ([]*wire.OutPoint, btcutil.Amount, error) {
 
 	const (
@@ -1458,7 +1594,7 @@ func coinSelect(feeRate uint64, amt btcutil.Amount,
 		// Based on the selected coins, estimate the size of the final
 		// fully signed transaction.
 		estimatedSize = ((len(selectedUtxos) * p2wkhSpendSize) +
-			p2wshOutputSize + txOverhead)
+			(numFundingOutputs * p2wshOutputSize) + txOverhead)
 
 		// The difference between the selected amount and the amount
 		// requested will be used to pay fees, and generate a change
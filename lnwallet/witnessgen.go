@@ -33,8 +33,92 @@ const (
 	// HtlcAcceptedRevoke is a witness that allows us to sweep an HTLC
 	// output that we accepted from the counterparty.
 	HtlcAcceptedRevoke WitnessType = 4
+
+	// HtlcOfferedTimeoutSecondLevel is a witness that allows us to sweep
+	// the output of our HTLC timeout transaction once its relative
+	// time-lock has passed.
+	HtlcOfferedTimeoutSecondLevel WitnessType = 5
+
+	// HtlcAcceptedSuccessSecondLevel is a witness that allows us to sweep
+	// the output of our HTLC success transaction once its relative
+	// time-lock has passed.
+	HtlcAcceptedSuccessSecondLevel WitnessType = 6
+
+	// HtlcOfferedRemoteTimeout is a witness that allows us to sweep an
+	// HTLC that we offered to the remote party directly from their
+	// commitment transaction once the absolute timeout has passed.
+	HtlcOfferedRemoteTimeout WitnessType = 7
+
+	// HtlcAcceptedRemoteSuccess is a witness that allows us to sweep an
+	// HTLC that the remote party offered to us directly from their
+	// commitment transaction using the payment preimage.
+	//
+	// NOTE: As the witness requires the payment preimage, a
+	// WitnessGenerator can't be created for this type. Instead, the
+	// witness should be generated with SenderHtlcSpendRedeem.
+	HtlcAcceptedRemoteSuccess WitnessType = 8
+
+	// CommitmentAnchor is a witness that allows us to spend our anchor
+	// output on a commitment transaction using our funding key.
+	CommitmentAnchor WitnessType = 9
 )
 
+// String returns a human readable version of the target WitnessType.
+func (wt WitnessType) String() string {
+	switch wt {
+	case CommitmentTimeLock:
+		return "CommitmentTimeLock"
+	case CommitmentNoDelay:
+		return "CommitmentNoDelay"
+	case CommitmentRevoke:
+		return "CommitmentRevoke"
+	case HtlcOfferedRevoke:
+		return "HtlcOfferedRevoke"
+	case HtlcAcceptedRevoke:
+		return "HtlcAcceptedRevoke"
+	case HtlcOfferedTimeoutSecondLevel:
+		return "HtlcOfferedTimeoutSecondLevel"
+	case HtlcAcceptedSuccessSecondLevel:
+		return "HtlcAcceptedSuccessSecondLevel"
+	case HtlcOfferedRemoteTimeout:
+		return "HtlcOfferedRemoteTimeout"
+	case HtlcAcceptedRemoteSuccess:
+		return "HtlcAcceptedRemoteSuccess"
+	case CommitmentAnchor:
+		return "CommitmentAnchor"
+	default:
+		return fmt.Sprintf("Unknown WitnessType: %v", uint16(wt))
+	}
+}
+
+// WitnessSize returns an upper bound on the size of the witness required to
+// spend an output of the target WitnessType. This is used to estimate the
+// weight, and therefore the fee, of a transaction sweeping the output.
+func (wt WitnessType) WitnessSize() (int, error) {
+	switch wt {
+	case CommitmentTimeLock, HtlcOfferedTimeoutSecondLevel,
+		HtlcAcceptedSuccessSecondLevel:
+
+		return ToLocalTimeoutWitnessSize, nil
+	case CommitmentNoDelay:
+		return P2WKHWitnessSize, nil
+	case CommitmentRevoke:
+		return ToLocalPenaltyWitnessSize, nil
+	case HtlcOfferedRevoke:
+		return OfferedHtlcPenaltyWitnessSize, nil
+	case HtlcAcceptedRevoke:
+		return AcceptedHtlcPenaltyWitnessSize, nil
+	case HtlcOfferedRemoteTimeout:
+		return AcceptedHtlcTimeoutWitnessSize, nil
+	case HtlcAcceptedRemoteSuccess:
+		return OfferedHtlcSuccessWitnessSize, nil
+	case CommitmentAnchor:
+		return AnchorWitnessSize, nil
+	default:
+		return 0, fmt.Errorf("unknown witness type: %v", wt)
+	}
+}
+
 // WitnessGenerator represents a function which is able to generate the final
 // witness for a particular public key script. This function acts as an
 // abstraction layer, hiding the details of the underlying script.
@@ -64,6 +148,12 @@ func (wt WitnessType) GenWitnessFunc(signer Signer,
 			return ReceiverHtlcSpendRevoke(signer, desc, tx)
 		case HtlcAcceptedRevoke:
 			return SenderHtlcSpendRevoke(signer, desc, tx)
+		case HtlcOfferedTimeoutSecondLevel, HtlcAcceptedSuccessSecondLevel:
+			return HtlcSecondLevelSpend(signer, desc, tx)
+		case HtlcOfferedRemoteTimeout:
+			return ReceiverHtlcSpendTimeout(signer, desc, tx)
+		case CommitmentAnchor:
+			return CommitSpendAnchor(signer, desc, tx)
 		default:
 			return nil, fmt.Errorf("unknown witness type: %v", wt)
 		}
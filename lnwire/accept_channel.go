@@ -80,6 +80,12 @@ type AcceptChannel struct {
 	// base point in order to derive the revocation keys that are placed
 	// within the commitment transaction of the sender.
 	FirstCommitmentPoint *btcec.PublicKey
+
+	// UpfrontShutdownScript is an optional script to which the responder
+	// commits its funds will be paid upon a cooperative close. If set, the
+	// initiator MUST reject any Shutdown message from the responder which
+	// uses a different delivery address.
+	UpfrontShutdownScript DeliveryAddress
 }
 
 // A compile time check to ensure AcceptChannel implements the lnwire.Message
@@ -106,6 +112,7 @@ func (a *AcceptChannel) Encode(w io.Writer, pver uint32) error {
 		a.PaymentPoint,
 		a.DelayedPaymentPoint,
 		a.FirstCommitmentPoint,
+		a.UpfrontShutdownScript,
 	)
 }
 
@@ -115,7 +122,7 @@ func (a *AcceptChannel) Encode(w io.Writer, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (a *AcceptChannel) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		a.PendingChannelID[:],
 		&a.DustLimit,
 		&a.MaxValueInFlight,
@@ -130,6 +137,12 @@ func (a *AcceptChannel) Decode(r io.Reader, pver uint32) error {
 		&a.DelayedPaymentPoint,
 		&a.FirstCommitmentPoint,
 	)
+	if err != nil {
+		return err
+	}
+
+	a.UpfrontShutdownScript, err = readUpfrontShutdownScript(r)
+	return err
 }
 
 // MsgType returns the MessageType code which uniquely identifies this message
@@ -145,6 +158,6 @@ func (a *AcceptChannel) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (a *AcceptChannel) MaxPayloadLength(uint32) uint32 {
-	// 32 + (8 * 4) + (4 * 1) + (2 * 2) + (33 * 5)
-	return 237
+	// 32 + (8 * 4) + (4 * 1) + (2 * 2) + (33 * 5) + (2 + 34)
+	return 273
 }
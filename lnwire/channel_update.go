@@ -8,6 +8,19 @@ import (
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 )
 
+const (
+	// ChanUpdateDirection is the bit within the Flags of a ChannelUpdate
+	// which denotes the direction of the channel the update applies to.
+	// If unset, the update was created by the first node in the channel
+	// announcement, otherwise it was created by the second node.
+	ChanUpdateDirection uint16 = 1 << iota
+
+	// ChanUpdateDisabled is the bit within the Flags of a ChannelUpdate
+	// which, when set, signals that the node which created the update is
+	// unwilling to forward payments over its direction of the channel.
+	ChanUpdateDisabled
+)
+
 // ChannelUpdate message is used after channel has been initially announced.
 // Each side independently announces its fees and minimum expiry for HTLCs and
 // other parameters. Also this message is used to redeclare initially setted
@@ -33,7 +46,8 @@ type ChannelUpdate struct {
 
 	// Flags least-significant bit must be set to 0 if the creating node
 	// corresponds to the first node in the previously sent channel
-	// announcement and 1 otherwise.
+	// announcement and 1 otherwise. The second bit is set if the channel
+	// is disabled. See ChanUpdateDirection and ChanUpdateDisabled.
 	Flags uint16
 
 	// TimeLockDelta is the minimum number of blocks this node requires to
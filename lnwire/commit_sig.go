@@ -36,6 +36,19 @@ type CommitSig struct {
 	// should be signed, for each incoming HTLC the HTLC timeout
 	// transaction should be signed.
 	HtlcSigs []*btcec.Signature
+
+	// SpliceCommitSig is an optional signature for the version of the
+	// new commitment transaction which spends the funding output of a
+	// pending splice. It's only present while the channel has a splice
+	// whose transaction has yet to confirm.
+	SpliceCommitSig *btcec.Signature
+
+	// SpliceHtlcSigs is a signature for each relevant HTLC output within
+	// the version of the new commitment which spends the funding output
+	// of a pending splice. The signatures are ordered identically to
+	// HtlcSigs, but according to the BIP 69 order of the splice
+	// commitment.
+	SpliceHtlcSigs []*btcec.Signature
 }
 
 // NewCommitSig creates a new empty CommitSig message.
@@ -52,11 +65,28 @@ var _ Message = (*CommitSig)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *CommitSig) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		&c.ChanID,
 		&c.CommitSig,
 		&c.HtlcSigs,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The splice signatures are an optional trailing field, so if the
+	// reader has no remaining bytes, then the sender has no pending
+	// splice.
+	err = readElement(r, &c.SpliceCommitSig)
+	switch {
+	case err == io.EOF:
+		c.SpliceCommitSig = nil
+		return nil
+	case err != nil:
+		return err
+	}
+
+	return readElement(r, &c.SpliceHtlcSigs)
 }
 
 // Encode serializes the target CommitSig into the passed io.Writer
@@ -64,11 +94,23 @@ func (c *CommitSig) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (c *CommitSig) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		c.ChanID,
 		c.CommitSig,
 		c.HtlcSigs,
 	)
+	if err != nil {
+		return err
+	}
+
+	if c.SpliceCommitSig == nil {
+		return nil
+	}
+
+	return writeElements(w,
+		c.SpliceCommitSig,
+		c.SpliceHtlcSigs,
+	)
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
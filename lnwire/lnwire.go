@@ -358,6 +358,29 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case *wire.MsgTx:
+		if e == nil {
+			return fmt.Errorf("cannot write nil transaction")
+		}
+
+		var txBuf bytes.Buffer
+		if err := e.Serialize(&txBuf); err != nil {
+			return err
+		}
+		if txBuf.Len() > MaxSliceLength {
+			return fmt.Errorf("transaction of size %v exceeds max "+
+				"size of %v", txBuf.Len(), MaxSliceLength)
+		}
+
+		var length [2]byte
+		binary.BigEndian.PutUint16(length[:], uint16(txBuf.Len()))
+		if _, err := w.Write(length[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(txBuf.Bytes()); err != nil {
+			return err
+		}
+
 	default:
 		return fmt.Errorf("Unknown type in writeElement: %T", e)
 	}
@@ -688,6 +711,23 @@ func readElement(r io.Reader, element interface{}) error {
 			return err
 		}
 		*e = addrBytes[:length]
+	case **wire.MsgTx:
+		var txLen [2]byte
+		if _, err = io.ReadFull(r, txLen[:]); err != nil {
+			return err
+		}
+		length := binary.BigEndian.Uint16(txLen[:])
+
+		txBytes := make([]byte, length)
+		if _, err = io.ReadFull(r, txBytes); err != nil {
+			return err
+		}
+
+		tx := wire.NewMsgTx(2)
+		if err := tx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+			return err
+		}
+		*e = tx
 	default:
 		return fmt.Errorf("Unknown type in readElement: %T", e)
 	}
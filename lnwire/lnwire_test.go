@@ -63,6 +63,22 @@ func randFeatureVector(r *rand.Rand) *FeatureVector {
 	return NewFeatureVector(features)
 }
 
+// randShortChanIDs returns a random set of short channel ID's. An empty set is
+// returned as nil, as that's how it's decoded from the wire.
+func randShortChanIDs(r *rand.Rand) []ShortChannelID {
+	numChanIDs := r.Int31n(MaxPlainShortChanIDs + 1)
+	if numChanIDs == 0 {
+		return nil
+	}
+
+	shortChanIDs := make([]ShortChannelID, numChanIDs)
+	for i := range shortChanIDs {
+		shortChanIDs[i] = NewShortChanIDFromInt(uint64(r.Int63()))
+	}
+
+	return shortChanIDs
+}
+
 func TestMaxOutPointIndex(t *testing.T) {
 	t.Parallel()
 
@@ -198,6 +214,17 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// Only include an upfront shutdown script half of the
+			// time, in order to exercise decoding of messages from
+			// peers that don't set the field.
+			if r.Intn(2) == 0 {
+				req.UpfrontShutdownScript = make([]byte, 22)
+				if _, err := r.Read(req.UpfrontShutdownScript); err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgAcceptChannel: func(v []reflect.Value, r *rand.Rand) {
@@ -243,6 +270,17 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// Only include an upfront shutdown script half of the
+			// time, in order to exercise decoding of messages from
+			// peers that don't set the field.
+			if r.Intn(2) == 0 {
+				req.UpfrontShutdownScript = make([]byte, 22)
+				if _, err := r.Read(req.UpfrontShutdownScript); err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgFundingCreated: func(v []reflect.Value, r *rand.Rand) {
@@ -308,6 +346,66 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(req)
 		},
+		MsgSpliceInit: func(v []reflect.Value, r *rand.Rand) {
+			req := SpliceInit{
+				SpliceTx:  wire.NewMsgTx(2),
+				CommitSig: testSig,
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			// We'll populate the splice transaction with at least
+			// a single input and output, each of which have a
+			// non-empty script to ensure they're decoded
+			// identically.
+			numInputs := r.Intn(3) + 1
+			for i := 0; i < numInputs; i++ {
+				var prevOut wire.OutPoint
+				if _, err := r.Read(prevOut.Hash[:]); err != nil {
+					t.Fatalf("unable to generate txid: %v", err)
+					return
+				}
+				prevOut.Index = uint32(r.Int31())
+
+				sigScript := make([]byte, r.Intn(20)+1)
+				if _, err := r.Read(sigScript); err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+
+				req.SpliceTx.AddTxIn(
+					wire.NewTxIn(&prevOut, sigScript, nil),
+				)
+			}
+			numOutputs := r.Intn(3) + 1
+			for i := 0; i < numOutputs; i++ {
+				pkScript := make([]byte, 22)
+				if _, err := r.Read(pkScript); err != nil {
+					t.Fatalf("unable to generate script: %v", err)
+					return
+				}
+
+				req.SpliceTx.AddTxOut(
+					wire.NewTxOut(r.Int63(), pkScript),
+				)
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgSpliceAccept: func(v []reflect.Value, r *rand.Rand) {
+			req := SpliceAccept{
+				CommitSig:  testSig,
+				FundingSig: testSig,
+			}
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
 		MsgCommitSig: func(v []reflect.Value, r *rand.Rand) {
 			req := NewCommitSig()
 			if _, err := r.Read(req.ChanID[:]); err != nil {
@@ -327,6 +425,26 @@ func TestLightningWireProtocol(t *testing.T) {
 				req.HtlcSigs[i] = testSig
 			}
 
+			// Half of the time, we'll also include signatures for
+			// a pending splice, ensuring the message still fits
+			// within the maximum payload.
+			if r.Int31n(2) == 0 {
+				req.HtlcSigs = req.HtlcSigs[:numSigs/2]
+				if len(req.HtlcSigs) == 0 {
+					req.HtlcSigs = nil
+				}
+
+				req.SpliceCommitSig = testSig
+				if numSigs/2 > 0 {
+					req.SpliceHtlcSigs = make(
+						[]*btcec.Signature, numSigs/2,
+					)
+				}
+				for i := 0; i < int(numSigs/2); i++ {
+					req.SpliceHtlcSigs[i] = testSig
+				}
+			}
+
 			v[0] = reflect.ValueOf(*req)
 		},
 		MsgRevokeAndAck: func(v []reflect.Value, r *rand.Rand) {
@@ -447,6 +565,35 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgQueryShortChanIDs: func(v []reflect.Value, r *rand.Rand) {
+			req := QueryShortChanIDs{
+				EncodingType: EncodingSortedPlain,
+				ShortChanIDs: randShortChanIDs(r),
+			}
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to generate chain hash: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgReplyChannelRange: func(v []reflect.Value, r *rand.Rand) {
+			req := ReplyChannelRange{
+				QueryChannelRange: QueryChannelRange{
+					FirstBlockHeight: uint32(r.Int31()),
+					NumBlocks:        uint32(r.Int31()),
+				},
+				Complete:     uint8(r.Int31n(2)),
+				EncodingType: EncodingSortedPlain,
+				ShortChanIDs: randShortChanIDs(r),
+			}
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to generate chain hash: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 	}
@@ -526,6 +673,18 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgSpliceInit,
+			scenario: func(m SpliceInit) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgSpliceAccept,
+			scenario: func(m SpliceAccept) bool {
+				return mainScenario(&m)
+			},
+		},
 		{
 			msgType: MsgUpdateAddHTLC,
 			scenario: func(m UpdateAddHTLC) bool {
@@ -593,6 +752,36 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgQueryShortChanIDs,
+			scenario: func(m QueryShortChanIDs) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyShortChanIDsEnd,
+			scenario: func(m ReplyShortChanIDsEnd) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgQueryChannelRange,
+			scenario: func(m QueryChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyChannelRange,
+			scenario: func(m ReplyChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgGossipTimestampRange,
+			scenario: func(m GossipTimestampRange) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
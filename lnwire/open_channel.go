@@ -102,6 +102,12 @@ type OpenChannel struct {
 	// Currently, the least significant bit of this bit field indicates the
 	// initiator of the channel wishes to advertise this channel publicly.
 	ChannelFlags byte
+
+	// UpfrontShutdownScript is an optional script to which the initiator
+	// commits its funds will be paid upon a cooperative close. If set, the
+	// responder MUST reject any Shutdown message from the initiator which
+	// uses a different delivery address.
+	UpfrontShutdownScript DeliveryAddress
 }
 
 // A compile time check to ensure OpenChannel implements the lnwire.Message
@@ -132,6 +138,7 @@ func (o *OpenChannel) Encode(w io.Writer, pver uint32) error {
 		o.DelayedPaymentPoint,
 		o.FirstCommitmentPoint,
 		o.ChannelFlags,
+		o.UpfrontShutdownScript,
 	)
 }
 
@@ -141,7 +148,7 @@ func (o *OpenChannel) Encode(w io.Writer, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (o *OpenChannel) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		o.ChainHash[:],
 		o.PendingChannelID[:],
 		&o.FundingAmount,
@@ -160,6 +167,12 @@ func (o *OpenChannel) Decode(r io.Reader, pver uint32) error {
 		&o.FirstCommitmentPoint,
 		&o.ChannelFlags,
 	)
+	if err != nil {
+		return err
+	}
+
+	o.UpfrontShutdownScript, err = readUpfrontShutdownScript(r)
+	return err
 }
 
 // MsgType returns the MessageType code which uniquely identifies this message
@@ -175,6 +188,6 @@ func (o *OpenChannel) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (o *OpenChannel) MaxPayloadLength(uint32) uint32 {
-	// (32 * 2) + (8 * 6) + (4 * 1) + (2 * 2) + (33 * 5) + 1
-	return 286
+	// (32 * 2) + (8 * 6) + (4 * 1) + (2 * 2) + (33 * 5) + 1 + (2 + 34)
+	return 322
 }
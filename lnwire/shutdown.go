@@ -22,6 +22,26 @@ type Shutdown struct {
 // p2wpkh.
 type DeliveryAddress []byte
 
+// readUpfrontShutdownScript attempts to read an optional trailing delivery
+// address from the passed io.Reader. This is used by messages which may
+// commit to an upfront shutdown script. If the reader has no remaining bytes,
+// as will be the case for peers which don't support the field, or the
+// encoded script is empty, then a nil DeliveryAddress is returned.
+func readUpfrontShutdownScript(r io.Reader) (DeliveryAddress, error) {
+	var addr DeliveryAddress
+	err := readElement(r, &addr)
+	switch {
+	case err == io.EOF:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case len(addr) == 0:
+		return nil, nil
+	}
+
+	return addr, nil
+}
+
 // NewShutdown creates a new Shutdown message.
 func NewShutdown(cid ChannelID, addr DeliveryAddress) *Shutdown {
 	return &Shutdown{
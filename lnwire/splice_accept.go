@@ -0,0 +1,83 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// SpliceAccept is sent in response to a SpliceInit message once the receiver
+// has validated the proposed splice transaction, along with the sender's
+// signature for the new commitment transaction. The message carries the
+// receiver's signature for the initiator's version of the new commitment
+// transaction, as well as its half of the signature required to spend the
+// current funding output within the splice transaction.
+type SpliceAccept struct {
+	// ChanID is the channel that the splice was proposed for.
+	ChanID ChannelID
+
+	// CommitSig is the sender's signature for the splice initiator's
+	// version of the commitment transaction which spends the new funding
+	// output.
+	CommitSig *btcec.Signature
+
+	// FundingSig is the sender's signature for the input of the splice
+	// transaction which spends the current funding output.
+	FundingSig *btcec.Signature
+}
+
+// NewSpliceAccept creates a new SpliceAccept message.
+func NewSpliceAccept(chanID ChannelID, commitSig,
+	fundingSig *btcec.Signature) *SpliceAccept {
+
+	return &SpliceAccept{
+		ChanID:     chanID,
+		CommitSig:  commitSig,
+		FundingSig: fundingSig,
+	}
+}
+
+// A compile time check to ensure SpliceAccept implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceAccept)(nil)
+
+// Decode deserializes a serialized SpliceAccept message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceAccept) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&c.ChanID,
+		&c.CommitSig,
+		&c.FundingSig,
+	)
+}
+
+// Encode serializes the target SpliceAccept into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceAccept) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		c.ChanID,
+		c.CommitSig,
+		c.FundingSig,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceAccept) MsgType() MessageType {
+	return MsgSpliceAccept
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// SpliceAccept complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceAccept) MaxPayloadLength(uint32) uint32 {
+	// 32 + 64 + 64
+	return 160
+}
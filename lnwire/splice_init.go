@@ -0,0 +1,84 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// SpliceInit is sent by the party wishing to splice funds into or out of an
+// active channel. The message carries the proposed splice transaction, which
+// spends the current funding output of the channel along with any additional
+// inputs of the sender, and creates a new funding output paying to the same
+// 2-of-2 multi-sig script. The sender also includes its signature for the
+// receiver's version of the commitment transaction which spends the new
+// funding output.
+type SpliceInit struct {
+	// ChanID is the channel that the splice is proposed for.
+	ChanID ChannelID
+
+	// SpliceTx is the unsigned splice transaction. The sender of this
+	// message is solely responsible for funding any additional inputs, as
+	// well as paying the fees of the transaction.
+	SpliceTx *wire.MsgTx
+
+	// CommitSig is the sender's signature for the receiver's version of
+	// the commitment transaction which spends the new funding output.
+	CommitSig *btcec.Signature
+}
+
+// NewSpliceInit creates a new SpliceInit message.
+func NewSpliceInit(chanID ChannelID, spliceTx *wire.MsgTx,
+	commitSig *btcec.Signature) *SpliceInit {
+
+	return &SpliceInit{
+		ChanID:    chanID,
+		SpliceTx:  spliceTx,
+		CommitSig: commitSig,
+	}
+}
+
+// A compile time check to ensure SpliceInit implements the lnwire.Message
+// interface.
+var _ Message = (*SpliceInit)(nil)
+
+// Decode deserializes a serialized SpliceInit message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceInit) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&c.ChanID,
+		&c.SpliceTx,
+		&c.CommitSig,
+	)
+}
+
+// Encode serializes the target SpliceInit into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceInit) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		c.ChanID,
+		c.SpliceTx,
+		c.CommitSig,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceInit) MsgType() MessageType {
+	return MsgSpliceInit
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a SpliceInit
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *SpliceInit) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
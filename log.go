@@ -70,6 +70,8 @@ var (
 	crtrLog = backendLog.Logger("CRTR")
 	btcnLog = backendLog.Logger("BTCN")
 	atplLog = backendLog.Logger("ATPL")
+	cnctLog = backendLog.Logger("CNCT")
+	swprLog = backendLog.Logger("SWPR")
 )
 
 // Initialize package-global logger variables.
@@ -103,6 +105,8 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"CRTR": crtrLog,
 	"BTCN": btcnLog,
 	"ATPL": atplLog,
+	"CNCT": cnctLog,
+	"SWPR": swprLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
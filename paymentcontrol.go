@@ -0,0 +1,181 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// paymentControl records the lifecycle of each outgoing payment within the
+// database, and notifies all clients tracking a payment each time its state
+// changes.
+type paymentControl struct {
+	db *channeldb.DB
+
+	clientMtx           sync.Mutex
+	nextClientID        uint32
+	notificationClients map[uint32]*paymentSubscription
+}
+
+// newPaymentControl creates a new paymentControl backed by the passed
+// database.
+func newPaymentControl(db *channeldb.DB) *paymentControl {
+	return &paymentControl{
+		db:                  db,
+		notificationClients: make(map[uint32]*paymentSubscription),
+	}
+}
+
+// paymentSubscription represents an intent to receive updates of the state of
+// a particular payment. A signal is sent over the Updates channel each time
+// the state of the payment changes, after which the current record of the
+// payment should be fetched from the database. Multiple changes may be
+// coalesced into a single signal, so a slow client always catches up with the
+// latest state of the payment.
+type paymentSubscription struct {
+	Updates chan struct{}
+
+	paymentHash [32]byte
+
+	control *paymentControl
+	id      uint32
+}
+
+// Cancel unregisters the paymentSubscription, freeing any previously allocated
+// resources.
+func (p *paymentSubscription) Cancel() {
+	p.control.clientMtx.Lock()
+	delete(p.control.notificationClients, p.id)
+	p.control.clientMtx.Unlock()
+}
+
+// SubscribePayment returns a paymentSubscription which allows the caller to
+// receive async notifications each time the state of the target payment
+// changes.
+func (p *paymentControl) SubscribePayment(
+	paymentHash [32]byte) *paymentSubscription {
+
+	client := &paymentSubscription{
+		Updates:     make(chan struct{}, 1),
+		paymentHash: paymentHash,
+		control:     p,
+	}
+
+	p.clientMtx.Lock()
+	p.notificationClients[p.nextClientID] = client
+	client.id = p.nextClientID
+	p.nextClientID++
+	p.clientMtx.Unlock()
+
+	return client
+}
+
+// isTracked returns true if the lifecycle of payments to the passed payment
+// hash should be recorded. In debug HTLC mode, all payments without an
+// explicit payment hash are sent to the same debug hash, so those can't be
+// tracked individually.
+func isTracked(paymentHash [32]byte) bool {
+	return !cfg.DebugHTLC || chainhash.Hash(paymentHash) != debugHash
+}
+
+// InitPayment records the intent to pay the target payment hash, failing if
+// the payment hash has already been paid, or is currently being paid.
+func (p *paymentControl) InitPayment(paymentHash [32]byte,
+	value lnwire.MilliSatoshi) error {
+
+	if !isTracked(paymentHash) {
+		return nil
+	}
+
+	if err := p.db.InitPayment(paymentHash, value); err != nil {
+		return err
+	}
+
+	p.notifyPayment(paymentHash)
+	return nil
+}
+
+// RegisterAttempt records that an HTLC has been sent out in an attempt to
+// complete the target payment.
+func (p *paymentControl) RegisterAttempt(paymentHash [32]byte,
+	attempt *channeldb.PaymentAttempt) error {
+
+	if !isTracked(paymentHash) {
+		return nil
+	}
+
+	if err := p.db.RegisterAttempt(paymentHash, attempt); err != nil {
+		return err
+	}
+
+	p.notifyPayment(paymentHash)
+	return nil
+}
+
+// FailAttempt records the failure of the attempt which is currently in
+// flight for the target payment.
+func (p *paymentControl) FailAttempt(paymentHash [32]byte,
+	failure string) error {
+
+	if !isTracked(paymentHash) {
+		return nil
+	}
+
+	if err := p.db.FailAttempt(paymentHash, failure); err != nil {
+		return err
+	}
+
+	p.notifyPayment(paymentHash)
+	return nil
+}
+
+// SucceedPayment marks the target payment as succeeded.
+func (p *paymentControl) SucceedPayment(paymentHash, preimage [32]byte) error {
+	if !isTracked(paymentHash) {
+		return nil
+	}
+
+	if err := p.db.SucceedPayment(paymentHash, preimage); err != nil {
+		return err
+	}
+
+	p.notifyPayment(paymentHash)
+	return nil
+}
+
+// FailPayment marks the target payment as failed for the passed reason.
+func (p *paymentControl) FailPayment(paymentHash [32]byte,
+	reason string) error {
+
+	if !isTracked(paymentHash) {
+		return nil
+	}
+
+	if err := p.db.FailPayment(paymentHash, reason); err != nil {
+		return err
+	}
+
+	p.notifyPayment(paymentHash)
+	return nil
+}
+
+// notifyPayment signals all clients tracking the target payment that its
+// state has changed. If a client hasn't yet consumed a prior signal, then it
+// isn't signalled again, so a slow client can't stall the payment itself.
+func (p *paymentControl) notifyPayment(paymentHash [32]byte) {
+	p.clientMtx.Lock()
+	defer p.clientMtx.Unlock()
+
+	for _, client := range p.notificationClients {
+		if client.paymentHash != paymentHash {
+			continue
+		}
+
+		select {
+		case client.Updates <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -89,6 +89,11 @@ type peer struct {
 	started    int32
 	disconnect int32
 
+	// disconnectReason is the reason passed to the first call to
+	// Disconnect. It's written before the quit channel is closed, so it
+	// may be safely read once WaitForDisconnect returns.
+	disconnectReason error
+
 	connReq *connmgr.ConnReq
 	conn    net.Conn
 
@@ -166,6 +171,8 @@ func newPeer(conn net.Conn, connReq *connmgr.ConnReq, server *server,
 		inbound: inbound,
 		connReq: connReq,
 
+		timeConnected: time.Now(),
+
 		server: server,
 
 		sendQueue:     make(chan outgoinMsg),
@@ -286,7 +293,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		}
 
 		lnChan, err := lnwallet.NewLightningChannel(p.server.cc.signer,
-			p.server.cc.chainNotifier, p.server.cc.feeEstimator, dbChan)
+			p.server.witnessBeacon, p.server.cc.chainNotifier, p.server.cc.feeEstimator, dbChan)
 		if err != nil {
 			return err
 		}
@@ -369,7 +376,9 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			SettledContracts: p.server.breachArbiter.settledContracts,
 			DebugHTLC:        cfg.DebugHTLC,
 			HodlHTLC:         cfg.HodlHTLC,
+			AcceptKeySend:    cfg.AcceptKeySend,
 			Registry:         p.server.invoices,
+			PreimageCache:    p.server.witnessBeacon,
 			Switch:           p.server.htlcSwitch,
 			FwrdingPolicy:    *forwardingPolicy,
 			BlockEpochs:      blockEpoch,
@@ -402,6 +411,8 @@ func (p *peer) Disconnect(reason error) {
 
 	peerLog.Tracef("Disconnecting %s, reason: %v", p, reason)
 
+	p.disconnectReason = reason
+
 	// Ensure that the TCP connection is properly closed before continuing.
 	p.conn.Close()
 
@@ -586,6 +597,7 @@ func (p *peer) readHandler() {
 	defer p.wg.Done()
 
 	chanMsgStreams := make(map[lnwire.ChannelID]*chanMsgStream)
+	var disconnectReason error = errors.New("read handler closed")
 out:
 	for atomic.LoadInt32(&p.disconnect) == 0 {
 		nextMsg, err := p.readNextMessage()
@@ -605,6 +617,7 @@ out:
 			// didn't recognize, then we'll stop all processing s
 			// this is a fatal error.
 			default:
+				disconnectReason = err
 				break out
 			}
 		}
@@ -638,6 +651,10 @@ out:
 			p.server.fundingMgr.processFundingSigned(msg, p.addr)
 		case *lnwire.FundingLocked:
 			p.server.fundingMgr.processFundingLocked(msg, p.addr)
+		case *lnwire.SpliceInit:
+			p.server.fundingMgr.processSpliceInit(msg, p.addr)
+		case *lnwire.SpliceAccept:
+			p.server.fundingMgr.processSpliceAccept(msg, p.addr)
 
 		case *lnwire.Shutdown:
 			p.shutdownChanReqs <- msg
@@ -670,7 +687,12 @@ out:
 		case *lnwire.ChannelUpdate,
 			*lnwire.ChannelAnnouncement,
 			*lnwire.NodeAnnouncement,
-			*lnwire.AnnounceSignatures:
+			*lnwire.AnnounceSignatures,
+			*lnwire.QueryShortChanIDs,
+			*lnwire.QueryChannelRange,
+			*lnwire.ReplyChannelRange,
+			*lnwire.ReplyShortChanIDsEnd,
+			*lnwire.GossipTimestampRange:
 
 			p.server.authGossiper.ProcessRemoteAnnouncement(msg,
 				p.addr.IdentityKey)
@@ -699,7 +721,7 @@ out:
 		}
 	}
 
-	p.Disconnect(errors.New("read handler closed"))
+	p.Disconnect(disconnectReason)
 
 	for cid, chanStream := range chanMsgStreams {
 		chanStream.Stop()
@@ -963,19 +985,6 @@ func (p *peer) channelManager() {
 	responderShutdownSigs := make(map[lnwire.ChannelID][]byte)
 	responderFeeProposals := make(map[lnwire.ChannelID]uint64)
 
-	// TODO(roasbeef): move to cfg closure func
-	genDeliveryScript := func() ([]byte, error) {
-		deliveryAddr, err := p.server.cc.wallet.NewAddress(
-			lnwallet.WitnessPubKey, false,
-		)
-		if err != nil {
-			return nil, err
-		}
-		peerLog.Infof("Delivery addr for channel close: %v",
-			deliveryAddr)
-
-		return txscript.PayToAddrScript(deliveryAddr)
-	}
 out:
 	for {
 		select {
@@ -1020,7 +1029,9 @@ out:
 				SettledContracts: p.server.breachArbiter.settledContracts,
 				DebugHTLC:        cfg.DebugHTLC,
 				HodlHTLC:         cfg.HodlHTLC,
+				AcceptKeySend:    cfg.AcceptKeySend,
 				Registry:         p.server.invoices,
+				PreimageCache:    p.server.witnessBeacon,
 				Switch:           p.server.htlcSwitch,
 				FwrdingPolicy:    p.server.cc.routingPolicy,
 				BlockEpochs:      blockEpoch,
@@ -1041,50 +1052,31 @@ out:
 		// We've just received a local quest to close an active
 		// channel.
 		case req := <-p.localCloseChanReqs:
-			// So we'll first transition the channel to a state of
-			// pending shutdown.
-			chanID := lnwire.NewChanIDFromOutPoint(req.ChanPoint)
-
-			// We'll only track this shutdown request if this is a
-			// regular close request, and not in response to a
-			// channel breach.
-			var (
-				deliveryScript []byte
-				err            error
-			)
-			if req.CloseType == htlcswitch.CloseRegular {
-				chanShutdowns[chanID] = req
-
-				// As we need to close out the channel and
-				// claim our funds on-chain, we'll request a
-				// new delivery address from the wallet, and
-				// turn that into it corresponding output
-				// script.
-				deliveryScript, err = genDeliveryScript()
-				if err != nil {
-					cErr := fmt.Errorf("Unable to generate "+
-						"delivery address: %v", err)
-
-					peerLog.Errorf(cErr.Error())
-
-					req.Err <- cErr
-					continue
-				}
-
-				// We'll also track this delivery script, as
-				// we'll need it to reconstruct the cooperative
-				// closure transaction during our closing fee
-				// negotiation ratchet.
-				deliveryAddrs[chanID] = &closingScripts{
-					localScript: deliveryScript,
-				}
-			}
-
 			// With the state marked as shutting down, we can now
 			// proceed with the channel close workflow. If this is
 			// regular close, we'll send a shutdown. Otherwise,
 			// we'll simply be clearing our indexes.
-			p.handleLocalClose(req, deliveryScript)
+			deliveryScript := p.handleLocalClose(req)
+
+			// We'll only track this shutdown request if this is a
+			// regular close request for which we've successfully
+			// sent a shutdown, and not in response to a channel
+			// breach.
+			if req.CloseType != htlcswitch.CloseRegular ||
+				deliveryScript == nil {
+				continue
+			}
+
+			chanID := lnwire.NewChanIDFromOutPoint(req.ChanPoint)
+			chanShutdowns[chanID] = req
+
+			// We'll also track our delivery script, as we'll need
+			// it to reconstruct the cooperative closure
+			// transaction during our closing fee negotiation
+			// ratchet.
+			deliveryAddrs[chanID] = &closingScripts{
+				localScript: deliveryScript,
+			}
 
 		// A receipt of a message over this channel indicates that
 		// either a shutdown proposal has been initiated, or a prior
@@ -1095,7 +1087,7 @@ out:
 			// ID, then we'll ignore this message.
 			chanID := req.ChannelID
 			p.activeChanMtx.Lock()
-			_, ok := p.activeChannels[chanID]
+			channel, ok := p.activeChannels[chanID]
 			p.activeChanMtx.Unlock()
 			if !ok {
 				peerLog.Warnf("Received unsolicited shutdown msg: %v",
@@ -1103,6 +1095,32 @@ out:
 				continue
 			}
 
+			// If the remote party committed to an upfront
+			// shutdown script when the channel was opened, then
+			// they MUST use it as their delivery address. If they
+			// don't, we'll reject the shutdown.
+			_, remoteUpfrontScript := channel.UpfrontShutdownScripts()
+			if len(remoteUpfrontScript) != 0 &&
+				!bytes.Equal(remoteUpfrontScript, req.Address) {
+
+				err := fmt.Errorf("delivery address for "+
+					"ChannelID(%v) doesn't match upfront "+
+					"shutdown script", chanID)
+				peerLog.Errorf(err.Error())
+
+				p.queueMsg(&lnwire.Error{
+					ChanID: chanID,
+					Data:   lnwire.ErrorData(err.Error()),
+				}, nil)
+
+				if localReq, ok := chanShutdowns[chanID]; ok {
+					localReq.Err <- err
+					delete(chanShutdowns, chanID)
+				}
+				delete(deliveryAddrs, chanID)
+				continue
+			}
+
 			// First, we'll track their delivery script for when we
 			// ultimately create the cooperative closure
 			// transaction.
@@ -1129,8 +1147,12 @@ out:
 				}
 
 				// As we're the responder, we'll need to
-				// generate a delivery script of our own.
-				deliveryScript, err := genDeliveryScript()
+				// select a delivery script of our own. If we
+				// committed to an upfront shutdown script,
+				// then we must use it.
+				deliveryScript, err := p.chooseDeliveryScript(
+					channel, nil,
+				)
 				if err != nil {
 					peerLog.Errorf("Unable to generate "+
 						"delivery address: %v", err)
@@ -1218,11 +1240,13 @@ out:
 }
 
 // handleLocalClose kicks-off the workflow to execute a cooperative or forced
-// unilateral closure of the channel initiated by a local subsystem.
+// unilateral closure of the channel initiated by a local subsystem. In the
+// case of a cooperative closure, the delivery script sent to the remote peer
+// is returned. Otherwise, or if the request fails, nil is returned.
 //
 // TODO(roasbeef): if no more active channels with peer call Remove on connMgr
 // with peerID
-func (p *peer) handleLocalClose(req *htlcswitch.ChanClose, deliveryScript []byte) {
+func (p *peer) handleLocalClose(req *htlcswitch.ChanClose) []byte {
 	chanID := lnwire.NewChanIDFromOutPoint(req.ChanPoint)
 
 	p.activeChanMtx.RLock()
@@ -1233,7 +1257,7 @@ func (p *peer) handleLocalClose(req *htlcswitch.ChanClose, deliveryScript []byte
 			"unknown", chanID)
 		peerLog.Errorf(err.Error())
 		req.Err <- err
-		return
+		return nil
 	}
 
 	switch req.CloseType {
@@ -1242,12 +1266,27 @@ func (p *peer) handleLocalClose(req *htlcswitch.ChanClose, deliveryScript []byte
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case htlcswitch.CloseRegular:
-		err := p.sendShutdown(channel, deliveryScript)
+		// As we need to close out the channel and claim our funds
+		// on-chain, we'll first determine the script our funds should
+		// be paid to, taking into account both the caller's request,
+		// and any upfront shutdown script we committed to.
+		deliveryScript, err := p.chooseDeliveryScript(
+			channel, req.DeliveryScript,
+		)
 		if err != nil {
+			peerLog.Errorf("Unable to select delivery script for "+
+				"ChannelPoint(%v): %v", req.ChanPoint, err)
 			req.Err <- err
-			return
+			return nil
+		}
+
+		if err := p.sendShutdown(channel, deliveryScript); err != nil {
+			req.Err <- err
+			return nil
 		}
 
+		return deliveryScript
+
 	// A type of CloseBreach indicates that the counterparty has breached
 	// the channel therefore we need to clean up our local state.
 	case htlcswitch.CloseBreach:
@@ -1258,10 +1297,83 @@ func (p *peer) handleLocalClose(req *htlcswitch.ChanClose, deliveryScript []byte
 			peerLog.Infof("Unable to wipe channel after detected "+
 				"breach: %v", err)
 			req.Err <- err
-			return
 		}
-		return
 	}
+
+	return nil
+}
+
+// genDeliveryScript requests a new delivery address from the wallet, and
+// returns its corresponding output script.
+func (p *peer) genDeliveryScript() ([]byte, error) {
+	deliveryAddr, err := p.server.cc.wallet.NewAddress(
+		lnwallet.WitnessPubKey, false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	peerLog.Infof("Delivery addr for channel close: %v", deliveryAddr)
+
+	return txscript.PayToAddrScript(deliveryAddr)
+}
+
+// chooseDeliveryScript selects the script our funds will be paid to in a
+// cooperative closure of the passed channel. If we committed to an upfront
+// shutdown script when the channel was opened, then it must be used, and any
+// script requested by the caller must match it. Otherwise, the requested
+// script is used if set, falling back to a fresh script from the wallet.
+func (p *peer) chooseDeliveryScript(channel *lnwallet.LightningChannel,
+	requested lnwire.DeliveryAddress) ([]byte, error) {
+
+	localUpfrontScript, _ := channel.UpfrontShutdownScripts()
+
+	switch {
+	case len(localUpfrontScript) != 0 && len(requested) != 0 &&
+		!bytes.Equal(localUpfrontScript, requested):
+
+		return nil, fmt.Errorf("delivery address doesn't match the " +
+			"upfront shutdown script committed to at funding time")
+
+	case len(localUpfrontScript) != 0:
+		return localUpfrontScript, nil
+
+	case len(requested) != 0:
+		return requested, nil
+	}
+
+	deliveryScript, err := p.genDeliveryScript()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate delivery "+
+			"address: %v", err)
+	}
+
+	return deliveryScript, nil
+}
+
+// closeFeeBounds returns our ideal fee for the cooperative closing transaction
+// of the passed channel, along with the maximum fee we're willing to pay. If
+// the closure was requested locally, then localReq should be set so the
+// caller's fee preferences are taken into account. A max fee of zero
+// indicates that no limit beyond the default negotiation bounds is enforced.
+func (p *peer) closeFeeBounds(channel *lnwallet.LightningChannel,
+	localReq *htlcswitch.ChanClose) (uint64, uint64) {
+
+	feeRate := p.server.cc.feeEstimator.EstimateFeePerWeight(1) * 1000
+
+	var maxFee uint64
+	if localReq != nil {
+		if localReq.TargetFeePerKw != 0 {
+			feeRate = uint64(localReq.TargetFeePerKw)
+		}
+		maxFee = uint64(localReq.MaxFee)
+	}
+
+	idealFee := channel.CalcFee(feeRate)
+	if maxFee != 0 && idealFee > maxFee {
+		idealFee = maxFee
+	}
+
+	return idealFee, maxFee
 }
 
 // handleShutdownResponse is called when a responder in a cooperative channel
@@ -1322,16 +1434,28 @@ func (p *peer) handleShutdownResponse(msg *lnwire.Shutdown,
 
 // calculateCompromiseFee performs the current fee negotiation algorithm,
 // taking into consideration our ideal fee based on current fee environment,
-// the fee we last proposed (if any), and the fee proposed by the peer.
-func calculateCompromiseFee(ourIdealFee, lastSentFee, peerFee uint64) uint64 {
+// the fee we last proposed (if any), and the fee proposed by the peer. If
+// maxFee is non-zero, then the fee returned will never exceed it.
+func calculateCompromiseFee(ourIdealFee, lastSentFee, peerFee,
+	maxFee uint64) uint64 {
+
 	// We will accept a proposed fee in the interval
 	// [0.5*ourIdealFee, 2*ourIdealFee]. If the peer's fee doesn't fall in
 	// this range, we'll propose the average of the peer's fee and our last
 	// sent fee, as long as it is in this range.
 	// TODO(halseth): Dynamic fee to determine what we consider min/max for
 	// timely confirmation.
-	maxFee := 2 * ourIdealFee
-	minFee := ourIdealFee / 2
+	upperFee := 2 * ourIdealFee
+	lowerFee := ourIdealFee / 2
+
+	// If the caller specified a maximum fee, then we'll further restrict
+	// the upper bound of the accepted interval.
+	if maxFee != 0 && upperFee > maxFee {
+		upperFee = maxFee
+	}
+	if lowerFee > upperFee {
+		lowerFee = upperFee
+	}
 
 	// If we didn't propose a fee before, just use our ideal fee value for
 	// the average calculation.
@@ -1341,24 +1465,24 @@ func calculateCompromiseFee(ourIdealFee, lastSentFee, peerFee uint64) uint64 {
 	avgFee := (lastSentFee + peerFee) / 2
 
 	switch {
-	case peerFee <= maxFee && peerFee >= minFee:
+	case peerFee <= upperFee && peerFee >= lowerFee:
 		// Peer fee is in the accepted range.
 		return peerFee
-	case avgFee <= maxFee && avgFee >= minFee:
+	case avgFee <= upperFee && avgFee >= lowerFee:
 		// The peer's fee is not in the accepted range, but the average
 		// fee is.
 		return avgFee
-	case avgFee > maxFee:
+	case avgFee > upperFee:
 		// TODO(halseth): We must ensure fee is not higher than the
 		// current fee on the commitment transaction.
 
 		// We cannot accept the average fee, as it is more than twice
-		// our own estimate. Set our proposed to the maximum we can
-		// accept.
-		return maxFee
+		// our own estimate, or above the caller's max fee. Set our
+		// proposed to the maximum we can accept.
+		return upperFee
 	default:
 		// Cannot accept the average, as we consider it too low.
-		return minFee
+		return lowerFee
 	}
 }
 
@@ -1404,7 +1528,7 @@ func (p *peer) handleClosingSigned(localReq *htlcswitch.ChanClose,
 	// with our new proposed fee. In case we can agree on a fee, it will
 	// assemble the close transaction, and we can go on to broadcasting it.
 	closeTx, ourSig, ourFee, err := p.negotiateFeeAndCreateCloseTx(channel,
-		msg, deliveryScripts, lastSig, lastFee)
+		msg, deliveryScripts, lastSig, lastFee, localReq)
 	if err != nil {
 		if localReq != nil {
 			localReq.Err <- err
@@ -1524,18 +1648,28 @@ func (p *peer) handleClosingSigned(localReq *htlcswitch.ChanClose,
 	}
 
 	go waitForChanToClose(uint32(bestHeight), notifier, errChan,
-		chanPoint, &closingTxid, func() {
+		chanPoint, &closingTxid, func(closeHeight uint32) {
 
-			// First, we'll mark the database as being fully closed
-			// so we'll no longer watch for its ultimate closure
-			// upon startup.
-			err := p.server.chanDB.MarkChanFullyClosed(chanPoint)
+			// First, we'll record the close height and mark the
+			// database as being fully closed so we'll no longer
+			// watch for its ultimate closure upon startup.
+			err := p.server.chanDB.SetCloseHeight(
+				chanPoint, closeHeight,
+			)
 			if err != nil {
 				if localReq != nil {
 					localReq.Err <- err
 				}
 				return
 			}
+			err = p.server.chanDB.MarkChanFullyClosed(chanPoint)
+			if err != nil {
+				if localReq != nil {
+					localReq.Err <- err
+				}
+				return
+			}
+			p.server.channelNotifier.NotifyClosedChannelEvent(*chanPoint)
 
 			// Respond to the local subsystem which requested the
 			// channel closure.
@@ -1563,12 +1697,16 @@ func (p *peer) handleClosingSigned(localReq *htlcswitch.ChanClose,
 // before the closing transaction is broadcasted. In the case where we cannot
 // accept the peer's proposed fee, a new fee proposal will be sent.
 //
+// If this is a locally initiated closure, then localReq should be set, in
+// which case the caller's target fee rate and max fee bound the negotiation.
+//
 // TODO(halseth): In the case where we cannot accept the fee, and we cannot
 // make more proposals, this method should return an error, and we should fail
 // the channel.
 func (p *peer) negotiateFeeAndCreateCloseTx(channel *lnwallet.LightningChannel,
 	msg *lnwire.ClosingSigned, deliveryScripts *closingScripts, ourSig []byte,
-	ourFeeProp uint64) (*wire.MsgTx, []byte, uint64, error) {
+	ourFeeProp uint64,
+	localReq *htlcswitch.ChanClose) (*wire.MsgTx, []byte, uint64, error) {
 
 	peerFeeProposal := msg.FeeSatoshis
 
@@ -1578,11 +1716,9 @@ func (p *peer) negotiateFeeAndCreateCloseTx(channel *lnwallet.LightningChannel,
 	if peerFeeProposal != ourFeeProp {
 		// The peer has suggested a different fee from what we proposed.
 		// Let's calculate if this one is tolerable.
-		ourIdealFeeRate := p.server.cc.feeEstimator.
-			EstimateFeePerWeight(1) * 1000
-		ourIdealFee := channel.CalcFee(ourIdealFeeRate)
+		ourIdealFee, maxFee := p.closeFeeBounds(channel, localReq)
 		fee := calculateCompromiseFee(ourIdealFee, ourFeeProp,
-			peerFeeProposal)
+			peerFeeProposal, maxFee)
 
 		// Our new proposed fee must be strictly between what we
 		// proposed before and what the peer proposed.
@@ -1651,11 +1787,12 @@ func (p *peer) negotiateFeeAndCreateCloseTx(channel *lnwallet.LightningChannel,
 // waitForChanToClose uses the passed notifier to wait until the channel has
 // been detected as closed on chain and then concludes by executing the
 // following actions: the channel point will be sent over the settleChan, and
-// finally the callback will be executed. If any error is encountered within
-// the function, then it will be sent over the errChan.
+// finally the callback will be executed with the height at which the closing
+// transaction confirmed. If any error is encountered within the function,
+// then it will be sent over the errChan.
 func waitForChanToClose(bestHeight uint32, notifier chainntnfs.ChainNotifier,
 	errChan chan error, chanPoint *wire.OutPoint,
-	closingTxID *chainhash.Hash, cb func()) {
+	closingTxID *chainhash.Hash, cb func(closeHeight uint32)) {
 
 	peerLog.Infof("Waiting for confirmation of cooperative close of "+
 		"ChannelPoint(%v) with txid: %v", chanPoint,
@@ -1685,7 +1822,7 @@ func waitForChanToClose(bestHeight uint32, notifier chainntnfs.ChainNotifier,
 
 	// Finally, execute the closure call back to mark the confirmation of
 	// the transaction closing the contract.
-	cb()
+	cb(height.BlockHeight)
 }
 
 // sendShutdown handles the creation and sending of the Shutdown messages sent
@@ -1719,6 +1856,10 @@ func (p *peer) sendShutdown(channel *lnwallet.LightningChannel,
 func (p *peer) WipeChannel(channel *lnwallet.LightningChannel) error {
 	channel.Stop()
 
+	p.server.channelNotifier.NotifyClosingChannelEvent(
+		*channel.ChannelPoint(),
+	)
+
 	chanID := lnwire.NewChanIDFromOutPoint(channel.ChannelPoint())
 
 	p.activeChanMtx.Lock()
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -519,3 +520,118 @@ func TestPeerChannelClosureFeeNegotiationsInitiator(t *testing.T) {
 		t.Fatalf("closing tx not broadcast")
 	}
 }
+
+// TestPeerChannelClosureCustomDeliveryScript tests that the shutdown initiator
+// will pay out to the delivery script specified within the local close
+// request, rather than generating a fresh one.
+func TestPeerChannelClosureCustomDeliveryScript(t *testing.T) {
+	disablePeerLogger(t)
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	broadcastTxChan := make(chan *wire.MsgTx)
+
+	initiator, initiatorChan, _, cleanUp, err := createTestPeer(
+		notifier, broadcastTxChan)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We make the initiator send a shutdown request, specifying the
+	// delivery script that the funds should be paid out to.
+	updateChan := make(chan *lnrpc.CloseStatusUpdate, 1)
+	errChan := make(chan error, 1)
+	closeCommand := &htlcswitch.ChanClose{
+		CloseType:      htlcswitch.CloseRegular,
+		ChanPoint:      initiatorChan.ChannelPoint(),
+		Updates:        updateChan,
+		Err:            errChan,
+		DeliveryScript: dummyDeliveryScript,
+	}
+	initiator.localCloseChanReqs <- closeCommand
+
+	var msg lnwire.Message
+	select {
+	case outMsg := <-initiator.outgoingQueue:
+		msg = outMsg.msg
+	case err := <-errChan:
+		t.Fatalf("unable to initiate close: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("did not receive shutdown request")
+	}
+
+	shutdownMsg, ok := msg.(*lnwire.Shutdown)
+	if !ok {
+		t.Fatalf("expected Shutdown message, got %T", msg)
+	}
+
+	// The address within the Shutdown message should be exactly the
+	// script that was requested.
+	if !bytes.Equal(shutdownMsg.Address, dummyDeliveryScript) {
+		t.Fatalf("expected delivery script %x, instead got %x",
+			dummyDeliveryScript, shutdownMsg.Address)
+	}
+}
+
+// TestCalculateCompromiseFeeMaxFee tests that the fee negotiation algorithm
+// never settles on a fee above the caller specified maximum fee.
+func TestCalculateCompromiseFeeMaxFee(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ourIdealFee uint64
+		lastSentFee uint64
+		peerFee     uint64
+		maxFee      uint64
+		expectedFee uint64
+	}{
+		// Without a max fee, a peer fee within [0.5x, 2x] of our ideal
+		// fee is accepted.
+		{
+			ourIdealFee: 1000,
+			peerFee:     1800,
+			expectedFee: 1800,
+		},
+		// With a max fee, the same peer fee is too high, so we'll
+		// propose the average instead.
+		{
+			ourIdealFee: 1000,
+			peerFee:     1800,
+			maxFee:      1500,
+			expectedFee: 1400,
+		},
+		// If the average is also above the max fee, then we'll
+		// propose the max fee itself.
+		{
+			ourIdealFee: 1000,
+			lastSentFee: 1200,
+			peerFee:     3000,
+			maxFee:      1100,
+			expectedFee: 1100,
+		},
+		// A max fee below our lower bound caps the lower bound as
+		// well.
+		{
+			ourIdealFee: 1000,
+			peerFee:     100,
+			maxFee:      300,
+			expectedFee: 300,
+		},
+	}
+
+	for i, test := range testCases {
+		fee := calculateCompromiseFee(test.ourIdealFee,
+			test.lastSentFee, test.peerFee, test.maxFee)
+		if fee != test.expectedFee {
+			t.Fatalf("test #%v: expected fee %v, got %v", i,
+				test.expectedFee, fee)
+		}
+		if test.maxFee != 0 && fee > test.maxFee {
+			t.Fatalf("test #%v: fee %v exceeds max fee %v", i,
+				fee, test.maxFee)
+		}
+	}
+}
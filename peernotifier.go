@@ -0,0 +1,126 @@
+package main
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// peerEventType denotes whether a peerEvent describes a peer coming online or
+// going offline.
+type peerEventType uint8
+
+const (
+	// peerOnlineEvent is sent once a connection to a peer has been
+	// established, and the peer has been added to the server.
+	peerOnlineEvent peerEventType = iota
+
+	// peerOfflineEvent is sent once the connection to a peer has been
+	// terminated.
+	peerOfflineEvent
+)
+
+// peerEvent describes a peer connecting to, or disconnecting from, the
+// server.
+type peerEvent struct {
+	eventType peerEventType
+
+	pubKey *btcec.PublicKey
+
+	// address is the network address of the connection to the peer.
+	address string
+
+	// inbound is true if the connection was initiated by the peer.
+	inbound bool
+
+	// reason describes why the connection was terminated. This is only
+	// set for peerOfflineEvents.
+	reason string
+}
+
+// peerNotifier dispatches notifications to all registered clients each time a
+// peer comes online or goes offline. The connection statistics persisted for
+// the peer are updated along with each notification.
+type peerNotifier struct {
+	cdb *channeldb.DB
+
+	clients *subscriptionServer
+}
+
+// newPeerNotifier creates a new peer notifier, which will record the
+// connection statistics of each peer within the passed database.
+func newPeerNotifier(cdb *channeldb.DB) *peerNotifier {
+	return &peerNotifier{
+		cdb:     cdb,
+		clients: newSubscriptionServer(),
+	}
+}
+
+// SubscribePeerEvents returns an eventSubscription which allows the caller to
+// receive async notifications each time a peer comes online or goes offline.
+// Each event is sent as a *peerEvent.
+func (p *peerNotifier) SubscribePeerEvents() *eventSubscription {
+	return p.clients.subscribe()
+}
+
+// updateLinkNodeStats applies the passed update to the connection statistics
+// persisted within the LinkNode of the target peer. As LinkNodes are only
+// stored for peers we have or had a channel with, the update is skipped for
+// all other peers.
+func (p *peerNotifier) updateLinkNodeStats(pubKey *btcec.PublicKey,
+	update func(*channeldb.LinkNode) error) {
+
+	linkNode, err := p.cdb.FetchLinkNode(pubKey)
+	switch {
+	case err == channeldb.ErrNodeNotFound ||
+		err == channeldb.ErrLinkNodesNotFound:
+		return
+
+	case err != nil:
+		srvrLog.Errorf("unable to fetch link node for %x: %v",
+			pubKey.SerializeCompressed(), err)
+		return
+	}
+
+	if err := update(linkNode); err != nil {
+		srvrLog.Errorf("unable to update connection stats for %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+}
+
+// NotifyPeerOnline records a new connection to the passed peer established
+// at connectTime, then notifies all clients of the connection.
+func (p *peerNotifier) NotifyPeerOnline(pubKey *btcec.PublicKey,
+	address string, inbound bool, connectTime time.Time) {
+
+	p.updateLinkNodeStats(pubKey, func(node *channeldb.LinkNode) error {
+		return node.RecordConnection(connectTime)
+	})
+
+	p.clients.notifyClients(&peerEvent{
+		eventType: peerOnlineEvent,
+		pubKey:    pubKey,
+		address:   address,
+		inbound:   inbound,
+	})
+}
+
+// NotifyPeerOffline records the termination of a connection to the passed
+// peer which lasted for the given uptime, then notifies all clients that the
+// connection has been terminated for the given reason.
+func (p *peerNotifier) NotifyPeerOffline(pubKey *btcec.PublicKey,
+	address string, inbound bool, uptime time.Duration, reason string) {
+
+	p.updateLinkNodeStats(pubKey, func(node *channeldb.LinkNode) error {
+		return node.RecordDisconnection(uptime, reason)
+	})
+
+	p.clients.notifyClients(&peerEvent{
+		eventType: peerOfflineEvent,
+		pubKey:    pubKey,
+		address:   address,
+		inbound:   inbound,
+		reason:    reason,
+	})
+}
@@ -0,0 +1,201 @@
+// +build !rpctest
+
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// assertPeerEvent asserts that the next event received by the passed client
+// is of the expected type, and concerns the expected peer.
+func assertPeerEvent(t *testing.T, client *eventSubscription,
+	eventType peerEventType, pubKey *btcec.PublicKey) *peerEvent {
+
+	select {
+	case update := <-client.Updates:
+		event := update.(*peerEvent)
+		if event.eventType != eventType {
+			t.Fatalf("expected event of type %v, got %v",
+				eventType, event.eventType)
+		}
+		if !event.pubKey.IsEqual(pubKey) {
+			t.Fatalf("expected event for peer %x, got %x",
+				pubKey.SerializeCompressed(),
+				event.pubKey.SerializeCompressed())
+		}
+		return event
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("peer event not received")
+	}
+
+	return nil
+}
+
+// TestPeerNotifierConnectionStats tests that the connection statistics of a
+// peer are persisted as it connects and disconnects, and that clients are
+// notified of each connection and disconnection.
+func TestPeerNotifierConnectionStats(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	// Connection statistics are only persisted for peers we have a
+	// LinkNode for, so we'll create one for Alice.
+	_, alicePub := btcec.PrivKeyFromBytes(btcec.S256(), alicesPrivKey)
+	_, bobPub := btcec.PrivKeyFromBytes(btcec.S256(), bobsPrivKey)
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	linkNode := db.NewLinkNode(wire.MainNet, alicePub, addr)
+	if err := linkNode.Sync(); err != nil {
+		t.Fatalf("unable to sync link node: %v", err)
+	}
+
+	notifier := newPeerNotifier(db)
+	client := notifier.SubscribePeerEvents()
+	defer client.Cancel()
+
+	// assertStats asserts that the connection statistics persisted for
+	// Alice match the expected values.
+	assertStats := func(connections uint32, uptime time.Duration,
+		reason string) *channeldb.LinkNode {
+
+		node, err := db.FetchLinkNode(alicePub)
+		if err != nil {
+			t.Fatalf("unable to fetch link node: %v", err)
+		}
+		if node.ConnectionCount != connections {
+			t.Fatalf("expected %v connections, got %v",
+				connections, node.ConnectionCount)
+		}
+		if node.Uptime != uptime {
+			t.Fatalf("expected uptime of %v, got %v", uptime,
+				node.Uptime)
+		}
+		if node.LastDisconnectReason != reason {
+			t.Fatalf("expected disconnect reason %q, got %q",
+				reason, node.LastDisconnectReason)
+		}
+		return node
+	}
+
+	// We'll connect to and disconnect from Alice twice, ensuring that
+	// each connection and its uptime is accumulated.
+	const aliceAddr = "127.0.0.1:18555"
+	connectTime := time.Unix(time.Now().Unix(), 0)
+	notifier.NotifyPeerOnline(alicePub, aliceAddr, true, connectTime)
+	event := assertPeerEvent(t, client, peerOnlineEvent, alicePub)
+	if event.address != aliceAddr || !event.inbound {
+		t.Fatalf("unexpected online event: address=%v, inbound=%v",
+			event.address, event.inbound)
+	}
+	node := assertStats(1, 0, "")
+	if !node.LastSeen.Equal(connectTime) {
+		t.Fatalf("expected last seen of %v, got %v", connectTime,
+			node.LastSeen)
+	}
+
+	notifier.NotifyPeerOffline(
+		alicePub, aliceAddr, true, time.Minute, "read timeout",
+	)
+	event = assertPeerEvent(t, client, peerOfflineEvent, alicePub)
+	if event.reason != "read timeout" {
+		t.Fatalf("expected reason %q, got %q", "read timeout",
+			event.reason)
+	}
+	assertStats(1, time.Minute, "read timeout")
+
+	notifier.NotifyPeerOnline(alicePub, aliceAddr, false, time.Now())
+	assertPeerEvent(t, client, peerOnlineEvent, alicePub)
+	assertStats(2, time.Minute, "read timeout")
+
+	notifier.NotifyPeerOffline(
+		alicePub, aliceAddr, false, time.Minute*2, "disconnect",
+	)
+	assertPeerEvent(t, client, peerOfflineEvent, alicePub)
+	assertStats(2, time.Minute*3, "disconnect")
+
+	// Clients should still be notified of the connections of peers we
+	// don't have a LinkNode for, for which no statistics are persisted.
+	notifier.NotifyPeerOnline(bobPub, "127.0.0.1:18556", true, time.Now())
+	assertPeerEvent(t, client, peerOnlineEvent, bobPub)
+	notifier.NotifyPeerOffline(
+		bobPub, "127.0.0.1:18556", true, time.Minute, "disconnect",
+	)
+	assertPeerEvent(t, client, peerOfflineEvent, bobPub)
+
+	if _, err := db.FetchLinkNode(bobPub); err != channeldb.ErrNodeNotFound {
+		t.Fatalf("expected ErrNodeNotFound, got: %v", err)
+	}
+	assertStats(2, time.Minute*3, "disconnect")
+}
+
+// TestPeerNotifierEventOrder tests that a client receives the connection
+// events of a peer in the order they were dispatched, even if it doesn't
+// consume them as they're dispatched.
+func TestPeerNotifierEventOrder(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	_, alicePub := btcec.PrivKeyFromBytes(btcec.S256(), alicesPrivKey)
+
+	notifier := newPeerNotifier(db)
+	client := notifier.SubscribePeerEvents()
+	defer client.Cancel()
+
+	// We'll dispatch each event before reading any of them, to ensure
+	// they're delivered in order regardless of how quickly the client
+	// consumes them.
+	const aliceAddr = "127.0.0.1:18555"
+	notifier.NotifyPeerOnline(alicePub, aliceAddr, true, time.Now())
+	notifier.NotifyPeerOffline(
+		alicePub, aliceAddr, true, time.Minute, "disconnect",
+	)
+	notifier.NotifyPeerOnline(alicePub, aliceAddr, false, time.Now())
+
+	assertPeerEvent(t, client, peerOnlineEvent, alicePub)
+	assertPeerEvent(t, client, peerOfflineEvent, alicePub)
+	event := assertPeerEvent(t, client, peerOnlineEvent, alicePub)
+	if event.inbound {
+		t.Fatalf("expected the final connection to be outbound")
+	}
+
+	// No further events should be received.
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("unexpected event of type %v",
+			event.(*peerEvent).eventType)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -6,8 +6,11 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -85,7 +88,7 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 
 	// With the connection established, we'll now establish our connection
 	// to the target peer, waiting for the first update before we exit.
-	updateStream, errChan := c.server.OpenChannel(-1, target, amt, 0)
+	updateStream, errChan := c.server.OpenChannel(-1, target, amt, 0, nil)
 
 	select {
 	case err := <-errChan:
@@ -100,13 +103,79 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 func (c *chanController) CloseChannel(chanPoint *wire.OutPoint) error {
 	return nil
 }
+
+// SpliceIn adds the specified amount from the wallet to the target channel
+// via a splice. This function un-blocks once the splice transaction has been
+// broadcast.
 func (c *chanController) SpliceIn(chanPoint *wire.OutPoint,
 	amt btcutil.Amount) (*autopilot.Channel, error) {
-	return nil, nil
+
+	return c.splice(chanPoint, amt, nil)
 }
+
+// SpliceOut removes the specified amount from the target channel via a
+// splice, paying it out to a fresh address of the backing wallet. This
+// function un-blocks once the splice transaction has been broadcast.
 func (c *chanController) SpliceOut(chanPoint *wire.OutPoint,
 	amt btcutil.Amount) (*autopilot.Channel, error) {
-	return nil, nil
+
+	addr, err := c.server.cc.wallet.NewAddress(lnwallet.WitnessPubKey,
+		false)
+	if err != nil {
+		return nil, err
+	}
+	spliceOutScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.splice(chanPoint, amt, spliceOutScript)
+}
+
+// splice executes a splice of the target channel, returning the resulting
+// channel once the splice transaction has been broadcast. As the short
+// channel ID of the spliced channel is only known once the splice
+// transaction has confirmed, it's left blank.
+func (c *chanController) splice(chanPoint *wire.OutPoint, amt btcutil.Amount,
+	spliceOutScript []byte) (*autopilot.Channel, error) {
+
+	dbChannels, err := c.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	var dbChan *channeldb.OpenChannel
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == *chanPoint {
+			dbChan = dbChannel
+			break
+		}
+	}
+	if dbChan == nil {
+		return nil, fmt.Errorf("unable to find channel %v", chanPoint)
+	}
+
+	updateChan, errChan := c.server.SpliceChannel(*chanPoint, amt,
+		spliceOutScript, 0)
+
+	select {
+	case err := <-errChan:
+		return nil, err
+	case <-updateChan:
+	case <-c.server.quit:
+		return nil, fmt.Errorf("server shutting down")
+	}
+
+	delta := amt
+	if spliceOutScript != nil {
+		delta = -amt
+	}
+
+	return &autopilot.Channel{
+		Capacity:  dbChan.Capacity + delta,
+		FundedAmt: dbChan.LocalBalance.ToSatoshis() + delta,
+		Node:      autopilot.NewNodeID(dbChan.IdentityPub),
+	}, nil
 }
 
 // A compile time assertion to ensure chanController meets the
@@ -1,4 +1,331 @@
 package routing
 
+import (
+	"sort"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
 // TODO(roasbeef): abstract out graph to interface
 //  * add in-memory version of graph for tests
+
+// cachedChannel is a channel within the graphCache, along with the routing
+// policies of both of its nodes.
+type cachedChannel struct {
+	info *channeldb.ChannelEdgeInfo
+
+	// nodes holds the vertexes of the first and second node of the
+	// channel.
+	nodes [2]vertex
+
+	// policies holds the routing policy advertised by each node of the
+	// channel, indexed by the direction bit of the policy. A nil entry
+	// denotes a policy that hasn't been advertised yet.
+	policies [2]*channeldb.ChannelEdgePolicy
+}
+
+// graphCache is an in-memory view of the channel graph which holds all the
+// information required to find paths within the graph. Path finding consults
+// the cache rather than walking the graph within the database, so it neither
+// pays for deserializing each edge visited, nor holds open a database
+// transaction which would block writers. The ChannelRouter keeps the cache in
+// sync with the database as it applies updates to the graph, or prunes
+// channels from it.
+//
+// All the nodes, channels and policies held by the cache are treated as
+// immutable: updates replace them rather than modifying them in place, and
+// callers must not modify those handed to them. The only exception are the
+// nodes and policies passed to the callback of forEachChannel, which are
+// fresh copies made for each call.
+type graphCache struct {
+	mtx sync.RWMutex
+
+	// nodes maps the vertex of each known node to its latest
+	// announcement. Nodes only known through the channels they're a party
+	// to hold nothing but their public key.
+	nodes map[vertex]*channeldb.LightningNode
+
+	// channels maps the short channel ID of each known channel to the
+	// channel itself.
+	channels map[uint64]*cachedChannel
+
+	// adjacency maps the vertex of each node to the IDs of the channels
+	// it's a party to. The IDs are kept sorted, so channels are visited in
+	// the same order as within the database.
+	adjacency map[vertex][]uint64
+}
+
+// newGraphCache creates a new graphCache populated with the current contents
+// of the passed channel graph.
+func newGraphCache(graph *channeldb.ChannelGraph) (*graphCache, error) {
+	c := &graphCache{
+		nodes:     make(map[vertex]*channeldb.LightningNode),
+		channels:  make(map[uint64]*cachedChannel),
+		adjacency: make(map[vertex][]uint64),
+	}
+
+	err := graph.ForEachNode(nil, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		c.addNode(node)
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNotFound {
+		return nil, err
+	}
+
+	err = graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		policy1, policy2 *channeldb.ChannelEdgePolicy) error {
+
+		c.addChannel(info)
+		if policy1 != nil {
+			c.updatePolicy(policy1)
+		}
+		if policy2 != nil {
+			c.updatePolicy(policy2)
+		}
+		return nil
+	})
+	switch {
+	case err == channeldb.ErrGraphNotFound:
+	case err == channeldb.ErrGraphNoEdgesFound:
+	case err != nil:
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// addNode adds the passed node to the cache, replacing any prior version of
+// the node.
+func (c *graphCache) addNode(node *channeldb.LightningNode) {
+	c.mtx.Lock()
+	c.nodes[newVertex(node.PubKey)] = node
+	c.mtx.Unlock()
+}
+
+// addChannel adds the passed channel to the cache. If the channel is already
+// known, then its information is replaced while its policies are kept. Nodes
+// of the channel which aren't yet known are added to the cache as well.
+func (c *graphCache) addChannel(info *channeldb.ChannelEdgeInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if channel, ok := c.channels[info.ChannelID]; ok {
+		channel.info = info
+		return
+	}
+
+	channel := &cachedChannel{
+		info: info,
+		nodes: [2]vertex{
+			newVertex(info.NodeKey1), newVertex(info.NodeKey2),
+		},
+	}
+	c.channels[info.ChannelID] = channel
+
+	for i, pub := range []*btcec.PublicKey{info.NodeKey1, info.NodeKey2} {
+		v := channel.nodes[i]
+		if _, ok := c.nodes[v]; !ok {
+			c.nodes[v] = &channeldb.LightningNode{
+				PubKey: pub,
+			}
+		}
+
+		c.adjacency[v] = insertChanID(c.adjacency[v], info.ChannelID)
+	}
+}
+
+// updatePolicy applies the passed routing policy to the direction of the
+// channel it belongs to. Policies for unknown channels are ignored.
+func (c *graphCache) updatePolicy(policy *channeldb.ChannelEdgePolicy) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	channel, ok := c.channels[policy.ChannelID]
+	if !ok {
+		return
+	}
+
+	// The node the policy points to is filled in each time the policy is
+	// handed out, so we store a copy without it.
+	policyCopy := *policy
+	policyCopy.Node = nil
+
+	direction := policy.Flags & lnwire.ChanUpdateDirection
+	channel.policies[direction] = &policyCopy
+}
+
+// removeChannel removes the target channel from the cache.
+func (c *graphCache) removeChannel(chanID uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	channel, ok := c.channels[chanID]
+	if !ok {
+		return
+	}
+
+	for _, v := range channel.nodes {
+		chanIDs := removeChanID(c.adjacency[v], chanID)
+		if len(chanIDs) == 0 {
+			delete(c.adjacency, v)
+			continue
+		}
+		c.adjacency[v] = chanIDs
+	}
+	delete(c.channels, chanID)
+}
+
+// removeNode removes the target node from the cache. The node must no longer
+// be a party to any channel within the cache.
+func (c *graphCache) removeNode(pub *btcec.PublicKey) {
+	c.mtx.Lock()
+	delete(c.nodes, newVertex(pub))
+	c.mtx.Unlock()
+}
+
+// hasNode returns true if the target node is known to the cache.
+func (c *graphCache) hasNode(pub *btcec.PublicKey) bool {
+	c.mtx.RLock()
+	_, ok := c.nodes[newVertex(pub)]
+	c.mtx.RUnlock()
+
+	return ok
+}
+
+// fetchNode returns a copy of the target node. If the node isn't known, then
+// channeldb.ErrGraphNodeNotFound is returned.
+func (c *graphCache) fetchNode(
+	pub *btcec.PublicKey) (*channeldb.LightningNode, error) {
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	node, ok := c.nodes[newVertex(pub)]
+	if !ok {
+		return nil, channeldb.ErrGraphNodeNotFound
+	}
+
+	return copyNode(node), nil
+}
+
+// forEachNode executes the passed callback with each node known to the cache.
+// If the callback returns an error, then the iteration is halted with the
+// error propagated back up to the caller.
+//
+// NOTE: The callback must not call back into the cache.
+func (c *graphCache) forEachNode(cb func(*channeldb.LightningNode) error) error {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for _, node := range c.nodes {
+		if err := cb(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachChannel executes the passed callback with each channel of the target
+// node for which the node has advertised a routing policy, mirroring
+// LightningNode.ForEachChannel. The first policy is the outgoing edge *to* the
+// connecting node, while the second is the incoming edge *from* the connecting
+// node, which may be nil. If the callback returns an error, then the iteration
+// is halted with the error propagated back up to the caller.
+//
+// NOTE: The callback must not call back into the cache.
+func (c *graphCache) forEachChannel(pub *btcec.PublicKey,
+	cb func(*channeldb.ChannelEdgeInfo, *channeldb.ChannelEdgePolicy,
+		*channeldb.ChannelEdgePolicy) error) error {
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	self := newVertex(pub)
+	for _, chanID := range c.adjacency[self] {
+		channel := c.channels[chanID]
+
+		// Determine which end of the channel the target node is on, so
+		// we can tell the outgoing policy from the incoming one.
+		var selfIndex int
+		if channel.nodes[1] == self {
+			selfIndex = 1
+		}
+		peerIndex := 1 - selfIndex
+
+		outPolicy := channel.policies[selfIndex]
+		if outPolicy == nil {
+			continue
+		}
+
+		// As with the graph within the database, the outgoing policy
+		// points to the connecting node, and the incoming policy
+		// points back to the target node.
+		peer := c.nodes[channel.nodes[peerIndex]]
+		outEdge := *outPolicy
+		outEdge.Node = copyNode(peer)
+
+		var inEdge *channeldb.ChannelEdgePolicy
+		if inPolicy := channel.policies[peerIndex]; inPolicy != nil {
+			inEdgeCopy := *inPolicy
+			inEdgeCopy.Node = copyNode(c.nodes[self])
+			inEdge = &inEdgeCopy
+		}
+
+		if err := cb(channel.info, &outEdge, inEdge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyNode returns a copy of the passed node, including its public key. Path
+// finding strips the curve parameters from the public keys of the nodes
+// within a route, so each route must be given its own copies.
+func copyNode(node *channeldb.LightningNode) *channeldb.LightningNode {
+	nodeCopy := *node
+	nodeCopy.PubKey = &btcec.PublicKey{
+		Curve: btcec.S256(),
+		X:     node.PubKey.X,
+		Y:     node.PubKey.Y,
+	}
+
+	return &nodeCopy
+}
+
+// insertChanID inserts the channel ID into the passed sorted slice of channel
+// IDs, returning the updated slice.
+func insertChanID(chanIDs []uint64, chanID uint64) []uint64 {
+	i := sort.Search(len(chanIDs), func(i int) bool {
+		return chanIDs[i] >= chanID
+	})
+	if i < len(chanIDs) && chanIDs[i] == chanID {
+		return chanIDs
+	}
+
+	chanIDs = append(chanIDs, 0)
+	copy(chanIDs[i+1:], chanIDs[i:])
+	chanIDs[i] = chanID
+
+	return chanIDs
+}
+
+// removeChanID removes the channel ID from the passed sorted slice of channel
+// IDs, returning the updated slice.
+func removeChanID(chanIDs []uint64, chanID uint64) []uint64 {
+	i := sort.Search(len(chanIDs), func(i int) bool {
+		return chanIDs[i] >= chanID
+	})
+	if i == len(chanIDs) || chanIDs[i] != chanID {
+		return chanIDs
+	}
+
+	return append(chanIDs[:i], chanIDs[i+1:]...)
+}
@@ -0,0 +1,234 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// edgeSummary is a summary of a channel handed to the callback of
+// ForEachChannel, used to compare the graph cache against the database.
+type edgeSummary struct {
+	chanID       uint64
+	peer         vertex
+	hasInEdge    bool
+	outTimeLock  uint16
+	inTimeLock   uint16
+	edgeCapacity int64
+}
+
+func summarizeEdge(info *channeldb.ChannelEdgeInfo,
+	outEdge, inEdge *channeldb.ChannelEdgePolicy) edgeSummary {
+
+	edge := edgeSummary{
+		chanID:       info.ChannelID,
+		peer:         newVertex(outEdge.Node.PubKey),
+		outTimeLock:  outEdge.TimeLockDelta,
+		edgeCapacity: int64(info.Capacity),
+	}
+	if inEdge != nil {
+		edge.hasInEdge = true
+		edge.inTimeLock = inEdge.TimeLockDelta
+	}
+
+	return edge
+}
+
+// TestGraphCacheMatchesGraph checks that a graph cache populated from the
+// database yields the same nodes and channels as the database itself.
+func TestGraphCacheMatchesGraph(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, _, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	var numNodes int
+	err = graph.ForEachNode(nil, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		numNodes++
+		if !graphCache.hasNode(node.PubKey) {
+			t.Fatalf("node %x missing from cache",
+				node.PubKey.SerializeCompressed())
+		}
+
+		var dbEdges, cacheEdges []edgeSummary
+		err := node.ForEachChannel(nil, func(_ *bolt.Tx,
+			info *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			dbEdges = append(dbEdges,
+				summarizeEdge(info, outEdge, inEdge))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = graphCache.forEachChannel(node.PubKey, func(
+			info *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			if inEdge != nil && !inEdge.Node.PubKey.IsEqual(node.PubKey) {
+				t.Fatalf("incoming edge should point to %x",
+					node.PubKey.SerializeCompressed())
+			}
+
+			cacheEdges = append(cacheEdges,
+				summarizeEdge(info, outEdge, inEdge))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(dbEdges) != len(cacheEdges) {
+			t.Fatalf("node %x: expected %v channels, cache has %v",
+				node.PubKey.SerializeCompressed(),
+				len(dbEdges), len(cacheEdges))
+		}
+		for i := range dbEdges {
+			if dbEdges[i] != cacheEdges[i] {
+				t.Fatalf("node %x: channel mismatch, expected "+
+					"%v, got %v",
+					node.PubKey.SerializeCompressed(),
+					dbEdges[i], cacheEdges[i])
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate nodes: %v", err)
+	}
+
+	var numCachedNodes int
+	graphCache.forEachNode(func(*channeldb.LightningNode) error {
+		numCachedNodes++
+		return nil
+	})
+	if numNodes != numCachedNodes {
+		t.Fatalf("expected %v nodes, cache has %v", numNodes,
+			numCachedNodes)
+	}
+}
+
+// TestGraphCacheUpdates checks that channels and policies added to the graph
+// cache become visible to path finding, and that removed channels disappear.
+func TestGraphCacheUpdates(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, err := makeTestGraph()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	node1, err := createTestNode()
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	node2, err := createTestNode()
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+
+	// Adding a channel should make both of its nodes known, even though
+	// neither has been announced.
+	const chanID = 12345
+	graphCache.addChannel(&channeldb.ChannelEdgeInfo{
+		ChannelID: chanID,
+		NodeKey1:  node1.PubKey,
+		NodeKey2:  node2.PubKey,
+		Capacity:  1000,
+	})
+	if !graphCache.hasNode(node1.PubKey) || !graphCache.hasNode(node2.PubKey) {
+		t.Fatalf("nodes of channel should be known")
+	}
+
+	countChannels := func(node *channeldb.LightningNode) (int, bool) {
+		var (
+			numChannels int
+			hasInEdge   bool
+		)
+		err := graphCache.forEachChannel(node.PubKey, func(
+			_ *channeldb.ChannelEdgeInfo,
+			_, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			numChannels++
+			hasInEdge = inEdge != nil
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to iterate channels: %v", err)
+		}
+
+		return numChannels, hasInEdge
+	}
+
+	// Without any policies, the channel can't be traversed yet.
+	if n, _ := countChannels(node1); n != 0 {
+		t.Fatalf("expected no channels, found %v", n)
+	}
+
+	// Once the first node advertises its policy, it may route over the
+	// channel, while the second node still can't.
+	graphCache.updatePolicy(&channeldb.ChannelEdgePolicy{
+		ChannelID:     chanID,
+		Flags:         0,
+		TimeLockDelta: 10,
+	})
+	if n, hasInEdge := countChannels(node1); n != 1 || hasInEdge {
+		t.Fatalf("expected one channel without incoming edge, found "+
+			"%v channels, incoming edge: %v", n, hasInEdge)
+	}
+	if n, _ := countChannels(node2); n != 0 {
+		t.Fatalf("expected no channels, found %v", n)
+	}
+
+	graphCache.updatePolicy(&channeldb.ChannelEdgePolicy{
+		ChannelID:     chanID,
+		Flags:         lnwire.ChanUpdateDirection,
+		TimeLockDelta: 20,
+	})
+	if n, hasInEdge := countChannels(node1); n != 1 || !hasInEdge {
+		t.Fatalf("expected one channel with incoming edge, found "+
+			"%v channels, incoming edge: %v", n, hasInEdge)
+	}
+
+	// Announcing a node should replace its prior version.
+	graphCache.addNode(node1)
+	cachedNode, err := graphCache.fetchNode(node1.PubKey)
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	if cachedNode.Alias != node1.Alias {
+		t.Fatalf("expected alias %v, got %v", node1.Alias,
+			cachedNode.Alias)
+	}
+
+	// Finally, once the channel is removed, it can't be traversed
+	// anymore.
+	graphCache.removeChannel(chanID)
+	if n, _ := countChannels(node1); n != 0 {
+		t.Fatalf("expected no channels, found %v", n)
+	}
+	if n, _ := countChannels(node2); n != 0 {
+		t.Fatalf("expected no channels, found %v", n)
+	}
+}
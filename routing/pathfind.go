@@ -7,7 +7,6 @@ import (
 
 	"container/heap"
 
-	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -159,6 +158,29 @@ func (r *Route) ToHopPayloads() []sphinx.HopData {
 	return hopPayloads
 }
 
+// containsChannel returns true if the route traverses the target channel.
+func (r *Route) containsChannel(chanID uint64) bool {
+	for _, hop := range r.Hops {
+		if hop.Channel.ChannelID == chanID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsNode returns true if the route passes through, or ends at, the
+// target node. The source node of the route isn't taken into account.
+func (r *Route) containsNode(pub *btcec.PublicKey) bool {
+	for _, hop := range r.Hops {
+		if hop.Channel.Node.PubKey.IsEqual(pub) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // sortableRoutes is a slice of routes that can be sorted. Routes are typically
 // sorted according to their total cumulative fee within the route. In the case
 // that two routes require and identical amount of fees, then the total
@@ -197,12 +219,14 @@ func (s sortableRoutes) Swap(i, j int) {
 // newRoute returns a fully valid route between the source and target that's
 // capable of supporting a payment of `amtToSend` after fees are fully
 // computed. If the route is too long, or the selected path cannot support the
-// fully payment including fees, then a non-nil error is returned.
+// fully payment including fees, then a non-nil error is returned. The time
+// lock of the final hop is set finalCLTVDelta blocks past the current height,
+// or if finalCLTVDelta is zero, by the time lock delta of the final edge.
 //
 // NOTE: The passed slice of ChannelHops MUST be sorted in forward order: from
 // the source to the target node of the path finding attempt.
 func newRoute(amtToSend lnwire.MilliSatoshi, pathEdges []*ChannelHop,
-	currentHeight uint32) (*Route, error) {
+	currentHeight uint32, finalCLTVDelta uint16) (*Route, error) {
 
 	// First, we'll create a new empty route with enough hops to match the
 	// amount of path edges. We set the TotalTimeLock to the current block
@@ -270,14 +294,19 @@ func newRoute(amtToSend lnwire.MilliSatoshi, pathEdges []*ChannelHop,
 
 		// Next, increment the total timelock of the entire route such
 		// that each hops time lock increases as we walk backwards in
-		// the route, using the delta of the previous hop.
-		route.TotalTimeLock += uint32(edge.TimeLockDelta)
+		// the route, using the delta of the previous hop. The final
+		// hop uses the requested final delta instead, if any.
+		timeLockDelta := edge.TimeLockDelta
+		if i == len(pathEdges)-1 && finalCLTVDelta != 0 {
+			timeLockDelta = finalCLTVDelta
+		}
+		route.TotalTimeLock += uint32(timeLockDelta)
 
 		// If this is the last hop, then for verification purposes, the
 		// value of the outgoing time-lock should be _exactly_ the
 		// absolute time out they'd expect in the HTLC.
 		if i == len(pathEdges)-1 {
-			nextHop.OutgoingTimeLock = currentHeight + uint32(edge.TimeLockDelta)
+			nextHop.OutgoingTimeLock = currentHeight + uint32(timeLockDelta)
 		} else {
 			// Otherwise, the value of the outgoing time-lock will
 			// be the value of the time-lock for the _outgoing_
@@ -308,6 +337,35 @@ func newVertex(pub *btcec.PublicKey) vertex {
 	return v
 }
 
+// EdgeLocator identifies a channel within the channel graph, along with the
+// direction in which it's traversed.
+type EdgeLocator struct {
+	// ChannelID is the short channel ID of the channel.
+	ChannelID uint64
+
+	// Direction is 0 if the channel is traversed from the first node of
+	// the channel towards the second, and 1 otherwise. This matches the
+	// direction bit within the flags of a ChannelUpdate.
+	Direction uint8
+}
+
+// newEdgeLocator returns the EdgeLocator of the channel described by the
+// passed policy, in the direction of the node which advertised the policy
+// towards the other end of the channel.
+func newEdgeLocator(policy *channeldb.ChannelEdgePolicy) EdgeLocator {
+	return EdgeLocator{
+		ChannelID: policy.ChannelID,
+		Direction: uint8(policy.Flags & lnwire.ChanUpdateDirection),
+	}
+}
+
+// ignoreChannel adds both directions of the target channel to the passed set
+// of ignored edges.
+func ignoreChannel(ignoredEdges map[EdgeLocator]struct{}, chanID uint64) {
+	ignoredEdges[EdgeLocator{ChannelID: chanID, Direction: 0}] = struct{}{}
+	ignoredEdges[EdgeLocator{ChannelID: chanID, Direction: 1}] = struct{}{}
+}
+
 // edgeWithPrev is a helper struct used in path finding that couples an
 // directional edge with the node's ID in the opposite direction.
 type edgeWithPrev struct {
@@ -333,9 +391,15 @@ func edgeWeight(e *channeldb.ChannelEdgePolicy) float64 {
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
 // from the target to the source.
-func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode,
+//
+// The bandwidth hints map the IDs of the source node's channels to the amount
+// which can currently be sent over each of them. They're consulted in place of
+// the capacity of those channels, and should only be provided if the source
+// node is our own node.
+func findPath(graph *graphCache, sourceNode *channeldb.LightningNode,
 	target *btcec.PublicKey, ignoredNodes map[vertex]struct{},
-	ignoredEdges map[uint64]struct{}, amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+	ignoredEdges map[EdgeLocator]struct{}, amt lnwire.MilliSatoshi,
+	bandwidthHints map[uint64]lnwire.MilliSatoshi) ([]*ChannelHop, error) {
 
 	// First we'll initialize an empty heap which'll help us to quickly
 	// locate the next edge we should visit next during our graph
@@ -346,9 +410,9 @@ func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode
 	// map for the node set with a distance of "infinity".  We also mark
 	// add the node to our set of unvisited nodes.
 	distance := make(map[vertex]nodeWithDist)
-	if err := graph.ForEachNode(nil, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
-		// TODO(roasbeef): with larger graph can just use disk seeks
-		// with a visited map
+	if err := graph.forEachNode(func(node *channeldb.LightningNode) error {
+		// TODO(roasbeef): with larger graph can just use a visited
+		// map
 		distance[newVertex(node.PubKey)] = nodeWithDist{
 			dist: infinity,
 			node: node,
@@ -392,13 +456,18 @@ func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode
 		// examine all the outgoing edge (channels) from this node to
 		// further our graph traversal.
 		pivot := newVertex(bestNode.PubKey)
-		err := bestNode.ForEachChannel(nil, func(tx *bolt.Tx,
+		err := graph.forEachChannel(bestNode.PubKey, func(
 			edgeInfo *channeldb.ChannelEdgeInfo,
 			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
 
 			v := newVertex(outEdge.Node.PubKey)
 
-			// TODO(roasbeef): skip if disabled
+			// If the current node has disabled its direction of
+			// this channel, then it won't forward payments over
+			// it, so we'll skip it.
+			if outEdge.Flags&lnwire.ChanUpdateDisabled != 0 {
+				return nil
+			}
 
 			// If this vertex or edge has been black listed, then
 			// we'll skip exploring this edge during this
@@ -406,13 +475,24 @@ func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode
 			if _, ok := ignoredNodes[v]; ok {
 				return nil
 			}
-			if _, ok := ignoredEdges[outEdge.ChannelID]; ok {
+			if _, ok := ignoredEdges[newEdgeLocator(outEdge)]; ok {
 				return nil
 			}
 			if inEdge == nil {
 				return nil
 			}
 
+			// If this is one of the channels of the source node,
+			// then only its local balance can be sent over it
+			// rather than its entire capacity. Channels without an
+			// active link have no bandwidth at all.
+			if pivot == sourceVertex {
+				bandwidth, ok := bandwidthHints[edgeInfo.ChannelID]
+				if ok && bandwidth < amt {
+					return nil
+				}
+			}
+
 			// Compute the tentative distance to this new
 			// channel/edge which is the distance to our current
 			// pivot node plus the weight of this edge.
@@ -508,6 +588,226 @@ func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode
 	return pathEdges, nil
 }
 
+// RouteRestrictions restricts the set of paths considered when searching for a
+// route to a target destination.
+type RouteRestrictions struct {
+	// OutgoingChannelID, if non-zero, is the channel ID of the channel of
+	// the source node which must be used as the first hop of the path.
+	OutgoingChannelID uint64
+
+	// LastHop, if non-nil, is the node which the path must traverse right
+	// before reaching the target.
+	LastHop *btcec.PublicKey
+
+	// LastChannelID, if non-zero, is the channel ID of the channel between
+	// LastHop and the target which must be used as the final hop of the
+	// path. This is only honored if LastHop is set.
+	LastChannelID uint64
+
+	// IgnoredNodes is the set of nodes the path must not traverse.
+	IgnoredNodes []*btcec.PublicKey
+
+	// IgnoredEdges is the set of directed edges the path must not
+	// traverse.
+	IgnoredEdges []EdgeLocator
+
+	// FeeLimit, if non-zero, is the maximum total fee that a route built
+	// from the path may pay.
+	FeeLimit lnwire.MilliSatoshi
+}
+
+// ignoredSets returns the nodes and edges excluded by the restrictions in the
+// form expected by findPath. The returned maps are fresh, so the caller may
+// freely add to them.
+func (r *RouteRestrictions) ignoredSets() (map[vertex]struct{},
+	map[EdgeLocator]struct{}) {
+
+	ignoredNodes := make(map[vertex]struct{}, len(r.IgnoredNodes))
+	for _, node := range r.IgnoredNodes {
+		ignoredNodes[newVertex(node)] = struct{}{}
+	}
+
+	ignoredEdges := make(map[EdgeLocator]struct{}, len(r.IgnoredEdges))
+	for _, edge := range r.IgnoredEdges {
+		ignoredEdges[edge] = struct{}{}
+	}
+
+	return ignoredNodes, ignoredEdges
+}
+
+// findRestrictedPath attempts to find a path from the source node to the
+// target node that's capable of supporting a payment of `amt` value, while
+// honoring the passed restrictions. The path is built from up to three
+// segments: the mandated first hop, the shortest path found by findPath, and
+// the mandated last hop. As a result, the source and target may be the same
+// node, allowing the caller to route a payment through the network back to
+// itself. Like findPath, the returned slice of ChannelHops is sorted in
+// forward order, and doesn't include a self-hop. The bandwidth hints are only
+// consulted for the channels of the source node.
+func findRestrictedPath(graph *graphCache,
+	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, restrictions *RouteRestrictions,
+	bandwidthHints map[uint64]lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+
+	sourceVertex := newVertex(sourceNode.PubKey)
+	targetVertex := newVertex(target)
+	if sourceVertex == targetVertex &&
+		restrictions.OutgoingChannelID == 0 &&
+		restrictions.LastHop == nil {
+
+		return nil, newErr(ErrNoPathFound, "a path to ourselves "+
+			"requires either an outgoing channel or a last hop")
+	}
+
+	ignoredNodes, ignoredEdges := restrictions.ignoredSets()
+
+	// If an outgoing channel was specified, then we'll locate it amongst
+	// our own channels, and start the search for the remainder of the
+	// path from the node at the other end of it.
+	var (
+		firstHop  *ChannelHop
+		startNode = sourceNode
+	)
+	if restrictions.OutgoingChannelID != 0 {
+		err := graph.forEachChannel(sourceNode.PubKey, func(
+			edgeInfo *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			if edgeInfo.ChannelID != restrictions.OutgoingChannelID {
+				return nil
+			}
+			if inEdge == nil || edgeInfo.Capacity < amt.ToSatoshis() {
+				return nil
+			}
+			bandwidth, ok := bandwidthHints[edgeInfo.ChannelID]
+			if ok && bandwidth < amt {
+				return nil
+			}
+
+			firstHop = &ChannelHop{
+				ChannelEdgePolicy: inEdge,
+				Capacity:          edgeInfo.Capacity,
+			}
+			firstHop.Node = outEdge.Node
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if firstHop == nil {
+			return nil, newErrf(ErrNoPathFound, "outgoing channel "+
+				"%v not found or unable to carry the payment",
+				restrictions.OutgoingChannelID)
+		}
+
+		startNode = firstHop.Node
+		ignoreChannel(ignoredEdges, restrictions.OutgoingChannelID)
+	}
+
+	// Similarly, if a last hop was specified, then we'll locate the
+	// cheapest eligible channel between it and the target, and search for
+	// a path to the last hop rather than the target itself.
+	var (
+		lastHop      *ChannelHop
+		middleTarget = target
+	)
+	if restrictions.LastHop != nil {
+		lastHopNode, err := graph.fetchNode(restrictions.LastHop)
+		if err != nil {
+			return nil, err
+		}
+
+		bestWeight := infinity
+		err = graph.forEachChannel(lastHopNode.PubKey, func(
+			edgeInfo *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			if newVertex(outEdge.Node.PubKey) != targetVertex {
+				return nil
+			}
+			if restrictions.LastChannelID != 0 &&
+				edgeInfo.ChannelID != restrictions.LastChannelID {
+
+				return nil
+			}
+			if _, ok := ignoredEdges[newEdgeLocator(outEdge)]; ok {
+				return nil
+			}
+			if outEdge.Flags&lnwire.ChanUpdateDisabled != 0 {
+				return nil
+			}
+			if inEdge == nil || edgeInfo.Capacity < amt.ToSatoshis() {
+				return nil
+			}
+			if edgeWeight(inEdge) >= bestWeight {
+				return nil
+			}
+
+			bestWeight = edgeWeight(inEdge)
+			lastHop = &ChannelHop{
+				ChannelEdgePolicy: inEdge,
+				Capacity:          edgeInfo.Capacity,
+			}
+			lastHop.Node = outEdge.Node
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if lastHop == nil {
+			return nil, newErrf(ErrNoPathFound, "no eligible channel "+
+				"between last hop %x and destination",
+				restrictions.LastHop.SerializeCompressed())
+		}
+
+		middleTarget = lastHopNode.PubKey
+		ignoredNodes[targetVertex] = struct{}{}
+		ignoreChannel(ignoredEdges, lastHop.ChannelID)
+	}
+
+	// We don't want the middle of the path to loop back through ourselves,
+	// unless we're the node it's meant to reach.
+	if newVertex(middleTarget) != sourceVertex {
+		ignoredNodes[sourceVertex] = struct{}{}
+	}
+
+	// With the ends of the path fixed, we'll now find the shortest path
+	// between them. If the first hop already lands on the middle target,
+	// then there's nothing left to search for.
+	// The bandwidth hints only apply if the search still starts from the
+	// source node itself.
+	var middle []*ChannelHop
+	if newVertex(startNode.PubKey) != newVertex(middleTarget) {
+		middleHints := bandwidthHints
+		if firstHop != nil {
+			middleHints = nil
+		}
+
+		var err error
+		middle, err = findPath(graph, startNode, middleTarget,
+			ignoredNodes, ignoredEdges, amt, middleHints)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pathEdges := make([]*ChannelHop, 0, len(middle)+2)
+	if firstHop != nil {
+		pathEdges = append(pathEdges, firstHop)
+	}
+	pathEdges = append(pathEdges, middle...)
+	if lastHop != nil {
+		pathEdges = append(pathEdges, lastHop)
+	}
+
+	if len(pathEdges) > HopLimit {
+		return nil, newErr(ErrMaxHopsExceeded, "potential path has "+
+			"too many hops")
+	}
+
+	return pathEdges, nil
+}
+
 // findPaths implements a k-shortest paths algorithm to find all the reachable
 // paths between the passed source and target. The algorithm will continue to
 // traverse the graph until all possible candidate paths have been depleted.
@@ -518,12 +818,16 @@ func findPath(graph *channeldb.ChannelGraph, sourceNode *channeldb.LightningNode
 // will be ignored by our modified Dijkstra's algorithm. With this approach, we
 // make our inner path finding algorithm aware of our k-shortest paths
 // algorithm, rather than attempting to use an unmodified path finding
-// algorithm in a block box manner.
-func findPaths(graph *channeldb.ChannelGraph, source *channeldb.LightningNode,
-	target *btcec.PublicKey, amt lnwire.MilliSatoshi) ([][]*ChannelHop, error) {
+// algorithm in a block box manner. The nodes and edges ignored by the passed
+// restrictions are excluded from every path found, while restrictions on the
+// first and last hop aren't supported. As with findPath, the bandwidth hints
+// only apply to the channels of the source node.
+func findPaths(graph *graphCache, source *channeldb.LightningNode,
+	target *btcec.PublicKey, restrictions *RouteRestrictions,
+	amt lnwire.MilliSatoshi,
+	bandwidthHints map[uint64]lnwire.MilliSatoshi) ([][]*ChannelHop, error) {
 
-	ignoredEdges := make(map[uint64]struct{})
-	ignoredVertexes := make(map[vertex]struct{})
+	ignoredVertexes, ignoredEdges := restrictions.ignoredSets()
 
 	// TODO(roasbeef): modifying ordering within heap to eliminate final
 	// sorting step?
@@ -536,7 +840,7 @@ func findPaths(graph *channeldb.ChannelGraph, source *channeldb.LightningNode,
 	// selfNode) to the target destination that's capable of carrying amt
 	// satoshis along the path before fees are calculated.
 	startingPath, err := findPath(graph, source, target,
-		ignoredVertexes, ignoredEdges, amt)
+		ignoredVertexes, ignoredEdges, amt, bandwidthHints)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
 		return nil, err
@@ -569,9 +873,9 @@ func findPaths(graph *channeldb.ChannelGraph, source *channeldb.LightningNode,
 			// These two maps will mark the edges and vertexes
 			// we'll exclude from the next path finding attempt.
 			// These are required to ensure the paths are unique
-			// and loopless.
-			ignoredEdges = make(map[uint64]struct{})
-			ignoredVertexes = make(map[vertex]struct{})
+			// and loopless. They start out with the nodes and
+			// edges excluded by the caller.
+			ignoredVertexes, ignoredEdges = restrictions.ignoredSets()
 
 			// Our spur node is the i-th node in the prior shortest
 			// path, and our root path will be all nodes in the
@@ -588,7 +892,7 @@ func findPaths(graph *channeldb.ChannelGraph, source *channeldb.LightningNode,
 				// directly _after_ our spur node from the
 				// graph so we don't repeat paths.
 				if len(path) > i+1 && isSamePath(rootPath, path[:i+1]) {
-					ignoredEdges[path[i+1].ChannelID] = struct{}{}
+					ignoreChannel(ignoredEdges, path[i+1].ChannelID)
 				}
 			}
 
@@ -607,8 +911,14 @@ func findPaths(graph *channeldb.ChannelGraph, source *channeldb.LightningNode,
 			// the vertexes (other than the spur path) within the
 			// root path removed, we'll attempt to find another
 			// shortest path from the spur node to the destination.
+			// Only a spur from the source itself may use the
+			// bandwidth hints.
+			var spurHints map[uint64]lnwire.MilliSatoshi
+			if i == 0 {
+				spurHints = bandwidthHints
+			}
 			spurPath, err := findPath(graph, spurNode, target,
-				ignoredVertexes, ignoredEdges, amt)
+				ignoredVertexes, ignoredEdges, amt, spurHints)
 
 			// If we weren't able to find a path, we'll continue to
 			// the next round.
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
@@ -303,8 +304,12 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch source node: %v", err)
 	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
 
-	ignoredEdges := make(map[uint64]struct{})
+	ignoredEdges := make(map[EdgeLocator]struct{})
 	ignoredVertexes := make(map[vertex]struct{})
 
 	// With the test graph loaded, we'll test some basic path finding using
@@ -315,12 +320,12 @@ func TestBasicGraphPathFinding(t *testing.T) {
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["sophon"]
-	path, err := findPath(graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
-	route, err := newRoute(paymentAmt, path, startingHeight)
+	route, err := newRoute(paymentAmt, path, startingHeight, 0)
 	if err != nil {
 		t.Fatalf("unable to create path: %v", err)
 	}
@@ -411,12 +416,12 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// exist two possible paths in the graph, but the shorter (1 hop) path
 	// should be selected.
 	target = aliases["luoji"]
-	path, err = findPath(graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err = findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
-	route, err = newRoute(paymentAmt, path, startingHeight)
+	route, err = newRoute(paymentAmt, path, startingHeight, 0)
 	if err != nil {
 		t.Fatalf("unable to create path: %v", err)
 	}
@@ -457,6 +462,10 @@ func TestKShortestPathFinding(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch source node: %v", err)
 	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
 
 	// In this test we'd like to ensure that our algoirthm to find the
 	// k-shortest paths from a given source node to any destination node
@@ -468,7 +477,8 @@ func TestKShortestPathFinding(t *testing.T) {
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["luoji"]
-	paths, err := findPaths(graph, sourceNode, target, paymentAmt)
+	paths, err := findPaths(graphCache, sourceNode, target, &RouteRestrictions{},
+		paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find paths between roasbeef and "+
 			"luo ji: %v", err)
@@ -520,8 +530,12 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch source node: %v", err)
 	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
 
-	ignoredEdges := make(map[uint64]struct{})
+	ignoredEdges := make(map[EdgeLocator]struct{})
 	ignoredVertexes := make(map[vertex]struct{})
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
@@ -529,8 +543,8 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// We start by confirminig that routing a payment 20 hops away is possible.
 	// Alice should be able to find a valid route to ursula.
 	target := aliases["ursula"]
-	_, err = findPath(graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	_, err = findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("path should have been found")
 	}
@@ -538,8 +552,8 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// Vincent is 21 hops away from Alice, and thus no valid route should be
 	// presented to Alice.
 	target = aliases["vincent"]
-	path, err := findPath(graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
 	if err == nil {
 		t.Fatalf("should not have been able to find path, supposed to be "+
 			"greater than 20 hops, found route with %v hops",
@@ -561,8 +575,12 @@ func TestPathNotAvailable(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch source node: %v", err)
 	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
 
-	ignoredEdges := make(map[uint64]struct{})
+	ignoredEdges := make(map[EdgeLocator]struct{})
 	ignoredVertexes := make(map[vertex]struct{})
 
 	// With the test graph loaded, we'll test that queries for target that
@@ -578,13 +596,189 @@ func TestPathNotAvailable(t *testing.T) {
 		t.Fatalf("unable to parse pubkey: %v", err)
 	}
 
-	_, err = findPath(graph, sourceNode, unknownNode, ignoredVertexes,
-		ignoredEdges, 100)
+	_, err = findPath(graphCache, sourceNode, unknownNode, ignoredVertexes,
+		ignoredEdges, 100, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("path shouldn't have been found: %v", err)
 	}
 }
 
+// TestPathDisabledEdge tests that path finding skips any channel whose
+// outgoing edge has been disabled by the node that controls it.
+func TestPathDisabledEdge(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	ignoredEdges := make(map[EdgeLocator]struct{})
+	ignoredVertexes := make(map[vertex]struct{})
+
+	// Initially, sophon should be reachable through songoku.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+	_, err = findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+
+	// Next, we'll disable songoku's direction of its channel with sophon,
+	// which is the only channel sophon has.
+	songoku, err := graph.FetchLightningNode(aliases["songoku"])
+	if err != nil {
+		t.Fatalf("unable to fetch songoku: %v", err)
+	}
+	var disabledEdge *channeldb.ChannelEdgePolicy
+	err = songoku.ForEachChannel(nil, func(_ *bolt.Tx,
+		_ *channeldb.ChannelEdgeInfo,
+		outEdge, _ *channeldb.ChannelEdgePolicy) error {
+
+		if outEdge.Node.PubKey.IsEqual(target) {
+			disabledEdge = outEdge
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate songoku's channels: %v", err)
+	}
+	if disabledEdge == nil {
+		t.Fatal("unable to find channel between songoku and sophon")
+	}
+
+	disabledEdge.Flags |= lnwire.ChanUpdateDisabled
+	if err := graph.UpdateEdgePolicy(disabledEdge); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+	graphCache.updatePolicy(disabledEdge)
+
+	// With the edge disabled, sophon should no longer be reachable.
+	_, err = findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("path shouldn't have been found: %v", err)
+	}
+}
+
+func TestRestrictedPathFinding(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	const (
+		roasbeefLuoji   = 689530843
+		roasbeefSatoshi = 2340213491
+	)
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+
+	tests := []struct {
+		name         string
+		target       string
+		restrictions *RouteRestrictions
+		expectedHops []string
+	}{
+		{
+			// Without restrictions, satoshi would be reached
+			// directly, so we force the path through luo ji.
+			name:   "outgoing channel",
+			target: "satoshi",
+			restrictions: &RouteRestrictions{
+				OutgoingChannelID: roasbeefLuoji,
+			},
+			expectedHops: []string{"luoji", "satoshi"},
+		},
+		{
+			name:   "last hop",
+			target: "satoshi",
+			restrictions: &RouteRestrictions{
+				LastHop: aliases["luoji"],
+			},
+			expectedHops: []string{"luoji", "satoshi"},
+		},
+		{
+			// The path back to ourselves mustn't return over the
+			// outgoing channel itself.
+			name:   "cycle with outgoing channel",
+			target: "roasbeef",
+			restrictions: &RouteRestrictions{
+				OutgoingChannelID: roasbeefSatoshi,
+			},
+			expectedHops: []string{"satoshi", "luoji", "roasbeef"},
+		},
+		{
+			name:   "cycle with outgoing channel and last hop",
+			target: "roasbeef",
+			restrictions: &RouteRestrictions{
+				OutgoingChannelID: roasbeefLuoji,
+				LastHop:           aliases["satoshi"],
+			},
+			expectedHops: []string{"luoji", "satoshi", "roasbeef"},
+		},
+	}
+
+	for _, test := range tests {
+		path, err := findRestrictedPath(graphCache, sourceNode,
+			aliases[test.target], paymentAmt, test.restrictions,
+			nil)
+		if err != nil {
+			t.Fatalf("%v: unable to find path: %v", test.name, err)
+		}
+
+		if len(path) != len(test.expectedHops) {
+			t.Fatalf("%v: path should have %v hops, instead has %v",
+				test.name, len(test.expectedHops), len(path))
+		}
+		for i, hop := range path {
+			expected := aliases[test.expectedHops[i]]
+			if !hop.Node.PubKey.IsEqual(expected) {
+				t.Fatalf("%v: hop %v should be %v", test.name, i,
+					test.expectedHops[i])
+			}
+		}
+
+		if test.restrictions.OutgoingChannelID != 0 &&
+			path[0].ChannelID != test.restrictions.OutgoingChannelID {
+
+			t.Fatalf("%v: first hop should use channel %v, "+
+				"instead uses %v", test.name,
+				test.restrictions.OutgoingChannelID,
+				path[0].ChannelID)
+		}
+	}
+
+	// A path to ourselves without any restrictions should be rejected.
+	_, err = findRestrictedPath(graphCache, sourceNode, sourceNode.PubKey,
+		paymentAmt, &RouteRestrictions{}, nil)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("path to self shouldn't have been found: %v", err)
+	}
+}
+
 func TestPathInsufficientCapacity(t *testing.T) {
 	t.Parallel()
 
@@ -598,7 +792,11 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch source node: %v", err)
 	}
-	ignoredEdges := make(map[uint64]struct{})
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+	ignoredEdges := make(map[EdgeLocator]struct{})
 	ignoredVertexes := make(map[vertex]struct{})
 
 	// Next, test that attempting to find a path in which the current
@@ -612,13 +810,104 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	target := aliases["sophon"]
 
 	const payAmt = btcutil.SatoshiPerBitcoin
-	_, err = findPath(graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(graphCache, sourceNode, target, ignoredVertexes,
+		ignoredEdges, payAmt, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
 }
 
+// TestPathBandwidthHints tests that path finding consults the bandwidth hints
+// of the source node's channels in place of their capacity.
+func TestPathBandwidthHints(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	graphCache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	const (
+		roasbeefLuoji   = 689530843
+		roasbeefSatoshi = 2340213491
+	)
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+
+	tests := []struct {
+		name           string
+		bandwidthHints map[uint64]lnwire.MilliSatoshi
+		expectedHops   []string
+	}{
+		{
+			name:         "no hints",
+			expectedHops: []string{"satoshi"},
+		},
+		{
+			name: "sufficient bandwidth",
+			bandwidthHints: map[uint64]lnwire.MilliSatoshi{
+				roasbeefSatoshi: paymentAmt,
+			},
+			expectedHops: []string{"satoshi"},
+		},
+		{
+			// Although the direct channel to satoshi has plenty of
+			// capacity, its local balance can't carry the payment,
+			// so the path detours through luo ji.
+			name: "insufficient bandwidth",
+			bandwidthHints: map[uint64]lnwire.MilliSatoshi{
+				roasbeefSatoshi: paymentAmt - 1,
+				roasbeefLuoji:   paymentAmt,
+			},
+			expectedHops: []string{"luoji", "satoshi"},
+		},
+		{
+			name: "inactive links",
+			bandwidthHints: map[uint64]lnwire.MilliSatoshi{
+				roasbeefSatoshi: 0,
+				roasbeefLuoji:   0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		path, err := findPath(graphCache, sourceNode, aliases["satoshi"],
+			make(map[vertex]struct{}), make(map[EdgeLocator]struct{}),
+			paymentAmt, test.bandwidthHints)
+		if test.expectedHops == nil {
+			if !IsError(err, ErrNoPathFound) {
+				t.Fatalf("%v: path shouldn't have been found: %v",
+					test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: unable to find path: %v", test.name, err)
+		}
+
+		if len(path) != len(test.expectedHops) {
+			t.Fatalf("%v: path should have %v hops, instead has %v",
+				test.name, len(test.expectedHops), len(path))
+		}
+		for i, hop := range path {
+			expected := aliases[test.expectedHops[i]]
+			if !hop.Node.PubKey.IsEqual(expected) {
+				t.Fatalf("%v: hop %v should be %v", test.name, i,
+					test.expectedHops[i])
+			}
+		}
+	}
+}
+
 func TestPathInsufficientCapacityWithFee(t *testing.T) {
 	t.Parallel()
 
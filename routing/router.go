@@ -6,10 +6,12 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/chainview"
@@ -60,6 +62,17 @@ type ChannelGraphSource interface {
 	GetChannelByID(chanID lnwire.ShortChannelID) (*channeldb.ChannelEdgeInfo,
 		*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy, error)
 
+	// GetZombieChannel returns the channel with the passed channel id if
+	// it has been pruned from the graph as a zombie. A fresh update for
+	// such a channel resurrects it.
+	GetZombieChannel(chanID lnwire.ShortChannelID) (
+		*channeldb.ChannelEdgeInfo, error)
+
+	// FetchLightningNode attempts to look up a target node by its
+	// identity public key. channeldb.ErrGraphNodeNotFound is returned if
+	// the node doesn't exist within the graph.
+	FetchLightningNode(*btcec.PublicKey) (*channeldb.LightningNode, error)
+
 	// ForEachNode is used to iterate over every node in the known graph.
 	ForEachNode(func(node *channeldb.LightningNode) error) error
 
@@ -105,10 +118,34 @@ type Config struct {
 
 	// SendToSwitch is a function that directs a link-layer switch to
 	// forward a fully encoded payment to the first hop in the route
-	// denoted by its public key. A non-nil error is to be returned if the
-	// payment was unsuccessful.
-	SendToSwitch func(firstHop *btcec.PublicKey, htlcAdd *lnwire.UpdateAddHTLC,
+	// denoted by its public key. If the outgoing channel is non-zero, then
+	// the payment MUST be sent over that particular channel with the first
+	// hop. A non-nil error is to be returned if the payment was
+	// unsuccessful.
+	SendToSwitch func(firstHop *btcec.PublicKey,
+		outgoingChan lnwire.ShortChannelID, htlcAdd *lnwire.UpdateAddHTLC,
 		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
+
+	// QueryBandwidth is a function that returns the amount which can
+	// currently be sent over one of our own channels, as reported by its
+	// link within the switch. If the channel doesn't have an active link,
+	// then zero is to be returned. When finding routes from our own node,
+	// this is used in place of the capacity of our channels, so we don't
+	// pick a first hop which is unable to carry the payment. If nil, then
+	// the capacity of our channels is used as is.
+	QueryBandwidth func(edge *channeldb.ChannelEdgeInfo) lnwire.MilliSatoshi
+
+	// ChannelPruneExpiry is the duration used to determine if a channel
+	// should be pruned from the graph as a zombie. If neither of the
+	// nodes of a channel has sent out an update within this duration,
+	// then the channel is pruned. Our own channels are never pruned. A
+	// value of zero disables zombie pruning.
+	ChannelPruneExpiry time.Duration
+
+	// GraphPruneInterval is the interval at which the graph is scanned
+	// for zombie channels, and nodes which are no longer a party to any
+	// channel.
+	GraphPruneInterval time.Duration
 }
 
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
@@ -154,12 +191,18 @@ type ChannelRouter struct {
 	// when doing any path finding.
 	selfNode *channeldb.LightningNode
 
+	// graphCache is an in-memory view of the channel graph used for path
+	// finding. It's updated alongside the channel graph within the
+	// database each time the graph is modified.
+	graphCache *graphCache
+
 	// routeCache is a map that caches the k-shortest paths from ourselves
 	// to a given target destination for a particular payment amount. This
 	// map is used as an optimization to speed up subsequent payments to a
-	// particular destination. This map will be cleared each time a new
-	// channel announcement is accepted, or a new block arrives that
-	// results in channels being closed.
+	// particular destination. Cached routes are evicted when the policy of
+	// one of the channels they traverse changes, and the map is cleared
+	// each time a new block arrives, as that invalidates the time locks
+	// within each route.
 	//
 	// TODO(roasbeef): make LRU
 	routeCacheMtx sync.RWMutex
@@ -205,9 +248,15 @@ func New(cfg Config) (*ChannelRouter, error) {
 		return nil, err
 	}
 
+	graphCache, err := newGraphCache(cfg.Graph)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ChannelRouter{
 		cfg:               &cfg,
 		selfNode:          selfNode,
+		graphCache:        graphCache,
 		networkUpdates:    make(chan *routingMsg),
 		topologyClients:   make(map[uint64]*topologyClient),
 		ntfnClientUpdates: make(chan *topologyClientUpdate),
@@ -359,6 +408,9 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 		if err != nil {
 			return err
 		}
+		for _, closedChan := range closedChans {
+			r.graphCache.removeChannel(closedChan.ChannelID)
+		}
 
 		numClosed := uint32(len(closedChans))
 		log.Infof("Block %v (height=%v) closed %v channels",
@@ -381,7 +433,15 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 func (r *ChannelRouter) networkHandler() {
 	defer r.wg.Done()
 
-	// TODO(roasbeef): ticker to check if should prune in two weeks or not
+	// If zombie pruning is enabled, then we'll periodically scan the graph
+	// for channels which haven't been updated for a long while.
+	var graphPruneTicks <-chan time.Time
+	if r.cfg.ChannelPruneExpiry != 0 {
+		graphPruneTicker := time.NewTicker(r.cfg.GraphPruneInterval)
+		defer graphPruneTicker.Stop()
+
+		graphPruneTicks = graphPruneTicker.C
+	}
 
 	for {
 		select {
@@ -414,9 +474,14 @@ func (r *ChannelRouter) networkHandler() {
 				r.notifyTopologyChange(topChange)
 			}
 
-			// TODO(roasbeef): remove all unconnected vertexes
-			// after N blocks pass with no corresponding
-			// announcements.
+		// The graph prune ticker has ticked, so we'll remove any zombie
+		// channels from the graph, along with the nodes which are left
+		// without channels.
+		case <-graphPruneTicks:
+			if err := r.pruneZombieChans(); err != nil {
+				log.Errorf("unable to prune zombie channels: %v",
+					err)
+			}
 
 		// A new block has arrived, so we can prune the channel graph
 		// of any channels which were closed in the block.
@@ -456,6 +521,9 @@ func (r *ChannelRouter) networkHandler() {
 				log.Errorf("unable to prune routing table: %v", err)
 				continue
 			}
+			for _, closedChan := range chansClosed {
+				r.graphCache.removeChannel(closedChan.ChannelID)
+			}
 
 			log.Infof("Block %v (height=%v) closed %v channels",
 				chainUpdate.Hash, blockHeight, len(chansClosed))
@@ -463,11 +531,6 @@ func (r *ChannelRouter) networkHandler() {
 			// Invalidate the route cache as the block height has
 			// changed which will invalidate the HTLC timeouts we
 			// have crafted within each of the pre-computed routes.
-			//
-			// TODO(roasbeef): need to invalidate after each
-			// chan ann update?
-			//  * can have map of chanID to routes involved, avoids
-			//    full invalidation
 			r.routeCacheMtx.Lock()
 			r.routeCache = make(map[routeTuple][]*Route)
 			r.routeCacheMtx.Unlock()
@@ -520,9 +583,6 @@ func (r *ChannelRouter) networkHandler() {
 // state of the draft due to either being out of date, invalid, or redundant,
 // then error is returned.
 func (r *ChannelRouter) processUpdate(msg interface{}) error {
-
-	var invalidateCache bool
-
 	switch msg := msg.(type) {
 	case *channeldb.LightningNode:
 		// If we are not already aware of this node, it means that we
@@ -556,6 +616,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			return errors.Errorf("unable to add node %v to the "+
 				"graph: %v", msg.PubKey.SerializeCompressed(), err)
 		}
+		r.graphCache.addNode(msg)
 
 		log.Infof("Updated vertex data for node=%x",
 			msg.PubKey.SerializeCompressed())
@@ -572,6 +633,17 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 				"chan_id=%v", msg.ChannelID)
 		}
 
+		// If the channel has been pruned as a zombie, then we won't
+		// add it back until a fresh update for it arrives.
+		_, err = r.cfg.Graph.FetchZombieEdge(msg.ChannelID)
+		if err == nil {
+			return newErrf(ErrIgnored, "Ignoring announcement for "+
+				"zombie chan_id=%v", msg.ChannelID)
+		} else if err != channeldb.ErrEdgeNotFound {
+			return errors.Errorf("unable to check for zombie "+
+				"edge: %v", err)
+		}
+
 		// Query the database for the existence of the two nodes in this
 		// channel. If not found, add a partial node to the database,
 		// containing only the node keys.
@@ -654,7 +726,9 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			return errors.Errorf("unable to add edge: %v", err)
 		}
 
-		invalidateCache = true
+		// The new channel can't be used for routing until its policies
+		// are known, so the cached routes remain valid for now.
+		r.graphCache.addChannel(msg)
 		log.Infof("New channel discovered! Link "+
 			"connects %x and %x with ChannelPoint(%v): "+
 			"chan_id=%v, capacity=%v",
@@ -688,11 +762,13 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		// the direction of the edge they control. Therefore we first
 		// check if we already have the most up to date information for
 		// that edge. If so, then we can exit early.
-		switch msg.Flags {
+		var prevUpdate time.Time
+		switch msg.Flags & lnwire.ChanUpdateDirection {
 
 		// A flag set of 0 indicates this is an announcement for the
 		// "first" node in the channel.
 		case 0:
+			prevUpdate = edge1Timestamp
 			if edge1Timestamp.After(msg.LastUpdate) ||
 				edge1Timestamp.Equal(msg.LastUpdate) {
 				return newErrf(ErrIgnored, "Ignoring announcement "+
@@ -704,6 +780,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		// Similarly, a flag set of 1 indicates this is an announcement
 		// for the "second" node in the channel.
 		case 1:
+			prevUpdate = edge2Timestamp
 			if edge2Timestamp.After(msg.LastUpdate) ||
 				edge2Timestamp.Equal(msg.LastUpdate) {
 
@@ -713,6 +790,17 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			}
 		}
 
+		// If we don't know of the channel, then it may have been
+		// pruned as a zombie. A stale update won't change that, while
+		// a fresh one resurrects the channel.
+		if !exists {
+			resurrected, err := r.resurrectZombieChan(msg)
+			if err != nil {
+				return err
+			}
+			exists = resurrected
+		}
+
 		if !exists {
 			// Before we can update the channel information, we'll
 			// ensure that the target channel is still open by
@@ -740,8 +828,21 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			log.Error(err)
 			return err
 		}
-
-		invalidateCache = true
+		r.graphCache.updatePolicy(msg)
+
+		// The fees and time locks of the cached routes traversing the
+		// channel no longer reflect its policy, so they're evicted.
+		r.invalidateRoutes(msg.ChannelID)
+
+		// If this is the first policy we know of for this direction of
+		// the channel, then it may offer paths the cached routes don't
+		// take into account, so we'll also evict the routes touching
+		// either of its nodes.
+		if prevUpdate.IsZero() {
+			if err := r.invalidateNodeRoutes(msg.ChannelID); err != nil {
+				return err
+			}
+		}
 		log.Infof("New channel update applied: %v",
 			spew.Sdump(msg))
 
@@ -749,18 +850,211 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		return errors.Errorf("wrong routing update message type")
 	}
 
-	// If we've received a channel update, then invalidate the route cache
-	// as channels within the graph have closed, which may affect our
-	// choice of the KSP's for a particular routeTuple.
-	if invalidateCache {
-		r.routeCacheMtx.Lock()
+	return nil
+}
+
+// resurrectZombieChan adds the channel of the passed policy back to the graph
+// if it has been pruned as a zombie, and the policy is fresh. True is returned
+// if the channel has been resurrected. If the channel isn't a zombie, then
+// false is returned, while a stale policy for a zombie channel results in an
+// ErrIgnored error.
+func (r *ChannelRouter) resurrectZombieChan(
+	policy *channeldb.ChannelEdgePolicy) (bool, error) {
+
+	zombie, err := r.cfg.Graph.FetchZombieEdge(policy.ChannelID)
+	if err == channeldb.ErrEdgeNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("unable to check for zombie "+
+			"edge: %v", err)
+	}
+
+	if r.isStaleUpdate(policy.LastUpdate) {
+		return false, newErrf(ErrIgnored, "Ignoring stale update "+
+			"(flags=%v) for zombie chan_id=%v", policy.Flags,
+			policy.ChannelID)
+	}
+
+	// Before adding the channel back, we'll ensure that it hasn't been
+	// closed while it was a zombie.
+	channelID := lnwire.NewShortChanIDFromInt(policy.ChannelID)
+	_, err = r.cfg.Chain.GetUtxo(&zombie.ChannelPoint, channelID.BlockHeight)
+	if err != nil {
+		return false, errors.Errorf("unable to fetch utxo for "+
+			"chan_id=%v: %v", policy.ChannelID, err)
+	}
+
+	if err := r.cfg.Graph.MarkEdgeLive(policy.ChannelID); err != nil {
+		return false, errors.Errorf("unable to mark edge live: %v",
+			err)
+	}
+	if err := r.cfg.Graph.AddChannelEdge(zombie); err != nil {
+		return false, errors.Errorf("unable to add edge: %v", err)
+	}
+	r.graphCache.addChannel(zombie)
+
+	// As with new channels, we'll ensure that we're notified if the
+	// channel is closed.
+	filterUpdate := []wire.OutPoint{zombie.ChannelPoint}
+	err = r.cfg.ChainView.UpdateFilter(filterUpdate, r.bestHeight)
+	if err != nil {
+		return false, errors.Errorf("unable to update chain view: %v",
+			err)
+	}
+
+	log.Infof("Resurrected zombie chan_id=%v after fresh update",
+		policy.ChannelID)
+
+	return true, nil
+}
+
+// isStaleUpdate returns true if the passed update time is beyond the channel
+// prune expiry, meaning that a channel whose policies were last updated at
+// that time is considered a zombie.
+func (r *ChannelRouter) isStaleUpdate(lastUpdate time.Time) bool {
+	return r.cfg.ChannelPruneExpiry != 0 &&
+		time.Since(lastUpdate) >= r.cfg.ChannelPruneExpiry
+}
+
+// isStaleChannel returns true if the channel identified by the passed ID was
+// funded beyond the channel prune expiry, assuming a block is mined every ten
+// minutes. The nodes of a younger channel may not have sent out their updates
+// for it yet.
+func (r *ChannelRouter) isStaleChannel(chanID uint64) bool {
+	if r.cfg.ChannelPruneExpiry == 0 {
+		return false
+	}
+
+	fundingHeight := lnwire.NewShortChanIDFromInt(chanID).BlockHeight
+	expiryBlocks := uint32(r.cfg.ChannelPruneExpiry / (10 * time.Minute))
+
+	return r.bestHeight >= fundingHeight &&
+		r.bestHeight-fundingHeight >= expiryBlocks
+}
+
+// pruneZombieChans removes all channels from the graph for which neither of
+// their nodes has sent out an update within the channel prune expiry. Missing
+// policies only count as stale once the channel was funded beyond the prune
+// expiry. The pruned channels are added to the zombie index,
+// so they aren't added back when their announcements are gossiped to us once
+// more. Afterwards, all nodes left without any channels are removed as well.
+func (r *ChannelRouter) pruneZombieChans() error {
+	var zombieChans []uint64
+	err := r.cfg.Graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		// Our own channels are only removed from the graph once
+		// they're closed.
+		if info.NodeKey1.IsEqual(r.selfNode.PubKey) ||
+			info.NodeKey2.IsEqual(r.selfNode.PubKey) {
+
+			return nil
+		}
+
+		// A channel lacking a policy may simply be too young for its
+		// nodes to have sent out their updates.
+		if (e1 == nil || e2 == nil) &&
+			!r.isStaleChannel(info.ChannelID) {
+
+			return nil
+		}
+
+		if e1 != nil && !r.isStaleUpdate(e1.LastUpdate) {
+			return nil
+		}
+		if e2 != nil && !r.isStaleUpdate(e2.LastUpdate) {
+			return nil
+		}
+
+		zombieChans = append(zombieChans, info.ChannelID)
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return err
+	}
+
+	if len(zombieChans) != 0 {
+		log.Infof("Pruning %v zombie channels from the graph",
+			len(zombieChans))
+
+		zombies, err := r.cfg.Graph.MarkEdgeZombie(zombieChans...)
+		if err != nil {
+			return err
+		}
+		for _, zombie := range zombies {
+			r.graphCache.removeChannel(zombie.ChannelID)
+			r.invalidateRoutes(zombie.ChannelID)
+		}
+	}
+
+	// With the zombie channels pruned, we'll remove all nodes which are
+	// no longer a party to any channel, as they can't be routed through.
+	prunedNodes, err := r.cfg.Graph.PruneGraphNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range prunedNodes {
+		r.graphCache.removeNode(node)
+	}
+
+	if len(prunedNodes) != 0 {
+		log.Infof("Pruned %v nodes without channels from the graph",
+			len(prunedNodes))
+	}
+
+	return nil
+}
+
+// invalidateNodeRoutes evicts all cached routes to any destination which touch
+// either of the nodes of the target channel. As all routes start at our own
+// node, all cached routes are evicted if it's one of our channels.
+func (r *ChannelRouter) invalidateNodeRoutes(chanID uint64) error {
+	info, _, _, err := r.cfg.Graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		return errors.Errorf("unable to fetch chan_id=%v: %v", chanID,
+			err)
+	}
+
+	r.routeCacheMtx.Lock()
+	defer r.routeCacheMtx.Unlock()
+
+	if info.NodeKey1.IsEqual(r.selfNode.PubKey) ||
+		info.NodeKey2.IsEqual(r.selfNode.PubKey) {
+
 		r.routeCache = make(map[routeTuple][]*Route)
-		r.routeCacheMtx.Unlock()
+		return nil
+	}
+
+	for tuple, routes := range r.routeCache {
+		for _, route := range routes {
+			if route.containsNode(info.NodeKey1) ||
+				route.containsNode(info.NodeKey2) {
+
+				delete(r.routeCache, tuple)
+				break
+			}
+		}
 	}
 
 	return nil
 }
 
+// invalidateRoutes evicts all cached routes to any destination which traverse
+// the target channel.
+func (r *ChannelRouter) invalidateRoutes(chanID uint64) {
+	r.routeCacheMtx.Lock()
+	defer r.routeCacheMtx.Unlock()
+
+	for tuple, routes := range r.routeCache {
+		for _, route := range routes {
+			if route.containsChannel(chanID) {
+				delete(r.routeCache, tuple)
+				break
+			}
+		}
+	}
+}
+
 // fetchChanPoint retrieves the original outpoint which is encoded within the
 // channelID.
 //
@@ -814,17 +1108,28 @@ type routingMsg struct {
 // required fee and time lock values running backwards along the route. The
 // route that will be ranked the highest is the one with the lowest cumulative
 // fee along the route.
-func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi) ([]*Route, error) {
+//
+// The search starts from the passed source node, or from our own node if the
+// source is nil. Nodes and edges ignored by the passed restrictions are
+// excluded from all routes, as are routes exceeding the fee limit. If
+// numRoutes is non-zero, then at most that many routes are returned. The
+// final hop's time lock delta is set by finalCLTVDelta, or if zero, by the
+// policy of the final channel.
+func (r *ChannelRouter) FindRoutes(source, target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, restrictions *RouteRestrictions,
+	numRoutes uint32, finalCLTVDelta uint16) ([]*Route, error) {
 
 	dest := target.SerializeCompressed()
 	log.Debugf("Searching for path to %x, sending %v", dest, amt)
 
+	sourceNode, err := r.fetchSourceNode(source)
+	if err != nil {
+		return nil, err
+	}
+
 	// We can short circuit the routing by opportunistically checking to
 	// see if the target vertex event exists in the current graph.
-	if _, exists, err := r.cfg.Graph.HasLightningNode(target); err != nil {
-		return nil, err
-	} else if !exists {
+	if !r.graphCache.hasNode(target) {
 		log.Debugf("Target %x is not in known graph", dest)
 		return nil, newErrf(ErrTargetNotInNetwork, "target not found")
 	}
@@ -838,8 +1143,11 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 
 	// Now that we know the destination is reachable within the graph,
 	// we'll execute our KSP algorithm to find the k-shortest paths from
-	// our source to the destination.
-	shortestPaths, err := findPaths(r.cfg.Graph, r.selfNode, target, amt)
+	// our source to the destination, taking the live bandwidth of our own
+	// channels into account.
+	bandwidthHints := r.bandwidthHints(sourceNode)
+	shortestPaths, err := findPaths(r.graphCache, sourceNode, target,
+		restrictions, amt, bandwidthHints)
 	if err != nil {
 		return nil, err
 	}
@@ -854,11 +1162,26 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 		// Attempt to make the path into a route. We snip off the first
 		// hop in the path as it contains a "self-hop" that is inserted
 		// by our KSP algorithm.
-		route, err := newRoute(amt, path[1:], uint32(currentHeight))
+		route, err := newRoute(amt, path[1:], uint32(currentHeight),
+			finalCLTVDelta)
 		if err != nil {
 			continue
 		}
 
+		// Routes paying more in fees than allowed are discarded.
+		if restrictions.FeeLimit != 0 &&
+			route.TotalFees > restrictions.FeeLimit {
+
+			continue
+		}
+
+		// Path finding only ensured that the first hop can carry the
+		// payment amount itself, so routes where it can't also carry
+		// the fees are discarded as well.
+		if !firstHopHasBandwidth(route, bandwidthHints) {
+			continue
+		}
+
 		// If the path as enough total flow to support the computed
 		// route, then we'll add it to our set of valid routes.
 		validRoutes = append(validRoutes, route)
@@ -876,6 +1199,10 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// a tie-breaker.
 	sort.Sort(validRoutes)
 
+	if numRoutes != 0 && uint32(len(validRoutes)) > numRoutes {
+		validRoutes = validRoutes[:numRoutes]
+	}
+
 	log.Debugf("Obtained %v paths sending %v to %x: %v", len(validRoutes),
 		amt, dest, newLogClosure(func() string {
 			return spew.Sdump(validRoutes)
@@ -885,12 +1212,138 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	return validRoutes, nil
 }
 
+// FindRestrictedRoute attempts to query the ChannelRouter for a single route
+// to a particular target destination which is able to send `amt`, while
+// honoring the passed restrictions on the first and last hop of the route.
+// Unlike FindRoutes, the target may be our own node, in which case the route
+// will be a cycle through the network back to ourselves. The source and
+// finalCLTVDelta are interpreted as they are by FindRoutes.
+func (r *ChannelRouter) FindRestrictedRoute(source, target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, restrictions *RouteRestrictions,
+	finalCLTVDelta uint16) (*Route, error) {
+
+	dest := target.SerializeCompressed()
+	log.Debugf("Searching for restricted path to %x, sending %v: %v",
+		dest, amt, newLogClosure(func() string {
+			return spew.Sdump(restrictions)
+		}),
+	)
+
+	if !r.graphCache.hasNode(target) {
+		log.Debugf("Target %x is not in known graph", dest)
+		return nil, newErrf(ErrTargetNotInNetwork, "target not found")
+	}
+
+	sourceNode, err := r.fetchSourceNode(source)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	bandwidthHints := r.bandwidthHints(sourceNode)
+	path, err := findRestrictedPath(r.graphCache, sourceNode, target, amt,
+		restrictions, bandwidthHints)
+	if err != nil {
+		return nil, err
+	}
+
+	// As the restricted path doesn't contain a self-hop, it can be turned
+	// into a route as is.
+	route, err := newRoute(amt, path, uint32(currentHeight),
+		finalCLTVDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	if restrictions.FeeLimit != 0 && route.TotalFees > restrictions.FeeLimit {
+		return nil, newErrf(ErrNoRouteFound, "route fee of %v exceeds "+
+			"limit of %v", route.TotalFees, restrictions.FeeLimit)
+	}
+
+	if !firstHopHasBandwidth(route, bandwidthHints) {
+		return nil, newErrf(ErrInsufficientCapacity, "first hop is "+
+			"unable to carry %v", route.TotalAmount)
+	}
+
+	return route, nil
+}
+
+// bandwidthHints returns the amount which can currently be sent over each of
+// the channels of the passed source node, keyed by channel ID. As the
+// bandwidth is only known for our own channels, nil is returned if the source
+// is another node, or no means of querying the bandwidth was configured.
+func (r *ChannelRouter) bandwidthHints(
+	sourceNode *channeldb.LightningNode) map[uint64]lnwire.MilliSatoshi {
+
+	if r.cfg.QueryBandwidth == nil || sourceNode != r.selfNode {
+		return nil
+	}
+
+	// We'll first gather our channels, then query their bandwidth once
+	// we're no longer holding the graph cache's lock, as that requires a
+	// round trip to the switch.
+	var channels []*channeldb.ChannelEdgeInfo
+	err := r.graphCache.forEachChannel(r.selfNode.PubKey, func(
+		edgeInfo *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		channels = append(channels, edgeInfo)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Unable to gather channels for bandwidth hints: %v",
+			err)
+		return nil
+	}
+
+	hints := make(map[uint64]lnwire.MilliSatoshi, len(channels))
+	for _, channel := range channels {
+		hints[channel.ChannelID] = r.cfg.QueryBandwidth(channel)
+	}
+
+	return hints
+}
+
+// firstHopHasBandwidth returns true if the bandwidth hint of the first hop of
+// the passed route, if there's any, is sufficient to carry the total amount of
+// the route.
+func firstHopHasBandwidth(route *Route,
+	bandwidthHints map[uint64]lnwire.MilliSatoshi) bool {
+
+	bandwidth, ok := bandwidthHints[route.Hops[0].Channel.ChannelID]
+	return !ok || bandwidth >= route.TotalAmount
+}
+
+// fetchSourceNode returns the node that path finding should start from: the
+// passed node if non-nil, otherwise our own node.
+func (r *ChannelRouter) fetchSourceNode(
+	source *btcec.PublicKey) (*channeldb.LightningNode, error) {
+
+	if source == nil || source.IsEqual(r.selfNode.PubKey) {
+		return r.selfNode, nil
+	}
+
+	sourceNode, err := r.graphCache.fetchNode(source)
+	if err == channeldb.ErrGraphNodeNotFound {
+		return nil, newErrf(ErrNoPathFound, "source node %x not found",
+			source.SerializeCompressed())
+	}
+
+	return sourceNode, err
+}
+
 // generateSphinxPacket generates then encodes a sphinx packet which encodes
 // the onion route specified by the passed layer 3 route. The blob returned
 // from this function can immediately be included within an HTLC add packet to
-// be sent to the first hop within the route.
-func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
-	*sphinx.Circuit, error) {
+// be sent to the first hop within the route. If a keysend preimage is passed,
+// then it's delivered to the final hop within an extra hop payload.
+func generateSphinxPacket(route *Route, paymentHash []byte,
+	keySendPreimage *[32]byte) ([]byte, *sphinx.Circuit, error) {
+
 	// First obtain all the public keys along the route which are contained
 	// in each hop.
 	nodes := make([]*btcec.PublicKey, len(route.Hops))
@@ -911,6 +1364,23 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 	// properly forward the payment.
 	hopPayloads := route.ToHopPayloads()
 
+	// The preimage of a spontaneous payment is delivered within an extra
+	// hop addressed to the final hop itself, which takes up one of the
+	// hops available within the onion.
+	if keySendPreimage != nil {
+		if len(nodes) >= HopLimit {
+			return nil, nil, newErr(ErrMaxHopsExceeded, "route "+
+				"has too many hops for a spontaneous payment")
+		}
+
+		nodes = append(nodes, nodes[len(nodes)-1])
+		hopPayloads = append(
+			hopPayloads, htlcswitch.NewKeySendHopData(
+				*keySendPreimage,
+			),
+		)
+	}
+
 	log.Tracef("Constructed per-hop payloads for payment_hash=%x: %v",
 		paymentHash[:], spew.Sdump(hopPayloads))
 
@@ -963,6 +1433,27 @@ type LightningPayment struct {
 	// the first hop.
 	PaymentHash [32]byte
 
+	// OutgoingChannelID, if non-zero, is the channel the payment must be
+	// sent over as its first hop.
+	OutgoingChannelID uint64
+
+	// LastHop, if non-nil, is the node the payment must traverse right
+	// before reaching the target.
+	LastHop *btcec.PublicKey
+
+	// LastChannelID, if non-zero, is the channel between LastHop and the
+	// target the payment must traverse as its final hop.
+	LastChannelID uint64
+
+	// FeeLimit, if non-zero, is the maximum total fee the payment may pay
+	// to the intermediate nodes of its route.
+	FeeLimit lnwire.MilliSatoshi
+
+	// KeySendPreimage, if non-nil, is the preimage of a spontaneous
+	// payment, which is delivered to the target within the onion. The
+	// PaymentHash must be the hash of this preimage.
+	KeySendPreimage *[32]byte
+
 	// TODO(roasbeef): add e2e message?
 }
 
@@ -997,13 +1488,50 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 	routes, ok := r.routeCache[rt]
 	r.routeCacheMtx.RUnlock()
 
+	// The bandwidth of our channels may have changed since the routes
+	// were cached, so we'll discard any cached routes whose first hop is
+	// no longer able to carry the payment. If none of them remain, then
+	// we'll query the graph for a fresh set of routes below.
+	if ok {
+		bandwidthHints := r.bandwidthHints(r.selfNode)
+
+		var usableRoutes []*Route
+		for _, route := range routes {
+			if firstHopHasBandwidth(route, bandwidthHints) {
+				usableRoutes = append(usableRoutes, route)
+			}
+		}
+
+		routes = usableRoutes
+		ok = len(routes) != 0
+	}
+
+	// If the payment restricts the first or last hop of its route, then
+	// the cached routes don't apply, so we'll search for a single route
+	// honoring the restrictions instead.
+	restricted := payment.OutgoingChannelID != 0 || payment.LastHop != nil
+	if restricted {
+		route, err := r.FindRestrictedRoute(nil, payment.Target,
+			payment.Amount, &RouteRestrictions{
+				OutgoingChannelID: payment.OutgoingChannelID,
+				LastHop:           payment.LastHop,
+				LastChannelID:     payment.LastChannelID,
+			}, 0)
+		if err != nil {
+			return preImage, nil, err
+		}
+
+		routes = []*Route{route}
+	}
+
 	// If we don't have a set of routes cached, we'll query the graph for a
 	// set of potential routes to the destination node that can support our
 	// payment amount. If no such routes can be found then an error will be
 	// returned.
-	if !ok {
+	if !ok && !restricted {
 		// TODO(roasbeef): put cache handling into FindRoutes
-		freshRoutes, err := r.FindRoutes(payment.Target, payment.Amount)
+		freshRoutes, err := r.FindRoutes(nil, payment.Target,
+			payment.Amount, &RouteRestrictions{}, 0, 0)
 		if err != nil {
 			return preImage, nil, err
 		}
@@ -1022,37 +1550,44 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 	// serially until either once succeeds, or we've exhausted our set of
 	// available paths.
 	for _, route := range routes {
+		// Routes which would pay more in fees than the payment allows
+		// are skipped entirely.
+		if payment.FeeLimit != 0 && route.TotalFees > payment.FeeLimit {
+			log.Debugf("Skipping route for payment %x, fee of %v "+
+				"exceeds limit of %v", payment.PaymentHash,
+				route.TotalFees, payment.FeeLimit)
+			continue
+		}
+
 		log.Tracef("Attempting to send payment %x, using route: %v",
 			payment.PaymentHash, newLogClosure(func() string {
 				return spew.Sdump(route)
 			}),
 		)
 
-		// Generate the raw encoded sphinx packet to be included along
-		// with the htlcAdd message that we send directly to the
-		// switch.
-		onionBlob, circuit, err := generateSphinxPacket(route,
-			payment.PaymentHash[:])
+		htlcAdd, circuit, err := newHtlcAdd(
+			route, payment.PaymentHash, payment.KeySendPreimage,
+		)
 		if err != nil {
 			return preImage, nil, err
 		}
 
-		// Craft an HTLC packet to send to the layer 2 switch. The
-		// metadata within this packet will be used to route the
-		// payment through the network, starting with the first-hop.
-		htlcAdd := &lnwire.UpdateAddHTLC{
-			Amount:      route.TotalAmount,
-			Expiry:      route.TotalTimeLock,
-			PaymentHash: payment.PaymentHash,
+		// If the payment must leave through a particular channel,
+		// then we'll instruct the switch to use it, rather than any
+		// channel with the first hop.
+		var outgoingChan lnwire.ShortChannelID
+		if payment.OutgoingChannelID != 0 {
+			outgoingChan = lnwire.NewShortChanIDFromInt(
+				payment.OutgoingChannelID,
+			)
 		}
-		copy(htlcAdd.OnionBlob[:], onionBlob)
 
 		// Attempt to send this payment through the network to complete
 		// the payment. If this attempt fails, then we'll continue on
 		// to the next available route.
 		firstHop := route.Hops[0].Channel.Node.PubKey
-		preImage, sendError = r.cfg.SendToSwitch(firstHop, htlcAdd,
-			circuit)
+		preImage, sendError = r.cfg.SendToSwitch(firstHop, outgoingChan,
+			htlcAdd, circuit)
 		if sendError != nil {
 			log.Errorf("Attempt to send payment %x failed: %v",
 				payment.PaymentHash, sendError)
@@ -1062,11 +1597,101 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		return preImage, route, nil
 	}
 
+	// If all of the routes we've found exceeded the fee limit, then we
+	// never attempted the payment at all.
+	if sendError == nil {
+		return [32]byte{}, nil, newErrf(ErrNoRouteFound, "unable to "+
+			"find a route within fee limit of %v", payment.FeeLimit)
+	}
+
 	// If we're unable to successfully make a payment using any of the
 	// routes we've found, then return an error.
 	return [32]byte{}, nil, sendError
 }
 
+// SendToRoute attempts to send a payment with the passed payment hash along
+// the passed routes, which were constructed by the caller rather than found
+// by path finding. The routes are attempted serially in the order given, and
+// each is sent over the exact channel specified by its first hop. If the
+// payment succeeds, then the preimage and the successful route are returned.
+// Otherwise, the error of the final attempt is returned, along with the route
+// the final attempt was made over.
+func (r *ChannelRouter) SendToRoute(routes []*Route,
+	paymentHash [32]byte) ([32]byte, *Route, error) {
+
+	if len(routes) == 0 {
+		return [32]byte{}, nil, newErr(ErrNoRouteFound, "no routes "+
+			"provided")
+	}
+
+	var (
+		sendError error
+		preImage  [32]byte
+		lastRoute *Route
+	)
+	for _, route := range routes {
+		if len(route.Hops) == 0 {
+			return [32]byte{}, nil, newErr(ErrNoRouteFound,
+				"route has no hops")
+		}
+
+		log.Tracef("Attempting to send payment %x, using route: %v",
+			paymentHash, newLogClosure(func() string {
+				return spew.Sdump(route)
+			}),
+		)
+
+		htlcAdd, circuit, err := newHtlcAdd(route, paymentHash, nil)
+		if err != nil {
+			return [32]byte{}, nil, err
+		}
+
+		firstHop := route.Hops[0].Channel
+		outgoingChan := lnwire.NewShortChanIDFromInt(firstHop.ChannelID)
+		lastRoute = route
+		preImage, sendError = r.cfg.SendToSwitch(firstHop.Node.PubKey,
+			outgoingChan, htlcAdd, circuit)
+		if sendError != nil {
+			log.Errorf("Attempt to send payment %x failed: %v",
+				paymentHash, sendError)
+			continue
+		}
+
+		return preImage, route, nil
+	}
+
+	return [32]byte{}, lastRoute, sendError
+}
+
+// newHtlcAdd crafts the HTLC add message, and the sphinx circuit used to
+// decrypt any failures, for a payment with the passed payment hash sent along
+// the passed route. The keysend preimage is only set for spontaneous payments.
+func newHtlcAdd(route *Route, paymentHash [32]byte,
+	keySendPreimage *[32]byte) (*lnwire.UpdateAddHTLC, *sphinx.Circuit,
+	error) {
+
+	// Generate the raw encoded sphinx packet to be included along with
+	// the htlcAdd message that we send directly to the switch.
+	onionBlob, circuit, err := generateSphinxPacket(
+		route, paymentHash[:], keySendPreimage,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Craft an HTLC packet to send to the layer 2 switch. The metadata
+	// within this packet will be used to route the payment through the
+	// network, starting with the first-hop.
+	htlcAdd := &lnwire.UpdateAddHTLC{
+		Amount:      route.TotalAmount,
+		Expiry:      route.TotalTimeLock,
+		PaymentHash: paymentHash,
+	}
+	copy(htlcAdd.OnionBlob[:], onionBlob)
+
+	return htlcAdd, circuit, nil
+}
+
 // AddNode is used to add information about a node to the router database. If
 // the node with this pubkey is not present in an existing channel, it will
 // be ignored.
@@ -1142,6 +1767,28 @@ func (r *ChannelRouter) GetChannelByID(chanID lnwire.ShortChannelID) (
 	return r.cfg.Graph.FetchChannelEdgesByID(chanID.ToUint64())
 }
 
+// GetZombieChannel returns the channel with the passed channel id if it has
+// been pruned from the graph as a zombie. If it hasn't, then
+// channeldb.ErrEdgeNotFound is returned.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) GetZombieChannel(chanID lnwire.ShortChannelID) (
+	*channeldb.ChannelEdgeInfo, error) {
+
+	return r.cfg.Graph.FetchZombieEdge(chanID.ToUint64())
+}
+
+// FetchLightningNode attempts to look up a target node by its identity public
+// key. channeldb.ErrGraphNodeNotFound is returned if the node doesn't exist
+// within the graph.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) FetchLightningNode(
+	node *btcec.PublicKey) (*channeldb.LightningNode, error) {
+
+	return r.cfg.Graph.FetchLightningNode(node)
+}
+
 // ForEachNode is used to iterate over every node in router topology.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
@@ -1188,5 +1835,10 @@ func (r *ChannelRouter) AddProof(chanID lnwire.ShortChannelID,
 	}
 
 	info.AuthProof = proof
-	return r.cfg.Graph.UpdateChannelEdge(info)
+	if err := r.cfg.Graph.UpdateChannelEdge(info); err != nil {
+		return err
+	}
+
+	r.graphCache.addChannel(info)
+	return nil
 }
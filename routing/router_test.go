@@ -81,7 +81,7 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 		Graph:     graph,
 		Chain:     chain,
 		ChainView: chainView,
-		SendToSwitch: func(_ *btcec.PublicKey,
+		SendToSwitch: func(_ *btcec.PublicKey, _ lnwire.ShortChannelID,
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
@@ -127,7 +127,8 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	// Execute a query for all possible routes between roasbeef and luo ji.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := ctx.aliases["luoji"]
-	routes, err := ctx.router.FindRoutes(target, paymentAmt)
+	routes, err := ctx.router.FindRoutes(nil, target, paymentAmt,
+		&RouteRestrictions{}, 0, 0)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -146,6 +147,331 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	}
 }
 
+// TestFindRoutesRestrictions asserts that the routes found by the FindRoutes
+// method honor the ignored nodes and edges, fee limit, number of routes, final
+// time lock delta and source node requested by the caller.
+func TestFindRoutesRestrictions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		startingBlockHeight = 101
+		roasbeefLuoji       = 689530843
+		satoshiLuoji        = 523452362
+	)
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := ctx.aliases["luoji"]
+
+	tests := []struct {
+		name           string
+		source         *btcec.PublicKey
+		restrictions   *RouteRestrictions
+		numRoutes      uint32
+		finalCLTVDelta uint16
+		expectedRoutes int
+		firstHopChan   uint64
+	}{
+		{
+			name: "ignored node",
+			restrictions: &RouteRestrictions{
+				IgnoredNodes: []*btcec.PublicKey{
+					ctx.aliases["satoshi"],
+				},
+			},
+			expectedRoutes: 1,
+			firstHopChan:   roasbeefLuoji,
+		},
+		{
+			// Roasbeef is the first node of the channel, so this
+			// ignores the direct channel towards luo ji.
+			name: "ignored edge",
+			restrictions: &RouteRestrictions{
+				IgnoredEdges: []EdgeLocator{
+					{ChannelID: roasbeefLuoji, Direction: 0},
+				},
+			},
+			expectedRoutes: 1,
+		},
+		{
+			// Ignoring the opposite direction of the channel
+			// shouldn't affect the routes towards luo ji.
+			name: "ignored reverse edge",
+			restrictions: &RouteRestrictions{
+				IgnoredEdges: []EdgeLocator{
+					{ChannelID: roasbeefLuoji, Direction: 1},
+				},
+			},
+			expectedRoutes: 2,
+			firstHopChan:   roasbeefLuoji,
+		},
+		{
+			// Only the direct route is free of fees.
+			name: "fee limit",
+			restrictions: &RouteRestrictions{
+				FeeLimit: 1,
+			},
+			expectedRoutes: 1,
+			firstHopChan:   roasbeefLuoji,
+		},
+		{
+			name:           "number of routes",
+			restrictions:   &RouteRestrictions{},
+			numRoutes:      1,
+			expectedRoutes: 1,
+			firstHopChan:   roasbeefLuoji,
+		},
+		{
+			name:           "final cltv delta",
+			restrictions:   &RouteRestrictions{},
+			finalCLTVDelta: 40,
+			expectedRoutes: 2,
+			firstHopChan:   roasbeefLuoji,
+		},
+		{
+			name:           "source node",
+			source:         ctx.aliases["satoshi"],
+			restrictions:   &RouteRestrictions{},
+			expectedRoutes: 2,
+			firstHopChan:   satoshiLuoji,
+		},
+	}
+
+	for _, test := range tests {
+		routes, err := ctx.router.FindRoutes(test.source, target,
+			paymentAmt, test.restrictions, test.numRoutes,
+			test.finalCLTVDelta)
+		if err != nil {
+			t.Fatalf("%v: unable to find routes: %v", test.name, err)
+		}
+
+		if len(routes) != test.expectedRoutes {
+			t.Fatalf("%v: expected %v routes, found %v: %v",
+				test.name, test.expectedRoutes, len(routes),
+				spew.Sdump(routes))
+		}
+
+		firstHop := routes[0].Hops[0].Channel
+		if test.firstHopChan != 0 &&
+			firstHop.ChannelID != test.firstHopChan {
+
+			t.Fatalf("%v: expected first hop over channel %v, "+
+				"instead used %v", test.name,
+				test.firstHopChan, firstHop.ChannelID)
+		}
+
+		for _, route := range routes {
+			for _, hop := range route.Hops {
+				for _, node := range test.restrictions.IgnoredNodes {
+					if hop.Channel.Node.PubKey.IsEqual(node) {
+						t.Fatalf("%v: route traverses "+
+							"ignored node", test.name)
+					}
+				}
+			}
+
+			if test.finalCLTVDelta == 0 {
+				continue
+			}
+			lastHop := route.Hops[len(route.Hops)-1]
+			expectedTimeLock := uint32(startingBlockHeight) +
+				uint32(test.finalCLTVDelta)
+			if lastHop.OutgoingTimeLock != expectedTimeLock {
+				t.Fatalf("%v: expected final time lock %v, got "+
+					"%v", test.name, expectedTimeLock,
+					lastHop.OutgoingTimeLock)
+			}
+		}
+	}
+}
+
+// TestRouteCacheInvalidation asserts that cached routes are only evicted once
+// the policy of a channel they traverse changes.
+func TestRouteCacheInvalidation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		startingBlockHeight = 101
+		roasbeefLuoji       = 689530843
+		songokuSophon       = 3495345
+	)
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Sending a payment to luo ji will populate the route cache with the
+	// routes towards it.
+	target := ctx.aliases["luoji"]
+	payment := LightningPayment{
+		Target: target,
+		Amount: lnwire.NewMSatFromSatoshis(1000),
+	}
+	if _, _, err := ctx.router.SendPayment(&payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	isCached := func() bool {
+		rt := newRouteTuple(payment.Amount, target)
+
+		ctx.router.routeCacheMtx.RLock()
+		defer ctx.router.routeCacheMtx.RUnlock()
+
+		_, ok := ctx.router.routeCache[rt]
+		return ok
+	}
+	if !isCached() {
+		t.Fatalf("routes to luo ji should be cached")
+	}
+
+	updatePolicy := func(chanID uint64) {
+		_, policy1, _, err := ctx.graph.FetchChannelEdgesByID(chanID)
+		if err != nil {
+			t.Fatalf("unable to fetch channel: %v", err)
+		}
+
+		policy1.LastUpdate = policy1.LastUpdate.Add(time.Second)
+		policy1.FeeBaseMSat++
+		if err := ctx.router.UpdateEdge(policy1); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	// An update to a channel which none of the cached routes traverse
+	// shouldn't affect them.
+	updatePolicy(songokuSophon)
+	if !isCached() {
+		t.Fatalf("routes to luo ji shouldn't have been evicted")
+	}
+
+	// Once the direct channel to luo ji changes its fee, the cached routes
+	// no longer reflect the cost of paying luo ji, so they're evicted.
+	updatePolicy(roasbeefLuoji)
+	if isCached() {
+		t.Fatalf("routes to luo ji should have been evicted")
+	}
+
+	// We'll repopulate the cache, then add a new channel between satoshi,
+	// which the cached routes pass through, and son goku.
+	if _, _, err := ctx.router.SendPayment(&payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if !isCached() {
+		t.Fatalf("routes to luo ji should be cached")
+	}
+
+	fundingTx, _, chanID, err := createChannelEdge(ctx,
+		bitcoinKey1.SerializeCompressed(),
+		bitcoinKey2.SerializeCompressed(),
+		30000, 200)
+	if err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	fundingBlock := &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}
+	ctx.chain.addBlock(fundingBlock, chanID.BlockHeight)
+
+	edge := &channeldb.ChannelEdgeInfo{
+		ChannelID:   chanID.ToUint64(),
+		NodeKey1:    ctx.aliases["satoshi"],
+		NodeKey2:    ctx.aliases["songoku"],
+		BitcoinKey1: bitcoinKey1,
+		BitcoinKey2: bitcoinKey2,
+	}
+	if err := ctx.router.AddEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	// Without any policies the channel can't be used, so the cached routes
+	// remain valid.
+	if !isCached() {
+		t.Fatalf("routes to luo ji shouldn't have been evicted")
+	}
+
+	// Once the channel receives its first policy, it may offer new paths
+	// through satoshi, so the cached routes should be evicted.
+	err = ctx.router.UpdateEdge(&channeldb.ChannelEdgePolicy{
+		Signature:     testSig,
+		ChannelID:     edge.ChannelID,
+		LastUpdate:    time.Now(),
+		TimeLockDelta: 10,
+		MinHTLC:       1,
+	})
+	if err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+	if isCached() {
+		t.Fatalf("routes to luo ji should have been evicted")
+	}
+}
+
+// TestRouteCacheBandwidth asserts that cached routes whose first hop no longer
+// has the bandwidth to carry a payment aren't used for it.
+func TestRouteCacheBandwidth(t *testing.T) {
+	t.Parallel()
+
+	const (
+		startingBlockHeight = 101
+		roasbeefLuoji       = 689530843
+	)
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Initially, the direct channel to luo ji is able to carry the
+	// payment.
+	directBandwidth := lnwire.NewMSatFromSatoshis(100000)
+	ctx.router.cfg.QueryBandwidth = func(
+		edge *channeldb.ChannelEdgeInfo) lnwire.MilliSatoshi {
+
+		if edge.ChannelID == roasbeefLuoji {
+			return directBandwidth
+		}
+		return lnwire.NewMSatFromSatoshis(100000)
+	}
+
+	var firstHop *btcec.PublicKey
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+		_ lnwire.ShortChannelID, _ *lnwire.UpdateAddHTLC,
+		_ *sphinx.Circuit) ([32]byte, error) {
+
+		firstHop = n
+		return [32]byte{}, nil
+	}
+
+	payment := LightningPayment{
+		Target: ctx.aliases["luoji"],
+		Amount: lnwire.NewMSatFromSatoshis(1000),
+	}
+
+	// The first payment should take the direct route, and populate the
+	// route cache.
+	if _, _, err := ctx.router.SendPayment(&payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if !firstHop.IsEqual(ctx.aliases["luoji"]) {
+		t.Fatalf("expected payment to use the direct channel")
+	}
+
+	// Once the direct channel has been drained, the cached direct route
+	// should be skipped in favor of the route through satoshi.
+	directBandwidth = 0
+	if _, _, err := ctx.router.SendPayment(&payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if !firstHop.IsEqual(ctx.aliases["satoshi"]) {
+		t.Fatalf("expected payment to be routed through satoshi")
+	}
+}
+
 // TestSendPaymentRouteFailureFallback tests that when sending a payment, if
 // one of the target routes is seen as unavailable, then the next route in the
 // queue is used instead. This process should continue until either a payment
@@ -177,7 +503,8 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	// first hop. This should force the router to instead take the
 	// available two hop path (through satoshi).
 	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
-		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+		_ lnwire.ShortChannelID, _ *lnwire.UpdateAddHTLC,
+		_ *sphinx.Circuit) ([32]byte, error) {
 
 		if ctx.aliases["luoji"].IsEqual(n) {
 			return [32]byte{}, errors.New("send error")
@@ -213,6 +540,87 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	}
 }
 
+// TestSendToRoute tests that payments sent along caller supplied routes are
+// attempted in order over the exact channel specified by the first hop of each
+// route, and that the route of the final failed attempt is returned.
+func TestSendToRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// We'll obtain the routes to luo ji, which will be handed back to the
+	// router as if they were supplied by the caller.
+	routes, err := ctx.router.FindRoutes(nil, ctx.aliases["luoji"],
+		lnwire.NewMSatFromSatoshis(1000), &RouteRestrictions{}, 0, 0)
+	if err != nil {
+		t.Fatalf("unable to find routes: %v", err)
+	}
+	if len(routes) < 2 {
+		t.Fatalf("expected at least two routes, got %v", len(routes))
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	// The switch will fail any attempt through luo ji directly, so only
+	// the route through satoshi should succeed.
+	var outgoingChans []lnwire.ShortChannelID
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+		outgoingChan lnwire.ShortChannelID, _ *lnwire.UpdateAddHTLC,
+		_ *sphinx.Circuit) ([32]byte, error) {
+
+		outgoingChans = append(outgoingChans, outgoingChan)
+		if ctx.aliases["luoji"].IsEqual(n) {
+			return [32]byte{}, errors.New("send error")
+		}
+
+		return preImage, nil
+	}
+
+	var payHash [32]byte
+	paymentPreImage, route, err := ctx.router.SendToRoute(routes, payHash)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+	if route.Hops[0].Channel.Node.Alias != "satoshi" {
+		t.Fatalf("route should go through satoshi as first hop, "+
+			"instead passes through: %v",
+			route.Hops[0].Channel.Node.Alias)
+	}
+
+	// Each attempt should have been pinned to the first channel of its
+	// route.
+	if len(outgoingChans) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", len(outgoingChans))
+	}
+	for i, outgoingChan := range outgoingChans {
+		expected := routes[i].Hops[0].Channel.ChannelID
+		if outgoingChan.ToUint64() != expected {
+			t.Fatalf("attempt %v used channel %v, expected %v", i,
+				outgoingChan.ToUint64(), expected)
+		}
+	}
+
+	// If we only supply the route through luo ji, then the payment should
+	// fail, with the failed route returned alongside the error.
+	_, route, err = ctx.router.SendToRoute(routes[:1], payHash)
+	if err == nil {
+		t.Fatalf("payment should have failed")
+	}
+	if route != routes[0] {
+		t.Fatalf("failed route wasn't returned")
+	}
+}
+
 // TestAddProof checks that we can update the channel proof after channel
 // info was added to the database.
 func TestAddProof(t *testing.T) {
@@ -494,7 +902,8 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 	// We should now be able to find one route to node 2.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	targetNode := priv2.PubKey()
-	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt)
+	routes, err := ctx.router.FindRoutes(nil, targetNode, paymentAmt,
+		&RouteRestrictions{}, 0, 0)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -536,7 +945,8 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 
 	// Should still be able to find the route, and the info should be
 	// updated.
-	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt)
+	routes, err = ctx.router.FindRoutes(nil, targetNode, paymentAmt,
+		&RouteRestrictions{}, 0, 0)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
 	}
@@ -562,3 +972,177 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		t.Fatalf("fetched node not equal to original")
 	}
 }
+
+// TestPruneZombieChans tests that channels which haven't been updated within
+// the prune expiry are removed from the graph along with their nodes, that
+// their announcements are ignored afterwards, and that only a fresh update
+// brings them back.
+func TestPruneZombieChans(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	const pruneExpiry = time.Hour
+	ctx.router.cfg.ChannelPruneExpiry = pruneExpiry
+
+	fundingTx, _, chanID, err := createChannelEdge(ctx,
+		bitcoinKey1.SerializeCompressed(),
+		bitcoinKey2.SerializeCompressed(),
+		10000, 500)
+	if err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	fundingBlock := &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}
+	ctx.chain.addBlock(fundingBlock, chanID.BlockHeight)
+
+	edge := &channeldb.ChannelEdgeInfo{
+		ChannelID:   chanID.ToUint64(),
+		NodeKey1:    priv1.PubKey(),
+		NodeKey2:    priv2.PubKey(),
+		BitcoinKey1: bitcoinKey1,
+		BitcoinKey2: bitcoinKey2,
+	}
+	if err := ctx.router.AddEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	// Both policies of the channel were last updated beyond the prune
+	// expiry.
+	staleUpdate := time.Now().Add(-2 * pruneExpiry)
+	newPolicy := func(flags uint16,
+		lastUpdate time.Time) *channeldb.ChannelEdgePolicy {
+
+		return &channeldb.ChannelEdgePolicy{
+			Signature:     testSig,
+			ChannelID:     edge.ChannelID,
+			LastUpdate:    lastUpdate,
+			Flags:         flags,
+			TimeLockDelta: 10,
+			MinHTLC:       1,
+		}
+	}
+	for _, flags := range []uint16{0, lnwire.ChanUpdateDirection} {
+		err := ctx.router.UpdateEdge(newPolicy(flags, staleUpdate))
+		if err != nil {
+			t.Fatalf("unable to update edge policy: %v", err)
+		}
+	}
+
+	if err := ctx.router.pruneZombieChans(); err != nil {
+		t.Fatalf("unable to prune zombie channels: %v", err)
+	}
+
+	// The channel should now be gone, along with both of its nodes, as
+	// they're no longer a party to any channel.
+	_, _, exists, err := ctx.graph.HasChannelEdge(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("unable to query graph: %v", err)
+	}
+	if exists {
+		t.Fatalf("zombie channel wasn't pruned")
+	}
+	for _, pub := range []*btcec.PublicKey{priv1.PubKey(), priv2.PubKey()} {
+		_, exists, err := ctx.graph.HasLightningNode(pub)
+		if err != nil {
+			t.Fatalf("unable to query graph: %v", err)
+		}
+		if exists {
+			t.Fatalf("node without channels wasn't pruned")
+		}
+	}
+
+	// The source node should never be pruned.
+	_, exists, err = ctx.graph.HasLightningNode(ctx.router.selfNode.PubKey)
+	if err != nil {
+		t.Fatalf("unable to query graph: %v", err)
+	}
+	if !exists {
+		t.Fatalf("source node was pruned")
+	}
+
+	// Re-announcing the channel, or sending a stale update for it,
+	// shouldn't add it back to the graph.
+	err = ctx.router.AddEdge(edge)
+	if !IsError(err, ErrIgnored) {
+		t.Fatalf("expected announcement of zombie to be ignored, "+
+			"instead got: %v", err)
+	}
+	err = ctx.router.UpdateEdge(newPolicy(0, staleUpdate.Add(time.Second)))
+	if !IsError(err, ErrIgnored) {
+		t.Fatalf("expected stale update of zombie to be ignored, "+
+			"instead got: %v", err)
+	}
+
+	// A fresh update should resurrect the channel, with the update
+	// applied.
+	if err := ctx.router.UpdateEdge(newPolicy(0, time.Now())); err != nil {
+		t.Fatalf("unable to resurrect zombie: %v", err)
+	}
+	_, e1, _, err := ctx.graph.FetchChannelEdgesByID(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("resurrected channel not found: %v", err)
+	}
+	if e1 == nil || ctx.router.isStaleUpdate(e1.LastUpdate) {
+		t.Fatalf("fresh update wasn't applied to resurrected channel")
+	}
+	if _, err := ctx.graph.FetchZombieEdge(edge.ChannelID); err == nil {
+		t.Fatalf("resurrected channel still marked as zombie")
+	}
+
+	// We'll now add a new channel without any policies, which was funded
+	// after our current height.
+	const newFundingHeight = 600
+	fundingTx, _, newChanID, err := createChannelEdge(ctx,
+		bitcoinKey1.SerializeCompressed(),
+		bitcoinKey2.SerializeCompressed(),
+		20000, newFundingHeight)
+	if err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	fundingBlock = &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}
+	ctx.chain.addBlock(fundingBlock, newChanID.BlockHeight)
+
+	newEdge := *edge
+	newEdge.ChannelID = newChanID.ToUint64()
+	if err := ctx.router.AddEdge(&newEdge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	assertChannelExists := func(chanID uint64, expected bool) {
+		_, _, exists, err := ctx.graph.HasChannelEdge(chanID)
+		if err != nil {
+			t.Fatalf("unable to query graph: %v", err)
+		}
+		if exists != expected {
+			t.Fatalf("expected channel %v to exist: %v, but it "+
+				"does: %v", chanID, expected, exists)
+		}
+	}
+
+	// Neither the young channel without any policies, nor the resurrected
+	// channel with a single fresh policy, should be pruned.
+	if err := ctx.router.pruneZombieChans(); err != nil {
+		t.Fatalf("unable to prune zombie channels: %v", err)
+	}
+	assertChannelExists(newEdge.ChannelID, true)
+	assertChannelExists(edge.ChannelID, true)
+
+	// Once the new channel was funded beyond the prune expiry, its missing
+	// policies count as stale, so it should be pruned.
+	ctx.router.bestHeight = newFundingHeight +
+		uint32(pruneExpiry/(10*time.Minute))
+	if err := ctx.router.pruneZombieChans(); err != nil {
+		t.Fatalf("unable to prune zombie channels: %v", err)
+	}
+	assertChannelExists(newEdge.ChannelID, false)
+	assertChannelExists(edge.ChannelID, true)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/boltdb/bolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -547,12 +548,23 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		nodePubKeyBytes = nodePubKey.SerializeCompressed()
 	}
 
+	// If the caller specified a close address, then we'll commit to it as
+	// our upfront shutdown script.
+	var shutdownScript lnwire.DeliveryAddress
+	if in.CloseAddress != "" {
+		shutdownScript, err = parseDeliveryAddress(in.CloseAddress)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Instruct the server to trigger the necessary events to attempt to
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	updateChan, errChan := r.server.OpenChannel(
 		in.TargetPeerId, nodePubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
+		shutdownScript,
 	)
 
 	var outpoint wire.OutPoint
@@ -656,9 +668,18 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 			"initial state must be below the local funding amount")
 	}
 
+	var shutdownScript lnwire.DeliveryAddress
+	if in.CloseAddress != "" {
+		shutdownScript, err = parseDeliveryAddress(in.CloseAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	updateChan, errChan := r.server.OpenChannel(
 		in.TargetPeerId, nodepubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
+		shutdownScript,
 	)
 
 	select {
@@ -689,6 +710,133 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// BatchOpenChannel attempts to open a set of channels to distinct peers, all
+// funded by a single transaction. The call returns once the funding
+// transaction has been broadcast, or any channel within the batch fails.
+func (r *rpcServer) BatchOpenChannel(ctx context.Context,
+	in *lnrpc.BatchOpenChannelRequest) (*lnrpc.BatchOpenChannelResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "openchannel",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Tracef("[batchopenchannel] request to open %v channels",
+		len(in.Channels))
+
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	// Creation of channels before the wallet syncs up is currently
+	// disallowed.
+	isSynced, err := r.server.cc.wallet.IsSynced()
+	if err != nil {
+		return nil, err
+	}
+	if !isSynced {
+		return nil, errors.New("channels cannot be created before the " +
+			"wallet is fully synced")
+	}
+
+	if len(in.Channels) == 0 {
+		return nil, fmt.Errorf("at least one channel must be specified")
+	}
+
+	const minChannelSize = btcutil.Amount(6000)
+
+	// Before we hand the batch off to the server, we'll validate each of
+	// the channels within it, as a single invalid channel would cause the
+	// entire batch to fail.
+	reqs := make([]*openChanReq, 0, len(in.Channels))
+	seenPeers := make(map[string]struct{})
+	for i, channel := range in.Channels {
+		localFundingAmt := btcutil.Amount(channel.LocalFundingAmount)
+		remoteInitialBalance := btcutil.Amount(channel.PushSat)
+
+		if remoteInitialBalance >= localFundingAmt {
+			return nil, fmt.Errorf("channel %v: amount pushed to "+
+				"remote peer for initial state must be below "+
+				"the local funding amount", i)
+		}
+		if localFundingAmt > maxFundingAmount {
+			return nil, fmt.Errorf("channel %v: funding amount is "+
+				"too large, the max channel size is: %v", i,
+				maxFundingAmount)
+		}
+		if localFundingAmt < minChannelSize {
+			return nil, fmt.Errorf("channel %v: channel is too "+
+				"small, the minimum channel size is: %v (6k sat)",
+				i, minChannelSize)
+		}
+
+		nodePubKey, err := btcec.ParsePubKey(channel.NodePubkey,
+			btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		// Making a channel to ourselves wouldn't be of any use, so we
+		// explicitly disallow them.
+		if nodePubKey.IsEqual(r.server.identityPriv.PubKey()) {
+			return nil, fmt.Errorf("cannot open channel to self")
+		}
+
+		// We'll also only allow a single channel per peer within a
+		// batch.
+		pubStr := string(nodePubKey.SerializeCompressed())
+		if _, ok := seenPeers[pubStr]; ok {
+			return nil, fmt.Errorf("duplicate peer %x in batch",
+				channel.NodePubkey)
+		}
+		seenPeers[pubStr] = struct{}{}
+
+		reqs = append(reqs, &openChanReq{
+			targetPubkey:    nodePubKey,
+			localFundingAmt: localFundingAmt,
+			pushAmt: lnwire.NewMSatFromSatoshis(
+				remoteInitialBalance,
+			),
+		})
+	}
+
+	updateChan, errChan := r.server.BatchOpenChannel(reqs)
+
+	// We'll now wait for the pending update of each channel within the
+	// batch. These are only sent once the shared funding transaction has
+	// been broadcast.
+	resp := &lnrpc.BatchOpenChannelResponse{}
+	for len(resp.PendingChannels) < len(reqs) {
+		select {
+		case err := <-errChan:
+			rpcsLog.Errorf("unable to open channel batch: %v", err)
+			return nil, err
+
+		case fundingUpdate := <-updateChan:
+			update, ok := fundingUpdate.Update.(*lnrpc.OpenStatusUpdate_ChanPending)
+			if !ok {
+				continue
+			}
+
+			resp.PendingChannels = append(
+				resp.PendingChannels, update.ChanPending,
+			)
+
+		case <-r.quit:
+			return nil, nil
+		}
+	}
+
+	rpcsLog.Tracef("[batchopenchannel] success, %v channels pending",
+		len(resp.PendingChannels))
+
+	return resp, nil
+}
+
 // CloseLink attempts to close an active channel identified by its channel
 // point. The actions of this method can additionally be augmented to attempt
 // a force close after a timeout period in the case of an inactive peer.
@@ -715,6 +863,16 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 	rpcsLog.Tracef("[closechannel] request for ChannelPoint(%v), force=%v",
 		chanPoint, force)
 
+	// The delivery address and fee preferences only apply to cooperative
+	// closures, as a force closure broadcasts our existing commitment
+	// transaction.
+	hasCoopPrefs := in.DeliveryAddress != "" || in.TargetConf != 0 ||
+		in.SatPerByte != 0 || in.MaxFeeSat != 0
+	if force && hasCoopPrefs {
+		return fmt.Errorf("a delivery address or fee preference " +
+			"cannot be specified when force closing a channel")
+	}
+
 	var (
 		updateChan chan *lnrpc.CloseStatusUpdate
 		errChan    chan error
@@ -764,7 +922,7 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 
 		// With the necessary indexes cleaned up, we'll now force close
 		// the channel.
-		closingTxid, closeSummary, err := r.forceCloseChan(channel)
+		closingTxid, closeSummary, err := r.server.forceCloseChan(channel)
 		if err != nil {
 			rpcsLog.Errorf("unable to force close transaction: %v", err)
 			return err
@@ -784,7 +942,15 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		errChan = make(chan error, 1)
 		notifier := r.server.cc.chainNotifier
 		go waitForChanToClose(uint32(bestHeight), notifier, errChan, chanPoint,
-			closingTxid, func() {
+			closingTxid, func(closeHeight uint32) {
+				err := r.server.chanDB.SetCloseHeight(
+					chanPoint, closeHeight,
+				)
+				if err != nil {
+					rpcsLog.Errorf("unable to set close "+
+						"height: %v", err)
+				}
+
 				// Respond to the local subsystem which
 				// requested the channel closure.
 				updateChan <- &lnrpc.CloseStatusUpdate{
@@ -800,14 +966,19 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 				// commitment transaction, and had no outgoing
 				// HTLC's then we can mark the channels as
 				// closed as there are no funds to be swept.
+				htlcs := closeSummary.HtlcResolutions
 				if closeSummary.SelfOutputSignDesc == nil &&
-					len(closeSummary.HtlcResolutions) == 0 {
+					len(htlcs.IncomingHTLCs) == 0 &&
+					len(htlcs.OutgoingHTLCs) == 0 {
 					err := r.server.chanDB.MarkChanFullyClosed(chanPoint)
 					if err != nil {
 						rpcsLog.Errorf("unable to "+
 							"mark channel as closed: %v", err)
 						return
 					}
+					r.server.channelNotifier.NotifyClosedChannelEvent(
+						*chanPoint,
+					)
 				}
 			})
 	} else {
@@ -815,8 +986,39 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		// cooperative channel closure. So we'll forward the request to
 		// the htlc switch which will handle the negotiation and
 		// broadcast details.
+		//
+		// If the caller specified a delivery address, then we'll
+		// convert it to the script our funds should be paid to.
+		var deliveryScript lnwire.DeliveryAddress
+		if in.DeliveryAddress != "" {
+			deliveryScript, err = parseDeliveryAddress(
+				in.DeliveryAddress,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Based on the passed fee related parameters, we'll determine
+		// an appropriate fee rate for the cooperative closure
+		// transaction. A rate of zero defers to the default estimate.
+		feePerKw, err := r.calculateCloseFeeRate(in.TargetConf,
+			in.SatPerByte)
+		if err != nil {
+			return err
+		}
+		if in.MaxFeeSat < 0 {
+			return fmt.Errorf("max fee cannot be negative")
+		}
+		maxFee := btcutil.Amount(in.MaxFeeSat)
+
+		rpcsLog.Debugf("[closechannel] target fee rate of %v sat/kw, "+
+			"max fee of %v for ChannelPoint(%v)", int64(feePerKw),
+			maxFee, chanPoint)
+
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(chanPoint,
-			htlcswitch.CloseRegular)
+			htlcswitch.CloseRegular, feePerKw, maxFee,
+			deliveryScript)
 	}
 out:
 	for {
@@ -850,100 +1052,319 @@ out:
 	return nil
 }
 
-// fetchActiveChannel attempts to locate a channel identified by it's channel
-// point from the database's set of all currently opened channels.
-func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
+// parseDeliveryAddress decodes the passed address string, ensuring it's valid
+// for the active network, and returns the output script paying to it.
+func parseDeliveryAddress(addrStr string) (lnwire.DeliveryAddress, error) {
+	addr, err := btcutil.DecodeAddress(addrStr, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery address: %v", err)
+	}
+	if !addr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("delivery address %v is not valid for "+
+			"the %v network", addrStr, activeNetParams.Name)
+	}
+
+	return txscript.PayToAddrScript(addr)
+}
+
+// calculateCloseFeeRate returns the fee rate, in sat/kw, that should be
+// targeted by a cooperative closing transaction given the caller's preferred
+// confirmation target or explicit fee rate in sat/byte. At most one of the
+// two may be set. If neither is set, then zero is returned, indicating that
+// the default fee estimate should be used.
+func (r *rpcServer) calculateCloseFeeRate(targetConf int32,
+	satPerByte int64) (btcutil.Amount, error) {
+
+	switch {
+	case targetConf != 0 && satPerByte != 0:
+		return 0, fmt.Errorf("either target_conf or sat_per_byte " +
+			"may be set, but not both")
+
+	case targetConf < 0 || satPerByte < 0:
+		return 0, fmt.Errorf("fee preferences cannot be negative")
+
+	// If a manual fee rate was specified, then we'll convert it from
+	// sat/byte to sat/kw. As a byte of witness data has a weight of four,
+	// a single byte corresponds to 250 sat/kw.
+	case satPerByte != 0:
+		return btcutil.Amount(satPerByte * 250), nil
+
+	// Otherwise, if a confirmation target was specified, we'll query the
+	// fee estimator for a suitable fee rate.
+	case targetConf != 0:
+		feePerWeight := r.server.cc.feeEstimator.EstimateFeePerWeight(
+			uint32(targetConf),
+		)
+		return btcutil.Amount(feePerWeight * 1000), nil
+	}
+
+	return 0, nil
+}
+
+// AbandonChannel removes a channel which is pending, or otherwise can't be
+// resolved, from the database. A close summary of type abandoned is recorded
+// in its place, any wallet inputs locked by the channel's funding transaction
+// are released, and the remote peer is notified. As abandoning a channel whose
+// commitment transaction may be broadcast can result in a loss of funds, such
+// channels are only abandoned if the request explicitly sets force.
+func (r *rpcServer) AbandonChannel(ctx context.Context,
+	in *lnrpc.AbandonChannelRequest) (*lnrpc.AbandonChannelResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "closechannel",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel point must be specified")
+	}
+
+	force := in.Force
+	index := in.ChannelPoint.OutputIndex
+	txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+	if err != nil {
+		rpcsLog.Errorf("[abandonchannel] invalid txid: %v", err)
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	rpcsLog.Tracef("[abandonchannel] request for ChannelPoint(%v), "+
+		"force=%v", chanPoint, force)
+
 	dbChannels, err := r.server.chanDB.FetchAllChannels()
 	if err != nil {
 		return nil, err
 	}
 
-	// With the channels fetched, attempt to locate the target channel
-	// according to its channel point.
 	var dbChan *channeldb.OpenChannel
 	for _, dbChannel := range dbChannels {
-		if dbChannel.FundingOutpoint == chanPoint {
+		if dbChannel.FundingOutpoint == *chanPoint {
 			dbChan = dbChannel
 			break
 		}
 	}
 
-	// If the channel cannot be located, then we exit with an error to the
-	// caller.
+	// If the channel isn't amongst our open or pending channels, then it
+	// may be pending closure. In this case, its commitment or closing
+	// transaction has already been broadcast, so we'll only mark it as
+	// abandoned if forced to.
 	if dbChan == nil {
-		return nil, fmt.Errorf("unable to find channel")
+		pendingClosed, err := r.server.chanDB.FetchClosedChannels(true)
+		if err != nil && err != channeldb.ErrNoClosedChannels {
+			return nil, err
+		}
+
+		var found bool
+		for _, summary := range pendingClosed {
+			if summary.ChanPoint == *chanPoint {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unable to find channel")
+		}
+
+		if !force {
+			return nil, fmt.Errorf("closing transaction for "+
+				"ChannelPoint(%v) has been broadcast, force must "+
+				"be set to abandon it", chanPoint)
+		}
+
+		if err := r.server.chanDB.MarkChanAbandoned(chanPoint); err != nil {
+			return nil, err
+		}
+		r.server.channelNotifier.NotifyClosedChannelEvent(*chanPoint)
+
+		rpcsLog.Infof("[abandonchannel] abandoned pending closed "+
+			"ChannelPoint(%v)", chanPoint)
+
+		return &lnrpc.AbandonChannelResponse{}, nil
 	}
 
-	// Otherwise, we create a fully populated channel state machine which
-	// uses the db channel as backing storage.
-	return lnwallet.NewLightningChannel(r.server.cc.wallet.Cfg.Signer, nil,
-		r.server.cc.feeEstimator, dbChan)
+	// Once a channel has finished funding, either party may broadcast
+	// their commitment transaction at any point. As a result, we'll only
+	// abandon channels which are still pending unless forced to.
+	if !dbChan.IsPending && !force {
+		return nil, fmt.Errorf("ChannelPoint(%v) is active, force "+
+			"must be set to abandon it", chanPoint)
+	}
+
+	// If the channel is active, then we'll need to ensure that the switch
+	// no longer considers it eligible for forwarding HTLC's, and that the
+	// breach arbiter stops watching it.
+	if !dbChan.IsPending {
+		channel, err := r.fetchActiveChannel(*chanPoint)
+		if err != nil {
+			return nil, err
+		}
+		defer channel.Stop()
+
+		if peer, err := r.server.FindPeer(dbChan.IdentityPub); err == nil {
+			peer.WipeChannel(channel)
+		} else {
+			chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+			r.server.htlcSwitch.RemoveLink(chanID)
+		}
+
+		select {
+		case r.server.breachArbiter.settledContracts <- chanPoint:
+		case <-r.quit:
+			return nil, fmt.Errorf("server shutting down")
+		}
+	}
+
+	// With the channel no longer in use, we'll remove it from the database,
+	// leaving behind a summary noting that it was abandoned.
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChanPoint: *chanPoint,
+		RemotePub: dbChan.IdentityPub,
+		Capacity:  dbChan.Capacity,
+		CloseType: channeldb.Abandoned,
+	}
+	if err := dbChan.CloseChannel(closeInfo); err != nil {
+		return nil, err
+	}
+	r.server.channelNotifier.NotifyClosedChannelEvent(*chanPoint)
+
+	// Now that the channel has been removed, we'll clean up any state the
+	// funding manager holds for it, notifying the remote peer in the
+	// process, and release any of our inputs spent by the funding
+	// transaction.
+	if err := r.server.fundingMgr.abandonChannel(dbChan); err != nil {
+		return nil, err
+	}
+	numReleased := r.server.cc.wallet.ReleaseFundingInputs(dbChan)
+
+	rpcsLog.Infof("[abandonchannel] abandoned ChannelPoint(%v), released "+
+		"%v inputs", chanPoint, numReleased)
+
+	return &lnrpc.AbandonChannelResponse{
+		NumReleasedInputs: uint32(numReleased),
+	}, nil
 }
 
-// forceCloseChan executes a unilateral close of the target channel by
-// broadcasting the current commitment state directly on-chain. Once the
-// commitment transaction has been broadcast, a struct describing the final
-// state of the channel is sent to the utxoNursery in order to ultimately sweep
-// the immature outputs.
-func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel) (*chainhash.Hash, *lnwallet.ForceCloseSummary, error) {
+// SpliceChannel splices funds into or out of an active channel without
+// closing it. The call returns once the splice transaction has been
+// broadcast, with the channel point the channel will continue under once the
+// splice transaction has confirmed.
+func (r *rpcServer) SpliceChannel(ctx context.Context,
+	in *lnrpc.SpliceChannelRequest) (*lnrpc.SpliceChannelResponse, error) {
 
-	// Execute a unilateral close shutting down all further channel
-	// operation.
-	closeSummary, err := channel.ForceClose()
-	if err != nil {
-		return nil, nil, err
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "openchannel",
+			r.authSvc); err != nil {
+			return nil, err
+		}
 	}
 
-	closeTx := closeSummary.CloseTx
-	txid := closeTx.TxHash()
+	if in.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel point must be specified")
+	}
+	if in.Amount <= 0 {
+		return nil, fmt.Errorf("splice amount must be positive")
+	}
+	if in.SatPerByte < 0 {
+		return nil, fmt.Errorf("fee rate cannot be negative")
+	}
+	if in.Address != "" && !in.SpliceOut {
+		return nil, fmt.Errorf("address may only be set for splice-out")
+	}
 
-	// With the close transaction in hand, broadcast the transaction to the
-	// network, thereby entering the postk channel resolution state.
-	rpcsLog.Infof("Broadcasting force close transaction, ChannelPoint(%v): %v",
-		channel.ChannelPoint(), newLogClosure(func() string {
-			return spew.Sdump(closeTx)
-		}))
-	if err := r.server.cc.wallet.PublishTransaction(closeTx); err != nil {
-		return nil, nil, err
+	index := in.ChannelPoint.OutputIndex
+	txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+	if err != nil {
+		rpcsLog.Errorf("[splicechannel] invalid txid: %v", err)
+		return nil, err
 	}
+	chanPoint := wire.NewOutPoint(txid, index)
+	amt := btcutil.Amount(in.Amount)
 
-	// Now that the closing transaction has been broadcast successfully,
-	// we'll mark this channel as being in the pending closed state. The
-	// UTXO nursery will mark the channel as fully closed once all the
-	// outputs have been swept.
-	//
-	// TODO(roasbeef): don't set local balance if close summary detects
-	// dust output?
-	chanPoint := channel.ChannelPoint()
-	chanInfo := channel.StateSnapshot()
-	closeInfo := &channeldb.ChannelCloseSummary{
-		ChanPoint:   *chanPoint,
-		ClosingTXID: closeTx.TxHash(),
-		RemotePub:   &chanInfo.RemoteIdentity,
-		Capacity:    chanInfo.Capacity,
-		CloseType:   channeldb.ForceClose,
-		IsPending:   true,
+	rpcsLog.Tracef("[splicechannel] request for ChannelPoint(%v), "+
+		"amt=%v, splice_out=%v", chanPoint, amt, in.SpliceOut)
+
+	// If this is a splice-out, then we'll pay the spliced out funds to
+	// the specified address, or a fresh wallet address if none was
+	// given.
+	var spliceOutScript []byte
+	switch {
+	case in.SpliceOut && in.Address != "":
+		spliceOutScript, err = parseDeliveryAddress(in.Address)
+		if err != nil {
+			return nil, err
+		}
+
+	case in.SpliceOut:
+		addr, err := r.server.cc.wallet.NewAddress(
+			lnwallet.WitnessPubKey, false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		spliceOutScript, err = txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// If our commitment output isn't dust or we have active HTLC's on the
-	// commitment transaction, then we'll populate the balances on the
-	// close channel summary.
-	if closeSummary.SelfOutputSignDesc != nil ||
-		len(closeSummary.HtlcResolutions) == 0 {
+	updateChan, errChan := r.server.SpliceChannel(*chanPoint, amt,
+		spliceOutScript, uint64(in.SatPerByte))
+
+	select {
+	case err := <-errChan:
+		rpcsLog.Errorf("[splicechannel] unable to splice "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+		return nil, err
+
+	case newChanPoint := <-updateChan:
+		rpcsLog.Infof("[splicechannel] ChannelPoint(%v) spliced, new "+
+			"ChannelPoint(%v) pending confirmation", chanPoint,
+			newChanPoint)
 
-		closeInfo.SettledBalance = chanInfo.LocalBalance.ToSatoshis()
-		closeInfo.TimeLockedBalance = chanInfo.LocalBalance.ToSatoshis()
+		return &lnrpc.SpliceChannelResponse{
+			ChannelPoint: &lnrpc.ChannelPoint{
+				FundingTxid: newChanPoint.Hash[:],
+				OutputIndex: newChanPoint.Index,
+			},
+		}, nil
+
+	case <-r.quit:
+		return nil, fmt.Errorf("server shutting down")
+	}
+}
+
+// fetchActiveChannel attempts to locate a channel identified by it's channel
+// point from the database's set of all currently opened channels.
+func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := channel.DeleteState(closeInfo); err != nil {
-		return nil, nil, err
+	// With the channels fetched, attempt to locate the target channel
+	// according to its channel point.
+	var dbChan *channeldb.OpenChannel
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == chanPoint {
+			dbChan = dbChannel
+			break
+		}
 	}
 
-	// Send the closed channel summary over to the utxoNursery in order to
-	// have its outputs swept back into the wallet once they're mature.
-	r.server.utxoNursery.IncubateOutputs(closeSummary)
+	// If the channel cannot be located, then we exit with an error to the
+	// caller.
+	if dbChan == nil {
+		return nil, fmt.Errorf("unable to find channel")
+	}
 
-	return &txid, closeSummary, nil
+	// Otherwise, we create a fully populated channel state machine which
+	// uses the db channel as backing storage.
+	return lnwallet.NewLightningChannel(r.server.cc.wallet.Cfg.Signer,
+		r.server.witnessBeacon, nil, r.server.cc.feeEstimator, dbChan)
 }
 
 // GetInfo returns general information concerning the lightning node including
@@ -1054,6 +1475,31 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			PingTime:  serverPeer.PingTime(),
 		}
 
+		// If we have or had a channel with this peer, then we'll also
+		// include the connection statistics we've persisted for it.
+		// The uptime includes the duration of the current connection.
+		linkNode, err := r.server.chanDB.FetchLinkNode(
+			serverPeer.addr.IdentityKey,
+		)
+		switch {
+		case err == nil:
+			serverPeer.RLock()
+			uptime := linkNode.Uptime +
+				time.Since(serverPeer.timeConnected)
+			serverPeer.RUnlock()
+
+			if !linkNode.FirstSeen.IsZero() {
+				peer.FirstSeen = linkNode.FirstSeen.Unix()
+			}
+			peer.ConnectionCount = linkNode.ConnectionCount
+			peer.Uptime = int64(uptime.Seconds())
+			peer.LastDisconnectReason = linkNode.LastDisconnectReason
+
+		case err != channeldb.ErrNodeNotFound &&
+			err != channeldb.ErrLinkNodesNotFound:
+			return nil, err
+		}
+
 		resp.Peers = append(resp.Peers, peer)
 	}
 
@@ -1062,12 +1508,56 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 	return resp, nil
 }
 
-// WalletBalance returns the sum of all confirmed unspent outputs under control
-// by the wallet. This method can be modified by having the request specify
-// only witness outputs should be factored into the final output sum.
-// TODO(roasbeef): split into total and confirmed/unconfirmed
-// TODO(roasbeef): add async hooks into wallet balance changes
-func (r *rpcServer) WalletBalance(ctx context.Context,
+// SubscribePeerEvents returns a uni-directional stream (server -> client)
+// for notifying the client of peers coming online and going offline.
+func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
+	updateStream lnrpc.Lightning_SubscribePeerEventsServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(updateStream.Context(),
+			"listpeers", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	client := r.server.peerNotifier.SubscribePeerEvents()
+	defer client.Cancel()
+
+	for {
+		select {
+		case e := <-client.Updates:
+			event := e.(*peerEvent)
+
+			eventType := lnrpc.PeerEvent_PEER_ONLINE
+			if event.eventType == peerOfflineEvent {
+				eventType = lnrpc.PeerEvent_PEER_OFFLINE
+			}
+
+			pubKey := event.pubKey.SerializeCompressed()
+			update := &lnrpc.PeerEvent{
+				PubKey:  hex.EncodeToString(pubKey),
+				Type:    eventType,
+				Address: event.address,
+				Inbound: event.inbound,
+				Reason:  event.reason,
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// WalletBalance returns the sum of all confirmed unspent outputs under control
+// by the wallet. This method can be modified by having the request specify
+// only witness outputs should be factored into the final output sum.
+// TODO(roasbeef): split into total and confirmed/unconfirmed
+// TODO(roasbeef): add async hooks into wallet balance changes
+func (r *rpcServer) WalletBalance(ctx context.Context,
 	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
 
 	// Check macaroon to see if this is allowed.
@@ -1217,7 +1707,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 
 		// If the channel was force closed, then we'll need to query
 		// the utxoNursery for additional information.
-		case channeldb.ForceClose:
+		case channeldb.ForceClose, channeldb.RemoteForceClose:
 			forceClose := &lnrpc.PendingChannelResponse_ForceClosedChannel{
 				Channel:     channel,
 				ClosingTxid: closeTXID,
@@ -1233,117 +1723,927 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 					chanPoint, err)
 			}
 
-			// If the nursery knows of this channel, then we can
-			// populate information detailing exactly how much
-			// funds are time locked and also the height in which
-			// we can ultimately sweep the funds into the wallet.
-			if nurseryInfo != nil {
-				forceClose.LimboBalance = int64(nurseryInfo.limboBalance)
-				forceClose.MaturityHeight = nurseryInfo.maturityHeight
+			// If the nursery knows of this channel, then we can
+			// populate information detailing exactly how much
+			// funds are time locked and also the height in which
+			// we can ultimately sweep the funds into the wallet.
+			if nurseryInfo != nil {
+				forceClose.LimboBalance = int64(nurseryInfo.limboBalance)
+				forceClose.MaturityHeight = nurseryInfo.maturityHeight
+
+				// If the transaction has been confirmed, then
+				// we can compute how many blocks it has left.
+				if forceClose.MaturityHeight != 0 {
+					forceClose.BlocksTilMaturity = (forceClose.MaturityHeight -
+						uint32(currentHeight))
+				}
+
+				resp.TotalLimboBalance += int64(nurseryInfo.limboBalance)
+			}
+
+			resp.PendingForceClosingChannels = append(
+				resp.PendingForceClosingChannels,
+				forceClose,
+			)
+		}
+	}
+
+	return resp, nil
+}
+
+// PendingSweeps returns a list of all the outputs that have been handed to the
+// sweeper, but haven't yet been swept within a confirmed transaction.
+func (r *rpcServer) PendingSweeps(ctx context.Context,
+	in *lnrpc.PendingSweepsRequest) (*lnrpc.PendingSweepsResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Debugf("[pendingsweeps]")
+
+	reports := r.server.sweeper.PendingSweeps()
+
+	resp := &lnrpc.PendingSweepsResponse{
+		PendingSweeps: make([]*lnrpc.PendingSweep, 0, len(reports)),
+	}
+	for _, report := range reports {
+		pendingSweep := &lnrpc.PendingSweep{
+			Outpoint:          report.outPoint.String(),
+			Amount:            int64(report.amt),
+			WitnessType:       report.witnessType.String(),
+			DeadlineHeight:    report.deadline,
+			SatPerWeight:      int64(report.feeRate),
+			BroadcastAttempts: report.broadcastAttempts,
+		}
+		if report.sweepTxid != nil {
+			pendingSweep.SweepTxid = report.sweepTxid.String()
+		}
+
+		resp.PendingSweeps = append(resp.PendingSweeps, pendingSweep)
+	}
+
+	return resp, nil
+}
+
+// BumpFee raises the fee of an unconfirmed transaction. If the transaction was
+// crafted by the sweeper, or the specified output is being swept, then the
+// sweep transaction is replaced by one paying the requested fee rate (RBF).
+// Otherwise, a child transaction spending one of our outputs of the
+// transaction is broadcast, paying enough fee for the parent and child to
+// confirm at the requested fee rate (CPFP).
+func (r *rpcServer) BumpFee(ctx context.Context,
+	in *lnrpc.BumpFeeRequest) (*lnrpc.BumpFeeResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "sendcoins",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	// We'll first determine the fee rate, in sat/weight, that the new
+	// transaction should pay. If no preference was given, then we'll aim
+	// for confirmation within the next block.
+	feeRate, err := r.calculateCloseFeeRate(in.TargetConf, in.SatPerByte)
+	if err != nil {
+		return nil, err
+	}
+	feePerWeight := uint64(feeRate+999) / 1000
+	if feePerWeight == 0 {
+		feePerWeight = r.server.cc.feeEstimator.EstimateFeePerWeight(
+			urgentSweepConfTarget,
+		)
+	}
+
+	var (
+		txid *chainhash.Hash
+		op   *wire.OutPoint
+	)
+	switch {
+	case in.Txid != "" && in.Outpoint != "":
+		return nil, fmt.Errorf("either txid or outpoint may be set, " +
+			"but not both")
+
+	case in.Outpoint != "":
+		op, err = parseOutPoint(in.Outpoint)
+		if err != nil {
+			return nil, err
+		}
+		txid = &op.Hash
+
+	case in.Txid != "":
+		txid, err = chainhash.NewHashFromStr(in.Txid)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("either txid or outpoint must be set")
+	}
+
+	rpcsLog.Debugf("[bumpfee] txid=%v, outpoint=%v, fee_rate=%v "+
+		"sat/weight", txid, op, feePerWeight)
+
+	// If the output is being swept, or the transaction is a sweep
+	// transaction, then we're able to replace the sweep transaction
+	// directly.
+	sweepOp := op
+	if sweepOp == nil {
+		if sweepInput, ok := r.server.sweeper.FindSweepInput(*txid); ok {
+			sweepOp = &sweepInput
+		}
+	}
+	if sweepOp != nil {
+		sweepTxid, err := r.server.sweeper.BumpFee(*sweepOp, feePerWeight)
+		switch {
+		case err == nil:
+			rpcsLog.Infof("[bumpfee] replaced sweep of %v with "+
+				"txid=%v", sweepOp, sweepTxid)
+
+			return &lnrpc.BumpFeeResponse{
+				Txid:         sweepTxid.String(),
+				Method:       "rbf",
+				SatPerWeight: int64(feePerWeight),
+			}, nil
+
+		case err != ErrSweepNotPending:
+			return nil, err
+		}
+	}
+
+	// If the transaction is an unconfirmed commitment with an anchor
+	// output, then we'll CPFP it by spending our anchor.
+	childTxid, err := r.server.sweeper.BumpCommitment(*txid, feePerWeight)
+	switch {
+	case err == nil:
+		rpcsLog.Infof("[bumpfee] CPFP'd commitment %v via anchor with "+
+			"txid=%v", txid, childTxid)
+
+		return &lnrpc.BumpFeeResponse{
+			Txid:         childTxid.String(),
+			Method:       "cpfp",
+			SatPerWeight: int64(feePerWeight),
+		}, nil
+
+	case err != ErrSweepNotPending:
+		return nil, err
+	}
+
+	// Otherwise, we'll need to spend one of our outputs of the
+	// transaction within a child transaction, so we'll locate the
+	// transaction within the wallet.
+	wallet := r.server.cc.wallet
+	txDetails, err := wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+	var parentDetail *lnwallet.TransactionDetail
+	for _, txDetail := range txDetails {
+		if txDetail.Hash == *txid {
+			parentDetail = txDetail
+			break
+		}
+	}
+	switch {
+	case parentDetail == nil:
+		return nil, fmt.Errorf("transaction %v not found within the "+
+			"wallet", txid)
+	case parentDetail.NumConfirmations > 0:
+		return nil, fmt.Errorf("transaction %v is already confirmed",
+			txid)
+	case parentDetail.RawTx == nil:
+		return nil, fmt.Errorf("raw transaction %v unavailable", txid)
+	}
+	parent := parentDetail.RawTx
+
+	// The funding output of a channel can only be spent by both parties
+	// together, so if it was specified, then we'll instead spend the
+	// change output of the funding transaction.
+	if op != nil {
+		isFunding, err := r.isPendingFundingOutPoint(*op)
+		if err != nil {
+			return nil, err
+		}
+		if isFunding {
+			rpcsLog.Debugf("[bumpfee] %v is a funding output, "+
+				"spending funding change output instead", op)
+			op = nil
+		}
+	}
+
+	// If the output to spend wasn't specified, then we'll select the
+	// largest output of the transaction that's controlled by the wallet.
+	var outputIndex uint32
+	switch {
+	case op != nil:
+		if _, err := wallet.FetchInputInfo(op); err != nil {
+			return nil, r.unspendableOutputErr(*txid, op, err)
+		}
+		outputIndex = op.Index
+
+	default:
+		found := false
+		for i, txOut := range parent.TxOut {
+			outPoint := wire.OutPoint{Hash: *txid, Index: uint32(i)}
+			if _, err := wallet.FetchInputInfo(&outPoint); err != nil {
+				continue
+			}
+
+			if !found || txOut.Value > parent.TxOut[outputIndex].Value {
+				outputIndex = uint32(i)
+				found = true
+			}
+		}
+		if !found {
+			return nil, r.unspendableOutputErr(
+				*txid, nil, lnwallet.ErrNotMine,
+			)
+		}
+	}
+
+	childTx, err := r.server.sweeper.CreateCpfpTx(
+		parent, outputIndex, btcutil.Amount(parentDetail.TotalFees),
+		feePerWeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[bumpfee] broadcasting child tx (txid=%v) spending "+
+		"%v:%v", childTx.TxHash(), txid, outputIndex)
+
+	if err := wallet.PublishTransaction(childTx); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BumpFeeResponse{
+		Txid:         childTx.TxHash().String(),
+		Method:       "cpfp",
+		SatPerWeight: int64(feePerWeight),
+	}, nil
+}
+
+// isPendingFundingOutPoint returns true if the passed outpoint is the funding
+// output of a channel whose funding transaction hasn't yet confirmed.
+func (r *rpcServer) isPendingFundingOutPoint(op wire.OutPoint) (bool, error) {
+	pendingChannels, err := r.server.chanDB.FetchPendingChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return false, err
+	}
+
+	for _, channel := range pendingChannels {
+		if channel.FundingOutpoint == op {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// unspendableOutputErr returns a descriptive error for a BumpFee request whose
+// output, or transaction if op is nil, can't be spent by a child transaction.
+// The time-locked outputs of our commitment transactions are called out
+// explicitly, as they'll be swept by the sweeper once mature.
+func (r *rpcServer) unspendableOutputErr(txid chainhash.Hash,
+	op *wire.OutPoint, err error) error {
+
+	closeSummaries, dbErr := r.server.chanDB.FetchClosedChannels(true)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	for _, summary := range closeSummaries {
+		if summary.ClosingTXID != txid ||
+			summary.CloseType != channeldb.ForceClose {
+			continue
+		}
+
+		return fmt.Errorf("transaction %v is the commitment "+
+			"transaction of ChannelPoint(%v), our output is "+
+			"time-locked and can't be spent by a child "+
+			"transaction, it will be swept once mature", txid,
+			summary.ChanPoint)
+	}
+
+	if op != nil {
+		return fmt.Errorf("output %v can't be spent by the wallet: %v",
+			op, err)
+	}
+
+	return fmt.Errorf("transaction %v has no outputs that can be spent "+
+		"by the wallet", txid)
+}
+
+// parseOutPoint parses an outpoint of the form txid:index.
+func parseOutPoint(s string) (*wire.OutPoint, error) {
+	split := strings.Split(s, ":")
+	if len(split) != 2 {
+		return nil, fmt.Errorf("outpoint %v must be of the form "+
+			"txid:index", s)
+	}
+
+	txid, err := chainhash.NewHashFromStr(split[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid txid: %v", err)
+	}
+	index, err := strconv.ParseUint(split[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output index: %v", err)
+	}
+
+	return wire.NewOutPoint(txid, uint32(index)), nil
+}
+
+// ListChannels returns a description of all the open channels that this node
+// is a participant in.
+func (r *rpcServer) ListChannels(ctx context.Context,
+	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &lnrpc.ListChannelsResponse{}
+
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[listchannels] fetched %v channels from DB",
+		len(dbChannels))
+
+	for _, dbChannel := range dbChannels {
+		if dbChannel.IsPending {
+			continue
+		}
+
+		resp.Channels = append(
+			resp.Channels, r.createRPCOpenChannel(dbChannel),
+		)
+	}
+
+	return resp, nil
+}
+
+// createRPCOpenChannel creates an *lnrpc.ActiveChannel from the passed
+// *channeldb.OpenChannel.
+func (r *rpcServer) createRPCOpenChannel(
+	dbChannel *channeldb.OpenChannel) *lnrpc.ActiveChannel {
+
+	graph := r.server.chanDB.ChannelGraph()
+
+	nodePub := dbChannel.IdentityPub
+	nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
+	chanPoint := dbChannel.FundingOutpoint
+
+	// With the channel point known, retrieve the network channel ID from
+	// the database.
+	var chanID uint64
+	chanID, _ = graph.ChannelID(&chanPoint)
+
+	var peerOnline bool
+	if _, err := r.server.FindPeer(nodePub); err == nil {
+		peerOnline = true
+	}
+
+	// As this is required for display purposes, we'll calculate the
+	// weight of the commitment transaction. We also add on the estimated
+	// weight of the witness to calculate the weight of the transaction if
+	// it were to be immediately unilaterally broadcast.
+	utx := btcutil.NewTx(&dbChannel.CommitTx)
+	commitBaseWeight := blockchain.GetTransactionWeight(utx)
+	commitWeight := commitBaseWeight + lnwallet.WitnessCommitmentTxWeight
+
+	channel := &lnrpc.ActiveChannel{
+		Active:                peerOnline,
+		RemotePubkey:          nodeID,
+		ChannelPoint:          chanPoint.String(),
+		ChanId:                chanID,
+		Capacity:              int64(dbChannel.Capacity),
+		LocalBalance:          int64(dbChannel.LocalBalance.ToSatoshis()),
+		RemoteBalance:         int64(dbChannel.RemoteBalance.ToSatoshis()),
+		CommitFee:             int64(dbChannel.CommitFee),
+		CommitWeight:          commitWeight,
+		FeePerKw:              int64(dbChannel.FeePerKw),
+		TotalSatoshisSent:     int64(dbChannel.TotalMSatSent.ToSatoshis()),
+		TotalSatoshisReceived: int64(dbChannel.TotalMSatReceived.ToSatoshis()),
+		NumUpdates:            dbChannel.NumUpdates,
+		PendingHtlcs:          make([]*lnrpc.HTLC, len(dbChannel.Htlcs)),
+	}
+
+	for i, htlc := range dbChannel.Htlcs {
+		channel.PendingHtlcs[i] = &lnrpc.HTLC{
+			Incoming:         htlc.Incoming,
+			Amount:           int64(htlc.Amt),
+			HashLock:         htlc.RHash[:],
+			ExpirationHeight: htlc.RefundTimeout,
+		}
+	}
+
+	return channel
+}
+
+// ClosedChannels returns a description of all the closed channels that this
+// node was a participant in, along with the on-chain resolution of each of
+// their outputs. The channels may be filtered by how they were closed.
+func (r *rpcServer) ClosedChannels(ctx context.Context,
+	in *lnrpc.ClosedChannelsRequest) (*lnrpc.ClosedChannelsResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Debugf("[closedchannels]")
+
+	resp := &lnrpc.ClosedChannelsResponse{}
+
+	dbChannels, err := r.server.chanDB.FetchClosedChannels(false)
+	switch {
+	case err == channeldb.ErrNoClosedChannels:
+		return resp, nil
+	case err != nil:
+		return nil, err
+	}
+
+	// If the caller didn't specify any filters, then we'll return all the
+	// closed channels.
+	filterResults := in.Cooperative || in.LocalForce || in.RemoteForce ||
+		in.Breach || in.FundingCanceled || in.Abandoned
+
+	for _, dbChannel := range dbChannels {
+		var include bool
+		switch dbChannel.CloseType {
+		case channeldb.CooperativeClose:
+			include = in.Cooperative
+		case channeldb.ForceClose:
+			include = in.LocalForce
+		case channeldb.RemoteForceClose:
+			include = in.RemoteForce
+		case channeldb.BreachClose:
+			include = in.Breach
+		case channeldb.FundingCanceled:
+			include = in.FundingCanceled
+		case channeldb.Abandoned:
+			include = in.Abandoned
+		}
+		if filterResults && !include {
+			continue
+		}
+
+		channel, err := r.createRPCClosedChannel(dbChannel)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	return resp, nil
+}
+
+// createRPCClosedChannel creates an *lnrpc.ChannelCloseSummary from the
+// passed *channeldb.ChannelCloseSummary, including the resolution of each of
+// the channel's outputs.
+func (r *rpcServer) createRPCClosedChannel(
+	dbChannel *channeldb.ChannelCloseSummary) (*lnrpc.ChannelCloseSummary,
+	error) {
+
+	var closeType lnrpc.ChannelCloseSummary_ClosureType
+	switch dbChannel.CloseType {
+	case channeldb.CooperativeClose:
+		closeType = lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE
+	// Force closes recorded by prior versions in which we had no balance
+	// couldn't be attributed to either party when migrated, so they'll
+	// still be reported as local force closes.
+	case channeldb.ForceClose:
+		closeType = lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
+	case channeldb.RemoteForceClose:
+		closeType = lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
+	case channeldb.BreachClose:
+		closeType = lnrpc.ChannelCloseSummary_BREACH_CLOSE
+	case channeldb.FundingCanceled:
+		closeType = lnrpc.ChannelCloseSummary_FUNDING_CANCELED
+	case channeldb.Abandoned:
+		closeType = lnrpc.ChannelCloseSummary_ABANDONED
+	default:
+		return nil, fmt.Errorf("unknown close type %v for "+
+			"ChannelPoint(%v)", dbChannel.CloseType,
+			dbChannel.ChanPoint)
+	}
+
+	resolutions, err := r.server.chanDB.FetchCloseResolutions(
+		&dbChannel.ChanPoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	remotePub := dbChannel.RemotePub.SerializeCompressed()
+	channel := &lnrpc.ChannelCloseSummary{
+		ChannelPoint:      dbChannel.ChanPoint.String(),
+		ClosingTxHash:     dbChannel.ClosingTXID.String(),
+		RemotePubkey:      hex.EncodeToString(remotePub),
+		Capacity:          int64(dbChannel.Capacity),
+		CloseHeight:       dbChannel.CloseHeight,
+		SettledBalance:    int64(dbChannel.SettledBalance),
+		TimeLockedBalance: int64(dbChannel.TimeLockedBalance),
+		CloseType:         closeType,
+	}
+	for _, res := range resolutions {
+		resolution := &lnrpc.Resolution{
+			Outpoint: res.OutPoint.String(),
+			ResolutionType: lnrpc.Resolution_ResolutionType(
+				res.Type,
+			),
+			Outcome: lnrpc.Resolution_ResolutionOutcome(
+				res.Outcome,
+			),
+			AmountSat: int64(res.Amount),
+		}
+		if res.Outcome != channeldb.ResolutionPending {
+			resolution.SweepTxid = res.SweepTxid.String()
+		}
+
+		channel.Resolutions = append(channel.Resolutions, resolution)
+	}
+
+	return channel, nil
+}
+
+// SubscribeChannelEvents returns a uni-directional stream (server -> client)
+// for notifying the client of state changes of our channels.
+func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelEventsServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(updateStream.Context(),
+			"listchannels", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	client := r.server.channelNotifier.SubscribeChannelEvents()
+	defer client.Cancel()
+
+	for {
+		select {
+		case event := <-client.Updates:
+			update, err := r.marshallChannelEvent(
+				event.(*channelEvent),
+			)
+			if err != nil {
+				return err
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SubscribeHtlcEvents returns a uni-directional stream (server -> client) for
+// notifying the client of each HTLC forwarded, settled, failed, sent or
+// received by the switch.
+func (r *rpcServer) SubscribeHtlcEvents(req *lnrpc.HtlcEventSubscription,
+	updateStream lnrpc.Lightning_SubscribeHtlcEventsServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(updateStream.Context(),
+			"listchannels", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	client := r.server.htlcNotifier.SubscribeHtlcEvents()
+	defer client.Cancel()
+
+	for {
+		select {
+		case event := <-client.Updates:
+			update, err := marshallHtlcEvent(
+				event.(*htlcswitch.HtlcEvent),
+			)
+			if err != nil {
+				return err
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallHtlcEvent converts an HTLC event emitted by the switch into the
+// form expected by the gRPC service.
+func marshallHtlcEvent(event *htlcswitch.HtlcEvent) (*lnrpc.HtlcEvent, error) {
+	var eventType lnrpc.HtlcEvent_EventType
+	switch event.Type {
+	case htlcswitch.HtlcEventForward:
+		eventType = lnrpc.HtlcEvent_FORWARD
+	case htlcswitch.HtlcEventForwardFail:
+		eventType = lnrpc.HtlcEvent_FORWARD_FAIL
+	case htlcswitch.HtlcEventLinkFail:
+		eventType = lnrpc.HtlcEvent_LINK_FAIL
+	case htlcswitch.HtlcEventSettle:
+		eventType = lnrpc.HtlcEvent_SETTLE
+	case htlcswitch.HtlcEventFail:
+		eventType = lnrpc.HtlcEvent_FAIL
+	case htlcswitch.HtlcEventSend:
+		eventType = lnrpc.HtlcEvent_SEND
+	case htlcswitch.HtlcEventReceive:
+		eventType = lnrpc.HtlcEvent_RECEIVE
+	default:
+		return nil, fmt.Errorf("unknown htlc event type: %v",
+			event.Type)
+	}
+
+	update := &lnrpc.HtlcEvent{
+		EventType:       eventType,
+		IncomingChanId:  event.IncomingChanID.ToUint64(),
+		OutgoingChanId:  event.OutgoingChanID.ToUint64(),
+		IncomingHtlcId:  event.IncomingHtlcID,
+		OutgoingHtlcId:  event.OutgoingHtlcID,
+		IncomingAmtMsat: int64(event.IncomingAmt),
+		OutgoingAmtMsat: int64(event.OutgoingAmt),
+		PaymentHash:     event.PaymentHash[:],
+		RemoteFailure:   event.RemoteFailure,
+		FailureDetail:   event.FailureDetail,
+		TimestampNs:     event.Timestamp.UnixNano(),
+	}
+	if event.FailCode != lnwire.CodeNone {
+		update.FailureCode = event.FailCode.String()
+	}
+
+	return update, nil
+}
+
+// HtlcInterceptor dispatches a bi-directional streaming RPC which hands each
+// HTLC the switch is about to forward to the client, and resolves it once the
+// client responds. Only a single interceptor may be active at a time. Once
+// the stream is closed, all HTLCs still held on behalf of the client are
+// resumed.
+func (r *rpcServer) HtlcInterceptor(
+	interceptStream lnrpc.Lightning_HtlcInterceptorServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(interceptStream.Context(),
+			"sendpayment", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	// The interceptor is called from within the main event loop of the
+	// switch, so rather than blocking on the stream, we'll hand each
+	// forward off to the goroutine below.
+	forwards := make(chan *htlcswitch.InterceptedForward)
+	streamQuit := make(chan struct{})
+	interceptor := func(fwd *htlcswitch.InterceptedForward) {
+		go func() {
+			select {
+			case forwards <- fwd:
+			case <-streamQuit:
+			}
+		}()
+	}
+	if err := r.server.htlcSwitch.SetInterceptor(interceptor); err != nil {
+		return err
+	}
+
+	// Once the stream exits, we'll unregister the interceptor, which
+	// resumes any HTLCs the client didn't get around to resolving.
+	defer func() {
+		close(streamQuit)
+
+		if err := r.server.htlcSwitch.SetInterceptor(nil); err != nil {
+			rpcsLog.Errorf("unable to remove htlc interceptor: %v",
+				err)
+		}
+	}()
+
+	// Launch a new goroutine to handle reading resolutions from the
+	// client, so we're able to keep sending intercepted HTLCs while
+	// waiting on the next one.
+	errChan := make(chan error, 1)
+	responses := make(chan *lnrpc.ForwardHtlcInterceptResponse)
+	go func() {
+		for {
+			resp, err := interceptStream.Recv()
+			if err == io.EOF {
+				errChan <- nil
+				return
+			} else if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case responses <- resp:
+			case <-streamQuit:
+				return
+			}
+		}
+	}()
+
+	type heldKey struct {
+		chanID uint64
+		htlcID uint64
+	}
+	held := make(map[heldKey]*htlcswitch.InterceptedForward)
+
+	// Forwards may be resolved by the switch without the client's
+	// involvement, for example once they time out, so we'll watch for
+	// each of them to be resolved in order to stop tracking it.
+	resolvedFwds := make(chan heldKey)
+	watchResolved := func(key heldKey, fwd *htlcswitch.InterceptedForward) {
+		select {
+		case <-fwd.Resolved():
+		case <-streamQuit:
+			return
+		}
+
+		select {
+		case resolvedFwds <- key:
+		case <-streamQuit:
+		}
+	}
+
+	for {
+		select {
+		case fwd := <-forwards:
+			key := heldKey{
+				chanID: fwd.IncomingChanID.ToUint64(),
+				htlcID: fwd.IncomingHtlcID,
+			}
+			held[key] = fwd
+			go watchResolved(key, fwd)
+
+			err := interceptStream.Send(&lnrpc.ForwardHtlcInterceptRequest{
+				IncomingChanId:  key.chanID,
+				IncomingHtlcId:  key.htlcID,
+				OutgoingChanId:  fwd.OutgoingChanID.ToUint64(),
+				PaymentHash:     fwd.PaymentHash[:],
+				IncomingAmtMsat: int64(fwd.IncomingAmt),
+				OutgoingAmtMsat: int64(fwd.OutgoingAmt),
+				OutgoingExpiry:  fwd.OutgoingExpiry,
+			})
+			if err != nil {
+				return err
+			}
+
+		case resp := <-responses:
+			key := heldKey{
+				chanID: resp.IncomingChanId,
+				htlcID: resp.IncomingHtlcId,
+			}
+			fwd, ok := held[key]
+			if !ok {
+				rpcsLog.Warnf("Received resolution for unknown "+
+					"intercepted htlc: chan_id=%v, htlc_id=%v",
+					key.chanID, key.htlcID)
+				continue
+			}
 
-				// If the transaction has been confirmed, then
-				// we can compute how many blocks it has left.
-				if forceClose.MaturityHeight != 0 {
-					forceClose.BlocksTilMaturity = (forceClose.MaturityHeight -
-						uint32(currentHeight))
+			// If the forward was already failed back due to a
+			// timeout, then there's nothing left for us to do.
+			err := resolveInterceptedForward(fwd, resp)
+			switch {
+			case err == htlcswitch.ErrForwardNotFound:
+				rpcsLog.Warnf("Intercepted htlc(chan_id=%v, "+
+					"htlc_id=%v) already resolved",
+					key.chanID, key.htlcID)
+
+			// If the client's response is invalid, then we'll
+			// fail the HTLC back rather than hold it until it
+			// times out.
+			case err != nil:
+				rpcsLog.Errorf("Invalid resolution for intercepted "+
+					"htlc(chan_id=%v, htlc_id=%v), failing it "+
+					"back: %v", key.chanID, key.htlcID, err)
+
+				err := fwd.Fail(lnwire.CodeTemporaryChannelFailure)
+				if err != nil &&
+					err != htlcswitch.ErrForwardNotFound {
+
+					rpcsLog.Errorf("unable to fail intercepted "+
+						"htlc(chan_id=%v, htlc_id=%v): %v",
+						key.chanID, key.htlcID, err)
 				}
-
-				resp.TotalLimboBalance += int64(nurseryInfo.limboBalance)
 			}
 
-			resp.PendingForceClosingChannels = append(
-				resp.PendingForceClosingChannels,
-				forceClose,
-			)
-		}
-	}
+			delete(held, key)
 
-	return resp, nil
-}
+		// The switch no longer holds the forward, so there's no need
+		// for us to keep tracking it.
+		case key := <-resolvedFwds:
+			delete(held, key)
 
-// ListChannels returns a description of all the open channels that this node
-// is a participant in.
-func (r *rpcServer) ListChannels(ctx context.Context,
-	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+		case err := <-errChan:
+			return err
 
-	// Check macaroon to see if this is allowed.
-	if r.authSvc != nil {
-		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
-			r.authSvc); err != nil {
-			return nil, err
+		case <-r.quit:
+			return nil
 		}
 	}
+}
 
-	resp := &lnrpc.ListChannelsResponse{}
+// resolveInterceptedForward carries out the resolution requested by the
+// client of the HtlcInterceptor RPC for the passed intercepted forward.
+func resolveInterceptedForward(fwd *htlcswitch.InterceptedForward,
+	resp *lnrpc.ForwardHtlcInterceptResponse) error {
 
-	graph := r.server.chanDB.ChannelGraph()
+	switch resp.Action {
+	case lnrpc.ForwardHtlcInterceptResponse_RESUME:
+		return fwd.Resume()
 
-	dbChannels, err := r.server.chanDB.FetchAllChannels()
-	if err != nil {
-		return nil, err
-	}
+	case lnrpc.ForwardHtlcInterceptResponse_FAIL:
+		failCode := lnwire.FailCode(resp.FailureCode)
+		if failCode == lnwire.CodeNone {
+			failCode = lnwire.CodeTemporaryChannelFailure
+		}
 
-	rpcsLog.Infof("[listchannels] fetched %v channels from DB",
-		len(dbChannels))
+		return fwd.Fail(failCode)
 
-	for _, dbChannel := range dbChannels {
-		if dbChannel.IsPending {
-			continue
+	case lnrpc.ForwardHtlcInterceptResponse_SETTLE:
+		var preimage [32]byte
+		if len(resp.Preimage) != len(preimage) {
+			return fmt.Errorf("preimage must be exactly %v bytes, "+
+				"is instead %v", len(preimage), len(resp.Preimage))
 		}
+		copy(preimage[:], resp.Preimage)
 
-		nodePub := dbChannel.IdentityPub
-		nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
-		chanPoint := dbChannel.FundingOutpoint
+		return fwd.Settle(preimage)
 
-		// With the channel point known, retrieve the network channel
-		// ID from the database.
-		var chanID uint64
-		chanID, _ = graph.ChannelID(&chanPoint)
+	default:
+		return fmt.Errorf("unknown resolve action: %v", resp.Action)
+	}
+}
 
-		var peerOnline bool
-		if _, err := r.server.FindPeer(nodePub); err == nil {
-			peerOnline = true
-		}
+// marshallChannelEvent converts a channel event dispatched by the channel
+// notifier into the form expected by the gRPC service.
+func (r *rpcServer) marshallChannelEvent(
+	event *channelEvent) (*lnrpc.ChannelEventUpdate, error) {
+
+	update := &lnrpc.ChannelEventUpdate{
+		ChannelPoint: event.chanPoint.String(),
+	}
+
+	switch event.eventType {
+	case pendingOpenChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL
+	case openChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_OPEN_CHANNEL
+	case activeChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL
+	case inactiveChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL
+	case closingChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_CLOSING_CHANNEL
+	case closedChannelEvent:
+		update.Type = lnrpc.ChannelEventUpdate_CLOSED_CHANNEL
+	default:
+		return nil, fmt.Errorf("unknown channel event type: %v",
+			event.eventType)
+	}
 
-		// As this is required for display purposes, we'll calculate
-		// the weight of the commitment transaction. We also add on the
-		// estimated weight of the witness to calculate the weight of
-		// the transaction if it were to be immediately unilaterally
-		// broadcast.
-		utx := btcutil.NewTx(&dbChannel.CommitTx)
-		commitBaseWeight := blockchain.GetTransactionWeight(utx)
-		commitWeight := commitBaseWeight + lnwallet.WitnessCommitmentTxWeight
+	switch {
+	case event.channel != nil:
+		update.Channel = r.createRPCOpenChannel(event.channel)
 
-		channel := &lnrpc.ActiveChannel{
-			Active:                peerOnline,
-			RemotePubkey:          nodeID,
-			ChannelPoint:          chanPoint.String(),
-			ChanId:                chanID,
-			Capacity:              int64(dbChannel.Capacity),
-			LocalBalance:          int64(dbChannel.LocalBalance.ToSatoshis()),
-			RemoteBalance:         int64(dbChannel.RemoteBalance.ToSatoshis()),
-			CommitFee:             int64(dbChannel.CommitFee),
-			CommitWeight:          commitWeight,
-			FeePerKw:              int64(dbChannel.FeePerKw),
-			TotalSatoshisSent:     int64(dbChannel.TotalMSatSent.ToSatoshis()),
-			TotalSatoshisReceived: int64(dbChannel.TotalMSatReceived.ToSatoshis()),
-			NumUpdates:            dbChannel.NumUpdates,
-			PendingHtlcs:          make([]*lnrpc.HTLC, len(dbChannel.Htlcs)),
-		}
-
-		for i, htlc := range dbChannel.Htlcs {
-			channel.PendingHtlcs[i] = &lnrpc.HTLC{
-				Incoming:         htlc.Incoming,
-				Amount:           int64(htlc.Amt),
-				HashLock:         htlc.RHash[:],
-				ExpirationHeight: htlc.RefundTimeout,
-			}
+	case event.closeSummary != nil:
+		closedChannel, err := r.createRPCClosedChannel(
+			event.closeSummary,
+		)
+		if err != nil {
+			return nil, err
 		}
-
-		resp.Channels = append(resp.Channels, channel)
+		update.ClosedChannel = closedChannel
 	}
 
-	return resp, nil
+	return update, nil
 }
 
 // savePayment saves a successfully completed payment to the database for
@@ -1372,6 +2672,36 @@ func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi
 	return r.server.chanDB.AddPayment(payment)
 }
 
+// sendTrackedPayment records the lifecycle of the payment to the passed
+// payment hash while it's being sent using the passed closure. An error is
+// returned without sending the payment if the payment hash has already been
+// paid, or is currently being paid.
+func (r *rpcServer) sendTrackedPayment(paymentHash [32]byte,
+	amt lnwire.MilliSatoshi,
+	send func() ([32]byte, *routing.Route, error)) ([32]byte,
+	*routing.Route, error) {
+
+	err := r.server.paymentControl.InitPayment(paymentHash, amt)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	preImage, route, err := send()
+	if err != nil {
+		pErr := r.server.paymentControl.FailPayment(
+			paymentHash, err.Error(),
+		)
+		if pErr != nil {
+			rpcsLog.Errorf("unable to fail payment(%x): %v",
+				paymentHash[:], pErr)
+		}
+
+		return preImage, route, err
+	}
+
+	return preImage, route, nil
+}
+
 // SendPayment dispatches a bi-directional streaming RPC for sending payments
 // through the Lightning Network. A single RPC invocation creates a persistent
 // bi-directional stream allowing clients to rapidly send payments through the
@@ -1509,14 +2839,31 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 			if err != nil {
 				return err
 			}
+			lastHop, err := parseLastHop(nextPayment.LastHopPubkey)
+			if err != nil {
+				return err
+			}
 
-			// If we're in debug HTLC mode, then all outgoing HTLCs
-			// will pay to the same debug rHash. Otherwise, we pay
-			// to the rHash specified within the RPC request.
-			var rHash [32]byte
-			if cfg.DebugHTLC && len(nextPayment.PaymentHash) == 0 {
+			// If this is a spontaneous payment, then we'll
+			// generate its preimage ourselves. If we're in debug
+			// HTLC mode, then all outgoing HTLCs will pay to the
+			// same debug rHash. Otherwise, we pay to the rHash
+			// specified within the RPC request.
+			var (
+				rHash           [32]byte
+				keySendPreimage *[32]byte
+			)
+			switch {
+			case nextPayment.KeySend:
+				keySendPreimage, rHash, err = newKeySendPreimage(
+					nextPayment,
+				)
+				if err != nil {
+					return err
+				}
+			case cfg.DebugHTLC && len(nextPayment.PaymentHash) == 0:
 				rHash = debugHash
-			} else {
+			default:
 				copy(rHash[:], nextPayment.PaymentHash)
 			}
 
@@ -1538,11 +2885,22 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// returned. Otherwise, we'll get a non-nil
 				// error.
 				payment := &routing.LightningPayment{
-					Target:      destNode,
-					Amount:      amtMSat,
-					PaymentHash: rHash,
+					Target:            destNode,
+					Amount:            amtMSat,
+					PaymentHash:       rHash,
+					OutgoingChannelID: nextPayment.OutgoingChanId,
+					LastHop:           lastHop,
+					KeySendPreimage:   keySendPreimage,
 				}
-				preImage, route, err := r.server.chanRouter.SendPayment(payment)
+				preImage, route, err := r.sendTrackedPayment(
+					rHash, amtMSat, func() ([32]byte,
+						*routing.Route, error) {
+
+						return r.server.chanRouter.SendPayment(
+							payment,
+						)
+					},
+				)
 				if err != nil {
 					// If we receive payment error than,
 					// instead of terminating the stream,
@@ -1603,11 +2961,22 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	}
 
 	var (
-		destPub *btcec.PublicKey
-		amt     btcutil.Amount
-		rHash   [32]byte
+		destPub         *btcec.PublicKey
+		amt             btcutil.Amount
+		rHash           [32]byte
+		keySendPreimage *[32]byte
+		err             error
 	)
 
+	// If this is a spontaneous payment, then we'll generate its preimage
+	// ourselves, as there's no invoice to pay to.
+	if nextPayment.KeySend {
+		keySendPreimage, rHash, err = newKeySendPreimage(nextPayment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If the proto request has an encoded payment request, then we we'll
 	// use that solely to dispatch the payment.
 	if nextPayment.PaymentRequest != "" {
@@ -1625,61 +2994,408 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		// If we're in debug HTLC mode, then all outgoing HTLCs will
 		// pay to the same debug rHash. Otherwise, we pay to the rHash
 		// specified within the RPC request.
-		if cfg.DebugHTLC && nextPayment.PaymentHashString == "" {
+		switch {
+		// The payment hash of a spontaneous payment has already been
+		// derived from its preimage.
+		case nextPayment.KeySend:
+
+		case cfg.DebugHTLC && nextPayment.PaymentHashString == "":
 			rHash = debugHash
-		} else {
+		default:
 			paymentHash, err := hex.DecodeString(nextPayment.PaymentHashString)
 			if err != nil {
 				return nil, err
 			}
 
-			copy(rHash[:], paymentHash)
+			copy(rHash[:], paymentHash)
+		}
+
+		pubBytes, err := hex.DecodeString(nextPayment.DestString)
+		if err != nil {
+			return nil, err
+		}
+		destPub, err = btcec.ParsePubKey(pubBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		amt = btcutil.Amount(nextPayment.Amt)
+	}
+
+	// Currently, within the bootstrap phase of the network, we limit the
+	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
+	// satoshis.
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", nextPayment.Amt,
+			maxPaymentMSat.ToSatoshis())
+	}
+
+	lastHop, err := parseLastHop(nextPayment.LastHopPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, send a payment request to the channel router. If the
+	// payment succeeds, then the returned route will be that was used
+	// successfully within the payment.
+	payment := &routing.LightningPayment{
+		Target:            destPub,
+		Amount:            amtMSat,
+		PaymentHash:       rHash,
+		OutgoingChannelID: nextPayment.OutgoingChanId,
+		LastHop:           lastHop,
+		KeySendPreimage:   keySendPreimage,
+	}
+	preImage, route, err := r.sendTrackedPayment(rHash, amtMSat,
+		func() ([32]byte, *routing.Route, error) {
+			return r.server.chanRouter.SendPayment(payment)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the payment completed successfully, we now ave the details of
+	// the completed payment to the database for historical record keeping.
+	if err := r.savePayment(route, amtMSat, rHash[:]); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshalRoute(route),
+	}, nil
+}
+
+// SendToRoute dispatches a bi-directional streaming RPC for sending payments
+// along routes constructed by the caller. Each request is processed in turn,
+// with its outcome sent back over the stream before the next request is read.
+func (r *rpcServer) SendToRoute(stream lnrpc.Lightning_SendToRouteServer) error {
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(stream.Context(),
+			"sendpayment", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	if !r.server.Started() {
+		return fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// If the request itself is invalid, then we'll send the error
+		// to the caller, but continue serving the stream.
+		resp, err := r.sendToRoute(req)
+		if err != nil {
+			resp = &lnrpc.SendResponse{
+				PaymentError: err.Error(),
+			}
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// SendToRouteSync is the synchronous non-streaming version of SendToRoute.
+// This RPC is intended to be consumed by clients of the REST proxy.
+func (r *rpcServer) SendToRouteSync(ctx context.Context,
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "sendpayment",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	return r.sendToRoute(req)
+}
+
+// sendToRoute attempts the payment described by the passed request along its
+// routes. A non-nil error is only returned if the request is invalid. If the
+// payment fails, then the failure is described within the returned response,
+// along with the position of the node which failed it within the route of the
+// final attempt.
+func (r *rpcServer) sendToRoute(
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	var (
+		rHash     [32]byte
+		hashBytes = req.PaymentHash
+		err       error
+	)
+	if req.PaymentHashString != "" {
+		hashBytes, err = hex.DecodeString(req.PaymentHashString)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(hashBytes) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(hashBytes))
+	}
+	copy(rHash[:], hashBytes)
+
+	if len(req.Routes) == 0 {
+		return nil, fmt.Errorf("at least one route must be provided")
+	}
+	routes := make([]*routing.Route, len(req.Routes))
+	for i, rpcRoute := range req.Routes {
+		routes[i], err = r.unmarshalRoute(rpcRoute)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route %v: %v", i, err)
+		}
+	}
+
+	// The amount paid is the amount forwarded to the final hop of the
+	// route, excluding the fees paid along the way.
+	hops := routes[0].Hops
+	amt := hops[len(hops)-1].AmtToForward
+
+	preImage, route, err := r.sendTrackedPayment(rHash, amt,
+		func() ([32]byte, *routing.Route, error) {
+			return r.server.chanRouter.SendToRoute(routes, rHash)
+		},
+	)
+	if err != nil {
+		resp := &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}
+		if route != nil {
+			resp.PaymentRoute = marshalRoute(route)
+		}
+
+		// If the failure was generated by a remote node, then we'll
+		// locate it within the route so the caller knows where the
+		// payment failed. Otherwise, the payment failed before it
+		// left our own node.
+		fErr, ok := err.(*htlcswitch.ForwardingError)
+		if !ok || route == nil {
+			return resp, nil
+		}
+		resp.FailureCode = uint32(fErr.Code())
+		for i, hop := range route.Hops {
+			if fErr.ErrorSource != nil &&
+				hop.Channel.Node.PubKey.IsEqual(fErr.ErrorSource) {
+
+				resp.FailureSourceIndex = uint32(i + 1)
+				break
+			}
+		}
+
+		return resp, nil
+	}
+
+	amt = route.Hops[len(route.Hops)-1].AmtToForward
+	if err := r.savePayment(route, amt, rHash[:]); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshalRoute(route),
+	}, nil
+}
+
+// unmarshalRoute converts a route supplied by the caller of SendToRoute into a
+// routing.Route. Each hop is validated against our current view of the
+// channel graph, and the first hop against the bandwidth of our own link.
+func (r *rpcServer) unmarshalRoute(rpcRoute *lnrpc.Route) (*routing.Route,
+	error) {
+
+	if rpcRoute == nil || len(rpcRoute.Hops) == 0 {
+		return nil, fmt.Errorf("route has no hops")
+	}
+	if len(rpcRoute.Hops) > routing.HopLimit {
+		return nil, fmt.Errorf("route has %v hops, max allowed is %v",
+			len(rpcRoute.Hops), routing.HopLimit)
+	}
+
+	totalFees, err := unmarshalMSat(rpcRoute.TotalFeesMsat,
+		rpcRoute.TotalFees)
+	if err != nil {
+		return nil, err
+	}
+	totalAmt, err := unmarshalMSat(rpcRoute.TotalAmtMsat, rpcRoute.TotalAmt)
+	if err != nil {
+		return nil, err
+	}
+	if totalAmt > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max "+
+			"payment allowed is %v", totalAmt.ToSatoshis(),
+			maxPaymentMSat.ToSatoshis())
+	}
+
+	route := &routing.Route{
+		TotalTimeLock: rpcRoute.TotalTimeLock,
+		TotalFees:     totalFees,
+		TotalAmount:   totalAmt,
+		Hops:          make([]*routing.Hop, len(rpcRoute.Hops)),
+	}
+
+	// Starting from our own node, we'll walk the route, ensuring that the
+	// channel of each hop connects the node we've reached so far to the
+	// next one.
+	graph := r.server.chanDB.ChannelGraph()
+	prevNode := r.server.identityPriv.PubKey()
+	for i, rpcHop := range rpcRoute.Hops {
+		info, e1, e2, err := graph.FetchChannelEdgesByID(rpcHop.ChanId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find channel %v of "+
+				"hop %v: %v", rpcHop.ChanId, i, err)
+		}
+
+		// The policy used for each hop is the one of the node the
+		// channel leads to, matching the edges selected by path
+		// finding. The policy in the opposite direction is the one of
+		// the node forwarding over the channel.
+		var (
+			nextNode           *btcec.PublicKey
+			policy, prevPolicy *channeldb.ChannelEdgePolicy
+		)
+		switch {
+		case info.NodeKey1.IsEqual(prevNode):
+			nextNode, policy, prevPolicy = info.NodeKey2, e2, e1
+		case info.NodeKey2.IsEqual(prevNode):
+			nextNode, policy, prevPolicy = info.NodeKey1, e1, e2
+		default:
+			return nil, fmt.Errorf("channel %v of hop %v doesn't "+
+				"connect to the previous hop", rpcHop.ChanId, i)
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("channel %v of hop %v has no "+
+				"routing policy", rpcHop.ChanId, i)
+		}
+		if prevPolicy != nil &&
+			prevPolicy.Flags&lnwire.ChanUpdateDisabled != 0 {
+
+			return nil, fmt.Errorf("channel %v of hop %v is "+
+				"disabled", rpcHop.ChanId, i)
 		}
 
-		pubBytes, err := hex.DecodeString(nextPayment.DestString)
+		policy.Node, err = graph.FetchLightningNode(nextNode)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find node %x of hop "+
+				"%v: %v", nextNode.SerializeCompressed(), i, err)
+		}
+
+		amtToForward, err := unmarshalMSat(rpcHop.AmtToForwardMsat,
+			rpcHop.AmtToForward)
 		if err != nil {
 			return nil, err
 		}
-		destPub, err = btcec.ParsePubKey(pubBytes, btcec.S256())
+		fee, err := unmarshalMSat(rpcHop.FeeMsat, rpcHop.Fee)
 		if err != nil {
 			return nil, err
 		}
+		if amtToForward.ToSatoshis() > info.Capacity {
+			return nil, fmt.Errorf("channel %v of hop %v has "+
+				"insufficient capacity: need %v, have %v",
+				rpcHop.ChanId, i, amtToForward.ToSatoshis(),
+				info.Capacity)
+		}
 
-		amt = btcutil.Amount(nextPayment.Amt)
-	}
+		route.Hops[i] = &routing.Hop{
+			Channel: &routing.ChannelHop{
+				ChannelEdgePolicy: policy,
+				Capacity:          info.Capacity,
+			},
+			OutgoingTimeLock: rpcHop.Expiry,
+			AmtToForward:     amtToForward,
+			Fee:              fee,
+		}
 
-	// Currently, within the bootstrap phase of the network, we limit the
-	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
-	// satoshis.
-	amtMSat := lnwire.NewMSatFromSatoshis(amt)
-	if amtMSat > maxPaymentMSat {
-		return nil, fmt.Errorf("payment of %v is too large, max payment "+
-			"allowed is %v", nextPayment.Amt,
-			maxPaymentMSat.ToSatoshis())
+		prevNode = nextNode
 	}
 
-	// Finally, send a payment request to the channel router. If the
-	// payment succeeds, then the returned route will be that was used
-	// successfully within the payment.
-	preImage, route, err := r.server.chanRouter.SendPayment(&routing.LightningPayment{
-		Target:      destPub,
-		Amount:      amtMSat,
-		PaymentHash: rHash,
-	})
+	// Finally, we'll make sure the link of the first hop is active, and
+	// currently has enough bandwidth to carry the payment.
+	var firstHopPub [33]byte
+	copy(firstHopPub[:],
+		route.Hops[0].Channel.Node.PubKey.SerializeCompressed())
+	links, err := r.server.htlcSwitch.GetLinksByInterface(firstHopPub)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("no active link with first hop: %v", err)
 	}
 
-	// With the payment completed successfully, we now ave the details of
-	// the completed payment to the database for historical record keeping.
-	if err := r.savePayment(route, amtMSat, rHash[:]); err != nil {
-		return nil, err
+	firstChan := lnwire.NewShortChanIDFromInt(rpcRoute.Hops[0].ChanId)
+	for _, link := range links {
+		if link.ShortChanID() != firstChan {
+			continue
+		}
+
+		if link.Bandwidth() < route.TotalAmount {
+			return nil, fmt.Errorf("channel %v has insufficient "+
+				"bandwidth: need %v, have %v", firstChan,
+				route.TotalAmount, link.Bandwidth())
+		}
+
+		return route, nil
 	}
 
-	return &lnrpc.SendResponse{
-		PaymentPreimage: preImage[:],
-		PaymentRoute:    marshalRoute(route),
-	}, nil
+	return nil, fmt.Errorf("no active link for channel %v", firstChan)
+}
+
+// unmarshalMSat returns the milli-satoshi value of an amount within a route
+// supplied by the caller, preferring the precise milli-satoshi value if it was
+// set.
+func unmarshalMSat(msat, sat int64) (lnwire.MilliSatoshi, error) {
+	if msat < 0 || sat < 0 {
+		return 0, fmt.Errorf("amounts within a route must not be " +
+			"negative")
+	}
+
+	if msat != 0 {
+		return lnwire.MilliSatoshi(msat), nil
+	}
+	return lnwire.NewMSatFromSatoshis(btcutil.Amount(sat)), nil
+}
+
+// parseLastHop parses the optional last hop restriction of a payment or route
+// query. If no last hop was specified, then nil is returned.
+func parseLastHop(pubBytes []byte) (*btcec.PublicKey, error) {
+	if len(pubBytes) == 0 {
+		return nil, nil
+	}
+
+	return btcec.ParsePubKey(pubBytes, btcec.S256())
+}
+
+// newKeySendPreimage generates the preimage of the spontaneous payment
+// described by the passed request, returning it along with its payment hash.
+// As the payment doesn't pay to an invoice, the request must not specify a
+// payment hash or payment request.
+func newKeySendPreimage(req *lnrpc.SendRequest) (*[32]byte, [32]byte, error) {
+	if len(req.PaymentHash) != 0 || req.PaymentHashString != "" ||
+		req.PaymentRequest != "" {
+
+		return nil, [32]byte{}, fmt.Errorf("payment hash and payment " +
+			"request must be empty for spontaneous payments")
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	return &preimage, sha256.Sum256(preimage[:]), nil
 }
 
 // AddInvoice attempts to add a new invoice to the invoice database. Any
@@ -2275,14 +3991,89 @@ func (r *rpcServer) QueryRoutes(ctx context.Context,
 			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
-	// Query the channel router for a possible path to the destination that
-	// can carry `in.Amt` satoshis _including_ the total fee required on
-	// the route.
-	routes, err := r.server.chanRouter.FindRoutes(pubKey, amtMSat)
+	lastHop, err := parseLastHop(in.LastHopPubkey)
 	if err != nil {
 		return nil, err
 	}
 
+	// If the routes should start from a node other than ourselves, then
+	// we'll parse its public key as well.
+	var sourcePubKey *btcec.PublicKey
+	if in.SourcePubKey != "" {
+		sourceBytes, err := hex.DecodeString(in.SourcePubKey)
+		if err != nil {
+			return nil, err
+		}
+		sourcePubKey, err = btcec.ParsePubKey(sourceBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if in.FinalCltvDelta < 0 || in.FinalCltvDelta > math.MaxUint16 {
+		return nil, fmt.Errorf("final cltv delta of %v is out of range",
+			in.FinalCltvDelta)
+	}
+	if in.NumRoutes < 0 {
+		return nil, fmt.Errorf("number of routes must not be negative")
+	}
+	if in.FeeLimit < 0 {
+		return nil, fmt.Errorf("fee limit must not be negative")
+	}
+
+	// Next, we'll gather the nodes and directed channels which the routes
+	// must avoid.
+	restrictions := &routing.RouteRestrictions{
+		OutgoingChannelID: in.OutgoingChanId,
+		LastHop:           lastHop,
+		FeeLimit: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(in.FeeLimit),
+		),
+	}
+	for _, nodeBytes := range in.IgnoredNodes {
+		node, err := btcec.ParsePubKey(nodeBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		restrictions.IgnoredNodes = append(
+			restrictions.IgnoredNodes, node,
+		)
+	}
+	for _, edge := range in.IgnoredEdges {
+		locator := routing.EdgeLocator{ChannelID: edge.ChannelId}
+		if edge.DirectionReverse {
+			locator.Direction = 1
+		}
+		restrictions.IgnoredEdges = append(
+			restrictions.IgnoredEdges, locator,
+		)
+	}
+
+	// Query the channel router for a possible path to the destination that
+	// can carry `in.Amt` satoshis _including_ the total fee required on
+	// the route. If the first or last hop of the route is restricted,
+	// then only a single route honoring the restrictions is returned.
+	finalCLTVDelta := uint16(in.FinalCltvDelta)
+	var routes []*routing.Route
+	if in.OutgoingChanId != 0 || lastHop != nil {
+		route, err := r.server.chanRouter.FindRestrictedRoute(
+			sourcePubKey, pubKey, amtMSat, restrictions,
+			finalCLTVDelta,
+		)
+		if err != nil {
+			return nil, err
+		}
+		routes = []*routing.Route{route}
+	} else {
+		routes, err = r.server.chanRouter.FindRoutes(
+			sourcePubKey, pubKey, amtMSat, restrictions,
+			uint32(in.NumRoutes), finalCLTVDelta,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// For each valid route, we'll convert the result into the format
 	// required by the RPC system.
 	routeResp := &lnrpc.QueryRoutesResponse{
@@ -2301,14 +4092,18 @@ func marshalRoute(route *routing.Route) *lnrpc.Route {
 		TotalFees:     int64(route.TotalFees.ToSatoshis()),
 		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
 		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+		TotalFeesMsat: int64(route.TotalFees),
+		TotalAmtMsat:  int64(route.TotalAmount),
 	}
 	for i, hop := range route.Hops {
 		resp.Hops[i] = &lnrpc.Hop{
-			ChanId:       hop.Channel.ChannelID,
-			ChanCapacity: int64(hop.Channel.Capacity),
-			AmtToForward: int64(hop.AmtToForward.ToSatoshis()),
-			Fee:          int64(hop.Fee.ToSatoshis()),
-			Expiry:       uint32(hop.OutgoingTimeLock),
+			ChanId:           hop.Channel.ChannelID,
+			ChanCapacity:     int64(hop.Channel.Capacity),
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(hop.Fee.ToSatoshis()),
+			Expiry:           uint32(hop.OutgoingTimeLock),
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(hop.Fee),
 		}
 	}
 
@@ -2414,6 +4209,10 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 
 	// TODO(roasbeef): graph diameter
 
+	// We'll also report the amount of gossip we've dropped, which gives
+	// an indication of how noisy the network is.
+	gossipStats := r.server.authGossiper.Stats()
+
 	// TODO(roasbeef): also add oldest channel?
 	//  * also add median channel size
 	netInfo := &lnrpc.NetworkInfo{
@@ -2426,6 +4225,12 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 
 		MinChannelSize: int64(minChannelSize),
 		MaxChannelSize: int64(maxChannelSize),
+
+		NumRateLimitedUpdates: gossipStats.NumRateLimited,
+		NumDuplicateUpdates:   gossipStats.NumDuplicate,
+		NumInvalidGossip:      gossipStats.NumInvalid,
+		NumBannedPeerGossip:   gossipStats.NumFromBannedPeers,
+		NumBannedPeers:        gossipStats.NumBannedPeers,
 	}
 
 	// Similarly, if we don't have any channels, then we'll also set the
@@ -2599,27 +4404,117 @@ func (r *rpcServer) ListPayments(ctx context.Context,
 	if err != nil && err != channeldb.ErrNoPaymentsCreated {
 		return nil, err
 	}
+	records, err := r.server.chanDB.FetchAllPaymentRecords()
+	if err != nil {
+		return nil, err
+	}
+	recordIndex := make(map[[32]byte]*channeldb.PaymentRecord)
+	for _, record := range records {
+		recordIndex[record.PaymentHash] = record
+	}
 
 	paymentsResp := &lnrpc.ListPaymentsResponse{
-		Payments: make([]*lnrpc.Payment, len(payments)),
+		Payments: make([]*lnrpc.Payment, 0, len(payments)),
 	}
-	for i, payment := range payments {
+	for _, payment := range payments {
 		path := make([]string, len(payment.Path))
 		for i, hop := range payment.Path {
 			path[i] = hex.EncodeToString(hop[:])
 		}
 
-		paymentsResp.Payments[i] = &lnrpc.Payment{
+		rpcPayment := &lnrpc.Payment{
 			PaymentHash:  hex.EncodeToString(payment.PaymentHash[:]),
 			Value:        int64(payment.Terms.Value.ToSatoshis()),
 			CreationDate: payment.CreationDate.Unix(),
 			Path:         path,
+			Status:       lnrpc.Payment_SUCCEEDED,
+		}
+
+		// Payments made before their lifecycle was tracked won't have
+		// any record of their attempts.
+		if record, ok := recordIndex[payment.PaymentHash]; ok {
+			rpcPayment.Attempts = marshalPaymentAttempts(record)
 		}
+
+		paymentsResp.Payments = append(paymentsResp.Payments, rpcPayment)
+	}
+
+	// Payments which haven't succeeded are only known by their record, so
+	// we'll include those as well.
+	for _, record := range records {
+		if record.Status == channeldb.StatusSucceeded {
+			continue
+		}
+
+		paymentsResp.Payments = append(
+			paymentsResp.Payments, marshalPaymentRecord(record),
+		)
 	}
 
 	return paymentsResp, nil
 }
 
+// marshalPaymentRecord converts the record of an outgoing payment into the
+// form expected by the gRPC service. The path of the payment is that of its
+// most recent attempt.
+func marshalPaymentRecord(record *channeldb.PaymentRecord) *lnrpc.Payment {
+	payment := &lnrpc.Payment{
+		PaymentHash:   hex.EncodeToString(record.PaymentHash[:]),
+		Value:         int64(record.Value.ToSatoshis()),
+		CreationDate:  record.CreationDate.Unix(),
+		FailureReason: record.FailureReason,
+		Attempts:      marshalPaymentAttempts(record),
+	}
+
+	switch record.Status {
+	case channeldb.StatusInitiated:
+		payment.Status = lnrpc.Payment_INITIATED
+	case channeldb.StatusInFlight:
+		payment.Status = lnrpc.Payment_IN_FLIGHT
+	case channeldb.StatusSucceeded:
+		payment.Status = lnrpc.Payment_SUCCEEDED
+	case channeldb.StatusFailed:
+		payment.Status = lnrpc.Payment_FAILED
+	default:
+		payment.Status = lnrpc.Payment_UNKNOWN
+	}
+
+	if len(payment.Attempts) == 0 {
+		return payment
+	}
+	lastAttempt := record.Attempts[len(record.Attempts)-1]
+	payment.Path = payment.Attempts[len(payment.Attempts)-1].Path
+
+	if record.Status == channeldb.StatusSucceeded {
+		fee := lastAttempt.Amount - record.Value
+		payment.Fee = int64(fee.ToSatoshis())
+	}
+
+	return payment
+}
+
+// marshalPaymentAttempts converts the attempts made to complete an outgoing
+// payment into the form expected by the gRPC service.
+func marshalPaymentAttempts(
+	record *channeldb.PaymentRecord) []*lnrpc.PaymentAttempt {
+
+	attempts := make([]*lnrpc.PaymentAttempt, len(record.Attempts))
+	for i, attempt := range record.Attempts {
+		path := make([]string, len(attempt.Path))
+		for j, hop := range attempt.Path {
+			path[j] = hex.EncodeToString(hop[:])
+		}
+
+		attempts[i] = &lnrpc.PaymentAttempt{
+			Path:          path,
+			Amt:           int64(attempt.Amount.ToSatoshis()),
+			FailureReason: attempt.Failure,
+		}
+	}
+
+	return attempts
+}
+
 // DeleteAllPayments deletes all outgoing payments from DB.
 func (r *rpcServer) DeleteAllPayments(ctx context.Context,
 	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
@@ -2641,6 +4536,70 @@ func (r *rpcServer) DeleteAllPayments(ctx context.Context,
 	return &lnrpc.DeleteAllPaymentsResponse{}, nil
 }
 
+// TrackPayment sends the current state of the target outgoing payment over
+// the stream, followed by an update each time its state changes. The stream
+// is closed once the payment has either succeeded or failed.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(updateStream.Context(),
+			"listpayments", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	var (
+		paymentHash [32]byte
+		hashBytes   = req.PaymentHash
+		err         error
+	)
+	if req.PaymentHashString != "" {
+		hashBytes, err = hex.DecodeString(req.PaymentHashString)
+		if err != nil {
+			return err
+		}
+	}
+	if len(hashBytes) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(hashBytes))
+	}
+	copy(paymentHash[:], hashBytes)
+
+	rpcsLog.Debugf("[trackpayment] payment_hash=%x", paymentHash[:])
+
+	// We'll subscribe to updates before fetching the current state of the
+	// payment, so we don't miss any changes in between.
+	client := r.server.paymentControl.SubscribePayment(paymentHash)
+	defer client.Cancel()
+
+	for {
+		record, err := r.server.chanDB.FetchPaymentRecord(paymentHash)
+		if err != nil {
+			return err
+		}
+
+		err = updateStream.Send(marshalPaymentRecord(record))
+		if err != nil {
+			return err
+		}
+
+		switch record.Status {
+		case channeldb.StatusSucceeded, channeldb.StatusFailed:
+			return nil
+		}
+
+		select {
+		case <-client.Updates:
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
 // SetAlias...
 func (r *rpcServer) SetAlias(ctx context.Context,
 	_ *lnrpc.SetAliasRequest) (*lnrpc.SetAliasResponse, error) {
@@ -2870,3 +4829,175 @@ func (r *rpcServer) UpdateFees(ctx context.Context,
 
 	return &lnrpc.FeeUpdateResponse{}, nil
 }
+
+// UpdateChanStatus manually disables or enables one of our channels by
+// announcing a new channel update with the disable flag set or cleared.
+func (r *rpcServer) UpdateChanStatus(ctx context.Context,
+	req *lnrpc.UpdateChanStatusRequest) (*lnrpc.UpdateChanStatusResponse,
+	error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "updatechanstatus",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	chanPoint, err := parseOutPoint(req.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll make sure the channel is actually one of our open channels
+	// before handing it off to be announced.
+	channels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	var found bool
+	for _, channel := range channels {
+		if channel.FundingOutpoint == *chanPoint {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unable to find open channel with "+
+			"ChannelPoint(%v)", chanPoint)
+	}
+
+	rpcsLog.Infof("[updatechanstatus] ChannelPoint(%v), disable=%v",
+		chanPoint, req.Disable)
+
+	err = r.server.chanStatusMgr.SetChanStatus(*chanPoint, req.Disable)
+	if err == discovery.ErrOutgoingChanNotFound {
+		return nil, fmt.Errorf("ChannelPoint(%v) hasn't been "+
+			"announced yet", chanPoint)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UpdateChanStatusResponse{}, nil
+}
+
+// Rebalance moves funds from one of our channels into another by paying an
+// invoice to ourselves, routed out through the outgoing channel and back in
+// through the incoming channel. The payment is aborted if no route within the
+// specified fee limit can be found.
+func (r *rpcServer) Rebalance(ctx context.Context,
+	req *lnrpc.RebalanceRequest) (*lnrpc.RebalanceResponse, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "sendpayment",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	amt := btcutil.Amount(req.Amt)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	switch {
+	case req.Amt <= 0:
+		return nil, fmt.Errorf("amount to rebalance must be positive")
+
+	case amtMSat > maxPaymentMSat:
+		return nil, fmt.Errorf("payment of %v is too large, max "+
+			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+
+	case req.FeeLimit <= 0:
+		return nil, fmt.Errorf("fee limit must be positive")
+
+	case req.OutgoingChanId == req.IncomingChanId:
+		return nil, fmt.Errorf("outgoing and incoming channel must " +
+			"differ")
+	}
+
+	// Both ends of the rebalance must be open channels of ours. We'll
+	// also need the remote node of the incoming channel, as it'll be the
+	// last hop of the route.
+	channels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	var outgoing, incoming *channeldb.OpenChannel
+	for _, channel := range channels {
+		if channel.IsPending {
+			continue
+		}
+
+		switch channel.ShortChanID.ToUint64() {
+		case req.OutgoingChanId:
+			outgoing = channel
+		case req.IncomingChanId:
+			incoming = channel
+		}
+	}
+	if outgoing == nil {
+		return nil, fmt.Errorf("unable to find open channel with "+
+			"id %v", req.OutgoingChanId)
+	}
+	if incoming == nil {
+		return nil, fmt.Errorf("unable to find open channel with "+
+			"id %v", req.IncomingChanId)
+	}
+
+	// With the channels validated, we'll create an invoice to ourselves
+	// which the payment will settle once it returns to us.
+	var paymentPreimage [32]byte
+	if _, err := rand.Read(paymentPreimage[:]); err != nil {
+		return nil, err
+	}
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Memo:         []byte("rebalance"),
+		Terms: channeldb.ContractTerm{
+			Value: amtMSat,
+		},
+	}
+	copy(invoice.Terms.PaymentPreimage[:], paymentPreimage[:])
+	if err := r.server.invoices.AddInvoice(invoice); err != nil {
+		return nil, err
+	}
+	rHash := sha256.Sum256(paymentPreimage[:])
+
+	rpcsLog.Infof("[rebalance] moving %v from ChannelPoint(%v) to "+
+		"ChannelPoint(%v), fee_limit=%v", amt, outgoing.FundingOutpoint,
+		incoming.FundingOutpoint, btcutil.Amount(req.FeeLimit))
+
+	// The payment must leave through the outgoing channel, and return to
+	// us through the incoming channel specifically, rather than any other
+	// channel we might have with the same peer.
+	payment := &routing.LightningPayment{
+		Target:            r.server.identityPriv.PubKey(),
+		Amount:            amtMSat,
+		PaymentHash:       rHash,
+		OutgoingChannelID: req.OutgoingChanId,
+		LastHop:           incoming.IdentityPub,
+		LastChannelID:     req.IncomingChanId,
+		FeeLimit: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(req.FeeLimit),
+		),
+	}
+	preImage, route, err := r.sendTrackedPayment(rHash, amtMSat,
+		func() ([32]byte, *routing.Route, error) {
+			return r.server.chanRouter.SendPayment(payment)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.savePayment(route, amtMSat, rHash[:]); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.RebalanceResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshalRoute(route),
+	}, nil
+}
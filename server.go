@@ -12,12 +12,14 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/btcec"
@@ -70,12 +72,43 @@ type server struct {
 	invoices      *invoiceRegistry
 	breachArbiter *breachArbiter
 
+	// channelNotifier dispatches a notification to all interested clients
+	// each time one of our channels changes state.
+	channelNotifier *channelNotifier
+
+	// peerNotifier dispatches a notification to all interested clients
+	// each time a peer comes online or goes offline.
+	peerNotifier *peerNotifier
+
+	// htlcNotifier dispatches a notification to all interested clients
+	// each time the switch forwards, settles, fails, sends or receives an
+	// HTLC.
+	htlcNotifier *htlcNotifier
+
+	// chanStatusMgr announces our channels as disabled once their peers
+	// have been offline for a while, and enables them once again when
+	// their links become active.
+	chanStatusMgr *chanStatusManager
+
+	// paymentControl records the lifecycle of each outgoing payment, and
+	// notifies the clients tracking a payment of its progress.
+	paymentControl *paymentControl
+
+	// witnessBeacon is the global preimage cache of the daemon. It's used
+	// to claim incoming HTLC's on-chain, and is populated by all the
+	// preimages we learn of either off-chain or on-chain.
+	witnessBeacon *preimageBeacon
+
 	chanRouter *routing.ChannelRouter
 
 	authGossiper *discovery.AuthenticatedGossiper
 
 	utxoNursery *utxoNursery
 
+	sweeper *utxoSweeper
+
+	contractCourt *contractCourt
+
 	sphinx *htlcswitch.OnionProcessor
 
 	connMgr *connmgr.ConnManager
@@ -118,9 +151,12 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		chanDB: chanDB,
 		cc:     cc,
 
-		invoices: newInvoiceRegistry(chanDB),
-
-		utxoNursery: newUtxoNursery(chanDB, cc.chainNotifier, cc.wallet),
+		invoices:        newInvoiceRegistry(chanDB),
+		witnessBeacon:   newPreimageBeacon(chanDB),
+		channelNotifier: newChannelNotifier(chanDB),
+		peerNotifier:    newPeerNotifier(chanDB),
+		htlcNotifier:    newHtlcNotifier(),
+		paymentControl:  newPaymentControl(chanDB),
 
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
@@ -145,6 +181,19 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		quit: make(chan struct{}),
 	}
 
+	s.chanStatusMgr = newChanStatusManager(
+		chanDB, cfg.ChanDisableTimeout,
+		func(chanPoint wire.OutPoint, disabled bool) error {
+			return s.authGossiper.PropagateChanStatus(
+				chanPoint, disabled,
+			)
+		},
+		func(pubKey *btcec.PublicKey) bool {
+			_, err := s.FindPeer(pubKey)
+			return err == nil
+		},
+	)
+
 	// If the debug HTLC flag is on, then we invoice a "master debug"
 	// invoice which all outgoing payments will be sent and all incoming
 	// HTLCs with the debug R-Hash immediately settled.
@@ -182,8 +231,41 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			s.authGossiper.ProcessRemoteAnnouncement(msg, nil)
 			return nil
 		},
+		NotifyActiveChannel: func(chanID lnwire.ChannelID) {
+			s.channelNotifier.NotifyActiveChannelEvent(chanID)
+			s.chanStatusMgr.ChanActive(chanID)
+		},
+		NotifyInactiveChannel: s.channelNotifier.NotifyInactiveChannelEvent,
+		NotifyHtlcEvent:       s.htlcNotifier.NotifyHtlcEvent,
+		InterceptTimeout:      cfg.InterceptTimeout,
+		HeldForwards:          chanDB,
+		DecodeOnionObfuscator: s.sphinx.DecodeOnionObfuscator,
+	})
+
+	// The sweeper batches all the outputs handed to it by the utxoNursery
+	// and the breachArbiter into transactions sweeping them into the
+	// wallet.
+	s.sweeper = newUtxoSweeper(&SweeperConfig{
+		ChainIO:   cc.chainIO,
+		Estimator: cc.feeEstimator,
+		GenSweepScript: func() ([]byte, error) {
+			return newSweepPkScript(cc.wallet)
+		},
+		Notifier:           cc.chainNotifier,
+		PublishTransaction: cc.wallet.PublishTransaction,
+		Signer:             cc.wallet.Cfg.Signer,
+		Wallet:             cc.wallet.WalletController,
+		BatchWindow:        defaultSweepBatchWindow,
+		FeeRateBucketSize:  defaultFeeRateBucketSize,
+		RecordResolution:   chanDB.PutCloseResolution,
 	})
 
+	// The utxoNursery settles any HTLC's backwards through the switch
+	// once it learns of their preimages on-chain.
+	s.utxoNursery = newUtxoNursery(chanDB, cc.chainNotifier, cc.wallet,
+		s.witnessBeacon, s.htlcSwitch.SettleHTLC, s.sweeper.SweepOutput,
+		s.channelNotifier.NotifyClosedChannelEvent)
+
 	// If external IP addresses have been specified, add those to the list
 	// of this server's addresses.
 	selfAddrs := make([]net.Addr, 0, len(cfg.ExternalIPs))
@@ -252,6 +334,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		Chain:     cc.chainIO,
 		ChainView: cc.chainView,
 		SendToSwitch: func(firstHop *btcec.PublicKey,
+			outgoingChan lnwire.ShortChannelID,
 			htlcAdd *lnwire.UpdateAddHTLC,
 			circuit *sphinx.Circuit) ([32]byte, error) {
 
@@ -262,11 +345,72 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 				OnionDeobfuscator: sphinx.NewOnionDeobfuscator(circuit),
 			}
 
+			// Before the HTLC leaves our node, we'll record the
+			// attempt, so its outcome can still be learned if we
+			// restart while it's in flight.
+			attempt := &channeldb.PaymentAttempt{
+				SessionKey: circuit.SessionKey,
+				Path:       make([][33]byte, len(circuit.PaymentPath)),
+				Amount:     htlcAdd.Amount,
+				TimeLock:   htlcAdd.Expiry,
+			}
+			for i, hop := range circuit.PaymentPath {
+				copy(attempt.Path[i][:], hop.SerializeCompressed())
+			}
+			paymentHash := htlcAdd.PaymentHash
+			err := s.paymentControl.RegisterAttempt(paymentHash, attempt)
+			if err != nil {
+				return [32]byte{}, err
+			}
+
 			var firstHopPub [33]byte
 			copy(firstHopPub[:], firstHop.SerializeCompressed())
 
-			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
+			preimage, err := s.htlcSwitch.SendHTLC(firstHopPub,
+				outgoingChan, htlcAdd, errorDecryptor)
+
+			// If we're shutting down, then the HTLC may still be in
+			// flight, in which case the payment will be resumed
+			// upon restart.
+			if err != nil && s.Stopped() {
+				return preimage, err
+			}
+			if err != nil {
+				pErr := s.paymentControl.FailAttempt(
+					paymentHash, err.Error(),
+				)
+				if pErr != nil {
+					srvrLog.Errorf("unable to fail attempt "+
+						"of payment(%x): %v", paymentHash[:],
+						pErr)
+				}
+				return preimage, err
+			}
+
+			err = s.paymentControl.SucceedPayment(paymentHash, preimage)
+			if err != nil {
+				srvrLog.Errorf("unable to mark payment(%x) as "+
+					"succeeded: %v", paymentHash[:], err)
+			}
+
+			return preimage, nil
 		},
+		QueryBandwidth: func(
+			edge *channeldb.ChannelEdgeInfo) lnwire.MilliSatoshi {
+
+			// If the switch doesn't have a link for this channel,
+			// then it isn't active, so nothing can be sent over
+			// it.
+			chanID := lnwire.NewChanIDFromOutPoint(&edge.ChannelPoint)
+			link, err := s.htlcSwitch.GetLink(chanID)
+			if err != nil {
+				return 0
+			}
+
+			return link.Bandwidth()
+		},
+		ChannelPruneExpiry: cfg.ChannelPruneExpiry,
+		GraphPruneInterval: time.Hour,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
@@ -282,6 +426,12 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		TrickleDelay:     time.Millisecond * 300,
 		DB:               chanDB,
 		AnnSigner:        s.nodeSigner,
+		ChannelSeries:    discovery.NewChanSeries(chanGraph),
+		NumActiveSyncers: cfg.NumGraphSyncPeers,
+		UpdateInterval:   cfg.GossipRateInterval,
+		MaxUpdateBurst:   cfg.MaxGossipBurst,
+		BanThreshold:     cfg.GossipBanThreshold,
+		BanDuration:      cfg.GossipBanDuration,
 	},
 		s.identityPriv.PubKey(),
 	)
@@ -294,21 +444,30 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		closureType htlcswitch.ChannelCloseType) {
 		// TODO(conner): Properly respect the update and error channels
 		// returned by CloseLink.
-		s.htlcSwitch.CloseLink(chanPoint, closureType)
+		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0, nil)
 	}
 
 	s.breachArbiter = newBreachArbiter(&BreachConfig{
-		Signer:             cc.wallet.Cfg.Signer,
-		DB:                 chanDB,
-		PublishTransaction: cc.wallet.PublishTransaction,
-		Notifier:           cc.chainNotifier,
-		ChainIO:            s.cc.chainIO,
-		Estimator:          s.cc.feeEstimator,
-		CloseLink:          closeLink,
-		Store:              newRetributionStore(chanDB),
-		GenSweepScript: func() ([]byte, error) {
-			return newSweepPkScript(cc.wallet)
-		},
+		DB:              chanDB,
+		Notifier:        cc.chainNotifier,
+		PreimageCache:   s.witnessBeacon,
+		ChainIO:         s.cc.chainIO,
+		Estimator:       s.cc.feeEstimator,
+		CloseLink:       closeLink,
+		Store:           newRetributionStore(chanDB),
+		IncubateOutputs: s.utxoNursery.IncubateOutputs,
+		SweepOutput:     s.sweeper.SweepOutput,
+		NotifyClosingChannel: s.channelNotifier.
+			NotifyClosingChannelEvent,
+		NotifyClosedChannel: s.channelNotifier.NotifyClosedChannelEvent,
+	})
+
+	s.contractCourt = newContractCourt(&ContractCourtConfig{
+		DB:             chanDB,
+		Notifier:       cc.chainNotifier,
+		PreimageCache:  s.witnessBeacon,
+		BroadcastDelta: defaultBroadcastDelta,
+		ForceCloseChan: s.forceCloseChanPoint,
 	})
 
 	// Create the connection manager which will be responsible for
@@ -358,12 +517,25 @@ func (s *server) Start() error {
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+
+	// Before any links are added to the switch, we'll resume tracking the
+	// payments which were in flight when we last shut down.
+	if err := s.resumePayments(); err != nil {
+		return err
+	}
+
+	if err := s.sweeper.Start(); err != nil {
+		return err
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
 	if err := s.breachArbiter.Start(); err != nil {
 		return err
 	}
+	if err := s.contractCourt.Start(); err != nil {
+		return err
+	}
 	if err := s.authGossiper.Start(); err != nil {
 		return err
 	}
@@ -415,7 +587,10 @@ func (s *server) Stop() error {
 	s.htlcSwitch.Stop()
 	s.utxoNursery.Stop()
 	s.breachArbiter.Stop()
+	s.contractCourt.Stop()
+	s.sweeper.Stop()
 	s.authGossiper.Stop()
+	s.chanStatusMgr.Stop()
 	s.cc.wallet.Shutdown()
 	s.cc.chainView.Stop()
 	s.connMgr.Stop()
@@ -444,6 +619,147 @@ func (s *server) WaitForShutdown() {
 	s.wg.Wait()
 }
 
+// resumePayments resumes tracking each payment which had an HTLC in flight
+// when the daemon last shut down, so its outcome is recorded once the HTLC is
+// either settled or failed. Payments which had no HTLC in flight can't make
+// any further progress, so they're marked as failed.
+//
+// NOTE: If the outcome of an in flight HTLC was learned right before the
+// daemon shut down, but wasn't recorded yet, then the HTLC won't be resolved
+// again, in which case the payment remains in flight.
+func (s *server) resumePayments() error {
+	records, err := s.chanDB.FetchAllPaymentRecords()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		paymentHash := record.PaymentHash
+
+		switch record.Status {
+		case channeldb.StatusInitiated:
+			srvrLog.Infof("Failing payment(%x) interrupted by "+
+				"restart", paymentHash[:])
+
+			err := s.paymentControl.FailPayment(
+				paymentHash, "payment interrupted by restart",
+			)
+			if err != nil {
+				return err
+			}
+			continue
+
+		case channeldb.StatusInFlight:
+
+		default:
+			continue
+		}
+
+		// With the payment in flight, we'll re-create the circuit of
+		// its last attempt, so we're able to decrypt any failure sent
+		// back for its HTLC.
+		attempt := record.Attempts[len(record.Attempts)-1]
+		circuit := &sphinx.Circuit{
+			SessionKey:  attempt.SessionKey,
+			PaymentPath: make([]*btcec.PublicKey, len(attempt.Path)),
+		}
+		for i, hop := range attempt.Path {
+			circuit.PaymentPath[i], err = btcec.ParsePubKey(
+				hop[:], btcec.S256(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		errorDecryptor := &htlcswitch.FailureDeobfuscator{
+			OnionDeobfuscator: sphinx.NewOnionDeobfuscator(circuit),
+		}
+
+		srvrLog.Infof("Resuming payment(%x) with htlc of %v in flight",
+			paymentHash[:], attempt.Amount)
+
+		wait := s.htlcSwitch.ResumePayment(
+			paymentHash, attempt.Amount, errorDecryptor,
+		)
+
+		s.wg.Add(1)
+		go func(record *channeldb.PaymentRecord,
+			attempt *channeldb.PaymentAttempt) {
+
+			defer s.wg.Done()
+
+			preimage, err := wait()
+			switch {
+			// If we're shutting down, then the payment remains in
+			// flight, and will be resumed once again upon restart.
+			case err != nil && s.Stopped():
+				return
+
+			case err != nil:
+				s.failResumedPayment(record.PaymentHash, err)
+
+			default:
+				s.succeedResumedPayment(record, attempt, preimage)
+			}
+		}(record, attempt)
+	}
+
+	return nil
+}
+
+// failResumedPayment records the failure of a payment which was resumed upon
+// startup. As the route of the payment is no longer known, no further
+// attempts are made to complete it.
+func (s *server) failResumedPayment(paymentHash [32]byte, payErr error) {
+	srvrLog.Infof("Resumed payment(%x) failed: %v", paymentHash[:], payErr)
+
+	err := s.paymentControl.FailAttempt(paymentHash, payErr.Error())
+	if err != nil {
+		srvrLog.Errorf("unable to fail attempt of payment(%x): %v",
+			paymentHash[:], err)
+		return
+	}
+
+	err = s.paymentControl.FailPayment(paymentHash, payErr.Error())
+	if err != nil {
+		srvrLog.Errorf("unable to fail payment(%x): %v",
+			paymentHash[:], err)
+	}
+}
+
+// succeedResumedPayment records the success of a payment which was resumed
+// upon startup, adding it to the set of completed payments.
+func (s *server) succeedResumedPayment(record *channeldb.PaymentRecord,
+	attempt *channeldb.PaymentAttempt, preimage [32]byte) {
+
+	paymentHash := record.PaymentHash
+	srvrLog.Infof("Resumed payment(%x) succeeded", paymentHash[:])
+
+	err := s.paymentControl.SucceedPayment(paymentHash, preimage)
+	if err != nil {
+		srvrLog.Errorf("unable to mark payment(%x) as succeeded: %v",
+			paymentHash[:], err)
+		return
+	}
+
+	payment := &channeldb.OutgoingPayment{
+		Invoice: channeldb.Invoice{
+			Terms: channeldb.ContractTerm{
+				Value: record.Value,
+			},
+			CreationDate: record.CreationDate,
+		},
+		Path:           attempt.Path,
+		Fee:            attempt.Amount - record.Value,
+		TimeLockLength: attempt.TimeLock,
+		PaymentHash:    paymentHash,
+	}
+	if err := s.chanDB.AddPayment(payment); err != nil {
+		srvrLog.Errorf("unable to save payment(%x): %v",
+			paymentHash[:], err)
+	}
+}
+
 // initNetworkBootstrappers initializes a set of network peer bootstrappers
 // based on the server, and currently active bootstrap mechanisms as defined
 // within the current configuration.
@@ -799,24 +1115,25 @@ func (s *server) establishPersistentConnections() error {
 }
 
 // BroadcastMessage sends a request to the server to broadcast a set of
-// messages to all peers other than the one specified by the `skip` parameter.
+// messages to all peers other than the ones specified by the `skips`
+// parameter.
 //
 // NOTE: This function is safe for concurrent access.
-func (s *server) BroadcastMessage(skip *btcec.PublicKey,
+func (s *server) BroadcastMessage(skips map[[33]byte]struct{},
 	msgs ...lnwire.Message) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	return s.broadcastMessages(skip, msgs)
+	return s.broadcastMessages(skips, msgs)
 }
 
 // broadcastMessages is an internal method that delivers messages to all active
-// peers except the one specified by `skip`.
+// peers except the ones specified by `skips`.
 //
 // NOTE: This method MUST be called while the server's mutex is locked.
 func (s *server) broadcastMessages(
-	skip *btcec.PublicKey,
+	skips map[[33]byte]struct{},
 	msgs []lnwire.Message) error {
 
 	srvrLog.Debugf("Broadcasting %v messages", len(msgs))
@@ -827,11 +1144,9 @@ func (s *server) broadcastMessages(
 	// of peers present at the time of invocation.
 	var wg sync.WaitGroup
 	for _, sPeer := range s.peersByPub {
-		if skip != nil &&
-			sPeer.addr.IdentityKey.IsEqual(skip) {
-
-			srvrLog.Debugf("Skipping %v in broadcast",
-				skip.SerializeCompressed())
+		if _, ok := skips[sPeer.PubKey()]; ok {
+			srvrLog.Tracef("Skipping %x in broadcast",
+				sPeer.PubKey())
 
 			continue
 		}
@@ -970,10 +1285,28 @@ func (s *server) findPeer(peerID string) (*peer, error) {
 func (s *server) peerTerminationWatcher(p *peer) {
 	defer p.wg.Done()
 
+	p.RLock()
+	timeConnected := p.timeConnected
+	p.RUnlock()
+
 	p.WaitForDisconnect()
 
 	srvrLog.Debugf("Peer %v has been disconnected", p)
 
+	reason := "unknown"
+	if p.disconnectReason != nil {
+		reason = p.disconnectReason.Error()
+	}
+	s.peerNotifier.NotifyPeerOffline(
+		p.addr.IdentityKey, p.String(), p.inbound,
+		time.Since(timeConnected), reason,
+	)
+	s.chanStatusMgr.PeerOffline(p.addr.IdentityKey)
+
+	// The gossiper no longer needs to synchronize the channel graph with
+	// this peer, so we'll stop its gossipSyncer, if it has one.
+	s.authGossiper.PruneSyncState(p.addr.IdentityKey)
+
 	// If the server is exiting then we can bail out early ourselves as all
 	// the other sub-systems will already be shutting down.
 	if s.Stopped() {
@@ -1263,6 +1596,18 @@ func (s *server) addPeer(p *peer) {
 		s.outboundPeers[pubStr] = p
 	}
 
+	// We'll notify interested clients of the new connection before we
+	// launch the termination watcher of the peer, ensuring the connection
+	// is always recorded before its termination.
+	p.RLock()
+	timeConnected := p.timeConnected
+	p.RUnlock()
+
+	s.peerNotifier.NotifyPeerOnline(
+		p.addr.IdentityKey, p.String(), p.inbound, timeConnected,
+	)
+	s.chanStatusMgr.PeerOnline(p.addr.IdentityKey)
+
 	// Launch a goroutine to watch for the termination of this peer so we
 	// can ensure all resources are properly cleaned up and if need be
 	// connections are re-established.  The go routine is tracked by the
@@ -1271,10 +1616,15 @@ func (s *server) addPeer(p *peer) {
 	p.wg.Add(1)
 	go s.peerTerminationWatcher(p)
 
-	// Once the peer has been added to our indexes, send a message to the
-	// channel router so we can synchronize our view of the channel graph
-	// with this new peer.
-	go s.authGossiper.SynchronizeNode(p.addr.IdentityKey)
+	// Once the peer has been added to our indexes, we'll synchronize our
+	// view of the channel graph with this new peer. If it supports gossip
+	// queries, then we'll only exchange the channels and updates either
+	// side is missing. Otherwise, we'll send it our entire graph.
+	if p.localSharedFeatures.IsActive(gossipQueriesFeature) {
+		s.authGossiper.InitSyncState(p.addr.IdentityKey)
+	} else {
+		go s.authGossiper.SynchronizeNode(p.addr.IdentityKey)
+	}
 }
 
 // removePeer removes the passed peer from the server's state of all active
@@ -1321,6 +1671,10 @@ type openChanReq struct {
 
 	pushAmt lnwire.MilliSatoshi
 
+	// shutdownScript is an optional upfront shutdown script. If set, we'll
+	// commit to paying our funds to this script upon a cooperative close.
+	shutdownScript lnwire.DeliveryAddress
+
 	// TODO(roasbeef): add ability to specify channel constraints as well
 
 	updates chan *lnrpc.OpenStatusUpdate
@@ -1436,12 +1790,15 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 }
 
 // OpenChannel sends a request to the server to open a channel to the specified
-// peer identified by ID with the passed channel funding parameters.
+// peer identified by ID with the passed channel funding parameters. If
+// shutdownScript is non-nil, then it'll be committed to as our upfront
+// shutdown script for the channel.
 //
 // NOTE: This function is safe for concurrent access.
 func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
-	localAmt btcutil.Amount,
-	pushAmt lnwire.MilliSatoshi) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	localAmt btcutil.Amount, pushAmt lnwire.MilliSatoshi,
+	shutdownScript lnwire.DeliveryAddress) (chan *lnrpc.OpenStatusUpdate,
+	chan error) {
 
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
 	errChan := make(chan error, 1)
@@ -1484,6 +1841,7 @@ func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
 		chainHash:       *activeNetParams.GenesisHash,
 		localFundingAmt: localAmt,
 		pushAmt:         pushAmt,
+		shutdownScript:  shutdownScript,
 		updates:         updateChan,
 		err:             errChan,
 	}
@@ -1495,6 +1853,109 @@ func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
 	return updateChan, errChan
 }
 
+// BatchOpenChannel sends a request to the server to open a set of channels
+// with distinct peers, all funded by a single transaction with a single change
+// output. The funding transaction is only broadcast once every peer has
+// signed our commitment transaction, if any peer fails, then none of the
+// channels are opened. A pending update will be sent over the returned update
+// channel for each channel in the batch once the funding transaction has been
+// broadcast.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) BatchOpenChannel(reqs []*openChanReq) (
+	chan *lnrpc.OpenStatusUpdate, chan error) {
+
+	// Each channel will send both a pending and an open update, so we'll
+	// size the update channel accordingly to ensure the funding manager
+	// never blocks on a slow caller.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate, len(reqs)*2)
+	errChan := make(chan error, 1)
+
+	// First, we'll locate each of the target peers. If we're unable to
+	// locate any single peer, then the entire batch will fail.
+	fundingReqs := make([]*initFundingMsg, 0, len(reqs))
+	s.mu.Lock()
+	for _, req := range reqs {
+		pubStr := string(req.targetPubkey.SerializeCompressed())
+		targetPeer, ok := s.peersByPub[pubStr]
+		if !ok {
+			s.mu.Unlock()
+			errChan <- fmt.Errorf("unable to find peer nodeID(%x)",
+				[]byte(pubStr))
+			return updateChan, errChan
+		}
+
+		req.chainHash = *activeNetParams.GenesisHash
+		req.updates = updateChan
+		req.err = errChan
+
+		fundingReqs = append(fundingReqs, &initFundingMsg{
+			peerAddress: targetPeer.addr,
+			openChanReq: req,
+		})
+	}
+	s.mu.Unlock()
+
+	go s.fundingMgr.initBatchFundingWorkflow(fundingReqs, updateChan,
+		errChan)
+
+	return updateChan, errChan
+}
+
+// SpliceChannel sends a request to the server to splice funds into or out of
+// the active channel identified by chanPoint. If spliceOutScript is nil, then
+// amt is spliced into the channel from the wallet, otherwise amt is spliced
+// out of the channel to the passed output script. A fee rate (in sat/byte)
+// of zero indicates that the current fee estimate should be used. The new
+// channel point of the channel is sent over the returned update channel once
+// the splice transaction has been broadcast.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) SpliceChannel(chanPoint wire.OutPoint, amt btcutil.Amount,
+	spliceOutScript []byte, feeRate uint64) (chan *wire.OutPoint,
+	chan error) {
+
+	updateChan := make(chan *wire.OutPoint, 1)
+	errChan := make(chan error, 1)
+
+	dbChannels, err := s.chanDB.FetchAllChannels()
+	if err != nil {
+		errChan <- err
+		return updateChan, errChan
+	}
+
+	var dbChan *channeldb.OpenChannel
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == chanPoint {
+			dbChan = dbChannel
+			break
+		}
+	}
+	if dbChan == nil || dbChan.IsPending {
+		errChan <- fmt.Errorf("unable to find active channel %v",
+			chanPoint)
+		return updateChan, errChan
+	}
+
+	if feeRate == 0 {
+		feeRate = s.cc.feeEstimator.EstimateFeePerByte(1)
+	}
+
+	req := &initSpliceMsg{
+		peerKey:         dbChan.IdentityPub,
+		chanPoint:       chanPoint,
+		amt:             amt,
+		spliceOutScript: spliceOutScript,
+		feeRate:         feeRate,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	go s.fundingMgr.initSpliceWorkflow(req)
+
+	return updateChan, errChan
+}
+
 // Peers returns a slice of all active peers.
 //
 // NOTE: This function is safe for concurrent access.
@@ -1509,3 +1970,158 @@ func (s *server) Peers() []*peer {
 
 	return peers
 }
+
+// forceCloseChanPoint force closes the open channel identified by the passed
+// channel point. Before the commitment transaction is broadcast, the channel
+// is removed from the switch, and from the peer if it's online, so no further
+// updates will be made to the channel.
+func (s *server) forceCloseChanPoint(chanPoint wire.OutPoint) error {
+	dbChannels, err := s.chanDB.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+
+	var dbChan *channeldb.OpenChannel
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == chanPoint {
+			dbChan = dbChannel
+			break
+		}
+	}
+	if dbChan == nil {
+		return fmt.Errorf("unable to find channel %v", chanPoint)
+	}
+
+	channel, err := lnwallet.NewLightningChannel(s.cc.wallet.Cfg.Signer,
+		s.witnessBeacon, nil, s.cc.feeEstimator, dbChan)
+	if err != nil {
+		return err
+	}
+	defer channel.Stop()
+
+	if peer, err := s.FindPeer(dbChan.IdentityPub); err == nil {
+		peer.WipeChannel(channel)
+	} else {
+		chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+		s.htlcSwitch.RemoveLink(chanID)
+	}
+
+	select {
+	case s.breachArbiter.settledContracts <- &chanPoint:
+	case <-s.quit:
+		return fmt.Errorf("server shutting down")
+	}
+
+	_, bestHeight, err := s.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	closingTxid, _, err := s.forceCloseChan(channel)
+	if err != nil {
+		return err
+	}
+
+	// Once the commitment transaction confirms, we'll record its height
+	// within the close summary.
+	go waitForChanToClose(uint32(bestHeight), s.cc.chainNotifier, nil,
+		&chanPoint, closingTxid, func(closeHeight uint32) {
+			err := s.chanDB.SetCloseHeight(&chanPoint, closeHeight)
+			if err != nil {
+				srvrLog.Errorf("unable to set close height: %v",
+					err)
+			}
+		})
+
+	return nil
+}
+
+// forceCloseChan executes a unilateral close of the target channel by
+// broadcasting the current commitment state directly on-chain. Once the
+// commitment transaction has been broadcast, a struct describing the final
+// state of the channel is sent to the utxoNursery in order to ultimately sweep
+// the immature outputs.
+func (s *server) forceCloseChan(channel *lnwallet.LightningChannel) (*chainhash.Hash,
+	*lnwallet.ForceCloseSummary, error) {
+
+	// Execute a unilateral close shutting down all further channel
+	// operation.
+	closeSummary, err := channel.ForceClose()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeTx := closeSummary.CloseTx
+	txid := closeTx.TxHash()
+
+	// With the close transaction in hand, broadcast the transaction to the
+	// network, thereby entering the post channel resolution state.
+	srvrLog.Infof("Broadcasting force close transaction, ChannelPoint(%v): %v",
+		channel.ChannelPoint(), newLogClosure(func() string {
+			return spew.Sdump(closeTx)
+		}))
+	if err := s.cc.wallet.PublishTransaction(closeTx); err != nil {
+		return nil, nil, err
+	}
+
+	// Now that the closing transaction has been broadcast successfully,
+	// we'll mark this channel as being in the pending closed state. The
+	// UTXO nursery will mark the channel as fully closed once all the
+	// outputs have been swept.
+	//
+	// TODO(roasbeef): don't set local balance if close summary detects
+	// dust output?
+	chanPoint := channel.ChannelPoint()
+	chanInfo := channel.StateSnapshot()
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChanPoint:   *chanPoint,
+		ClosingTXID: closeTx.TxHash(),
+		RemotePub:   &chanInfo.RemoteIdentity,
+		Capacity:    chanInfo.Capacity,
+		CloseType:   channeldb.ForceClose,
+		IsPending:   true,
+	}
+
+	// If our commitment output isn't dust or we have active HTLC's on the
+	// commitment transaction, then we'll populate the balances on the
+	// close channel summary.
+	htlcs := closeSummary.HtlcResolutions
+	if closeSummary.SelfOutputSignDesc != nil ||
+		len(htlcs.IncomingHTLCs) != 0 || len(htlcs.OutgoingHTLCs) != 0 {
+
+		closeInfo.SettledBalance = chanInfo.LocalBalance.ToSatoshis()
+		closeInfo.TimeLockedBalance = chanInfo.LocalBalance.ToSatoshis()
+	}
+
+	if err := channel.DeleteState(closeInfo); err != nil {
+		return nil, nil, err
+	}
+	s.channelNotifier.NotifyClosingChannelEvent(*chanPoint)
+
+	// Send the closed channel summary over to the utxoNursery in order to
+	// have its outputs swept back into the wallet once they're mature.
+	s.utxoNursery.IncubateOutputs(closeSummary)
+
+	// If the commitment carries anchor outputs, then we'll hand ours to
+	// the sweeper so the commitment can be CPFP'd if its fee turns out to
+	// be insufficient. It must confirm before the earliest of our
+	// outgoing HTLCs expires.
+	if closeSummary.AnchorResolution != nil {
+		var deadline uint32
+		for _, htlc := range htlcs.OutgoingHTLCs {
+			if deadline == 0 || htlc.Expiry < deadline {
+				deadline = htlc.Expiry
+			}
+		}
+
+		err := s.sweeper.SweepAnchor(
+			closeSummary.AnchorResolution, deadline,
+		)
+		if err != nil {
+			srvrLog.Errorf("unable to hand off anchor of "+
+				"ChannelPoint(%v) to sweeper: %v", chanPoint, err)
+		}
+	}
+
+	return &txid, closeSummary, nil
+}
@@ -0,0 +1,675 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// initSpliceMsg is sent by a local subsystem to the fundingManager in order
+// to kick off a splice of an active channel. If spliceOutScript is nil, then
+// amt is spliced into the channel from the wallet, otherwise amt is spliced
+// out of the channel to the given output script.
+type initSpliceMsg struct {
+	peerKey         *btcec.PublicKey
+	chanPoint       wire.OutPoint
+	amt             btcutil.Amount
+	spliceOutScript []byte
+	feeRate         uint64
+
+	// updates is sent the new channel point of the channel once the
+	// splice transaction has been broadcast.
+	updates chan *wire.OutPoint
+
+	err chan error
+}
+
+// spliceInitMsg couples an lnwire.SpliceInit message with the peer who sent
+// the message. This allows the fundingManager to queue a response directly
+// to the peer, progressing the splice workflow.
+type spliceInitMsg struct {
+	msg         *lnwire.SpliceInit
+	peerAddress *lnwire.NetAddress
+}
+
+// spliceAcceptMsg couples an lnwire.SpliceAccept message with the peer who
+// sent the message. This allows the fundingManager to finalize the splice
+// workflow.
+type spliceAcceptMsg struct {
+	msg         *lnwire.SpliceAccept
+	peerAddress *lnwire.NetAddress
+}
+
+// spliceCtx houses the state of a splice being negotiated. For a splice
+// we've initiated, req is set, and the context is kept while we await the
+// signatures of the remote party.
+type spliceCtx struct {
+	peer    *peer
+	channel *lnwallet.LightningChannel
+	splice  *lnwallet.Splice
+	req     *initSpliceMsg
+}
+
+// initSpliceWorkflow sends a message to the funding manager instructing it
+// to initiate a splice of the target channel.
+func (f *fundingManager) initSpliceWorkflow(req *initSpliceMsg) {
+	f.spliceRequests <- req
+}
+
+// processSpliceInit sends a message to the fundingManager allowing it to
+// respond to a splice proposed by the remote peer.
+func (f *fundingManager) processSpliceInit(msg *lnwire.SpliceInit,
+	peerAddress *lnwire.NetAddress) {
+
+	f.fundingMsgs <- &spliceInitMsg{msg, peerAddress}
+}
+
+// processSpliceAccept sends a message to the fundingManager allowing it to
+// finalize and broadcast a splice we've initiated.
+func (f *fundingManager) processSpliceAccept(msg *lnwire.SpliceAccept,
+	peerAddress *lnwire.NetAddress) {
+
+	f.fundingMsgs <- &spliceAcceptMsg{msg, peerAddress}
+}
+
+// fetchActiveChannel locates the peer and the live channel state machine of
+// the active channel identified by chanID.
+func (f *fundingManager) fetchActiveChannel(peerKey *btcec.PublicKey,
+	chanID lnwire.ChannelID) (*peer, *lnwallet.LightningChannel, error) {
+
+	p, err := f.cfg.FindPeer(peerKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.activeChanMtx.RLock()
+	channel, ok := p.activeChannels[chanID]
+	p.activeChanMtx.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("channel %v is not active", chanID)
+	}
+
+	return p, channel, nil
+}
+
+// pauseLink removes the link of the target channel from the switch. This is
+// done once a splice has confirmed, so the live channel can be swapped out for
+// the spliced channel.
+func (f *fundingManager) pauseLink(p *peer, chanID lnwire.ChannelID) error {
+	err := p.server.htlcSwitch.RemoveLink(chanID)
+	if err != nil && err != htlcswitch.ErrChannelLinkNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// resumeLink re-registers a link for the passed channel with the switch,
+// via the peer that it belongs to.
+func (f *fundingManager) resumeLink(p *peer,
+	channel *lnwallet.LightningChannel) {
+
+	newChanDone := make(chan struct{})
+	newChanMsg := &newChannelMsg{
+		channel: channel,
+		done:    newChanDone,
+	}
+
+	select {
+	case p.newChannels <- newChanMsg:
+	case <-f.quit:
+		return
+	}
+
+	select {
+	case <-newChanDone:
+	case <-f.quit:
+	}
+}
+
+// failSplice aborts a splice we've initiated, releasing any of our inputs
+// locked for the splice transaction, and allowing the channel to sign new
+// commitments once again. The passed error is returned to the caller that
+// initiated the splice.
+func (f *fundingManager) failSplice(ctx *spliceCtx, spliceErr error) {
+	fndgLog.Errorf("Failing splice of ChannelPoint(%v): %v",
+		ctx.req.chanPoint, spliceErr)
+
+	chanID := lnwire.NewChanIDFromOutPoint(&ctx.req.chanPoint)
+	delete(f.activeSplices, chanID)
+
+	if ctx.splice != nil {
+		f.cfg.Wallet.ReleaseSpliceInputs(ctx.splice.SpliceTx)
+		ctx.channel.AbortSplice(ctx.splice)
+	}
+
+	ctx.req.err <- spliceErr
+}
+
+// failSpliceResponse aborts a splice proposed by the remote peer, and sends
+// an error to the peer. If the splice was created within the channel, then
+// it's discarded, allowing the channel to sign new commitments once again.
+func (f *fundingManager) failSpliceResponse(ctx *spliceCtx,
+	chanID lnwire.ChannelID, peerKey *btcec.PublicKey, spliceErr error) {
+
+	fndgLog.Errorf("Rejecting splice of ChannelID(%v): %v", chanID,
+		spliceErr)
+
+	if ctx != nil {
+		delete(f.activeSplices, chanID)
+
+		if ctx.splice != nil {
+			ctx.channel.AbortSplice(ctx.splice)
+		}
+	}
+
+	errMsg := &lnwire.Error{
+		ChanID: chanID,
+		Data:   []byte(spliceErr.Error()),
+	}
+	if err := f.cfg.SendToPeer(peerKey, errMsg); err != nil {
+		fndgLog.Errorf("unable to send error message to peer %v", err)
+	}
+}
+
+// fundingPkScript returns the output script of the funding output of the
+// passed channel. As a splice re-uses the multi-sig keys of the channel, this
+// is also the output script of the funding output of any splice transaction.
+func fundingPkScript(channel *lnwallet.LightningChannel) ([]byte, error) {
+	_, fundingOutput, err := lnwallet.GenFundingPkScript(
+		channel.LocalFundingKey.SerializeCompressed(),
+		channel.RemoteFundingKey.SerializeCompressed(),
+		int64(channel.Capacity),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return fundingOutput.PkScript, nil
+}
+
+// handleInitSplice creates a new splice transaction for the target channel,
+// along with our signature for the remote party's new commitment, and sends
+// a SpliceInit message to the remote peer.
+//
+// NOTE: The splice initiator funds all additional inputs, and pays the full
+// fee of the splice transaction.
+func (f *fundingManager) handleInitSplice(msg *initSpliceMsg) {
+	chanID := lnwire.NewChanIDFromOutPoint(&msg.chanPoint)
+	if _, ok := f.activeSplices[chanID]; ok {
+		msg.err <- fmt.Errorf("splice of ChannelPoint(%v) already "+
+			"in progress", msg.chanPoint)
+		return
+	}
+
+	p, channel, err := f.fetchActiveChannel(msg.peerKey, chanID)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	// The link of the channel remains active while the splice is
+	// negotiated and awaiting confirmation, as the channel will sign each
+	// new state against both the current and the new funding output.
+	ctx := &spliceCtx{
+		peer:    p,
+		channel: channel,
+		req:     msg,
+	}
+	f.activeSplices[chanID] = ctx
+
+	pkScript, err := fundingPkScript(channel)
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	spliceTx, delta, err := f.cfg.Wallet.CreateSpliceTx(msg.chanPoint,
+		channel.Capacity, pkScript, msg.amt, msg.spliceOutScript,
+		msg.feeRate)
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	if channel.Capacity+delta > maxFundingAmount {
+		f.cfg.Wallet.ReleaseSpliceInputs(spliceTx)
+		f.failSplice(ctx, fmt.Errorf("spliced channel capacity %v "+
+			"exceeds maximum of %v", channel.Capacity+delta,
+			maxFundingAmount))
+		return
+	}
+
+	// Only our balance is modified by the splice: the amount spliced in
+	// is credited to us, and the amount spliced out is debited from us.
+	splice, err := channel.NewSplice(spliceTx, delta, 0)
+	if err != nil {
+		f.cfg.Wallet.ReleaseSpliceInputs(spliceTx)
+		f.failSplice(ctx, err)
+		return
+	}
+	ctx.splice = splice
+
+	commitSig, err := channel.SignSpliceCommitment(splice)
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	fndgLog.Infof("Initiating splice of ChannelPoint(%v) into %v, "+
+		"capacity=%v", msg.chanPoint, splice.FundingOutpoint,
+		splice.Capacity)
+
+	spliceInit := lnwire.NewSpliceInit(chanID, spliceTx, commitSig)
+	if err := f.cfg.SendToPeer(msg.peerKey, spliceInit); err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+}
+
+// handleSpliceInit processes a splice proposed by the remote peer. If the
+// splice is valid, then it's durably recorded, and our signatures for both
+// the new commitment and the funding input of the splice transaction are
+// sent back to the remote peer.
+func (f *fundingManager) handleSpliceInit(fmsg *spliceInitMsg) {
+	msg := fmsg.msg
+	peerKey := fmsg.peerAddress.IdentityKey
+
+	// Only a single splice of a channel may be in flight at a time, so
+	// we'll reject the splice if we're already negotiating one.
+	if _, ok := f.activeSplices[msg.ChanID]; ok {
+		err := fmt.Errorf("splice of ChannelID(%v) already in progress",
+			msg.ChanID)
+		f.failSpliceResponse(nil, msg.ChanID, peerKey, err)
+		return
+	}
+
+	p, channel, err := f.fetchActiveChannel(peerKey, msg.ChanID)
+	if err != nil {
+		f.failSpliceResponse(nil, msg.ChanID, peerKey, err)
+		return
+	}
+
+	ctx := &spliceCtx{
+		peer:    p,
+		channel: channel,
+	}
+	f.activeSplices[msg.ChanID] = ctx
+
+	chanState, err := f.fetchChannelState(channel.ChannelPoint())
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+
+	// The remote party funds the splice in its entirety, so we'll
+	// attribute the full change in capacity to the balance of the remote
+	// party.
+	pkScript, err := fundingPkScript(channel)
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	found, outputIndex := lnwallet.FindScriptOutputIndex(msg.SpliceTx,
+		pkScript)
+	if !found {
+		err := errors.New("splice transaction doesn't re-create the " +
+			"funding output")
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	newCapacity := btcutil.Amount(msg.SpliceTx.TxOut[outputIndex].Value)
+	if newCapacity > maxFundingAmount {
+		err := fmt.Errorf("spliced channel capacity %v exceeds "+
+			"maximum of %v", newCapacity, maxFundingAmount)
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	delta := newCapacity - channel.Capacity
+
+	// If either balance would be negative after the splice, then the
+	// splice is rejected within NewSplice.
+	splice, err := channel.NewSplice(msg.SpliceTx, 0, delta)
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	ctx.splice = splice
+
+	// As the remote party may splice funds out of the channel, we'll
+	// ensure that its balance after the splice meets the reserve we
+	// require of it.
+	remoteReserve := lnwire.NewMSatFromSatoshis(
+		chanState.RemoteChanCfg.ChanReserve,
+	)
+	if splice.RemoteBalance < remoteReserve {
+		err := fmt.Errorf("remote balance of %v after splice is "+
+			"below channel reserve of %v", splice.RemoteBalance,
+			remoteReserve)
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+
+	err = channel.ReceiveSpliceCommitment(splice, msg.CommitSig)
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+
+	commitSig, err := channel.SignSpliceCommitment(splice)
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	fundingSig, err := channel.SignSpliceFunding(splice)
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+
+	// Before we send our signature for the funding input, we'll durably
+	// record the splice so we're able to recognize the splice
+	// transaction once it's broadcast.
+	_, bestHeight, err := f.cfg.Wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+	err = channel.CommitSplice(splice, false, uint32(bestHeight))
+	if err != nil {
+		f.failSpliceResponse(ctx, msg.ChanID, peerKey, err)
+		return
+	}
+
+	// Now that the splice has been durably recorded, any further splice
+	// will be rejected by the channel until this one has confirmed.
+	delete(f.activeSplices, msg.ChanID)
+
+	fndgLog.Infof("Accepting splice of ChannelID(%v) into %v, "+
+		"capacity=%v", msg.ChanID, splice.FundingOutpoint,
+		splice.Capacity)
+
+	spliceAccept := lnwire.NewSpliceAccept(msg.ChanID, commitSig,
+		fundingSig)
+	if err := f.cfg.SendToPeer(peerKey, spliceAccept); err != nil {
+		fndgLog.Errorf("unable to send SpliceAccept to peer: %v", err)
+	}
+
+	f.wg.Add(1)
+	go f.waitForSpliceConfirmation(chanState)
+}
+
+// handleSpliceAccept finalizes a splice we've initiated once the remote
+// peer has sent over its signatures. The splice transaction is then signed
+// in full and broadcast.
+func (f *fundingManager) handleSpliceAccept(fmsg *spliceAcceptMsg) {
+	msg := fmsg.msg
+
+	ctx, ok := f.activeSplices[msg.ChanID]
+	if !ok || ctx.req == nil {
+		fndgLog.Warnf("Received SpliceAccept for unknown splice of "+
+			"ChannelID(%v)", msg.ChanID)
+		return
+	}
+	channel := ctx.channel
+	splice := ctx.splice
+
+	err := channel.ReceiveSpliceCommitment(splice, msg.CommitSig)
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	// With the remote party's signature for the funding input, we can
+	// now fully sign the splice transaction.
+	err = channel.CompleteSpliceFunding(splice, msg.FundingSig)
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+	if err := f.cfg.Wallet.SignSpliceInputs(splice.SpliceTx); err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	_, bestHeight, err := f.cfg.Wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+	err = channel.CommitSplice(splice, true, uint32(bestHeight))
+	if err != nil {
+		f.failSplice(ctx, err)
+		return
+	}
+
+	// Now that the splice has been durably recorded, the splice can no
+	// longer be aborted, so we'll broadcast the splice transaction.
+	delete(f.activeSplices, msg.ChanID)
+
+	fndgLog.Infof("Broadcasting splice tx for ChannelPoint(%v): %v",
+		ctx.req.chanPoint, splice.SpliceTx.TxHash())
+
+	if err := f.cfg.Wallet.PublishTransaction(splice.SpliceTx); err != nil {
+		fndgLog.Errorf("unable to broadcast splice tx: %v", err)
+	}
+
+	newChanPoint := splice.FundingOutpoint
+	ctx.req.updates <- &newChanPoint
+
+	chanState, err := f.fetchChannelState(&ctx.req.chanPoint)
+	if err != nil {
+		fndgLog.Errorf("unable to fetch channel state: %v", err)
+		return
+	}
+
+	f.wg.Add(1)
+	go f.waitForSpliceConfirmation(chanState)
+}
+
+// handleSpliceError fails the splice of the target channel in response to an
+// error sent by the remote peer. If no splice we've initiated of the channel
+// is active, then false is returned.
+func (f *fundingManager) handleSpliceError(fmsg *fundingErrorMsg) bool {
+	ctx, ok := f.activeSplices[fmsg.err.ChanID]
+	if !ok || ctx.req == nil {
+		return false
+	}
+
+	f.failSplice(ctx, fmt.Errorf("splice rejected by remote peer: %v",
+		string(fmsg.err.Data)))
+
+	return true
+}
+
+// fetchChannelState retrieves the persistent state of the open channel
+// identified by chanPoint from the database.
+func (f *fundingManager) fetchChannelState(
+	chanPoint *wire.OutPoint) (*channeldb.OpenChannel, error) {
+
+	openChannels, err := f.cfg.Wallet.Cfg.Database.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channel := range openChannels {
+		if channel.FundingOutpoint == *chanPoint {
+			return channel, nil
+		}
+	}
+
+	return nil, ErrChannelNotFound
+}
+
+// resumePendingSplices launches a goroutine for each channel with a pending
+// splice, which waits for the splice transaction to confirm. If we're the
+// initiator of the splice, then the splice transaction is re-broadcast.
+func (f *fundingManager) resumePendingSplices() error {
+	openChannels, err := f.cfg.Wallet.Cfg.Database.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range openChannels {
+		splice, err := channel.FetchPendingSplice()
+		if err == channeldb.ErrNoPendingSplice {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if splice.IsInitiator {
+			err := f.cfg.Wallet.PublishTransaction(splice.SpliceTx)
+			if err != nil {
+				fndgLog.Warnf("unable to re-broadcast splice "+
+					"tx %v: %v", splice.SpliceTx.TxHash(),
+					err)
+			}
+		}
+
+		f.wg.Add(1)
+		go f.waitForSpliceConfirmation(channel)
+	}
+
+	return nil
+}
+
+// waitForSpliceConfirmation waits for the splice transaction of the passed
+// channel to confirm. Once confirmed, the channel state is migrated over to
+// the new funding output, the live channel within the peer is swapped out for
+// the spliced channel, and the new channel is announced to the network.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) waitForSpliceConfirmation(
+	chanState *channeldb.OpenChannel) {
+
+	defer f.wg.Done()
+
+	splice, err := chanState.FetchPendingSplice()
+	if err != nil {
+		fndgLog.Errorf("unable to fetch pending splice: %v", err)
+		return
+	}
+
+	oldChanPoint := chanState.FundingOutpoint
+	oldChanID := lnwire.NewChanIDFromOutPoint(&oldChanPoint)
+
+	txid := splice.FundingOutpoint.Hash
+	numConfs := uint32(chanState.NumConfsRequired)
+	if numConfs == 0 {
+		numConfs = 1
+	}
+	confNtfn, err := f.cfg.Notifier.RegisterConfirmationsNtfn(&txid,
+		numConfs, splice.BroadcastHeight)
+	if err != nil {
+		fndgLog.Errorf("Unable to register for confirmation of "+
+			"splice tx %v", txid)
+		return
+	}
+
+	fndgLog.Infof("Waiting for splice tx (%v) of ChannelPoint(%v) to "+
+		"reach %v confirmations", txid, oldChanPoint, numConfs)
+
+	var (
+		confDetails *chainntnfs.TxConfirmation
+		ok          bool
+	)
+	select {
+	case confDetails, ok = <-confNtfn.Confirmed:
+	case <-f.quit:
+		return
+	}
+	if !ok {
+		fndgLog.Warnf("ChainNotifier shutting down, cannot complete "+
+			"splice of ChannelPoint(%v)", oldChanPoint)
+		return
+	}
+
+	shortChanID := lnwire.ShortChannelID{
+		BlockHeight: confDetails.BlockHeight,
+		TxIndex:     confDetails.TxIndex,
+		TxPosition:  uint16(splice.FundingOutpoint.Index),
+	}
+
+	// With the splice confirmed, the old channel is now defunct. If the
+	// peer is online, then we'll remove the old channel from it before
+	// we migrate the channel state over to the new funding output.
+	p, oldChannel, err := f.fetchActiveChannel(chanState.IdentityPub,
+		oldChanID)
+	if err == nil {
+		if err := f.pauseLink(p, oldChanID); err != nil {
+			fndgLog.Errorf("unable to remove link: %v", err)
+		}
+
+		oldChannel.Stop()
+
+		p.activeChanMtx.Lock()
+		delete(p.activeChannels, oldChanID)
+		p.activeChanMtx.Unlock()
+	}
+
+	// As the channel has continued to be updated while the splice
+	// awaited confirmation, we'll re-fetch the latest channel state,
+	// along with the splice version of its latest commitment.
+	chanState, err = f.fetchChannelState(&oldChanPoint)
+	if err != nil {
+		fndgLog.Errorf("unable to fetch channel state: %v", err)
+		return
+	}
+
+	if err := chanState.CompleteSplice(shortChanID); err != nil {
+		fndgLog.Errorf("unable to complete splice of "+
+			"ChannelPoint(%v): %v", oldChanPoint, err)
+		return
+	}
+
+	newChanPoint := chanState.FundingOutpoint
+	newChanID := lnwire.NewChanIDFromOutPoint(&newChanPoint)
+
+	fndgLog.Infof("ChannelPoint(%v) has been spliced into "+
+		"ChannelPoint(%v)", oldChanPoint, newChanPoint)
+
+	// The old funding output is now spent, so the breach arbiter no
+	// longer needs to watch it. Instead, it'll watch the new channel.
+	select {
+	case f.cfg.SettledContracts <- &oldChanPoint:
+	case <-f.quit:
+		return
+	}
+
+	channel, err := f.cfg.FindChannel(newChanID)
+	if err != nil {
+		fndgLog.Errorf("unable to find spliced channel: %v", err)
+		return
+	}
+	select {
+	case f.cfg.ArbiterChan <- channel:
+	case <-f.quit:
+		return
+	}
+
+	// If the peer is online, then we'll add the new channel to it, which
+	// resumes the link. Otherwise, the new channel will be loaded once
+	// the peer reconnects.
+	if p != nil {
+		f.resumeLink(p, channel)
+	}
+
+	// Finally, we'll announce the spliced channel to the network. The
+	// edge of the old channel is pruned from the graph as its funding
+	// output has been spent.
+	err = f.announceChannel(f.cfg.IDKey, chanState.IdentityPub,
+		channel.LocalFundingKey, channel.RemoteFundingKey, shortChanID,
+		newChanID)
+	if err != nil {
+		fndgLog.Errorf("spliced channel announcement failed: %v", err)
+	}
+}
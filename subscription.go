@@ -0,0 +1,142 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// subscriptionServer tracks the clients subscribed to the events of one of
+// our notifiers, and delivers each event dispatched by the notifier to all of
+// them. Each client has its own queue of pending events, so a slow client
+// neither stalls the notifier nor any of the other clients, and each client
+// receives the events in the order they were dispatched.
+type subscriptionServer struct {
+	clientMtx           sync.Mutex
+	nextClientID        uint32
+	notificationClients map[uint32]*eventSubscription
+}
+
+// newSubscriptionServer creates a new subscriptionServer without any clients.
+func newSubscriptionServer() *subscriptionServer {
+	return &subscriptionServer{
+		notificationClients: make(map[uint32]*eventSubscription),
+	}
+}
+
+// eventSubscription represents an intent to receive the events dispatched by
+// one of our notifiers. Each event will be sent over the Updates channel, in
+// the order the events were dispatched.
+type eventSubscription struct {
+	Updates chan interface{}
+
+	server *subscriptionServer
+	id     uint32
+
+	// pendingEvents is the queue of events yet to be delivered to the
+	// client. newEvents is signalled each time an event is added to the
+	// queue.
+	queueMtx      sync.Mutex
+	pendingEvents *list.List
+	newEvents     chan struct{}
+
+	exit chan struct{}
+}
+
+// subscribe registers a new client, which will receive every event dispatched
+// from now on until it's cancelled.
+func (s *subscriptionServer) subscribe() *eventSubscription {
+	client := &eventSubscription{
+		Updates:       make(chan interface{}),
+		server:        s,
+		pendingEvents: list.New(),
+		newEvents:     make(chan struct{}, 1),
+		exit:          make(chan struct{}),
+	}
+
+	s.clientMtx.Lock()
+	s.notificationClients[s.nextClientID] = client
+	client.id = s.nextClientID
+	s.nextClientID++
+	s.clientMtx.Unlock()
+
+	go client.eventDispatcher()
+
+	return client
+}
+
+// hasClients returns true if there are any registered notification clients.
+// This allows notifiers to skip gathering the details of an event if nobody
+// will receive it.
+func (s *subscriptionServer) hasClients() bool {
+	s.clientMtx.Lock()
+	defer s.clientMtx.Unlock()
+
+	return len(s.notificationClients) != 0
+}
+
+// notifyClients queues the passed event for delivery to all currently
+// registered notification clients. This never blocks on the clients.
+func (s *subscriptionServer) notifyClients(event interface{}) {
+	s.clientMtx.Lock()
+	defer s.clientMtx.Unlock()
+
+	for _, client := range s.notificationClients {
+		client.queueEvent(event)
+	}
+}
+
+// Cancel unregisters the eventSubscription, freeing any previously allocated
+// resources.
+func (c *eventSubscription) Cancel() {
+	c.server.clientMtx.Lock()
+	delete(c.server.notificationClients, c.id)
+	c.server.clientMtx.Unlock()
+
+	close(c.exit)
+}
+
+// queueEvent adds the passed event to the queue of events to be delivered to
+// the client.
+func (c *eventSubscription) queueEvent(event interface{}) {
+	c.queueMtx.Lock()
+	c.pendingEvents.PushBack(event)
+	c.queueMtx.Unlock()
+
+	select {
+	case c.newEvents <- struct{}{}:
+	default:
+	}
+}
+
+// eventDispatcher delivers the queued events to the client one at a time,
+// ensuring the client observes the events in the order they were
+// dispatched.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *eventSubscription) eventDispatcher() {
+	for {
+		c.queueMtx.Lock()
+		next := c.pendingEvents.Front()
+		if next != nil {
+			c.pendingEvents.Remove(next)
+		}
+		c.queueMtx.Unlock()
+
+		// If there are no events to deliver, then we'll wait until
+		// a new event is queued.
+		if next == nil {
+			select {
+			case <-c.newEvents:
+				continue
+			case <-c.exit:
+				return
+			}
+		}
+
+		select {
+		case c.Updates <- next.Value:
+		case <-c.exit:
+			return
+		}
+	}
+}
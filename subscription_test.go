@@ -0,0 +1,73 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/htlcswitch"
+)
+
+// TestSubscriptionEventOrder tests that each client of a notifier receives the
+// dispatched events in order, and that a client which isn't consuming its
+// events doesn't hold up the delivery of events to the other clients.
+func TestSubscriptionEventOrder(t *testing.T) {
+	t.Parallel()
+
+	notifier := newHtlcNotifier()
+
+	slowClient := notifier.SubscribeHtlcEvents()
+	defer slowClient.Cancel()
+	client := notifier.SubscribeHtlcEvents()
+
+	// We'll dispatch each event of an HTLC's lifecycle, followed by those
+	// of a second HTLC. None of the calls should block, even though
+	// neither client is consuming its events yet.
+	expectedEvents := []*htlcswitch.HtlcEvent{
+		{Type: htlcswitch.HtlcEventForward, IncomingHtlcID: 1},
+		{Type: htlcswitch.HtlcEventSettle, IncomingHtlcID: 1},
+		{Type: htlcswitch.HtlcEventForward, IncomingHtlcID: 2},
+		{Type: htlcswitch.HtlcEventForwardFail, IncomingHtlcID: 2},
+	}
+	for _, event := range expectedEvents {
+		notifier.NotifyHtlcEvent(event)
+	}
+
+	// assertEvents asserts that the passed client receives each of the
+	// expected events, in order.
+	assertEvents := func(client *eventSubscription) {
+		for i, expected := range expectedEvents {
+			select {
+			case event := <-client.Updates:
+				if event.(*htlcswitch.HtlcEvent) != expected {
+					t.Fatalf("event #%v received out of "+
+						"order", i)
+				}
+			case <-time.After(time.Second * 5):
+				t.Fatalf("event #%v not received", i)
+			}
+		}
+	}
+
+	// The second client should receive all of its events while the first
+	// client is yet to consume any of its own.
+	assertEvents(client)
+	assertEvents(slowClient)
+
+	// Once a client has been cancelled, it should no longer receive any
+	// events, while the remaining client continues to.
+	client.Cancel()
+	notifier.NotifyHtlcEvent(expectedEvents[0])
+
+	select {
+	case <-client.Updates:
+		t.Fatalf("cancelled client received an event")
+	case event := <-slowClient.Updates:
+		if event.(*htlcswitch.HtlcEvent) != expectedEvents[0] {
+			t.Fatalf("unexpected event received")
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("event not received")
+	}
+}
@@ -0,0 +1,1392 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// defaultSweepConfTarget is the number of blocks within which we'll
+	// aim to confirm the sweep of an output that only we're able to spend,
+	// such as our delayed output on our own commitment transaction.
+	defaultSweepConfTarget = 6
+
+	// urgentSweepConfTarget is the number of blocks within which we'll aim
+	// to confirm the sweep of an output that the remote party is also able
+	// to spend, such as a revoked output or an HTLC output.
+	urgentSweepConfTarget = 1
+
+	// defaultSweepBatchWindow is the duration the sweeper will wait after
+	// receiving a new output before crafting a sweep transaction. This
+	// gives other outputs a chance to arrive so they can be batched within
+	// the same transaction.
+	defaultSweepBatchWindow = 30 * time.Second
+
+	// defaultFeeRateBucketSize is the width, expressed in satoshis/weight,
+	// of each of the fee rate buckets the sweeper groups outputs into.
+	// Outputs that fall within the same bucket are swept within a single
+	// transaction.
+	defaultFeeRateBucketSize = 10
+)
+
+var (
+	// ErrSweepInputSpentRemotely is returned to the caller of SweepOutput
+	// if the output was spent by a transaction that wasn't crafted by the
+	// sweeper.
+	ErrSweepInputSpentRemotely = errors.New("output spent by a " +
+		"transaction not created by the sweeper")
+
+	// ErrSweepNotPending is returned by BumpFee if the output isn't
+	// pending within the sweeper.
+	ErrSweepNotPending = errors.New("output not pending within the " +
+		"sweeper")
+
+	// ErrSweeperShuttingDown is returned if an output is handed to the
+	// sweeper while it's shutting down.
+	ErrSweeperShuttingDown = errors.New("sweeper shutting down")
+)
+
+// SpendableOutput an interface which can be used by the sweeper to construct
+// a transaction spending from outputs we control.
+type SpendableOutput interface {
+	// Amount returns the number of satoshis contained within the output.
+	Amount() btcutil.Amount
+
+	// Outpoint returns the reference to the output being spent, used to
+	// construct the corresponding transaction input.
+	OutPoint() *wire.OutPoint
+
+	// WitnessType returns the type of witness that must be generated to
+	// spend the output. This is used to estimate the weight of the
+	// transaction spending the output.
+	WitnessType() lnwallet.WitnessType
+
+	// BuildWitness returns a valid witness allowing this output to be
+	// spent, the witness should be attached to the transaction at the
+	// location determined by the given `txinIdx`.
+	BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
+		hashCache *txscript.TxSigHashes,
+		txinIdx int) ([][]byte, error)
+}
+
+// SweepRequest describes an output handed to the sweeper, along with the
+// constraints that must be satisfied by any transaction spending it.
+type SweepRequest struct {
+	// Output is the output to be swept into the wallet.
+	Output SpendableOutput
+
+	// Sequence is the sequence number of the input spending the output.
+	// This must be set to the relative delay of outputs guarded by
+	// CheckSequenceVerify.
+	Sequence uint32
+
+	// LockTime is the minimum absolute lock time of the transaction
+	// spending the output. This must be set for outputs guarded by
+	// CheckLockTimeVerify, and the output won't be swept before this
+	// height has been reached.
+	LockTime uint32
+
+	// Deadline is the block height by which the output should be swept.
+	// It determines the fee rate of the sweep transaction, and if the
+	// sweep hasn't confirmed by this height, the fee of the transaction
+	// will be bumped at each new block until it does.
+	Deadline uint32
+
+	// HeightHint is the earliest height at which the output could have
+	// been spent. If zero, then the current height is used.
+	HeightHint uint32
+
+	// ChanPoint is the channel point of the closed channel the output
+	// belongs to. If set, then the resolution of the output will be
+	// recorded as it progresses.
+	ChanPoint *wire.OutPoint
+}
+
+// SweepResult is sent to the caller of SweepOutput once the output has been
+// spent within a confirmed transaction.
+type SweepResult struct {
+	// Tx is the transaction that spent the output.
+	Tx *wire.MsgTx
+
+	// Err is non-nil if the output wasn't swept by the sweeper. If the
+	// output was spent by a transaction not crafted by the sweeper, then
+	// this will be ErrSweepInputSpentRemotely.
+	Err error
+}
+
+// SweeperConfig bundles the required subsystems used by the sweeper. An
+// instance of SweeperConfig is passed to newUtxoSweeper during instantiation.
+type SweeperConfig struct {
+	// ChainIO is used to determine the current height of the blockchain
+	// when the sweeper is started.
+	ChainIO lnwallet.BlockChainIO
+
+	// Estimator is used to determine the fee rate of each sweep
+	// transaction according to the deadlines of the outputs it spends.
+	Estimator lnwallet.FeeEstimator
+
+	// GenSweepScript generates the receiving scripts for swept outputs.
+	GenSweepScript func() ([]byte, error)
+
+	// Notifier is used to receive a notification for each new block, and
+	// once each of the outputs handed to the sweeper has been spent.
+	Notifier chainntnfs.ChainNotifier
+
+	// PublishTransaction facilitates the process of broadcasting a
+	// transaction to the network.
+	PublishTransaction func(*wire.MsgTx) error
+
+	// Signer is used to generate the witness for each of the inputs of a
+	// sweep transaction.
+	Signer lnwallet.Signer
+
+	// Wallet is used to select the wallet outputs which fund the child
+	// transactions spending the anchor outputs of unconfirmed commitment
+	// transactions.
+	Wallet lnwallet.WalletController
+
+	// BatchWindow is the duration the sweeper waits after receiving a new
+	// output before sweeping, allowing outputs to be batched.
+	BatchWindow time.Duration
+
+	// FeeRateBucketSize is the width, expressed in satoshis/weight, of
+	// each of the fee rate buckets the sweeper groups outputs into.
+	FeeRateBucketSize uint64
+
+	// RecordResolution persists the resolution of an output belonging to
+	// the closed channel identified by the passed channel point.
+	RecordResolution func(*wire.OutPoint, *channeldb.OutputResolution) error
+}
+
+// pendingInput is an output handed to the sweeper which hasn't yet been spent
+// within a confirmed transaction.
+type pendingInput struct {
+	req *SweepRequest
+
+	// resultChans are the channels which will be sent upon once the
+	// output has been spent. An output may be handed to the sweeper more
+	// than once, for example after a restart of the caller.
+	resultChans []chan *SweepResult
+
+	// sweep is the broadcast sweep transaction that currently spends the
+	// output. If this is nil, then the output will be included within the
+	// next batch.
+	sweep *pendingSweep
+}
+
+// pendingSweep is a sweep transaction which has been broadcast, but not yet
+// confirmed.
+type pendingSweep struct {
+	// tx is the latest version of the sweep transaction.
+	tx *wire.MsgTx
+
+	// txids is the set of txids of every version of the sweep transaction
+	// we've broadcast. As any of these may confirm, all of them are
+	// recognized as being our own.
+	txids map[chainhash.Hash]struct{}
+
+	// pkScript is the output script of the sweep transaction. It's reused
+	// each time the transaction is replaced.
+	pkScript []byte
+
+	// feeRate is the fee rate, expressed in satoshis/weight, of the latest
+	// version of the sweep transaction.
+	feeRate uint64
+
+	// deadline is the earliest deadline of the outputs spent by the
+	// transaction. If the transaction hasn't confirmed by this height,
+	// then its fee will be bumped.
+	deadline uint32
+
+	// broadcastAttempts is the number of versions of the sweep transaction
+	// that have been broadcast.
+	broadcastAttempts uint32
+
+	// confirmed is true once any of the outputs spent by the transaction
+	// has been reported as spent by one of its versions.
+	confirmed bool
+
+	inputs []*pendingInput
+}
+
+// pendingAnchor is our anchor output on a commitment transaction which hasn't
+// yet confirmed. Until it does, the anchor is used to CPFP the commitment.
+type pendingAnchor struct {
+	resolution *lnwallet.AnchorResolution
+
+	// deadline is the height by which the commitment transaction should
+	// confirm. If it hasn't confirmed by this height, then the fee of the
+	// child transaction will be bumped at each new block.
+	deadline uint32
+
+	// utxo is the wallet output which, along with the anchor, funds the
+	// child transaction. The same output is spent by each version of the
+	// child, ensuring that each replaces the last.
+	utxo       *lnwallet.Utxo
+	utxoOutput *wire.TxOut
+
+	// pkScript is the output script of the child transaction.
+	pkScript []byte
+
+	// cpfpTx is the latest version of the child transaction. If this is
+	// nil, then the commitment hasn't yet needed a fee bump.
+	cpfpTx *wire.MsgTx
+
+	// feeRate is the fee rate, expressed in satoshis/weight, paid by the
+	// commitment and the latest version of the child transaction together.
+	feeRate uint64
+}
+
+// sweepInputRequest is a request sent to the main goroutine of the sweeper
+// to add a new output to the pending set.
+type sweepInputRequest struct {
+	req        *SweepRequest
+	resultChan chan *SweepResult
+}
+
+// sweepReport describes the current state of a single output handed to the
+// sweeper.
+type sweepReport struct {
+	outPoint          wire.OutPoint
+	amt               btcutil.Amount
+	witnessType       lnwallet.WitnessType
+	deadline          uint32
+	sweepTxid         *chainhash.Hash
+	feeRate           uint64
+	broadcastAttempts uint32
+}
+
+// utxoSweeper is a subsystem which sweeps the outputs handed to it by the
+// utxoNursery and the breachArbiter into the wallet. Outputs are grouped into
+// buckets according to the fee rate required to confirm them before their
+// deadline, and all the outputs within a bucket are swept within a single
+// transaction. If a sweep transaction hasn't confirmed by the deadline of the
+// outputs it spends, then it's replaced by a transaction paying a higher fee
+// at each new block.
+type utxoSweeper struct {
+	sync.RWMutex
+
+	cfg *SweeperConfig
+
+	// currentHeight is the height of the latest block we've been notified
+	// of.
+	currentHeight uint32
+
+	// pendingInputs is the set of outputs which haven't yet been spent
+	// within a confirmed transaction.
+	pendingInputs map[wire.OutPoint]*pendingInput
+
+	// sweeps is the set of sweep transactions which have been broadcast,
+	// but not yet confirmed.
+	sweeps map[*pendingSweep]struct{}
+
+	// anchors is the set of our anchor outputs on commitment transactions
+	// which haven't yet confirmed, keyed by the anchor's outpoint.
+	anchors map[wire.OutPoint]*pendingAnchor
+
+	newInputs chan *sweepInputRequest
+	spends    chan *chainntnfs.SpendDetail
+
+	started uint32
+	stopped uint32
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newUtxoSweeper creates a new instance of the utxoSweeper backed by the
+// passed config.
+func newUtxoSweeper(cfg *SweeperConfig) *utxoSweeper {
+	return &utxoSweeper{
+		cfg:           cfg,
+		pendingInputs: make(map[wire.OutPoint]*pendingInput),
+		sweeps:        make(map[*pendingSweep]struct{}),
+		anchors:       make(map[wire.OutPoint]*pendingAnchor),
+		newInputs:     make(chan *sweepInputRequest),
+		spends:        make(chan *chainntnfs.SpendDetail),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start launches all goroutines the utxoSweeper needs to properly carry out
+// its duties.
+func (s *utxoSweeper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	swprLog.Tracef("Starting UTXO sweeper")
+
+	_, bestHeight, err := s.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	s.currentHeight = uint32(bestHeight)
+
+	blockEpochs, err := s.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go s.collector(blockEpochs)
+
+	return nil
+}
+
+// Stop gracefully shuts down any lingering goroutines launched during normal
+// operation of the utxoSweeper.
+func (s *utxoSweeper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	swprLog.Infof("UTXO sweeper shutting down")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// SweepOutput hands off an output to the sweeper. The returned channel will
+// be sent upon once the output has been spent within a confirmed transaction.
+func (s *utxoSweeper) SweepOutput(req *SweepRequest) (chan *SweepResult, error) {
+	resultChan := make(chan *SweepResult, 1)
+
+	select {
+	case s.newInputs <- &sweepInputRequest{req, resultChan}:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	return resultChan, nil
+}
+
+// PendingSweeps returns a report for each of the outputs handed to the
+// sweeper which haven't yet been spent within a confirmed transaction.
+func (s *utxoSweeper) PendingSweeps() []*sweepReport {
+	s.RLock()
+	defer s.RUnlock()
+
+	reports := make([]*sweepReport, 0, len(s.pendingInputs))
+	for op, input := range s.pendingInputs {
+		report := &sweepReport{
+			outPoint:    op,
+			amt:         input.req.Output.Amount(),
+			witnessType: input.req.Output.WitnessType(),
+			deadline:    input.req.Deadline,
+		}
+
+		if input.sweep != nil {
+			txid := input.sweep.tx.TxHash()
+			report.sweepTxid = &txid
+			report.feeRate = input.sweep.feeRate
+			report.broadcastAttempts = input.sweep.broadcastAttempts
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// BumpFee replaces the sweep transaction spending the passed output with one
+// paying the passed fee rate, expressed in satoshis/weight. If the output
+// hasn't yet been swept, then it's swept immediately at the passed fee rate.
+// The txid of the newly broadcast transaction is returned.
+func (s *utxoSweeper) BumpFee(op wire.OutPoint,
+	feeRate uint64) (*chainhash.Hash, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	input, ok := s.pendingInputs[op]
+	if !ok {
+		return nil, ErrSweepNotPending
+	}
+	if input.req.LockTime > s.currentHeight {
+		return nil, fmt.Errorf("output %v can't be swept before "+
+			"height %v", op, input.req.LockTime)
+	}
+
+	sweep := input.sweep
+	if sweep == nil {
+		sweep, err := s.publishSweep(
+			[]*pendingInput{input}, feeRate, input.req.Deadline,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		txid := sweep.tx.TxHash()
+		return &txid, nil
+	}
+
+	// In order for the replacement to be accepted, it must pay a higher
+	// fee rate than the transaction it replaces.
+	if feeRate <= sweep.feeRate {
+		return nil, fmt.Errorf("fee rate of %v sat/weight must exceed "+
+			"current fee rate of %v sat/weight", feeRate,
+			sweep.feeRate)
+	}
+
+	if err := s.replaceSweep(sweep, feeRate); err != nil {
+		return nil, err
+	}
+
+	txid := sweep.tx.TxHash()
+	return &txid, nil
+}
+
+// FindSweepInput returns one of the outputs spent by the sweep transaction
+// with the passed txid. Any version of the sweep transaction is recognized.
+// If the transaction wasn't crafted by the sweeper, then false is returned.
+func (s *utxoSweeper) FindSweepInput(txid chainhash.Hash) (wire.OutPoint, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for sweep := range s.sweeps {
+		if _, ok := sweep.txids[txid]; !ok || len(sweep.inputs) == 0 {
+			continue
+		}
+
+		return *sweep.inputs[0].req.Output.OutPoint(), true
+	}
+
+	return wire.OutPoint{}, false
+}
+
+// CreateCpfpTx creates a signed transaction which spends the parent's output
+// at index outputIndex, which must pay to a key controlled by the wallet, into
+// a fresh wallet output. The fee of the child is chosen such that the parent
+// and child together pay the passed fee rate, expressed in satoshis/weight,
+// given that the parent already pays parentFee.
+func (s *utxoSweeper) CreateCpfpTx(parent *wire.MsgTx, outputIndex uint32,
+	parentFee btcutil.Amount, feeRate uint64) (*wire.MsgTx, error) {
+
+	if outputIndex >= uint32(len(parent.TxOut)) {
+		return nil, fmt.Errorf("output index %v out of range", outputIndex)
+	}
+	prevOut := parent.TxOut[outputIndex]
+
+	parentWeight := uint64(blockchain.GetTransactionWeight(
+		btcutil.NewTx(parent),
+	))
+	childWeight := uint64(4*lnwallet.BaseSweepTxSize +
+		lnwallet.WitnessHeaderSize + 4*lnwallet.InputSize +
+		lnwallet.P2WKHWitnessSize)
+
+	// The child must pay for the weight of the entire package, less the
+	// fee already paid by the parent. Regardless, the child must pay at
+	// least the requested fee rate itself.
+	childFee := btcutil.Amount(feeRate * childWeight)
+	packageFee := btcutil.Amount(feeRate * (parentWeight + childWeight))
+	if packageFee-parentFee > childFee {
+		childFee = packageFee - parentFee
+	}
+
+	sweepAmt := btcutil.Amount(prevOut.Value) - childFee
+	if sweepAmt < lnwallet.DefaultDustLimit() {
+		return nil, fmt.Errorf("output of %v is insufficient to pay "+
+			"child fee of %v", btcutil.Amount(prevOut.Value),
+			childFee)
+	}
+
+	pkScript, err := s.cfg.GenSweepScript()
+	if err != nil {
+		return nil, err
+	}
+
+	childTx := wire.NewMsgTx(2)
+	childTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  parent.TxHash(),
+			Index: outputIndex,
+		},
+	})
+	childTx.AddTxOut(&wire.TxOut{
+		PkScript: pkScript,
+		Value:    int64(sweepAmt),
+	})
+
+	btx := btcutil.NewTx(childTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return nil, err
+	}
+
+	signDesc := &lnwallet.SignDescriptor{
+		Output:     prevOut,
+		HashType:   txscript.SigHashAll,
+		SigHashes:  txscript.NewTxSigHashes(childTx),
+		InputIndex: 0,
+	}
+	inputScript, err := s.cfg.Signer.ComputeInputScript(childTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+	childTx.TxIn[0].Witness = inputScript.Witness
+	childTx.TxIn[0].SignatureScript = inputScript.ScriptSig
+
+	return childTx, nil
+}
+
+// SweepAnchor hands off our anchor output on a commitment transaction which
+// has just been broadcast. Until the commitment confirms, the anchor is used
+// to CPFP it whenever its fee rate falls short of the rate required to confirm
+// by the passed deadline. Once the commitment confirms, the anchor is swept
+// like any other output if it wasn't spent by a child transaction. If the
+// deadline is zero, then the default confirmation target is used.
+func (s *utxoSweeper) SweepAnchor(anchor *lnwallet.AnchorResolution,
+	deadline uint32) error {
+
+	s.Lock()
+	defer s.Unlock()
+
+	op := anchor.CommitAnchor
+	if _, ok := s.anchors[op]; ok {
+		return nil
+	}
+
+	if deadline == 0 {
+		deadline = s.currentHeight + defaultSweepConfTarget
+	}
+
+	confNtfn, err := s.cfg.Notifier.RegisterConfirmationsNtfn(
+		&op.Hash, 1, s.currentHeight,
+	)
+	if err != nil {
+		return err
+	}
+
+	swprLog.Infof("Anchor %v of commitment (fee=%v, weight=%v) added to "+
+		"sweeper with deadline=%v", op, anchor.CommitFee,
+		anchor.CommitWeight, deadline)
+
+	pending := &pendingAnchor{
+		resolution: anchor,
+		deadline:   deadline,
+	}
+	s.anchors[op] = pending
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case conf, ok := <-confNtfn.Confirmed:
+			if !ok {
+				return
+			}
+			s.handleAnchorConf(op, conf.BlockHeight)
+
+		case <-s.quit:
+		}
+	}()
+
+	// If the commitment already pays the fee rate required to confirm by
+	// its deadline, then there's no need to CPFP it yet.
+	feeRate := s.feeRateForDeadline(deadline)
+	if feeRate <= commitFeeRate(anchor) {
+		return nil
+	}
+
+	if err := s.cpfpAnchor(pending, feeRate); err != nil {
+		swprLog.Errorf("unable to CPFP commitment %v: %v", op.Hash,
+			err)
+	}
+
+	return nil
+}
+
+// BumpCommitment broadcasts a child transaction spending our anchor output on
+// the unconfirmed commitment transaction with the passed txid, such that the
+// commitment and child together pay the passed fee rate, expressed in
+// satoshis/weight. The txid of the child transaction is returned.
+func (s *utxoSweeper) BumpCommitment(commitTxid chainhash.Hash,
+	feeRate uint64) (*chainhash.Hash, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	var anchor *pendingAnchor
+	for op, pending := range s.anchors {
+		if op.Hash == commitTxid {
+			anchor = pending
+			break
+		}
+	}
+	if anchor == nil {
+		return nil, ErrSweepNotPending
+	}
+
+	// As the new child will replace the previous one, it must pay a
+	// higher fee rate.
+	currentRate := anchor.feeRate
+	commitRate := commitFeeRate(anchor.resolution)
+	if commitRate > currentRate {
+		currentRate = commitRate
+	}
+	if feeRate <= currentRate {
+		return nil, fmt.Errorf("fee rate of %v sat/weight must exceed "+
+			"current fee rate of %v sat/weight", feeRate,
+			currentRate)
+	}
+
+	if err := s.cpfpAnchor(anchor, feeRate); err != nil {
+		return nil, err
+	}
+
+	txid := anchor.cpfpTx.TxHash()
+	return &txid, nil
+}
+
+// commitFeeRate returns the fee rate, expressed in satoshis/weight, paid by
+// the commitment transaction the anchor belongs to.
+func commitFeeRate(anchor *lnwallet.AnchorResolution) uint64 {
+	return uint64(anchor.CommitFee) / uint64(anchor.CommitWeight)
+}
+
+// handleAnchorConf stops tracking the anchor with the passed outpoint once its
+// commitment transaction has confirmed. If the anchor wasn't spent by a child
+// transaction, then it's handed off to be swept like any other output.
+func (s *utxoSweeper) handleAnchorConf(op wire.OutPoint, confHeight uint32) {
+	s.Lock()
+	anchor, ok := s.anchors[op]
+	if !ok {
+		s.Unlock()
+		return
+	}
+	delete(s.anchors, op)
+
+	if anchor.utxo != nil {
+		s.cfg.Wallet.UnlockOutpoint(anchor.utxo.OutPoint)
+	}
+	s.Unlock()
+
+	swprLog.Infof("Commitment %v confirmed", op.Hash)
+
+	output := newBreachedOutput(
+		&op, lnwallet.CommitmentAnchor,
+		&anchor.resolution.AnchorSignDesc,
+	)
+	req := &SweepRequest{
+		Output:     output,
+		Deadline:   confHeight + defaultSweepConfTarget,
+		HeightHint: confHeight,
+		ChanPoint:  &anchor.resolution.ChanPoint,
+	}
+
+	// If a child was broadcast, then the anchor has already been spent
+	// alongside the commitment.
+	if anchor.cpfpTx != nil {
+		s.recordResolution(
+			req, channeldb.ResolutionClaimed, anchor.cpfpTx.TxHash(),
+		)
+		return
+	}
+
+	_, err := s.SweepOutput(req)
+	if err != nil {
+		swprLog.Errorf("unable to sweep anchor %v: %v", op, err)
+	}
+}
+
+// bumpExpiredAnchors broadcasts a child transaction paying a higher fee rate
+// for each commitment transaction which hasn't confirmed by its deadline.
+func (s *utxoSweeper) bumpExpiredAnchors() {
+	s.Lock()
+	defer s.Unlock()
+
+	for op, anchor := range s.anchors {
+		if s.currentHeight < anchor.deadline {
+			continue
+		}
+
+		// As with sweep transactions, we'll increase the fee rate by a
+		// quarter at each block, ensuring we pay at least the rate
+		// required for confirmation within the next block.
+		feeRate := anchor.feeRate
+		commitRate := commitFeeRate(anchor.resolution)
+		if commitRate > feeRate {
+			feeRate = commitRate
+		}
+		feeRate += feeRate/4 + 1
+		if urgentRate := s.cfg.Estimator.EstimateFeePerWeight(
+			urgentSweepConfTarget,
+		); urgentRate > feeRate {
+			feeRate = urgentRate
+		}
+
+		swprLog.Infof("Commitment %v unconfirmed at deadline=%v, "+
+			"bumping fee", op.Hash, anchor.deadline)
+
+		if err := s.cpfpAnchor(anchor, feeRate); err != nil {
+			swprLog.Errorf("unable to CPFP commitment %v: %v",
+				op.Hash, err)
+		}
+	}
+}
+
+// cpfpAnchor broadcasts a child transaction spending the anchor along with a
+// wallet output, such that the commitment and child together pay the passed
+// fee rate, expressed in satoshis/weight. Any previous version of the child is
+// replaced.
+//
+// NOTE: This method MUST be called with the sweeper's lock held.
+func (s *utxoSweeper) cpfpAnchor(anchor *pendingAnchor, feeRate uint64) error {
+	if anchor.utxo == nil {
+		if err := s.selectAnchorUtxo(anchor); err != nil {
+			return err
+		}
+	}
+	if anchor.pkScript == nil {
+		pkScript, err := s.cfg.GenSweepScript()
+		if err != nil {
+			return err
+		}
+		anchor.pkScript = pkScript
+	}
+
+	res := anchor.resolution
+	childWeight := uint64(4*lnwallet.BaseSweepTxSize +
+		lnwallet.WitnessHeaderSize + 8*lnwallet.InputSize +
+		lnwallet.AnchorWitnessSize + lnwallet.P2WKHWitnessSize)
+
+	// The child must pay for the weight of the entire package, less the
+	// fee already paid by the commitment. Regardless, the child must pay
+	// at least the requested fee rate itself.
+	childFee := btcutil.Amount(feeRate * childWeight)
+	packageFee := btcutil.Amount(
+		feeRate * (uint64(res.CommitWeight) + childWeight),
+	)
+	if packageFee-res.CommitFee > childFee {
+		childFee = packageFee - res.CommitFee
+	}
+
+	anchorAmt := btcutil.Amount(res.AnchorSignDesc.Output.Value)
+	changeAmt := anchorAmt + anchor.utxo.Value - childFee
+	if changeAmt < lnwallet.DefaultDustLimit() {
+		return fmt.Errorf("inputs of %v are insufficient to pay child "+
+			"fee of %v", anchorAmt+anchor.utxo.Value, childFee)
+	}
+
+	childTx := wire.NewMsgTx(2)
+	childTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: res.CommitAnchor,
+	})
+	childTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: anchor.utxo.OutPoint,
+	})
+	childTx.AddTxOut(&wire.TxOut{
+		PkScript: anchor.pkScript,
+		Value:    int64(changeAmt),
+	})
+
+	btx := btcutil.NewTx(childTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return err
+	}
+
+	hashCache := txscript.NewTxSigHashes(childTx)
+
+	anchorDesc := res.AnchorSignDesc
+	anchorDesc.SigHashes = hashCache
+	anchorDesc.InputIndex = 0
+	anchorWitness, err := lnwallet.CommitSpendAnchor(
+		s.cfg.Signer, &anchorDesc, childTx,
+	)
+	if err != nil {
+		return err
+	}
+	childTx.TxIn[0].Witness = anchorWitness
+
+	utxoDesc := &lnwallet.SignDescriptor{
+		Output:     anchor.utxoOutput,
+		HashType:   txscript.SigHashAll,
+		SigHashes:  hashCache,
+		InputIndex: 1,
+	}
+	inputScript, err := s.cfg.Signer.ComputeInputScript(childTx, utxoDesc)
+	if err != nil {
+		return err
+	}
+	childTx.TxIn[1].Witness = inputScript.Witness
+	childTx.TxIn[1].SignatureScript = inputScript.ScriptSig
+
+	swprLog.Infof("CPFP'ing commitment %v with child tx (txid=%v, "+
+		"fee_rate=%v sat/weight)", res.CommitAnchor.Hash,
+		childTx.TxHash(), feeRate)
+
+	if err := s.cfg.PublishTransaction(childTx); err != nil {
+		return err
+	}
+
+	anchor.cpfpTx = childTx
+	anchor.feeRate = feeRate
+
+	return nil
+}
+
+// selectAnchorUtxo selects the largest confirmed p2wkh wallet output to fund
+// the child transactions of the passed anchor, and locks it so it isn't spent
+// elsewhere.
+//
+// NOTE: This method MUST be called with the sweeper's lock held.
+func (s *utxoSweeper) selectAnchorUtxo(anchor *pendingAnchor) error {
+	utxos, err := s.cfg.Wallet.ListUnspentWitness(1)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		if anchor.utxo != nil && utxo.Value <= anchor.utxo.Value {
+			continue
+		}
+
+		output, err := s.cfg.Wallet.FetchInputInfo(&utxo.OutPoint)
+		if err != nil {
+			return err
+		}
+
+		// Only p2wkh outputs are considered, as the weight of the
+		// child is estimated with a p2wkh witness.
+		scriptClass := txscript.GetScriptClass(output.PkScript)
+		if scriptClass != txscript.WitnessV0PubKeyHashTy {
+			continue
+		}
+
+		anchor.utxo = utxo
+		anchor.utxoOutput = output
+	}
+	if anchor.utxo == nil {
+		return fmt.Errorf("no wallet outputs available to CPFP " +
+			"commitment")
+	}
+
+	s.cfg.Wallet.LockOutpoint(anchor.utxo.OutPoint)
+
+	return nil
+}
+
+// collector is the main goroutine of the utxoSweeper. It adds new outputs to
+// the pending set, sweeps them in batches once the batch window has passed,
+// bumps the fee of any sweep transactions which haven't confirmed by their
+// deadline, and notifies callers once their outputs have been spent.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *utxoSweeper) collector(blockEpochs *chainntnfs.BlockEpochEvent) {
+	defer s.wg.Done()
+	defer blockEpochs.Cancel()
+
+	var batchTimer <-chan time.Time
+	for {
+		select {
+		case inputReq := <-s.newInputs:
+			s.addPendingInput(inputReq)
+
+			if batchTimer == nil {
+				batchTimer = time.After(s.cfg.BatchWindow)
+			}
+
+		case spend := <-s.spends:
+			// If the output was spent by a transaction we didn't
+			// create, then any other outputs within our sweep
+			// transaction will need to be swept again.
+			if s.handleSpend(spend) && batchTimer == nil {
+				batchTimer = time.After(s.cfg.BatchWindow)
+			}
+
+		case <-batchTimer:
+			batchTimer = nil
+
+			s.sweepPendingInputs()
+
+		case epoch, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+			s.Lock()
+			s.currentHeight = uint32(epoch.Height)
+			s.Unlock()
+
+			// With the new height known, we'll replace any sweep
+			// transactions that have passed their deadline, and
+			// attempt to sweep any outputs which previously
+			// failed to be swept, or whose lock time has now
+			// passed.
+			s.bumpExpiredSweeps()
+			s.bumpExpiredAnchors()
+			s.sweepPendingInputs()
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// addPendingInput adds a new output to the pending set, and registers for a
+// notification once it has been spent. If the output is already pending,
+// then the result channel of the request is added to the existing entry.
+func (s *utxoSweeper) addPendingInput(inputReq *sweepInputRequest) {
+	s.Lock()
+	defer s.Unlock()
+
+	req := inputReq.req
+	op := *req.Output.OutPoint()
+	if input, ok := s.pendingInputs[op]; ok {
+		input.resultChans = append(input.resultChans,
+			inputReq.resultChan)
+		return
+	}
+
+	heightHint := req.HeightHint
+	if heightHint == 0 {
+		heightHint = s.currentHeight
+	}
+
+	spendNtfn, err := s.cfg.Notifier.RegisterSpendNtfn(&op, heightHint)
+	if err != nil {
+		inputReq.resultChan <- &SweepResult{
+			Err: fmt.Errorf("unable to register spend "+
+				"notification for %v: %v", op, err),
+		}
+		return
+	}
+
+	swprLog.Infof("Output %v (witness_type=%v, amt=%v) added to "+
+		"sweeper with deadline=%v", op, req.Output.WitnessType(),
+		req.Output.Amount(), req.Deadline)
+
+	s.pendingInputs[op] = &pendingInput{
+		req:         req,
+		resultChans: []chan *SweepResult{inputReq.resultChan},
+	}
+	s.recordResolution(req, channeldb.ResolutionPending, chainhash.Hash{})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case spend, ok := <-spendNtfn.Spend:
+			if !ok {
+				return
+			}
+
+			select {
+			case s.spends <- spend:
+			case <-s.quit:
+			}
+
+		case <-s.quit:
+		}
+	}()
+}
+
+// handleSpend removes a spent output from the pending set, and notifies the
+// callers that handed it to the sweeper. True is returned if the output was
+// spent by a transaction we didn't create, and other outputs within our sweep
+// transaction must be swept again.
+func (s *utxoSweeper) handleSpend(spend *chainntnfs.SpendDetail) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	op := *spend.SpentOutPoint
+	input, ok := s.pendingInputs[op]
+	if !ok {
+		return false
+	}
+	delete(s.pendingInputs, op)
+
+	result := &SweepResult{
+		Tx: spend.SpendingTx,
+	}
+
+	var (
+		resweep bool
+		isOurs  bool
+	)
+	sweep := input.sweep
+	if sweep != nil {
+		_, isOurs = sweep.txids[*spend.SpenderTxHash]
+	}
+
+	switch {
+	case isOurs:
+		swprLog.Infof("Output %v swept by txid=%v", op,
+			spend.SpenderTxHash)
+
+		s.recordResolution(
+			input.req, channeldb.ResolutionClaimed,
+			*spend.SpenderTxHash,
+		)
+
+		sweep.confirmed = true
+		for i, sweptInput := range sweep.inputs {
+			if sweptInput == input {
+				sweep.inputs = append(sweep.inputs[:i],
+					sweep.inputs[i+1:]...)
+				break
+			}
+		}
+		if len(sweep.inputs) == 0 {
+			delete(s.sweeps, sweep)
+		}
+
+	default:
+		swprLog.Warnf("Output %v spent by foreign txid=%v", op,
+			spend.SpenderTxHash)
+
+		result.Err = ErrSweepInputSpentRemotely
+
+		s.recordResolution(
+			input.req, channeldb.ResolutionLost,
+			*spend.SpenderTxHash,
+		)
+
+		// As one of its inputs has been spent elsewhere, our sweep
+		// transaction can no longer confirm, so we'll release the rest
+		// of its inputs to be swept again.
+		if sweep != nil {
+			delete(s.sweeps, sweep)
+			for _, sweptInput := range sweep.inputs {
+				sweptInput.sweep = nil
+			}
+			resweep = len(sweep.inputs) > 1
+		}
+	}
+
+	for _, resultChan := range input.resultChans {
+		resultChan <- result
+	}
+
+	return resweep
+}
+
+// recordResolution persists the current resolution of the output of the
+// passed request, if it belongs to a closed channel.
+func (s *utxoSweeper) recordResolution(req *SweepRequest,
+	outcome channeldb.ResolutionOutcome, sweepTxid chainhash.Hash) {
+
+	if req.ChanPoint == nil || s.cfg.RecordResolution == nil {
+		return
+	}
+
+	res := &channeldb.OutputResolution{
+		OutPoint:  *req.Output.OutPoint(),
+		Type:      resolutionType(req.Output.WitnessType()),
+		Outcome:   outcome,
+		Amount:    req.Output.Amount(),
+		SweepTxid: sweepTxid,
+	}
+	if err := s.cfg.RecordResolution(req.ChanPoint, res); err != nil {
+		swprLog.Errorf("unable to record resolution of %v: %v",
+			res.OutPoint, err)
+	}
+}
+
+// resolutionType maps the witness type of a swept output to the kind of
+// channel output it resolves.
+func resolutionType(w lnwallet.WitnessType) channeldb.ResolutionType {
+	switch w {
+	case lnwallet.CommitmentAnchor:
+		return channeldb.ResolutionAnchor
+
+	case lnwallet.CommitmentRevoke, lnwallet.HtlcOfferedRevoke,
+		lnwallet.HtlcAcceptedRevoke:
+
+		return channeldb.ResolutionRevoked
+
+	case lnwallet.HtlcOfferedTimeoutSecondLevel,
+		lnwallet.HtlcOfferedRemoteTimeout:
+
+		return channeldb.ResolutionOutgoingHtlc
+
+	case lnwallet.HtlcAcceptedSuccessSecondLevel,
+		lnwallet.HtlcAcceptedRemoteSuccess:
+
+		return channeldb.ResolutionIncomingHtlc
+
+	default:
+		return channeldb.ResolutionCommit
+	}
+}
+
+// feeRateForDeadline returns the fee rate, expressed in satoshis/weight,
+// required to confirm a transaction before the passed deadline.
+//
+// NOTE: This method MUST be called with the sweeper's lock held.
+func (s *utxoSweeper) feeRateForDeadline(deadline uint32) uint64 {
+	confTarget := uint32(1)
+	if deadline > s.currentHeight+1 {
+		confTarget = deadline - s.currentHeight
+	}
+
+	return s.cfg.Estimator.EstimateFeePerWeight(confTarget)
+}
+
+// sweepPendingInputs groups all the pending outputs which aren't yet spent by
+// a sweep transaction into fee rate buckets, then sweeps each bucket within a
+// single transaction. Any outputs that fail to be swept will be retried at
+// the next block.
+func (s *utxoSweeper) sweepPendingInputs() {
+	s.Lock()
+	defer s.Unlock()
+
+	type bucket struct {
+		inputs   []*pendingInput
+		feeRate  uint64
+		deadline uint32
+	}
+
+	buckets := make(map[uint64]*bucket)
+	for _, input := range s.pendingInputs {
+		if input.sweep != nil || input.req.LockTime > s.currentHeight {
+			continue
+		}
+
+		feeRate := s.feeRateForDeadline(input.req.Deadline)
+
+		// If the output isn't worth the fee required to spend it at
+		// this fee rate, then we'll leave it pending until fees drop.
+		output := input.req.Output
+		witnessSize, err := output.WitnessType().WitnessSize()
+		if err != nil {
+			swprLog.Errorf("unable to sweep %v: %v",
+				output.OutPoint(), err)
+			continue
+		}
+		inputFee := feeRate * uint64(4*lnwallet.InputSize+witnessSize)
+		if uint64(output.Amount()) <= inputFee {
+			swprLog.Debugf("Output %v (amt=%v) is uneconomical to "+
+				"sweep at %v sat/weight", output.OutPoint(),
+				output.Amount(), feeRate)
+			continue
+		}
+
+		key := feeRate / s.cfg.FeeRateBucketSize
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{
+				feeRate:  feeRate,
+				deadline: input.req.Deadline,
+			}
+			buckets[key] = b
+		}
+
+		// Each bucket pays the highest fee rate, and is bound by the
+		// earliest deadline, of all its outputs.
+		b.inputs = append(b.inputs, input)
+		if feeRate > b.feeRate {
+			b.feeRate = feeRate
+		}
+		if input.req.Deadline < b.deadline {
+			b.deadline = input.req.Deadline
+		}
+	}
+
+	for _, b := range buckets {
+		_, err := s.publishSweep(b.inputs, b.feeRate, b.deadline)
+		if err != nil {
+			swprLog.Errorf("unable to sweep %v outputs: %v",
+				len(b.inputs), err)
+		}
+	}
+}
+
+// publishSweep crafts and broadcasts a transaction sweeping the passed
+// outputs at the passed fee rate, expressed in satoshis/weight. Once
+// broadcast, the sweep is tracked until it confirms, and its fee is bumped if
+// it hasn't confirmed by the passed deadline.
+//
+// NOTE: This method MUST be called with the sweeper's lock held.
+func (s *utxoSweeper) publishSweep(inputs []*pendingInput, feeRate uint64,
+	deadline uint32) (*pendingSweep, error) {
+
+	pkScript, err := s.cfg.GenSweepScript()
+	if err != nil {
+		return nil, err
+	}
+
+	sweepTx, err := s.createSweepTx(inputs, feeRate, pkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	swprLog.Infof("Sweeping %v outputs with sweep tx (txid=%v, "+
+		"fee_rate=%v sat/weight): %v", len(inputs), sweepTx.TxHash(),
+		feeRate, newLogClosure(func() string {
+			return spew.Sdump(sweepTx)
+		}))
+
+	if err := s.cfg.PublishTransaction(sweepTx); err != nil {
+		return nil, err
+	}
+
+	sweep := &pendingSweep{
+		tx: sweepTx,
+		txids: map[chainhash.Hash]struct{}{
+			sweepTx.TxHash(): {},
+		},
+		pkScript:          pkScript,
+		feeRate:           feeRate,
+		deadline:          deadline,
+		broadcastAttempts: 1,
+		inputs:            inputs,
+	}
+	for _, input := range inputs {
+		input.sweep = sweep
+	}
+	s.sweeps[sweep] = struct{}{}
+
+	return sweep, nil
+}
+
+// replaceSweep replaces the passed sweep transaction with a transaction
+// spending the same outputs, but paying the passed fee rate, expressed in
+// satoshis/weight.
+//
+// NOTE: This method MUST be called with the sweeper's lock held.
+func (s *utxoSweeper) replaceSweep(sweep *pendingSweep, feeRate uint64) error {
+	sweepTx, err := s.createSweepTx(sweep.inputs, feeRate, sweep.pkScript)
+	if err != nil {
+		return err
+	}
+
+	swprLog.Infof("Replacing sweep tx (txid=%v) with txid=%v "+
+		"(fee_rate=%v sat/weight)", sweep.tx.TxHash(),
+		sweepTx.TxHash(), feeRate)
+
+	if err := s.cfg.PublishTransaction(sweepTx); err != nil {
+		return err
+	}
+
+	sweep.tx = sweepTx
+	sweep.txids[sweepTx.TxHash()] = struct{}{}
+	sweep.feeRate = feeRate
+	sweep.broadcastAttempts++
+
+	return nil
+}
+
+// bumpExpiredSweeps replaces each unconfirmed sweep transaction which has
+// passed its deadline with a transaction spending the same outputs, but paying
+// a higher fee rate.
+func (s *utxoSweeper) bumpExpiredSweeps() {
+	s.Lock()
+	defer s.Unlock()
+
+	for sweep := range s.sweeps {
+		if sweep.confirmed || s.currentHeight < sweep.deadline {
+			continue
+		}
+
+		// We'll increase the fee rate by a quarter at each block,
+		// ensuring we pay at least the rate required for confirmation
+		// within the next block.
+		feeRate := sweep.feeRate + sweep.feeRate/4 + 1
+		if urgentRate := s.cfg.Estimator.EstimateFeePerWeight(
+			urgentSweepConfTarget,
+		); urgentRate > feeRate {
+			feeRate = urgentRate
+		}
+
+		swprLog.Infof("Sweep tx (txid=%v) unconfirmed at deadline=%v, "+
+			"bumping fee", sweep.tx.TxHash(), sweep.deadline)
+
+		if err := s.replaceSweep(sweep, feeRate); err != nil {
+			swprLog.Errorf("unable to bump fee of sweep tx "+
+				"(txid=%v): %v", sweep.tx.TxHash(), err)
+		}
+	}
+}
+
+// createSweepTx creates a signed transaction sweeping all the passed outputs
+// into a single output paying to pkScript, at the passed fee rate expressed
+// in satoshis/weight.
+func (s *utxoSweeper) createSweepTx(inputs []*pendingInput, feeRate uint64,
+	pkScript []byte) (*wire.MsgTx, error) {
+
+	// We'll begin by computing the weight of the sweep transaction, along
+	// with the total amount swept, and the lock time required by the
+	// outputs being spent.
+	txWeight := uint64(4*lnwallet.BaseSweepTxSize +
+		lnwallet.WitnessHeaderSize)
+
+	var (
+		totalAmt btcutil.Amount
+		lockTime uint32
+	)
+	for _, input := range inputs {
+		output := input.req.Output
+
+		witnessSize, err := output.WitnessType().WitnessSize()
+		if err != nil {
+			return nil, err
+		}
+		txWeight += uint64(4*lnwallet.InputSize + witnessSize)
+
+		totalAmt += output.Amount()
+		if input.req.LockTime > lockTime {
+			lockTime = input.req.LockTime
+		}
+	}
+
+	txFee := btcutil.Amount(txWeight * feeRate)
+	sweepAmt := totalAmt - txFee
+	if sweepAmt < lnwallet.DefaultDustLimit() {
+		return nil, fmt.Errorf("sweep output of %v would be dust "+
+			"after paying fee of %v", totalAmt, txFee)
+	}
+
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = lockTime
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: pkScript,
+		Value:    int64(sweepAmt),
+	})
+	for _, input := range inputs {
+		sweepTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *input.req.Output.OutPoint(),
+			Sequence:         input.req.Sequence,
+		})
+	}
+
+	// Before signing the transaction, check to ensure that it meets some
+	// basic validity requirements.
+	btx := btcutil.NewTx(sweepTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return nil, err
+	}
+
+	// With all the inputs in place, use each output's unique witness
+	// function to generate the final witness required for spending.
+	hashCache := txscript.NewTxSigHashes(sweepTx)
+	for i, input := range inputs {
+		witness, err := input.req.Output.BuildWitness(
+			s.cfg.Signer, sweepTx, hashCache, i,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sweepTx.TxIn[i].Witness = witness
+	}
+
+	return sweepTx, nil
+}
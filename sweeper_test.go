@@ -0,0 +1,421 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockSpendableOutput is a SpendableOutput which generates a static witness.
+type mockSpendableOutput struct {
+	amt      btcutil.Amount
+	outpoint wire.OutPoint
+}
+
+func (m *mockSpendableOutput) Amount() btcutil.Amount {
+	return m.amt
+}
+
+func (m *mockSpendableOutput) OutPoint() *wire.OutPoint {
+	return &m.outpoint
+}
+
+func (m *mockSpendableOutput) WitnessType() lnwallet.WitnessType {
+	return lnwallet.CommitmentNoDelay
+}
+
+func (m *mockSpendableOutput) BuildWitness(signer lnwallet.Signer,
+	txn *wire.MsgTx, hashCache *txscript.TxSigHashes,
+	txinIdx int) ([][]byte, error) {
+
+	return [][]byte{{byte(txinIdx)}}, nil
+}
+
+// mockTargetFeeEstimator is a FeeEstimator which returns a fee rate based on
+// the requested confirmation target.
+type mockTargetFeeEstimator struct {
+	rates map[uint32]uint64
+}
+
+func (m *mockTargetFeeEstimator) EstimateFeePerByte(numBlocks uint32) uint64 {
+	return m.EstimateFeePerWeight(numBlocks) * 4
+}
+
+func (m *mockTargetFeeEstimator) EstimateFeePerWeight(numBlocks uint32) uint64 {
+	if rate, ok := m.rates[numBlocks]; ok {
+		return rate
+	}
+	return 1
+}
+
+func (m *mockTargetFeeEstimator) EstimateConfirmation(satPerByte int64) uint32 {
+	return 1
+}
+
+// newTestSweeper creates a utxoSweeper at the passed height, along with a
+// slice that's populated with each transaction the sweeper broadcasts.
+func newTestSweeper(height uint32,
+	rates map[uint32]uint64) (*utxoSweeper, *[]*wire.MsgTx) {
+
+	var published []*wire.MsgTx
+	sweeper := newUtxoSweeper(&SweeperConfig{
+		Estimator: &mockTargetFeeEstimator{rates: rates},
+		GenSweepScript: func() ([]byte, error) {
+			return make([]byte, 22), nil
+		},
+		PublishTransaction: func(tx *wire.MsgTx) error {
+			published = append(published, tx)
+			return nil
+		},
+		FeeRateBucketSize: defaultFeeRateBucketSize,
+	})
+	sweeper.currentHeight = height
+
+	return sweeper, &published
+}
+
+// addTestInput adds a new pending output to the sweeper, bypassing the
+// registration for its spend notification.
+func addTestInput(s *utxoSweeper, index uint32, amt btcutil.Amount,
+	deadline, lockTime uint32) *pendingInput {
+
+	output := &mockSpendableOutput{
+		amt: amt,
+		outpoint: wire.OutPoint{
+			Hash:  chainhash.Hash{1},
+			Index: index,
+		},
+	}
+	input := &pendingInput{
+		req: &SweepRequest{
+			Output:   output,
+			Deadline: deadline,
+			LockTime: lockTime,
+		},
+		resultChans: []chan *SweepResult{make(chan *SweepResult, 1)},
+	}
+	s.pendingInputs[output.outpoint] = input
+
+	return input
+}
+
+// TestSweeperBatching tests that the sweeper batches outputs whose deadlines
+// map to the same fee rate bucket within a single transaction, while outputs
+// in distinct buckets, or which are still time-locked, aren't swept together.
+func TestSweeperBatching(t *testing.T) {
+	t.Parallel()
+
+	const height = 100
+	sweeper, published := newTestSweeper(height, map[uint32]uint64{
+		1: 50,
+		6: 10,
+		7: 12,
+	})
+
+	// The first two outputs have deadlines that map to the same bucket,
+	// while the third requires a much higher fee rate. The final output
+	// can't yet be spent as its lock time hasn't passed.
+	input1 := addTestInput(sweeper, 0, 100000, height+6, 0)
+	input2 := addTestInput(sweeper, 1, 200000, height+7, 0)
+	input3 := addTestInput(sweeper, 2, 300000, height+1, 0)
+	input4 := addTestInput(sweeper, 3, 400000, height+6, height+1)
+
+	sweeper.sweepPendingInputs()
+
+	if len(*published) != 2 {
+		t.Fatalf("expected 2 sweep txns, instead got %v",
+			len(*published))
+	}
+	if input1.sweep == nil || input1.sweep != input2.sweep {
+		t.Fatalf("outputs within the same bucket weren't batched")
+	}
+	if input3.sweep == nil || input3.sweep == input1.sweep {
+		t.Fatalf("outputs within distinct buckets were batched")
+	}
+	if input4.sweep != nil {
+		t.Fatalf("time-locked output was swept")
+	}
+
+	// The batched transaction should pay the highest fee rate of all the
+	// outputs it spends, and be bound by the earliest deadline.
+	batch := input1.sweep
+	if batch.feeRate != 12 {
+		t.Fatalf("expected fee rate of 12, instead got %v",
+			batch.feeRate)
+	}
+	if batch.deadline != height+6 {
+		t.Fatalf("expected deadline of %v, instead got %v", height+6,
+			batch.deadline)
+	}
+	if len(batch.tx.TxIn) != 2 {
+		t.Fatalf("expected 2 inputs, instead got %v",
+			len(batch.tx.TxIn))
+	}
+
+	witnessSize, _ := lnwallet.CommitmentNoDelay.WitnessSize()
+	txWeight := 4*lnwallet.BaseSweepTxSize + lnwallet.WitnessHeaderSize +
+		2*(4*lnwallet.InputSize+witnessSize)
+	expectedAmt := int64(300000 - txWeight*12)
+	if batch.tx.TxOut[0].Value != expectedAmt {
+		t.Fatalf("expected sweep output of %v, instead got %v",
+			expectedAmt, batch.tx.TxOut[0].Value)
+	}
+
+	// Once the lock time of the final output has passed, it should be
+	// swept within its own transaction.
+	sweeper.currentHeight = height + 1
+	sweeper.sweepPendingInputs()
+
+	if len(*published) != 3 {
+		t.Fatalf("expected 3 sweep txns, instead got %v",
+			len(*published))
+	}
+	if input4.sweep == nil || input4.sweep.tx.LockTime != height+1 {
+		t.Fatalf("time-locked output wasn't swept with proper " +
+			"lock time")
+	}
+}
+
+// TestSweeperFeeBump tests that the sweeper replaces a sweep transaction that
+// hasn't confirmed by its deadline with one paying a higher fee rate, and
+// recognizes the confirmation of any version of the transaction.
+func TestSweeperFeeBump(t *testing.T) {
+	t.Parallel()
+
+	const height = 100
+	sweeper, published := newTestSweeper(height, map[uint32]uint64{
+		1: 10,
+		2: 10,
+	})
+
+	input := addTestInput(sweeper, 0, 100000, height+2, 0)
+	sweeper.sweepPendingInputs()
+
+	if len(*published) != 1 {
+		t.Fatalf("expected 1 sweep tx, instead got %v", len(*published))
+	}
+	sweep := input.sweep
+	origTx := sweep.tx
+
+	// Prior to the deadline, the transaction shouldn't be replaced.
+	sweeper.currentHeight = height + 1
+	sweeper.bumpExpiredSweeps()
+	if len(*published) != 1 {
+		t.Fatalf("sweep tx replaced before deadline")
+	}
+
+	// Once the deadline has been reached, a replacement transaction
+	// paying a higher fee should be broadcast.
+	sweeper.currentHeight = height + 2
+	sweeper.bumpExpiredSweeps()
+	if len(*published) != 2 {
+		t.Fatalf("sweep tx wasn't replaced at deadline")
+	}
+	if sweep.feeRate <= 10 {
+		t.Fatalf("fee rate wasn't increased: %v", sweep.feeRate)
+	}
+	if sweep.tx.TxOut[0].Value >= origTx.TxOut[0].Value {
+		t.Fatalf("replacement tx doesn't pay a higher fee")
+	}
+	if sweep.broadcastAttempts != 2 {
+		t.Fatalf("expected 2 broadcast attempts, instead got %v",
+			sweep.broadcastAttempts)
+	}
+
+	// If the original version of the transaction confirms, then the
+	// output should be reported as swept.
+	origTxid := origTx.TxHash()
+	resweep := sweeper.handleSpend(&chainntnfs.SpendDetail{
+		SpentOutPoint: input.req.Output.OutPoint(),
+		SpenderTxHash: &origTxid,
+		SpendingTx:    origTx,
+	})
+	if resweep {
+		t.Fatalf("resweep requested after our own spend")
+	}
+
+	result := <-input.resultChans[0]
+	if result.Err != nil {
+		t.Fatalf("expected successful sweep, instead got: %v",
+			result.Err)
+	}
+	if len(sweeper.pendingInputs) != 0 || len(sweeper.sweeps) != 0 {
+		t.Fatalf("swept output still pending")
+	}
+}
+
+// TestSweeperRemoteSpend tests that if an output is spent by a transaction the
+// sweeper didn't create, then the caller is notified, and the remaining
+// outputs of the sweep transaction are swept once again.
+func TestSweeperRemoteSpend(t *testing.T) {
+	t.Parallel()
+
+	const height = 100
+	sweeper, published := newTestSweeper(height, nil)
+
+	input1 := addTestInput(sweeper, 0, 100000, height+6, 0)
+	input2 := addTestInput(sweeper, 1, 100000, height+6, 0)
+	sweeper.sweepPendingInputs()
+
+	remoteTx := wire.NewMsgTx(2)
+	remoteTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *input1.req.Output.OutPoint(),
+	})
+	remoteTxid := remoteTx.TxHash()
+
+	resweep := sweeper.handleSpend(&chainntnfs.SpendDetail{
+		SpentOutPoint: input1.req.Output.OutPoint(),
+		SpenderTxHash: &remoteTxid,
+		SpendingTx:    remoteTx,
+	})
+	if !resweep {
+		t.Fatalf("resweep not requested after remote spend")
+	}
+
+	result := <-input1.resultChans[0]
+	if result.Err != ErrSweepInputSpentRemotely {
+		t.Fatalf("expected ErrSweepInputSpentRemotely, instead "+
+			"got: %v", result.Err)
+	}
+	if input2.sweep != nil {
+		t.Fatalf("remaining output wasn't released")
+	}
+
+	// The remaining output should now be swept on its own.
+	sweeper.sweepPendingInputs()
+	if len(*published) != 2 {
+		t.Fatalf("expected 2 sweep txns, instead got %v",
+			len(*published))
+	}
+	if len(input2.sweep.tx.TxIn) != 1 {
+		t.Fatalf("expected 1 input, instead got %v",
+			len(input2.sweep.tx.TxIn))
+	}
+}
+
+// TestSweeperBumpFee tests that a manual fee bump replaces the sweep
+// transaction of an output only if the new fee rate is higher, and sweeps an
+// output that hasn't yet been swept immediately.
+func TestSweeperBumpFee(t *testing.T) {
+	t.Parallel()
+
+	const height = 100
+	sweeper, published := newTestSweeper(height, map[uint32]uint64{
+		6: 10,
+	})
+
+	input1 := addTestInput(sweeper, 0, 100000, height+6, 0)
+	sweeper.sweepPendingInputs()
+
+	// A fee rate that doesn't exceed the current rate of the sweep
+	// transaction should be rejected.
+	op := *input1.req.Output.OutPoint()
+	if _, err := sweeper.BumpFee(op, 10); err == nil {
+		t.Fatalf("fee bump with lower fee rate accepted")
+	}
+
+	origTxid := input1.sweep.tx.TxHash()
+	txid, err := sweeper.BumpFee(op, 20)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if len(*published) != 2 || input1.sweep.feeRate != 20 {
+		t.Fatalf("sweep tx wasn't replaced")
+	}
+	if *txid != input1.sweep.tx.TxHash() {
+		t.Fatalf("expected txid %v, instead got %v",
+			input1.sweep.tx.TxHash(), txid)
+	}
+
+	// Both versions of the sweep transaction should be recognized.
+	for _, sweepTxid := range []chainhash.Hash{origTxid, *txid} {
+		sweepInput, ok := sweeper.FindSweepInput(sweepTxid)
+		if !ok || sweepInput != op {
+			t.Fatalf("sweep tx %v not found", sweepTxid)
+		}
+	}
+
+	// An output that hasn't yet been swept should be swept immediately.
+	input2 := addTestInput(sweeper, 1, 100000, height+6, 0)
+	if _, err := sweeper.BumpFee(*input2.req.Output.OutPoint(), 30); err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if input2.sweep == nil || input2.sweep.feeRate != 30 {
+		t.Fatalf("output wasn't swept at requested fee rate")
+	}
+
+	// Finally, an output unknown to the sweeper should be rejected.
+	_, err = sweeper.BumpFee(wire.OutPoint{Index: 5}, 30)
+	if err != ErrSweepNotPending {
+		t.Fatalf("expected ErrSweepNotPending, instead got: %v", err)
+	}
+}
+
+// TestCreateCpfpTx tests that the child transaction pays enough fee for the
+// parent and child together to pay the requested fee rate.
+func TestCreateCpfpTx(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(privKey.PubKey().SerializeCompressed())).
+		Script()
+	if err != nil {
+		t.Fatalf("unable to generate script: %v", err)
+	}
+
+	sweeper, _ := newTestSweeper(100, nil)
+	sweeper.cfg.Signer = &mockSigner{key: privKey}
+
+	parent := wire.NewMsgTx(2)
+	parent.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+	})
+	parent.AddTxOut(&wire.TxOut{PkScript: pkScript, Value: 50000})
+	parent.AddTxOut(&wire.TxOut{PkScript: pkScript, Value: 100000})
+
+	const (
+		feeRate   = 10
+		parentFee = btcutil.Amount(500)
+	)
+	childTx, err := sweeper.CreateCpfpTx(parent, 1, parentFee, feeRate)
+	if err != nil {
+		t.Fatalf("unable to create child tx: %v", err)
+	}
+
+	if childTx.TxIn[0].PreviousOutPoint.Hash != parent.TxHash() ||
+		childTx.TxIn[0].PreviousOutPoint.Index != 1 {
+		t.Fatalf("child tx doesn't spend parent output")
+	}
+	if len(childTx.TxIn[0].Witness) == 0 {
+		t.Fatalf("child tx wasn't signed")
+	}
+
+	parentWeight := blockchain.GetTransactionWeight(btcutil.NewTx(parent))
+	childWeight := int64(4*lnwallet.BaseSweepTxSize +
+		lnwallet.WitnessHeaderSize + 4*lnwallet.InputSize +
+		lnwallet.P2WKHWitnessSize)
+	childFee := 100000 - childTx.TxOut[0].Value
+	packageFee := int64(parentFee) + childFee
+	if packageFee != feeRate*(parentWeight+childWeight) {
+		t.Fatalf("expected package fee of %v, instead got %v",
+			feeRate*(parentWeight+childWeight), packageFee)
+	}
+
+	// An output that's unable to pay for the package should be rejected.
+	_, err = sweeper.CreateCpfpTx(parent, 0, parentFee, 1000)
+	if err == nil {
+		t.Fatalf("child tx created with insufficient output value")
+	}
+}
@@ -117,7 +117,7 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 	aliceCommitTx, bobCommitTx, err := lnwallet.CreateCommitmentTxns(channelBal,
 		channelBal, &aliceCfg, &bobCfg, aliceCommitPoint, bobCommitPoint,
-		fundingTxIn)
+		fundingTxIn, channeldb.SingleFunder)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -203,13 +203,13 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 	aliceSigner := &mockSigner{aliceKeyPriv}
 	bobSigner := &mockSigner{bobKeyPriv}
 
-	channelAlice, err := lnwallet.NewLightningChannel(aliceSigner, notifier,
-		estimator, aliceChannelState)
+	channelAlice, err := lnwallet.NewLightningChannel(aliceSigner, nil,
+		notifier, estimator, aliceChannelState)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
-	channelBob, err := lnwallet.NewLightningChannel(bobSigner, notifier,
-		estimator, bobChannelState)
+	channelBob, err := lnwallet.NewLightningChannel(bobSigner, nil,
+		notifier, estimator, bobChannelState)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -233,11 +233,17 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 	}
 
 	s := &server{
-		chanDB:        dbAlice,
-		cc:            cc,
-		breachArbiter: breachArbiter,
+		chanDB:          dbAlice,
+		cc:              cc,
+		breachArbiter:   breachArbiter,
+		channelNotifier: newChannelNotifier(dbAlice),
+		peerNotifier:    newPeerNotifier(dbAlice),
 	}
-	s.htlcSwitch = htlcswitch.New(htlcswitch.Config{})
+	s.htlcSwitch = htlcswitch.New(htlcswitch.Config{
+		NotifyActiveChannel:   func(lnwire.ChannelID) {},
+		NotifyInactiveChannel: func(lnwire.ChannelID) {},
+		NotifyHtlcEvent:       func(*htlcswitch.HtlcEvent) {},
+	})
 	s.htlcSwitch.Start()
 
 	alicePeer := &peer{
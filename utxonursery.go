@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -14,12 +15,24 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
 
 var (
+	// cribBucket stores the HTLC outputs of commitment transactions that
+	// have been broadcast, but which can't yet be acted upon. Outgoing
+	// HTLC's remain in the crib until their absolute timeout has passed,
+	// while incoming HTLC's that we know the preimage to are hatched at
+	// the next block. Once hatched, an output either moves into the
+	// preschool bucket if it requires a second-level transaction, or is
+	// swept directly into the wallet.
+	//
+	// mapping: htlcOutpoint -> babyOutput
+	cribBucket = []byte("crib")
+
 	// preschoolBucket stores outputs from commitment transactions that
 	// have been broadcast, but not yet confirmed. This set of outputs is
 	// persisted in case the system is shut down between the time when the
@@ -60,6 +73,15 @@ var (
 	// mapping: chanPoint -> graduationHeight || byte-offset-in-kindergartenBucket
 	contractIndex = []byte("contract-index")
 
+	// channelIndex is an index that maps a contract's channel point to
+	// the set of all outputs of that contract currently held by the
+	// nursery, regardless of their stage. An output is removed from the
+	// index once it has been resolved, and the channel is only marked as
+	// fully closed once the index for the channel is empty.
+	//
+	// mapping: chanPoint -> outpoint -> nil
+	channelIndex = []byte("channel-index")
+
 	// lastGraduatedHeightKey is used to persist the last block height that
 	// has been checked for graduating outputs. When the nursery is
 	// restarted, lastGraduatedHeightKey is used to determine the point
@@ -91,6 +113,28 @@ type utxoNursery struct {
 
 	db *channeldb.DB
 
+	// pCache is the global preimage cache of the daemon. Any preimages we
+	// learn of from the remote party sweeping an outgoing HTLC on-chain
+	// are added to the cache, and it's consulted when claiming incoming
+	// HTLC's directly from the remote party's commitment transaction.
+	pCache lnwallet.PreimageCache
+
+	// settleHTLC is used to settle an HTLC backwards once we learn of its
+	// preimage from an on-chain spend by the remote party.
+	settleHTLC func(preimage [32]byte, amt lnwire.MilliSatoshi) error
+
+	// sweepOutput hands off a mature output to the sweeper, which will
+	// sweep it into the wallet.
+	sweepOutput func(*SweepRequest) (chan *SweepResult, error)
+
+	// chanClosed is called each time the nursery marks a channel as fully
+	// closed within the database.
+	chanClosed func(wire.OutPoint)
+
+	// hatching is the set of HTLC outpoints of babyOutputs which have been
+	// handed to the sweeper, but not yet swept.
+	hatching map[wire.OutPoint]struct{}
+
 	requests chan *incubationRequest
 
 	started uint32
@@ -100,16 +144,27 @@ type utxoNursery struct {
 }
 
 // newUtxoNursery creates a new instance of the utxoNursery from a
-// ChainNotifier and LightningWallet instance.
+// ChainNotifier and LightningWallet instance. The passed preimage cache and
+// settle function are used to resolve HTLC's on-chain, and mature outputs are
+// handed off to the sweeper using sweepOutput. chanClosed is called once a
+// channel has been marked as fully closed.
 func newUtxoNursery(db *channeldb.DB, notifier chainntnfs.ChainNotifier,
-	wallet *lnwallet.LightningWallet) *utxoNursery {
+	wallet *lnwallet.LightningWallet, pCache lnwallet.PreimageCache,
+	settleHTLC func([32]byte, lnwire.MilliSatoshi) error,
+	sweepOutput func(*SweepRequest) (chan *SweepResult, error),
+	chanClosed func(wire.OutPoint)) *utxoNursery {
 
 	return &utxoNursery{
-		notifier: notifier,
-		wallet:   wallet,
-		requests: make(chan *incubationRequest),
-		db:       db,
-		quit:     make(chan struct{}),
+		notifier:    notifier,
+		wallet:      wallet,
+		pCache:      pCache,
+		settleHTLC:  settleHTLC,
+		sweepOutput: sweepOutput,
+		chanClosed:  chanClosed,
+		hatching:    make(map[wire.OutPoint]struct{}),
+		requests:    make(chan *incubationRequest),
+		db:          db,
+		quit:        make(chan struct{}),
 	}
 }
 
@@ -147,6 +202,9 @@ func (u *utxoNursery) Start() error {
 	if err := u.reloadPreschool(lastGraduatedHeight); err != nil {
 		return err
 	}
+	if err := u.reloadCrib(lastGraduatedHeight); err != nil {
+		return err
+	}
 
 	// Register with the notifier to receive notifications for each newly
 	// connected block. We register during startup to ensure that no blocks
@@ -156,6 +214,9 @@ func (u *utxoNursery) Start() error {
 	if err != nil {
 		return err
 	}
+	if err := u.resweepKindergarten(lastGraduatedHeight); err != nil {
+		return err
+	}
 	if err := u.catchUpKindergarten(lastGraduatedHeight); err != nil {
 		return err
 	}
@@ -199,6 +260,43 @@ func (u *utxoNursery) reloadPreschool(heightHint uint32) error {
 	})
 }
 
+// resweepKindergarten hands off the outputs which graduated within the last
+// six blocks prior to shutdown to the sweeper once again. As the sweeper
+// doesn't persist its state, the sweep of these outputs may not have
+// confirmed before shutdown. Any outputs which have already been swept are
+// detected by the sweeper once it registers for their spend.
+func (u *utxoNursery) resweepKindergarten(lastGraduatedHeight uint32) error {
+	if lastGraduatedHeight == 0 {
+		return nil
+	}
+
+	_, bestHeight, err := u.wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	startHeight := uint32(1)
+	if lastGraduatedHeight > 6 {
+		startHeight = lastGraduatedHeight - 5
+	}
+
+	for height := startHeight; height <= lastGraduatedHeight; height++ {
+		kgtnOutputs, err := fetchGraduatingOutputs(u.db, height)
+		if err != nil {
+			return err
+		}
+
+		err = u.sweepGraduatingOutputs(
+			kgtnOutputs, height, uint32(bestHeight),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // catchUpKindergarten handles the graduation of kindergarten outputs from
 // blocks that were missed while the UTXO Nursery was down or offline.
 // graduateMissedBlocks is called during the startup of the UTXO Nursery.
@@ -272,11 +370,57 @@ type kidOutput struct {
 	witnessFunc    lnwallet.WitnessGenerator
 }
 
+// Amount returns the number of satoshis contained within the output.
+//
+// NOTE: Part of the SpendableOutput interface.
+func (k *kidOutput) Amount() btcutil.Amount {
+	return k.amt
+}
+
+// OutPoint returns the outpoint of the output, used to construct the input of
+// the transaction sweeping it.
+//
+// NOTE: Part of the SpendableOutput interface.
+func (k *kidOutput) OutPoint() *wire.OutPoint {
+	return &k.outPoint
+}
+
+// WitnessType returns the type of witness that must be generated to spend the
+// output.
+//
+// NOTE: Part of the SpendableOutput interface.
+func (k *kidOutput) WitnessType() lnwallet.WitnessType {
+	return k.witnessType
+}
+
+// BuildWitness computes a valid witness that allows us to spend the output.
+// If the witness generation function of the output hasn't yet been set, then
+// it's derived from the output's witness type and sign descriptor.
+//
+// NOTE: Part of the SpendableOutput interface.
+func (k *kidOutput) BuildWitness(signer lnwallet.Signer, txn *wire.MsgTx,
+	hashCache *txscript.TxSigHashes, txinIdx int) ([][]byte, error) {
+
+	if k.witnessFunc == nil {
+		k.witnessFunc = k.witnessType.GenWitnessFunc(
+			signer, k.signDescriptor,
+		)
+	}
+
+	return k.witnessFunc(txn, hashCache, txinIdx)
+}
+
+// A compile time check to ensure kidOutput meets the SpendableOutput
+// interface.
+var _ SpendableOutput = (*kidOutput)(nil)
+
 // incubationRequest is a request to the utxoNursery to incubate a set of
 // outputs until their mature, finally sweeping them into the wallet once
 // available.
 type incubationRequest struct {
 	outputs []*kidOutput
+
+	babyOutputs []*babyOutput
 }
 
 // incubateOutputs sends a request to utxoNursery to incubate the outputs
@@ -302,9 +446,28 @@ func (u *utxoNursery) IncubateOutputs(closeSummary *lnwallet.ForceCloseSummary)
 		incReq.outputs = append(incReq.outputs, selfOutput)
 	}
 
+	// Additionally, each of the HTLC's we're able to resolve on-chain
+	// will be placed in the crib. Outgoing HTLC's can only be acted upon
+	// once their absolute timeout has passed, while incoming HTLC's can be
+	// claimed immediately.
+	if closeSummary.HtlcResolutions != nil {
+		chanPoint := closeSummary.ChanPoint
+		htlcs := closeSummary.HtlcResolutions
+		for i := range htlcs.OutgoingHTLCs {
+			baby := newOutgoingBabyOutput(chanPoint,
+				&htlcs.OutgoingHTLCs[i])
+			incReq.babyOutputs = append(incReq.babyOutputs, baby)
+		}
+		for i := range htlcs.IncomingHTLCs {
+			baby := newIncomingBabyOutput(chanPoint,
+				&htlcs.IncomingHTLCs[i])
+			incReq.babyOutputs = append(incReq.babyOutputs, baby)
+		}
+	}
+
 	// If there are no outputs to incubate, there is nothing to send to the
 	// request channel.
-	if len(incReq.outputs) != 0 {
+	if len(incReq.outputs) != 0 || len(incReq.babyOutputs) != 0 {
 		u.requests <- &incReq
 	}
 }
@@ -368,6 +531,38 @@ out:
 				go output.waitForPromotion(u.db, confChan)
 			}
 
+			for _, baby := range preschoolRequest.babyOutputs {
+				if err := baby.enterCrib(u.db); err != nil {
+					utxnLog.Errorf("unable to add babyOutput "+
+						"to crib: %v, %v", baby, err)
+					continue
+				}
+
+				// If this is an outgoing HTLC, then the
+				// remote party may sweep it using the preimage
+				// before it times out. We'll watch the HTLC
+				// output so we can settle the HTLC backwards
+				// if that happens.
+				if !baby.isOutgoing() {
+					continue
+				}
+				err := u.watchForPreimage(baby, currentHeight)
+				if err != nil {
+					utxnLog.Errorf("unable to watch HTLC "+
+						"output %v for spend: %v",
+						baby.htlcOutpoint, err)
+				}
+			}
+
+			// Incoming HTLC's don't have a timeout we need to
+			// wait for, so we'll attempt to hatch them right away.
+			if len(preschoolRequest.babyOutputs) != 0 {
+				if err := u.hatchCrib(currentHeight); err != nil {
+					utxnLog.Errorf("error while hatching "+
+						"crib outputs: %v", err)
+				}
+			}
+
 		case epoch, ok := <-newBlockChan.Epochs:
 			// If the epoch channel has been closed, then the
 			// ChainNotifier is exiting which means the daemon is
@@ -387,6 +582,15 @@ out:
 			// entails successfully sweeping a time-locked output.
 			height := uint32(epoch.Height)
 			currentHeight = height
+
+			// Before graduating any outputs, we'll hatch any
+			// HTLC outputs within the crib which can now be acted
+			// upon.
+			if err := u.hatchCrib(height); err != nil {
+				utxnLog.Errorf("error while hatching crib "+
+					"outputs: %v", err)
+			}
+
 			if err := u.graduateKindergarten(height); err != nil {
 				utxnLog.Errorf("error while graduating "+
 					"kindergarten outputs: %v", err)
@@ -398,6 +602,625 @@ out:
 	}
 }
 
+// babyOutput is an HTLC output on a commitment transaction that has been
+// broadcast, which can't yet be acted upon. Once the absolute timeout of the
+// HTLC has passed (or immediately for incoming HTLC's that we know the
+// preimage to), the output "hatches": either the signed second-level
+// transaction is broadcast and the output of that transaction is handed off
+// to the preschool, or the HTLC is swept directly into the wallet.
+type babyOutput struct {
+	// expiry is the absolute block height after which the output can be
+	// acted upon. For incoming HTLC's this is zero.
+	expiry uint32
+
+	// htlcOutpoint is the outpoint of the HTLC output on the commitment
+	// transaction.
+	htlcOutpoint wire.OutPoint
+
+	// paymentHash is the payment hash of the HTLC. For outgoing HTLC's
+	// it's used to recognize the preimage if the remote party sweeps the
+	// HTLC on-chain, while for incoming HTLC's it's used to fetch the
+	// preimage from the preimage cache.
+	paymentHash [32]byte
+
+	// htlcAmt is the full value of the HTLC. This is used to settle an
+	// outgoing HTLC backwards within the switch.
+	htlcAmt lnwire.MilliSatoshi
+
+	// secondLevelTx is the fully signed second-level transaction which
+	// spends the HTLC output. If this is nil, then the HTLC is on the
+	// remote party's commitment transaction, and the kidOutput spends the
+	// HTLC output directly.
+	secondLevelTx *wire.MsgTx
+
+	// kidOutput is the output that will ultimately be swept into the
+	// wallet. If the secondLevelTx is non-nil, then this is the sole output
+	// of that transaction, and blocksToMaturity is the relative delay of
+	// the output.
+	kidOutput
+}
+
+// newOutgoingBabyOutput creates a new babyOutput from the resolution of an
+// outgoing HTLC on a channel that has been closed on-chain.
+func newOutgoingBabyOutput(chanPoint wire.OutPoint,
+	htlcRes *lnwallet.OutgoingHtlcResolution) *babyOutput {
+
+	witnessType := lnwallet.HtlcOfferedRemoteTimeout
+	if htlcRes.SignedTimeoutTx != nil {
+		witnessType = lnwallet.HtlcOfferedTimeoutSecondLevel
+	}
+
+	signDesc := htlcRes.SweepSignDesc
+	return &babyOutput{
+		expiry:        htlcRes.Expiry,
+		htlcOutpoint:  htlcRes.HtlcOutpoint,
+		paymentHash:   htlcRes.PaymentHash,
+		htlcAmt:       htlcRes.Amount,
+		secondLevelTx: htlcRes.SignedTimeoutTx,
+		kidOutput: kidOutput{
+			originChanPoint:  chanPoint,
+			amt:              btcutil.Amount(signDesc.Output.Value),
+			outPoint:         htlcRes.ClaimOutpoint,
+			blocksToMaturity: htlcRes.CsvDelay,
+			signDescriptor:   &signDesc,
+			witnessType:      witnessType,
+		},
+	}
+}
+
+// newIncomingBabyOutput creates a new babyOutput from the resolution of an
+// incoming HTLC that we know the preimage to on a channel that has been closed
+// on-chain.
+func newIncomingBabyOutput(chanPoint wire.OutPoint,
+	htlcRes *lnwallet.IncomingHtlcResolution) *babyOutput {
+
+	witnessType := lnwallet.HtlcAcceptedRemoteSuccess
+	htlcOutpoint := htlcRes.ClaimOutpoint
+	if htlcRes.SignedSuccessTx != nil {
+		witnessType = lnwallet.HtlcAcceptedSuccessSecondLevel
+		htlcOutpoint = htlcRes.SignedSuccessTx.TxIn[0].PreviousOutPoint
+	}
+
+	signDesc := htlcRes.SweepSignDesc
+	return &babyOutput{
+		htlcOutpoint:  htlcOutpoint,
+		paymentHash:   sha256.Sum256(htlcRes.Preimage[:]),
+		secondLevelTx: htlcRes.SignedSuccessTx,
+		kidOutput: kidOutput{
+			originChanPoint:  chanPoint,
+			amt:              btcutil.Amount(signDesc.Output.Value),
+			outPoint:         htlcRes.ClaimOutpoint,
+			blocksToMaturity: htlcRes.CsvDelay,
+			signDescriptor:   &signDesc,
+			witnessType:      witnessType,
+		},
+	}
+}
+
+// isOutgoing returns true if the babyOutput is an HTLC that we offered to the
+// remote party.
+func (b *babyOutput) isOutgoing() bool {
+	return b.witnessType == lnwallet.HtlcOfferedRemoteTimeout ||
+		b.witnessType == lnwallet.HtlcOfferedTimeoutSecondLevel
+}
+
+// enterCrib adds the babyOutput to the crib bucket, where it'll remain until
+// it's ready to be hatched.
+func (b *babyOutput) enterCrib(db *channeldb.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		cribIndex, err := tx.CreateBucketIfNotExists(cribBucket)
+		if err != nil {
+			return err
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, &b.htlcOutpoint); err != nil {
+			return err
+		}
+		var babyBytes bytes.Buffer
+		if err := serializeBabyOutput(&babyBytes, b); err != nil {
+			return err
+		}
+		err = cribIndex.Put(outpointBytes.Bytes(), babyBytes.Bytes())
+		if err != nil {
+			return err
+		}
+
+		err = addToChannelIndex(tx, &b.originChanPoint, &b.htlcOutpoint)
+		if err != nil {
+			return err
+		}
+
+		utxnLog.Infof("HTLC outpoint %v now in crib, waiting for "+
+			"height %v", b.htlcOutpoint, b.expiry)
+
+		return nil
+	})
+}
+
+// leaveCrib removes the babyOutput from the crib bucket, along with the HTLC
+// output from the channel index.
+func (b *babyOutput) leaveCrib(db *channeldb.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		cribIndex := tx.Bucket(cribBucket)
+		if cribIndex == nil {
+			return nil
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, &b.htlcOutpoint); err != nil {
+			return err
+		}
+		if err := cribIndex.Delete(outpointBytes.Bytes()); err != nil {
+			return err
+		}
+
+		return removeFromChannelIndex(
+			tx, &b.originChanPoint, &b.htlcOutpoint,
+		)
+	})
+}
+
+// abandonPreschool removes the second-level output of the babyOutput from the
+// preschool bucket. This is used if the remote party sweeps an outgoing HTLC
+// after we've broadcast our timeout transaction, as the output of that
+// transaction will never confirm.
+func (b *babyOutput) abandonPreschool(db *channeldb.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		psclBucket := tx.Bucket(preschoolBucket)
+		if psclBucket == nil {
+			return nil
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, &b.outPoint); err != nil {
+			return err
+		}
+		if err := psclBucket.Delete(outpointBytes.Bytes()); err != nil {
+			return err
+		}
+
+		return removeFromChannelIndex(tx, &b.originChanPoint, &b.outPoint)
+	})
+}
+
+// addToChannelIndex records the passed outpoint as one of the outputs of the
+// target channel held by the nursery.
+func addToChannelIndex(tx *bolt.Tx, chanPoint, outPoint *wire.OutPoint) error {
+	chanIndex, err := tx.CreateBucketIfNotExists(channelIndex)
+	if err != nil {
+		return err
+	}
+
+	var chanPointBytes bytes.Buffer
+	if err := writeOutpoint(&chanPointBytes, chanPoint); err != nil {
+		return err
+	}
+	chanBucket, err := chanIndex.CreateBucketIfNotExists(
+		chanPointBytes.Bytes(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var outpointBytes bytes.Buffer
+	if err := writeOutpoint(&outpointBytes, outPoint); err != nil {
+		return err
+	}
+	return chanBucket.Put(outpointBytes.Bytes(), nil)
+}
+
+// removeFromChannelIndex removes the passed outpoint from the set of outputs
+// of the target channel held by the nursery, as it has been resolved.
+func removeFromChannelIndex(tx *bolt.Tx, chanPoint,
+	outPoint *wire.OutPoint) error {
+
+	chanIndex := tx.Bucket(channelIndex)
+	if chanIndex == nil {
+		return nil
+	}
+
+	var chanPointBytes bytes.Buffer
+	if err := writeOutpoint(&chanPointBytes, chanPoint); err != nil {
+		return err
+	}
+	chanBucket := chanIndex.Bucket(chanPointBytes.Bytes())
+	if chanBucket == nil {
+		return nil
+	}
+
+	var outpointBytes bytes.Buffer
+	if err := writeOutpoint(&outpointBytes, outPoint); err != nil {
+		return err
+	}
+	return chanBucket.Delete(outpointBytes.Bytes())
+}
+
+// closeChanIfResolved marks the target channel as fully closed once all of
+// its outputs held by the nursery have been resolved, and the channel index
+// for the channel is empty. If any outputs of the channel remain, then this
+// is a no-op.
+func (u *utxoNursery) closeChanIfResolved(chanPoint wire.OutPoint) error {
+	// The lock ensures that a channel whose final outputs are resolved
+	// concurrently is only marked as fully closed once.
+	u.Lock()
+	defer u.Unlock()
+
+	var chanPointBytes bytes.Buffer
+	if err := writeOutpoint(&chanPointBytes, &chanPoint); err != nil {
+		return err
+	}
+
+	var resolved bool
+	err := u.db.View(func(tx *bolt.Tx) error {
+		chanIndex := tx.Bucket(channelIndex)
+		if chanIndex == nil {
+			return nil
+		}
+		chanBucket := chanIndex.Bucket(chanPointBytes.Bytes())
+		if chanBucket == nil {
+			return nil
+		}
+
+		key, _ := chanBucket.Cursor().First()
+		resolved = key == nil
+		return nil
+	})
+	if err != nil || !resolved {
+		return err
+	}
+
+	utxnLog.Infof("All outputs of ChannelPoint(%v) have been resolved, "+
+		"marking channel as fully closed", chanPoint)
+
+	if err := u.db.MarkChanFullyClosed(&chanPoint); err != nil {
+		return err
+	}
+
+	// With the channel marked as fully closed, we'll remove its now
+	// empty entry from the channel index.
+	err = u.db.Update(func(tx *bolt.Tx) error {
+		chanIndex := tx.Bucket(channelIndex)
+		if chanIndex == nil {
+			return nil
+		}
+		return chanIndex.DeleteBucket(chanPointBytes.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	u.chanClosed(chanPoint)
+
+	return nil
+}
+
+// fetchCribOutputs returns all the babyOutputs currently within the crib.
+func fetchCribOutputs(db *channeldb.DB) ([]*babyOutput, error) {
+	var babies []*babyOutput
+	err := db.View(func(tx *bolt.Tx) error {
+		cribIndex := tx.Bucket(cribBucket)
+		if cribIndex == nil {
+			return nil
+		}
+
+		return cribIndex.ForEach(func(k, v []byte) error {
+			baby, err := deserializeBabyOutput(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			babies = append(babies, baby)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return babies, nil
+}
+
+// reloadCrib re-registers for spend notifications for all the outgoing HTLC's
+// within the crib, so we're able to learn of any preimages revealed on-chain
+// by the remote party.
+func (u *utxoNursery) reloadCrib(heightHint uint32) error {
+	babies, err := fetchCribOutputs(u.db)
+	if err != nil {
+		return err
+	}
+
+	for _, baby := range babies {
+		if !baby.isOutgoing() {
+			continue
+		}
+
+		if err := u.watchForPreimage(baby, heightHint); err != nil {
+			return err
+		}
+
+		utxnLog.Infof("Crib outpoint %v re-registered for spend "+
+			"notification.", baby.htlcOutpoint)
+	}
+
+	return nil
+}
+
+// hatchCrib is called at each new block height in order to act upon any HTLC
+// outputs within the crib which are now spendable. A failure to hatch any
+// single output is logged, and the output is re-tried at the next block.
+func (u *utxoNursery) hatchCrib(blockHeight uint32) error {
+	babies, err := fetchCribOutputs(u.db)
+	if err != nil {
+		return err
+	}
+
+	for _, baby := range babies {
+		if baby.expiry > blockHeight {
+			continue
+		}
+
+		// If the output has already been handed to the sweeper, then
+		// there's nothing left to do until it has been swept.
+		u.RLock()
+		_, ok := u.hatching[baby.htlcOutpoint]
+		u.RUnlock()
+		if ok {
+			continue
+		}
+
+		if err := u.hatchBabyOutput(baby, blockHeight); err != nil {
+			utxnLog.Errorf("unable to hatch HTLC output %v: %v",
+				baby.htlcOutpoint, err)
+		}
+	}
+
+	return nil
+}
+
+// hatchBabyOutput acts upon a single babyOutput whose timeout has passed. If
+// the HTLC requires a second-level transaction, then it's broadcast and the
+// output of that transaction enters the preschool. Otherwise, the HTLC is
+// swept directly into the wallet.
+func (u *utxoNursery) hatchBabyOutput(baby *babyOutput,
+	blockHeight uint32) error {
+
+	if baby.secondLevelTx != nil {
+		utxnLog.Infof("Broadcasting second-level HTLC transaction "+
+			"(txid=%v) for HTLC %v: %v", baby.secondLevelTx.TxHash(),
+			baby.htlcOutpoint, newLogClosure(func() string {
+				return spew.Sdump(baby.secondLevelTx)
+			}))
+
+		err := u.wallet.PublishTransaction(baby.secondLevelTx)
+		if err != nil {
+			return err
+		}
+
+		// With the second-level transaction broadcast, the output of
+		// the transaction will now follow the regular incubation
+		// process of a time-locked output.
+		kid := baby.kidOutput
+		if err := kid.enterPreschool(u.db); err != nil {
+			return err
+		}
+		if err := baby.leaveCrib(u.db); err != nil {
+			return err
+		}
+
+		sourceTxid := kid.outPoint.Hash
+		confChan, err := u.notifier.RegisterConfirmationsNtfn(
+			&sourceTxid, 1, blockHeight,
+		)
+		if err != nil {
+			return err
+		}
+		go kid.waitForPromotion(u.db, confChan)
+
+		return nil
+	}
+
+	// Otherwise, the HTLC resides on the remote party's commitment
+	// transaction, and can be swept directly into the wallet. If this is
+	// an incoming HTLC, then the witness requires the preimage, so we'll
+	// set the witness function of the output ourselves.
+	kid := baby.kidOutput
+	if kid.witnessType == lnwallet.HtlcAcceptedRemoteSuccess {
+		preimage, ok := u.pCache.LookupPreimage(baby.paymentHash[:])
+		if !ok {
+			return fmt.Errorf("unable to find preimage for "+
+				"payment hash %x", baby.paymentHash[:])
+		}
+
+		signer := u.wallet.Cfg.Signer
+		kid.witnessFunc = func(tx *wire.MsgTx, hc *txscript.TxSigHashes,
+			inputIndex int) ([][]byte, error) {
+
+			desc := kid.signDescriptor
+			desc.SigHashes = hc
+			desc.InputIndex = inputIndex
+
+			return lnwallet.SenderHtlcSpendRedeem(signer, desc, tx,
+				preimage)
+		}
+	}
+
+	// Outgoing HTLC's can only be swept once the absolute timeout of the
+	// HTLC has passed, so we'll set the lock time of the sweep transaction
+	// accordingly. As the remote party is also able to claim the HTLC, we
+	// ask the sweeper to sweep it as soon as possible.
+	resultChan, err := u.sweepOutput(&SweepRequest{
+		Output:     &kid,
+		LockTime:   baby.expiry,
+		Deadline:   blockHeight + urgentSweepConfTarget,
+		HeightHint: blockHeight,
+		ChanPoint:  &kid.originChanPoint,
+	})
+	if err != nil {
+		return err
+	}
+
+	u.Lock()
+	u.hatching[baby.htlcOutpoint] = struct{}{}
+	u.Unlock()
+
+	u.wg.Add(1)
+	go u.waitForSweep(baby, resultChan)
+
+	return nil
+}
+
+// waitForSweep waits until the HTLC output of a babyOutput handed to the
+// sweeper has been spent, at which point the output leaves the crib. If the
+// sweep failed, then the output will be handed to the sweeper again at the
+// next block.
+//
+// NOTE: This MUST be run as a goroutine.
+func (u *utxoNursery) waitForSweep(baby *babyOutput,
+	resultChan chan *SweepResult) {
+
+	defer u.wg.Done()
+
+	var result *SweepResult
+	select {
+	case result = <-resultChan:
+	case <-u.quit:
+		return
+	}
+
+	u.Lock()
+	delete(u.hatching, baby.htlcOutpoint)
+	u.Unlock()
+
+	switch {
+	// If the remote party spent the output, then they either timed out
+	// an incoming HTLC, or claimed an outgoing HTLC with the preimage,
+	// which is handled by waitForPreimage. Either way, the HTLC has been
+	// resolved.
+	case result.Err == ErrSweepInputSpentRemotely:
+		utxnLog.Infof("HTLC output %v claimed by remote party",
+			baby.htlcOutpoint)
+
+	case result.Err != nil:
+		utxnLog.Errorf("unable to sweep HTLC output %v: %v",
+			baby.htlcOutpoint, result.Err)
+		return
+
+	default:
+		utxnLog.Infof("HTLC output %v swept by txid=%v",
+			baby.htlcOutpoint, result.Tx.TxHash())
+	}
+
+	if err := baby.leaveCrib(u.db); err != nil {
+		utxnLog.Errorf("unable to remove HTLC output %v from "+
+			"crib: %v", baby.htlcOutpoint, err)
+		return
+	}
+
+	// Other outputs of the channel may still be awaiting resolution, in
+	// which case the channel will be marked as fully closed once the last
+	// of them has been resolved.
+	if err := u.closeChanIfResolved(baby.originChanPoint); err != nil {
+		utxnLog.Errorf("unable to mark chan as closed: %v", err)
+	}
+}
+
+// watchForPreimage registers for a spend notification of the HTLC output of
+// an outgoing babyOutput. If the remote party sweeps the HTLC using the
+// preimage, then we'll settle the HTLC backwards.
+func (u *utxoNursery) watchForPreimage(baby *babyOutput,
+	heightHint uint32) error {
+
+	spendNtfn, err := u.notifier.RegisterSpendNtfn(
+		&baby.htlcOutpoint, heightHint,
+	)
+	if err != nil {
+		return err
+	}
+
+	u.wg.Add(1)
+	go u.waitForPreimage(baby, spendNtfn)
+
+	return nil
+}
+
+// waitForPreimage waits for the HTLC output of the outgoing babyOutput to be
+// spent. If the spending transaction reveals the preimage of the HTLC, then
+// the preimage is added to the preimage cache, the HTLC is settled backwards
+// within the switch, and the output is removed from the crib.
+//
+// NOTE: This MUST be run as a goroutine.
+func (u *utxoNursery) waitForPreimage(baby *babyOutput,
+	spendNtfn *chainntnfs.SpendEvent) {
+
+	defer u.wg.Done()
+
+	var spendDetail *chainntnfs.SpendDetail
+	select {
+	case detail, ok := <-spendNtfn.Spend:
+		if !ok {
+			return
+		}
+		spendDetail = detail
+
+	case <-u.quit:
+		return
+	}
+
+	// If the HTLC was swept by the remote party, then the preimage will
+	// be within the witness of the spending input. Otherwise, this is our
+	// own timeout spend, so there's nothing left to do.
+	spendingTx := spendDetail.SpendingTx
+	txIn := spendingTx.TxIn[spendDetail.SpenderInputIndex]
+
+	var (
+		preimage [32]byte
+		found    bool
+	)
+	for _, witnessItem := range txIn.Witness {
+		if len(witnessItem) != len(preimage) {
+			continue
+		}
+		if sha256.Sum256(witnessItem) != baby.paymentHash {
+			continue
+		}
+
+		copy(preimage[:], witnessItem)
+		found = true
+		break
+	}
+	if !found {
+		return
+	}
+
+	utxnLog.Infof("Remote party swept HTLC %v (payment_hash=%x) "+
+		"on-chain, settling backwards", baby.htlcOutpoint,
+		baby.paymentHash[:])
+
+	if err := u.pCache.AddPreimage(preimage[:]); err != nil {
+		utxnLog.Errorf("unable to add preimage to cache: %v", err)
+	}
+
+	if err := baby.leaveCrib(u.db); err != nil {
+		utxnLog.Errorf("unable to remove HTLC %v from crib: %v",
+			baby.htlcOutpoint, err)
+	}
+	if baby.secondLevelTx != nil {
+		if err := baby.abandonPreschool(u.db); err != nil {
+			utxnLog.Errorf("unable to remove HTLC %v from "+
+				"preschool: %v", baby.htlcOutpoint, err)
+		}
+	}
+
+	if err := u.settleHTLC(preimage, baby.htlcAmt); err != nil {
+		utxnLog.Errorf("unable to settle HTLC backwards: %v", err)
+	}
+
+	if err := u.closeChanIfResolved(baby.originChanPoint); err != nil {
+		utxnLog.Errorf("unable to mark chan as closed: %v", err)
+	}
+}
+
 // contractMaturityReport is a report that details the maturity progress of a
 // particular force closed contract.
 type contractMaturityReport struct {
@@ -558,18 +1381,24 @@ func (k *kidOutput) enterPreschool(db *channeldb.DB) error {
 		if err != nil {
 			return err
 		}
-
-		// Additionally, we'll populate the preschool index so we can
-		// track all the immature outpoints for a particular channel's
-		// chanPoint.
-		var b bytes.Buffer
-		err = writeOutpoint(&b, &k.originChanPoint)
+		err = addToChannelIndex(tx, &k.originChanPoint, &k.outPoint)
 		if err != nil {
 			return err
 		}
-		err = psclIndex.Put(b.Bytes(), outpointBytes.Bytes())
-		if err != nil {
-			return err
+
+		// Additionally, if this is our commitment output, then we'll
+		// populate the preschool index so we can track the immature
+		// outpoint for a particular channel's chanPoint.
+		if k.witnessType == lnwallet.CommitmentTimeLock {
+			var b bytes.Buffer
+			err = writeOutpoint(&b, &k.originChanPoint)
+			if err != nil {
+				return err
+			}
+			err = psclIndex.Put(b.Bytes(), outpointBytes.Bytes())
+			if err != nil {
+				return err
+			}
 		}
 
 		utxnLog.Infof("Outpoint %v now in preschool, waiting for "+
@@ -629,10 +1458,15 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 				"preschool bucket: %v", k.outPoint)
 			return err
 		}
-		if err := psclIndex.Delete(originPoint.Bytes()); err != nil {
-			utxnLog.Errorf("unable to delete kindergarten output from "+
-				"preschool index: %v", k.outPoint)
-			return err
+		isCommitOutput := k.witnessType == lnwallet.CommitmentTimeLock
+		if isCommitOutput {
+			err := psclIndex.Delete(originPoint.Bytes())
+			if err != nil {
+				utxnLog.Errorf("unable to delete kindergarten "+
+					"output from preschool index: %v",
+					k.outPoint)
+				return err
+			}
 		}
 
 		// Next, fetch the kindergarten bucket. This output will remain
@@ -667,20 +1501,23 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 			return err
 		}
 
-		// Finally, we'll insert a new entry into the contract index.
-		// The entry itself consists of 4 bytes for the height, and 4
-		// bytes for the offset within the value for the height.
-		var indexEntry [4 + 4]byte
-		copy(indexEntry[:4], heightBytes)
-		byteOrder.PutUint32(indexEntry[4:], uint32(outputOffset))
+		// Finally, if this is our commitment output, we'll insert a
+		// new entry into the contract index. The entry itself consists
+		// of 4 bytes for the height, and 4 bytes for the offset within
+		// the value for the height.
+		if isCommitOutput {
+			var indexEntry [4 + 4]byte
+			copy(indexEntry[:4], heightBytes)
+			byteOrder.PutUint32(indexEntry[4:], uint32(outputOffset))
 
-		indexBucket, err := tx.CreateBucketIfNotExists(contractIndex)
-		if err != nil {
-			return err
-		}
-		err = indexBucket.Put(originPoint.Bytes(), indexEntry[:])
-		if err != nil {
-			return err
+			indexBucket, err := tx.CreateBucketIfNotExists(contractIndex)
+			if err != nil {
+				return err
+			}
+			err = indexBucket.Put(originPoint.Bytes(), indexEntry[:])
+			if err != nil {
+				return err
+			}
 		}
 
 		utxnLog.Infof("Outpoint %v now in kindergarten, will mature "+
@@ -705,25 +1542,43 @@ func (u *utxoNursery) graduateKindergarten(blockHeight uint32) error {
 	// First fetch the set of outputs that we can "graduate" at this
 	// particular block height. We can graduate an output once we've
 	// reached its height maturity.
-	kgtnOutputs, err := fetchGraduatingOutputs(u.db, u.wallet, blockHeight)
+	kgtnOutputs, err := fetchGraduatingOutputs(u.db, blockHeight)
 	if err != nil {
 		return err
 	}
 
-	// If we're able to graduate any outputs, then create a single
-	// transaction which sweeps them all into the wallet.
+	// If we're able to graduate any outputs, then hand them off to the
+	// sweeper, which will sweep them into the wallet.
 	if len(kgtnOutputs) > 0 {
-		err := sweepGraduatingOutputs(u.wallet, kgtnOutputs)
+		err := u.sweepGraduatingOutputs(
+			kgtnOutputs, blockHeight, blockHeight,
+		)
 		if err != nil {
 			return err
 		}
 
-		// Now that the sweeping transaction has been broadcast, for
-		// each of the immature outputs, we'll mark them as being fully
-		// closed within the database.
-		for _, closedChan := range kgtnOutputs {
-			err := u.db.MarkChanFullyClosed(&closedChan.originChanPoint)
-			if err != nil {
+		// Now that the outputs have been handed off to the sweeper,
+		// they've been resolved, so we'll remove them from the channel
+		// index. Any channel with no outputs left is then marked as
+		// fully closed within the database.
+		chanPoints := make(map[wire.OutPoint]struct{})
+		err = u.db.Update(func(tx *bolt.Tx) error {
+			for _, kid := range kgtnOutputs {
+				err := removeFromChannelIndex(
+					tx, &kid.originChanPoint, &kid.outPoint,
+				)
+				if err != nil {
+					return err
+				}
+				chanPoints[kid.originChanPoint] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for chanPoint := range chanPoints {
+			if err := u.closeChanIfResolved(chanPoint); err != nil {
 				return err
 			}
 		}
@@ -746,7 +1601,7 @@ func (u *utxoNursery) graduateKindergarten(blockHeight uint32) error {
 // outputs have become newly spendable. If fetchGraduatingOutputs finds outputs
 // that are ready for "graduation," it passes them on to be swept.  This is the
 // third step in the output incubation process.
-func fetchGraduatingOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
+func fetchGraduatingOutputs(db *channeldb.DB,
 	blockHeight uint32) ([]*kidOutput, error) {
 
 	var results []byte
@@ -774,106 +1629,43 @@ func fetchGraduatingOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
 	}
 
 	// Otherwise, we deserialize the list of kid outputs into their full
-	// forms.
+	// forms. The witness function of each output is generated by the
+	// sweeper based on its witness type.
 	kgtnOutputs, err := deserializeKidList(bytes.NewReader(results))
 	if err != nil {
 		utxnLog.Errorf("error while deserializing list of kidOutputs: %v", err)
 	}
 
-	// For each of the outputs, we also generate its proper witness
-	// function based on its witness type. This varies if the output is on
-	// our commitment transaction or theirs, and also if it's an HTLC
-	// output or not.
-	for _, kgtnOutput := range kgtnOutputs {
-		kgtnOutput.witnessFunc = kgtnOutput.witnessType.GenWitnessFunc(
-			wallet.Cfg.Signer, kgtnOutput.signDescriptor)
-	}
-
 	utxnLog.Infof("New block: height=%v, sweeping %v mature outputs",
 		blockHeight, len(kgtnOutputs))
 
 	return kgtnOutputs, nil
 }
 
-// sweepGraduatingOutputs generates and broadcasts the transaction that
-// transfers control of funds from a channel commitment transaction to the
-// user's wallet.
-func sweepGraduatingOutputs(wallet *lnwallet.LightningWallet, kgtnOutputs []*kidOutput) error {
-	// Create a transaction which sweeps all the newly mature outputs into
-	// a output controlled by the wallet.
-	// TODO(roasbeef): can be more intelligent about buffering outputs to
-	// be more efficient on-chain.
-	sweepTx, err := createSweepTx(wallet, kgtnOutputs)
-	if err != nil {
-		// TODO(roasbeef): retry logic?
-		utxnLog.Errorf("unable to create sweep tx: %v", err)
-		return err
-	}
-
-	utxnLog.Infof("Sweeping %v time-locked outputs "+
-		"with sweep tx (txid=%v): %v", len(kgtnOutputs),
-		sweepTx.TxHash(),
-		newLogClosure(func() string {
-			return spew.Sdump(sweepTx)
-		}))
-
-	// With the sweep transaction fully signed, broadcast the transaction
-	// to the network. Additionally, we can stop tracking these outputs as
-	// they've just been swept.
-	if err := wallet.PublishTransaction(sweepTx); err != nil {
-		utxnLog.Errorf("unable to broadcast sweep tx: %v, %v",
-			err, spew.Sdump(sweepTx))
-		return err
-	}
-
-	return nil
-}
-
-// createSweepTx creates a final sweeping transaction with all witnesses in
-// place for all inputs. The created transaction has a single output sending
-// all the funds back to the source wallet.
-func createSweepTx(wallet *lnwallet.LightningWallet,
-	matureOutputs []*kidOutput) (*wire.MsgTx, error) {
+// sweepGraduatingOutputs hands off the passed outputs, which matured at
+// graduationHeight, to the sweeper, which will batch them into a transaction
+// that transfers control of the funds to the user's wallet. As only we're
+// able to spend these outputs, they aren't swept with any urgency.
+func (u *utxoNursery) sweepGraduatingOutputs(kgtnOutputs []*kidOutput,
+	graduationHeight, currentHeight uint32) error {
 
-	pkScript, err := newSweepPkScript(wallet)
-	if err != nil {
-		return nil, err
-	}
-
-	var totalSum btcutil.Amount
-	for _, o := range matureOutputs {
-		totalSum += o.amt
-	}
-
-	sweepTx := wire.NewMsgTx(2)
-	sweepTx.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    int64(totalSum - 5000),
-	})
-	for _, utxo := range matureOutputs {
-		sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: utxo.outPoint,
+	for _, kid := range kgtnOutputs {
+		_, err := u.sweepOutput(&SweepRequest{
+			Output: kid,
 			// TODO(roasbeef): assumes pure block delays
-			Sequence: utxo.blocksToMaturity,
+			Sequence:   kid.blocksToMaturity,
+			Deadline:   currentHeight + defaultSweepConfTarget,
+			HeightHint: graduationHeight,
+			ChanPoint:  &kid.originChanPoint,
 		})
-	}
-
-	// TODO(roasbeef): insert fee calculation
-	//  * remove hardcoded fee above
-
-	// With all the inputs in place, use each output's unique witness
-	// function to generate the final witness required for spending.
-	hashCache := txscript.NewTxSigHashes(sweepTx)
-	for i, txIn := range sweepTx.TxIn {
-		witness, err := matureOutputs[i].witnessFunc(sweepTx, hashCache, i)
 		if err != nil {
-			return nil, err
+			utxnLog.Errorf("unable to sweep output %v: %v",
+				kid.outPoint, err)
+			return err
 		}
-
-		txIn.Witness = witness
 	}
 
-	return sweepTx, nil
+	return nil
 }
 
 // deleteGraduatedOutputs removes outputs from the kindergarten database bucket
@@ -914,6 +1706,10 @@ func deleteGraduatedOutputs(db *channeldb.DB, deleteHeight uint32) error {
 			return nil
 		}
 		for _, sweptOutput := range sweptOutputs {
+			if sweptOutput.witnessType != lnwallet.CommitmentTimeLock {
+				continue
+			}
+
 			var chanPoint bytes.Buffer
 			err := writeOutpoint(&chanPoint, &sweptOutput.originChanPoint)
 			if err != nil {
@@ -1061,6 +1857,92 @@ func deserializeKidOutput(r io.Reader) (*kidOutput, error) {
 	return kid, nil
 }
 
+// serializeBabyOutput converts a babyOutput struct into a form suitable for
+// on-disk database storage. The embedded kidOutput is serialized last.
+func serializeBabyOutput(w io.Writer, baby *babyOutput) error {
+	var scratch [8]byte
+	byteOrder.PutUint32(scratch[:4], baby.expiry)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	if err := writeOutpoint(w, &baby.htlcOutpoint); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(baby.paymentHash[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(baby.htlcAmt))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	// The second-level transaction is optional, so we'll prefix it with
+	// a single byte indicating its presence.
+	if baby.secondLevelTx == nil {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	} else {
+		if _, err := w.Write([]byte{1}); err != nil {
+			return err
+		}
+		if err := baby.secondLevelTx.Serialize(w); err != nil {
+			return err
+		}
+	}
+
+	return serializeKidOutput(w, &baby.kidOutput)
+}
+
+// deserializeBabyOutput takes a byte array representation of a babyOutput
+// and converts it to a struct. As with deserializeKidOutput, the witnessFunc
+// of the embedded kidOutput isn't populated.
+func deserializeBabyOutput(r io.Reader) (*babyOutput, error) {
+	scratch := make([]byte, 8)
+
+	baby := &babyOutput{}
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	baby.expiry = byteOrder.Uint32(scratch[:4])
+
+	err := readOutpoint(io.LimitReader(r, 40), &baby.htlcOutpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, baby.paymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	baby.htlcAmt = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	if scratch[0] == 1 {
+		baby.secondLevelTx = &wire.MsgTx{}
+		if err := baby.secondLevelTx.Deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+
+	kid, err := deserializeKidOutput(r)
+	if err != nil {
+		return nil, err
+	}
+	baby.kidOutput = *kid
+
+	return baby, nil
+}
+
 // TODO(bvu): copied from channeldb, remove repetition
 func writeOutpoint(w io.Writer, o *wire.OutPoint) error {
 	// TODO(roasbeef): make all scratch buffers on the stack
@@ -6,7 +6,10 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -253,3 +256,238 @@ func TestSerializeKidOutput(t *testing.T) {
 			deserializedKid)
 	}
 }
+
+func TestSerializeBabyOutput(t *testing.T) {
+	kid := kidOutputs[1]
+	descriptor := &signDescriptors[1]
+	pk, err := btcec.ParsePubKey(keys[1], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pub key: %v", keys[1])
+	}
+	descriptor.PubKey = pk
+	kid.signDescriptor = descriptor
+	kid.witnessType = lnwallet.HtlcOfferedTimeoutSecondLevel
+
+	timeoutTx := wire.NewMsgTx(2)
+	timeoutTx.LockTime = 1770001
+	timeoutTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: outPoints[2],
+		Witness:          [][]byte{{0x01}, {0x02, 0x03}},
+	})
+	timeoutTx.AddTxOut(&wire.TxOut{
+		Value:    int64(kid.amt),
+		PkScript: descriptor.Output.PkScript,
+	})
+
+	babies := []*babyOutput{
+		{
+			expiry:        1770001,
+			htlcOutpoint:  outPoints[2],
+			paymentHash:   [32]byte{0x01, 0x02, 0x03},
+			htlcAmt:       123456,
+			secondLevelTx: timeoutTx,
+			kidOutput:     kid,
+		},
+		{
+			expiry:       1770001,
+			htlcOutpoint: outPoints[0],
+			paymentHash:  [32]byte{0x04, 0x05, 0x06},
+			htlcAmt:      654321,
+			kidOutput:    kidOutputs[0],
+		},
+	}
+	babies[1].kidOutput.signDescriptor = descriptor
+	babies[1].kidOutput.witnessType = lnwallet.HtlcOfferedRemoteTimeout
+
+	for i, baby := range babies {
+		var b bytes.Buffer
+		if err := serializeBabyOutput(&b, baby); err != nil {
+			t.Fatalf("unable to serialize baby output #%v: %v", i,
+				err)
+		}
+
+		deserializedBaby, err := deserializeBabyOutput(&b)
+		if err != nil {
+			t.Fatalf("unable to deserialize baby output #%v: %v",
+				i, err)
+		}
+
+		// The second-level transaction is compared by its witness
+		// hash, as the internal representation of the scripts may
+		// differ after deserialization.
+		if baby.secondLevelTx != nil {
+			if deserializedBaby.secondLevelTx == nil {
+				t.Fatalf("second-level tx not deserialized")
+			}
+			expectedHash := baby.secondLevelTx.WitnessHash()
+			if deserializedBaby.secondLevelTx.WitnessHash() != expectedHash {
+				t.Fatalf("second-level txs don't match")
+			}
+			deserializedBaby.secondLevelTx = baby.secondLevelTx
+		}
+
+		if !reflect.DeepEqual(baby, deserializedBaby) {
+			t.Fatalf("babyOutputs don't match %+v vs %+v", baby,
+				deserializedBaby)
+		}
+	}
+}
+
+// TestNurseryHtlcOutputsResolveChannel tests that a channel with several
+// HTLC outputs in the nursery is only marked as fully closed once all of
+// them have been resolved, rather than once the first of them is swept.
+func TestNurseryHtlcOutputsResolveChannel(t *testing.T) {
+	t.Parallel()
+
+	notifier := &mockNotfier{
+		confChannel: make(chan *chainntnfs.TxConfirmation),
+	}
+	p, _, _, cleanUp, err := createTestPeer(notifier, nil)
+	if err != nil {
+		t.Fatalf("unable to create test peer: %v", err)
+	}
+	defer cleanUp()
+
+	chanDB := p.server.chanDB
+	channels, err := chanDB.FetchAllChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch channels: %v", err)
+	}
+	if len(channels) != 1 {
+		t.Fatalf("expected 1 channel, got %v", len(channels))
+	}
+	channel := channels[0]
+	chanPoint := channel.FundingOutpoint
+
+	// The channel's commitment has been confirmed, so it's now pending
+	// the resolution of its outputs.
+	closeSummary := &channeldb.ChannelCloseSummary{
+		ChanPoint: chanPoint,
+		RemotePub: channel.IdentityPub,
+		Capacity:  channel.Capacity,
+		CloseType: channeldb.ForceClose,
+		IsPending: true,
+	}
+	if err := channel.CloseChannel(closeSummary); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+
+	// Each output handed to the sweeper is given its own result channel,
+	// allowing us to control when each of them is swept.
+	sweepRequests := make(chan *SweepRequest, 2)
+	sweepResults := make(map[wire.OutPoint]chan *SweepResult)
+	sweepOutput := func(req *SweepRequest) (chan *SweepResult, error) {
+		resultChan := make(chan *SweepResult, 1)
+		sweepResults[*req.Output.OutPoint()] = resultChan
+		sweepRequests <- req
+		return resultChan, nil
+	}
+	closedChans := make(chan wire.OutPoint, 1)
+	chanClosed := func(chanPoint wire.OutPoint) {
+		closedChans <- chanPoint
+	}
+
+	nursery := newUtxoNursery(
+		chanDB, notifier, nil, nil, nil, sweepOutput, chanClosed,
+	)
+	defer func() {
+		close(nursery.quit)
+		nursery.wg.Wait()
+	}()
+
+	pk, err := btcec.ParsePubKey(keys[0], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pub key: %v", keys[0])
+	}
+	descriptor := signDescriptors[0]
+	descriptor.PubKey = pk
+
+	// We'll add two outgoing HTLC outputs of the channel to the crib,
+	// which time out at different heights.
+	babies := []*babyOutput{
+		{
+			expiry:       100,
+			htlcOutpoint: outPoints[0],
+			htlcAmt:      100000,
+		},
+		{
+			expiry:       110,
+			htlcOutpoint: outPoints[1],
+			htlcAmt:      200000,
+		},
+	}
+	for _, baby := range babies {
+		baby.kidOutput = kidOutput{
+			originChanPoint: chanPoint,
+			amt:             btcutil.Amount(baby.htlcAmt.ToSatoshis()),
+			outPoint:        baby.htlcOutpoint,
+			witnessType:     lnwallet.HtlcOfferedRemoteTimeout,
+			signDescriptor:  &descriptor,
+		}
+		if err := baby.enterCrib(chanDB); err != nil {
+			t.Fatalf("unable to add output to crib: %v", err)
+		}
+	}
+
+	// sweepOutputAt hatches the crib at the passed height, and asserts
+	// that the expected output is handed to the sweeper, after which it's
+	// swept.
+	sweepOutputAt := func(height uint32, baby *babyOutput) {
+		if err := nursery.hatchCrib(height); err != nil {
+			t.Fatalf("unable to hatch crib: %v", err)
+		}
+
+		select {
+		case req := <-sweepRequests:
+			if *req.Output.OutPoint() != baby.htlcOutpoint {
+				t.Fatalf("expected sweep of %v, got %v",
+					baby.htlcOutpoint, *req.Output.OutPoint())
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("output not handed to sweeper")
+		}
+
+		sweepResults[baby.htlcOutpoint] <- &SweepResult{
+			Tx: wire.NewMsgTx(2),
+		}
+	}
+
+	// assertPending asserts whether the channel is still pending the
+	// resolution of its outputs.
+	assertPending := func(pending bool) {
+		pendingChans, err := chanDB.FetchClosedChannels(true)
+		if err != nil {
+			t.Fatalf("unable to fetch closed channels: %v", err)
+		}
+		if (len(pendingChans) == 1) != pending {
+			t.Fatalf("expected channel pending=%v, found %v "+
+				"pending channels", pending, len(pendingChans))
+		}
+	}
+
+	// Once the first HTLC output has been swept, the channel should remain
+	// pending, as the second HTLC output is yet to be resolved.
+	sweepOutputAt(100, babies[0])
+
+	select {
+	case <-closedChans:
+		t.Fatalf("channel closed with unresolved outputs")
+	case <-time.After(time.Millisecond * 100):
+	}
+	assertPending(true)
+
+	// Once the second HTLC output has been swept, the channel should be
+	// marked as fully closed.
+	sweepOutputAt(110, babies[1])
+
+	select {
+	case closedChan := <-closedChans:
+		if closedChan != chanPoint {
+			t.Fatalf("expected %v to be closed, got %v", chanPoint,
+				closedChan)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channel not marked as fully closed")
+	}
+	assertPending(false)
+}
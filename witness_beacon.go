@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// preimageBeacon is an implementation of the lnwallet.PreimageCache interface
+// backed by the persistent witness cache within the channel database. Every
+// preimage the daemon learns of, either when settling an HTLC off-chain, or
+// by observing the remote party sweep an HTLC on-chain, is added to the
+// beacon. The beacon is consulted when claiming incoming HTLC's on-chain
+// after a channel has been force closed.
+type preimageBeacon struct {
+	wCache *channeldb.WitnessCache
+}
+
+// newPreimageBeacon creates a new preimageBeacon backed by the witness cache
+// of the passed database.
+func newPreimageBeacon(db *channeldb.DB) *preimageBeacon {
+	return &preimageBeacon{
+		wCache: db.NewWitnessCache(),
+	}
+}
+
+// LookupPreimage attempts to lookup a preimage in the global cache.  True is
+// returned for the second argument if the preimage is found.
+//
+// NOTE: Part of the lnwallet.PreimageCache interface.
+func (p *preimageBeacon) LookupPreimage(payHash []byte) ([]byte, bool) {
+	preimage, err := p.wCache.LookupWitness(
+		channeldb.Sha256HashWitness, payHash,
+	)
+	if err != nil {
+		return nil, false
+	}
+
+	return preimage, true
+}
+
+// AddPreimage adds a newly discovered preimage to the global cache.
+//
+// NOTE: Part of the lnwallet.PreimageCache interface.
+func (p *preimageBeacon) AddPreimage(pre []byte) error {
+	srvrLog.Tracef("Adding preimage=%x to witness cache", pre[:])
+
+	return p.wCache.AddWitness(channeldb.Sha256HashWitness, pre)
+}
+
+// A compile time check to ensure preimageBeacon meets the
+// lnwallet.PreimageCache interface.
+var _ lnwallet.PreimageCache = (*preimageBeacon)(nil)